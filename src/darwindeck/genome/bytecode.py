@@ -150,6 +150,7 @@ SCORING_TRIGGER_MAP = {
     ScoringTrigger.PLAY: 2,
     ScoringTrigger.HAND_END: 3,
     ScoringTrigger.SET_COMPLETE: 4,
+    ScoringTrigger.SWEEP: 5,
 }
 
 # Suit encoding (for CardCondition)
@@ -318,7 +319,7 @@ def compile_bidding_phase(phase: BiddingPhase, scoring: ContractScoring = None)
     """Compile BiddingPhase to bytecode.
 
     Format: [opcode=70] [min_bid] [max_bid] [flags] [scoring_data...]
-    flags byte: bit 0 = allow_nil
+    flags byte: bit 0 = allow_nil, bit 1 = declare_trump
     scoring_data: 12 bytes of ContractScoring
 
     Total: 16 bytes (4 header + 12 scoring)
@@ -329,6 +330,8 @@ def compile_bidding_phase(phase: BiddingPhase, scoring: ContractScoring = None)
     flags = 0
     if phase.allow_nil:
         flags |= 0x01
+    if phase.declare_trump:
+        flags |= 0x02
 
     result = bytes([
         OPCODE_BIDDING_PHASE,
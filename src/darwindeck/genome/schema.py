@@ -102,6 +102,7 @@ class ScoringTrigger(Enum):
     PLAY = "play"                 # Score when playing this card
     HAND_END = "hand_end"         # Score for cards in hand at end
     SET_COMPLETE = "set_complete" # Score when completing a set (Go Fish)
+    SWEEP = "sweep"               # Score when a capture clears the table (Scopa)
 
 
 @dataclass(frozen=True)
@@ -344,6 +345,7 @@ class BiddingPhase:
     min_bid: int = 1          # Minimum bid allowed (1 = no Nil, 0 = allow Nil)
     max_bid: int = 13         # Maximum bid (validated against hand size at runtime)
     allow_nil: bool = True    # Allow bidding exactly 0 (Nil)
+    declare_trump: bool = False  # Each bid names a trump suit; the highest bidder's is trump
 
 
 @dataclass(frozen=True)
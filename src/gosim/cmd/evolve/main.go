@@ -8,12 +8,15 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/signalnine/darwindeck/gosim/evolution"
 	"github.com/signalnine/darwindeck/gosim/evolution/fitness"
 	"github.com/signalnine/darwindeck/gosim/genome"
+	"github.com/signalnine/darwindeck/gosim/simulation"
 )
 
 // Version information (set by build flags)
@@ -22,6 +25,10 @@ var (
 	BuildTime = "unknown"
 )
 
+// checkpointLogCompactEvery is how many incremental checkpoints are appended
+// before the log is rewritten as a single snapshot.
+const checkpointLogCompactEvery = 20
+
 // CLI flags
 var (
 	generations       int
@@ -29,12 +36,35 @@ var (
 	style             string
 	gamesPerEval      int
 	seed              int64
+	seedDir           string
+	skipBuiltinSeeds  bool
+	overlayPath       string
+	referenceAI       string
+	referenceGames    int
+	skillGapTarget    float64
+	skillGapStrong    string
+	skillGapWeak      string
+	skillGapGames     int
+	maxPhases         int
+	maxRules          int
+	realDeck          bool
+	attributeMutations bool
+	playerCounts      string
+	playerCountAgg    string
+	playerCountWeights string
+	screenFraction    float64
 	checkpointPath    string
 	checkpointInterval int
+	checkpointLog     bool
 	skipSkillEval     bool
+	determinizations  int
+	memoryWindow      int
 	outputDir         string
 	saveTopN          int
 	workers           int
+	statsdAddr        string
+	statsdPrefix      string
+	pushgatewayURL    string
 	verbose           bool
 	showVersion       bool
 )
@@ -42,15 +72,38 @@ var (
 func init() {
 	flag.IntVar(&generations, "generations", 100, "Number of generations to evolve")
 	flag.IntVar(&populationSize, "population-size", 50, "Population size")
-	flag.StringVar(&style, "style", "balanced", "Fitness style preset (balanced, bluffing, strategic, party, trick-taking)")
+	flag.StringVar(&style, "style", "balanced", "Fitness style preset (balanced, bluffing, strategic, party, dramatic, trick-taking)")
 	flag.IntVar(&gamesPerEval, "games-per-eval", 100, "Number of games per fitness evaluation")
 	flag.Int64Var(&seed, "seed", 0, "Random seed (0 = use current time)")
+	flag.StringVar(&seedDir, "seed-dir", "", "Directory of genome JSON files to add to the initial population")
+	flag.BoolVar(&skipBuiltinSeeds, "skip-builtin-seeds", false, "Seed only from -seed-dir, not the built-in games")
+	flag.StringVar(&overlayPath, "overlay", "", "House rules JSON file applied over every -seed-dir genome")
+	flag.StringVar(&referenceAI, "reference-ai", "", "Also evaluate each genome against this AI (random, greedy, mcts100, mcts500, mcts1000, mcts2000)")
+	flag.IntVar(&referenceGames, "reference-games", 0, "Games against the reference AI per evaluation (0 = games-per-eval)")
+	flag.Float64Var(&skillGapTarget, "skill-gap-target", 0, "Penalize genomes whose strong AI wins further than this share of games from the weak AI, e.g. 0.65 (0 = off)")
+	flag.StringVar(&skillGapStrong, "skill-gap-strong", "mcts500", "Strong AI for -skill-gap-target")
+	flag.StringVar(&skillGapWeak, "skill-gap-weak", "mcts100", "Weak AI for -skill-gap-target")
+	flag.IntVar(&skillGapGames, "skill-gap-games", 0, "Games between the strong and weak AI per evaluation (0 = games-per-eval)")
+	flag.IntVar(&maxPhases, "max-phases", 0, "Most turn phases an offspring may have (0 = unlimited)")
+	flag.IntVar(&maxRules, "max-rules", 0, "Most win conditions, effects and scoring rules an offspring may have (0 = unlimited)")
+	flag.BoolVar(&realDeck, "real-deck", false, "Only evolve games playable with one standard 52-card deck: no chips or duplicate cards")
+	flag.BoolVar(&attributeMutations, "attribute-mutations", false, "Re-evaluate each change behind an improved offspring to credit it (slow)")
+	flag.StringVar(&playerCounts, "player-counts", "", "Comma-separated player counts to evaluate each genome at, e.g. 2,3,4 (default: 2 only)")
+	flag.StringVar(&playerCountAgg, "player-count-agg", evolution.PlayerCountMean, "How to combine fitness across -player-counts (min, mean, weighted)")
+	flag.StringVar(&playerCountWeights, "player-count-weights", "", "Comma-separated weights for -player-counts with -player-count-agg weighted")
+	flag.Float64Var(&screenFraction, "screen-fraction", 0, "Screen new genomes with cheap RandomAI games and fully evaluate only this top fraction (0 = evaluate all fully)")
 	flag.StringVar(&checkpointPath, "checkpoint", "", "Resume from checkpoint file")
 	flag.IntVar(&checkpointInterval, "checkpoint-interval", 10, "Auto-save checkpoint every N generations (0 = disabled)")
+	flag.BoolVar(&checkpointLog, "checkpoint-log", false, "Append incremental checkpoints to checkpoint.jsonl instead of rewriting checkpoint.json")
 	flag.BoolVar(&skipSkillEval, "skip-skill-eval", false, "Skip MCTS skill evaluation (faster but less accurate)")
+	flag.IntVar(&determinizations, "determinizations", 1, "Deals of opponents' hidden cards each MCTS skill-eval move searches (1 = the true deal; skipped for fully observable games)")
+	flag.IntVar(&memoryWindow, "memory-window", 0, "Tricks a forgetful MCTS remembers in a match against perfect recall, measuring how much each game rewards memory (0 = not measured)")
 	flag.StringVar(&outputDir, "output-dir", "", "Output directory for results (default: output/evolution-TIMESTAMP)")
 	flag.IntVar(&saveTopN, "save-top-n", 20, "Save top N genomes to output directory")
 	flag.IntVar(&workers, "workers", 0, "Number of worker goroutines (0 = auto-detect CPU count)")
+	flag.StringVar(&statsdAddr, "statsd", "", "Send per-generation metrics as statsd gauges to this host:port")
+	flag.StringVar(&statsdPrefix, "statsd-prefix", "darwindeck", "Prefix for -statsd metric names")
+	flag.StringVar(&pushgatewayURL, "pushgateway", "", "Push per-generation metrics to this Prometheus Pushgateway URL")
 	flag.BoolVar(&verbose, "verbose", false, "Enable verbose output")
 	flag.BoolVar(&showVersion, "version", false, "Show version information")
 }
@@ -69,6 +122,40 @@ func main() {
 		outputDir = filepath.Join("output", fmt.Sprintf("evolution-%s", timestamp))
 	}
 
+	if referenceAI != "" {
+		if _, err := simulation.ParseAIPlayerType(referenceAI); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -reference-ai: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if skillGapTarget < 0 || skillGapTarget > 1 {
+		fmt.Fprintf(os.Stderr, "Error: -skill-gap-target %v is not between 0 and 1\n", skillGapTarget)
+		os.Exit(1)
+	}
+	for _, name := range []string{skillGapStrong, skillGapWeak} {
+		if _, err := simulation.ParseAIPlayerType(name); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -skill-gap-strong/-skill-gap-weak: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if statsdAddr != "" && pushgatewayURL != "" {
+		fmt.Fprintln(os.Stderr, "Error: use only one of -statsd and -pushgateway")
+		os.Exit(1)
+	}
+
+	if screenFraction < 0 || screenFraction > 1 {
+		fmt.Fprintf(os.Stderr, "Error: -screen-fraction %v is not between 0 and 1\n", screenFraction)
+		os.Exit(1)
+	}
+
+	counts, weights, err := parsePlayerCounts()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Set random seed
 	if seed == 0 {
 		seed = time.Now().UnixNano()
@@ -79,7 +166,6 @@ func main() {
 
 	// Create or resume engine
 	var engine *evolution.EvolutionEngine
-	var err error
 
 	if checkpointPath != "" {
 		fmt.Printf("Resuming from checkpoint: %s\n", checkpointPath)
@@ -92,8 +178,36 @@ func main() {
 		engine.Config.MaxGenerations = generations
 		engine.Config.NumWorkers = workers
 		engine.Config.Verbose = verbose
+		engine.Evaluator.Verbose = verbose
+		if attributeMutations {
+			engine.Config.AttributeMutations = true
+		}
+		if screenFraction != 0 {
+			engine.Config.ScreenFraction = screenFraction
+		}
 		fmt.Printf("Resumed at generation %d\n\n", engine.Population.Generation)
+		if seedDir != "" || overlayPath != "" {
+			fmt.Println("Note: -seed-dir and -overlay are ignored when resuming")
+		}
+		if referenceAI != "" {
+			fmt.Println("Note: -reference-ai is ignored when resuming; the checkpoint's setting is kept")
+		}
+		if skillGapTarget != 0 {
+			fmt.Println("Note: -skill-gap-target is ignored when resuming; the checkpoint's setting is kept")
+		}
+		if maxPhases != 0 || maxRules != 0 || realDeck {
+			fmt.Println("Note: -max-phases, -max-rules and -real-deck are ignored when resuming; the checkpoint's budget is kept")
+		}
+		if playerCounts != "" {
+			fmt.Println("Note: -player-counts is ignored when resuming; the checkpoint's player counts are kept")
+		}
 	} else {
+		seedGenomes, err := loadSeedGenomes()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading seed genomes: %v\n", err)
+			os.Exit(1)
+		}
+
 		config := &evolution.EvolutionConfig{
 			PopulationSize:       populationSize,
 			MaxGenerations:       generations,
@@ -105,11 +219,29 @@ func main() {
 			FitnessStyle:         style,
 			GamesPerEval:         gamesPerEval,
 			UseMCTS:              !skipSkillEval,
+			Determinizations:     determinizations,
+			MemoryWindow:         memoryWindow,
 			NumWorkers:           workers,
 			Verbose:              verbose,
 			PlateauThreshold:     10,
 			ImprovementThreshold: 0.001,
 			DiversityThreshold:   0.05,
+			SeedGenomes:          seedGenomes,
+			SkipBuiltinSeeds:     skipBuiltinSeeds,
+			ReferenceAI:          referenceAI,
+			ReferenceGames:       referenceGames,
+			SkillGapTarget:       skillGapTarget,
+			SkillGapStrongAI:     skillGapStrong,
+			SkillGapWeakAI:       skillGapWeak,
+			SkillGapGames:        skillGapGames,
+			MaxPhases:            maxPhases,
+			MaxRules:             maxRules,
+			RealDeckOnly:         realDeck,
+			AttributeMutations:   attributeMutations,
+			PlayerCounts:         counts,
+			PlayerCountAggregate: playerCountAgg,
+			PlayerCountWeights:   weights,
+			ScreenFraction:       screenFraction,
 		}
 		engine = evolution.NewEvolutionEngine(config)
 	}
@@ -123,9 +255,15 @@ func main() {
 
 	// Setup auto-checkpointing
 	var autoCheckpointer *evolution.AutoCheckpointer
+	cpPath := filepath.Join(outputDir, "checkpoint.json")
+	if checkpointLog {
+		cpPath = filepath.Join(outputDir, "checkpoint.jsonl")
+	}
 	if checkpointInterval > 0 {
-		cpPath := filepath.Join(outputDir, "checkpoint.json")
 		autoCheckpointer = evolution.NewAutoCheckpointer(engine, cpPath, checkpointInterval)
+		if checkpointLog {
+			autoCheckpointer.Log = evolution.NewCheckpointLog(engine, cpPath, checkpointLogCompactEvery)
+		}
 	}
 
 	// Setup signal handler for graceful shutdown
@@ -138,12 +276,26 @@ func main() {
 			if err := autoCheckpointer.SaveFinal(); err != nil {
 				fmt.Fprintf(os.Stderr, "Error saving checkpoint: %v\n", err)
 			} else {
-				fmt.Printf("Checkpoint saved to %s\n", filepath.Join(outputDir, "checkpoint.json"))
+				fmt.Printf("Checkpoint saved to %s\n", cpPath)
 			}
 		}
 		os.Exit(130)
 	}()
 
+	// Live metrics for monitoring long runs
+	if statsdAddr != "" {
+		exporter, err := evolution.NewStatsdExporter(statsdAddr, statsdPrefix)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -statsd: %v\n", err)
+			os.Exit(1)
+		}
+		defer exporter.Close()
+		engine.Exporter = exporter
+	}
+	if pushgatewayURL != "" {
+		engine.Exporter = evolution.NewPushgatewayExporter(pushgatewayURL, "darwindeck-evolve")
+	}
+
 	// Track progress
 	startTime := time.Now()
 	engine.OnGenerationComplete = func(stats evolution.GenerationStats) {
@@ -170,7 +322,7 @@ func main() {
 	}
 
 	// Run evolution
-	fmt.Println("Starting evolution...\n")
+	fmt.Print("Starting evolution...\n\n")
 	err = engine.Evolve()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "\nEvolution failed: %v\n", err)
@@ -198,6 +350,14 @@ func main() {
 		}
 	}
 
+	// The Pareto front shows the trade-offs between metrics that a single
+	// fitness ranking hides
+	front := engine.ParetoFront()
+	fmt.Printf("Saving Pareto front (%d genomes)...\n", len(front))
+	if err := saveParetoFront(front, filepath.Join(outputDir, "pareto_front.json")); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save Pareto front: %v\n", err)
+	}
+
 	// Save final checkpoint
 	if autoCheckpointer != nil {
 		if err := autoCheckpointer.SaveFinal(); err != nil {
@@ -222,12 +382,116 @@ func printBanner() {
 	fmt.Printf("  Games/Eval:     %d\n", gamesPerEval)
 	fmt.Printf("  Workers:        %d (0=auto)\n", workers)
 	fmt.Printf("  Output:         %s\n", outputDir)
+	if seedDir != "" {
+		fmt.Printf("  Seed Dir:       %s\n", seedDir)
+	}
+	if overlayPath != "" {
+		fmt.Printf("  Overlay:        %s\n", overlayPath)
+	}
+	if referenceAI != "" {
+		fmt.Printf("  Reference AI:   %s\n", referenceAI)
+	}
+	if playerCounts != "" {
+		fmt.Printf("  Player Counts:  %s (%s)\n", playerCounts, playerCountAgg)
+	}
+	if screenFraction > 0 && screenFraction < 1 {
+		fmt.Printf("  Screening:      top %.0f%% fully evaluated\n", screenFraction*100)
+	}
 	if checkpointInterval > 0 {
 		fmt.Printf("  Checkpoint:     every %d generations\n", checkpointInterval)
+		if checkpointLog {
+			fmt.Printf("  Checkpoint Log: incremental\n")
+		}
 	}
 	fmt.Println()
 }
 
+// parsePlayerCounts reads -player-counts and -player-count-weights,
+// checking each count is a table size the engine can seat.
+func parsePlayerCounts() ([]int, []float64, error) {
+	if playerCounts == "" {
+		return nil, nil, nil
+	}
+	switch playerCountAgg {
+	case evolution.PlayerCountMin, evolution.PlayerCountMean, evolution.PlayerCountWeighted:
+	default:
+		return nil, nil, fmt.Errorf("-player-count-agg: unknown aggregation %q", playerCountAgg)
+	}
+
+	var counts []int
+	for _, field := range strings.Split(playerCounts, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 2 || n > simulation.MaxPlayers {
+			return nil, nil, fmt.Errorf("-player-counts: %q is not a player count from 2 to %d", field, simulation.MaxPlayers)
+		}
+		counts = append(counts, n)
+	}
+
+	var weights []float64
+	if playerCountWeights != "" {
+		for _, field := range strings.Split(playerCountWeights, ",") {
+			w, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil || w < 0 {
+				return nil, nil, fmt.Errorf("-player-count-weights: %q is not a weight", field)
+			}
+			weights = append(weights, w)
+		}
+		if len(weights) != len(counts) {
+			return nil, nil, fmt.Errorf("-player-count-weights: got %d weights for %d player counts", len(weights), len(counts))
+		}
+	}
+	return counts, weights, nil
+}
+
+// loadSeedGenomes loads the genomes in -seed-dir, with any -overlay house
+// rules applied, warning about any that can't be used. It fails only when nothing would be left to seed from.
+func loadSeedGenomes() ([]*genome.GameGenome, error) {
+	if seedDir == "" {
+		if skipBuiltinSeeds {
+			return nil, fmt.Errorf("-skip-builtin-seeds requires -seed-dir")
+		}
+		if overlayPath != "" {
+			return nil, fmt.Errorf("-overlay requires -seed-dir")
+		}
+		return nil, nil
+	}
+
+	genomes, skipped, err := genome.LoadGenomeDir(seedDir)
+	if err != nil {
+		return nil, err
+	}
+	if overlayPath != "" {
+		overlay, err := genome.LoadRuleOverlayFile(overlayPath)
+		if err != nil {
+			return nil, err
+		}
+		overlaid := genomes[:0]
+		for _, g := range genomes {
+			variant, err := overlay.Apply(g)
+			if err == nil {
+				if invalid := genome.ValidateGenome(variant); len(invalid) > 0 {
+					err = invalid[0]
+				}
+			}
+			if err != nil {
+				skipped = append(skipped, fmt.Errorf("%s with %s: %w", g.Name, overlayPath, err))
+				continue
+			}
+			overlaid = append(overlaid, variant)
+		}
+		genomes = overlaid
+	}
+	for _, err := range skipped {
+		fmt.Fprintf(os.Stderr, "Warning: skipping seed genome %v\n", err)
+	}
+	fmt.Printf("Loaded %d seed genome(s) from %s (%d skipped)\n\n", len(genomes), seedDir, len(skipped))
+
+	if len(genomes) == 0 && skipBuiltinSeeds {
+		return nil, fmt.Errorf("no usable genomes in %s", seedDir)
+	}
+	return genomes, nil
+}
+
 func printSummary(engine *evolution.EvolutionEngine, totalTime time.Duration, outputDir string) {
 	fmt.Println()
 	fmt.Println("════════════════════════════════════════════════════════════")
@@ -245,7 +509,26 @@ func printSummary(engine *evolution.EvolutionEngine, totalTime time.Duration, ou
 			fmt.Printf("  Metrics:\n")
 			fmt.Printf("    Decision Density:  %.2f\n", m.DecisionDensity)
 			fmt.Printf("    Skill vs Luck:     %.2f\n", m.SkillVsLuck)
+			if engine.Config.ReferenceAI != "" {
+				fmt.Printf("    Reference Edge:    %.2f\n", m.ReferenceEdge)
+			}
 			fmt.Printf("    Complexity:        %.2f\n", m.RulesComplexity)
+			if len(m.SeatWinRates) > 0 {
+				fmt.Printf("    Seat Win Rates:    %s\n", formatSeatWinRates(m))
+			}
+			for _, c := range m.ByPlayerCount {
+				fmt.Printf("    Fitness (%d players): %.4f\n", c.Players, c.Metrics.TotalFitness)
+				if len(c.Metrics.SeatWinRates) > 0 {
+					fmt.Printf("      Seat Win Rates:  %s\n", formatSeatWinRates(c.Metrics))
+				}
+			}
+		}
+	}
+
+	if engine.Config.AttributeMutations {
+		fmt.Printf("  Change Impact:   %d improved offspring\n", len(engine.Attributions))
+		for _, c := range evolution.SummarizeAttributions(engine.Attributions) {
+			fmt.Printf("    %-24s %+.4f (x%d)\n", c.Change, c.MeanMarginal, c.Count)
 		}
 	}
 
@@ -268,14 +551,26 @@ func saveGenome(g *genome.GameGenome, fit float64, metrics *fitness.FitnessMetri
 	}
 
 	if metrics != nil {
-		output.FitnessMetrics = map[string]float64{
-			"decision_density":      metrics.DecisionDensity,
-			"comeback_potential":    metrics.ComebackPotential,
-			"tension_curve":         metrics.TensionCurve,
-			"interaction_frequency": metrics.InteractionFrequency,
-			"rules_complexity":      metrics.RulesComplexity,
-			"skill_vs_luck":         metrics.SkillVsLuck,
-			"total_fitness":         metrics.TotalFitness,
+		output.FitnessMetrics = metricsOutput(metrics)
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// saveParetoFront writes the Pareto front's genomes, each with its full
+// metric vector, as one JSON array.
+func saveParetoFront(front []*evolution.Individual, path string) error {
+	output := make([]GenomeOutput, len(front))
+	for i, ind := range front {
+		output[i] = GenomeOutput{
+			Genome:         ind.Genome,
+			Fitness:        ind.Fitness,
+			FitnessMetrics: metricsOutput(ind.FitnessMetrics),
 		}
 	}
 
@@ -287,6 +582,41 @@ func saveGenome(g *genome.GameGenome, fit float64, metrics *fitness.FitnessMetri
 	return os.WriteFile(path, data, 0644)
 }
 
+// metricsOutput returns every fitness objective by name, with the total
+// fitness and each seat's win rate overall and at each player count.
+func metricsOutput(metrics *fitness.FitnessMetrics) map[string]float64 {
+	out := map[string]float64{
+		"total_fitness":       metrics.TotalFitness,
+		"standoff_rate":       metrics.StandoffRate,
+		"hand_lead_stability": metrics.HandLeadStability,
+		"seat_edge":           metrics.SeatEdge,
+	}
+	for i, r := range metrics.SeatWinRates {
+		out[fmt.Sprintf("seat_%d_win_rate", i)] = r
+	}
+	objectives := metrics.Objectives()
+	for i, name := range fitness.ObjectiveNames {
+		out[name] = objectives[i]
+	}
+	for _, c := range metrics.ByPlayerCount {
+		out[fmt.Sprintf("total_fitness_%dp", c.Players)] = c.Metrics.TotalFitness
+		for i, r := range c.Metrics.SeatWinRates {
+			out[fmt.Sprintf("seat_%d_win_rate_%dp", i, c.Players)] = r
+		}
+	}
+	return out
+}
+
+// formatSeatWinRates lists each seat's share of the decided games and the
+// best seat's edge over an even share, e.g. "55% 45% (edge +0.05)".
+func formatSeatWinRates(m *fitness.FitnessMetrics) string {
+	parts := make([]string, len(m.SeatWinRates))
+	for i, r := range m.SeatWinRates {
+		parts[i] = fmt.Sprintf("%.0f%%", r*100)
+	}
+	return fmt.Sprintf("%s (edge %+.2f)", strings.Join(parts, " "), m.SeatEdge)
+}
+
 func sanitizeFilename(name string) string {
 	// Replace spaces and special characters with underscores
 	result := make([]byte, 0, len(name))
@@ -491,6 +491,9 @@ func describeMoveLabel(move engine.LegalMove, state *engine.GameState, genome *e
 	case engine.PhaseTypeDiscard:
 		if move.CardIndex >= 0 && move.CardIndex < len(state.Players[currentPlayer].Hand) {
 			card := state.Players[currentPlayer].Hand[move.CardIndex]
+			if len(phase.Data) > 6 && phase.Data[6] == 1 {
+				return fmt.Sprintf("Discard %s face-down", cardName(card))
+			}
 			return fmt.Sprintf("Discard %s", cardName(card))
 		}
 		return "Discard"
@@ -537,9 +540,53 @@ func describeMoveLabel(move engine.LegalMove, state *engine.GameState, genome *e
 			if move.TargetLoc == engine.LocationDiscard { // Nil marker
 				return "Bid Nil"
 			}
+			if move.TargetLoc >= engine.LocationTrumpBid {
+				return fmt.Sprintf("Bid %d, %s trump", bidValue, suitName(uint8(move.TargetLoc-engine.LocationTrumpBid)))
+			}
 			return fmt.Sprintf("Bid %d", bidValue)
 		}
 		return "Bid"
+
+	case engine.PhaseTypeShow:
+		return "Show Hands"
+
+	case engine.PhaseTypePeek:
+		return "Peek"
+
+	case engine.PhaseTypeDrawDiscard:
+		if move.CardIndex == engine.MoveDraw {
+			if move.TargetLoc == engine.LocationDiscard {
+				return "Take discard"
+			}
+			return "Draw"
+		}
+		if move.CardIndex >= 0 && move.CardIndex < len(state.Players[currentPlayer].Hand) {
+			card := state.Players[currentPlayer].Hand[move.CardIndex]
+			return fmt.Sprintf("Discard %s", cardName(card))
+		}
+		return "Discard"
+
+	case engine.PhaseTypeGive:
+		if move.CardIndex == engine.MoveGivePass {
+			return "Keep cards"
+		}
+		recipient := engine.GiveRecipient(&move)
+		if recipient >= 0 && move.CardIndex < len(state.Players[currentPlayer].Hand) {
+			card := state.Players[currentPlayer].Hand[move.CardIndex]
+			return fmt.Sprintf("Give %s to player %d", cardName(card), recipient+1)
+		}
+		return "Give"
+
+	case engine.PhaseTypeTrade:
+		if move.CardIndex == engine.MoveTradePass {
+			return "No trade"
+		}
+		recipient := engine.TradeRecipient(&move)
+		if recipient >= 0 && move.CardIndex < len(state.Players[currentPlayer].Hand) {
+			card := state.Players[currentPlayer].Hand[move.CardIndex]
+			return fmt.Sprintf("Offer %s to player %d", cardName(card), recipient+1)
+		}
+		return "Trade"
 	}
 
 	return "Unknown"
@@ -567,6 +614,16 @@ func describeMoveType(move engine.LegalMove, genome *engine.Genome) string {
 		return "claim"
 	case engine.PhaseTypeBidding:
 		return "bidding"
+	case engine.PhaseTypeShow:
+		return "show"
+	case engine.PhaseTypePeek:
+		return "peek"
+	case engine.PhaseTypeDrawDiscard:
+		return "draw_discard"
+	case engine.PhaseTypeGive:
+		return "give"
+	case engine.PhaseTypeTrade:
+		return "trade"
 	}
 	return "unknown"
 }
@@ -0,0 +1,35 @@
+package engine
+
+// EliminateBankrupt knocks out every seated player with no chips left at
+// the start of a hand: they are folded before the deal is bet on, so they
+// neither act nor take part in the showdown. ResetHand clears folds, so it
+// is called again each hand. Returns how many players still hold chips.
+func EliminateBankrupt(gs *GameState) int {
+	solvent := 0
+	for i := 0; i < showPlayerCount(gs); i++ {
+		p := &gs.Players[i]
+		if p.Chips <= 0 {
+			p.HasFolded = true
+			continue
+		}
+		solvent++
+	}
+	return solvent
+}
+
+// LastChipStanding returns the only seated player still holding chips, or
+// -1 while two or more do. Call it between hands, once the pot has been
+// awarded: mid-hand an all-in player has no chips but is still playing.
+func LastChipStanding(gs *GameState) int {
+	holder := -1
+	for i := 0; i < showPlayerCount(gs); i++ {
+		if gs.Players[i].Chips <= 0 {
+			continue
+		}
+		if holder >= 0 {
+			return -1
+		}
+		holder = i
+	}
+	return holder
+}
@@ -14,7 +14,19 @@ const (
 	BettingFold
 )
 
-// GenerateBettingMoves returns all valid betting actions for a player
+// MinRaise returns the least a raise must add to the current bet: the size
+// of the last full bet or raise this round, and never less than the MinBet.
+func (gs *GameState) MinRaise(phase *BettingPhaseData) int64 {
+	if gs.LastRaise > int64(phase.MinBet) {
+		return gs.LastRaise
+	}
+	return int64(phase.MinBet)
+}
+
+// GenerateBettingMoves returns all valid betting actions for a player.
+// A player who has acted since the last full bet or raise may not raise: an
+// all-in short of the minimum raise lets them call or fold, but doesn't
+// reopen the raising.
 func GenerateBettingMoves(gs *GameState, phase *BettingPhaseData, playerID int) []BettingAction {
 	player := &gs.Players[playerID]
 	moves := make([]BettingAction, 0, 4)
@@ -39,8 +51,13 @@ func GenerateBettingMoves(gs *GameState, phase *BettingPhaseData, playerID int)
 		// Must match, raise, all-in, or fold
 		if player.Chips >= toCall {
 			moves = append(moves, BettingCall)
-			if player.Chips >= toCall+int64(phase.MinBet) && gs.RaiseCount < phase.MaxRaises {
-				moves = append(moves, BettingRaise)
+			if gs.RaiseCount < phase.MaxRaises && !player.RaiseClosed {
+				if player.Chips >= toCall+gs.MinRaise(phase) {
+					moves = append(moves, BettingRaise)
+				} else if player.Chips > toCall {
+					// Can't afford a full raise, but can raise all-in
+					moves = append(moves, BettingAllIn)
+				}
 			}
 		}
 		if player.Chips > 0 && player.Chips < toCall {
@@ -57,6 +74,12 @@ func GenerateBettingMoves(gs *GameState, phase *BettingPhaseData, playerID int)
 func ApplyBettingAction(gs *GameState, phase *BettingPhaseData, playerID int, action BettingAction) {
 	player := &gs.Players[playerID]
 
+	player.BettingTurns++
+	if action == BettingBet || action == BettingRaise || action == BettingAllIn {
+		player.BetsMade++
+	}
+
+	oldBet, minRaise := gs.CurrentBet, gs.MinRaise(phase)
 	switch action {
 	case BettingCheck:
 		// No change
@@ -64,7 +87,8 @@ func ApplyBettingAction(gs *GameState, phase *BettingPhaseData, playerID int, ac
 		player.Chips -= int64(phase.MinBet)
 		player.CurrentBet += int64(phase.MinBet)
 		gs.Pot += int64(phase.MinBet)
-		gs.CurrentBet = int64(phase.MinBet)
+		// Above the MinBet when the bettor had already posted a blind
+		gs.CurrentBet = player.CurrentBet
 	case BettingCall:
 		toCall := gs.CurrentBet - player.CurrentBet
 		player.Chips -= toCall
@@ -72,9 +96,9 @@ func ApplyBettingAction(gs *GameState, phase *BettingPhaseData, playerID int, ac
 		gs.Pot += toCall
 	case BettingRaise:
 		toCall := gs.CurrentBet - player.CurrentBet
-		raiseAmount := toCall + int64(phase.MinBet)
+		raiseAmount := toCall + minRaise
 		player.Chips -= raiseAmount
-		player.CurrentBet = gs.CurrentBet + int64(phase.MinBet)
+		player.CurrentBet = gs.CurrentBet + minRaise
 		gs.Pot += raiseAmount
 		gs.CurrentBet = player.CurrentBet
 		gs.RaiseCount++
@@ -90,6 +114,53 @@ func ApplyBettingAction(gs *GameState, phase *BettingPhaseData, playerID int, ac
 	case BettingFold:
 		player.HasFolded = true
 	}
+
+	// A full bet or raise reopens the raising to everyone else; a short
+	// all-in only puts them to calling it
+	if raise := gs.CurrentBet - oldBet; raise >= minRaise {
+		gs.LastRaise = raise
+		for i := range gs.Players {
+			gs.Players[i].RaiseClosed = false
+		}
+	}
+	player.RaiseClosed = true
+}
+
+// PostBlinds has the seats after the dealer post forced bets to open a
+// hand: the small blind (half the big blind) and then the big blind.
+// Heads-up, the dealer posts the small blind. A player short of the blind
+// posts what they have and is all-in. Returns the seat that acts first,
+// the one after the big blind.
+func PostBlinds(gs *GameState, bigBlind int64) int {
+	n := showPlayerCount(gs)
+	small, big := gs.SeatAfterDealer(1), gs.SeatAfterDealer(2)
+	if n == 2 {
+		small, big = gs.Dealer, gs.SeatAfterDealer(1)
+	}
+
+	postBlind(gs, small, bigBlind/2)
+	postBlind(gs, big, bigBlind)
+	return (big + 1) % n
+}
+
+// postBlind moves a forced bet of up to amount from seat's chips to the pot.
+func postBlind(gs *GameState, seat int, amount int64) {
+	p := &gs.Players[seat]
+	if amount > p.Chips {
+		amount = p.Chips
+	}
+	if amount <= 0 {
+		return
+	}
+	p.Chips -= amount
+	p.CurrentBet += amount
+	gs.Pot += amount
+	if p.Chips == 0 {
+		p.IsAllIn = true
+	}
+	if p.CurrentBet > gs.CurrentBet {
+		gs.CurrentBet = p.CurrentBet
+	}
 }
 
 // CountActivePlayers returns the number of players who haven't folded
@@ -115,6 +186,45 @@ func CountActingPlayers(gs *GameState) int {
 	return count
 }
 
+// CountPlayersInHand returns the number of seated players who haven't folded.
+// Unlike CountActivePlayers, it ignores unused player slots.
+func CountPlayersInHand(gs *GameState) int {
+	count := 0
+	for i := 0; i < showPlayerCount(gs); i++ {
+		if !gs.Players[i].HasFolded {
+			count++
+		}
+	}
+	return count
+}
+
+// DefaultBettingMinPlayers is how many players must remain in the hand for a
+// betting round to continue: once everyone else folds, the round is over.
+const DefaultBettingMinPlayers = 2
+
+// MinPlayersToContinue returns how many players must remain in the hand for
+// the betting round to continue. Games may raise it to end the round early,
+// or lower it to 1 to let a lone player keep acting.
+func (p *BettingPhaseData) MinPlayersToContinue() int {
+	if p.MinPlayers <= 0 {
+		return DefaultBettingMinPlayers
+	}
+	return p.MinPlayers
+}
+
+// MaxBettingActions returns an upper bound on the actions in one betting
+// round, for use as a loop guard that never truncates a legal round.
+//
+// Each action uses up the actor's turn, and turns are only handed out again
+// when the current bet goes up. The current bet can go up at most
+// MaxRaises + numPlayers + 1 times: one opening bet, MaxRaises raises, and
+// one all-in per player (all-in players never act again). Before the first
+// increase and between increases, each player acts at most once, so a round
+// has at most numPlayers * (MaxRaises + numPlayers + 2) actions.
+func MaxBettingActions(numPlayers int, phase *BettingPhaseData) int {
+	return numPlayers * (phase.MaxRaises + numPlayers + 2)
+}
+
 // AllBetsMatched returns true if all active players have matched the current bet
 // or are all-in/folded
 func AllBetsMatched(gs *GameState) bool {
@@ -143,6 +253,7 @@ func ResolveShowdown(gs *GameState) []int {
 
 // AwardPot distributes the pot to the winner(s)
 // If multiple winners, pot is split evenly with remainder going to first winner
+// Under ChipBridgePotToScore the chips each winner takes are added to their score
 func AwardPot(gs *GameState, winnerIDs []int) {
 	if len(winnerIDs) == 0 {
 		return
@@ -153,9 +264,14 @@ func AwardPot(gs *GameState, winnerIDs []int) {
 	remainder := gs.Pot % int64(len(winnerIDs))
 
 	for i, winnerID := range winnerIDs {
-		gs.Players[winnerID].Chips += share
+		won := share
 		if i == 0 {
-			gs.Players[winnerID].Chips += remainder
+			won += remainder
+		}
+		gs.Players[winnerID].Chips += won
+		if gs.ChipBridge == ChipBridgePotToScore {
+			gs.Players[winnerID].Score += int32(won)
+			UpdateTeamScore(gs, winnerID, int32(won))
 		}
 	}
 	gs.Pot = 0
@@ -206,6 +322,74 @@ func SelectGreedyBettingAction(gs *GameState, moves []BettingAction, handStrengt
 	return BettingFold
 }
 
+// Opponent modeling for the greedy betting AI
+const (
+	bluffSuspicionRate   = 0.5  // Bettors above this rate may be bluffing
+	bluffMinObservations = 4    // Betting decisions seen before the rate is trusted
+	bluffCallFloor       = 0.15 // Weakest hand that will call a suspected bluff
+)
+
+// AggressionRate returns how often playerID has bet, raised, or gone all-in
+// when it was their turn to bet this game. With little history the rate is
+// pulled toward 0.5.
+func AggressionRate(gs *GameState, playerID int) float64 {
+	p := &gs.Players[playerID]
+	return (float64(p.BetsMade) + 1) / (float64(p.BettingTurns) + 2)
+}
+
+// CallPrice returns the pot odds playerID is offered: the share of the pot,
+// once they have called, that their call makes up. Calling pays when the
+// hand's chance of winning beats the price, so a cheap call into a big pot
+// is worth making with a weaker hand than a large bet.
+func CallPrice(gs *GameState, playerID int) float64 {
+	toCall := gs.CurrentBet - gs.Players[playerID].CurrentBet
+	if toCall <= 0 {
+		return 0
+	}
+	return float64(toCall) / float64(gs.Pot+toCall)
+}
+
+// SelectModeledBettingAction is SelectGreedyBettingAction weighing pot odds,
+// with a simple opponent model. Facing a bet it would call or fold, it calls
+// when the hand strength beats the CallPrice, however strong or weak the
+// hand. A hand priced out by a bet from a habitual bettor still calls the
+// suspected bluff; bets from players who rarely bet are respected.
+func SelectModeledBettingAction(gs *GameState, playerID int, moves []BettingAction, handStrength float64) BettingAction {
+	action := SelectGreedyBettingAction(gs, moves, handStrength)
+	if (action == BettingCall || action == BettingFold) && containsBettingAction(moves, BettingCall) {
+		action = BettingFold
+		if handStrength >= CallPrice(gs, playerID) {
+			action = BettingCall
+		}
+	}
+	if action != BettingFold || handStrength < bluffCallFloor || !containsBettingAction(moves, BettingCall) {
+		return action
+	}
+
+	bettor := currentBettor(gs, playerID)
+	if bettor >= 0 && gs.Players[bettor].BettingTurns >= bluffMinObservations &&
+		AggressionRate(gs, bettor) > bluffSuspicionRate {
+		return BettingCall
+	}
+	return action
+}
+
+// currentBettor returns the most aggressive opponent still in the hand who
+// has matched the current bet, or -1 if there is none.
+func currentBettor(gs *GameState, playerID int) int {
+	bettor := -1
+	for i := 0; i < showPlayerCount(gs); i++ {
+		p := &gs.Players[i]
+		if i == playerID || p.HasFolded || p.CurrentBet != gs.CurrentBet {
+			continue
+		}
+		if bettor < 0 || AggressionRate(gs, i) > AggressionRate(gs, bettor) {
+			bettor = i
+		}
+	}
+	return bettor
+}
+
 // containsBettingAction checks if action is in moves
 func containsBettingAction(moves []BettingAction, target BettingAction) bool {
 	for _, m := range moves {
@@ -218,8 +402,7 @@ func containsBettingAction(moves []BettingAction, target BettingAction) bool {
 
 // EvaluateHandStrength returns a 0-1 score based on poker hand ranking heuristics.
 // Simple implementation: based on high cards and pairs.
-// Rank values: 0=Ace, 1-9=2-10, 10=Jack, 11=Queen, 12=King
-// For scoring, Ace is high (treated as 13), King is 12, etc.
+// Rank values: 0-8=2-10, 9=Jack, 10=Queen, 11=King, 12=Ace (Ace high).
 func EvaluateHandStrength(hand []Card) float64 {
 	if len(hand) == 0 {
 		return 0.0
@@ -237,21 +420,16 @@ func EvaluateHandStrength(hand []Card) float64 {
 		if count > maxCount {
 			maxCount = count
 		}
-		// Convert rank for comparison: Ace (0) becomes highest (13)
-		effectiveRank := rank
-		if rank == 0 {
-			effectiveRank = 13 // Ace high
-		}
-		if effectiveRank > highRank {
-			highRank = effectiveRank
+		if rank > highRank {
+			highRank = rank
 		}
 	}
 
 	// Score components
 	// pairScore: 0 for no pair, 0.2 for pair, 0.4 for trips, 0.6 for quads
 	pairScore := float64(maxCount-1) * 0.2
-	// highCardScore: 0-0.4 based on highest card (Ace = 13, King = 12)
-	highCardScore := float64(highRank) / 13.0 * 0.4
+	// highCardScore: 0-0.4 based on highest card (Ace = 13/13, King = 12/13)
+	highCardScore := float64(highRank+1) / 13.0 * 0.4
 
 	return minFloat64(pairScore+highCardScore, 1.0)
 }
@@ -385,8 +563,7 @@ func CalculateHandValue(hand []Card, eval *HandEvaluation) int {
 			}
 		} else {
 			// Default: rank value + 2 (so rank 0 = 2, rank 1 = 3, etc.)
-			// This matches traditional pip values: rank 0=Ace(2 in default), etc.
-			// But for blackjack-like games, cards should be in CardValues
+			// For blackjack-like games, face cards and Aces should be in CardValues
 			total += int(card.Rank) + 2
 		}
 	}
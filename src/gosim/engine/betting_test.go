@@ -196,6 +196,91 @@ func TestBettingMoves_CanCallCantRaise(t *testing.T) {
 	}
 }
 
+func TestBettingMoves_ShortAllInRaise(t *testing.T) {
+	gs := GetState()
+	defer PutState(gs)
+
+	gs.Players[0].Chips = 25
+	gs.CurrentBet = 20
+	phase := &BettingPhaseData{MinBet: 10, MaxRaises: 3}
+
+	// More than the call but short of a full raise: can raise all-in
+	moves := GenerateBettingMoves(gs, phase, 0)
+	if !containsAction(moves, BettingAllIn) || containsAction(moves, BettingRaise) {
+		t.Errorf("Expected an all-in but no full raise, got %v", moves)
+	}
+
+	// Not once the raising is closed to them
+	gs.Players[0].RaiseClosed = true
+	moves = GenerateBettingMoves(gs, phase, 0)
+	if containsAction(moves, BettingAllIn) || !containsAction(moves, BettingCall) {
+		t.Errorf("Expected only call or fold with the raising closed, got %v", moves)
+	}
+}
+
+func TestApplyBettingAction_ShortAllInDoesntReopenRaising(t *testing.T) {
+	gs := NewGameState(3)
+	defer PutState(gs)
+
+	gs.Players[0].Chips = 100
+	gs.Players[1].Chips = 15
+	gs.Players[2].Chips = 100
+	phase := &BettingPhaseData{MinBet: 10, MaxRaises: 3}
+
+	ApplyBettingAction(gs, phase, 0, BettingBet)
+	ApplyBettingAction(gs, phase, 1, BettingAllIn)
+
+	// The all-in only raises by 5, under the minimum raise of 10
+	if gs.CurrentBet != 15 || gs.LastRaise != 10 {
+		t.Fatalf("Expected a bet of 15 with the last full raise 10, got %d and %d", gs.CurrentBet, gs.LastRaise)
+	}
+	moves := GenerateBettingMoves(gs, phase, 0)
+	if containsAction(moves, BettingRaise) || !containsAction(moves, BettingCall) || !containsAction(moves, BettingFold) {
+		t.Errorf("Expected the bettor to only call or fold, got %v", moves)
+	}
+
+	// A player yet to act may still raise, by the full minimum
+	if !containsAction(GenerateBettingMoves(gs, phase, 2), BettingRaise) {
+		t.Fatal("Expected player 2 to be able to raise")
+	}
+	ApplyBettingAction(gs, phase, 2, BettingRaise)
+	if gs.CurrentBet != 25 {
+		t.Errorf("Expected the raise to make the bet 25, got %d", gs.CurrentBet)
+	}
+
+	// A full raise reopens the raising to the bettor
+	if !containsAction(GenerateBettingMoves(gs, phase, 0), BettingRaise) {
+		t.Error("Expected a full raise to reopen the raising")
+	}
+}
+
+func TestApplyBettingAction_FullAllInSetsMinRaise(t *testing.T) {
+	gs := NewGameState(2)
+	defer PutState(gs)
+
+	gs.Players[0].Chips = 100
+	gs.Players[1].Chips = 40
+	phase := &BettingPhaseData{MinBet: 10, MaxRaises: 3}
+
+	ApplyBettingAction(gs, phase, 0, BettingBet)
+	ApplyBettingAction(gs, phase, 1, BettingAllIn)
+
+	// The all-in raises by 30, so a raise must now add at least 30
+	if got := gs.MinRaise(phase); got != 30 {
+		t.Fatalf("Expected a minimum raise of 30, got %d", got)
+	}
+	ApplyBettingAction(gs, phase, 0, BettingRaise)
+	if gs.CurrentBet != 70 || gs.Players[0].Chips != 30 {
+		t.Errorf("Expected a raise to 70 leaving 30 chips, got %d and %d", gs.CurrentBet, gs.Players[0].Chips)
+	}
+
+	// A new street starts the minimum over
+	StartBettingStreet(gs)
+	if gs.MinRaise(phase) != 10 || gs.Players[0].RaiseClosed {
+		t.Error("Expected a new street to reset the minimum raise")
+	}
+}
+
 func TestApplyBettingAction_Check(t *testing.T) {
 	gs := GetState()
 	defer PutState(gs)
@@ -901,7 +986,7 @@ func TestEvaluateHandStrength_HighCard(t *testing.T) {
 	}
 	strength := EvaluateHandStrength(hand)
 
-	// High card of 4 (rank 2) -> 2/13 * 0.4 = ~0.062
+	// High card of 4 (rank 2) -> 3/13 * 0.4 = ~0.092
 	// No pairs -> 0
 	// Total ~0.062
 	if strength >= 0.2 {
@@ -912,12 +997,12 @@ func TestEvaluateHandStrength_HighCard(t *testing.T) {
 func TestEvaluateHandStrength_HighCardAce(t *testing.T) {
 	// Ace high card - should have higher score
 	hand := []Card{
-		{Rank: 0, Suit: 0}, // Ace
-		{Rank: 2, Suit: 1}, // 4
+		{Rank: 12, Suit: 0}, // Ace
+		{Rank: 2, Suit: 1},  // 4
 	}
 	strength := EvaluateHandStrength(hand)
 
-	// Ace (rank 0 -> effective 13) -> 13/13 * 0.4 = 0.4
+	// Ace (rank 12) -> 13/13 * 0.4 = 0.4
 	// No pairs -> 0
 	// Total 0.4
 	if strength < 0.35 || strength > 0.45 {
@@ -935,8 +1020,8 @@ func TestEvaluateHandStrength_Pair(t *testing.T) {
 	strength := EvaluateHandStrength(hand)
 
 	// Pair (maxCount=2) -> (2-1) * 0.2 = 0.2
-	// High card 7 (rank 5) -> 5/13 * 0.4 = ~0.154
-	// Total ~0.354
+	// High card 7 (rank 5) -> 6/13 * 0.4 = ~0.185
+	// Total ~0.385
 	if strength < 0.3 || strength > 0.45 {
 		t.Errorf("Pair of 7s should have medium strength (0.3-0.45), got %f", strength)
 	}
@@ -953,8 +1038,8 @@ func TestEvaluateHandStrength_Trips(t *testing.T) {
 	strength := EvaluateHandStrength(hand)
 
 	// Trips (maxCount=3) -> (3-1) * 0.2 = 0.4
-	// High card 10 (rank 8) -> 8/13 * 0.4 = ~0.246
-	// Total ~0.646
+	// High card 10 (rank 8) -> 9/13 * 0.4 = ~0.277
+	// Total ~0.677
 	if strength < 0.55 || strength > 0.75 {
 		t.Errorf("Trips should have high strength (0.55-0.75), got %f", strength)
 	}
@@ -963,15 +1048,15 @@ func TestEvaluateHandStrength_Trips(t *testing.T) {
 func TestEvaluateHandStrength_Quads(t *testing.T) {
 	// Four of a kind (quads)
 	hand := []Card{
-		{Rank: 10, Suit: 0}, // Jack
-		{Rank: 10, Suit: 1}, // Jack
-		{Rank: 10, Suit: 2}, // Jack
-		{Rank: 10, Suit: 3}, // Jack
+		{Rank: 9, Suit: 0}, // Jack
+		{Rank: 9, Suit: 1}, // Jack
+		{Rank: 9, Suit: 2}, // Jack
+		{Rank: 9, Suit: 3}, // Jack
 	}
 	strength := EvaluateHandStrength(hand)
 
 	// Quads (maxCount=4) -> (4-1) * 0.2 = 0.6
-	// High card Jack (rank 10) -> 10/13 * 0.4 = ~0.308
+	// High card Jack (rank 9) -> 10/13 * 0.4 = ~0.308
 	// Total ~0.908
 	if strength < 0.8 || strength > 1.0 {
 		t.Errorf("Quads should have very high strength (0.8-1.0), got %f", strength)
@@ -990,13 +1075,13 @@ func TestEvaluateHandStrength_EmptyHand(t *testing.T) {
 func TestEvaluateHandStrength_PairOfAces(t *testing.T) {
 	// Pair of Aces - should be strong
 	hand := []Card{
-		{Rank: 0, Suit: 0}, // Ace
-		{Rank: 0, Suit: 1}, // Ace
+		{Rank: 12, Suit: 0}, // Ace
+		{Rank: 12, Suit: 1}, // Ace
 	}
 	strength := EvaluateHandStrength(hand)
 
 	// Pair (maxCount=2) -> (2-1) * 0.2 = 0.2
-	// Ace high (rank 0 -> effective 13) -> 13/13 * 0.4 = 0.4
+	// Ace high (rank 12) -> 13/13 * 0.4 = 0.4
 	// Total 0.6
 	if strength < 0.55 || strength > 0.65 {
 		t.Errorf("Pair of Aces should have strength around 0.6, got %f", strength)
@@ -1581,3 +1666,183 @@ func TestCalculateDefaultHandValueEmpty(t *testing.T) {
 		t.Errorf("Empty hand default value should be 0, got %d", value)
 	}
 }
+
+func TestCountPlayersInHand(t *testing.T) {
+	gs := NewGameState(2)
+	defer PutState(gs)
+
+	// Unused slots are not in the hand
+	if count := CountPlayersInHand(gs); count != 2 {
+		t.Errorf("Expected 2 players in hand, got %d", count)
+	}
+	gs.Players[1].HasFolded = true
+	if count := CountPlayersInHand(gs); count != 1 {
+		t.Errorf("Expected 1 player in hand after a fold, got %d", count)
+	}
+}
+
+func TestBettingRoundLimits(t *testing.T) {
+	phase := &BettingPhaseData{MinBet: 10, MaxRaises: 3}
+	if got := phase.MinPlayersToContinue(); got != DefaultBettingMinPlayers {
+		t.Errorf("Expected default min players %d, got %d", DefaultBettingMinPlayers, got)
+	}
+	phase.MinPlayers = 3
+	if got := phase.MinPlayersToContinue(); got != 3 {
+		t.Errorf("Expected min players 3, got %d", got)
+	}
+
+	// Checks around to the last player, who opens; every raise is used,
+	// then everyone else calls
+	for players := 2; players <= 4; players++ {
+		longest := (players - 1) + 1 + phase.MaxRaises + (players - 1)
+		if limit := MaxBettingActions(players, phase); limit < longest {
+			t.Errorf("%d players: bound %d is below a legal %d-action round", players, limit, longest)
+		}
+	}
+}
+
+func TestApplyBettingActionTracksAggression(t *testing.T) {
+	gs := NewGameState(2)
+	defer PutState(gs)
+	gs.Players[0].Chips = 1000
+	phase := &BettingPhaseData{MinBet: 10, MaxRaises: 3}
+
+	if rate := AggressionRate(gs, 0); rate != 0.5 {
+		t.Errorf("Expected neutral rate without history, got %f", rate)
+	}
+
+	ApplyBettingAction(gs, phase, 0, BettingBet)
+	ApplyBettingAction(gs, phase, 0, BettingCheck)
+	ApplyBettingAction(gs, phase, 0, BettingCheck)
+	if gs.Players[0].BettingTurns != 3 || gs.Players[0].BetsMade != 1 {
+		t.Errorf("Expected 1 bet in 3 turns, got %d in %d", gs.Players[0].BetsMade, gs.Players[0].BettingTurns)
+	}
+
+	// History survives the next hand but not a new game
+	gs.ResetHand()
+	if gs.Players[0].BettingTurns != 3 {
+		t.Error("Betting history should persist across hands")
+	}
+	clone := gs.Clone()
+	defer PutState(clone)
+	if clone.Players[0].BetsMade != 1 {
+		t.Error("Clone lost betting history")
+	}
+	gs.Reset()
+	if gs.Players[0].BettingTurns != 0 || gs.Players[0].BetsMade != 0 {
+		t.Error("Reset should clear betting history")
+	}
+}
+
+func TestSelectModeledBettingActionCallsSuspectedBluffs(t *testing.T) {
+	gs := NewGameState(2)
+	defer PutState(gs)
+	gs.CurrentBet = 20
+	gs.Players[1].CurrentBet = 20
+	moves := []BettingAction{BettingCall, BettingFold}
+	weak := 0.2
+
+	// Player 1 bets almost every turn
+	gs.Players[1].BettingTurns = 10
+	gs.Players[1].BetsMade = 9
+	if got := SelectModeledBettingAction(gs, 0, moves, weak); got != BettingCall {
+		t.Errorf("Expected to call a habitual bettor, got %d", got)
+	}
+
+	// Hopeless hands still fold
+	if got := SelectModeledBettingAction(gs, 0, moves, 0.05); got != BettingFold {
+		t.Errorf("Expected to fold a hopeless hand, got %d", got)
+	}
+
+	// A rare bettor is respected
+	gs.Players[1].BetsMade = 1
+	if got := SelectModeledBettingAction(gs, 0, moves, weak); got != BettingFold {
+		t.Errorf("Expected to fold against a rare bettor, got %d", got)
+	}
+
+	// Too little history to judge
+	gs.Players[1].BettingTurns = 2
+	gs.Players[1].BetsMade = 2
+	if got := SelectModeledBettingAction(gs, 0, moves, weak); got != BettingFold {
+		t.Errorf("Expected to fold without enough history, got %d", got)
+	}
+}
+
+func TestSelectModeledBettingActionWeighsPotOdds(t *testing.T) {
+	gs := NewGameState(2)
+	defer PutState(gs)
+	moves := []BettingAction{BettingCall, BettingFold}
+	hand := 0.4
+
+	// A bet of 20 into a pot of 200: calling buys a 220 pot for 20
+	gs.Pot = 200
+	gs.CurrentBet = 20
+	gs.Players[1].CurrentBet = 20
+	if price := CallPrice(gs, 0); price != 20.0/220 {
+		t.Errorf("Expected a price of 20/220, got %f", price)
+	}
+	if got := SelectModeledBettingAction(gs, 0, moves, hand); got != BettingCall {
+		t.Errorf("Expected the hand to call a cheap bet, got %d", got)
+	}
+	// Even a weak hand is worth that price
+	if got := SelectModeledBettingAction(gs, 0, moves, 0.2); got != BettingCall {
+		t.Errorf("Expected a weak hand to call a cheap bet, got %d", got)
+	}
+
+	// A bet of 1000 into the same pot: the same hand isn't worth 1000/2200
+	gs.Pot = 1200
+	gs.CurrentBet = 1000
+	gs.Players[1].CurrentBet = 1000
+	if got := SelectModeledBettingAction(gs, 0, moves, hand); got != BettingFold {
+		t.Errorf("Expected the hand to fold to an expensive bet, got %d", got)
+	}
+
+	// Strong hands still raise rather than just call
+	if got := SelectModeledBettingAction(gs, 0, []BettingAction{BettingCall, BettingRaise, BettingFold}, 0.9); got != BettingRaise {
+		t.Errorf("Expected a strong hand to raise, got %d", got)
+	}
+}
+
+func TestPostBlinds(t *testing.T) {
+	gs := NewGameState(3)
+	defer PutState(gs)
+	gs.NumPlayers = 3
+	gs.InitializeChips(100)
+	gs.Players[0].Chips = 3 // Too short for the big blind
+	gs.Dealer = 1
+
+	// Seat 2 posts the small blind, seat 0 the big blind, and seat 1 acts first
+	first := PostBlinds(gs, 10)
+	if first != 1 {
+		t.Errorf("Expected the seat after the big blind to act first, got %d", first)
+	}
+	if gs.Players[2].CurrentBet != 5 || gs.Players[2].Chips != 95 {
+		t.Errorf("Expected a small blind of 5 from seat 2, got %d", gs.Players[2].CurrentBet)
+	}
+	if gs.Players[0].CurrentBet != 3 || !gs.Players[0].IsAllIn {
+		t.Errorf("Expected seat 0 all-in for its 3 chips, got bet %d", gs.Players[0].CurrentBet)
+	}
+	if gs.Pot != 8 || gs.CurrentBet != 5 {
+		t.Errorf("Expected pot 8 and a bet of 5 to match, got %d and %d", gs.Pot, gs.CurrentBet)
+	}
+
+	// Heads-up, the dealer posts the small blind and acts first
+	gs = NewGameState(2)
+	defer PutState(gs)
+	gs.InitializeChips(100)
+	gs.Dealer = 0
+	if first := PostBlinds(gs, 10); first != 0 {
+		t.Errorf("Expected the dealer to act first heads-up, got %d", first)
+	}
+	if gs.Players[0].CurrentBet != 5 || gs.Players[1].CurrentBet != 10 || gs.CurrentBet != 10 {
+		t.Errorf("Expected blinds 5/10, got %d/%d", gs.Players[0].CurrentBet, gs.Players[1].CurrentBet)
+	}
+
+	// The big blind may raise by betting once everyone has called
+	phase := &BettingPhaseData{MinBet: 10, MaxRaises: 3}
+	ApplyBettingAction(gs, phase, 0, BettingCall)
+	ApplyBettingAction(gs, phase, 1, BettingBet)
+	if gs.CurrentBet != 20 || gs.Players[1].CurrentBet != 20 {
+		t.Errorf("Expected the big blind's bet to raise the bet to 20, got %d", gs.CurrentBet)
+	}
+}
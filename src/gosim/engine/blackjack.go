@@ -1,32 +1,57 @@
 package engine
 
+// BustRule is what going over the target costs in a point-total game
+// (matching genome.BustRule).
+type BustRule uint8
+
+const (
+	// BustLoses puts a busted hand out of the hand: it cannot win.
+	BustLoses BustRule = 0
+	// BustPenalty scores a busted hand as the target less what it went
+	// over by, so 23 against 21 scores 19: a losing total, but one that
+	// still beats a hand that stood low.
+	BustPenalty BustRule = 1
+)
+
+// BustScore returns the score of a point total of value that went over
+// target under rule, or -1 if the hand cannot win.
+func BustScore(value, target int, rule BustRule) int {
+	if rule != BustPenalty {
+		return -1
+	}
+	score := target - (value - target)
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
+
 // CalculateBlackjackValue calculates the value of a blackjack hand
 // Returns the best value (using Ace as 11 if it doesn't bust, otherwise 1)
-// Card.Rank encoding: 0=Ace, 1-9=2-10, 10-12=J,Q,K
+// Cards count their DefaultRankValues
 func CalculateBlackjackValue(cards []Card) int {
-	if len(cards) == 0 {
-		return 0
-	}
+	return BlackjackValue(cards, nil)
+}
 
+// BlackjackValue is the blackjack total of cards with each card counting
+// its points in values (see RankPoints). An Ace counting 1 may count 11
+// instead where that doesn't bust the hand.
+func BlackjackValue(cards []Card, values []int32) int {
 	total := 0
-	aceCount := 0
+	softAces := 0
 
 	for _, card := range cards {
-		switch {
-		case card.Rank == 0: // Ace
-			aceCount++
-			total += 11 // Initially count Ace as 11
-		case card.Rank >= 10: // J, Q, K (ranks 10, 11, 12)
-			total += 10
-		default: // 2-10 (ranks 1-9)
-			total += int(card.Rank) + 1 // rank 1 = 2, rank 9 = 10
+		points := int(RankPoints(values, card.Rank))
+		if card.Rank == RankAce && points == 1 {
+			softAces++
 		}
+		total += points
 	}
 
-	// Convert Aces from 11 to 1 as needed to avoid bust
-	for aceCount > 0 && total > 21 {
-		total -= 10 // Change an Ace from 11 to 1
-		aceCount--
+	// Count Aces as 11 while that stays within 21
+	for softAces > 0 && total+10 <= 21 {
+		total += 10
+		softAces--
 	}
 
 	return total
@@ -35,8 +60,9 @@ func CalculateBlackjackValue(cards []Card) int {
 // FindBestBlackjackWinner finds the player with the best blackjack hand
 // Returns player ID or -1 for tie
 // In blackjack, closest to 21 without going over wins
-// Bust (over 21) loses to any non-bust hand
-func FindBestBlackjackWinner(state *GameState, numPlayers int) int8 {
+// A bust (over 21) is out of the hand under BustLoses, and scored by
+// BustScore under BustPenalty
+func FindBestBlackjackWinner(state *GameState, numPlayers int, rule BustRule) int8 {
 	if numPlayers == 0 {
 		numPlayers = 2
 	}
@@ -55,10 +81,13 @@ func FindBestBlackjackWinner(state *GameState, numPlayers int) int8 {
 			continue // Skip players with no cards
 		}
 
-		value := CalculateBlackjackValue(hand)
-
-		// Skip busted hands
+		value := BlackjackValue(hand, state.RankValues)
 		if value > 21 {
+			value = BustScore(value, 21, rule)
+		}
+
+		// Skip busted hands that cannot win
+		if value < 0 {
 			continue
 		}
 
@@ -88,17 +117,26 @@ func IsBlackjackGame(genome *Genome) bool {
 	return false
 }
 
+// BlackjackBustRule returns the bust rule of genome's hand evaluation,
+// BustLoses if it has none.
+func BlackjackBustRule(genome *Genome) BustRule {
+	if genome.HandEval == nil {
+		return BustLoses
+	}
+	return genome.HandEval.Bust
+}
+
 // SelectBlackjackMove implements basic blackjack strategy
-// Hit on < 17, stand on >= 17 (or if already busted)
+// Hit below the stand value for rule, stand on it (or if already busted)
 // Returns the index into the moves slice
-func SelectBlackjackMove(state *GameState, moves []LegalMove) int {
+func SelectBlackjackMove(state *GameState, moves []LegalMove, rule BustRule) int {
 	if len(moves) == 0 {
 		return -1
 	}
 
 	// Calculate current hand value
 	hand := state.Players[state.CurrentPlayer].Hand
-	handValue := CalculateBlackjackValue(hand)
+	handValue := BlackjackValue(hand, state.RankValues)
 
 	// Find hit and stand moves
 	hitIdx := -1
@@ -111,12 +149,13 @@ func SelectBlackjackMove(state *GameState, moves []LegalMove) int {
 		}
 	}
 
-	// Basic strategy: hit on < 17, stand on >= 17
+	// Basic strategy: hit below the stand value, stand on it
 	// Also stand if busted (>=22) to avoid making it worse
-	if handValue >= 17 && standIdx >= 0 {
+	stand := BlackjackStandValue(rule)
+	if handValue >= stand && standIdx >= 0 {
 		return standIdx
 	}
-	if handValue < 17 && hitIdx >= 0 {
+	if handValue < stand && hitIdx >= 0 {
 		return hitIdx
 	}
 
@@ -124,6 +163,16 @@ func SelectBlackjackMove(state *GameState, moves []LegalMove) int {
 	return 0
 }
 
+// BlackjackStandValue returns the hand value basic strategy stands on
+// under rule. A bust that only costs the overshoot is cheap, so hitting a
+// 17 pays: on average it ends on 17.5. Hitting an 18 averages 17.
+func BlackjackStandValue(rule BustRule) int {
+	if rule == BustPenalty {
+		return 18
+	}
+	return 17
+}
+
 // IsBlackjackDrawMove checks if a move is a hit/stand from blackjack
 func IsBlackjackDrawMove(move *LegalMove) bool {
 	return move.CardIndex == MoveDraw || move.CardIndex == MoveDrawPass
@@ -5,8 +5,8 @@ import "testing"
 func TestCalculateBlackjackValue_SimpleHand(t *testing.T) {
 	// 10 + 7 = 17
 	cards := []Card{
-		{Rank: 9, Suit: 0},  // 10 (rank 9 = 10)
-		{Rank: 6, Suit: 1},  // 7 (rank 6 = 7)
+		{Rank: 8, Suit: 0},  // 10
+		{Rank: 5, Suit: 1},  // 7
 	}
 	value := CalculateBlackjackValue(cards)
 	if value != 17 {
@@ -17,8 +17,8 @@ func TestCalculateBlackjackValue_SimpleHand(t *testing.T) {
 func TestCalculateBlackjackValue_FaceCards(t *testing.T) {
 	// K + Q = 20
 	cards := []Card{
-		{Rank: 12, Suit: 0}, // K (rank 12)
-		{Rank: 11, Suit: 1}, // Q (rank 11)
+		{Rank: 11, Suit: 0}, // K
+		{Rank: 10, Suit: 1}, // Q
 	}
 	value := CalculateBlackjackValue(cards)
 	if value != 20 {
@@ -29,8 +29,8 @@ func TestCalculateBlackjackValue_FaceCards(t *testing.T) {
 func TestCalculateBlackjackValue_AceAsEleven(t *testing.T) {
 	// A + 7 = 18 (Ace counts as 11)
 	cards := []Card{
-		{Rank: 0, Suit: 0},  // Ace (rank 0)
-		{Rank: 6, Suit: 1},  // 7 (rank 6 = 7)
+		{Rank: 12, Suit: 0},  // Ace
+		{Rank: 5, Suit: 1},  // 7
 	}
 	value := CalculateBlackjackValue(cards)
 	if value != 18 {
@@ -41,9 +41,9 @@ func TestCalculateBlackjackValue_AceAsEleven(t *testing.T) {
 func TestCalculateBlackjackValue_AceAsOne(t *testing.T) {
 	// A + 10 + 5 = 16 (Ace counts as 1 to avoid bust)
 	cards := []Card{
-		{Rank: 0, Suit: 0},  // Ace (rank 0)
-		{Rank: 9, Suit: 1},  // 10 (rank 9 = 10)
-		{Rank: 4, Suit: 2},  // 5 (rank 4 = 5)
+		{Rank: 12, Suit: 0},  // Ace
+		{Rank: 8, Suit: 1},  // 10
+		{Rank: 3, Suit: 2},  // 5
 	}
 	value := CalculateBlackjackValue(cards)
 	if value != 16 {
@@ -54,8 +54,8 @@ func TestCalculateBlackjackValue_AceAsOne(t *testing.T) {
 func TestCalculateBlackjackValue_Blackjack(t *testing.T) {
 	// A + K = 21 (Natural blackjack)
 	cards := []Card{
-		{Rank: 0, Suit: 0},  // Ace (rank 0)
-		{Rank: 12, Suit: 1}, // K (rank 12)
+		{Rank: 12, Suit: 0},  // Ace
+		{Rank: 11, Suit: 1}, // K
 	}
 	value := CalculateBlackjackValue(cards)
 	if value != 21 {
@@ -66,8 +66,8 @@ func TestCalculateBlackjackValue_Blackjack(t *testing.T) {
 func TestCalculateBlackjackValue_TwoAces(t *testing.T) {
 	// A + A = 12 (one as 11, one as 1)
 	cards := []Card{
-		{Rank: 0, Suit: 0}, // Ace
-		{Rank: 0, Suit: 1}, // Ace
+		{Rank: 12, Suit: 0}, // Ace
+		{Rank: 12, Suit: 1}, // Ace
 	}
 	value := CalculateBlackjackValue(cards)
 	if value != 12 {
@@ -78,9 +78,9 @@ func TestCalculateBlackjackValue_TwoAces(t *testing.T) {
 func TestCalculateBlackjackValue_Bust(t *testing.T) {
 	// K + Q + 5 = 25 (bust)
 	cards := []Card{
-		{Rank: 12, Suit: 0}, // K
-		{Rank: 11, Suit: 1}, // Q
-		{Rank: 4, Suit: 2},  // 5 (rank 4 = 5)
+		{Rank: 11, Suit: 0}, // K
+		{Rank: 10, Suit: 1}, // Q
+		{Rank: 3, Suit: 2},  // 5
 	}
 	value := CalculateBlackjackValue(cards)
 	if value != 25 {
@@ -95,16 +95,16 @@ func TestFindBestBlackjackWinner_HigherWins(t *testing.T) {
 	gs.NumPlayers = 2
 	// Player 0: 20
 	gs.Players[0].Hand = []Card{
-		{Rank: 12, Suit: 0}, // K
-		{Rank: 9, Suit: 1},  // 10 (rank 9 = 10)
+		{Rank: 11, Suit: 0}, // K
+		{Rank: 8, Suit: 1},  // 10
 	}
 	// Player 1: 18
 	gs.Players[1].Hand = []Card{
-		{Rank: 0, Suit: 0}, // A (11)
-		{Rank: 6, Suit: 1}, // 7 (rank 6 = 7)
+		{Rank: 12, Suit: 0}, // A (11)
+		{Rank: 5, Suit: 1}, // 7
 	}
 
-	winner := FindBestBlackjackWinner(gs, 2)
+	winner := FindBestBlackjackWinner(gs, 2, BustLoses)
 	if winner != 0 {
 		t.Errorf("Expected player 0 to win with 20, got player %d", winner)
 	}
@@ -117,17 +117,17 @@ func TestFindBestBlackjackWinner_BustLoses(t *testing.T) {
 	gs.NumPlayers = 2
 	// Player 0: 25 (bust)
 	gs.Players[0].Hand = []Card{
-		{Rank: 12, Suit: 0}, // K
-		{Rank: 11, Suit: 1}, // Q
-		{Rank: 4, Suit: 2},  // 5 (rank 4 = 5)
+		{Rank: 11, Suit: 0}, // K
+		{Rank: 10, Suit: 1}, // Q
+		{Rank: 3, Suit: 2},  // 5
 	}
 	// Player 1: 18
 	gs.Players[1].Hand = []Card{
-		{Rank: 0, Suit: 0}, // A (11)
-		{Rank: 6, Suit: 1}, // 7 (rank 6 = 7)
+		{Rank: 12, Suit: 0}, // A (11)
+		{Rank: 5, Suit: 1}, // 7
 	}
 
-	winner := FindBestBlackjackWinner(gs, 2)
+	winner := FindBestBlackjackWinner(gs, 2, BustLoses)
 	if winner != 1 {
 		t.Errorf("Expected player 1 to win (player 0 busted), got player %d", winner)
 	}
@@ -140,18 +140,18 @@ func TestFindBestBlackjackWinner_BothBust(t *testing.T) {
 	gs.NumPlayers = 2
 	// Player 0: 25 (bust)
 	gs.Players[0].Hand = []Card{
-		{Rank: 12, Suit: 0}, // K
-		{Rank: 11, Suit: 1}, // Q
-		{Rank: 4, Suit: 2},  // 5 (rank 4 = 5)
+		{Rank: 11, Suit: 0}, // K
+		{Rank: 10, Suit: 1}, // Q
+		{Rank: 3, Suit: 2},  // 5
 	}
 	// Player 1: 23 (bust)
 	gs.Players[1].Hand = []Card{
-		{Rank: 12, Suit: 0}, // K
-		{Rank: 9, Suit: 1},  // 10 (rank 9 = 10)
-		{Rank: 2, Suit: 2},  // 3 (rank 2 = 3)
+		{Rank: 11, Suit: 0}, // K
+		{Rank: 8, Suit: 1},  // 10
+		{Rank: 1, Suit: 2},  // 3
 	}
 
-	winner := FindBestBlackjackWinner(gs, 2)
+	winner := FindBestBlackjackWinner(gs, 2, BustLoses)
 	if winner != -1 {
 		t.Errorf("Expected -1 (both busted), got player %d", winner)
 	}
@@ -164,17 +164,17 @@ func TestFindBestBlackjackWinner_SkipsFoldedPlayers(t *testing.T) {
 	gs.NumPlayers = 2
 	// Player 0: 21 but folded
 	gs.Players[0].Hand = []Card{
-		{Rank: 0, Suit: 0},  // A (11)
-		{Rank: 12, Suit: 1}, // K (10)
+		{Rank: 12, Suit: 0},  // A (11)
+		{Rank: 11, Suit: 1}, // K (10)
 	}
 	gs.Players[0].HasFolded = true
 	// Player 1: 18
 	gs.Players[1].Hand = []Card{
-		{Rank: 0, Suit: 0}, // A (11)
-		{Rank: 6, Suit: 1}, // 7 (rank 6 = 7)
+		{Rank: 12, Suit: 0}, // A (11)
+		{Rank: 5, Suit: 1}, // 7
 	}
 
-	winner := FindBestBlackjackWinner(gs, 2)
+	winner := FindBestBlackjackWinner(gs, 2, BustLoses)
 	if winner != 1 {
 		t.Errorf("Expected player 1 to win (player 0 folded), got player %d", winner)
 	}
@@ -219,8 +219,8 @@ func TestSelectBlackjackMove_HitOnLow(t *testing.T) {
 	gs.NumPlayers = 2
 	// Player 0: hand value 12 (should hit)
 	gs.Players[0].Hand = []Card{
-		{Rank: 4, Suit: 0}, // 5
-		{Rank: 6, Suit: 1}, // 7
+		{Rank: 3, Suit: 0}, // 5
+		{Rank: 5, Suit: 1}, // 7
 	}
 	gs.CurrentPlayer = 0
 
@@ -229,7 +229,7 @@ func TestSelectBlackjackMove_HitOnLow(t *testing.T) {
 		{PhaseIndex: 0, CardIndex: MoveDrawPass, TargetLoc: LocationDeck}, // stand
 	}
 
-	idx := SelectBlackjackMove(gs, moves)
+	idx := SelectBlackjackMove(gs, moves, BustLoses)
 	if idx != 0 {
 		t.Errorf("Expected to hit (idx 0) on hand value 12, got idx %d", idx)
 	}
@@ -242,8 +242,8 @@ func TestSelectBlackjackMove_StandOnHigh(t *testing.T) {
 	gs.NumPlayers = 2
 	// Player 0: hand value 18 (should stand)
 	gs.Players[0].Hand = []Card{
-		{Rank: 9, Suit: 0},  // 10
-		{Rank: 7, Suit: 1},  // 8
+		{Rank: 8, Suit: 0},  // 10
+		{Rank: 6, Suit: 1},  // 8
 	}
 	gs.CurrentPlayer = 0
 
@@ -252,7 +252,7 @@ func TestSelectBlackjackMove_StandOnHigh(t *testing.T) {
 		{PhaseIndex: 0, CardIndex: MoveDrawPass, TargetLoc: LocationDeck}, // stand
 	}
 
-	idx := SelectBlackjackMove(gs, moves)
+	idx := SelectBlackjackMove(gs, moves, BustLoses)
 	if idx != 1 {
 		t.Errorf("Expected to stand (idx 1) on hand value 18, got idx %d", idx)
 	}
@@ -265,8 +265,8 @@ func TestSelectBlackjackMove_StandOn17(t *testing.T) {
 	gs.NumPlayers = 2
 	// Player 0: hand value 17 (should stand on 17)
 	gs.Players[0].Hand = []Card{
-		{Rank: 9, Suit: 0},  // 10
-		{Rank: 6, Suit: 1},  // 7
+		{Rank: 8, Suit: 0},  // 10
+		{Rank: 5, Suit: 1},  // 7
 	}
 	gs.CurrentPlayer = 0
 
@@ -275,7 +275,7 @@ func TestSelectBlackjackMove_StandOn17(t *testing.T) {
 		{PhaseIndex: 0, CardIndex: MoveDrawPass, TargetLoc: LocationDeck}, // stand
 	}
 
-	idx := SelectBlackjackMove(gs, moves)
+	idx := SelectBlackjackMove(gs, moves, BustLoses)
 	if idx != 1 {
 		t.Errorf("Expected to stand (idx 1) on hand value 17, got idx %d", idx)
 	}
@@ -288,8 +288,8 @@ func TestSelectBlackjackMove_HitOn16(t *testing.T) {
 	gs.NumPlayers = 2
 	// Player 0: hand value 16 (should hit on 16)
 	gs.Players[0].Hand = []Card{
-		{Rank: 9, Suit: 0},  // 10
-		{Rank: 5, Suit: 1},  // 6
+		{Rank: 8, Suit: 0},  // 10
+		{Rank: 4, Suit: 1},  // 6
 	}
 	gs.CurrentPlayer = 0
 
@@ -298,7 +298,7 @@ func TestSelectBlackjackMove_HitOn16(t *testing.T) {
 		{PhaseIndex: 0, CardIndex: MoveDrawPass, TargetLoc: LocationDeck}, // stand
 	}
 
-	idx := SelectBlackjackMove(gs, moves)
+	idx := SelectBlackjackMove(gs, moves, BustLoses)
 	if idx != 0 {
 		t.Errorf("Expected to hit (idx 0) on hand value 16, got idx %d", idx)
 	}
@@ -311,8 +311,8 @@ func TestSelectBlackjackMove_SoftAce(t *testing.T) {
 	gs.NumPlayers = 2
 	// Player 0: A + 6 = soft 17 (should stand)
 	gs.Players[0].Hand = []Card{
-		{Rank: 0, Suit: 0},  // Ace (11)
-		{Rank: 5, Suit: 1},  // 6
+		{Rank: 12, Suit: 0},  // Ace (11)
+		{Rank: 4, Suit: 1},  // 6
 	}
 	gs.CurrentPlayer = 0
 
@@ -322,8 +322,68 @@ func TestSelectBlackjackMove_SoftAce(t *testing.T) {
 	}
 
 	// Soft 17 = 17, should stand
-	idx := SelectBlackjackMove(gs, moves)
+	idx := SelectBlackjackMove(gs, moves, BustLoses)
 	if idx != 1 {
 		t.Errorf("Expected to stand (idx 1) on soft 17, got idx %d", idx)
 	}
 }
+
+// TestBustRule plays a hand where player 0 busts on 23 and player 1 stands
+// on 15: out of the hand the bust loses, scored as a penalty (19) it wins.
+// Basic strategy hits a 17 only when a bust is a penalty.
+func TestBustRule(t *testing.T) {
+	gs := GetState()
+	defer PutState(gs)
+
+	gs.NumPlayers = 2
+	gs.Players[0].Hand = []Card{
+		{Rank: 11, Suit: 0}, // K
+		{Rank: 8, Suit: 1},  // 10
+		{Rank: 1, Suit: 2},  // 3
+	}
+	gs.Players[1].Hand = []Card{
+		{Rank: 8, Suit: 0}, // 10
+		{Rank: 3, Suit: 1}, // 5
+	}
+	eval := &HandEvaluation{Method: EvalMethodPointTotal, TargetValue: 21, BustThreshold: 22}
+	for rank := uint8(0); rank <= RankAce; rank++ {
+		value := rank + 2
+		if rank >= RankJack {
+			value = 10
+		}
+		cv := CardValue{Rank: rank, Value: value}
+		if rank == RankAce {
+			cv = CardValue{Rank: rank, Value: 1, AltValue: 11}
+		}
+		eval.CardValues = append(eval.CardValues, cv)
+	}
+
+	tests := []struct {
+		rule   BustRule
+		winner int8
+		score  int
+		move   int
+	}{
+		{BustLoses, 1, -1, 1},
+		{BustPenalty, 0, 19, 0},
+	}
+	moves := []LegalMove{
+		{PhaseIndex: 0, CardIndex: MoveDraw, TargetLoc: LocationDeck},     // hit
+		{PhaseIndex: 0, CardIndex: MoveDrawPass, TargetLoc: LocationDeck}, // stand
+	}
+	for _, tt := range tests {
+		if got := FindBestBlackjackWinner(gs, 2, tt.rule); got != tt.winner {
+			t.Errorf("Rule %d: expected player %d to win, got %d", tt.rule, tt.winner, got)
+		}
+		eval.Bust = tt.rule
+		if got := ScoreHand(gs.Players[0].Hand, eval, AceBoth); got != tt.score {
+			t.Errorf("Rule %d: expected the bust to score %d, got %d", tt.rule, tt.score, got)
+		}
+		gs.Players[1].Hand = append(gs.Players[1].Hand[:1], Card{Rank: 5, Suit: 1}) // 10 + 7
+		gs.CurrentPlayer = 1
+		if got := SelectBlackjackMove(gs, moves, tt.rule); got != tt.move {
+			t.Errorf("Rule %d: expected move %d on 17, got %d", tt.rule, tt.move, got)
+		}
+		gs.Players[1].Hand[1] = Card{Rank: 3, Suit: 1}
+	}
+}
@@ -0,0 +1,16 @@
+package engine
+
+// Burned cards are taken out of play for the rest of the game, as a dealer
+// burns the top card before each Hold'em street. They go to their own pile
+// rather than the discard, so reshuffling the discard into the deck never
+// brings them back.
+
+// Burn moves count cards from the top of the deck to the burned pile. It
+// burns what is left when the deck runs short, without reshuffling.
+func Burn(state *GameState, count int) {
+	for i := 0; i < count && len(state.Deck) > 0; i++ {
+		card := state.Deck[len(state.Deck)-1]
+		state.Deck = state.Deck[:len(state.Deck)-1]
+		state.Burned = append(state.Burned, card)
+	}
+}
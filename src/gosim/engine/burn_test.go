@@ -0,0 +1,49 @@
+package engine
+
+import "testing"
+
+// TestBurnedCardsNeverReshuffled checks burned cards stay out of the deck
+// however the discard pile is shuffled back in.
+func TestBurnedCardsNeverReshuffled(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	for r := uint8(0); r < 6; r++ {
+		state.Deck = append(state.Deck, Card{Rank: r, Suit: 0})
+	}
+	state.Players[0].Hand = []Card{{Rank: RankAce, Suit: 1}, {Rank: RankKing, Suit: 1}}
+
+	Burn(state, 2)
+	if !state.PlayCard(0, 0, LocationBurned) {
+		t.Fatal("Expected a card to be played to the burned pile")
+	}
+	burned := append([]Card(nil), state.Burned...)
+	if len(burned) != 3 || len(state.Deck) != 4 {
+		t.Fatalf("Expected 3 burned and 4 left in the deck, got %d and %d", len(burned), len(state.Deck))
+	}
+
+	// Empty the deck into the discard, then reshuffle both ways
+	state.Discard = append(state.Discard, state.Deck...)
+	state.Deck = state.Deck[:0]
+	reshuffleDeck(state)
+	ReshuffleShoe(state, 0, 7)
+	if len(state.Deck) != 4 {
+		t.Errorf("Expected the 4 discards back in the deck, got %d", len(state.Deck))
+	}
+	for _, c := range state.Deck {
+		for _, b := range burned {
+			if c == b {
+				t.Errorf("Burned card %v returned to the deck", c)
+			}
+		}
+	}
+	if len(state.Burned) != 3 {
+		t.Errorf("Expected the burned pile untouched, got %v", state.Burned)
+	}
+
+	// Burning more than the deck holds burns what is left
+	Burn(state, 10)
+	if len(state.Deck) != 0 || len(state.Burned) != 7 {
+		t.Errorf("Expected the deck burned out, got %d left and %d burned", len(state.Deck), len(state.Burned))
+	}
+}
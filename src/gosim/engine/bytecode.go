@@ -18,6 +18,12 @@ const (
 	PhaseTypeBetting = 5
 	PhaseTypeClaim   = 6
 	PhaseTypeBidding = 7
+	PhaseTypeShow    = 8
+	PhaseTypePeek    = 9
+
+	PhaseTypeDrawDiscard = 10
+	PhaseTypeGive        = 11
+	PhaseTypeTrade       = 12
 )
 
 const (
@@ -224,6 +230,8 @@ const (
 	TriggerPlay        uint8 = 2
 	TriggerHandEnd     uint8 = 3
 	TriggerSetComplete uint8 = 4
+	TriggerSweep       uint8 = 5 // A capture clears the table (Scopa's scopa); matched against the capturing card
+	TriggerExposed     uint8 = 6 // Game end; matched against each card in a player's exposed area
 )
 
 // CardScoringRule represents explicit scoring for cards
@@ -231,7 +239,8 @@ type CardScoringRule struct {
 	Suit    uint8 // 0-3 for H/D/C/S, 255 for "any"
 	Rank    uint8 // 0-12 for 2-A, 255 for "any"
 	Points  int16 // Points to award (can be negative)
-	Trigger uint8 // 0=TRICK_WIN, 1=CAPTURE, 2=PLAY, 3=HAND_END, 4=SET_COMPLETE
+	Trigger uint8 // 0=TRICK_WIN, 1=CAPTURE, 2=PLAY, 3=HAND_END, 4=SET_COMPLETE, 5=SWEEP, 6=EXPOSED
+	Meld    uint8 // MeldAny, MeldMatched or MeldUnmatched (HAND_END only)
 }
 
 // HandEvalMethod constants define how hands are evaluated
@@ -263,9 +272,10 @@ type HandPattern struct {
 
 // HandEvaluation represents how to evaluate hands
 type HandEvaluation struct {
-	Method        uint8 // 0=NONE, 1=HIGH_CARD, 2=POINT_TOTAL, 3=PATTERN_MATCH, 4=CARD_COUNT
-	TargetValue   uint8 // For POINT_TOTAL (e.g., 21 for Blackjack)
-	BustThreshold uint8 // For POINT_TOTAL (e.g., 22 for Blackjack)
+	Method        uint8    // 0=NONE, 1=HIGH_CARD, 2=POINT_TOTAL, 3=PATTERN_MATCH, 4=CARD_COUNT
+	TargetValue   uint8    // For POINT_TOTAL (e.g., 21 for Blackjack)
+	BustThreshold uint8    // For POINT_TOTAL (e.g., 22 for Blackjack)
+	Bust          BustRule // For POINT_TOTAL: what going over costs
 	CardValues    []CardValue
 	Patterns      []HandPattern
 }
@@ -279,22 +289,39 @@ type Genome struct {
 	Effects       map[uint8]SpecialEffect // rank -> effect lookup
 	CardScoring   []CardScoringRule       // explicit card scoring rules
 	HandEval      *HandEvaluation         // hand evaluation method
+
+	MaxEffectsPerTurn int // Effects resolved per turn before the rest are ignored (0 = DefaultMaxEffectsPerTurn; not in bytecode)
+	MaxDrawsPerGame   int // Draws each player may take from draw phases in a game (0 = unlimited; not in bytecode)
+	MaxLegalMoves     int // Legal moves offered per decision before they are sampled (0 = DefaultMaxLegalMoves; not in bytecode)
+
+	// End-of-game scoring of the captured piles (not in bytecode). When set,
+	// rank-match captures no longer score a point per card.
+	CaptureCategories []CaptureCategory
+
+	// Shooting the moon, checked after the last trick of each hand (not in
+	// bytecode). nil = none.
+	MoonShot *MoonShotRule
 }
 
 type PhaseDescriptor struct {
-	PhaseType uint8  // 1=Draw, 2=Play, 3=Discard, 4=Trick, 5=Betting, 6=Claim
-	Data      []byte // Raw bytes for this phase
+	PhaseType uint8        // 1=Draw, 2=Play, 3=Discard, 4=Trick, 5=Betting, 6=Claim
+	Data      []byte       // Raw bytes for this phase
+	Repeat    *PhaseRepeat // Repeats within a turn (nil = runs once; not in bytecode)
+	RefillTo  int          // Play phases: hand size to draw back up to when the phase ends (0 = none; not in bytecode)
+	DrawLimit int          // Draw phases: draws a player may take per turn (0 = unlimited; not in bytecode)
 }
 
 // BettingPhaseData holds parsed betting phase parameters
 type BettingPhaseData struct {
-	MinBet    int // Minimum bet/raise amount
-	MaxRaises int // Maximum raises per round (prevents infinite loops)
+	MinBet     int // Minimum bet/raise amount
+	MaxRaises  int // Maximum raises per round (prevents infinite loops)
+	MinPlayers int // Players who must remain in the hand for the round to continue (0 = default; not in bytecode)
 }
 
 type WinCondition struct {
 	WinType   uint8
 	Threshold int32
+	Margin    int32 // Lead needed to win a high_score or first_to_score game (see MarginLeader)
 }
 
 // ParseBettingPhaseData extracts betting phase parameters from raw phase data.
@@ -310,6 +337,106 @@ func ParseBettingPhaseData(data []byte) (*BettingPhaseData, error) {
 	}, nil
 }
 
+// ShowPhaseData holds parsed show phase parameters
+type ShowPhaseData struct {
+	Award      uint8 // ShowAwardPot, ShowAwardPoints or ShowAwardBoth
+	Points     int   // Points added to each winner's score
+	ClearHands bool  // Discard all hands after the comparison
+}
+
+// ParseShowPhaseData extracts show phase parameters from raw phase data.
+// Expected format: award:1 + points:4 + clear_hands:1 = 6 bytes
+func ParseShowPhaseData(data []byte) (*ShowPhaseData, error) {
+	if len(data) < 6 {
+		return nil, errors.New("show phase data too short: need at least 6 bytes")
+	}
+
+	return &ShowPhaseData{
+		Award:      data[0],
+		Points:     int(int32(binary.BigEndian.Uint32(data[1:5]))),
+		ClearHands: data[5] == 1,
+	}, nil
+}
+
+// PeekPhaseData holds parsed peek phase parameters
+type PeekPhaseData struct {
+	Target   uint8  // TARGET_NEXT_PLAYER, TARGET_PREV_PLAYER or TARGET_ALL_OPPONENTS
+	Duration uint32 // Turns the peeked cards stay known (0 = rest of the hand)
+}
+
+// ParsePeekPhaseData extracts peek phase parameters from raw phase data.
+// Expected format: target:1 + duration:2 = 3 bytes
+func ParsePeekPhaseData(data []byte) (*PeekPhaseData, error) {
+	if len(data) < 3 {
+		return nil, errors.New("peek phase data too short: need at least 3 bytes")
+	}
+
+	return &PeekPhaseData{
+		Target:   data[0],
+		Duration: uint32(binary.BigEndian.Uint16(data[1:3])),
+	}, nil
+}
+
+// DrawDiscardPhaseData holds parsed draw-discard phase parameters
+type DrawDiscardPhaseData struct {
+	Source      Location // Pile drawn from
+	Count       int      // Cards drawn from Source
+	TakeDiscard bool     // May take the top discard instead of drawing from Source
+}
+
+// ParseDrawDiscardPhaseData extracts draw-discard phase parameters from raw
+// phase data.
+// Expected format: source:1 + count:4 + take_discard:1 = 6 bytes
+func ParseDrawDiscardPhaseData(data []byte) (*DrawDiscardPhaseData, error) {
+	if len(data) < 6 {
+		return nil, errors.New("draw-discard phase data too short: need at least 6 bytes")
+	}
+
+	return &DrawDiscardPhaseData{
+		Source:      Location(data[0]),
+		Count:       int(binary.BigEndian.Uint32(data[1:5])),
+		TakeDiscard: data[5] == 1,
+	}, nil
+}
+
+// GivePhaseData holds parsed give phase parameters
+type GivePhaseData struct {
+	Target    uint8 // TARGET_NEXT_PLAYER, TARGET_PREV_PLAYER or TARGET_ALL_OPPONENTS (giver chooses)
+	Mandatory bool  // If false, the player may keep their cards
+}
+
+// ParseGivePhaseData extracts give phase parameters from raw phase data.
+// Expected format: target:1 + mandatory:1 = 2 bytes
+func ParseGivePhaseData(data []byte) (*GivePhaseData, error) {
+	if len(data) < 2 {
+		return nil, errors.New("give phase data too short: need at least 2 bytes")
+	}
+
+	return &GivePhaseData{
+		Target:    data[0],
+		Mandatory: data[1] == 1,
+	}, nil
+}
+
+// TradePhaseData holds parsed trade phase parameters
+type TradePhaseData struct {
+	Target uint8 // TARGET_NEXT_PLAYER, TARGET_PREV_PLAYER or TARGET_ALL_OPPONENTS (proposer chooses)
+	Price  int32 // Chips paid for the offered card; 0 = a card in exchange
+}
+
+// ParseTradePhaseData extracts trade phase parameters from raw phase data.
+// Expected format: target:1 + price:4 = 5 bytes
+func ParseTradePhaseData(data []byte) (*TradePhaseData, error) {
+	if len(data) < 5 {
+		return nil, errors.New("trade phase data too short: need at least 5 bytes")
+	}
+
+	return &TradePhaseData{
+		Target: data[0],
+		Price:  int32(binary.BigEndian.Uint32(data[1:5])),
+	}, nil
+}
+
 // ParseGenome parses full bytecode into structured Genome
 func ParseGenome(bytecode []byte) (*Genome, error) {
 	header, err := ParseHeader(bytecode)
@@ -410,6 +537,16 @@ func (g *Genome) parseTurnStructure() error {
 			phaseLen = 10
 		case PhaseTypeBidding: // BiddingPhase: opcode:1 + min_bid:1 + max_bid:1 + flags:1 + scoring:12 = 16 bytes
 			phaseLen = 16
+		case PhaseTypeShow: // ShowPhase: award:1 + points:4 + clear_hands:1 = 6 bytes
+			phaseLen = 6
+		case PhaseTypePeek: // PeekPhase: target:1 + duration:2 = 3 bytes
+			phaseLen = 3
+		case PhaseTypeDrawDiscard: // DrawDiscardPhase: source:1 + count:4 + take_discard:1 = 6 bytes
+			phaseLen = 6
+		case PhaseTypeGive: // GivePhase: target:1 + mandatory:1 = 2 bytes
+			phaseLen = 2
+		case PhaseTypeTrade: // TradePhase: target:1 + price:4 = 5 bytes
+			phaseLen = 5
 		default:
 			return fmt.Errorf("unknown phase type: %d", phaseType)
 		}
@@ -439,9 +576,11 @@ const OPCODE_BIDDING_PHASE = 70
 
 // BiddingPhase holds parsed bidding phase parameters for contract games (Spades, Bridge)
 type BiddingPhase struct {
-	MinBid   int
-	MaxBid   int
-	AllowNil bool
+	MinBid       int
+	MaxBid       int
+	AllowNil     bool
+	DeclareTrump bool // Each bid names trump; the highest bidder's suit is trump for the hand
+	Blind        bool // Bids are sealed: chosen before any is revealed, then resolved together
 }
 
 // ContractScoring holds scoring parameters for contract-based games
@@ -460,7 +599,7 @@ type ContractScoring struct {
 // - Byte 0: opcode (70)
 // - Byte 1: min_bid
 // - Byte 2: max_bid
-// - Byte 3: flags (bit 0 = allow_nil)
+// - Byte 3: flags (bit 0 = allow_nil, bit 1 = declare_trump)
 // - Bytes 4-15: ContractScoring (12 bytes)
 //   - Byte 4: points_per_trick_bid
 //   - Byte 5: overtrick_points
@@ -479,9 +618,11 @@ func ParseBiddingPhase(data []byte) (BiddingPhase, ContractScoring, int) {
 	}
 
 	phase := BiddingPhase{
-		MinBid:   int(data[1]),
-		MaxBid:   int(data[2]),
-		AllowNil: data[3]&0x01 != 0,
+		MinBid:       int(data[1]),
+		MaxBid:       int(data[2]),
+		AllowNil:     data[3]&0x01 != 0,
+		DeclareTrump: data[3]&0x02 != 0,
+		Blind:        data[3]&0x04 != 0,
 	}
 
 	scoring := ContractScoring{
@@ -0,0 +1,121 @@
+package engine
+
+// Capture categories score the captured piles when the game ends, the way
+// Scopa awards a point each for the most cards, the most coins, the seven
+// of coins (settebello) and the best prime (primiera). A category shared
+// by several players scores for nobody.
+
+// Capture category kinds
+const (
+	CaptureMostCards uint8 = 0 // Most cards captured
+	CaptureMostSuit  uint8 = 1 // Most cards of Suit captured
+	CaptureCard      uint8 = 2 // Captured the card of Rank and Suit
+	CapturePrime     uint8 = 3 // Best prime: the best-valued card captured in each suit, summed
+	CapturePoints    uint8 = 4 // Most points captured, each card counting its rank's points
+)
+
+// DefaultPrimeValues are Scopa's prime values indexed by rank (2 through
+// Ace): seven 21, six 18, ace 16, five 15, four 14, three 13, two 12 and 10
+// for the court cards. The 8, 9 and 10, which an Italian deck lacks, count
+// as court cards.
+var DefaultPrimeValues = [13]int32{12, 13, 14, 15, 18, 21, 10, 10, 10, 10, 10, 10, 16}
+
+// CaptureCategory is one category the captured piles are scored on.
+type CaptureCategory struct {
+	Kind        uint8   // CaptureMostCards, CaptureMostSuit, CaptureCard, CapturePrime or CapturePoints
+	Suit        uint8   // Suit counted (CaptureMostSuit, CaptureCard)
+	Rank        uint8   // Rank of the card (CaptureCard)
+	Points      int32   // Points for the category's winner
+	PrimeValues []int32 // Prime value per rank (CapturePrime; nil = DefaultPrimeValues)
+}
+
+// captureMeasure returns how well pile does in category c, higher being
+// better, or -1 if it cannot take the category at all. Cards count their
+// points in values (see RankPoints) for CapturePoints.
+func captureMeasure(pile []Card, c CaptureCategory, values []int32) int32 {
+	switch c.Kind {
+	case CaptureMostCards:
+		return int32(len(pile))
+	case CaptureMostSuit:
+		n := int32(0)
+		for _, card := range pile {
+			if card.Suit == c.Suit {
+				n++
+			}
+		}
+		return n
+	case CaptureCard:
+		for _, card := range pile {
+			if card.Suit == c.Suit && card.Rank == c.Rank {
+				return 1
+			}
+		}
+		return -1
+	case CapturePrime:
+		// A prime needs a card of every suit
+		var best [4]int32
+		for i := range best {
+			best[i] = -1
+		}
+		for _, card := range pile {
+			value := primeValue(card.Rank, c.PrimeValues)
+			if int(card.Suit) < len(best) && value > best[card.Suit] {
+				best[card.Suit] = value
+			}
+		}
+		total := int32(0)
+		for _, v := range best {
+			if v < 0 {
+				return -1
+			}
+			total += v
+		}
+		return total
+	case CapturePoints:
+		total := int32(0)
+		for _, card := range pile {
+			total += RankPoints(values, card.Rank)
+		}
+		return total
+	}
+	return -1
+}
+
+func primeValue(rank uint8, values []int32) int32 {
+	if int(rank) < len(values) {
+		return values[rank]
+	}
+	if int(rank) < len(DefaultPrimeValues) {
+		return DefaultPrimeValues[rank]
+	}
+	return 0
+}
+
+// CaptureCategoryWinner returns the seat that alone does best in category
+// c, or -1 if the best is shared or nobody can take it.
+func CaptureCategoryWinner(state *GameState, c CaptureCategory) int {
+	winner, best, tied := -1, int32(-1), false
+	for seat := 0; seat < showPlayerCount(state); seat++ {
+		m := captureMeasure(state.Players[seat].Captured, c, state.RankValues)
+		switch {
+		case m > best:
+			winner, best, tied = seat, m, false
+		case m == best && m >= 0:
+			tied = true
+		}
+	}
+	if tied || best < 0 {
+		return -1
+	}
+	return winner
+}
+
+// ScoreCaptureCategories awards each category's points to its winner.
+func ScoreCaptureCategories(state *GameState, categories []CaptureCategory) {
+	for _, c := range categories {
+		if seat := CaptureCategoryWinner(state, c); seat >= 0 {
+			state.Players[seat].Score += c.Points
+			UpdateTeamScore(state, seat, c.Points)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package engine
+
+import "testing"
+
+const coins uint8 = 1 // Diamonds stand in for Scopa's coins
+
+func TestCaptureCoinsMajority(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.Players[0].Captured = []Card{{Rank: RankTwo, Suit: coins}, {Rank: RankKing, Suit: coins}, {Rank: RankFour, Suit: 0}}
+	state.Players[1].Captured = []Card{{Rank: RankFive, Suit: coins}, {Rank: RankSix, Suit: 2}, {Rank: RankJack, Suit: 3}, {Rank: RankAce, Suit: 0}}
+
+	categories := []CaptureCategory{
+		{Kind: CaptureMostSuit, Suit: coins, Points: 1},
+		{Kind: CaptureMostCards, Points: 1},
+	}
+	ScoreCaptureCategories(state, categories)
+	if state.Players[0].Score != 1 || state.Players[1].Score != 1 {
+		t.Fatalf("Expected coins to player 0 and cards to player 1, got scores %d and %d", state.Players[0].Score, state.Players[1].Score)
+	}
+
+	// A shared majority scores for nobody
+	state.Players[1].Captured = append(state.Players[1].Captured, Card{Rank: RankNine, Suit: coins})
+	if seat := CaptureCategoryWinner(state, categories[0]); seat != -1 {
+		t.Errorf("Expected a coins tie to score for nobody, got player %d", seat)
+	}
+}
+
+func TestCaptureSettebello(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.Players[0].Captured = []Card{{Rank: RankSeven, Suit: 0}, {Rank: RankSeven, Suit: 2}, {Rank: RankSeven, Suit: 3}}
+	state.Players[1].Captured = []Card{{Rank: RankSeven, Suit: coins}}
+
+	settebello := CaptureCategory{Kind: CaptureCard, Suit: coins, Rank: RankSeven, Points: 1}
+	if seat := CaptureCategoryWinner(state, settebello); seat != 1 {
+		t.Fatalf("Expected the seven of coins to score for player 1, got %d", seat)
+	}
+
+	// Nobody scores a card still on the table
+	state.Players[1].Captured = state.Players[1].Captured[:0]
+	if seat := CaptureCategoryWinner(state, settebello); seat != -1 {
+		t.Errorf("Expected an uncaptured settebello to score for nobody, got player %d", seat)
+	}
+
+	// Player 0 holds three sevens, but a prime needs every suit
+	prime := CaptureCategory{Kind: CapturePrime, Points: 1}
+	if seat := CaptureCategoryWinner(state, prime); seat != -1 {
+		t.Errorf("Expected no prime without all four suits, got player %d", seat)
+	}
+	state.Players[0].Captured = append(state.Players[0].Captured, Card{Rank: RankTwo, Suit: coins})
+	if seat := CaptureCategoryWinner(state, prime); seat != 0 {
+		t.Errorf("Expected player 0's prime to score, got %d", seat)
+	}
+}
+
+// TestMatchCaptureFillsPile checks rank-match captures go to the captured
+// pile, and score no points per card once categories score the piles.
+func TestMatchCaptureFillsPile(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.TableauMode = 2 // MATCH_RANK
+	state.Tableau = [][]Card{{{Rank: RankSeven, Suit: coins}}}
+	state.Players[0].Hand = []Card{{Rank: RankSeven, Suit: 0}}
+
+	genome := minimalPlayPhaseGenome()
+	genome.CaptureCategories = []CaptureCategory{{Kind: CaptureMostCards, Points: 1}}
+	ApplyMove(state, &LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationTableau}, genome)
+
+	if len(state.Players[0].Captured) != 2 || state.Players[0].Score != 0 {
+		t.Errorf("Expected 2 cards captured and no points yet, got %d cards and score %d", len(state.Players[0].Captured), state.Players[0].Score)
+	}
+}
@@ -0,0 +1,79 @@
+package engine
+
+// A catch-up rule is a rubber band against snowballing: at the start of
+// each turn the player in last place is given a bonus, so a trailing player
+// has a way back into the game. Last place is judged by the same standings
+// the tension metrics use to find the leader.
+
+// Standing returns seat's standing as detector ranks the game, higher being
+// better: its score in score-led games, the fewer cards it holds in
+// shedding games, its chips in betting games, and so on. ok is false for a
+// detector with no per-seat standing.
+func Standing(state *GameState, detector LeaderDetector, seat int) (standing int64, ok bool) {
+	p := &state.Players[seat]
+	switch detector.(type) {
+	case *ScoreLeaderDetector:
+		return int64(p.Score), true
+	case *LowScoreLeaderDetector:
+		return -int64(p.Score), true
+	case *HandSizeLeaderDetector:
+		return -int64(len(p.Hand)), true
+	case *HandSizeMaxLeaderDetector:
+		return int64(len(p.Hand)), true
+	case *TrickLeaderDetector:
+		tricks, _ := tricksBySeat(state)
+		return int64(tricks[seat]), true
+	case *TrickAvoidanceLeaderDetector:
+		tricks, _ := tricksBySeat(state)
+		return -int64(tricks[seat]), true
+	case *ChipLeaderDetector:
+		return p.Chips, true
+	case *MatchLeaderDetector:
+		return int64(p.HandsWon), true
+	}
+	return 0, false
+}
+
+// TrailingPlayer returns the seat strictly behind every other seat in play
+// by detector's standings, or -1 when last place is shared.
+func TrailingPlayer(state *GameState, detector LeaderDetector) int {
+	n := len(seatsInPlay(state))
+	if n < 2 {
+		return -1
+	}
+	last, lowest, tied := -1, int64(0), false
+	for seat := 0; seat < n; seat++ {
+		standing, ok := Standing(state, detector, seat)
+		if !ok {
+			return -1
+		}
+		switch {
+		case last < 0 || standing < lowest:
+			last, lowest, tied = seat, standing, false
+		case standing == lowest:
+			tied = true
+		}
+	}
+	if tied {
+		return -1
+	}
+	return last
+}
+
+// ApplyCatchUp gives seat its catch-up bonus: draw extra cards from the
+// deck, reshuffling the discards in if it runs out, and points towards the
+// lead, added to its score or, in a low-score game under detector, taken off.
+func ApplyCatchUp(state *GameState, detector LeaderDetector, seat int, draw int, points int32) {
+	for i := 0; i < draw; i++ {
+		if !drawFromDeck(state, uint8(seat)) {
+			break
+		}
+	}
+	if _, low := detector.(*LowScoreLeaderDetector); low {
+		points = -points
+	}
+	if points != 0 {
+		state.Players[seat].Score += points
+		UpdateTeamScore(state, seat, points)
+	}
+}
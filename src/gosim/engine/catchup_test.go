@@ -0,0 +1,57 @@
+package engine
+
+import "testing"
+
+func TestTrailingPlayer(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.Players[0].Score = 10
+	state.Players[1].Score = 4
+	state.Players[2].Score = 7
+	state.Players[0].Hand = []Card{{Rank: RankTwo, Suit: 0}}
+	state.Players[1].Hand = []Card{{Rank: RankTwo, Suit: 1}, {Rank: RankThree, Suit: 1}}
+	state.Players[2].Hand = []Card{{Rank: RankTwo, Suit: 2}, {Rank: RankThree, Suit: 2}, {Rank: RankFour, Suit: 2}}
+
+	tests := []struct {
+		name     string
+		detector LeaderDetector
+		want     int
+	}{
+		{"high score", &ScoreLeaderDetector{}, 1},
+		{"low score", &LowScoreLeaderDetector{}, 0},
+		{"shedding", &HandSizeLeaderDetector{}, 2},
+		{"collecting", &HandSizeMaxLeaderDetector{}, 0},
+	}
+	for _, tt := range tests {
+		if got := TrailingPlayer(state, tt.detector); got != tt.want {
+			t.Errorf("%s: expected seat %d in last place, got %d", tt.name, tt.want, got)
+		}
+	}
+
+	// A shared last place has no trailing player
+	state.Players[2].Score = 4
+	if got := TrailingPlayer(state, &ScoreLeaderDetector{}); got != -1 {
+		t.Errorf("Expected no trailing player on a tie, got %d", got)
+	}
+}
+
+func TestApplyCatchUp(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.Deck = []Card{{Rank: RankFive, Suit: 0}}
+	state.Discard = []Card{{Rank: RankSix, Suit: 0}, {Rank: RankSeven, Suit: 0}}
+
+	// The second card comes from the discards reshuffled into the deck
+	ApplyCatchUp(state, &ScoreLeaderDetector{}, 1, 2, 3)
+	if len(state.Players[1].Hand) != 2 || state.Players[1].Score != 3 {
+		t.Errorf("Expected 2 cards and 3 points, got %d cards and %d points", len(state.Players[1].Hand), state.Players[1].Score)
+	}
+
+	// In a low-score game points towards the lead come off the score
+	ApplyCatchUp(state, &LowScoreLeaderDetector{}, 1, 0, 3)
+	if state.Players[1].Score != 0 {
+		t.Errorf("Expected the low-score bonus to take 3 points off, got %d", state.Players[1].Score)
+	}
+}
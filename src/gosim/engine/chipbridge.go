@@ -0,0 +1,39 @@
+package engine
+
+// Chip bridges link a betting game's chips to its points, for hybrid games
+// that bet on a hand and also score it (matching genome.ChipBridge).
+const (
+	ChipBridgeNone         uint8 = 0 // Chips and points are kept apart
+	ChipBridgePointsWinPot uint8 = 1 // A pot contested past the betting goes to the hand's top scorer
+	ChipBridgePotToScore   uint8 = 2 // Chips taken from the pot also count as points
+)
+
+// PointsLeaders returns the players still in the hand, not having folded,
+// who share the highest score.
+func PointsLeaders(gs *GameState) []int {
+	var leaders []int
+	for seat := 0; seat < showPlayerCount(gs); seat++ {
+		if gs.Players[seat].HasFolded {
+			continue
+		}
+		if len(leaders) > 0 && gs.Players[seat].Score < gs.Players[leaders[0]].Score {
+			continue
+		}
+		if len(leaders) > 0 && gs.Players[seat].Score > gs.Players[leaders[0]].Score {
+			leaders = leaders[:0]
+		}
+		leaders = append(leaders, seat)
+	}
+	return leaders
+}
+
+// SettlePotOnPoints awards a pot left on the table after the betting to the
+// top scorer still in the hand, split between any tied, under
+// ChipBridgePointsWinPot. Call it when the hand has been scored. It does
+// nothing under other bridges or with no pot.
+func SettlePotOnPoints(gs *GameState) {
+	if gs.ChipBridge != ChipBridgePointsWinPot || gs.Pot == 0 {
+		return
+	}
+	AwardPot(gs, PointsLeaders(gs))
+}
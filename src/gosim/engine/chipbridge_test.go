@@ -0,0 +1,48 @@
+package engine
+
+import "testing"
+
+// TestSettlePotOnPoints checks a contested pot goes to the top scorer still
+// in the hand, is split on a tie, and stays put without the bridge.
+func TestSettlePotOnPoints(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.Players[0].Score = 9 // Folded, so cannot take the pot
+	state.Players[0].HasFolded = true
+	state.Players[1].Score = 4
+	state.Players[2].Score = 7
+
+	state.Pot = 100
+	SettlePotOnPoints(state)
+	if state.Pot != 100 {
+		t.Fatalf("Expected the pot left alone without a bridge, got %d", state.Pot)
+	}
+
+	state.ChipBridge = ChipBridgePointsWinPot
+	SettlePotOnPoints(state)
+	if state.Pot != 0 || state.Players[2].Chips != 100 || state.Players[0].Chips != 0 {
+		t.Errorf("Expected seat 2 to take the pot on points, got chips %d, %d, %d", state.Players[0].Chips, state.Players[1].Chips, state.Players[2].Chips)
+	}
+
+	state.Players[1].Score = 7
+	state.Pot = 51
+	SettlePotOnPoints(state)
+	if state.Players[1].Chips != 26 || state.Players[2].Chips != 125 {
+		t.Errorf("Expected a tied pot split, got chips %d and %d", state.Players[1].Chips, state.Players[2].Chips)
+	}
+}
+
+// TestAwardPotToScore checks chips won count as points under
+// ChipBridgePotToScore.
+func TestAwardPotToScore(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.ChipBridge = ChipBridgePotToScore
+	state.Pot = 40
+	AwardPot(state, []int{1})
+	if state.Players[1].Chips != 40 || state.Players[1].Score != 40 || state.Players[0].Score != 0 {
+		t.Errorf("Expected seat 1 to score the 40 chips it won, got %d chips and %d points", state.Players[1].Chips, state.Players[1].Score)
+	}
+}
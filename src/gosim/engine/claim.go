@@ -0,0 +1,64 @@
+package engine
+
+// How a ClaimPhase decides the rank each claim names, read from the
+// phase's sixth data byte.
+const (
+	ClaimRankSequential    uint8 = 0 // A, 2, 3, ..., K, A, ... in turn
+	ClaimRankFree          uint8 = 1 // Any rank the claimer names
+	ClaimRankMatchOrHigher uint8 = 2 // Any rank at least the last claim's, until a challenge clears the pile
+)
+
+// A claim naming its own rank carries it in TargetLoc as
+// LocationClaimRank + rank. Sequential claims target LocationDiscard.
+const LocationClaimRank Location = 130
+
+// claimRankRule returns the rank rule in a ClaimPhase's data.
+func claimRankRule(data []byte) uint8 {
+	if len(data) > 5 {
+		return data[5]
+	}
+	return ClaimRankSequential
+}
+
+// AppendClaims appends the claims the current player can make: each card
+// in hand played face down, named as the next rank in sequence or as each
+// rank the rule allows.
+func AppendClaims(moves []LegalMove, state *GameState, phaseIdx int, rule uint8) []LegalMove {
+	hand := state.Players[state.CurrentPlayer].Hand
+	lowest := 0
+	switch rule {
+	case ClaimRankFree:
+	case ClaimRankMatchOrHigher:
+		if state.LastClaimRank > 0 {
+			lowest = int(state.LastClaimRank)
+		}
+	default:
+		for cardIdx := range hand {
+			moves = append(moves, LegalMove{
+				PhaseIndex: phaseIdx,
+				CardIndex:  cardIdx,
+				TargetLoc:  LocationDiscard, // Cards go face-down to discard
+			})
+		}
+		return moves
+	}
+	for cardIdx := range hand {
+		for rank := lowest; rank < 13; rank++ {
+			moves = append(moves, LegalMove{
+				PhaseIndex: phaseIdx,
+				CardIndex:  cardIdx,
+				TargetLoc:  LocationClaimRank + Location(rank),
+			})
+		}
+	}
+	return moves
+}
+
+// ClaimedRank returns the rank a claim move names: its own, or the next in
+// sequence by turn number.
+func ClaimedRank(state *GameState, move *LegalMove) uint8 {
+	if move.TargetLoc >= LocationClaimRank {
+		return uint8(move.TargetLoc - LocationClaimRank)
+	}
+	return uint8(state.TurnNumber % 13) // A, 2, 3, ..., K, A, 2, ...
+}
@@ -0,0 +1,106 @@
+package engine
+
+import "testing"
+
+func claimGenome(rule uint8) *Genome {
+	return &Genome{
+		Header: &BytecodeHeader{PlayerCount: 2},
+		TurnPhases: []PhaseDescriptor{
+			{PhaseType: PhaseTypeClaim, Data: []byte{1, 1, 0, 1, 1, rule, 0, 0, 0, 0}},
+		},
+	}
+}
+
+// TestFreeChoiceClaims checks a free-choice claim offers every rank for
+// each card, and that a challenge is judged against the rank named.
+func TestFreeChoiceClaims(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	genome := claimGenome(ClaimRankFree)
+	state.Players[0].Hand = append(state.Players[0].Hand, Card{Rank: 5, Suit: 0}, Card{Rank: 9, Suit: 1})
+	state.Players[1].Hand = append(state.Players[1].Hand, Card{Rank: 2, Suit: 2})
+
+	moves := GenerateLegalMoves(state, genome)
+	if len(moves) != 26 {
+		t.Fatalf("Expected 13 claimable ranks for each of 2 cards, got %d moves", len(moves))
+	}
+
+	// Claim the five truthfully as a five; the challenger takes the pile
+	truthful := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationClaimRank + 5}
+	ApplyMove(state, &truthful, genome)
+	if state.CurrentClaim == nil || state.CurrentClaim.ClaimedRank != 5 {
+		t.Fatalf("Expected a claim of rank 5, got %+v", state.CurrentClaim)
+	}
+	challenge := LegalMove{PhaseIndex: 0, CardIndex: MoveChallenge, TargetLoc: LocationDiscard}
+	ApplyMove(state, &challenge, genome)
+	if len(state.Players[1].Hand) != 2 || len(state.Players[0].Hand) != 1 {
+		t.Errorf("Expected the challenger to take the pile, got hands of %d and %d", len(state.Players[0].Hand), len(state.Players[1].Hand))
+	}
+
+	// Seat 1 now claims its two as a king, and is caught
+	state.CurrentPlayer = 1
+	bluff := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationClaimRank + 12}
+	if ClaimedRank(state, &bluff) != 12 {
+		t.Fatalf("Expected the move to name rank 12, got %d", ClaimedRank(state, &bluff))
+	}
+	ApplyMove(state, &bluff, genome)
+	state.CurrentPlayer = 0
+	ApplyMove(state, &challenge, genome)
+	if len(state.Players[1].Hand) != 2 {
+		t.Errorf("Expected the bluffer to take the pile back, got %d cards", len(state.Players[1].Hand))
+	}
+}
+
+// TestMatchOrHigherClaims checks claims cannot name a lower rank than the
+// last claim until a challenge clears the pile.
+func TestMatchOrHigherClaims(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	genome := claimGenome(ClaimRankMatchOrHigher)
+	state.Players[0].Hand = append(state.Players[0].Hand, Card{Rank: 8, Suit: 0}, Card{Rank: 1, Suit: 0})
+	state.Players[1].Hand = append(state.Players[1].Hand, Card{Rank: 3, Suit: 2})
+
+	claim := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationClaimRank + 8}
+	ApplyMove(state, &claim, genome)
+	pass := LegalMove{PhaseIndex: 0, CardIndex: MovePass, TargetLoc: LocationDiscard}
+	state.CurrentPlayer = 1
+	ApplyMove(state, &pass, genome)
+
+	moves := GenerateLegalMoves(state, genome)
+	if len(moves) != 5 {
+		t.Fatalf("Expected claims of ranks 8 to 12 only, got %d moves", len(moves))
+	}
+	for _, m := range moves {
+		if ClaimedRank(state, &m) < 8 {
+			t.Errorf("Expected no claim below the last claim's rank, got %d", ClaimedRank(state, &m))
+		}
+	}
+
+	// A challenge clears the pile and the restriction
+	ApplyMove(state, &moves[0], genome)
+	state.CurrentPlayer = 0
+	challenge := LegalMove{PhaseIndex: 0, CardIndex: MoveChallenge, TargetLoc: LocationDiscard}
+	ApplyMove(state, &challenge, genome)
+	if n := len(GenerateLegalMoves(state, genome)); n != 13*len(state.Players[0].Hand) {
+		t.Errorf("Expected every rank claimable after the challenge, got %d moves for %d cards", n, len(state.Players[0].Hand))
+	}
+}
+
+// TestSequentialClaims checks the default rule still names the rank by
+// turn number.
+func TestSequentialClaims(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	genome := claimGenome(ClaimRankSequential)
+	state.Players[0].Hand = append(state.Players[0].Hand, Card{Rank: 4, Suit: 0})
+	state.TurnNumber = 15
+
+	moves := GenerateLegalMoves(state, genome)
+	if len(moves) != 1 || moves[0].TargetLoc != LocationDiscard {
+		t.Fatalf("Expected a single sequential claim, got %v", moves)
+	}
+	ApplyMove(state, &moves[0], genome)
+	if state.CurrentClaim.ClaimedRank != 2 {
+		t.Errorf("Expected turn 15 to claim rank 2, got %d", state.CurrentClaim.ClaimedRank)
+	}
+}
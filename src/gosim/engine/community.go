@@ -0,0 +1,83 @@
+package engine
+
+// Community cards are dealt face up to a board shared by every player,
+// between the betting rounds of a hand (Texas Hold'em's flop, turn and
+// river). At a showdown each player's hand is scored together with them.
+// The board is kept apart from the tableau, which games like War use for
+// their own piles.
+
+// DealCommunity deals count cards from the deck face up to the board,
+// reshuffling the discard pile into an empty deck.
+func DealCommunity(state *GameState, count int) {
+	for i := 0; i < count; i++ {
+		if len(state.Deck) == 0 {
+			reshuffleDeck(state)
+			if len(state.Deck) == 0 {
+				return
+			}
+		}
+		card := state.Deck[len(state.Deck)-1]
+		state.Deck = state.Deck[:len(state.Deck)-1]
+		state.Community = append(state.Community, card)
+	}
+}
+
+// ClearCommunity moves the board to the discard pile before a new hand.
+func ClearCommunity(state *GameState) {
+	state.Discard = append(state.Discard, state.Community...)
+	state.Community = state.Community[:0]
+}
+
+// StartBettingStreet opens a new betting round within the same hand: bets
+// start again from zero, while the pot and who has folded or gone all-in
+// carry over.
+func StartBettingStreet(state *GameState) {
+	for i := range state.Players {
+		state.Players[i].CurrentBet = 0
+		state.Players[i].RaiseClosed = false
+	}
+	state.CurrentBet = 0
+	state.RaiseCount = 0
+	state.LastRaise = 0
+}
+
+// ShowdownCards returns the cards playerID's hand is scored with: their
+// own, plus any community cards.
+func ShowdownCards(state *GameState, playerID int) []Card {
+	hand := state.Players[playerID].Hand
+	community := state.Community
+	if len(community) == 0 {
+		return hand
+	}
+	cards := make([]Card, 0, len(hand)+len(community))
+	cards = append(cards, hand...)
+	return append(cards, community...)
+}
+
+// BestPokerHand returns the best five-card poker hand among cards, which
+// must hold at least five.
+func BestPokerHand(cards []Card, mode AceMode) PokerHand {
+	if len(cards) <= 5 {
+		return EvaluatePokerHandWithAceMode(cards, mode)
+	}
+
+	var best PokerHand
+	found := false
+	pick := make([]Card, 5)
+	var choose func(start, n int)
+	choose = func(start, n int) {
+		if n == 5 {
+			hand := EvaluatePokerHandWithAceMode(pick, mode)
+			if !found || ComparePokerHands(hand, best) > 0 {
+				best, found = hand, true
+			}
+			return
+		}
+		for i := start; i <= len(cards)-(5-n); i++ {
+			pick[n] = cards[i]
+			choose(i+1, n+1)
+		}
+	}
+	choose(0, 0)
+	return best
+}
@@ -0,0 +1,56 @@
+package engine
+
+import "testing"
+
+// TestCommunityChangesShowdownWinner checks each hand is scored with the
+// board: pocket aces win on a dry board, but lose to a straight flush the
+// board makes for seven-eight of clubs.
+func TestCommunityChangesShowdownWinner(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.AceMode = AceBoth
+	state.Players[0].Hand = []Card{{Rank: RankAce, Suit: 0}, {Rank: RankAce, Suit: 1}}
+	state.Players[1].Hand = []Card{{Rank: RankSeven, Suit: 2}, {Rank: RankEight, Suit: 2}}
+
+	tests := []struct {
+		name  string
+		board []Card
+		want  int8
+	}{
+		{"dry board", []Card{{Rank: RankTwo, Suit: 3}, {Rank: RankFive, Suit: 1}, {Rank: RankNine, Suit: 0}, {Rank: RankJack, Suit: 3}, {Rank: RankKing, Suit: 1}}, 0},
+		{"straight flush", []Card{{Rank: RankNine, Suit: 2}, {Rank: RankTen, Suit: 2}, {Rank: RankJack, Suit: 2}, {Rank: RankTwo, Suit: 0}, {Rank: RankKing, Suit: 3}}, 1},
+	}
+	for _, tt := range tests {
+		state.Community = append(state.Community[:0], tt.board...)
+		if got := FindBestPokerWinner(state, 2); got != tt.want {
+			t.Errorf("%s: expected player %d to win, got %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestDealCommunity checks streets are dealt from the deck to the board,
+// leaving hands alone, and that the board is cleared for the next hand.
+func TestDealCommunity(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	for r := uint8(0); r < 6; r++ {
+		state.Deck = append(state.Deck, Card{Rank: r, Suit: 0})
+	}
+	state.Players[0].Hand = []Card{{Rank: RankAce, Suit: 3}}
+
+	DealCommunity(state, 3)
+	DealCommunity(state, 1)
+	if len(state.Community) != 4 || len(state.Deck) != 2 {
+		t.Fatalf("Expected 4 cards on the board and 2 in the deck, got %d and %d", len(state.Community), len(state.Deck))
+	}
+	if cards := ShowdownCards(state, 0); len(cards) != 5 || len(state.Players[0].Hand) != 1 {
+		t.Errorf("Expected the hand scored with the board, got %v", cards)
+	}
+
+	ClearCommunity(state)
+	if len(state.Community) != 0 || len(state.Discard) != 4 {
+		t.Errorf("Expected the board discarded, got %d on the board and %d discarded", len(state.Community), len(state.Discard))
+	}
+}
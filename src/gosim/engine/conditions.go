@@ -2,7 +2,6 @@ package engine
 
 import (
 	"encoding/binary"
-	"sort"
 )
 
 // EvaluateCondition checks if condition is true for given state
@@ -72,48 +71,11 @@ func EvaluateCondition(state *GameState, playerID uint8, conditionBytes []byte)
 	// Optional extensions: pattern matching
 	case OpCheckHasSetOfN:
 		// Detect N cards of same rank in player's hand
-		requiredCount := int(value)
-		rankCounts := make(map[uint8]int)
-
-		for _, card := range state.Players[playerID].Hand {
-			rankCounts[card.Rank]++
-			if rankCounts[card.Rank] >= requiredCount {
-				return true
-			}
-		}
-		return false
+		return HasSetOfN(state.Players[playerID].Hand, int(value), state.WildRanks)
 
 	case OpCheckHasRunOfN:
 		// Detect N cards in sequence (any suit, sequential ranks)
-		requiredLength := int(value)
-		hand := state.Players[playerID].Hand
-
-		if len(hand) < requiredLength {
-			return false
-		}
-
-		// Sort by rank
-		sorted := make([]Card, len(hand))
-		copy(sorted, hand)
-		sort.Slice(sorted, func(i, j int) bool {
-			return sorted[i].Rank < sorted[j].Rank
-		})
-
-		// Find sequential run
-		runLength := 1
-		for i := 1; i < len(sorted); i++ {
-			if sorted[i].Rank == sorted[i-1].Rank+1 {
-				runLength++
-				if runLength >= requiredLength {
-					return true
-				}
-			} else if sorted[i].Rank != sorted[i-1].Rank {
-				// Different rank, not sequential - reset counter
-				runLength = 1
-			}
-			// Same rank = continue current run length
-		}
-		return false
+		return HasRunOfN(state.Players[playerID].Hand, int(value), state.WildRanks)
 
 	case OpCheckHasMatchingPair:
 		// Detect two cards with matching rank and color (Old Maid style)
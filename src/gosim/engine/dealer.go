@@ -0,0 +1,52 @@
+package engine
+
+// RotateDealer passes the deal one seat to the left.
+func (gs *GameState) RotateDealer() {
+	gs.Dealer = (gs.Dealer + 1) % showPlayerCount(gs)
+}
+
+// SeatAfterDealer returns the seat offset places to the dealer's left.
+func (gs *GameState) SeatAfterDealer(offset int) int {
+	return (gs.Dealer + offset) % showPlayerCount(gs)
+}
+
+// Opening lead rules pick who leads the first trick of a hand (matching
+// genome.OpeningLead).
+const (
+	OpeningLeadDealerLeft uint8 = 0 // The player after the dealer
+	OpeningLeadDealer     uint8 = 1 // The dealer
+	OpeningLeadCardHolder uint8 = 2 // Whoever holds the lead card
+)
+
+// OpeningLeader returns the seat that leads the first trick of a hand. When
+// nobody was dealt the lead card, the player after the dealer leads.
+func (gs *GameState) OpeningLeader(rule uint8, leadCard Card) int {
+	seat := gs.SeatAfterDealer(1)
+	switch rule {
+	case OpeningLeadDealer:
+		seat = gs.Dealer
+	case OpeningLeadCardHolder:
+		if holder := FindCardHolder(gs, leadCard.Rank, leadCard.Suit); holder >= 0 {
+			return holder
+		}
+	}
+	// A player knocked out of the game passes the lead on
+	if !gs.Players[seat].Active {
+		seat = gs.NextActiveSeat(seat)
+	}
+	return seat
+}
+
+// FindCardHolder returns the seat whose hand holds the card of the given
+// rank and suit, or -1 if no hand does. With several decks, the first seat
+// holding a copy is returned.
+func FindCardHolder(state *GameState, rank, suit uint8) int {
+	for seat := 0; seat < showPlayerCount(state); seat++ {
+		for _, card := range state.Players[seat].Hand {
+			if card.Rank == rank && card.Suit == suit {
+				return seat
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,76 @@
+package engine
+
+import "testing"
+
+func TestRotateDealer(t *testing.T) {
+	gs := NewGameState(3)
+	defer PutState(gs)
+	gs.NumPlayers = 3
+	gs.Dealer = 2
+
+	if seat := gs.SeatAfterDealer(1); seat != 0 {
+		t.Errorf("Expected seat 0 after the dealer, got %d", seat)
+	}
+	gs.RotateDealer()
+	if gs.Dealer != 0 {
+		t.Errorf("Expected the deal to pass to seat 0, got %d", gs.Dealer)
+	}
+
+	// ResetHand counts hands but leaves the dealer to the caller
+	gs.ResetHand()
+	if gs.HandsPlayed != 1 || gs.Dealer != 0 {
+		t.Errorf("Expected 1 hand played with the dealer unchanged, got %d and %d", gs.HandsPlayed, gs.Dealer)
+	}
+	clone := gs.Clone()
+	defer PutState(clone)
+	if clone.Dealer != gs.Dealer || clone.HandsPlayed != gs.HandsPlayed {
+		t.Error("Clone lost the dealer state")
+	}
+}
+
+func TestOpeningLeader(t *testing.T) {
+	gs := NewGameState(4)
+	defer PutState(gs)
+	gs.NumPlayers = 4
+	gs.Dealer = 1
+	twoOfClubs := Card{Rank: RankTwo, Suit: 2}
+	gs.Players[3].Hand = []Card{{Rank: RankAce, Suit: 3}, twoOfClubs}
+
+	if seat := gs.OpeningLeader(OpeningLeadDealerLeft, twoOfClubs); seat != 2 {
+		t.Errorf("Expected the seat after the dealer to lead, got %d", seat)
+	}
+	if seat := gs.OpeningLeader(OpeningLeadDealer, twoOfClubs); seat != 1 {
+		t.Errorf("Expected the dealer to lead, got %d", seat)
+	}
+	if seat := gs.OpeningLeader(OpeningLeadCardHolder, twoOfClubs); seat != 3 {
+		t.Errorf("Expected the holder of the 2 of clubs to lead, got %d", seat)
+	}
+
+	// An undealt lead card falls back to the dealer's left
+	gs.Players[3].Hand = gs.Players[3].Hand[:1]
+	if seat := gs.OpeningLeader(OpeningLeadCardHolder, twoOfClubs); seat != 2 {
+		t.Errorf("Expected the seat after the dealer when nobody holds the card, got %d", seat)
+	}
+}
+
+func TestFindCardHolder(t *testing.T) {
+	gs := NewGameState(3)
+	defer PutState(gs)
+	gs.NumPlayers = 3
+	gs.Players[1].Hand = []Card{{Rank: RankKing, Suit: 0}}
+	gs.Players[2].Hand = []Card{{Rank: RankAce, Suit: 3}, {Rank: RankTwo, Suit: 2}}
+
+	if seat := FindCardHolder(gs, RankTwo, 2); seat != 2 {
+		t.Errorf("Expected seat 2 to hold the 2 of clubs, got %d", seat)
+	}
+	if seat := FindCardHolder(gs, RankTwo, 0); seat != -1 {
+		t.Errorf("Expected nobody to hold the 2 of hearts, got %d", seat)
+	}
+
+	// Seats beyond the table are ignored
+	gs.Players[3].Hand = []Card{{Rank: RankTwo, Suit: 0}}
+	if seat := FindCardHolder(gs, RankTwo, 0); seat != -1 {
+		t.Errorf("Expected an empty seat's hand to be ignored, got %d", seat)
+	}
+	gs.Players[3].Hand = nil
+}
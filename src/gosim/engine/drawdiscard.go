@@ -0,0 +1,97 @@
+package engine
+
+// AppendDrawDiscardMoves adds a DrawDiscard phase's moves: the draws that
+// start the turn, then, once the player has drawn, the discards that end
+// it. A player with nothing to draw goes straight to the discard.
+func AppendDrawDiscardMoves(moves []LegalMove, state *GameState, phaseIdx int, dd *DrawDiscardPhaseData) []LegalMove {
+	hand := state.Players[state.CurrentPlayer].Hand
+	if !state.DiscardPending {
+		drawn := false
+		if canDrawFrom(state, dd.Source) {
+			moves = append(moves, LegalMove{
+				PhaseIndex: phaseIdx,
+				CardIndex:  MoveDraw,
+				TargetLoc:  dd.Source,
+			})
+			drawn = true
+		}
+		if dd.TakeDiscard && dd.Source != LocationDiscard && len(state.Discard) > 0 {
+			moves = append(moves, LegalMove{
+				PhaseIndex: phaseIdx,
+				CardIndex:  MoveDraw,
+				TargetLoc:  LocationDiscard,
+			})
+			drawn = true
+		}
+		if drawn {
+			return moves
+		}
+	} else if state.DiscardPhase != phaseIdx {
+		return moves
+	}
+
+	for cardIdx := range hand {
+		moves = append(moves, LegalMove{
+			PhaseIndex: phaseIdx,
+			CardIndex:  cardIdx,
+			TargetLoc:  LocationDiscard,
+		})
+	}
+	return moves
+}
+
+// canDrawFrom reports whether a card can be drawn from source, counting a
+// deck that would be refilled from the discard pile.
+func canDrawFrom(state *GameState, source Location) bool {
+	switch source {
+	case LocationDeck:
+		return len(state.Deck) > 0 || len(state.Discard) > 1
+	case LocationDiscard:
+		return len(state.Discard) > 0
+	}
+	return false
+}
+
+// PendingDiscardMoves narrows moves, once a DrawDiscard phase's draw has
+// been made, to the discards that finish the turn.
+func PendingDiscardMoves(state *GameState, moves []LegalMove) []LegalMove {
+	if !state.DiscardPending {
+		return moves
+	}
+	kept := moves[:0]
+	for _, m := range moves {
+		if m.PhaseIndex == state.DiscardPhase {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// ApplyDrawDiscard applies a DrawDiscard phase move and reports whether the
+// player keeps the turn, which they do after drawing. Taking the top
+// discard takes one card; drawing from Source takes Count.
+func ApplyDrawDiscard(state *GameState, move *LegalMove, dd *DrawDiscardPhaseData) bool {
+	player := state.CurrentPlayer
+	if move.CardIndex == MoveDraw {
+		count := dd.Count
+		if move.TargetLoc != dd.Source || count < 1 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			if move.TargetLoc == LocationDeck {
+				drawFromDeck(state, player)
+			} else {
+				state.DrawCard(player, move.TargetLoc)
+			}
+		}
+		state.DiscardPending = true
+		state.DiscardPhase = move.PhaseIndex
+		return true
+	}
+
+	if move.CardIndex >= 0 && move.CardIndex < len(state.Players[player].Hand) {
+		state.PlayCard(player, move.CardIndex, LocationDiscard)
+	}
+	state.DiscardPending = false
+	return false
+}
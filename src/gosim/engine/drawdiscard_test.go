@@ -0,0 +1,93 @@
+package engine
+
+import "testing"
+
+// drawDiscardGenome returns a genome whose only phase is a DrawDiscard
+// from the deck, optionally allowing the top discard to be taken instead.
+func drawDiscardGenome(takeDiscard bool) *Genome {
+	data := []byte{byte(LocationDeck), 0, 0, 0, 1, 0}
+	if takeDiscard {
+		data[5] = 1
+	}
+	return &Genome{TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypeDrawDiscard, Data: data}}}
+}
+
+// TestDrawDiscardKeepsTurn checks the opponent doesn't act between a
+// player's draw and their discard.
+func TestDrawDiscardKeepsTurn(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.Deck = []Card{{Rank: RankAce, Suit: 0}, {Rank: RankTwo, Suit: 1}}
+	state.Players[0].Hand = []Card{{Rank: RankFive, Suit: 2}}
+	state.Players[1].Hand = []Card{{Rank: RankSix, Suit: 3}}
+	genome := drawDiscardGenome(false)
+
+	moves := GenerateLegalMoves(state, genome)
+	if len(moves) != 1 || moves[0].CardIndex != MoveDraw {
+		t.Fatalf("Expected only a draw to start the turn, got %v", moves)
+	}
+	ApplyMove(state, &moves[0], genome)
+
+	if state.CurrentPlayer != 0 {
+		t.Fatalf("Expected player 0 to keep the turn after drawing, got player %d", state.CurrentPlayer)
+	}
+	if len(state.Players[0].Hand) != 2 {
+		t.Fatalf("Expected 2 cards in hand after drawing, got %d", len(state.Players[0].Hand))
+	}
+	moves = GenerateLegalMoves(state, genome)
+	if len(moves) != 2 {
+		t.Fatalf("Expected a discard for each card in hand, got %v", moves)
+	}
+	for _, m := range moves {
+		if m.CardIndex == MoveDraw {
+			t.Fatalf("Expected no second draw before discarding, got %v", moves)
+		}
+	}
+
+	ApplyMove(state, &moves[0], genome)
+	if state.CurrentPlayer != 1 {
+		t.Errorf("Expected the turn to pass to player 1 after the discard, got player %d", state.CurrentPlayer)
+	}
+	if state.DiscardPending {
+		t.Error("Expected no discard pending after the discard")
+	}
+	if len(state.Discard) != 1 || len(state.Players[0].Hand) != 1 {
+		t.Errorf("Expected 1 card discarded and 1 in hand, got %d and %d", len(state.Discard), len(state.Players[0].Hand))
+	}
+}
+
+// TestDrawDiscardTakeDiscard checks a player may take the top discard
+// instead of drawing, when the phase allows it.
+func TestDrawDiscardTakeDiscard(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.Deck = []Card{{Rank: RankAce, Suit: 0}}
+	state.Discard = []Card{{Rank: RankKing, Suit: 1}}
+	state.Players[0].Hand = []Card{{Rank: RankFive, Suit: 2}}
+	genome := drawDiscardGenome(true)
+
+	moves := GenerateLegalMoves(state, genome)
+	if len(moves) != 2 {
+		t.Fatalf("Expected a draw and a take of the discard, got %v", moves)
+	}
+	var take *LegalMove
+	for i := range moves {
+		if moves[i].TargetLoc == LocationDiscard {
+			take = &moves[i]
+		}
+	}
+	if take == nil {
+		t.Fatalf("Expected a move taking the top discard, got %v", moves)
+	}
+	ApplyMove(state, take, genome)
+
+	hand := state.Players[0].Hand
+	if state.CurrentPlayer != 0 || len(hand) != 2 || hand[1] != (Card{Rank: RankKing, Suit: 1}) {
+		t.Errorf("Expected player 0 to hold the taken king and keep the turn, got hand %v, player %d", hand, state.CurrentPlayer)
+	}
+	if len(state.Discard) != 0 || len(state.Deck) != 1 {
+		t.Errorf("Expected the discard taken and the deck untouched, got %d and %d", len(state.Discard), len(state.Deck))
+	}
+}
@@ -0,0 +1,31 @@
+package engine
+
+// DrawsThisTurn returns the draws the current player has taken from draw
+// phases this turn. A turn lasts for as long as the same player keeps
+// acting, as for the effect cap.
+func (gs *GameState) DrawsThisTurn() int {
+	if gs.DrawsPlayer != gs.CurrentPlayer {
+		return 0
+	}
+	return gs.DrawsTurnCount
+}
+
+// DrawAllowed reports whether the current player may take another draw
+// under a draw phase's per-turn limit and the genome's per-game limit
+// (0 = unlimited). Once either limit is reached the draw is withdrawn.
+func DrawAllowed(state *GameState, turnLimit, gameLimit int) bool {
+	if turnLimit > 0 && state.DrawsThisTurn() >= turnLimit {
+		return false
+	}
+	return gameLimit <= 0 || int(state.Players[state.CurrentPlayer].Draws) < gameLimit
+}
+
+// noteDraw counts a draw by the current player toward the limits.
+func (gs *GameState) noteDraw() {
+	if gs.DrawsPlayer != gs.CurrentPlayer {
+		gs.DrawsPlayer = gs.CurrentPlayer
+		gs.DrawsTurnCount = 0
+	}
+	gs.DrawsTurnCount++
+	gs.Players[gs.CurrentPlayer].Draws++
+}
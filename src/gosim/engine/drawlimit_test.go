@@ -0,0 +1,68 @@
+package engine
+
+import "testing"
+
+// hasDraw reports whether moves offer a draw.
+func hasDraw(moves []LegalMove) bool {
+	for _, m := range moves {
+		if m.CardIndex == MoveDraw {
+			return true
+		}
+	}
+	return false
+}
+
+// TestDrawLimits checks a repeating draw phase stops offering the draw once
+// the player reaches the per-turn limit, and again across turns once the
+// per-game limit is used up.
+func TestDrawLimits(t *testing.T) {
+	genome := &Genome{
+		Header: &BytecodeHeader{PlayerCount: 2, MaxTurns: 100},
+		TurnPhases: []PhaseDescriptor{{
+			PhaseType: 1,
+			Data:      []byte{byte(LocationDeck), 0, 0, 0, 1, 0, 0}, // draw 1, optional
+			Repeat:    &PhaseRepeat{Count: 3, Optional: true},
+			DrawLimit: 2,
+		}},
+		MaxDrawsPerGame: 3,
+	}
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	for i := 0; i < 10; i++ {
+		state.Deck = append(state.Deck, Card{Rank: uint8(i), Suit: 0})
+	}
+	draw := LegalMove{PhaseIndex: 0, CardIndex: MoveDraw, TargetLoc: LocationDeck}
+
+	ApplyMove(state, &draw, genome)
+	if !hasDraw(GenerateLegalMoves(state, genome)) {
+		t.Fatal("Expected a second draw within the turn limit")
+	}
+	ApplyMove(state, &draw, genome)
+	if state.CurrentPlayer != 0 || state.DrawsThisTurn() != 2 {
+		t.Fatalf("Expected player 0 to still be drawing with 2 draws, got player %d with %d", state.CurrentPlayer, state.DrawsThisTurn())
+	}
+	if hasDraw(GenerateLegalMoves(state, genome)) {
+		t.Error("Expected the draw withdrawn at the per-turn limit")
+	}
+
+	// A new turn resets the turn limit but not the game limit
+	newTurn := func() {
+		state.CurrentPlayer = 0
+		state.DrawsTurnCount = 0
+		state.PhaseRuns = 0
+	}
+	newTurn()
+	ApplyMove(state, &draw, genome)
+	if state.Players[0].Draws != 3 {
+		t.Fatalf("Expected 3 draws this game, got %d", state.Players[0].Draws)
+	}
+	newTurn()
+	if hasDraw(GenerateLegalMoves(state, genome)) {
+		t.Error("Expected the draw withdrawn once the per-game limit is used up")
+	}
+	state.CurrentPlayer = 1
+	if !hasDraw(GenerateLegalMoves(state, genome)) {
+		t.Error("Expected the other player to still draw")
+	}
+}
@@ -7,6 +7,7 @@ const (
 	EFFECT_DRAW_CARDS
 	EFFECT_EXTRA_TURN
 	EFFECT_FORCE_DISCARD
+	EFFECT_FORCE_REVEAL
 )
 
 // Target constants
@@ -28,11 +29,38 @@ type SpecialEffect struct {
 	Value       uint8
 }
 
+// DefaultMaxEffectsPerTurn caps effect resolution for genomes that set no
+// cap of their own.
+const DefaultMaxEffectsPerTurn = 8
+
 // RNG interface for deterministic random (nil = no random effects)
 type RNG interface {
 	Intn(n int) int
 }
 
+// ResolveCardEffect applies the effect of a played card unless the current
+// player has already resolved the genome's cap of effects this turn, in
+// which case the effect is ignored and counted in state.EffectsCapped. A
+// turn lasts for as long as the same player keeps acting, so effects that
+// chain through repeated plays all count toward one cap.
+func ResolveCardEffect(state *GameState, effect *SpecialEffect, genome *Genome) {
+	if state.EffectsPlayer != state.CurrentPlayer {
+		state.EffectsPlayer = state.CurrentPlayer
+		state.EffectsThisTurn = 0
+	}
+
+	limit := genome.MaxEffectsPerTurn
+	if limit <= 0 {
+		limit = DefaultMaxEffectsPerTurn
+	}
+	if state.EffectsThisTurn >= limit {
+		state.EffectsCapped++
+		return
+	}
+	state.EffectsThisTurn++
+	ApplyEffect(state, effect, nil) // nil RNG for now
+}
+
 // ApplyEffect executes a special effect on the game state
 func ApplyEffect(state *GameState, effect *SpecialEffect, rng RNG) {
 	switch effect.EffectType {
@@ -74,6 +102,26 @@ func ApplyEffect(state *GameState, effect *SpecialEffect, rng RNG) {
 			}
 		})
 
+	case EFFECT_FORCE_REVEAL:
+		// At least one card, chosen at random when there is an RNG
+		count := int(effect.Value)
+		if count == 0 {
+			count = 1
+		}
+		applyToTargets(state, effect.Target, rng, func(targetID int) {
+			for i := 0; i < count; i++ {
+				hidden := state.unrevealedCards(targetID)
+				if len(hidden) == 0 {
+					return
+				}
+				pick := 0
+				if rng != nil {
+					pick = rng.Intn(len(hidden))
+				}
+				state.RecordReveal(targetID, hidden[pick])
+			}
+		})
+
 	default:
 		// Unknown effect type - ignore for forward compatibility
 	}
@@ -113,6 +161,24 @@ func applyToTargets(state *GameState, target uint8, rng RNG, action func(int)) {
 	}
 }
 
+// ResolveStartingCard applies the effect of the card turned up to start
+// the discard pile as if the dealer had just played it, before the first
+// turn: a skip passes over the first player, a reverse sends play the
+// other way, and a draw makes the first player draw. The player the turn
+// then passes to leads.
+func ResolveStartingCard(state *GameState, effects map[uint8]SpecialEffect) {
+	if len(state.Discard) == 0 {
+		return
+	}
+	effect, ok := effects[state.Discard[len(state.Discard)-1].Rank]
+	if !ok {
+		return
+	}
+	state.CurrentPlayer = uint8(state.Dealer)
+	ApplyEffect(state, &effect, nil)
+	AdvanceTurn(state)
+}
+
 // AdvanceTurn moves to the next player, respecting direction and skips
 func AdvanceTurn(state *GameState) {
 	step := int(state.PlayDirection)
@@ -124,6 +190,10 @@ func AdvanceTurn(state *GameState) {
 		next = (next + step + numPlayers) % numPlayers
 	}
 
+	if next != int(state.CurrentPlayer) {
+		state.EffectsThisTurn = 0
+		state.DrawsTurnCount = 0
+	}
 	state.CurrentPlayer = uint8(next)
 	state.SkipCount = 0 // Reset after applying
 }
@@ -113,6 +113,37 @@ func TestApplyForceDiscard(t *testing.T) {
 	}
 }
 
+func TestApplyForceReveal(t *testing.T) {
+	state := GetState()
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.CurrentPlayer = 0
+	state.PlayDirection = 1
+	state.Players[2].Hand = []Card{{Rank: 5, Suit: 0}, {Rank: 9, Suit: 2}}
+
+	// Value 0 still reveals one card of the previous player's hand
+	effect := &SpecialEffect{EffectType: EFFECT_FORCE_REVEAL, Target: TARGET_PREV_PLAYER}
+	ApplyEffect(state, effect, nil)
+	for _, viewer := range []int{0, 1} {
+		if known := state.KnownCards(viewer, 2); len(known) != 1 {
+			t.Errorf("Player %d should know 1 of player 2's cards, got %v", viewer, known)
+		}
+	}
+	if known := state.KnownCards(0, 1); len(known) != 0 {
+		t.Errorf("Player 1 was not targeted, but %v are known", known)
+	}
+
+	// A second reveal shows a card not already seen
+	ApplyEffect(state, effect, nil)
+	if known, total := state.KnownOpponentCards(1); known != 2 || total != 2 {
+		t.Errorf("Player 1 should know both of player 2's cards, got %d of %d", known, total)
+	}
+	ApplyEffect(state, effect, nil)
+	if known := state.KnownCards(0, 2); len(known) != 2 {
+		t.Errorf("Nothing is left to reveal, but player 0 knows %v", known)
+	}
+}
+
 func TestResolveTargetNextPlayer(t *testing.T) {
 	state := GetState()
 	defer PutState(state)
@@ -191,3 +222,97 @@ func TestAdvanceTurnWraparound(t *testing.T) {
 		t.Errorf("Should wrap to 0, got %d", state.CurrentPlayer)
 	}
 }
+
+func TestResolveCardEffectCapStopsChain(t *testing.T) {
+	// Every card makes the next player draw, and the play phase repeats
+	// for as long as the player has cards: without a cap one turn could
+	// bury the opponent under the whole deck.
+	genome := &Genome{
+		TurnPhases:        []PhaseDescriptor{{PhaseType: PhaseTypePlay, Repeat: &PhaseRepeat{}}},
+		Effects:           make(map[uint8]SpecialEffect),
+		MaxEffectsPerTurn: 3,
+	}
+	for rank := uint8(0); rank < 13; rank++ {
+		genome.Effects[rank] = SpecialEffect{TriggerRank: rank, EffectType: EFFECT_DRAW_CARDS, Target: TARGET_NEXT_PLAYER, Value: 2}
+	}
+	state := NewGameState(2)
+	defer PutState(state)
+	for i := 0; i < 40; i++ {
+		card := Card{Rank: uint8(i % 13), Suit: uint8(i % 4)}
+		if i < 10 {
+			state.Players[0].Hand = append(state.Players[0].Hand, card)
+		} else {
+			state.Deck = append(state.Deck, card)
+		}
+	}
+
+	plays := 0
+	for state.CurrentPlayer == 0 && len(state.Players[0].Hand) > 0 {
+		ApplyMove(state, &LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationDiscard}, genome)
+		plays++
+	}
+
+	if plays != 10 {
+		t.Fatalf("Expected the player to chain all 10 cards, got %d", plays)
+	}
+	if got := len(state.Players[1].Hand); got != 6 {
+		t.Errorf("Expected 3 effects of 2 cards each, opponent drew %d", got)
+	}
+	if state.EffectsCapped != 7 {
+		t.Errorf("Expected 7 effects ignored past the cap, got %d", state.EffectsCapped)
+	}
+
+	// The next player's turn starts a fresh count
+	state.CurrentPlayer = 1
+	ApplyMove(state, &LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationDiscard}, genome)
+	if got := len(state.Players[0].Hand); got != 2 {
+		t.Errorf("Expected the next turn's effect to resolve, player 0 drew %d", got)
+	}
+}
+
+func TestResolveCardEffectDefaultCap(t *testing.T) {
+	state := GetState()
+	defer PutState(state)
+	effect := &SpecialEffect{EffectType: EFFECT_REVERSE}
+
+	for i := 0; i < DefaultMaxEffectsPerTurn+2; i++ {
+		ResolveCardEffect(state, effect, &Genome{})
+	}
+	if state.EffectsThisTurn != DefaultMaxEffectsPerTurn || state.EffectsCapped != 2 {
+		t.Errorf("Expected the default cap of %d, got %d resolved and %d capped",
+			DefaultMaxEffectsPerTurn, state.EffectsThisTurn, state.EffectsCapped)
+	}
+}
+
+func TestResolveStartingCardSkip(t *testing.T) {
+	state := GetState()
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.Dealer = 2
+	state.Discard = append(state.Discard, Card{Rank: RankJack, Suit: 0})
+	effects := map[uint8]SpecialEffect{RankJack: {TriggerRank: RankJack, EffectType: EFFECT_SKIP_NEXT, Value: 1}}
+
+	ResolveStartingCard(state, effects)
+
+	// Player 0 would lead after the dealer, but is skipped
+	if state.CurrentPlayer != 1 {
+		t.Errorf("Expected player 1 to lead after a starting skip, got %d", state.CurrentPlayer)
+	}
+	if state.SkipCount != 0 {
+		t.Errorf("Expected the skip to be used up, got %d", state.SkipCount)
+	}
+}
+
+func TestResolveStartingCardNoEffect(t *testing.T) {
+	state := GetState()
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.Dealer = 2
+	state.Discard = append(state.Discard, Card{Rank: RankFive, Suit: 0})
+	effects := map[uint8]SpecialEffect{RankJack: {TriggerRank: RankJack, EffectType: EFFECT_SKIP_NEXT, Value: 1}}
+
+	ResolveStartingCard(state, effects)
+	if state.CurrentPlayer != 0 {
+		t.Errorf("Expected an ordinary starting card to leave player 0 to lead, got %d", state.CurrentPlayer)
+	}
+}
@@ -0,0 +1,24 @@
+package engine
+
+// ScoreExposed scores the cards every seated player has laid face up in
+// front of them (see LocationExposed) once the game ends: each card scores
+// the points of every exposed card scoring rule it matches, as rummy
+// players score their laid-down melds and penalty games charge for the
+// cards taken face up.
+func ScoreExposed(state *GameState, scoring []CardScoringRule) {
+	for seat := 0; seat < showPlayerCount(state); seat++ {
+		total := int32(0)
+		for _, card := range state.Players[seat].Exposed {
+			for _, rule := range scoring {
+				if rule.Trigger != TriggerExposed {
+					continue
+				}
+				if (rule.Suit == 255 || rule.Suit == card.Suit) && (rule.Rank == 255 || rule.Rank == card.Rank) {
+					total += int32(rule.Points)
+				}
+			}
+		}
+		state.Players[seat].Score += total
+		UpdateTeamScore(state, seat, total)
+	}
+}
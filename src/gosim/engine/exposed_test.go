@@ -0,0 +1,67 @@
+package engine
+
+import "testing"
+
+// TestPlayToExposed checks single cards and whole sets played to the
+// exposed area land face up in front of the player, where every player's
+// observation shows them, and go back to the deck between rounds.
+func TestPlayToExposed(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	seven := Card{Rank: RankSeven, Suit: 1}
+	state.Players[0].Hand = []Card{
+		{Rank: RankKing, Suit: 0}, seven, {Rank: RankKing, Suit: 2}, {Rank: RankKing, Suit: 3},
+	}
+	// Sets of three or four, laid down as melds
+	genome := &Genome{
+		Header: &BytecodeHeader{PlayerCount: 2},
+		TurnPhases: []PhaseDescriptor{
+			{PhaseType: PhaseTypePlay, Data: []byte{byte(LocationExposed), 3, 4, 0, 1, 0, 0, 0, 0}},
+		},
+	}
+
+	moves := GenerateLegalMoves(state, genome)
+	if len(moves) != 1 || moves[0].CardIndex != -int(RankKing)-100 || moves[0].TargetLoc != LocationExposed {
+		t.Fatalf("Expected one move laying down the kings, got %+v", moves)
+	}
+	ApplyMove(state, &moves[0], genome)
+	if len(state.Players[0].Exposed) != 3 || len(state.Players[0].Hand) != 1 || state.Players[0].Score != 0 {
+		t.Fatalf("Expected three kings exposed and no points yet, got %v exposed, %v in hand, score %d",
+			state.Players[0].Exposed, state.Players[0].Hand, state.Players[0].Score)
+	}
+	if !state.PlayCard(0, 0, LocationExposed) || len(state.Players[0].Exposed) != 4 {
+		t.Fatalf("Expected the seven played face up, got %v", state.Players[0].Exposed)
+	}
+
+	public := opponentPlane(EncodeObservation(state, 1), 2)
+	if planeCount(public) != 4 || public[cardSlot(seven)] != 1 {
+		t.Errorf("Expected every exposed card in the opponent's view, got %v", public)
+	}
+
+	CollectCards(state)
+	if len(state.Players[0].Exposed) != 0 || len(state.Deck) != 4 {
+		t.Errorf("Expected the exposed cards collected into the deck, got %d exposed, %d in the deck", len(state.Players[0].Exposed), len(state.Deck))
+	}
+}
+
+// TestScoreExposed checks only exposed rules score the exposed cards, and
+// each player scores their own.
+func TestScoreExposed(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.Players[0].Exposed = []Card{{Rank: RankKing, Suit: 0}, {Rank: RankKing, Suit: 1}, {Rank: RankTwo, Suit: 0}}
+	state.Players[1].Exposed = []Card{{Rank: RankQueen, Suit: 3}}
+	state.Players[1].Hand = []Card{{Rank: RankKing, Suit: 2}}
+	scoring := []CardScoringRule{
+		{Suit: 255, Rank: RankKing, Points: 10, Trigger: TriggerExposed},
+		{Suit: 3, Rank: RankQueen, Points: -13, Trigger: TriggerExposed},
+		{Suit: 255, Rank: RankTwo, Points: 5, Trigger: TriggerHandEnd},
+	}
+
+	ScoreExposed(state, scoring)
+	if state.Players[0].Score != 20 || state.Players[1].Score != -13 {
+		t.Errorf("Expected scores 20 and -13, got %d and %d", state.Players[0].Score, state.Players[1].Score)
+	}
+}
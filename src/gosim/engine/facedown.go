@@ -0,0 +1,85 @@
+package engine
+
+// Some games discard face-down: the card joins the discard pile, but only
+// the player who laid it knows what it is. The pile's face-up cards stay
+// public; a concealed card is hidden from everyone else until it leaves
+// the pile.
+
+// ConcealedDiscard is a card laid face-down on the discard pile.
+type ConcealedDiscard struct {
+	Card Card
+	Seat uint8 // Who discarded it, and so knows it
+}
+
+// DiscardFaceDown moves the card at cardIndex in playerID's hand to the
+// discard pile face-down. Only the shared pile conceals cards; with
+// personal decks the card goes to the player's own pile as usual.
+func (gs *GameState) DiscardFaceDown(playerID uint8, cardIndex int) bool {
+	if int(playerID) >= len(gs.Players) {
+		return false
+	}
+	hand := gs.Players[playerID].Hand
+	if cardIndex < 0 || cardIndex >= len(hand) {
+		return false
+	}
+	card := hand[cardIndex]
+	if !gs.PlayCard(playerID, cardIndex, LocationDiscard) {
+		return false
+	}
+	if !gs.PersonalDecks {
+		gs.ConcealedDiscards = append(gs.ConcealedDiscards, ConcealedDiscard{Card: card, Seat: playerID})
+	}
+	return true
+}
+
+// ConcealedFrom returns the cards on the discard pile that lie face-down
+// and that viewer did not discard.
+func (gs *GameState) ConcealedFrom(viewer int) []Card {
+	if len(gs.ConcealedDiscards) == 0 {
+		return nil
+	}
+	onPile := pileCounts(gs.Discard)
+	var cards []Card
+	for i := len(gs.ConcealedDiscards) - 1; i >= 0; i-- {
+		c := gs.ConcealedDiscards[i]
+		slot := cardSlot(c.Card)
+		if onPile[slot] == 0 {
+			continue
+		}
+		onPile[slot]--
+		if int(c.Seat) != viewer {
+			cards = append(cards, c.Card)
+		}
+	}
+	return cards
+}
+
+// pruneConcealed forgets face-down cards that are no longer on the discard
+// pile, as after a draw from the pile or a reshuffle, so a copy discarded
+// face-up later is not taken for one of them. The latest face-down copies
+// of a card are the ones kept.
+func (gs *GameState) pruneConcealed() {
+	if len(gs.ConcealedDiscards) == 0 {
+		return
+	}
+	onPile := pileCounts(gs.Discard)
+	keep := len(gs.ConcealedDiscards)
+	for i := len(gs.ConcealedDiscards) - 1; i >= 0; i-- {
+		c := gs.ConcealedDiscards[i]
+		if slot := cardSlot(c.Card); onPile[slot] > 0 {
+			onPile[slot]--
+			keep--
+			gs.ConcealedDiscards[keep] = c
+		}
+	}
+	gs.ConcealedDiscards = append(gs.ConcealedDiscards[:0], gs.ConcealedDiscards[keep:]...)
+}
+
+// pileCounts counts the copies of each card in pile, by cardSlot.
+func pileCounts(pile []Card) [52]int {
+	var counts [52]int
+	for _, c := range pile {
+		counts[cardSlot(c)]++
+	}
+	return counts
+}
@@ -0,0 +1,47 @@
+package engine
+
+import "testing"
+
+// TestFaceDownDiscardMasked checks a face-down discard is missing from the
+// other players' observations but not the discarder's, while a face-up
+// discard shows to everyone, and that a concealed card drawn back off the
+// pile is forgotten.
+func TestFaceDownDiscardMasked(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	ace := Card{Rank: RankAce, Suit: 0}
+	nine := Card{Rank: RankNine, Suit: 2}
+	state.Players[0].Hand = []Card{ace, nine}
+	genome := &Genome{
+		Header: &BytecodeHeader{PlayerCount: 2},
+		TurnPhases: []PhaseDescriptor{
+			{PhaseType: PhaseTypeDiscard, Data: []byte{byte(LocationDiscard), 0, 0, 0, 1, 1, 1}},
+		},
+	}
+	public := func(viewer int) []uint8 {
+		return opponentPlane(EncodeObservation(state, viewer), 2)
+	}
+
+	ApplyMove(state, &LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationDiscard}, genome)
+	state.PlayCard(0, 0, LocationDiscard) // The nine, face-up
+	if len(state.Discard) != 2 {
+		t.Fatalf("Expected both cards discarded, got %v", state.Discard)
+	}
+	if got := public(1); got[cardSlot(ace)] != 0 || got[cardSlot(nine)] != 1 {
+		t.Errorf("Expected only the face-up nine visible to player 1, got %v", got)
+	}
+	if got := public(0); got[cardSlot(ace)] != 1 || got[cardSlot(nine)] != 1 {
+		t.Errorf("Expected the discarder to see both cards, got %v", got)
+	}
+	if hidden := state.ConcealedFrom(1); len(hidden) != 1 || hidden[0] != ace {
+		t.Errorf("Expected the ace concealed from player 1, got %v", hidden)
+	}
+
+	// Drawn back and discarded face-up, the ace is public
+	state.Discard[0], state.Discard[1] = nine, ace
+	state.DrawCard(1, LocationDiscard)
+	state.PlayCard(1, 0, LocationDiscard)
+	if got := public(0); got[cardSlot(ace)] != 1 || len(state.ConcealedFrom(0)) != 0 {
+		t.Errorf("Expected the ace face-up once discarded again, got %v", got)
+	}
+}
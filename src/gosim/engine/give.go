@@ -0,0 +1,54 @@
+package engine
+
+// AppendGiveMoves adds a GivePhase's moves: one for each card in the
+// current player's hand and each opponent the phase lets them give it to,
+// plus keeping their cards when the phase is optional.
+func AppendGiveMoves(moves []LegalMove, state *GameState, phaseIdx int, give *GivePhaseData) []LegalMove {
+	hand := state.Players[state.CurrentPlayer].Hand
+	if len(hand) == 0 {
+		return moves
+	}
+	applyToTargets(state, give.Target, nil, func(recipient int) {
+		for cardIdx := range hand {
+			moves = append(moves, LegalMove{
+				PhaseIndex: phaseIdx,
+				CardIndex:  cardIdx,
+				TargetLoc:  LocationGiveTo + Location(recipient),
+			})
+		}
+	})
+	if !give.Mandatory {
+		moves = append(moves, LegalMove{
+			PhaseIndex: phaseIdx,
+			CardIndex:  MoveGivePass,
+			TargetLoc:  LocationHand,
+		})
+	}
+	return moves
+}
+
+// GiveRecipient returns the player a give move hands its card to, or -1
+// for a move that gives nothing.
+func GiveRecipient(move *LegalMove) int {
+	if move.CardIndex < 0 || move.TargetLoc < LocationGiveTo {
+		return -1
+	}
+	return int(move.TargetLoc - LocationGiveTo)
+}
+
+// ApplyGive moves the chosen card from the current player's hand to the
+// recipient's.
+func ApplyGive(state *GameState, move *LegalMove) {
+	giver := int(state.CurrentPlayer)
+	recipient := GiveRecipient(move)
+	if recipient < 0 || recipient >= int(state.NumPlayers) || recipient == giver {
+		return
+	}
+	hand := state.Players[giver].Hand
+	if move.CardIndex >= len(hand) {
+		return
+	}
+	card := hand[move.CardIndex]
+	state.Players[giver].Hand = append(hand[:move.CardIndex], hand[move.CardIndex+1:]...)
+	state.Players[recipient].Hand = append(state.Players[recipient].Hand, card)
+}
@@ -0,0 +1,76 @@
+package engine
+
+import "testing"
+
+// giveGenome returns a genome whose only phase gives a card to target.
+func giveGenome(target uint8, mandatory bool) *Genome {
+	data := []byte{target, 0}
+	if mandatory {
+		data[1] = 1
+	}
+	return &Genome{TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypeGive, Data: data}}}
+}
+
+// TestGiveToChosenOpponent checks a player can give any card to any
+// opponent, and the chosen opponent alone receives it.
+func TestGiveToChosenOpponent(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.Players[0].Hand = []Card{{Rank: RankQueen, Suit: 3}, {Rank: RankTwo, Suit: 0}}
+	genome := giveGenome(TARGET_ALL_OPPONENTS, true)
+
+	moves := GenerateLegalMoves(state, genome)
+	if len(moves) != 4 {
+		t.Fatalf("Expected a move for each of 2 cards and 2 opponents, got %v", moves)
+	}
+	pairs := make(map[[2]int]bool)
+	for _, m := range moves {
+		pairs[[2]int{m.CardIndex, GiveRecipient(&m)}] = true
+	}
+	for _, want := range [][2]int{{0, 1}, {0, 2}, {1, 1}, {1, 2}} {
+		if !pairs[want] {
+			t.Errorf("Expected a move giving card %d to player %d, got %v", want[0], want[1], moves)
+		}
+	}
+
+	give := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationGiveTo + 2}
+	ApplyMove(state, &give, genome)
+
+	if hand := state.Players[2].Hand; len(hand) != 1 || hand[0] != (Card{Rank: RankQueen, Suit: 3}) {
+		t.Errorf("Expected player 2 to receive the queen, got %v", hand)
+	}
+	if len(state.Players[1].Hand) != 0 {
+		t.Errorf("Expected player 1 to receive nothing, got %v", state.Players[1].Hand)
+	}
+	if hand := state.Players[0].Hand; len(hand) != 1 || hand[0] != (Card{Rank: RankTwo, Suit: 0}) {
+		t.Errorf("Expected player 0 to keep only the two, got %v", hand)
+	}
+	if state.CurrentPlayer != 1 {
+		t.Errorf("Expected the turn to pass to player 1, got player %d", state.CurrentPlayer)
+	}
+}
+
+// TestGiveToNextPlayer checks a fixed recipient and the option to keep
+// your cards.
+func TestGiveToNextPlayer(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.CurrentPlayer = 2
+	state.Players[2].Hand = []Card{{Rank: RankAce, Suit: 1}}
+	genome := giveGenome(TARGET_NEXT_PLAYER, false)
+
+	moves := GenerateLegalMoves(state, genome)
+	if len(moves) != 2 {
+		t.Fatalf("Expected a give and a pass, got %v", moves)
+	}
+	if GiveRecipient(&moves[0]) != 0 || moves[1].CardIndex != MoveGivePass {
+		t.Fatalf("Expected to give to player 0 or keep the card, got %v", moves)
+	}
+
+	ApplyMove(state, &moves[1], genome)
+	if len(state.Players[2].Hand) != 1 || len(state.Players[0].Hand) != 0 {
+		t.Errorf("Expected passing to keep the card, got hands %v and %v", state.Players[2].Hand, state.Players[0].Hand)
+	}
+}
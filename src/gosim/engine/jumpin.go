@@ -0,0 +1,38 @@
+package engine
+
+// JumpIn rules define which cards may be played out of turn
+const (
+	JumpInNone      uint8 = 0 // Strict turn order
+	JumpInRank      uint8 = 1 // Any card of the same rank (Snap)
+	JumpInIdentical uint8 = 2 // Same rank and suit (Uno)
+)
+
+// JumpIn is an out-of-turn play open to a player holding a card that
+// matches the one just played.
+type JumpIn struct {
+	PlayerID  int
+	CardIndex int // Index of the matching card in the player's hand
+}
+
+// JumpInCandidates returns the players who may jump in on the top card of
+// the discard pile under rule, in seat order starting after lastPlayer (who
+// played it). Each player is offered their first matching card.
+func JumpInCandidates(state *GameState, lastPlayer int, rule uint8) []JumpIn {
+	if rule == JumpInNone || len(state.Discard) == 0 {
+		return nil
+	}
+	top := state.Discard[len(state.Discard)-1]
+	n := showPlayerCount(state)
+
+	var candidates []JumpIn
+	for offset := 1; offset < n; offset++ {
+		p := (lastPlayer + offset) % n
+		for i, c := range state.Players[p].Hand {
+			if c.Rank == top.Rank && (rule == JumpInRank || c.Suit == top.Suit) {
+				candidates = append(candidates, JumpIn{PlayerID: p, CardIndex: i})
+				break
+			}
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,42 @@
+package engine
+
+import "testing"
+
+func TestJumpInCandidates(t *testing.T) {
+	state := NewGameState(4)
+	defer PutState(state)
+	state.Discard = append(state.Discard, Card{Rank: RankSeven, Suit: 0})
+	state.Players[0].Hand = append(state.Players[0].Hand, Card{Rank: RankSeven, Suit: 2})
+	state.Players[1].Hand = append(state.Players[1].Hand, Card{Rank: RankTwo, Suit: 0})
+	state.Players[2].Hand = append(state.Players[2].Hand, Card{Rank: RankNine, Suit: 1}, Card{Rank: RankSeven, Suit: 0})
+	state.Players[3].Hand = append(state.Players[3].Hand, Card{Rank: RankSeven, Suit: 1})
+
+	// Player 1 just played; polling starts with player 2 and wraps to player 0
+	candidates := JumpInCandidates(state, 1, JumpInRank)
+	want := []JumpIn{{PlayerID: 2, CardIndex: 1}, {PlayerID: 3, CardIndex: 0}, {PlayerID: 0, CardIndex: 0}}
+	if len(candidates) != len(want) {
+		t.Fatalf("Expected %d candidates, got %v", len(want), candidates)
+	}
+	for i := range want {
+		if candidates[i] != want[i] {
+			t.Errorf("Candidate %d: expected %v, got %v", i, want[i], candidates[i])
+		}
+	}
+
+	// Only the duplicate Seven matches exactly
+	candidates = JumpInCandidates(state, 1, JumpInIdentical)
+	if len(candidates) != 1 || candidates[0] != (JumpIn{PlayerID: 2, CardIndex: 1}) {
+		t.Errorf("Expected only player 2's identical card, got %v", candidates)
+	}
+
+	// The player who played is never offered a jump-in
+	for _, c := range JumpInCandidates(state, 2, JumpInRank) {
+		if c.PlayerID == 2 {
+			t.Error("Player who just played should not be a candidate")
+		}
+	}
+
+	if JumpInCandidates(state, 1, JumpInNone) != nil {
+		t.Error("Expected no candidates when jump-in is off")
+	}
+}
@@ -0,0 +1,85 @@
+package engine
+
+// NextActiveSeat returns the first seat after seat still in the game, or
+// seat itself when no other is.
+func (gs *GameState) NextActiveSeat(seat int) int {
+	return gs.nextActive(seat, showPlayerCount(gs))
+}
+
+// nextActive is NextActiveSeat around a table of n seats.
+func (gs *GameState) nextActive(seat, n int) int {
+	for i := 1; i < n; i++ {
+		if next := (seat + i) % n; gs.Players[next].Active {
+			return next
+		}
+	}
+	return seat
+}
+
+// activeSeats counts the players still in the game among the first n seats.
+func (gs *GameState) activeSeats(n int) int {
+	count := 0
+	for seat := 0; seat < n; seat++ {
+		if gs.Players[seat].Active {
+			count++
+		}
+	}
+	return count
+}
+
+// trickSeat returns the seat k places round from the trick's leader,
+// passing over players knocked out of the game.
+func (gs *GameState) trickSeat(k, n int) int {
+	seat := int(gs.TrickLeader)
+	for ; k > 0; k-- {
+		seat = gs.nextActive(seat, n)
+	}
+	return seat
+}
+
+// RoundPlayedOut reports whether every hand has been played out and the
+// last trick taken, ending a round of a knockout game.
+func RoundPlayedOut(state *GameState) bool {
+	return len(state.CurrentTrick) == 0 && handsEmpty(state)
+}
+
+// KnockOutTrickless ends a round of a knockout trick game, as in Knock-Out
+// Whist: every player still in who took no trick this hand is out of the
+// game and is dealt no more cards. It returns the number of players left.
+func KnockOutTrickless(state *GameState) int {
+	left := 0
+	for seat := 0; seat < showPlayerCount(state); seat++ {
+		p := &state.Players[seat]
+		if p.Active && p.TricksWon == 0 {
+			p.Active = false
+		}
+		if p.Active {
+			left++
+		}
+	}
+	return left
+}
+
+// KnockoutLeader returns the player still in who took the most tricks this
+// hand, ties going to the lower seat: the winner once one player is left, or
+// once the hands have shrunk away.
+func KnockoutLeader(state *GameState) int {
+	best := -1
+	for seat := 0; seat < showPlayerCount(state); seat++ {
+		p := state.Players[seat]
+		if p.Active && (best < 0 || p.TricksWon > state.Players[best].TricksWon) {
+			best = seat
+		}
+	}
+	return best
+}
+
+// ShrunkHandSize returns the cards dealt for round (0 = the first) when each
+// round deals shrink fewer than the one before, starting from start. It is
+// never below zero.
+func ShrunkHandSize(start, shrink, round int) int {
+	if size := start - shrink*round; size > 0 {
+		return size
+	}
+	return 0
+}
@@ -0,0 +1,54 @@
+package engine
+
+import "testing"
+
+// TestTrickSkipsKnockedOut checks a trick goes round only the players still
+// in, is complete once each has played, and that knocked-out players are
+// dealt nothing.
+func TestTrickSkipsKnockedOut(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.Players[1].Active = false
+	state.Players[0].Hand = []Card{{Rank: RankFive, Suit: 0}}
+	state.Players[2].Hand = []Card{{Rank: RankKing, Suit: 0}}
+	genome := &Genome{
+		TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypeTrick, Data: []byte{1, 255, 1, 255}}},
+	}
+
+	move := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationTableau}
+	ApplyMove(state, &move, genome)
+	if state.CurrentPlayer != 2 {
+		t.Fatalf("Expected seat 2 to follow past the knocked-out seat 1, got %d", state.CurrentPlayer)
+	}
+	ApplyMove(state, &move, genome)
+	if len(state.CurrentTrick) != 0 || state.Players[2].TricksWon != 1 {
+		t.Fatalf("Expected seat 2 to take a two-card trick, got %d cards in play and %d tricks", len(state.CurrentTrick), state.Players[2].TricksWon)
+	}
+	if !RoundPlayedOut(state) {
+		t.Error("Expected the round to be played out")
+	}
+
+	if left := KnockOutTrickless(state); left != 1 || state.Players[0].Active {
+		t.Errorf("Expected seat 0 knocked out for taking no trick, leaving 1 player, got %d", left)
+	}
+	if leader := KnockoutLeader(state); leader != 2 {
+		t.Errorf("Expected seat 2 to be left in, got %d", leader)
+	}
+
+	for r := uint8(0); r < 6; r++ {
+		state.Deck = append(state.Deck, Card{Rank: r, Suit: 1})
+	}
+	state.DealHands(3, 2, DealRoundRobin)
+	if len(state.Players[0].Hand) != 0 || len(state.Players[1].Hand) != 0 || len(state.Players[2].Hand) != 2 {
+		t.Errorf("Expected only seat 2 dealt, got hands of %d, %d and %d", len(state.Players[0].Hand), len(state.Players[1].Hand), len(state.Players[2].Hand))
+	}
+}
+
+func TestShrunkHandSize(t *testing.T) {
+	for round, want := range []int{7, 5, 3, 1, 0, 0} {
+		if got := ShrunkHandSize(7, 2, round); got != want {
+			t.Errorf("Round %d: expected %d cards, got %d", round, want, got)
+		}
+	}
+}
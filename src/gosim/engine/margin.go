@@ -0,0 +1,23 @@
+package engine
+
+// MarginLeader returns the player among the first numPlayers whose score
+// has reached threshold and leads every other player's by at least margin,
+// as in games played to a score that must be won by two, or -1 while
+// nobody does. Players tied for the lead lead by nothing.
+func MarginLeader(state *GameState, numPlayers int, threshold, margin int32) int {
+	leader := -1
+	for seat := 0; seat < numPlayers; seat++ {
+		if leader < 0 || state.Players[seat].Score > state.Players[leader].Score {
+			leader = seat
+		}
+	}
+	if leader < 0 || state.Players[leader].Score < threshold {
+		return -1
+	}
+	for seat := 0; seat < numPlayers; seat++ {
+		if seat != leader && state.Players[leader].Score-state.Players[seat].Score < margin {
+			return -1
+		}
+	}
+	return leader
+}
@@ -0,0 +1,42 @@
+package engine
+
+// SettleMatchHand ends a hand of a match in which each hand is scored on
+// its own. The player who gained the most over the hand wins it: in chips
+// when the match is played for chips, otherwise in score. Everyone then
+// starts the next hand level, with no score and startingChips. Call it
+// between hands, once the pot has been awarded. Returns the hand's winner,
+// or -1 for a tied hand, which nobody wins.
+func SettleMatchHand(state *GameState, startingChips int64) int {
+	players := seatsInPlay(state)
+	gain := func(p *PlayerState) int64 {
+		if startingChips > 0 {
+			return p.Chips - startingChips
+		}
+		return int64(p.Score)
+	}
+
+	winner := -1
+	var best int64
+	tied := false
+	for i := range players {
+		switch g := gain(&players[i]); {
+		case winner < 0 || g > best:
+			winner, best, tied = i, g, false
+		case g == best:
+			tied = true
+		}
+	}
+	if tied {
+		winner = -1
+	}
+	if winner >= 0 {
+		players[winner].HandsWon++
+	}
+
+	for i := range players {
+		players[i].Score = 0
+		players[i].SweepPoints = 0
+		players[i].Chips = startingChips
+	}
+	return winner
+}
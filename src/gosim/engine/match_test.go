@@ -0,0 +1,40 @@
+package engine
+
+import "testing"
+
+func TestSettleMatchHand(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+
+	// Played for chips, the biggest winner takes the hand
+	state.Players[0].Chips, state.Players[1].Chips, state.Players[2].Chips = 400, 700, 400
+	state.Players[0].Score = 9
+	if winner := SettleMatchHand(state, 500); winner != 1 {
+		t.Errorf("Expected seat 1 to win the hand, got %d", winner)
+	}
+	for i := 0; i < 3; i++ {
+		if p := state.Players[i]; p.Chips != 500 || p.Score != 0 {
+			t.Errorf("Seat %d: expected a level start, got %d chips and score %d", i, p.Chips, p.Score)
+		}
+	}
+
+	// Without chips, score decides; a tie is won by nobody
+	state.Players[0].Score, state.Players[2].Score = 12, 12
+	if winner := SettleMatchHand(state, 0); winner != -1 {
+		t.Errorf("Expected a tied hand, got winner %d", winner)
+	}
+	state.Players[2].Score = 3
+	if winner := SettleMatchHand(state, 0); winner != 2 {
+		t.Errorf("Expected seat 2 to win the hand, got %d", winner)
+	}
+
+	if won := []int32{state.Players[0].HandsWon, state.Players[1].HandsWon, state.Players[2].HandsWon}; won[0] != 0 || won[1] != 1 || won[2] != 1 {
+		t.Errorf("Expected hands won [0 1 1], got %v", won)
+	}
+	if clone := state.Clone(); clone.Players[1].HandsWon != 1 {
+		t.Error("Clone lost the hands won")
+	} else {
+		PutState(clone)
+	}
+}
@@ -0,0 +1,236 @@
+package engine
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Melds are the runs and sets rummy-style games score: a set is cards of
+// one rank, a run cards of consecutive ranks (in any suit, as the
+// HAS_RUN_OF_N condition counts them, with the Ace high).
+
+// MinMeldSize is the fewest cards that make a run or a set.
+const MinMeldSize = 3
+
+// Meld filters restrict a hand_end card scoring rule to the cards a hand
+// melds, or to those it leaves unmatched.
+const (
+	MeldAny       uint8 = 0 // Every card in hand
+	MeldMatched   uint8 = 1 // Cards in a run or set
+	MeldUnmatched uint8 = 2 // Cards in no run or set
+)
+
+// IsWildRank reports whether rank is one of the wild ranks, a mask with
+// bit r set when rank r is wild.
+func IsWildRank(wild uint16, rank uint8) bool {
+	return rank < 16 && wild&(1<<rank) != 0
+}
+
+// splitWilds returns hand's natural cards and how many wild cards it holds.
+func splitWilds(hand []Card, wild uint16) ([]Card, int) {
+	if wild == 0 {
+		return hand, 0
+	}
+	naturals := make([]Card, 0, len(hand))
+	for _, card := range hand {
+		if !IsWildRank(wild, card.Rank) {
+			naturals = append(naturals, card)
+		}
+	}
+	return naturals, len(hand) - len(naturals)
+}
+
+// HasSetOfN reports whether hand holds n cards of one rank. Wild cards
+// stand in for missing cards of the rank, but a set needs at least one
+// natural card.
+func HasSetOfN(hand []Card, n int, wild uint16) bool {
+	naturals, wilds := splitWilds(hand, wild)
+	rankCounts := make(map[uint8]int)
+	for _, card := range naturals {
+		rankCounts[card.Rank]++
+		if rankCounts[card.Rank]+wilds >= n {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRunOfN reports whether hand holds n cards of consecutive ranks. Wild
+// cards fill gaps in the run or extend it, but a run needs at least one
+// natural card.
+func HasRunOfN(hand []Card, n int, wild uint16) bool {
+	if len(hand) < n {
+		return false
+	}
+	if wild != 0 {
+		naturals, wilds := splitWilds(hand, wild)
+		var held [13]bool
+		for _, card := range naturals {
+			if int(card.Rank) < len(held) {
+				held[card.Rank] = true
+			}
+		}
+		for low := 0; low+n <= len(held); low++ {
+			missing := 0
+			for r := low; r < low+n; r++ {
+				if !held[r] {
+					missing++
+				}
+			}
+			if missing < n && missing <= wilds {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Sort by rank
+	sorted := make([]Card, len(hand))
+	copy(sorted, hand)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Rank < sorted[j].Rank
+	})
+
+	// Find sequential run
+	runLength := 1
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Rank == sorted[i-1].Rank+1 {
+			runLength++
+			if runLength >= n {
+				return true
+			}
+		} else if sorted[i].Rank != sorted[i-1].Rank {
+			// Different rank, not sequential - reset counter
+			runLength = 1
+		}
+		// Same rank = continue current run length
+	}
+	return false
+}
+
+// BestMelds arranges hand into runs and sets of at least MinMeldSize cards,
+// no card in two melds, so as to meld as many cards as possible, and
+// reports for each card whether it is melded. Cards of the wild ranks may
+// stand for any card a meld lacks, whichever rank that makes best; every
+// meld needs at least one natural card, and spare wilds lengthen a meld
+// already made.
+func BestMelds(hand []Card, wild uint16) []bool {
+	melded := make([]bool, len(hand))
+	naturals, wilds := splitWilds(hand, wild)
+	if len(hand) < MinMeldSize || len(naturals) > 64 {
+		return melded
+	}
+
+	// Natural cards in rank order; masks index into order
+	order := make([]int, 0, len(naturals))
+	for i, card := range hand {
+		if !IsWildRank(wild, card.Rank) {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return hand[order[a]].Rank < hand[order[b]].Rank
+	})
+	rank := func(pos int) uint8 { return hand[order[pos]].Rank }
+	all := uint64(1)<<len(order) - 1
+
+	type key struct {
+		used  uint64
+		wilds int
+	}
+	type result struct {
+		count int
+		melds uint64
+	}
+	memo := make(map[key]result)
+
+	// search melds the natural cards not in used with at most wilds wild
+	// cards, returning how many natural cards it can meld and which. Every
+	// meld holding the lowest unused card either is a set of its rank or a
+	// run whose lowest natural card it is.
+	var search func(used uint64, wilds int) result
+	search = func(used uint64, wilds int) result {
+		if used == all {
+			return result{}
+		}
+		k := key{used, wilds}
+		if r, ok := memo[k]; ok {
+			return r
+		}
+		first := bits.TrailingZeros64(^used)
+		firstBit := uint64(1) << first
+
+		// Leave the lowest card unmatched
+		best := search(used|firstBit, wilds)
+		try := func(meld uint64, wildsUsed int) {
+			r := search(used|meld, wilds-wildsUsed)
+			if n := r.count + bits.OnesCount64(meld); n > best.count {
+				best = result{count: n, melds: r.melds | meld}
+			}
+		}
+
+		// Sets of its rank, wilds making up the numbers
+		var same []int
+		for pos := first + 1; pos < len(order) && rank(pos) == rank(first); pos++ {
+			if used&(1<<pos) == 0 {
+				same = append(same, pos)
+			}
+		}
+		for subset := 0; subset < 1<<len(same); subset++ {
+			size := 1 + bits.OnesCount(uint(subset))
+			need := 0
+			if size < MinMeldSize {
+				need = MinMeldSize - size
+			}
+			if need > wilds {
+				continue
+			}
+			meld := firstBit
+			for i, pos := range same {
+				if subset&(1<<i) != 0 {
+					meld |= 1 << pos
+				}
+			}
+			try(meld, need)
+		}
+
+		// Runs upward from it, one card of each rank, wilds filling gaps
+		// or standing for the ranks below it
+		var extend func(meld uint64, top uint8, length, wildsUsed int)
+		extend = func(meld uint64, top uint8, length, wildsUsed int) {
+			if length >= MinMeldSize {
+				try(meld, wildsUsed)
+			}
+			if int(top)+1 >= len(DefaultRankValues) {
+				return
+			}
+			for pos := first + 1; pos < len(order); pos++ {
+				if rank(pos) == top+1 && used&(1<<pos) == 0 {
+					extend(meld|1<<pos, top+1, length+1, wildsUsed)
+				}
+			}
+			if wildsUsed < wilds {
+				extend(meld, top+1, length+1, wildsUsed+1)
+			}
+		}
+		for below := 0; below <= wilds && below <= int(rank(first)); below++ {
+			extend(firstBit, rank(first), 1+below, below)
+		}
+
+		memo[k] = best
+		return best
+	}
+
+	melds := search(0, wilds).melds
+	for pos, i := range order {
+		melded[i] = melds&(1<<pos) != 0
+	}
+	if melds != 0 {
+		for i, card := range hand {
+			if IsWildRank(wild, card.Rank) {
+				melded[i] = true
+			}
+		}
+	}
+	return melded
+}
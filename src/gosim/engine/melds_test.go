@@ -0,0 +1,84 @@
+package engine
+
+import "testing"
+
+// TestBestMelds checks the arrangement melds the most cards: taking the
+// three fives as a set would strand both sixes and sevens, while two runs
+// meld all but one five.
+func TestBestMelds(t *testing.T) {
+	hand := []Card{
+		{Rank: RankFive, Suit: 0}, {Rank: RankFive, Suit: 1}, {Rank: RankFive, Suit: 2},
+		{Rank: RankSix, Suit: 0}, {Rank: RankSeven, Suit: 0},
+		{Rank: RankSix, Suit: 1}, {Rank: RankSeven, Suit: 1},
+	}
+	melded := BestMelds(hand, 0)
+	count := 0
+	for _, m := range melded {
+		if m {
+			count++
+		}
+	}
+	if count != 6 {
+		t.Errorf("Expected two runs melding 6 cards, got %v", melded)
+	}
+
+	// Two cards of a rank are no set
+	if melded := BestMelds(hand[:2], 0); melded[0] || melded[1] {
+		t.Errorf("Expected a pair to stay unmatched, got %v", melded)
+	}
+}
+
+// TestHandEndMeldScoring checks a run held at the end scores while the
+// unmatched cards count against the hand.
+func TestHandEndMeldScoring(t *testing.T) {
+	hand := []Card{
+		{Rank: RankEight, Suit: 0}, {Rank: RankNine, Suit: 3}, {Rank: RankTen, Suit: 0},
+		{Rank: RankTwo, Suit: 1}, {Rank: RankKing, Suit: 2},
+	}
+	scoring := []CardScoringRule{
+		{Suit: 255, Rank: 255, Points: 5, Trigger: TriggerHandEnd, Meld: MeldMatched},
+		{Suit: 255, Rank: 255, Points: -2, Trigger: TriggerHandEnd, Meld: MeldUnmatched},
+	}
+	if got := HandPenaltyValue(hand, scoring, nil, nil, 0); got != 3*5-2*2 {
+		t.Errorf("Expected 15 for the run less 4 for the two and king, got %d", got)
+	}
+	if got := RevealValue(hand[3:], scoring, nil, nil, 0); got != -4 {
+		t.Errorf("Expected -4 without the run, got %d", got)
+	}
+}
+
+// TestWildMelds checks a wild fills the gap in a run and makes up a set,
+// and that it stands for whichever rank melds the most cards.
+func TestWildMelds(t *testing.T) {
+	wild := uint16(1) << RankTwo
+	joker := Card{Rank: RankTwo, Suit: 3}
+
+	gap := []Card{{Rank: RankFive, Suit: 0}, joker, {Rank: RankSeven, Suit: 1}}
+	if !HasRunOfN(gap, 3, wild) || HasRunOfN(gap, 3, 0) {
+		t.Error("Expected 5-wild-7 to count as a run only with twos wild")
+	}
+	pair := []Card{{Rank: RankNine, Suit: 0}, {Rank: RankNine, Suit: 1}, joker}
+	if !HasSetOfN(pair, 3, wild) || HasSetOfN(pair, 3, 0) {
+		t.Error("Expected a pair and a wild to count as a set only with twos wild")
+	}
+	if HasSetOfN([]Card{joker, joker, joker}, 3, wild) {
+		t.Error("Expected a set to need a natural card")
+	}
+
+	// The wild could make a set of fours or stand for the five in 4-5-6-7;
+	// the run melds more
+	hand := []Card{
+		{Rank: RankFour, Suit: 0}, {Rank: RankFour, Suit: 1},
+		{Rank: RankSix, Suit: 0}, {Rank: RankSeven, Suit: 2}, joker,
+	}
+	melded := BestMelds(hand, wild)
+	if melded[0] == melded[1] || !melded[2] || !melded[3] || !melded[4] {
+		t.Errorf("Expected 4-wild-6-7 melded and one four unmatched, got %v", melded)
+	}
+
+	// A wild below the lowest card runs king-ace down to the queen
+	top := []Card{{Rank: RankKing, Suit: 0}, {Rank: RankAce, Suit: 0}, joker}
+	if melded := BestMelds(top, wild); !melded[0] || !melded[1] || !melded[2] {
+		t.Errorf("Expected wild-K-A melded, got %v", melded)
+	}
+}
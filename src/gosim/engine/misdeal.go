@@ -0,0 +1,47 @@
+package engine
+
+// Starting hands thrown in as a misdeal (see IsMisdeal).
+const (
+	MisdealNone        uint8 = 0 // Every deal stands
+	MisdealNoFaceCards uint8 = 1 // No jack, queen or king
+	MisdealNoSuit      uint8 = 2 // No card of the given suit, such as no trumps
+	MisdealAllBelow    uint8 = 3 // Every card ranks below the given rank
+)
+
+// IsMisdeal reports whether hand is unplayable under condition, with rank
+// and suit the condition's parameters.
+func IsMisdeal(hand []Card, condition, rank, suit uint8) bool {
+	if condition == MisdealNone {
+		return false
+	}
+	for _, c := range hand {
+		switch condition {
+		case MisdealNoFaceCards:
+			if c.Rank >= RankJack && c.Rank <= RankKing {
+				return false
+			}
+		case MisdealNoSuit:
+			if c.Suit == suit {
+				return false
+			}
+		case MisdealAllBelow:
+			if c.Rank >= rank {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// HasMisdeal reports whether any seated player still in the game was dealt
+// a hand IsMisdeal throws in.
+func HasMisdeal(state *GameState, condition, rank, suit uint8) bool {
+	for seat := 0; seat < showPlayerCount(state); seat++ {
+		if state.Players[seat].Active && IsMisdeal(state.Players[seat].Hand, condition, rank, suit) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package engine
+
+import "testing"
+
+func TestIsMisdeal(t *testing.T) {
+	low := []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankTen, Suit: 1}, {Rank: RankAce, Suit: 1}}
+	tests := []struct {
+		condition, rank, suit uint8
+		hand                  []Card
+		want                  bool
+	}{
+		{MisdealNone, 0, 0, low, false},
+		{MisdealNoFaceCards, 0, 0, low, true},
+		{MisdealNoFaceCards, 0, 0, append([]Card{{Rank: RankQueen, Suit: 3}}, low...), false},
+		{MisdealNoSuit, 0, 3, low, true},
+		{MisdealNoSuit, 0, 1, low, false},
+		{MisdealAllBelow, RankJack, 0, low, false}, // The ace is high
+		{MisdealAllBelow, RankJack, 0, low[:2], true},
+	}
+	for _, tt := range tests {
+		if got := IsMisdeal(tt.hand, tt.condition, tt.rank, tt.suit); got != tt.want {
+			t.Errorf("IsMisdeal(%v, %d, %d, %d) = %v, want %v", tt.hand, tt.condition, tt.rank, tt.suit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package engine
+
+// MoonShotRule is Hearts' "shooting the moon": a player, or a team, who
+// takes every card the trick scoring values turns the penalty around.
+type MoonShotRule struct {
+	Points int32 // Added to every other player's score (0 = the points the shooter took)
+}
+
+// moonOwner is who a seat's captures count for: its team when teams are
+// configured, otherwise the seat itself.
+func moonOwner(state *GameState, seat int) int {
+	if state.TeamScores != nil && seat < len(state.PlayerToTeam) && state.PlayerToTeam[seat] >= 0 {
+		return int(state.PlayerToTeam[seat])
+	}
+	return seat
+}
+
+// ShootTheMoon checks the captured piles once the last trick of a hand is
+// won. If every scoring card taken went to one player, or one team, the
+// shooters give back the points those cards scored and every other player
+// scores rule.Points instead. Reports whether the moon was shot.
+func ShootTheMoon(state *GameState, genome *Genome, breakingSuit uint8, rule *MoonShotRule) bool {
+	n := showPlayerCount(state)
+	shooter := -1
+	taken := make([]int32, n)
+	total := int32(0)
+	for seat := 0; seat < n; seat++ {
+		for _, card := range state.Players[seat].Captured {
+			points := trickCardPoints(card, genome, breakingSuit)
+			if points == 0 {
+				continue
+			}
+			owner := moonOwner(state, seat)
+			if shooter >= 0 && owner != shooter {
+				return false
+			}
+			shooter = owner
+			taken[seat] += points
+			total += points
+		}
+	}
+	if shooter < 0 {
+		return false
+	}
+
+	award := rule.Points
+	if award == 0 {
+		award = total
+	}
+	for seat := 0; seat < n; seat++ {
+		delta := award
+		if moonOwner(state, seat) == shooter {
+			delta = -taken[seat]
+		}
+		state.Players[seat].Score += delta
+		UpdateTeamScore(state, seat, delta)
+	}
+	return true
+}
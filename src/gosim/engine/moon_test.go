@@ -0,0 +1,56 @@
+package engine
+
+import "testing"
+
+// TestShootTheMoonHearts plays the last trick of a Hearts hand in which
+// player 0 has taken every heart and the queen of spades: the 26 points
+// turn into 26 for each opponent.
+func TestShootTheMoonHearts(t *testing.T) {
+	genome := &Genome{
+		Header: &BytecodeHeader{PlayerCount: 4},
+		CardScoring: []CardScoringRule{
+			{Suit: 0, Rank: 255, Points: 1, Trigger: TriggerTrickWin},
+			{Suit: 3, Rank: RankQueen, Points: 13, Trigger: TriggerTrickWin},
+		},
+		MoonShot: &MoonShotRule{},
+	}
+	phase := PhaseDescriptor{PhaseType: PhaseTypeTrick, Data: []byte{1, 255, 1, 0}}
+
+	setup := func() *GameState {
+		state := NewGameState(4)
+		state.NumPlayers = 4
+		for r := uint8(RankTwo); r < RankAce; r++ {
+			state.Players[0].Captured = append(state.Players[0].Captured, Card{Rank: r, Suit: 0})
+		}
+		state.Players[0].Captured = append(state.Players[0].Captured, Card{Rank: RankQueen, Suit: 3})
+		state.Players[0].Score = 25
+		state.CurrentTrick = append(state.CurrentTrick,
+			TrickCard{PlayerID: 0, Card: Card{Rank: RankAce, Suit: 0}},
+			TrickCard{PlayerID: 1, Card: Card{Rank: RankTwo, Suit: 2}},
+			TrickCard{PlayerID: 2, Card: Card{Rank: RankThree, Suit: 2}},
+			TrickCard{PlayerID: 3, Card: Card{Rank: RankFour, Suit: 2}},
+		)
+		return state
+	}
+
+	state := setup()
+	defer PutState(state)
+	resolveTrick(state, genome, phase)
+	want := []int32{0, 26, 26, 26}
+	for seat, score := range want {
+		if state.Players[seat].Score != score {
+			t.Errorf("Expected scores %v after the moon shot, player %d has %d", want, seat, state.Players[seat].Score)
+		}
+	}
+
+	// One heart taken by another player and the points stand
+	missed := setup()
+	defer PutState(missed)
+	missed.Players[1].Captured = append(missed.Players[1].Captured, missed.Players[0].Captured[0])
+	missed.Players[0].Captured = missed.Players[0].Captured[1:]
+	missed.Players[0].Score, missed.Players[1].Score = 24, 1
+	resolveTrick(missed, genome, phase)
+	if missed.Players[1].Score != 1 || missed.Players[0].Score != 25 {
+		t.Errorf("Expected a missed moon to score as usual, got %d and %d", missed.Players[0].Score, missed.Players[1].Score)
+	}
+}
@@ -0,0 +1,28 @@
+package engine
+
+// DefaultMaxLegalMoves caps the moves offered for one decision for genomes
+// that set no cap of their own. Real card games rarely offer more than a
+// hundred; a genome offering thousands would have MCTS expand a node for
+// every one.
+const DefaultMaxLegalMoves = 512
+
+// CapMoves bounds the moves offered for one decision to limit (0 =
+// DefaultMaxLegalMoves). Past the cap an evenly spaced sample of limit moves
+// is kept, in their original order, so every phase stays represented and
+// the sample is the same on every run; the decision is counted in
+// state.MovesCapped as a sign of a degenerate genome. The sample is copied
+// out so the oversized slice can be freed.
+func CapMoves(state *GameState, moves []LegalMove, limit int) []LegalMove {
+	if limit <= 0 {
+		limit = DefaultMaxLegalMoves
+	}
+	if len(moves) <= limit {
+		return moves
+	}
+	state.MovesCapped++
+	sample := make([]LegalMove, limit)
+	for i := range sample {
+		sample[i] = moves[i*len(moves)/limit]
+	}
+	return sample
+}
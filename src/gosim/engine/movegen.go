@@ -1,6 +1,9 @@
 package engine
 
-import "encoding/binary"
+import (
+	"encoding/binary"
+	"math"
+)
 
 // UpdateTeamScore updates the team score when a player scores.
 // This should be called whenever a player's score changes.
@@ -39,6 +42,31 @@ const (
 	MovePlayPass = -4 // Pass/skip playing (used in President when can't beat top card)
 )
 
+// Special CardIndex values for ShowPhase
+const (
+	MoveShow = -5 // Reveal all hands and compare
+)
+
+// Special CardIndex values for repeating phases
+const (
+	MoveEndRepeat = -6 // Stop repeating the current phase and end the turn
+)
+
+// Special CardIndex values for PeekPhase
+const (
+	MovePeek = -7 // Look at the target's hand
+)
+
+// Special CardIndex values for GivePhase
+const (
+	MoveGivePass = -8 // Keep your cards (optional give phases only)
+)
+
+// Special CardIndex values for TradePhase
+const (
+	MoveTradePass = -9 // Make no offer
+)
+
 // Special CardIndex values for BettingPhase
 const (
 	MoveBettingCheck = -10
@@ -56,6 +84,18 @@ const (
 	MoveBidOffset = -50 // CardIndex = -(bid_value + 50)
 )
 
+// A bid that names trump carries the suit in TargetLoc as
+// LocationTrumpBid + suit.
+const LocationTrumpBid Location = 100
+
+// A give move carries the recipient in TargetLoc as LocationGiveTo +
+// player ID.
+const LocationGiveTo Location = 110
+
+// A trade offer carries the player it is made to in TargetLoc as
+// LocationTradeWith + player ID.
+const LocationTradeWith Location = 120
+
 // LegalMove represents a possible action
 type LegalMove struct {
 	PhaseIndex int
@@ -94,21 +134,32 @@ func GenerateLegalMoves(state *GameState, genome *Genome) []LegalMove {
 				}
 			}
 
+			// A draw past the turn or game limit is withdrawn
+			if !DrawAllowed(state, phase.DrawLimit, genome.MaxDrawsPerGame) {
+				continue
+			}
+
 			// Check if can draw, with automatic deck reshuffling
 			canDraw := false
-			switch source {
-			case LocationDeck:
+			switch {
+			case source == LocationDeck && state.PersonalDecks:
+				// DrawCard reshuffles the player's own discards when needed
+				canDraw = CanDrawOwn(state, currentPlayer)
+			case source == LocationDeck:
 				// If deck is empty but discard has cards, reshuffle discard into deck
 				if len(state.Deck) == 0 && len(state.Discard) > 1 {
 					reshuffleDeck(state)
 				}
 				canDraw = len(state.Deck) > 0
-			case LocationDiscard:
-				canDraw = len(state.Discard) > 0
-			case LocationOpponentHand:
+			case source == LocationDiscard:
+				canDraw = len(*state.discardPile(currentPlayer)) > 0
+			case source == LocationOpponentHand:
 				// Pick next player for N-player support
 				opponentID := (currentPlayer + 1) % state.NumPlayers
 				canDraw = len(state.Players[opponentID].Hand) > 0
+			case source == LocationOpponentDiscard && state.PersonalDecks:
+				opponentID := (currentPlayer + 1) % state.NumPlayers
+				canDraw = len(state.Players[opponentID].Discard) > 0
 			}
 
 			if canDraw {
@@ -197,7 +248,7 @@ func GenerateLegalMoves(state *GameState, genome *Genome) []LegalMove {
 						for _, pile := range state.Tableau {
 							if len(pile) > 0 {
 								topCard := pile[len(pile)-1]
-								if isValidSequencePlay(card, topCard, state.SequenceDirection) {
+								if isValidSequencePlay(card, topCard, state.SequenceDirection, state.AceMode) {
 									canPlayOnExisting = true
 									break
 								}
@@ -247,6 +298,12 @@ func GenerateLegalMoves(state *GameState, genome *Genome) []LegalMove {
 							continue // Card doesn't satisfy condition
 						}
 					}
+					if target == LocationPiles {
+						var added int
+						moves, added = AppendPilePlays(moves, state, phaseIdx, cardIdx, card)
+						playMoveCount += added
+						continue
+					}
 					moves = append(moves, LegalMove{
 						PhaseIndex: phaseIdx,
 						CardIndex:  cardIdx,
@@ -259,7 +316,7 @@ func GenerateLegalMoves(state *GameState, genome *Genome) []LegalMove {
 			// Multi-card plays (Go Fish sets)
 			// When min_cards > 1, we need a complete set of matching rank
 			// CardIndex encodes the rank to play (all cards of that rank)
-			if minCards > 1 {
+			if minCards > 1 && target != LocationPiles {
 				// Count cards by rank
 				rankCounts := make(map[uint8]int)
 				for _, card := range hand {
@@ -306,9 +363,6 @@ func GenerateLegalMoves(state *GameState, genome *Genome) []LegalMove {
 			if len(phase.Data) < 4 {
 				continue
 			}
-			leadSuitRequired := phase.Data[0] == 1
-			// trumpSuit := phase.Data[1]  // 255 = none
-			// highCardWins := phase.Data[2] == 1
 			breakingSuit := phase.Data[3] // 255 = none
 
 			hand := state.Players[currentPlayer].Hand
@@ -344,49 +398,15 @@ func GenerateLegalMoves(state *GameState, genome *Genome) []LegalMove {
 					})
 				}
 			} else {
-				// Following: must follow suit if able
-				leadSuit := state.CurrentTrick[0].Card.Suit
-
-				if leadSuitRequired {
-					// Check if we have cards of lead suit
-					hasLeadSuit := false
-					for _, card := range hand {
-						if card.Suit == leadSuit {
-							hasLeadSuit = true
-							break
-						}
-					}
-
-					if hasLeadSuit {
-						// Must follow suit
-						for cardIdx, card := range hand {
-							if card.Suit == leadSuit {
-								moves = append(moves, LegalMove{
-									PhaseIndex: phaseIdx,
-									CardIndex:  cardIdx,
-									TargetLoc:  LocationTableau,
-								})
-							}
-						}
-					} else {
-						// Can't follow suit - can play any card
-						for cardIdx := range hand {
-							moves = append(moves, LegalMove{
-								PhaseIndex: phaseIdx,
-								CardIndex:  cardIdx,
-								TargetLoc:  LocationTableau,
-							})
-						}
-					}
-				} else {
-					// No suit following required - can play any card
-					for cardIdx := range hand {
-						moves = append(moves, LegalMove{
-							PhaseIndex: phaseIdx,
-							CardIndex:  cardIdx,
-							TargetLoc:  LocationTableau,
-						})
-					}
+				// Following: must follow suit if able, and meet any
+				// must-beat or must-overtrump constraint
+				rules := ParseTrickRules(state, phase.Data)
+				for _, cardIdx := range rules.Follows(hand, state.CurrentTrick, state.AceMode) {
+					moves = append(moves, LegalMove{
+						PhaseIndex: phaseIdx,
+						CardIndex:  cardIdx,
+						TargetLoc:  LocationTableau,
+					})
 				}
 			}
 
@@ -434,16 +454,7 @@ func GenerateLegalMoves(state *GameState, genome *Genome) []LegalMove {
 			if state.CurrentClaim == nil {
 				// No active claim - current player makes a claim
 				// For simplicity, play 1 card at a time
-				hand := state.Players[currentPlayer].Hand
-				if len(hand) > 0 {
-					for cardIdx := range hand {
-						moves = append(moves, LegalMove{
-							PhaseIndex: phaseIdx,
-							CardIndex:  cardIdx,
-							TargetLoc:  LocationDiscard, // Cards go face-down to discard
-						})
-					}
-				}
+				moves = AppendClaims(moves, state, phaseIdx, claimRankRule(phase.Data))
 			} else {
 				// Active claim exists - opponent responds
 				if currentPlayer != state.CurrentClaim.ClaimerID {
@@ -486,6 +497,8 @@ func GenerateLegalMoves(state *GameState, genome *Genome) []LegalMove {
 				targetLoc := LocationDeck // Default, unused
 				if bid.IsNil {
 					targetLoc = LocationDiscard // Use as marker for Nil
+				} else if bid.NamesTrump {
+					targetLoc = LocationTrumpBid + Location(bid.Trump)
 				}
 				moves = append(moves, LegalMove{
 					PhaseIndex: phaseIdx,
@@ -493,10 +506,53 @@ func GenerateLegalMoves(state *GameState, genome *Genome) []LegalMove {
 					TargetLoc:  targetLoc,
 				})
 			}
+
+		case PhaseTypeShow:
+			if ShowDue(state) {
+				moves = append(moves, LegalMove{
+					PhaseIndex: phaseIdx,
+					CardIndex:  MoveShow,
+					TargetLoc:  LocationDeck, // Unused but required
+				})
+			}
+
+		case PhaseTypePeek:
+			peek, err := ParsePeekPhaseData(phase.Data)
+			if err == nil && PeekDue(state, peek.Target) {
+				moves = append(moves, LegalMove{
+					PhaseIndex: phaseIdx,
+					CardIndex:  MovePeek,
+					TargetLoc:  LocationHand, // Unused but required
+				})
+			}
+
+		case PhaseTypeDrawDiscard:
+			if dd, err := ParseDrawDiscardPhaseData(phase.Data); err == nil {
+				moves = AppendDrawDiscardMoves(moves, state, phaseIdx, dd)
+			}
+
+		case PhaseTypeGive:
+			if give, err := ParseGivePhaseData(phase.Data); err == nil {
+				moves = AppendGiveMoves(moves, state, phaseIdx, give)
+			}
+
+		case PhaseTypeTrade:
+			if trade, err := ParseTradePhaseData(phase.Data); err == nil {
+				moves = AppendTradeMoves(moves, state, phaseIdx, trade)
+			}
 		}
 	}
 
-	return moves
+	// Partway through a repeating phase, only that phase can continue
+	if state.PhaseRuns > 0 && state.RepeatPhase < len(genome.TurnPhases) {
+		if repeat := genome.TurnPhases[state.RepeatPhase].Repeat; repeat != nil {
+			moves = RepeatMoves(moves, state.RepeatPhase, repeat)
+		}
+	}
+	// Between a draw-discard's draw and discard, only the discard is left
+	moves = PendingDiscardMoves(state, moves)
+
+	return CapMoves(state, moves, genome.MaxLegalMoves)
 }
 
 // ApplyMove executes a legal move, mutating state
@@ -512,6 +568,9 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 	case 1: // DrawPhase
 		// MoveDrawPass (-3) = stand/pass, mark player as stood (for Blackjack-style games)
 		// MoveDraw (-1) = hit/draw
+		if move.CardIndex == MoveDraw {
+			state.noteDraw()
+		}
 		if move.CardIndex == MoveDraw && len(phase.Data) >= 5 {
 			count := int(binary.BigEndian.Uint32(phase.Data[1:5]))
 			for i := 0; i < count; i++ {
@@ -550,12 +609,14 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 				}
 				state.ConsecutivePasses = 0
 			}
-		} else if move.CardIndex >= 0 {
+		} else if move.CardIndex >= 0 && move.CardIndex < len(state.Players[currentPlayer].Hand) {
 			// Single-card play - reset pass counter
 			state.ConsecutivePasses = 0
 
 			playedCard := state.Players[currentPlayer].Hand[move.CardIndex]
-			state.PlayCard(currentPlayer, move.CardIndex, move.TargetLoc)
+			if state.PlayCard(currentPlayer, move.CardIndex, move.TargetLoc) {
+				state.notePlayed(playedCard)
+			}
 
 			if move.TargetLoc == LocationTableau {
 				// Use explicit TableauMode switch for clarity
@@ -563,11 +624,11 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 				case 0: // NONE
 					// No special handling - card just sits on tableau
 				case 1: // WAR
-					// War-style battle: compare ranks, winner takes both
-					resolveWarBattle(state)
+					// War-style battle: compare ranks, winner takes all
+					resolveWarBattle(state, currentPlayer)
 				case 2: // MATCH_RANK
 					// Scopa-style capture: match by rank
-					resolveMatchRankCapture(state, currentPlayer, playedCard)
+					resolveMatchRankCapture(state, currentPlayer, playedCard, genome)
 				case 3: // SEQUENCE
 					// Sequence validation done in move generation; card just added to pile
 					// No additional resolution needed here
@@ -577,7 +638,7 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 			// Check for special effect after playing a card
 			if genome != nil && genome.Effects != nil {
 				if effect, ok := genome.Effects[playedCard.Rank]; ok {
-					ApplyEffect(state, &effect, nil) // nil RNG for now
+					ResolveCardEffect(state, &effect, genome)
 				}
 			}
 		} else if move.CardIndex <= -100 {
@@ -596,6 +657,9 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 				}
 			}
 			state.Players[currentPlayer].Hand = newHand
+			for _, card := range cardsToPlay {
+				state.notePlayed(card)
+			}
 
 			// Play cards to target location
 			switch move.TargetLoc {
@@ -604,24 +668,40 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 				// Score point for completing a set (Go Fish scoring)
 				state.Players[currentPlayer].Score++
 				UpdateTeamScore(state, int(currentPlayer), 1)
+				for _, card := range cardsToPlay {
+					state.noteScored(card)
+				}
 			case LocationTableau:
 				if len(state.Tableau) == 0 {
 					state.Tableau = make([][]Card, 1)
 				}
 				state.Tableau[0] = append(state.Tableau[0], cardsToPlay...)
+			case LocationExposed:
+				// Laid down face up as a meld, scored at the end of the game
+				player := &state.Players[currentPlayer]
+				player.Exposed = append(player.Exposed, cardsToPlay...)
 			}
 
 			// Check for special effect after playing cards (multi-card play)
 			if genome != nil && genome.Effects != nil {
 				if effect, ok := genome.Effects[targetRank]; ok {
-					ApplyEffect(state, &effect, nil) // nil RNG for now
+					ResolveCardEffect(state, &effect, genome)
 				}
 			}
 		}
 
 	case 3: // DiscardPhase
+		// Data layout (typed genomes): target:1, count:4, mandatory:1, face_down:1
 		if move.CardIndex >= 0 {
-			state.PlayCard(currentPlayer, move.CardIndex, LocationDiscard)
+			target := LocationDiscard
+			if move.TargetLoc == LocationBurned {
+				target = LocationBurned
+			}
+			if target == LocationDiscard && len(phase.Data) > 6 && phase.Data[6] == 1 {
+				state.DiscardFaceDown(currentPlayer, move.CardIndex)
+			} else {
+				state.PlayCard(currentPlayer, move.CardIndex, target)
+			}
 		}
 
 	case 4: // TrickPhase
@@ -634,11 +714,18 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 				state.Players[currentPlayer].Hand[move.CardIndex+1:]...,
 			)
 
+			// Whoever plays to an empty trick leads it. That is the previous
+			// winner or the opening leader, unless another phase passed the turn
+			if len(state.CurrentTrick) == 0 {
+				state.TrickLeader = currentPlayer
+			}
+
 			// Add to current trick
 			state.CurrentTrick = append(state.CurrentTrick, TrickCard{
 				PlayerID: currentPlayer,
 				Card:     card,
 			})
+			state.notePlayed(card)
 
 			// Check if this card breaks hearts (or other breaking suit)
 			if len(phase.Data) >= 4 {
@@ -653,11 +740,19 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 			if numPlayers == 0 {
 				numPlayers = 2 // Default to 2 players
 			}
-			if len(state.CurrentTrick) >= numPlayers {
+			if len(state.CurrentTrick) >= state.activeSeats(numPlayers) {
 				// Resolve trick
 				resolveTrick(state, genome, phase)
 				return // Don't advance turn normally - resolveTrick sets next player
 			}
+
+			// The trick goes round from its leader, passing over players
+			// knocked out; a trick card always ends the turn, so no repeat
+			// carries over
+			state.PhaseRuns = 0
+			state.CurrentPlayer = uint8(state.trickSeat(len(state.CurrentTrick), numPlayers))
+			state.TurnNumber++
+			return
 		}
 
 	case 5: // BettingPhase
@@ -687,9 +782,12 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 
 				// Add to discard pile (face-down conceptually)
 				state.Discard = append(state.Discard, card)
+				state.notePlayed(card)
 
-				// Create claim - claimed rank is sequential based on turn number
-				claimedRank := uint8(state.TurnNumber % 13) // A, 2, 3, ..., K, A, 2, ...
+				// Create claim - the move names its rank, or it is sequential
+				// based on turn number
+				claimedRank := ClaimedRank(state, move)
+				state.LastClaimRank = int8(claimedRank)
 				state.CurrentClaim = &Claim{
 					ClaimerID:    currentPlayer,
 					ClaimedRank:  claimedRank,
@@ -723,6 +821,10 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 			isNil := move.TargetLoc == LocationDiscard // Nil marker
 
 			bid := BidMove{Value: bidValue, IsNil: isNil}
+			if move.TargetLoc >= LocationTrumpBid {
+				bid.Trump = uint8(move.TargetLoc - LocationTrumpBid)
+				bid.NamesTrump = true
+			}
 			ApplyBidMove(state, int(currentPlayer), bid)
 
 			// Don't advance turn for bidding - round continues until all players bid
@@ -731,6 +833,61 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 			state.TurnNumber++
 			return
 		}
+
+	case PhaseTypeShow:
+		if move.CardIndex == MoveShow {
+			show, err := ParseShowPhaseData(phase.Data)
+			if err != nil {
+				show = &ShowPhaseData{Award: ShowAwardPot}
+			}
+			ResolveShow(state, genome.HandEval, show)
+			// The reveal is not a player's turn - the same player acts next
+			state.TurnNumber++
+			return
+		}
+
+	case PhaseTypePeek:
+		if move.CardIndex == MovePeek {
+			if peek, err := ParsePeekPhaseData(phase.Data); err == nil {
+				ApplyPeek(state, peek)
+			}
+			// Looking is not a player's turn - the same player acts next
+			state.TurnNumber++
+			return
+		}
+
+	case PhaseTypeDrawDiscard:
+		dd, err := ParseDrawDiscardPhaseData(phase.Data)
+		if err == nil && ApplyDrawDiscard(state, move, dd) {
+			// The draw keeps the turn - the same player discards next
+			state.TurnNumber++
+			return
+		}
+
+	case PhaseTypeGive:
+		ApplyGive(state, move)
+
+	case PhaseTypeTrade:
+		// The offer is answered by the greedy reply; a simulation that
+		// polls the recipient's AI applies the trade itself and passes
+		trade, err := ParseTradePhaseData(phase.Data)
+		if recipient := TradeRecipient(move); err == nil && recipient >= 0 && move.CardIndex < len(state.Players[currentPlayer].Hand) {
+			card := state.Players[currentPlayer].Hand[move.CardIndex]
+			if accept, giveBack := TradeReply(state, recipient, card, trade.Price); accept {
+				ApplyTrade(state, move, trade.Price, giveBack)
+			}
+		}
+	}
+
+	// A repeating phase keeps the turn until the repeat is done
+	if continueRepeat(state, move, phase.Repeat) {
+		state.TurnNumber++
+		return
+	}
+
+	// A play phase that keeps hands full draws replacements once it ends
+	if phase.PhaseType == PhaseTypePlay && phase.RefillTo > 0 {
+		refillHand(state, currentPlayer, phase.RefillTo)
 	}
 
 	// Advance turn
@@ -738,6 +895,10 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 	if state.NumPlayers == 0 {
 		state.CurrentPlayer = 1 - currentPlayer // Fallback for 2 players
 	}
+	if state.CurrentPlayer != currentPlayer {
+		state.EffectsThisTurn = 0
+		state.DrawsTurnCount = 0
+	}
 	state.TurnNumber++
 }
 
@@ -746,119 +907,88 @@ func ApplyMove(state *GameState, move *LegalMove, genome *Genome) {
 // falls back to implicit Hearts scoring for backwards compatibility.
 func calculateTrickPoints(state *GameState, genome *Genome, breakingSuit uint8) int32 {
 	points := int32(0)
+	for _, tc := range state.CurrentTrick {
+		points += trickCardPoints(tc.Card, genome, breakingSuit)
+	}
+	return points
+}
+
+// trickCardPoints returns what one card in a won trick is worth.
+func trickCardPoints(card Card, genome *Genome, breakingSuit uint8) int32 {
+	points := int32(0)
 
 	// Use explicit scoring rules if available
 	if len(genome.CardScoring) > 0 {
-		for _, tc := range state.CurrentTrick {
-			for _, rule := range genome.CardScoring {
-				if rule.Trigger != TriggerTrickWin {
-					continue
-				}
-				// Check if card matches condition
-				suitMatch := rule.Suit == 255 || rule.Suit == tc.Card.Suit
-				rankMatch := rule.Rank == 255 || rule.Rank == tc.Card.Rank
-				if suitMatch && rankMatch {
-					points += int32(rule.Points)
-				}
+		for _, rule := range genome.CardScoring {
+			if rule.Trigger != TriggerTrickWin {
+				continue
+			}
+			// Check if card matches condition
+			suitMatch := rule.Suit == 255 || rule.Suit == card.Suit
+			rankMatch := rule.Rank == 255 || rule.Rank == card.Rank
+			if suitMatch && rankMatch {
+				points += int32(rule.Points)
 			}
 		}
 		return points
 	}
 
 	// Fallback to implicit Hearts scoring for backwards compatibility
-	for _, tc := range state.CurrentTrick {
-		if breakingSuit != 255 && tc.Card.Suit == breakingSuit {
-			points++ // Each breaking suit card = 1 point
-		}
-		// Queen of Spades = 13 points in Hearts
-		if tc.Card.Suit == 3 && tc.Card.Rank == 10 { // Spades (3), Queen (10)
-			points += 13
-		}
+	if breakingSuit != 255 && card.Suit == breakingSuit {
+		points++ // Each breaking suit card = 1 point
+	}
+	// Queen of Spades = 13 points in Hearts
+	if card.Suit == 3 && card.Rank == 10 { // Spades (3), Queen (10)
+		points += 13
 	}
-
 	return points
 }
 
+// Trick tie rules decide between two identical winning cards. Bytecode trick
+// phases are 4 bytes and always use TrickTieFirstPlayed; the rule is read
+// from an optional fifth byte.
+const (
+	TrickTieFirstPlayed uint8 = 0 // The earlier card keeps the trick
+	TrickTieLastPlayed  uint8 = 1 // The later card takes the trick
+)
+
+// outranks reports whether a card of rank beats the current winner of
+// winningRank when both are of the suit that decides the trick. Equal ranks
+// in one suit only happen with duplicate cards, and tieRule settles them.
+func outranks(rank, winningRank int, highCardWins bool, tieRule uint8) bool {
+	if rank == winningRank {
+		return tieRule == TrickTieLastPlayed
+	}
+	if highCardWins {
+		return rank > winningRank
+	}
+	return rank < winningRank
+}
+
 // resolveTrick determines the winner and scores points
 func resolveTrick(state *GameState, genome *Genome, phase PhaseDescriptor) {
 	if len(state.CurrentTrick) == 0 {
 		return
 	}
 
-	// Parse phase data
-	trumpSuit := uint8(255) // None
-	highCardWins := true
-	breakingSuit := uint8(255)
-	if len(phase.Data) >= 4 {
-		trumpSuit = phase.Data[1]
-		highCardWins = phase.Data[2] == 1
-		breakingSuit = phase.Data[3]
-	}
-
-	leadSuit := state.CurrentTrick[0].Card.Suit
-	winnerIdx := 0
-	winningCard := state.CurrentTrick[0].Card
-
-	for i := 1; i < len(state.CurrentTrick); i++ {
-		tc := state.CurrentTrick[i]
-		card := tc.Card
-
-		// Determine if this card beats the current winner
-		beats := false
-
-		if trumpSuit != 255 {
-			// Trump game rules
-			winnerIsTrump := winningCard.Suit == trumpSuit
-			cardIsTrump := card.Suit == trumpSuit
-
-			if cardIsTrump && !winnerIsTrump {
-				// Trump beats non-trump
-				beats = true
-			} else if cardIsTrump && winnerIsTrump {
-				// Both trump - compare ranks
-				if highCardWins {
-					beats = card.Rank > winningCard.Rank
-				} else {
-					beats = card.Rank < winningCard.Rank
-				}
-			} else if !cardIsTrump && !winnerIsTrump && card.Suit == leadSuit {
-				// Neither trump - must follow suit to win
-				if winningCard.Suit == leadSuit {
-					if highCardWins {
-						beats = card.Rank > winningCard.Rank
-					} else {
-						beats = card.Rank < winningCard.Rank
-					}
-				} else {
-					// Current winner didn't follow suit, this card does
-					beats = true
-				}
-			}
-		} else {
-			// No trump - only lead suit counts
-			if card.Suit == leadSuit {
-				if winningCard.Suit != leadSuit {
-					beats = true
-				} else if highCardWins {
-					beats = card.Rank > winningCard.Rank
-				} else {
-					beats = card.Rank < winningCard.Rank
-				}
-			}
-		}
-
-		if beats {
-			winnerIdx = i
-			winningCard = card
-		}
-	}
+	rules := ParseTrickRules(state, phase.Data)
+	breakingSuit := rules.BreakingSuit
+	winnerIdx := rules.winner(state.CurrentTrick, state.AceMode)
 
 	winner := state.CurrentTrick[winnerIdx].PlayerID
+	leader := rules.nextLeader(state, winnerIdx)
 
 	// Calculate and award points for trick
 	points := calculateTrickPoints(state, genome, breakingSuit)
 	state.Players[winner].Score += points
 	UpdateTeamScore(state, int(winner), points)
+	for _, tc := range state.CurrentTrick {
+		state.noteCaptured(tc.Card)
+		state.Players[winner].Captured = append(state.Players[winner].Captured, tc.Card)
+		if trickCardPoints(tc.Card, genome, breakingSuit) != 0 {
+			state.noteScored(tc.Card)
+		}
+	}
 
 	// Track tricks won
 	if len(state.TricksWon) <= int(winner) {
@@ -868,43 +998,93 @@ func resolveTrick(state *GameState, genome *Genome, phase PhaseDescriptor) {
 		}
 	}
 	state.TricksWon[winner]++
+	// Per-player count for contract scoring (reset each hand); partners'
+	// counts are pooled by TeamTricksWon
+	if state.Players[winner].TricksWon < math.MaxInt8 {
+		state.Players[winner].TricksWon++
+	}
 
-	// Clear current trick
+	// Remember the trick, then clear it
+	played := make([]Card, len(state.CurrentTrick))
+	for i, tc := range state.CurrentTrick {
+		played[i] = tc.Card
+	}
+	state.PlayedTricks = append(state.PlayedTricks, played)
 	state.CurrentTrick = state.CurrentTrick[:0]
 
-	// Winner leads next trick
-	state.CurrentPlayer = winner
-	state.TrickLeader = winner
+	// The hand is over once the last trick is won
+	if genome != nil && genome.MoonShot != nil && handsEmpty(state) {
+		ShootTheMoon(state, genome, breakingSuit, genome.MoonShot)
+	}
+
+	// The winner, or whoever the phase names, leads the next trick
+	state.CurrentPlayer = leader
+	state.TrickLeader = leader
 	state.TurnNumber++
 }
 
-// resolveWarBattle handles War game card comparison
-func resolveWarBattle(state *GameState) {
-	// Check if both players have played (tableau has 2 cards)
-	if len(state.Tableau) == 0 || len(state.Tableau[0]) < 2 {
+// handsEmpty reports whether every player has played out their hand.
+func handsEmpty(state *GameState) bool {
+	for seat := 0; seat < showPlayerCount(state); seat++ {
+		if len(state.Players[seat].Hand) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// War tie rules: how a battle tied for the high card is settled.
+const (
+	WarTieAlternate   uint8 = 0 // The tied players take turns winning, by battle number
+	WarTieFirstPlayed uint8 = 1 // The first of the tied cards played wins
+	WarTieCarryOver   uint8 = 2 // The cards stay for the next battle's winner ("war")
+)
+
+// resolveWarBattle handles War game card comparison once every player has
+// played to the battle, mover last. Players play in seat order, so each
+// card's seat follows from its place in the battle.
+func resolveWarBattle(state *GameState, mover uint8) {
+	n := int(state.NumPlayers)
+	if n < 2 || len(state.Tableau) == 0 || len(state.Tableau[0]) == 0 || len(state.Tableau[0])%n != 0 {
 		return
 	}
 
 	tableau := state.Tableau[0]
-	card1 := tableau[len(tableau)-2] // Second-to-last card (player 0's card)
-	card2 := tableau[len(tableau)-1] // Last card (player 1's card)
-
-	// Compare ranks (Ace high: A=12, K=11, ..., 2=0)
-	var winner uint8
-	if card1.Rank > card2.Rank {
-		winner = 0
-	} else if card2.Rank > card1.Rank {
-		winner = 1
-	} else {
-		// Tie - alternate who wins ties based on battle number
-		// Each battle takes 2 turns, so divide TurnNumber by 2
-		battleNum := state.TurnNumber / 2
-		winner = uint8(battleNum % 2)
+	battle := tableau[len(tableau)-n:]
+
+	// Compare ranks (A=12, K=11, ..., 2=0; the Ace drops below 2 when ace-low),
+	// listing the seats holding the high card in the order they played
+	var tied []uint8
+	high := 0
+	for k, card := range battle {
+		seat := uint8((int(mover) - (n - 1 - k) + n) % n)
+		rank := RankValue(card.Rank, state.AceMode)
+		if k == 0 || rank > high {
+			high, tied = rank, tied[:0]
+		}
+		if rank == high {
+			tied = append(tied, seat)
+		}
+	}
+
+	winner := tied[0]
+	if len(tied) > 1 {
+		switch state.WarTieRule {
+		case WarTieFirstPlayed:
+			// The first tied card keeps its place
+		case WarTieCarryOver:
+			return
+		default:
+			// Each battle takes one turn per player
+			battleNum := int(state.TurnNumber) / n
+			winner = tied[battleNum%len(tied)]
+		}
 	}
 
 	// Winner takes all cards from tableau
 	for _, card := range tableau {
 		state.Players[winner].Hand = append(state.Players[winner].Hand, card)
+		state.noteCaptured(card)
 	}
 
 	// Clear tableau
@@ -912,8 +1092,9 @@ func resolveWarBattle(state *GameState) {
 }
 
 // resolveMatchRankCapture handles rank-matching capture (Scopa-style)
-// When playing a card to tableau, capture any card with matching rank
-func resolveMatchRankCapture(state *GameState, playerID uint8, playedCard Card) {
+// When playing a card to tableau, capture any card with matching rank. A
+// capture that leaves the tableau empty also earns the genome's sweep bonus.
+func resolveMatchRankCapture(state *GameState, playerID uint8, playedCard Card, genome *Genome) {
 	if len(state.Tableau) == 0 || len(state.Tableau[0]) == 0 {
 		return
 	}
@@ -945,17 +1126,53 @@ func resolveMatchRankCapture(state *GameState, playerID uint8, playedCard Card)
 			state.Tableau[0] = state.Tableau[0][:len(state.Tableau[0])-1]
 		}
 
-		// Score captures (each captured card = 1 point)
-		state.Players[playerID].Score += 2 // Both captured card and played card
-		UpdateTeamScore(state, int(playerID), 2)
+		// Both cards go to the player's captured pile. Unless the captured
+		// piles are scored by category at the end, each card is a point now.
+		player := &state.Players[playerID]
+		player.Captured = append(player.Captured, capturedCard, playedCard)
+		perCard := genome == nil || len(genome.CaptureCategories) == 0
+		if perCard {
+			player.Score += 2
+			UpdateTeamScore(state, int(playerID), 2)
+		}
+		for _, card := range []Card{capturedCard, playedCard} {
+			state.noteCaptured(card)
+			if perCard {
+				state.noteScored(card)
+			}
+		}
 
-		// For a more complete Scopa implementation, we'd track captured cards
-		// in a separate pile, but for scoring purposes, just increment Score
-		_ = capturedCard // Used for capture
+		if len(state.Tableau[0]) == 0 {
+			if bonus := sweepPoints(playedCard, genome); bonus != 0 {
+				state.Players[playerID].Score += bonus
+				state.Players[playerID].SweepPoints += bonus
+				UpdateTeamScore(state, int(playerID), bonus)
+			}
+		}
 	}
 	// If no match, played card stays on tableau (already added by PlayCard)
 }
 
+// sweepPoints returns the bonus for clearing the table with card, from the
+// genome's sweep scoring rules.
+func sweepPoints(card Card, genome *Genome) int32 {
+	if genome == nil {
+		return 0
+	}
+	points := int32(0)
+	for _, rule := range genome.CardScoring {
+		if rule.Trigger != TriggerSweep {
+			continue
+		}
+		suitMatch := rule.Suit == 255 || rule.Suit == card.Suit
+		rankMatch := rule.Rank == 255 || rule.Rank == card.Rank
+		if suitMatch && rankMatch {
+			points += int32(rule.Points)
+		}
+	}
+	return points
+}
+
 // setWinnerWithTeam sets the winner ID and also sets WinningTeam if teams are configured.
 // Returns the winner ID for convenience in return statements.
 func setWinnerWithTeam(state *GameState, winnerID int8) int8 {
@@ -990,6 +1207,12 @@ func CheckWinConditions(state *GameState, genome *Genome) int8 {
 				}
 			}
 		case 1: // high_score (highest score wins, triggers when anyone reaches threshold)
+			if wc.Margin > 0 {
+				if winner := MarginLeader(state, numPlayers, wc.Threshold, wc.Margin); winner >= 0 {
+					return setWinnerWithTeam(state, int8(winner))
+				}
+				continue
+			}
 			maxScore := int32(-1)
 			winner := int8(-1)
 			triggered := false
@@ -1007,6 +1230,12 @@ func CheckWinConditions(state *GameState, genome *Genome) int8 {
 				return setWinnerWithTeam(state, winner)
 			}
 		case 2: // first_to_score
+			if wc.Margin > 0 {
+				if winner := MarginLeader(state, numPlayers, wc.Threshold, wc.Margin); winner >= 0 {
+					return setWinnerWithTeam(state, int8(winner))
+				}
+				continue
+			}
 			for playerID := 0; playerID < numPlayers; playerID++ {
 				if state.Players[playerID].Score >= wc.Threshold {
 					return setWinnerWithTeam(state, int8(playerID))
@@ -1134,9 +1363,32 @@ func resolveChallenge(state *GameState, challengerID uint8) {
 		state.Players[loserID].Hand = append(state.Players[loserID].Hand, card)
 	}
 	state.Discard = state.Discard[:0]
+	state.pruneConcealed()
 
-	// Clear the claim
+	// Clear the claim; the next claim may name any rank again
 	state.CurrentClaim = nil
+	state.LastClaimRank = -1
+}
+
+// refillHand draws from the deck until the player holds size cards,
+// reshuffling the discards in when the deck runs out. Once both are used up
+// the player plays on with a short hand.
+func refillHand(state *GameState, playerID uint8, size int) {
+	for len(state.Players[playerID].Hand) < size {
+		if !drawFromDeck(state, playerID) {
+			return
+		}
+	}
+}
+
+// drawFromDeck draws playerID a card off the deck they draw from, first
+// reshuffling the discards in if it has run out. DrawCard reshuffles a
+// personal deck itself.
+func drawFromDeck(state *GameState, playerID uint8) bool {
+	if !state.PersonalDecks && len(state.Deck) == 0 {
+		reshuffleDeck(state)
+	}
+	return state.DrawCard(playerID, LocationDeck)
 }
 
 // reshuffleDeck moves all discard cards except the top one into the deck and shuffles.
@@ -1153,6 +1405,7 @@ func reshuffleDeck(state *GameState) {
 	}
 	state.Discard = state.Discard[:1]
 	state.Discard[0] = topCard
+	state.pruneConcealed()
 
 	// Shuffle the deck using turn number as seed for determinism
 	state.ShuffleDeck(uint64(state.TurnNumber))
@@ -1162,38 +1415,26 @@ func reshuffleDeck(state *GameState) {
 // Rules:
 // - Cards must match suit
 // - Direction determines valid ranks:
-//   - ASCENDING (0): card.Rank must be exactly topCard.Rank + 1
-//   - DESCENDING (1): card.Rank must be exactly topCard.Rank - 1
+//   - ASCENDING (0): card must be exactly one rank above topCard
+//   - DESCENDING (1): card must be exactly one rank below topCard
 //   - BOTH (2): either direction is valid
 //
-// No wrapping: K (13) can't go to A (14) in ascending, 2 can't go to A in descending.
-// Rank encoding: 2-10 are face value, 11=J, 12=Q, 13=K, 14=A (Ace high)
-// Valid ascending range: 2 -> 3 -> ... -> 13 (K is the end, can't go to A which is 14)
-// Valid descending range: 13 -> 12 -> ... -> 2 (2 is the end)
-func isValidSequencePlay(card Card, topCard Card, direction uint8) bool {
+// The ace mode decides whether the Ace continues Q-K-A (high), starts A-2 (low),
+// or both. Sequences never wrap from K through A to 2.
+func isValidSequencePlay(card Card, topCard Card, direction uint8, aceMode AceMode) bool {
 	// Must match suit
 	if card.Suit != topCard.Suit {
 		return false
 	}
 
 	switch direction {
-	case 0: // ASCENDING - card must be exactly 1 rank higher
-		// King (13) is the highest in ascending sequences - can't go to Ace (14)
-		if topCard.Rank == 13 {
-			return false // K is the end of ascending sequence
-		}
-		return card.Rank == topCard.Rank+1
-	case 1: // DESCENDING - card must be exactly 1 rank lower
-		// 2 is the lowest in descending sequences - can't go lower
-		if topCard.Rank == 2 {
-			return false // 2 is the end of descending sequence
-		}
-		return card.Rank == topCard.Rank-1
+	case 0: // ASCENDING
+		return RanksAdjacent(topCard.Rank, card.Rank, aceMode)
+	case 1: // DESCENDING
+		return RanksAdjacent(card.Rank, topCard.Rank, aceMode)
 	case 2: // BOTH - either direction is valid
-		// Apply both boundary checks
-		canAscend := topCard.Rank != 13 && card.Rank == topCard.Rank+1
-		canDescend := topCard.Rank != 2 && card.Rank == topCard.Rank-1
-		return canAscend || canDescend
+		return RanksAdjacent(topCard.Rank, card.Rank, aceMode) ||
+			RanksAdjacent(card.Rank, topCard.Rank, aceMode)
 	}
 	return false
 }
@@ -1202,15 +1443,25 @@ func isValidSequencePlay(card Card, topCard Card, direction uint8) bool {
 type BidMove struct {
 	Value int
 	IsNil bool
+	// Suit the bidder names as trump, if NamesTrump
+	Trump      uint8
+	NamesTrump bool
 }
 
 // ApplyBidMove applies a bid from a player and checks if bidding is complete.
-// When all players have bid, it sets BiddingComplete and calculates TeamContracts.
+// When all players have bid, it sets BiddingComplete, calculates TeamContracts
+// and gives the kitty to the highest bidder.
+// A bid naming trump that beats every earlier bid makes its suit trump.
 func ApplyBidMove(state *GameState, playerIdx int, bid BidMove) {
 	if playerIdx < 0 || playerIdx >= len(state.Players) {
 		return
 	}
 
+	if bid.NamesTrump && !bid.IsNil && bid.Value > highestBid(state) {
+		state.Trump = bid.Trump
+		state.TrumpNamed = true
+	}
+
 	// Set player's bid
 	state.Players[playerIdx].CurrentBid = int8(bid.Value)
 	state.Players[playerIdx].IsNilBid = bid.IsNil
@@ -1227,9 +1478,22 @@ func ApplyBidMove(state *GameState, playerIdx int, bid BidMove) {
 	if allBid {
 		state.BiddingComplete = true
 		calculateTeamContracts(state)
+		TakeKitty(state)
 	}
 }
 
+// highestBid returns the highest non-Nil bid made so far, or -1 if none.
+func highestBid(state *GameState) int {
+	highest := -1
+	for i := 0; i < int(state.NumPlayers); i++ {
+		p := &state.Players[i]
+		if p.CurrentBid >= 0 && !p.IsNilBid && int(p.CurrentBid) > highest {
+			highest = int(p.CurrentBid)
+		}
+	}
+	return highest
+}
+
 // calculateTeamContracts sums non-Nil bids for each team.
 // Call after all players have bid.
 func calculateTeamContracts(state *GameState) {
@@ -1276,9 +1540,15 @@ func GenerateBidMoves(phase BiddingPhase, handSize int) []BidMove {
 		moves = append(moves, BidMove{Value: 0, IsNil: true})
 	}
 
-	// Generate valid bid range
+	// Generate valid bid range, naming each suit if the bid names trump
 	for bid := phase.MinBid; bid <= effectiveMax; bid++ {
-		moves = append(moves, BidMove{Value: bid, IsNil: false})
+		if !phase.DeclareTrump {
+			moves = append(moves, BidMove{Value: bid, IsNil: false})
+			continue
+		}
+		for suit := uint8(0); suit < 4; suit++ {
+			moves = append(moves, BidMove{Value: bid, Trump: suit, NamesTrump: true})
+		}
 	}
 
 	return moves
@@ -38,6 +38,45 @@ func TestApplyMoveTableauModeNone(t *testing.T) {
 	}
 }
 
+// TestApplyMoveZeroValueCard verifies that hands are compact: the zero-value
+// card is the 2 of hearts, not an empty slot, and plays like any other card
+func TestApplyMoveZeroValueCard(t *testing.T) {
+	state := NewGameState(2)
+	state.NumPlayers = 2
+	state.Players[0].Hand = []Card{{}, {Rank: 5, Suit: 1}}
+	state.Players[1].Hand = []Card{{Rank: 7, Suit: 2}}
+	genome := minimalPlayPhaseGenome()
+
+	state.CurrentPlayer = 0
+	move := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationDiscard}
+	ApplyMove(state, &move, genome)
+
+	hand := state.Players[0].Hand
+	if len(hand) != 1 || hand[0] != (Card{Rank: 5, Suit: 1}) {
+		t.Errorf("Expected only the 7 of diamonds left in hand, got %v", hand)
+	}
+	if len(state.Discard) != 1 || state.Discard[0] != (Card{}) {
+		t.Errorf("Expected the 2 of hearts on the discard pile, got %v", state.Discard)
+	}
+}
+
+// TestApplyMoveCardIndexOutOfRange verifies that a stale card index leaves
+// the hand alone instead of panicking
+func TestApplyMoveCardIndexOutOfRange(t *testing.T) {
+	state := NewGameState(2)
+	state.NumPlayers = 2
+	state.Players[0].Hand = []Card{{Rank: 5, Suit: 1}}
+	genome := minimalPlayPhaseGenome()
+
+	state.CurrentPlayer = 0
+	move := LegalMove{PhaseIndex: 0, CardIndex: 1, TargetLoc: LocationTableau}
+	ApplyMove(state, &move, genome)
+
+	if len(state.Players[0].Hand) != 1 {
+		t.Errorf("Expected hand to be unchanged, got %v", state.Players[0].Hand)
+	}
+}
+
 // TestApplyMoveTableauModeWar verifies War-style battle resolution where
 // higher rank wins and takes both cards to hand
 func TestApplyMoveTableauModeWar(t *testing.T) {
@@ -172,6 +211,73 @@ func TestApplyMoveTableauModeWarTie(t *testing.T) {
 	}
 }
 
+// TestApplyMoveTableauModeWarFourPlayers verifies a 4-player battle in which
+// three players tie for the high card, under each tie rule
+func TestApplyMoveTableauModeWarFourPlayers(t *testing.T) {
+	genome := minimalPlayPhaseGenome()
+	move := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationTableau}
+	battle := func(state *GameState, ranks ...uint8) {
+		for seat, rank := range ranks {
+			state.CurrentPlayer = uint8(seat)
+			state.Players[seat].Hand = []Card{{Rank: rank, Suit: uint8(seat)}}
+			ApplyMove(state, &move, genome)
+		}
+	}
+	newState := func(rule uint8) *GameState {
+		state := NewGameState(4)
+		state.TableauMode = 1 // WAR
+		state.NumPlayers = 4
+		state.WarTieRule = rule
+		state.TurnNumber = 4 // The second battle
+		state.Tableau = [][]Card{{}}
+		return state
+	}
+
+	tests := []struct {
+		name   string
+		rule   uint8
+		winner int
+	}{
+		{"alternate", WarTieAlternate, 2}, // Battle 1 goes to the second tied player
+		{"first played", WarTieFirstPlayed, 1},
+	}
+	for _, tt := range tests {
+		state := newState(tt.rule)
+		battle(state, RankNine, RankKing, RankKing, RankKing)
+		if len(state.Tableau[0]) != 0 {
+			t.Errorf("%s: expected the battle to clear the tableau, got %d cards", tt.name, len(state.Tableau[0]))
+		}
+		for seat := 0; seat < 4; seat++ {
+			want := 0
+			if seat == tt.winner {
+				want = 4
+			}
+			if got := len(state.Players[seat].Hand); got != want {
+				t.Errorf("%s: expected player %d to hold %d cards, got %d", tt.name, seat, want, got)
+			}
+		}
+	}
+
+	// War: the tied battle stays on the tableau for the next one's winner
+	state := newState(WarTieCarryOver)
+	battle(state, RankNine, RankKing, RankKing, RankKing)
+	if len(state.Tableau[0]) != 4 {
+		t.Fatalf("Expected the tied battle to stay on the tableau, got %d cards", len(state.Tableau[0]))
+	}
+	battle(state, RankTwo, RankThree, RankFour, RankAce)
+	if len(state.Players[3].Hand) != 8 || len(state.Tableau[0]) != 0 {
+		t.Errorf("Expected player 3 to take both battles, got %d cards with %d left on the tableau",
+			len(state.Players[3].Hand), len(state.Tableau[0]))
+	}
+
+	// Nothing is decided until every player has played
+	state = newState(WarTieAlternate)
+	battle(state, RankNine, RankKing, RankTwo)
+	if len(state.Tableau[0]) != 3 {
+		t.Errorf("Expected 3 cards waiting on the tableau, got %d", len(state.Tableau[0]))
+	}
+}
+
 // TestApplyMoveTableauModeMatchRankNoMatch verifies that when there's no
 // matching card, the played card stays on the tableau
 func TestApplyMoveTableauModeMatchRankNoMatch(t *testing.T) {
@@ -202,6 +308,38 @@ func TestApplyMoveTableauModeMatchRankNoMatch(t *testing.T) {
 	}
 }
 
+// TestApplyMoveMatchRankSweepBonus verifies that a capture which clears the
+// tableau earns the sweep bonus, tracked apart from the card points, and a
+// capture that leaves cards behind does not
+func TestApplyMoveMatchRankSweepBonus(t *testing.T) {
+	genome := minimalPlayPhaseGenome()
+	genome.CardScoring = []CardScoringRule{{Suit: 255, Rank: 255, Points: 3, Trigger: TriggerSweep}}
+
+	for _, tc := range []struct {
+		name      string
+		tableau   []Card
+		wantScore int32
+		wantSweep int32
+	}{
+		{"clears table", []Card{{Rank: 7, Suit: 1}}, 5, 3},
+		{"leaves cards", []Card{{Rank: 7, Suit: 1}, {Rank: 9, Suit: 2}}, 2, 0},
+	} {
+		state := NewGameState(2)
+		state.TableauMode = 2 // MATCH_RANK
+		state.Tableau = [][]Card{append([]Card(nil), tc.tableau...)}
+		state.Players[0].Hand = []Card{{Rank: 7, Suit: 0}}
+
+		move := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationTableau}
+		ApplyMove(state, &move, genome)
+
+		if state.Players[0].Score != tc.wantScore || state.Players[0].SweepPoints != tc.wantSweep {
+			t.Errorf("%s: expected score %d with %d sweep points, got %d with %d",
+				tc.name, tc.wantScore, tc.wantSweep, state.Players[0].Score, state.Players[0].SweepPoints)
+		}
+		PutState(state)
+	}
+}
+
 // TestSequenceModeEmptyTableau verifies that on empty tableau any card is playable
 func TestSequenceModeEmptyTableau(t *testing.T) {
 	state := NewGameState(2)
@@ -376,91 +514,79 @@ func TestSequenceModeBoth(t *testing.T) {
 	}
 }
 
-// TestSequenceModeBoundaryKing verifies that K can't go higher in ascending mode
+// TestSequenceModeBoundaryKing verifies Q-K-A runs when the Ace is high
 func TestSequenceModeBoundaryKing(t *testing.T) {
+	queen := Card{Rank: RankQueen, Suit: 0}
+	king := Card{Rank: RankKing, Suit: 0}
+	ace := Card{Rank: RankAce, Suit: 0}
+	two := Card{Rank: RankTwo, Suit: 0}
+
+	if !isValidSequencePlay(king, queen, 0, AceHigh) || !isValidSequencePlay(ace, king, 0, AceHigh) {
+		t.Errorf("Q-K-A should be a valid ascending sequence with Ace high")
+	}
+	if !isValidSequencePlay(ace, king, 0, AceBoth) {
+		t.Errorf("Ace should be playable on King with Ace both")
+	}
+	if isValidSequencePlay(ace, king, 0, AceLow) {
+		t.Errorf("Ace should NOT be playable on King with Ace low")
+	}
+	// No wrapping from K through A to 2
+	if isValidSequencePlay(two, king, 0, AceBoth) {
+		t.Errorf("2 should never follow King")
+	}
+
+	// Move generation picks the ace mode up from the state
 	state := NewGameState(2)
 	state.TableauMode = 3       // SEQUENCE
 	state.SequenceDirection = 0 // ASCENDING
 	state.NumPlayers = 2
-
-	// Tableau has King (rank 13) - can't go higher
-	state.Tableau = make([][]Card, 4)
-	state.Tableau[0] = []Card{{Rank: 13, Suit: 0}} // King of spades
-	state.Tableau[1] = []Card{}
-	state.Tableau[2] = []Card{}
-	state.Tableau[3] = []Card{}
-
-	// Player has Ace (rank 14 in our system) - can't play on King in ascending
-	state.Players[0].Hand = []Card{
-		{Rank: 14, Suit: 0}, // Ace of spades
-	}
+	state.Tableau = [][]Card{{king}}
+	state.Players[0].Hand = []Card{ace}
 	state.CurrentPlayer = 0
 
-	genome := sequencePhaseGenome()
-	moves := GenerateLegalMoves(state, genome)
-
-	// Count moves that play Ace on the spade pile specifically (should be 0)
-	// The Ace can start a new pile on empty slots, but shouldn't wrap around King
-	aceOnKingPile := false
-	for _, m := range moves {
-		if m.TargetLoc == LocationTableau {
-			card := state.Players[0].Hand[m.CardIndex]
-			// For sequence mode with pile-specific targeting, this would need to check
-			// if the move is specifically for pile 0. For now, check that we have
-			// at least some moves (Ace can start new piles on empty slots)
-			if card.Rank == 14 && card.Suit == 0 {
-				// This is tricky - the current design may not track which pile
-				// Let's just verify the helper function rejects King->Ace
-				_ = card
-			}
-		}
+	moves := GenerateLegalMoves(state, sequencePhaseGenome())
+	if !hasCardPlay(moves, 0) {
+		t.Errorf("Expected Ace playable on King with Ace high, got moves %v", moves)
 	}
 
-	// Verify the helper function directly
-	kingCard := Card{Rank: 13, Suit: 0}
-	aceCard := Card{Rank: 14, Suit: 0}
-
-	if isValidSequencePlay(aceCard, kingCard, 0) { // ASCENDING
-		aceOnKingPile = true
-	}
-
-	if aceOnKingPile {
-		t.Errorf("Ace should NOT be playable on King in ASCENDING mode (no wrapping)")
+	state.AceMode = AceLow
+	moves = GenerateLegalMoves(state, sequencePhaseGenome())
+	if hasCardPlay(moves, 0) {
+		t.Errorf("Expected no Ace play on King with Ace low, got moves %v", moves)
 	}
 }
 
-// TestSequenceModeBoundaryAce verifies that A can't go lower in descending mode
+// TestSequenceModeBoundaryAce verifies A-2-3 runs when the Ace is low
 func TestSequenceModeBoundaryAce(t *testing.T) {
-	state := NewGameState(2)
-	state.TableauMode = 3       // SEQUENCE
-	state.SequenceDirection = 1 // DESCENDING
-	state.NumPlayers = 2
-
-	// For ranks where 2 is the lowest playable rank
-	// Ace (14) is high, so descending from 2 has no valid play
+	ace := Card{Rank: RankAce, Suit: 0}
+	two := Card{Rank: RankTwo, Suit: 0}
+	three := Card{Rank: 1, Suit: 0}
 
-	// Tableau has 2 (rank 2) - can't go lower
-	state.Tableau = make([][]Card, 4)
-	state.Tableau[0] = []Card{{Rank: 2, Suit: 0}} // 2 of spades
-	state.Tableau[1] = []Card{}
-	state.Tableau[2] = []Card{}
-	state.Tableau[3] = []Card{}
-
-	// Player has Ace - in typical card games, Ace is high (14)
-	// So descending from 2 would need rank 1, which doesn't exist
-	state.Players[0].Hand = []Card{
-		{Rank: 14, Suit: 0}, // Ace of spades (high)
+	if !isValidSequencePlay(two, ace, 0, AceLow) || !isValidSequencePlay(three, two, 0, AceLow) {
+		t.Errorf("A-2-3 should be a valid ascending sequence with Ace low")
 	}
-	state.CurrentPlayer = 0
-
-	// Verify the helper function directly - 2 descending has no valid lower card
-	twoCard := Card{Rank: 2, Suit: 0}
-	aceCard := Card{Rank: 14, Suit: 0}
+	if !isValidSequencePlay(ace, two, 1, AceLow) {
+		t.Errorf("Ace should be playable below 2 descending with Ace low")
+	}
+	if !isValidSequencePlay(ace, two, 1, AceBoth) {
+		t.Errorf("Ace should be playable below 2 descending with Ace both")
+	}
+	if isValidSequencePlay(ace, two, 1, AceHigh) {
+		t.Errorf("Ace should NOT be playable below 2 with Ace high")
+	}
+	if isValidSequencePlay(two, ace, 0, AceHigh) {
+		t.Errorf("2 should NOT follow Ace with Ace high")
+	}
+}
 
-	// Ace (14) is not rank 1, so it shouldn't be valid descending from 2
-	if isValidSequencePlay(aceCard, twoCard, 1) { // DESCENDING
-		t.Errorf("Ace (rank 14) should NOT be valid descending from 2 (would need rank 1)")
+// hasCardPlay reports whether moves contain a play of the given hand index
+func hasCardPlay(moves []LegalMove, cardIndex int) bool {
+	for _, m := range moves {
+		if m.CardIndex == cardIndex && m.TargetLoc == LocationTableau {
+			return true
+		}
 	}
+	return false
 }
 
 // TestSequenceModeSuitMatching verifies that cards must match suit
@@ -482,11 +608,11 @@ func TestSequenceModeSuitMatching(t *testing.T) {
 	eightHearts := Card{Rank: 8, Suit: 1}
 	eightSpades := Card{Rank: 8, Suit: 0}
 
-	if isValidSequencePlay(eightHearts, sevenSpades, 0) {
+	if isValidSequencePlay(eightHearts, sevenSpades, 0, AceHigh) {
 		t.Errorf("8 of hearts should NOT be valid on 7 of spades (wrong suit)")
 	}
 
-	if !isValidSequencePlay(eightSpades, sevenSpades, 0) {
+	if !isValidSequencePlay(eightSpades, sevenSpades, 0, AceHigh) {
 		t.Errorf("8 of spades SHOULD be valid on 7 of spades (same suit, ascending)")
 	}
 }
@@ -1441,3 +1567,227 @@ func TestApplyBidMoveNoTeams(t *testing.T) {
 		t.Errorf("TeamContracts should be empty for non-team game")
 	}
 }
+
+func TestGenerateBidMovesDeclareTrump(t *testing.T) {
+	phase := BiddingPhase{MinBid: 1, MaxBid: 13, AllowNil: true, DeclareTrump: true}
+
+	moves := GenerateBidMoves(phase, 3)
+
+	// Nil, then bids 1-3 each naming one of the 4 suits
+	if len(moves) != 13 {
+		t.Fatalf("Expected 13 moves, got %d", len(moves))
+	}
+	if !moves[0].IsNil || moves[0].NamesTrump {
+		t.Errorf("Expected a Nil bid not to name trump")
+	}
+	for _, m := range moves[1:] {
+		if !m.NamesTrump {
+			t.Errorf("Expected bid %d to name trump", m.Value)
+		}
+	}
+}
+
+// TestBidWinnerDeclaresTrump checks the highest bidder's suit becomes trump
+// and changes who takes a trick that the phase's own trump would decide.
+func TestBidWinnerDeclaresTrump(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+
+	// Player 1 outbids players 0 and 2; the tie with player 1 keeps hearts
+	ApplyBidMove(state, 0, BidMove{Value: 2, Trump: 1, NamesTrump: true})
+	ApplyBidMove(state, 1, BidMove{Value: 4, Trump: 0, NamesTrump: true})
+	ApplyBidMove(state, 2, BidMove{Value: 4, Trump: 2, NamesTrump: true})
+	if !state.TrumpNamed || state.Trump != 0 {
+		t.Fatalf("Expected the highest bidder's hearts to be trump, got %d (named %v)", state.Trump, state.TrumpNamed)
+	}
+
+	// Diamonds led, a spade and a low heart played; the phase names spades trump
+	phase := PhaseDescriptor{PhaseType: PhaseTypeTrick, Data: []byte{1, 3, 1, 255}}
+	genome := &Genome{Header: &BytecodeHeader{PlayerCount: 3}}
+	trick := []TrickCard{
+		{PlayerID: 0, Card: Card{Rank: RankAce, Suit: 1}},
+		{PlayerID: 1, Card: Card{Rank: RankTwo, Suit: 0}},
+		{PlayerID: 2, Card: Card{Rank: RankKing, Suit: 3}},
+	}
+
+	state.CurrentTrick = append(state.CurrentTrick[:0], trick...)
+	resolveTrick(state, genome, phase)
+	if state.TrickLeader != 1 {
+		t.Errorf("Expected the declared hearts trump to win, got player %d", state.TrickLeader)
+	}
+
+	state.TrumpNamed = false
+	state.CurrentTrick = append(state.CurrentTrick[:0], trick...)
+	resolveTrick(state, genome, phase)
+	if state.TrickLeader != 2 {
+		t.Errorf("Expected the phase's spade trump to win, got player %d", state.TrickLeader)
+	}
+}
+
+func TestTrumpBidMoveRoundTrip(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	for i := range state.Players[:2] {
+		state.Players[i].Hand = []Card{{Rank: RankAce, Suit: 2}}
+		state.Players[i].CurrentBid = -1
+	}
+	genome := &Genome{
+		Header: &BytecodeHeader{PlayerCount: 2},
+		TurnPhases: []PhaseDescriptor{{
+			PhaseType: PhaseTypeBidding,
+			Data:      []byte{0x46, 1, 13, 0x02, 10, 1, 10, 100, 0, 100, 0, 10, 100, 0, 0, 0},
+		}},
+	}
+
+	moves := GenerateLegalMoves(state, genome)
+	var found bool
+	for i := range moves {
+		if moves[i].TargetLoc == LocationTrumpBid+2 {
+			ApplyMove(state, &moves[i], genome)
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Fatalf("Expected a bid naming diamonds among %v", moves)
+	}
+	if !state.TrumpNamed || state.Trump != 2 {
+		t.Errorf("Expected diamonds to be trump, got %d (named %v)", state.Trump, state.TrumpNamed)
+	}
+}
+
+// TestResolveTrickTieRuleDoubleDeck plays two identical cards to one trick,
+// as can happen with a double deck, and checks each tie rule picks its winner.
+func TestResolveTrickTieRuleDoubleDeck(t *testing.T) {
+	kingOfHearts := Card{Rank: RankKing, Suit: 0}
+	kingOfSpades := Card{Rank: RankKing, Suit: 3}
+	twoOfHearts := Card{Rank: RankTwo, Suit: 0}
+
+	tests := []struct {
+		name   string
+		data   []byte // lead_suit_required, trump, high_card_wins, breaking_suit[, tie_rule]
+		trick  []Card
+		winner uint8
+	}{
+		{"no trump, first played", []byte{1, 255, 1, 255, TrickTieFirstPlayed}, []Card{{Rank: RankNine, Suit: 0}, kingOfHearts, kingOfHearts}, 1},
+		{"no trump, last played", []byte{1, 255, 1, 255, TrickTieLastPlayed}, []Card{{Rank: RankNine, Suit: 0}, kingOfHearts, kingOfHearts}, 2},
+		{"trump, first played", []byte{1, 3, 1, 255, TrickTieFirstPlayed}, []Card{{Rank: RankAce, Suit: 0}, kingOfSpades, kingOfSpades}, 1},
+		{"trump, last played", []byte{1, 3, 1, 255, TrickTieLastPlayed}, []Card{{Rank: RankAce, Suit: 0}, kingOfSpades, kingOfSpades}, 2},
+		{"low wins, last played", []byte{1, 255, 0, 255, TrickTieLastPlayed}, []Card{twoOfHearts, {Rank: RankFive, Suit: 0}, twoOfHearts}, 2},
+		{"4-byte phase defaults to first played", []byte{1, 255, 1, 255}, []Card{kingOfHearts, kingOfHearts, {Rank: RankNine, Suit: 0}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := NewGameState(3)
+			defer PutState(state)
+			state.NumPlayers = 3
+			for i, c := range tt.trick {
+				state.CurrentTrick = append(state.CurrentTrick, TrickCard{PlayerID: uint8(i), Card: c})
+			}
+			genome := &Genome{Header: &BytecodeHeader{PlayerCount: 3}}
+
+			resolveTrick(state, genome, PhaseDescriptor{PhaseType: PhaseTypeTrick, Data: tt.data})
+			if state.TrickLeader != tt.winner {
+				t.Errorf("Expected player %d to win the trick, got %d", tt.winner, state.TrickLeader)
+			}
+		})
+	}
+}
+
+// TestResolveTrickNextLeader checks each next-leader rule on a trick seat 0
+// leads, seat 2 plays the ace and seat 3 takes by trumping.
+func TestResolveTrickNextLeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   uint8
+		leader uint8
+	}{
+		{"winner", TrickLeadWinner, 3},
+		{"rotate", TrickLeadRotate, 1},
+		{"high card", TrickLeadHighCard, 2},
+	}
+	genome := &Genome{Header: &BytecodeHeader{PlayerCount: 4}}
+	for _, tt := range tests {
+		state := NewGameState(4)
+		state.NumPlayers = 4
+		state.CurrentTrick = []TrickCard{
+			{PlayerID: 0, Card: Card{Rank: RankFive, Suit: 0}},
+			{PlayerID: 1, Card: Card{Rank: RankThree, Suit: 0}},
+			{PlayerID: 2, Card: Card{Rank: RankAce, Suit: 0}},
+			{PlayerID: 3, Card: Card{Rank: RankTwo, Suit: 3}},
+		}
+		resolveTrick(state, genome, PhaseDescriptor{PhaseType: PhaseTypeTrick, Data: []byte{1, 3, 1, 255, 0, 0, tt.rule}})
+		if state.TrickLeader != tt.leader || state.CurrentPlayer != tt.leader {
+			t.Errorf("%s: expected seat %d to lead, got leader %d and player %d", tt.name, tt.leader, state.TrickLeader, state.CurrentPlayer)
+		}
+		if state.TricksWon[3] != 1 {
+			t.Errorf("%s: expected seat 3 to win the trick, got %v", tt.name, state.TricksWon)
+		}
+		PutState(state)
+	}
+}
+
+// TestTrickLeadRotates plays three tricks that seat 0 wins every time and
+// checks a fixed rotation still passes the lead one seat on each trick.
+func TestTrickLeadRotates(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.Players[0].Hand = []Card{{Rank: RankAce, Suit: 0}, {Rank: RankKing, Suit: 0}, {Rank: RankQueen, Suit: 0}}
+	state.Players[1].Hand = []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankThree, Suit: 0}, {Rank: RankFour, Suit: 0}}
+	state.Players[2].Hand = []Card{{Rank: RankFive, Suit: 0}, {Rank: RankSix, Suit: 0}, {Rank: RankSeven, Suit: 0}}
+	genome := &Genome{
+		TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypeTrick, Data: []byte{1, 255, 1, 255, 0, 0, TrickLeadRotate}}},
+	}
+
+	move := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationTableau}
+	for trick := 0; trick < 3; trick++ {
+		leader := state.CurrentPlayer
+		if want := uint8(trick); leader != want {
+			t.Fatalf("Trick %d: expected seat %d to lead, got %d", trick, want, leader)
+		}
+		for i := 0; i < 3; i++ {
+			ApplyMove(state, &move, genome)
+		}
+	}
+	if len(state.TricksWon) == 0 || state.TricksWon[0] != 3 {
+		t.Errorf("Expected seat 0 to win every trick, got %v", state.TricksWon)
+	}
+	if state.TrickLeader != 0 {
+		t.Errorf("Expected the lead to come back round to seat 0, got %d", state.TrickLeader)
+	}
+}
+
+// TestApplyMoveTrickFollowsLeader verifies that a trick goes round from the
+// player who led it and that the winner leads the next one
+func TestApplyMoveTrickFollowsLeader(t *testing.T) {
+	state := NewGameState(3)
+	state.NumPlayers = 3
+	state.Players[0].Hand = []Card{{Rank: RankKing, Suit: 0}}
+	state.Players[1].Hand = []Card{{Rank: RankFive, Suit: 0}}
+	state.Players[2].Hand = []Card{{Rank: RankNine, Suit: 0}}
+	genome := &Genome{
+		TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypeTrick, Data: []byte{1, 255, 1, 255}}},
+	}
+
+	// Seat 2 leads, then seats 0 and 1 follow
+	state.CurrentPlayer = 2
+	move := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationTableau}
+	ApplyMove(state, &move, genome)
+	if state.TrickLeader != 2 || state.CurrentPlayer != 0 {
+		t.Fatalf("Expected seat 2 to lead and seat 0 to follow, got leader %d and player %d", state.TrickLeader, state.CurrentPlayer)
+	}
+	ApplyMove(state, &move, genome)
+	if state.CurrentPlayer != 1 {
+		t.Fatalf("Expected seat 1 to play last, got %d", state.CurrentPlayer)
+	}
+	ApplyMove(state, &move, genome)
+
+	// The king wins and leads next
+	if state.TrickLeader != 0 || state.CurrentPlayer != 0 || len(state.CurrentTrick) != 0 {
+		t.Errorf("Expected seat 0 to win and lead, got leader %d and player %d", state.TrickLeader, state.CurrentPlayer)
+	}
+}
@@ -12,8 +12,14 @@ func (s *GameState) DrawCard(playerID uint8, source Location) bool {
 	switch source {
 	case LocationDeck:
 		srcPile = &s.Deck
+		if s.PersonalDecks {
+			if len(s.Players[playerID].Deck) == 0 {
+				reshufflePersonalDeck(s, playerID)
+			}
+			srcPile = &s.Players[playerID].Deck
+		}
 	case LocationDiscard:
-		srcPile = &s.Discard
+		srcPile = s.discardPile(playerID)
 	case LocationOpponentHand:
 		// Optional extension: draw from opponent's hand
 		// For multi-player games, use next player as opponent
@@ -27,9 +33,15 @@ func (s *GameState) DrawCard(playerID uint8, source Location) bool {
 		}
 		srcPile = &s.Players[opponentID].Hand
 	case LocationOpponentDiscard:
-		// Optional extension: draw from opponent's discard (not standard)
-		// Would need per-player discard piles
-		return false
+		// Only players with their own discard piles have one to draw from
+		if !s.PersonalDecks || s.NumPlayers == 0 {
+			return false
+		}
+		opponentID := (playerID + 1) % s.NumPlayers
+		if int(opponentID) >= len(s.Players) {
+			return false
+		}
+		srcPile = &s.Players[opponentID].Discard
 	default:
 		return false
 	}
@@ -41,12 +53,67 @@ func (s *GameState) DrawCard(playerID uint8, source Location) bool {
 	// Pop from source
 	card := (*srcPile)[len(*srcPile)-1]
 	*srcPile = (*srcPile)[:len(*srcPile)-1]
+	if srcPile == &s.Discard {
+		s.pruneConcealed()
+	}
 
 	// Add to player hand
 	s.Players[playerID].Hand = append(s.Players[playerID].Hand, card)
 	return true
 }
 
+// DealOrder selects how initial hands are dealt from the deck.
+type DealOrder uint8
+
+const (
+	DealRoundRobin DealOrder = 0 // One card to each player in turn (default)
+	DealSequential DealOrder = 1 // A player's full hand before moving to the next
+)
+
+// DealHands deals cardsPerPlayer cards from the top of the deck to each of
+// the first numPlayers players. Round-robin gives player 0 the top card,
+// player 1 the next, and so on; sequential gives player 0 the top
+// cardsPerPlayer cards. With the same shuffle the two orders produce
+// different hands, so implementations compared on identical seeds must
+// agree on the order. Players no longer Active are dealt nothing.
+func (s *GameState) DealHands(numPlayers, cardsPerPlayer int, order DealOrder) {
+	if order == DealSequential {
+		for p := 0; p < numPlayers; p++ {
+			if s.Players[p].Active {
+				s.DrawHand(uint8(p), cardsPerPlayer)
+			}
+		}
+		return
+	}
+
+	for i := 0; i < cardsPerPlayer; i++ {
+		for p := 0; p < numPlayers; p++ {
+			if s.Players[p].Active {
+				s.DrawCard(uint8(p), LocationDeck)
+			}
+		}
+	}
+}
+
+// DealStrategy selects who takes the starting hands off the deck.
+type DealStrategy uint8
+
+const (
+	DealPreDealt DealStrategy = 0 // Every hand is dealt in DealOrder before anyone acts (default)
+	DealShoeDraw DealStrategy = 1 // Each player draws their own full hand off a shared shoe, one player at a time
+)
+
+// DrawHand has playerID draw n cards off the top of the deck, as a player
+// taking their own starting cards from a shoe does. It stops early if the
+// deck runs out.
+func (s *GameState) DrawHand(playerID uint8, n int) {
+	for i := 0; i < n; i++ {
+		if !s.DrawCard(playerID, LocationDeck) {
+			return
+		}
+	}
+}
+
 // PlayCard moves a card from player hand to target location
 func (s *GameState) PlayCard(playerID uint8, cardIndex int, target Location) bool {
 	// Bounds check to prevent panic on invalid playerID
@@ -67,28 +134,45 @@ func (s *GameState) PlayCard(playerID uint8, cardIndex int, target Location) boo
 	// Add to target
 	switch target {
 	case LocationDiscard:
-		s.Discard = append(s.Discard, card)
+		pile := s.discardPile(playerID)
+		*pile = append(*pile, card)
 	case LocationTableau:
 		if len(s.Tableau) == 0 {
 			s.Tableau = append(s.Tableau, make([]Card, 0, 10))
 		}
 		s.Tableau[0] = append(s.Tableau[0], card)
+	case LocationBurned:
+		s.Burned = append(s.Burned, card)
+	case LocationExposed:
+		s.Players[playerID].Exposed = append(s.Players[playerID].Exposed, card)
 	default:
-		return false
+		i := s.PileIndex(target)
+		if i < 0 {
+			return false
+		}
+		s.Piles[i] = append(s.Piles[i], card)
 	}
 
 	return true
 }
 
-// ShuffleDeck randomizes deck order (in-place)
+// ShuffleDeck randomizes deck order (in-place). The shuffle is a
+// Fisher-Yates pass driven by a 64-bit LCG seeded with seed, so a given seed
+// always produces the same order on every platform. The top of the deck,
+// the next card drawn, is the last card of the slice.
 func (s *GameState) ShuffleDeck(seed uint64) {
+	shuffleCards(s.Deck, seed)
+}
+
+// shuffleCards is ShuffleDeck's shuffle, for any pile of cards.
+func shuffleCards(cards []Card, seed uint64) {
 	// Simple LCG for deterministic shuffle
 	rng := seed
-	n := len(s.Deck)
+	n := len(cards)
 
 	for i := n - 1; i > 0; i-- {
 		rng = rng*6364136223846793005 + 1442695040888963407
 		j := int(rng % uint64(i+1))
-		s.Deck[i], s.Deck[j] = s.Deck[j], s.Deck[i]
+		cards[i], cards[j] = cards[j], cards[i]
 	}
 }
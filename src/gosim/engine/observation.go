@@ -0,0 +1,61 @@
+package engine
+
+// ObservationPlaneSize is the length of one card plane: a count per card,
+// indexed by suit*13 + rank.
+const ObservationPlaneSize = 52
+
+// ObservationSize returns the length of an observation for numPlayers.
+func ObservationSize(numPlayers int) int {
+	return (numPlayers+1)*ObservationPlaneSize + numPlayers
+}
+
+// EncodeObservation encodes what viewer can know about state as card-count
+// planes: the viewer's own hand first, then each opponent's hand in seat
+// order after the viewer, then the face-up cards (discard pile, tableau,
+// current trick, other discard piles and every player's exposed cards), leaving out discards laid face-down by another player
+// (see ConcealedFrom). An opponent's plane holds only the cards the viewer has
+// peeked at and still remembers; the rest of that hand is hidden. The
+// planes are followed by every hand's size in the same seat order (capped
+// at 255), with 0 for an opponent whose hand size the game hides.
+func EncodeObservation(state *GameState, viewer int) []uint8 {
+	numPlayers := int(state.NumPlayers)
+	obs := make([]uint8, ObservationSize(numPlayers))
+
+	for offset := 0; offset < numPlayers; offset++ {
+		seat := (viewer + offset) % numPlayers
+		plane := obs[offset*ObservationPlaneSize : (offset+1)*ObservationPlaneSize]
+		addToPlane(plane, state.KnownCards(viewer, seat))
+	}
+
+	public := obs[numPlayers*ObservationPlaneSize : (numPlayers+1)*ObservationPlaneSize]
+	addToPlane(public, state.Discard)
+	for _, c := range state.ConcealedFrom(viewer) {
+		public[cardSlot(c)]--
+	}
+	for _, pile := range state.Tableau {
+		addToPlane(public, pile)
+	}
+	for _, pile := range state.Piles {
+		addToPlane(public, pile)
+	}
+	for _, tc := range state.CurrentTrick {
+		public[cardSlot(tc.Card)]++
+	}
+	for seat := 0; seat < numPlayers; seat++ {
+		addToPlane(public, state.Players[seat].Exposed)
+	}
+
+	sizes := obs[(numPlayers+1)*ObservationPlaneSize:]
+	for offset := 0; offset < numPlayers; offset++ {
+		if size, ok := state.KnownHandSize(viewer, (viewer+offset)%numPlayers); ok {
+			sizes[offset] = uint8(min(size, 255))
+		}
+	}
+	return obs
+}
+
+func addToPlane(plane []uint8, cards []Card) {
+	for _, c := range cards {
+		plane[cardSlot(c)]++
+	}
+}
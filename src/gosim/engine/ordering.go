@@ -0,0 +1,64 @@
+package engine
+
+import "sort"
+
+// Search AIs that prune, such as the exhaustive solver, cut the most when
+// the best move is tried first. GenerateLegalMoves lists moves in phase and
+// hand order; the ordering below puts the likeliest good moves first by a
+// cheap guess, never by searching.
+
+// MovePriority returns a cheap guess at how good move is for the player to
+// move, higher first: card plays that capture a tableau card of the same
+// rank, then other card plays with the high cards first, then moves that
+// play no card such as draws and passes.
+func MovePriority(state *GameState, move *LegalMove) int {
+	if move.CardIndex < 0 {
+		return 0
+	}
+	hand := state.Players[state.CurrentPlayer].Hand
+	if move.CardIndex >= len(hand) {
+		return 0
+	}
+	card := hand[move.CardIndex]
+	priority := 2 + RankValue(card.Rank, state.AceMode) // An ace-low ace is -1
+	if state.TableauMode == 2 && move.TargetLoc == LocationTableau && len(state.Tableau) > 0 {
+		for _, c := range state.Tableau[0] {
+			if c.Rank == card.Rank {
+				return priority + 100
+			}
+		}
+	}
+	return priority
+}
+
+// OrderMoves sorts moves by MovePriority, highest first. Moves of equal
+// priority keep their generated order, so the order is deterministic.
+func OrderMoves(state *GameState, moves []LegalMove) {
+	priorities := make([]int, len(moves))
+	for i := range moves {
+		priorities[i] = MovePriority(state, &moves[i])
+	}
+	sort.Stable(byPriority{moves, priorities})
+}
+
+// GenerateOrderedMoves returns the legal moves of GenerateLegalMoves in
+// OrderMoves order. Paths that must match generated order, such as seeded
+// playouts and replays, keep using GenerateLegalMoves.
+func GenerateOrderedMoves(state *GameState, genome *Genome) []LegalMove {
+	moves := GenerateLegalMoves(state, genome)
+	OrderMoves(state, moves)
+	return moves
+}
+
+// byPriority sorts moves and their priorities together, highest first.
+type byPriority struct {
+	moves      []LegalMove
+	priorities []int
+}
+
+func (b byPriority) Len() int           { return len(b.moves) }
+func (b byPriority) Less(i, j int) bool { return b.priorities[i] > b.priorities[j] }
+func (b byPriority) Swap(i, j int) {
+	b.moves[i], b.moves[j] = b.moves[j], b.moves[i]
+	b.priorities[i], b.priorities[j] = b.priorities[j], b.priorities[i]
+}
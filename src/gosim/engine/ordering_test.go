@@ -0,0 +1,30 @@
+package engine
+
+import "testing"
+
+// TestOrderMoves checks a capture comes first, then the other plays high
+// card first, then the draw, with equal moves in generated order.
+func TestOrderMoves(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.TableauMode = 2 // Match rank
+	state.Tableau = [][]Card{{{Rank: RankFour, Suit: 1}}}
+	state.Players[0].Hand = []Card{
+		{Rank: RankKing, Suit: 0}, {Rank: RankFour, Suit: 2}, {Rank: RankNine, Suit: 3}, {Rank: RankKing, Suit: 1},
+	}
+	moves := []LegalMove{
+		{PhaseIndex: 1, CardIndex: MoveDraw, TargetLoc: LocationHand},
+		{CardIndex: 0, TargetLoc: LocationTableau},
+		{CardIndex: 1, TargetLoc: LocationTableau},
+		{CardIndex: 2, TargetLoc: LocationTableau},
+		{CardIndex: 3, TargetLoc: LocationTableau},
+	}
+
+	OrderMoves(state, moves)
+	want := []int{1, 0, 3, 2, MoveDraw}
+	for i, move := range moves {
+		if move.CardIndex != want[i] {
+			t.Fatalf("Expected card order %v, got %v", want, moves)
+		}
+	}
+}
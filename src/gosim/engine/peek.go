@@ -0,0 +1,181 @@
+package engine
+
+// Peek records what one player saw of another's hand.
+type Peek struct {
+	Peeker uint8
+	Target uint8
+	Cards  []Card // Target's hand when it was seen; never modified
+	Until  uint32 // TurnNumber at which the peeker forgets (0 = end of the hand)
+}
+
+// active reports whether the peeker still remembers what they saw.
+func (p *Peek) active(turn uint32) bool {
+	return p.Until == 0 || turn < p.Until
+}
+
+// PeekDue reports whether the current player has an opponent hand to look
+// at: one of target's hands they have not seen, or have since forgotten.
+func PeekDue(state *GameState, target uint8) bool {
+	due := false
+	applyToTargets(state, target, nil, func(targetID int) {
+		if state.findPeek(int(state.CurrentPlayer), targetID) == nil && len(state.Players[targetID].Hand) > 0 {
+			due = true
+		}
+	})
+	return due
+}
+
+// ApplyPeek shows the current player the hands peek targets. The cards stay
+// known for peek.Duration turns after the peek, or until the hand ends.
+func ApplyPeek(state *GameState, peek *PeekPhaseData) {
+	peeker := int(state.CurrentPlayer)
+	until := uint32(0)
+	if peek.Duration > 0 {
+		// The peek itself takes a turn before the duration starts
+		until = state.TurnNumber + 1 + peek.Duration
+	}
+	applyToTargets(state, peek.Target, nil, func(targetID int) {
+		state.RecordPeek(peeker, targetID, until)
+	})
+}
+
+// RecordPeek remembers target's current hand as seen by peeker until turn
+// until (0 = end of the hand), replacing anything peeker saw before.
+func (gs *GameState) RecordPeek(peeker, target int, until uint32) {
+	seen := Peek{
+		Peeker: uint8(peeker),
+		Target: uint8(target),
+		Cards:  append([]Card(nil), gs.Players[target].Hand...),
+		Until:  until,
+	}
+	for i := range gs.Peeks {
+		if int(gs.Peeks[i].Peeker) == peeker && int(gs.Peeks[i].Target) == target {
+			gs.Peeks[i] = seen
+			return
+		}
+	}
+	gs.Peeks = append(gs.Peeks, seen)
+}
+
+// RecordReveal shows card, from target's hand, to every other player, who
+// remember it until the end of the hand along with anything they peeked.
+func (gs *GameState) RecordReveal(target int, card Card) {
+	held := countCard(gs.Players[target].Hand, card)
+	for viewer := 0; viewer < int(gs.NumPlayers); viewer++ {
+		if viewer == target || countCard(gs.KnownCards(viewer, target), card) >= held {
+			continue
+		}
+		if p := gs.findPeek(viewer, target); p != nil {
+			// Peeked cards are shared with clones, so the list is copied
+			p.Cards = append(append([]Card(nil), p.Cards...), card)
+			continue
+		}
+		gs.Peeks = append(gs.Peeks, Peek{Peeker: uint8(viewer), Target: uint8(target), Cards: []Card{card}})
+	}
+}
+
+// countCard counts the copies of card in cards.
+func countCard(cards []Card, card Card) int {
+	n := 0
+	for _, c := range cards {
+		if c == card {
+			n++
+		}
+	}
+	return n
+}
+
+// unrevealedCards returns the cards in target's hand that some other player
+// has not seen.
+func (gs *GameState) unrevealedCards(target int) []Card {
+	var hidden []Card
+	for viewer := 0; viewer < int(gs.NumPlayers); viewer++ {
+		if viewer == target {
+			continue
+		}
+		var seen [52]int16
+		for _, c := range gs.KnownCards(viewer, target) {
+			seen[cardSlot(c)]++
+		}
+		hidden = hidden[:0]
+		for _, c := range gs.Players[target].Hand {
+			if seen[cardSlot(c)] > 0 {
+				seen[cardSlot(c)]--
+				continue
+			}
+			hidden = append(hidden, c)
+		}
+		if len(hidden) > 0 {
+			return hidden
+		}
+	}
+	return nil
+}
+
+// findPeek returns peeker's live peek at target's hand, or nil.
+func (gs *GameState) findPeek(peeker, target int) *Peek {
+	for i := range gs.Peeks {
+		p := &gs.Peeks[i]
+		if int(p.Peeker) == peeker && int(p.Target) == target && p.active(gs.TurnNumber) {
+			return p
+		}
+	}
+	return nil
+}
+
+// KnownCards returns the cards in target's hand that viewer knows about:
+// all of them for the viewer's own hand or once the hands are revealed, and
+// for an opponent the peeked cards the opponent still holds. Cards drawn
+// since the peek stay hidden.
+func (gs *GameState) KnownCards(viewer, target int) []Card {
+	hand := gs.Players[target].Hand
+	if viewer == target || gs.Revealed {
+		return hand
+	}
+	peek := gs.findPeek(viewer, target)
+	if peek == nil {
+		return nil
+	}
+
+	var seen [52]int16
+	for _, c := range peek.Cards {
+		seen[cardSlot(c)]++
+	}
+	var known []Card
+	for _, c := range hand {
+		if seen[cardSlot(c)] > 0 {
+			seen[cardSlot(c)]--
+			known = append(known, c)
+		}
+	}
+	return known
+}
+
+// KnownHandSize returns how many cards target holds as far as viewer can
+// tell. Hand sizes are public unless the game hides them, in which case
+// viewer only knows its own until the hands are revealed; ok is false while
+// target's size is hidden.
+func (gs *GameState) KnownHandSize(viewer, target int) (size int, ok bool) {
+	if gs.HandSizesHidden && viewer != target && !gs.Revealed {
+		return 0, false
+	}
+	return len(gs.Players[target].Hand), true
+}
+
+// KnownOpponentCards counts the cards in opponents' hands and how many of
+// them viewer knows, a measure of how much hidden information is left.
+func (gs *GameState) KnownOpponentCards(viewer int) (known, total int) {
+	for i := 0; i < int(gs.NumPlayers); i++ {
+		if i == viewer {
+			continue
+		}
+		total += len(gs.Players[i].Hand)
+		known += len(gs.KnownCards(viewer, i))
+	}
+	return known, total
+}
+
+// cardSlot indexes a card within a 52-card deck.
+func cardSlot(c Card) int {
+	return int(c.Suit)*13 + int(c.Rank)
+}
@@ -0,0 +1,151 @@
+package engine
+
+import "testing"
+
+// peekGenome is a game whose only phase peeks at the next player's hand.
+func peekGenome(duration uint16) *Genome {
+	return &Genome{
+		Header: &BytecodeHeader{PlayerCount: 2},
+		TurnPhases: []PhaseDescriptor{
+			{PhaseType: PhaseTypePeek, Data: []byte{TARGET_NEXT_PLAYER, byte(duration >> 8), byte(duration)}},
+		},
+	}
+}
+
+// opponentPlane returns the observation plane of the opponent seated
+// offset places after the viewer.
+func opponentPlane(obs []uint8, offset int) []uint8 {
+	return obs[offset*ObservationPlaneSize : (offset+1)*ObservationPlaneSize]
+}
+
+func planeCount(plane []uint8) int {
+	n := 0
+	for _, c := range plane {
+		n += int(c)
+	}
+	return n
+}
+
+func TestPeekRevealsHandInObservation(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	ace := Card{Rank: RankAce, Suit: 0}
+	queen := Card{Rank: RankQueen, Suit: 3}
+	state.Players[0].Hand = append(state.Players[0].Hand, Card{Rank: RankTwo, Suit: 1})
+	state.Players[1].Hand = append(state.Players[1].Hand, ace, queen)
+	genome := peekGenome(0)
+
+	if obs := EncodeObservation(state, 0); planeCount(opponentPlane(obs, 1)) != 0 {
+		t.Fatal("Expected the opponent's hand to be hidden before the peek")
+	}
+
+	moves := GenerateLegalMoves(state, genome)
+	if len(moves) != 1 || moves[0].CardIndex != MovePeek {
+		t.Fatalf("Expected a single peek move, got %v", moves)
+	}
+	ApplyMove(state, &moves[0], genome)
+	if state.CurrentPlayer != 0 {
+		t.Errorf("Expected the peeker to keep the turn, got player %d", state.CurrentPlayer)
+	}
+
+	obs := EncodeObservation(state, 0)
+	plane := opponentPlane(obs, 1)
+	if plane[cardSlot(ace)] != 1 || plane[cardSlot(queen)] != 1 || planeCount(plane) != 2 {
+		t.Errorf("Expected the peeked hand in the observation, got %v", plane)
+	}
+	if planeCount(opponentPlane(EncodeObservation(state, 1), 1)) != 0 {
+		t.Error("Expected the peek to tell the opponent nothing")
+	}
+	if known, total := state.KnownOpponentCards(0); known != 2 || total != 2 {
+		t.Errorf("Expected 2 of 2 opponent cards known, got %d of %d", known, total)
+	}
+	if len(GenerateLegalMoves(state, genome)) != 0 {
+		t.Error("Expected no second peek at a hand already seen")
+	}
+
+	// A card drawn after the peek stays hidden; a card played is forgotten
+	king := Card{Rank: RankKing, Suit: 2}
+	state.Players[1].Hand = append(state.Players[1].Hand[1:], king)
+	plane = opponentPlane(EncodeObservation(state, 0), 1)
+	if plane[cardSlot(queen)] != 1 || planeCount(plane) != 1 {
+		t.Errorf("Expected only the queen to stay known, got %v", plane)
+	}
+}
+
+func TestPeekDurationExpires(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.Players[1].Hand = append(state.Players[1].Hand, Card{Rank: RankAce, Suit: 0})
+	genome := peekGenome(2)
+
+	moves := GenerateLegalMoves(state, genome)
+	ApplyMove(state, &moves[0], genome)
+	state.TurnNumber += 1
+	if len(state.KnownCards(0, 1)) != 1 {
+		t.Fatal("Expected the hand to be known during the peek's duration")
+	}
+
+	state.TurnNumber += 1
+	if len(state.KnownCards(0, 1)) != 0 {
+		t.Error("Expected the peek to be forgotten once its duration passed")
+	}
+	if !PeekDue(state, TARGET_NEXT_PLAYER) {
+		t.Error("Expected a forgotten hand to be worth peeking at again")
+	}
+
+	// Clones remember, a new hand forgets
+	ApplyPeek(state, &PeekPhaseData{Target: TARGET_NEXT_PLAYER})
+	clone := state.Clone()
+	defer PutState(clone)
+	if len(clone.KnownCards(0, 1)) != 1 {
+		t.Error("Expected a clone to keep the peek")
+	}
+	state.ResetHand()
+	if len(state.KnownCards(0, 1)) != 0 {
+		t.Error("Expected peeks to end with the hand")
+	}
+}
+
+func TestParsePeekPhaseData(t *testing.T) {
+	peek, err := ParsePeekPhaseData([]byte{TARGET_ALL_OPPONENTS, 0x01, 0x02})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if peek.Target != TARGET_ALL_OPPONENTS || peek.Duration != 258 {
+		t.Errorf("Unexpected peek data: %+v", peek)
+	}
+	if _, err := ParsePeekPhaseData([]byte{0}); err == nil {
+		t.Error("Expected error for short data")
+	}
+}
+
+func TestObservationMasksHiddenHandSizes(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.Players[0].Hand = []Card{{Rank: RankTwo, Suit: 0}}
+	state.Players[1].Hand = []Card{{Rank: RankAce, Suit: 1}, {Rank: RankKing, Suit: 1}}
+	state.Players[2].Hand = []Card{{Rank: RankFive, Suit: 2}, {Rank: RankSix, Suit: 2}, {Rank: RankSeven, Suit: 2}}
+	sizes := func(viewer int) []uint8 {
+		return EncodeObservation(state, viewer)[4*ObservationPlaneSize:]
+	}
+
+	// Sizes are public by default, in seat order from the viewer
+	if got := sizes(1); len(got) != 3 || got[0] != 2 || got[1] != 3 || got[2] != 1 {
+		t.Errorf("Expected hand sizes [2 3 1], got %v", got)
+	}
+
+	state.HandSizesHidden = true
+	if got := sizes(1); got[0] != 2 || got[1] != 0 || got[2] != 0 {
+		t.Errorf("Expected only the viewer's own size, got %v", got)
+	}
+	if _, ok := state.KnownHandSize(1, 2); ok {
+		t.Error("Expected the opponent's hand size to be hidden")
+	}
+
+	// The end-of-game reveal shows every hand
+	state.Revealed = true
+	if got := sizes(1); got[1] != 3 || got[2] != 1 {
+		t.Errorf("Expected revealed hand sizes, got %v", got)
+	}
+}
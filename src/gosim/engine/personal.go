@@ -0,0 +1,60 @@
+package engine
+
+// Personal decks give each player their own draw and discard piles, as in
+// deckbuilders and dueling games, in place of the shared Deck and Discard.
+// Draws from LocationDeck come off the player's own deck, cards played or
+// discarded to LocationDiscard land on their own discard pile, and when a
+// player's deck runs out their discards are shuffled into a new one. The
+// shared piles stay in play for everything else, such as starting cards
+// and a common supply.
+
+// SetupPersonalDecks gives each of the first numPlayers players a shuffled
+// standard deck of their own, cut down to size cards (0 = all 52), and
+// turns personal decks on. Each deck is shuffled from its own seed derived
+// from seed, so the players' decks differ.
+func SetupPersonalDecks(state *GameState, numPlayers, size int, seed uint64) {
+	state.PersonalDecks = true
+	for p := 0; p < numPlayers && p < len(state.Players); p++ {
+		player := &state.Players[p]
+		player.Deck = player.Deck[:0]
+		player.Discard = player.Discard[:0]
+		for suit := uint8(0); suit < 4; suit++ {
+			for rank := uint8(0); rank < 13; rank++ {
+				player.Deck = append(player.Deck, Card{Rank: rank, Suit: suit})
+			}
+		}
+		shuffleCards(player.Deck, seed+uint64(p)*0x9E3779B97F4A7C15)
+		if size > 0 && size < len(player.Deck) {
+			player.Deck = player.Deck[len(player.Deck)-size:]
+		}
+	}
+}
+
+// reshufflePersonalDeck shuffles every card in the player's discard pile
+// into their deck. Unlike the shared pile, no top card stays behind: a
+// personal discard pile is never played on.
+func reshufflePersonalDeck(state *GameState, playerID uint8) {
+	player := &state.Players[playerID]
+	if len(player.Discard) == 0 {
+		return
+	}
+	player.Deck = append(player.Deck, player.Discard...)
+	player.Discard = player.Discard[:0]
+	shuffleCards(player.Deck, uint64(state.TurnNumber)+uint64(playerID))
+}
+
+// discardPile returns the pile playerID's discards go to: their own with
+// personal decks, otherwise the shared discard.
+func (s *GameState) discardPile(playerID uint8) *[]Card {
+	if s.PersonalDecks {
+		return &s.Players[playerID].Discard
+	}
+	return &s.Discard
+}
+
+// CanDrawOwn reports whether playerID has a card to draw from their own
+// deck, counting the discards a reshuffle would bring back.
+func CanDrawOwn(state *GameState, playerID uint8) bool {
+	player := &state.Players[playerID]
+	return len(player.Deck) > 0 || len(player.Discard) > 0
+}
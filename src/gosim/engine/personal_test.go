@@ -0,0 +1,56 @@
+package engine
+
+import "testing"
+
+// TestPersonalDeckExhaustion checks a player who runs through their own
+// deck cannot draw from anyone else's, and that their own discards are
+// shuffled back in once there are some.
+func TestPersonalDeckExhaustion(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	SetupPersonalDecks(state, 2, 5, 42)
+	if len(state.Players[0].Deck) != 5 || len(state.Players[1].Deck) != 5 || len(state.Deck) != 0 {
+		t.Fatalf("Expected two 5-card decks and no shared deck, got %d, %d and %d",
+			len(state.Players[0].Deck), len(state.Players[1].Deck), len(state.Deck))
+	}
+
+	state.DrawHand(0, 5)
+	if state.DrawCard(0, LocationDeck) || CanDrawOwn(state, 0) {
+		t.Fatal("Expected player 0 unable to draw with their deck used up")
+	}
+	if len(state.Players[0].Hand) != 5 || len(state.Players[1].Deck) != 5 {
+		t.Fatalf("Expected player 0's draws to come from their own deck, got hand %d and opponent deck %d",
+			len(state.Players[0].Hand), len(state.Players[1].Deck))
+	}
+
+	// Discards go to the player's own pile and come back in a reshuffle
+	state.PlayCard(0, 0, LocationDiscard)
+	state.PlayCard(0, 0, LocationDiscard)
+	if len(state.Players[0].Discard) != 2 || len(state.Discard) != 0 {
+		t.Fatalf("Expected 2 cards on player 0's own discard, got %d (shared %d)", len(state.Players[0].Discard), len(state.Discard))
+	}
+	if !state.DrawCard(0, LocationDeck) {
+		t.Fatal("Expected the discards reshuffled into a new deck")
+	}
+	if len(state.Players[0].Deck) != 1 || len(state.Players[0].Discard) != 0 || len(state.Players[0].Hand) != 4 {
+		t.Errorf("Expected 1 card left in the reshuffled deck, got deck %d, discard %d, hand %d",
+			len(state.Players[0].Deck), len(state.Players[0].Discard), len(state.Players[0].Hand))
+	}
+}
+
+// TestPersonalDecksDiffer checks each player's deck is shuffled on its own.
+func TestPersonalDecksDiffer(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	SetupPersonalDecks(state, 2, 0, 7)
+	same := 0
+	for i := range state.Players[0].Deck {
+		if state.Players[0].Deck[i] == state.Players[1].Deck[i] {
+			same++
+		}
+	}
+	if len(state.Players[0].Deck) != 52 || same == 52 {
+		t.Errorf("Expected two differently shuffled full decks, got %d cards with %d in the same place", len(state.Players[0].Deck), same)
+	}
+}
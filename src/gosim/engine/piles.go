@@ -0,0 +1,82 @@
+package engine
+
+// Some games discard to several piles rather than one, each taking only
+// certain cards, as patience games build a foundation for each suit up
+// from the ace. A play phase targeting LocationPiles offers each card to
+// every pile that accepts it; the move's TargetLoc names the pile.
+
+// LocationPile+i is discard pile i in a move's TargetLoc.
+const LocationPile Location = 140
+
+// MaxPiles is the most discard piles a game can have.
+const MaxPiles = 8
+
+// How a discard pile is built once started (see PileRule).
+const (
+	PileBuildAny  uint8 = 0 // Any card of the pile's suit
+	PileBuildUp   uint8 = 1 // One rank above the top card, the ace following the king
+	PileBuildDown uint8 = 2 // One rank below the top card, the king following the ace
+)
+
+// PileRule says which cards a discard pile takes.
+type PileRule struct {
+	Suit      uint8 // Suit taken (255 = any)
+	StartRank uint8 // Rank of the card that starts the pile (255 = any)
+	Build     uint8 // PileBuildAny, PileBuildUp or PileBuildDown
+}
+
+// Accepts reports whether card may be played onto pile under the rule.
+func (r PileRule) Accepts(pile []Card, card Card) bool {
+	if r.Suit != 255 && card.Suit != r.Suit {
+		return false
+	}
+	if len(pile) == 0 {
+		return r.StartRank == 255 || card.Rank == r.StartRank
+	}
+	top := pile[len(pile)-1].Rank
+	switch r.Build {
+	case PileBuildUp:
+		return card.Rank == (top+1)%13
+	case PileBuildDown:
+		return card.Rank == (top+12)%13
+	}
+	return true
+}
+
+// PileIndex returns the discard pile loc names, or -1 if it names none.
+func (s *GameState) PileIndex(loc Location) int {
+	if loc < LocationPile || int(loc-LocationPile) >= len(s.Piles) {
+		return -1
+	}
+	return int(loc - LocationPile)
+}
+
+// AppendPilePlays appends a move playing card, the hand's cardIdx-th, onto
+// each discard pile that accepts it. It returns the moves and how many it
+// added.
+func AppendPilePlays(moves []LegalMove, state *GameState, phaseIdx, cardIdx int, card Card) ([]LegalMove, int) {
+	added := 0
+	for i, rule := range state.PileRules {
+		if i < len(state.Piles) && rule.Accepts(state.Piles[i], card) {
+			moves = append(moves, LegalMove{
+				PhaseIndex: phaseIdx,
+				CardIndex:  cardIdx,
+				TargetLoc:  LocationPile + Location(i),
+			})
+			added++
+		}
+	}
+	return moves, added
+}
+
+// SetupPiles readies an empty discard pile for each rule.
+func SetupPiles(state *GameState, rules []PileRule) {
+	state.PileRules = rules
+	for len(state.Piles) < len(rules) {
+		state.Piles = append(state.Piles, nil)
+	}
+	state.Piles = state.Piles[:len(rules)]
+	for i := range state.Piles {
+		state.Piles[i] = state.Piles[i][:0]
+	}
+}
@@ -0,0 +1,28 @@
+package engine
+
+import "testing"
+
+func TestPileRuleAccepts(t *testing.T) {
+	hearts := PileRule{Suit: 0, StartRank: RankAce, Build: PileBuildUp}
+	down := PileRule{Suit: 255, StartRank: 255, Build: PileBuildDown}
+	tests := []struct {
+		rule PileRule
+		pile []Card
+		card Card
+		want bool
+	}{
+		{hearts, nil, Card{Rank: RankAce, Suit: 0}, true},
+		{hearts, nil, Card{Rank: RankTwo, Suit: 0}, false},                             // Must start with the ace
+		{hearts, nil, Card{Rank: RankAce, Suit: 3}, false},                             // Wrong suit
+		{hearts, []Card{{Rank: RankAce, Suit: 0}}, Card{Rank: RankTwo, Suit: 0}, true}, // The two follows the ace
+		{hearts, []Card{{Rank: RankTwo, Suit: 0}}, Card{Rank: RankFour, Suit: 0}, false},
+		{down, nil, Card{Rank: RankSeven, Suit: 2}, true},
+		{down, []Card{{Rank: RankSeven, Suit: 2}}, Card{Rank: RankSix, Suit: 1}, true},
+		{down, []Card{{Rank: RankTwo, Suit: 2}}, Card{Rank: RankAce, Suit: 1}, true}, // The ace follows the two going down
+	}
+	for _, tt := range tests {
+		if got := tt.rule.Accepts(tt.pile, tt.card); got != tt.want {
+			t.Errorf("%+v.Accepts(%v, %v) = %v, want %v", tt.rule, tt.pile, tt.card, got, tt.want)
+		}
+	}
+}
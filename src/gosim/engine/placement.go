@@ -0,0 +1,108 @@
+package engine
+
+// HasFinished reports whether seat has gone out this hand.
+func (gs *GameState) HasFinished(seat int) bool {
+	for _, s := range gs.FinishOrder {
+		if int(s) == seat {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordFinishers adds every seat that has emptied its hand, and has not
+// already gone out, to the finishing order. Call it after each move so the
+// order is the order players went out.
+func RecordFinishers(state *GameState) {
+	for seat := 0; seat < int(state.NumPlayers); seat++ {
+		if len(state.Players[seat].Hand) == 0 && !state.HasFinished(seat) {
+			state.FinishOrder = append(state.FinishOrder, uint8(seat))
+		}
+	}
+}
+
+// PlacementRoundOver reports whether at most one player still holds cards,
+// so every placing is decided.
+func PlacementRoundOver(state *GameState) bool {
+	return len(state.FinishOrder) >= int(state.NumPlayers)-1
+}
+
+// SkipFinished passes the turn on from a player who has gone out to the
+// next player, in seat order, who is still playing.
+func SkipFinished(state *GameState) {
+	n := int(state.NumPlayers)
+	for i := 0; i < n && state.HasFinished(int(state.CurrentPlayer)); i++ {
+		state.CurrentPlayer = uint8((int(state.CurrentPlayer) + 1) % n)
+	}
+}
+
+// AwardPlacements ends a round of a shedding game: players still holding
+// cards are placed after those who went out, fewest cards first, and each
+// player scores points[place] for their placing (places past the end of
+// points score nothing).
+func AwardPlacements(state *GameState, points []int32) {
+	for len(state.FinishOrder) < int(state.NumPlayers) {
+		next := -1
+		for seat := 0; seat < int(state.NumPlayers); seat++ {
+			if state.HasFinished(seat) {
+				continue
+			}
+			if next < 0 || len(state.Players[seat].Hand) < len(state.Players[next].Hand) {
+				next = seat
+			}
+		}
+		state.FinishOrder = append(state.FinishOrder, uint8(next))
+	}
+	for place, seat := range state.FinishOrder {
+		if place < len(points) {
+			state.Players[seat].Score += points[place]
+		}
+	}
+}
+
+// PlacementLeader returns the player with the highest score, ties going to
+// whoever went out first this hand.
+func PlacementLeader(state *GameState) int {
+	best := -1
+	for _, s := range state.FinishOrder {
+		seat := int(s)
+		if best < 0 || state.Players[seat].Score > state.Players[best].Score {
+			best = seat
+		}
+	}
+	return best
+}
+
+// CollectCards returns every card in play to the deck: the hands and
+// captured and exposed piles, the discard piles, the tableau, the board,
+// the kitty and any unfinished trick. It readies a full deck to shuffle and
+// deal the next round from.
+func CollectCards(state *GameState) {
+	for i := range state.Players {
+		state.Deck = append(state.Deck, state.Players[i].Hand...)
+		state.Players[i].Hand = state.Players[i].Hand[:0]
+		state.Deck = append(state.Deck, state.Players[i].Captured...)
+		state.Players[i].Captured = state.Players[i].Captured[:0]
+		state.Deck = append(state.Deck, state.Players[i].Exposed...)
+		state.Players[i].Exposed = state.Players[i].Exposed[:0]
+	}
+	state.Deck = append(state.Deck, state.Discard...)
+	state.Discard = state.Discard[:0]
+	state.ConcealedDiscards = state.ConcealedDiscards[:0]
+	for _, pile := range state.Tableau {
+		state.Deck = append(state.Deck, pile...)
+	}
+	state.Tableau = state.Tableau[:0]
+	for i, pile := range state.Piles {
+		state.Deck = append(state.Deck, pile...)
+		state.Piles[i] = pile[:0]
+	}
+	state.Deck = append(state.Deck, state.Community...)
+	state.Community = state.Community[:0]
+	state.Deck = append(state.Deck, state.Kitty...)
+	state.Kitty = state.Kitty[:0]
+	for _, tc := range state.CurrentTrick {
+		state.Deck = append(state.Deck, tc.Card)
+	}
+	state.CurrentTrick = state.CurrentTrick[:0]
+}
@@ -0,0 +1,75 @@
+package engine
+
+import "testing"
+
+// TestPlacementScoring checks players are placed in the order they went
+// out, the last one by cards left, and that each scores by placing.
+func TestPlacementScoring(t *testing.T) {
+	state := NewGameState(4)
+	defer PutState(state)
+	state.NumPlayers = 4
+	state.Players[0].Hand = []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankThree, Suit: 0}}
+	state.Players[1].Hand = []Card{{Rank: RankTwo, Suit: 1}}
+	state.Players[3].Hand = []Card{{Rank: RankTwo, Suit: 3}}
+
+	// Seat 2 goes out first, then seat 3
+	RecordFinishers(state)
+	state.Players[3].Hand = nil
+	RecordFinishers(state)
+	if len(state.FinishOrder) != 2 || state.FinishOrder[0] != 2 || state.FinishOrder[1] != 3 {
+		t.Fatalf("Expected seats 2 then 3 to finish, got %v", state.FinishOrder)
+	}
+	if PlacementRoundOver(state) {
+		t.Fatal("Expected the round to go on while two players hold cards")
+	}
+
+	state.CurrentPlayer = 2
+	SkipFinished(state)
+	if state.CurrentPlayer != 0 {
+		t.Errorf("Expected the turn to pass to seat 0, got %d", state.CurrentPlayer)
+	}
+
+	state.Players[1].Hand = nil
+	RecordFinishers(state)
+	if !PlacementRoundOver(state) {
+		t.Fatal("Expected the round over with one player holding cards")
+	}
+	state.Players[2].Score = 1
+	AwardPlacements(state, []int32{5, 3, 1})
+	want := []int32{0, 1, 6, 3} // seat 0 placed last and past the points
+	for seat, score := range want {
+		if state.Players[seat].Score != score {
+			t.Errorf("Seat %d: expected %d points, got %d", seat, score, state.Players[seat].Score)
+		}
+	}
+	if leader := PlacementLeader(state); leader != 2 {
+		t.Errorf("Expected seat 2 to lead, got %d", leader)
+	}
+
+	// Ties go to whoever went out first
+	state.Players[3].Score = 6
+	if leader := PlacementLeader(state); leader != 2 {
+		t.Errorf("Expected seat 2 to win the tie, got %d", leader)
+	}
+}
+
+// TestCollectCards checks every card in play returns to the deck.
+func TestCollectCards(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.Players[0].Hand = []Card{{Rank: RankTwo, Suit: 0}}
+	state.Discard = []Card{{Rank: RankThree, Suit: 0}}
+	state.Tableau = [][]Card{{{Rank: RankFour, Suit: 0}}, {{Rank: RankFive, Suit: 0}}}
+	state.Community = []Card{{Rank: RankSix, Suit: 0}}
+	state.CurrentTrick = []TrickCard{{PlayerID: 1, Card: Card{Rank: RankSeven, Suit: 0}}}
+	state.Deck = []Card{{Rank: RankEight, Suit: 0}}
+
+	CollectCards(state)
+	if len(state.Deck) != 7 {
+		t.Errorf("Expected 7 cards in the deck, got %d", len(state.Deck))
+	}
+	if len(state.Players[0].Hand)+len(state.Discard)+len(state.Tableau)+len(state.Community)+len(state.CurrentTrick) != 0 {
+		t.Error("Expected nothing left in play")
+	}
+}
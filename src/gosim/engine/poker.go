@@ -21,11 +21,19 @@ const (
 // PokerHand represents an evaluated poker hand
 type PokerHand struct {
 	Rank     HandRank
-	Kickers  []uint8 // For tie-breaking (high cards)
+	Kickers  []uint8 // For tie-breaking (RankValue+1, so an ace-low Ace is 0)
 }
 
-// EvaluatePokerHand evaluates a 5-card poker hand
+// EvaluatePokerHand evaluates a 5-card poker hand using standard poker rules,
+// where the Ace plays both high (A-K-Q-J-10) and low (A-2-3-4-5).
 func EvaluatePokerHand(cards []Card) PokerHand {
+	return EvaluatePokerHandWithAceMode(cards, AceBoth)
+}
+
+// EvaluatePokerHandWithAceMode evaluates a 5-card poker hand under the given
+// ace mode. AceHigh only allows the A-K-Q-J-10 straight, AceLow only allows
+// A-2-3-4-5 and ranks the Ace as the lowest kicker, AceBoth allows either.
+func EvaluatePokerHandWithAceMode(cards []Card, mode AceMode) PokerHand {
 	if len(cards) != 5 {
 		return PokerHand{Rank: HighCard}
 	}
@@ -34,7 +42,7 @@ func EvaluatePokerHand(cards []Card) PokerHand {
 	sorted := make([]Card, 5)
 	copy(sorted, cards)
 	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Rank > sorted[j].Rank
+		return RankValue(sorted[i].Rank, mode) > RankValue(sorted[j].Rank, mode)
 	})
 
 	// Check for flush (all same suit)
@@ -46,18 +54,19 @@ func EvaluatePokerHand(cards []Card) PokerHand {
 		}
 	}
 
-	// Check for straight (5 consecutive ranks)
+	// Check for straight (5 consecutive ranks). Ace-low ranks the Ace below
+	// the 2, so the wheel falls out of this check in that mode.
 	isStraight := true
 	for i := 1; i < 5; i++ {
-		if sorted[i-1].Rank != sorted[i].Rank+1 {
+		if RankValue(sorted[i-1].Rank, mode) != RankValue(sorted[i].Rank, mode)+1 {
 			isStraight = false
 			break
 		}
 	}
 
-	// Special case: A-2-3-4-5 (wheel straight)
+	// Special case: A-2-3-4-5 (wheel straight) when the Ace plays both ways
 	// Ace is rank 12, so check for 12-3-2-1-0
-	if !isStraight && sorted[0].Rank == 12 && sorted[1].Rank == 3 &&
+	if !isStraight && mode == AceBoth && sorted[0].Rank == 12 && sorted[1].Rank == 3 &&
 		sorted[2].Rank == 2 && sorted[3].Rank == 1 && sorted[4].Rank == 0 {
 		isStraight = true
 		// Reorder for wheel: 3-2-1-0-12 becomes 5-high straight
@@ -86,7 +95,7 @@ func EvaluatePokerHand(cards []Card) PokerHand {
 	// Build kickers list (all ranks sorted descending)
 	kickers := make([]uint8, 5)
 	for i, card := range sorted {
-		kickers[i] = card.Rank
+		kickers[i] = uint8(RankValue(card.Rank, mode) + 1)
 	}
 
 	// Determine hand rank
@@ -155,7 +164,7 @@ func ComparePokerHands(hand1, hand2 PokerHand) int {
 }
 
 // FindBestPokerWinner finds the player with the best poker hand
-// Returns player ID or -1 for tie
+// under the state's ace mode. Returns player ID or -1 for tie
 func FindBestPokerWinner(state *GameState, numPlayers int) int8 {
 	if numPlayers == 0 {
 		numPlayers = 2
@@ -164,13 +173,17 @@ func FindBestPokerWinner(state *GameState, numPlayers int) int8 {
 	bestPlayer := int8(-1)
 	var bestHand PokerHand
 
+	community := len(state.Community) > 0
 	for playerID := 0; playerID < numPlayers; playerID++ {
-		hand := state.Players[playerID].Hand
-		if len(hand) != 5 {
-			continue // Skip players without exactly 5 cards
+		if state.Players[playerID].HasFolded {
+			continue // Folded and knocked-out players cannot win the pot
+		}
+		hand := ShowdownCards(state, playerID)
+		if len(hand) != 5 && !(community && len(hand) > 5) {
+			continue // Skip players without exactly 5 cards, or 5 to pick from the board
 		}
 
-		pokerHand := EvaluatePokerHand(hand)
+		pokerHand := BestPokerHand(hand, state.AceMode)
 
 		if bestPlayer == -1 {
 			bestPlayer = int8(playerID)
@@ -0,0 +1,69 @@
+package engine
+
+// Card ranks use a single encoding throughout the engine:
+// 0=2, 1=3, ..., 8=10, 9=J, 10=Q, 11=K, 12=A.
+const (
+	RankTwo   uint8 = 0
+	RankThree uint8 = 1
+	RankFour  uint8 = 2
+	RankFive  uint8 = 3
+	RankSix   uint8 = 4
+	RankSeven uint8 = 5
+	RankEight uint8 = 6
+	RankNine  uint8 = 7
+	RankTen   uint8 = 8
+	RankJack  uint8 = 9
+	RankQueen uint8 = 10
+	RankKing  uint8 = 11
+	RankAce   uint8 = 12
+)
+
+// DefaultRankValues are the points each rank counts for, indexed by rank
+// (2 through Ace), when a genome sets no table of its own: the pips at
+// face value, the court cards 10 and the Ace 1. It is the count Blackjack
+// (where an Ace may also count 11) and rummy hand penalties use.
+var DefaultRankValues = [13]int32{2, 3, 4, 5, 6, 7, 8, 9, 10, 10, 10, 10, 1}
+
+// RankPoints returns the points rank counts for in values, a table indexed
+// by rank, falling back to DefaultRankValues for ranks it doesn't cover.
+func RankPoints(values []int32, rank uint8) int32 {
+	if int(rank) < len(values) {
+		return values[rank]
+	}
+	if int(rank) < len(DefaultRankValues) {
+		return DefaultRankValues[rank]
+	}
+	return 0
+}
+
+// AceMode controls where the Ace sits in rank order.
+type AceMode uint8
+
+const (
+	AceHigh AceMode = 0 // Ace ranks above King (default)
+	AceLow  AceMode = 1 // Ace ranks below Two
+	AceBoth AceMode = 2 // Ace is high for comparisons and may also sit below Two in sequences
+)
+
+// RankValue returns a comparable value for rank under the given ace mode.
+// Only AceLow moves the Ace below Two; AceBoth compares the Ace as high.
+func RankValue(rank uint8, mode AceMode) int {
+	if rank == RankAce && mode == AceLow {
+		return -1
+	}
+	return int(rank)
+}
+
+// RanksAdjacent reports whether upper is exactly one step above lower in a
+// sequence. Sequences never wrap, so K-A-2 is never a run even in AceBoth.
+func RanksAdjacent(lower, upper uint8, mode AceMode) bool {
+	if upper == RankAce {
+		// Q-K-A
+		return lower == RankKing && mode != AceLow
+	}
+	if lower == RankAce {
+		// A-2
+		return upper == RankTwo && mode != AceHigh
+	}
+	return upper == lower+1
+}
@@ -0,0 +1,146 @@
+package engine
+
+import "testing"
+
+func TestRankValueAceModes(t *testing.T) {
+	if RankValue(RankAce, AceHigh) <= RankValue(RankKing, AceHigh) {
+		t.Error("Ace should outrank King with Ace high")
+	}
+	if RankValue(RankAce, AceBoth) <= RankValue(RankKing, AceBoth) {
+		t.Error("Ace should outrank King in comparisons with Ace both")
+	}
+	if RankValue(RankAce, AceLow) >= RankValue(RankTwo, AceLow) {
+		t.Error("Ace should rank below Two with Ace low")
+	}
+}
+
+func TestPokerLowStraight(t *testing.T) {
+	// A-2-3-4-5 of mixed suits
+	wheel := []Card{
+		{Rank: RankAce, Suit: 0},
+		{Rank: 0, Suit: 1},
+		{Rank: 1, Suit: 2},
+		{Rank: 2, Suit: 3},
+		{Rank: 3, Suit: 0},
+	}
+
+	if got := EvaluatePokerHandWithAceMode(wheel, AceLow).Rank; got != Straight {
+		t.Errorf("A-2-3-4-5 with Ace low: expected Straight, got %d", got)
+	}
+	if got := EvaluatePokerHandWithAceMode(wheel, AceBoth).Rank; got != Straight {
+		t.Errorf("A-2-3-4-5 with Ace both: expected Straight, got %d", got)
+	}
+	if got := EvaluatePokerHandWithAceMode(wheel, AceHigh).Rank; got != HighCard {
+		t.Errorf("A-2-3-4-5 with Ace high: expected HighCard, got %d", got)
+	}
+
+	// The wheel is the lowest straight: 2-3-4-5-6 beats it
+	sixHigh := []Card{
+		{Rank: 0, Suit: 1},
+		{Rank: 1, Suit: 2},
+		{Rank: 2, Suit: 3},
+		{Rank: 3, Suit: 0},
+		{Rank: 4, Suit: 0},
+	}
+	for _, mode := range []AceMode{AceLow, AceBoth} {
+		cmp := ComparePokerHands(EvaluatePokerHandWithAceMode(sixHigh, mode), EvaluatePokerHandWithAceMode(wheel, mode))
+		if cmp <= 0 {
+			t.Errorf("Mode %d: 6-high straight should beat the wheel, got %d", mode, cmp)
+		}
+	}
+}
+
+func TestPokerHighStraight(t *testing.T) {
+	// 10-J-Q-K-A of mixed suits
+	broadway := []Card{
+		{Rank: RankTen, Suit: 0},
+		{Rank: RankJack, Suit: 1},
+		{Rank: RankQueen, Suit: 2},
+		{Rank: RankKing, Suit: 3},
+		{Rank: RankAce, Suit: 0},
+	}
+
+	if got := EvaluatePokerHandWithAceMode(broadway, AceHigh).Rank; got != Straight {
+		t.Errorf("10-J-Q-K-A with Ace high: expected Straight, got %d", got)
+	}
+	if got := EvaluatePokerHandWithAceMode(broadway, AceBoth).Rank; got != Straight {
+		t.Errorf("10-J-Q-K-A with Ace both: expected Straight, got %d", got)
+	}
+	if got := EvaluatePokerHandWithAceMode(broadway, AceLow).Rank; got != HighCard {
+		t.Errorf("10-J-Q-K-A with Ace low: expected HighCard, got %d", got)
+	}
+}
+
+func TestPokerAceLowKicker(t *testing.T) {
+	// Ace-high vs King-high: the Ace only wins when it ranks high
+	aceHigh := []Card{
+		{Rank: RankAce, Suit: 0}, {Rank: 7, Suit: 1}, {Rank: 5, Suit: 2}, {Rank: 3, Suit: 3}, {Rank: 1, Suit: 0},
+	}
+	kingHigh := []Card{
+		{Rank: RankKing, Suit: 1}, {Rank: 7, Suit: 2}, {Rank: 5, Suit: 3}, {Rank: 3, Suit: 0}, {Rank: 1, Suit: 1},
+	}
+
+	if ComparePokerHands(EvaluatePokerHandWithAceMode(aceHigh, AceHigh), EvaluatePokerHandWithAceMode(kingHigh, AceHigh)) <= 0 {
+		t.Error("Ace should beat King with Ace high")
+	}
+	if ComparePokerHands(EvaluatePokerHandWithAceMode(aceHigh, AceLow), EvaluatePokerHandWithAceMode(kingHigh, AceLow)) >= 0 {
+		t.Error("King should beat Ace with Ace low")
+	}
+}
+
+func TestTrickComparisonAceLow(t *testing.T) {
+	state := NewGameState(2)
+	state.NumPlayers = 2
+	state.CurrentTrick = []TrickCard{
+		{PlayerID: 0, Card: Card{Rank: RankAce, Suit: 0}},
+		{PlayerID: 1, Card: Card{Rank: RankTwo, Suit: 0}},
+	}
+	genome := &Genome{Header: &BytecodeHeader{PlayerCount: 2}}
+	phase := PhaseDescriptor{PhaseType: PhaseTypeTrick, Data: []byte{1, 255, 1, 255}}
+
+	state.AceMode = AceLow
+	resolveTrick(state, genome, phase)
+	if state.TrickLeader != 1 {
+		t.Errorf("With Ace low, 2 should beat Ace; winner was %d", state.TrickLeader)
+	}
+}
+
+// TestRankValues checks the default point count and that a genome's table
+// drives blackjack totals, hand penalties and point captures alike.
+func TestRankValues(t *testing.T) {
+	for _, tt := range []struct {
+		rank uint8
+		want int32
+	}{{RankTwo, 2}, {RankTen, 10}, {RankJack, 10}, {RankKing, 10}, {RankAce, 1}} {
+		if got := RankPoints(nil, tt.rank); got != tt.want {
+			t.Errorf("Expected rank %d to count %d by default, got %d", tt.rank, tt.want, got)
+		}
+	}
+
+	// Court cards count 11-13 and the Ace a flat 15
+	values := []int32{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 15}
+	hand := []Card{{Rank: RankAce, Suit: 0}, {Rank: RankQueen, Suit: 1}}
+	if got := BlackjackValue(hand, nil); got != 21 {
+		t.Errorf("Expected A-Q to make 21 by default, got %d", got)
+	}
+	if got := BlackjackValue(hand, values); got != 27 {
+		t.Errorf("Expected A-Q to make 27 with a flat Ace, got %d", got)
+	}
+	if got := HandPenaltyValue(hand, nil, nil, values, 0); got != 27 {
+		t.Errorf("Expected a hand penalty of 27, got %d", got)
+	}
+
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.Players[0].Captured = []Card{{Rank: RankAce, Suit: 0}, {Rank: RankTwo, Suit: 1}}
+	state.Players[1].Captured = []Card{{Rank: RankFive, Suit: 2}}
+	points := CaptureCategory{Kind: CapturePoints, Points: 1}
+	if seat := CaptureCategoryWinner(state, points); seat != 1 {
+		t.Errorf("Expected 5 points to beat 3 by default, got player %d", seat)
+	}
+	state.RankValues = values
+	if seat := CaptureCategoryWinner(state, points); seat != 0 {
+		t.Errorf("Expected 17 points to beat 5 with a flat Ace, got player %d", seat)
+	}
+}
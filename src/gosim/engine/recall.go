@@ -0,0 +1,15 @@
+package engine
+
+// ForgottenCards returns the cards played to tricks this hand that a player
+// remembering only the last memory tricks no longer recalls: every trick
+// but the last memory ones. With memory 0 or less nothing is forgotten.
+func (gs *GameState) ForgottenCards(memory int) []Card {
+	if memory <= 0 || len(gs.PlayedTricks) <= memory {
+		return nil
+	}
+	var forgotten []Card
+	for _, trick := range gs.PlayedTricks[:len(gs.PlayedTricks)-memory] {
+		forgotten = append(forgotten, trick...)
+	}
+	return forgotten
+}
@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestForgottenCards(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	genome := &Genome{TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypeTrick}}}
+	tricks := [][]TrickCard{
+		{{PlayerID: 0, Card: Card{Rank: RankTwo, Suit: 0}}, {PlayerID: 1, Card: Card{Rank: RankFive, Suit: 0}}},
+		{{PlayerID: 1, Card: Card{Rank: RankKing, Suit: 1}}, {PlayerID: 0, Card: Card{Rank: RankAce, Suit: 1}}},
+	}
+	for _, trick := range tricks {
+		state.CurrentTrick = append(state.CurrentTrick[:0], trick...)
+		resolveTrick(state, genome, genome.TurnPhases[0])
+	}
+
+	if len(state.PlayedTricks) != 2 {
+		t.Fatalf("Expected both tricks remembered, got %v", state.PlayedTricks)
+	}
+	if got := state.ForgottenCards(0); got != nil {
+		t.Errorf("Expected perfect recall to forget nothing, got %v", got)
+	}
+	if got := state.ForgottenCards(2); got != nil {
+		t.Errorf("Expected a two-trick memory to hold both tricks, got %v forgotten", got)
+	}
+	want := []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankFive, Suit: 0}}
+	if got := state.ForgottenCards(1); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the first trick forgotten, got %v", got)
+	}
+
+	state.ResetHand()
+	if len(state.PlayedTricks) != 0 {
+		t.Errorf("Expected a new hand to start with no tricks played, got %v", state.PlayedTricks)
+	}
+}
@@ -0,0 +1,50 @@
+package engine
+
+// Where the cards left in the deck after the deal go (see DealRemainder).
+const (
+	RemainderDeck   uint8 = 0 // Stay in the deck to be drawn
+	RemainderKitty  uint8 = 1 // Set aside face down for the winning bidder (see TakeKitty)
+	RemainderBurn   uint8 = 2 // Taken out of play with the burned cards
+	RemainderDealer uint8 = 3 // Dealt to the dealer as extra cards
+)
+
+// DealRemainder disposes of the cards still in the deck once the hands and
+// starting cards are dealt. With RemainderDeck they stay where they are.
+func DealRemainder(state *GameState, disposition uint8) {
+	switch disposition {
+	case RemainderKitty:
+		state.Kitty = append(state.Kitty, state.Deck...)
+	case RemainderBurn:
+		state.Burned = append(state.Burned, state.Deck...)
+	case RemainderDealer:
+		dealer := &state.Players[state.Dealer]
+		dealer.Hand = append(dealer.Hand, state.Deck...)
+	default:
+		return
+	}
+	state.Deck = state.Deck[:0]
+}
+
+// TakeKitty gives the kitty to the player with the highest bid, ties going
+// to the lower seat, once bidding is over. Nil bids never take it. The
+// kitty stays set aside when nobody made a bid.
+func TakeKitty(state *GameState) {
+	if len(state.Kitty) == 0 {
+		return
+	}
+	taker := -1
+	for seat := 0; seat < int(state.NumPlayers); seat++ {
+		p := &state.Players[seat]
+		if p.CurrentBid < 0 || p.IsNilBid {
+			continue
+		}
+		if taker < 0 || p.CurrentBid > state.Players[taker].CurrentBid {
+			taker = seat
+		}
+	}
+	if taker < 0 {
+		return
+	}
+	state.Players[taker].Hand = append(state.Players[taker].Hand, state.Kitty...)
+	state.Kitty = state.Kitty[:0]
+}
@@ -0,0 +1,26 @@
+package engine
+
+import "testing"
+
+// TestTakeKitty checks the highest bidder picks up the kitty once every
+// player has bid, and that a Nil bid never takes it.
+func TestTakeKitty(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.Deck = append(state.Deck, Card{Rank: 0, Suit: 0}, Card{Rank: 1, Suit: 0}, Card{Rank: 2, Suit: 0})
+	DealRemainder(state, RemainderKitty)
+	if len(state.Kitty) != 3 || len(state.Deck) != 0 {
+		t.Fatalf("Expected the deck set aside as the kitty, got %d in the kitty and %d in the deck", len(state.Kitty), len(state.Deck))
+	}
+
+	ApplyBidMove(state, 0, BidMove{Value: 9, IsNil: true})
+	ApplyBidMove(state, 1, BidMove{Value: 3})
+	if len(state.Kitty) != 3 {
+		t.Fatal("Expected the kitty untouched until bidding is over")
+	}
+	ApplyBidMove(state, 2, BidMove{Value: 4})
+	if len(state.Kitty) != 0 || len(state.Players[2].Hand) != 3 || len(state.Players[0].Hand) != 0 {
+		t.Errorf("Expected seat 2 to take the kitty, got hands of %d, %d, %d", len(state.Players[0].Hand), len(state.Players[1].Hand), len(state.Players[2].Hand))
+	}
+}
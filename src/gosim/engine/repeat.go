@@ -0,0 +1,58 @@
+package engine
+
+// MaxPhaseRunsPerTurn caps how many times a repeating phase can run in a
+// single turn, so a repeat whose stop condition never holds still ends.
+const MaxPhaseRunsPerTurn = 52
+
+// PhaseRepeat lets a phase run several times in one turn before play passes
+// to the next player.
+type PhaseRepeat struct {
+	Count    int    // Most runs per turn (0 = until Until holds or the cap)
+	Until    []byte // Stop once this condition holds for the player (nil = none)
+	Optional bool   // Player may stop repeating at any point
+}
+
+// RepeatMoves narrows moves to those that continue the phase being repeated.
+// The phase's own pass moves are dropped, since stopping ends the turn;
+// MoveEndRepeat is offered instead when the repeat is optional or nothing
+// else is left.
+func RepeatMoves(moves []LegalMove, phaseIdx int, repeat *PhaseRepeat) []LegalMove {
+	kept := moves[:0]
+	for _, m := range moves {
+		if m.PhaseIndex == phaseIdx && m.CardIndex != MoveDrawPass && m.CardIndex != MovePlayPass {
+			kept = append(kept, m)
+		}
+	}
+	if len(kept) == 0 || repeat.Optional {
+		kept = append(kept, LegalMove{
+			PhaseIndex: phaseIdx,
+			CardIndex:  MoveEndRepeat,
+			TargetLoc:  LocationDeck, // Unused but required
+		})
+	}
+	return kept
+}
+
+// continueRepeat records a run of the phase move belongs to and reports
+// whether the current player goes again. Passing, ending the repeat,
+// reaching Count or the per-turn cap, or meeting Until all end the turn.
+func continueRepeat(state *GameState, move *LegalMove, repeat *PhaseRepeat) bool {
+	if repeat == nil || move.CardIndex == MoveEndRepeat || move.CardIndex == MoveDrawPass || move.CardIndex == MovePlayPass {
+		state.PhaseRuns = 0
+		return false
+	}
+
+	runs := 1
+	if state.PhaseRuns > 0 && state.RepeatPhase == move.PhaseIndex {
+		runs = state.PhaseRuns + 1
+	}
+	if runs >= MaxPhaseRunsPerTurn || (repeat.Count > 0 && runs >= repeat.Count) ||
+		(len(repeat.Until) >= 7 && EvaluateCondition(state, state.CurrentPlayer, repeat.Until)) {
+		state.PhaseRuns = 0
+		return false
+	}
+
+	state.RepeatPhase = move.PhaseIndex
+	state.PhaseRuns = runs
+	return true
+}
@@ -0,0 +1,70 @@
+package engine
+
+import "testing"
+
+func TestRepeatStopsAtPerTurnCap(t *testing.T) {
+	genome := &Genome{
+		TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypePlay, Repeat: &PhaseRepeat{}}},
+	}
+	state := NewGameState(2)
+	defer PutState(state)
+	for i := 0; i < 2*MaxPhaseRunsPerTurn; i++ {
+		state.Players[0].Hand = append(state.Players[0].Hand, Card{Rank: uint8(i % 13), Suit: uint8(i % 4)})
+	}
+
+	plays := 0
+	for state.CurrentPlayer == 0 && plays <= MaxPhaseRunsPerTurn {
+		ApplyMove(state, &LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationDiscard}, genome)
+		plays++
+	}
+
+	if plays != MaxPhaseRunsPerTurn {
+		t.Errorf("Expected the turn to end after %d runs, got %d", MaxPhaseRunsPerTurn, plays)
+	}
+	if state.PhaseRuns != 0 {
+		t.Errorf("Expected the repeat to be cleared, got %d runs", state.PhaseRuns)
+	}
+}
+
+func TestRefillHandWhenPlayPhaseEnds(t *testing.T) {
+	genome := &Genome{
+		TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypePlay, RefillTo: 3}},
+	}
+	state := NewGameState(2)
+	defer PutState(state)
+	state.Players[0].Hand = append(state.Players[0].Hand, Card{Rank: 0, Suit: 0}, Card{Rank: 1, Suit: 0}, Card{Rank: 2, Suit: 0})
+	state.Deck = append(state.Deck, Card{Rank: 3, Suit: 1}, Card{Rank: 4, Suit: 1})
+	play := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationDiscard}
+
+	// Two plays use up the deck, and the third reshuffles all but the top discard
+	for i, wantDeck := range []int{1, 0, 1} {
+		state.CurrentPlayer = 0
+		ApplyMove(state, &play, genome)
+		if len(state.Players[0].Hand) != 3 || len(state.Deck) != wantDeck {
+			t.Fatalf("Play %d: expected 3 cards in hand and %d in the deck, got %d and %d",
+				i+1, wantDeck, len(state.Players[0].Hand), len(state.Deck))
+		}
+	}
+
+	// With the deck and discards both used up the hand stays short
+	state.Deck = state.Deck[:0]
+	state.Discard = state.Discard[:0]
+	state.CurrentPlayer = 0
+	ApplyMove(state, &play, genome)
+	if len(state.Players[0].Hand) != 2 {
+		t.Errorf("Expected a short hand once no cards are left, got %d", len(state.Players[0].Hand))
+	}
+
+	// A repeating phase refills once, after the last play of the turn
+	genome.TurnPhases[0].Repeat = &PhaseRepeat{Count: 2}
+	state.Deck = append(state.Deck, Card{Rank: 5, Suit: 2}, Card{Rank: 6, Suit: 2}, Card{Rank: 7, Suit: 2})
+	state.CurrentPlayer = 0
+	ApplyMove(state, &play, genome)
+	if len(state.Players[0].Hand) != 1 {
+		t.Errorf("Expected no refill partway through a repeat, got %d cards", len(state.Players[0].Hand))
+	}
+	ApplyMove(state, &play, genome)
+	if len(state.Players[0].Hand) != 3 || state.CurrentPlayer != 1 {
+		t.Errorf("Expected a refill to 3 as the turn ends, got %d cards", len(state.Players[0].Hand))
+	}
+}
@@ -0,0 +1,42 @@
+package engine
+
+// Some games keep every hand hidden while play goes on and only score the
+// hands when the game ends, as rummy players lay down their melds at the
+// close. The reveal scores each final hand; until it, opponents' cards stay
+// hidden from the players and their AIs.
+
+// RevealValue returns the points hand scores when revealed at the end of
+// the game: the sum of any hand_end card scoring rules, otherwise the hand
+// evaluation's best pattern for pattern-matched hands, its total (nothing
+// once busted) for point-total hands, or the card values (see
+// HandPenaltyValue).
+func RevealValue(hand []Card, scoring []CardScoringRule, eval *HandEvaluation, values []int32, wild uint16) int32 {
+	for _, rule := range scoring {
+		if rule.Trigger == TriggerHandEnd {
+			return HandPenaltyValue(hand, scoring, eval, values, wild)
+		}
+	}
+	if eval != nil {
+		switch eval.Method {
+		case EvalMethodPatternMatch:
+			return int32(EvaluateHandPattern(hand, eval))
+		case EvalMethodPointTotal:
+			if IsBust(hand, eval) {
+				return 0
+			}
+			return int32(CalculateHandValue(hand, eval))
+		}
+	}
+	return HandPenaltyValue(hand, nil, eval, values, wild)
+}
+
+// RevealHands reveals every seated player's hand and adds its RevealValue
+// to their score. Afterwards every player knows every hand (see KnownCards).
+func RevealHands(state *GameState, scoring []CardScoringRule, eval *HandEvaluation) {
+	for i := 0; i < showPlayerCount(state); i++ {
+		value := RevealValue(state.Players[i].Hand, scoring, eval, state.RankValues, state.WildRanks)
+		state.Players[i].Score += value
+		UpdateTeamScore(state, i, value)
+	}
+	state.Revealed = true
+}
@@ -0,0 +1,54 @@
+package engine
+
+import "testing"
+
+func TestRevealValue(t *testing.T) {
+	kings := []Card{{Rank: RankKing, Suit: 0}, {Rank: RankKing, Suit: 1}, {Rank: RankFive, Suit: 2}}
+	pairs := &HandEvaluation{
+		Method:   EvalMethodPatternMatch,
+		Patterns: []HandPattern{{RankPriority: 3, SameRankGroups: []uint8{2}}},
+	}
+	blackjack := &HandEvaluation{Method: EvalMethodPointTotal, TargetValue: 21, BustThreshold: 22}
+
+	tests := []struct {
+		name    string
+		hand    []Card
+		scoring []CardScoringRule
+		eval    *HandEvaluation
+		want    int32
+	}{
+		{"card values", kings, nil, nil, 10 + 10 + 5},
+		{"hand end rules", kings, []CardScoringRule{{Suit: 255, Rank: RankKing, Points: 10, Trigger: TriggerHandEnd}}, pairs, 20},
+		{"best pattern", kings, nil, pairs, 3},
+		{"point total", kings[1:], nil, blackjack, int32(CalculateHandValue(kings[1:], blackjack))},
+		{"point total bust", kings, nil, blackjack, 0},
+	}
+	for _, tt := range tests {
+		if got := RevealValue(tt.hand, tt.scoring, tt.eval, nil, 0); got != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestRevealHands checks the reveal scores every hand and shows it to every
+// player, and that the next hand is hidden again.
+func TestRevealHands(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.Players[0].Hand = []Card{{Rank: RankTwo, Suit: 0}}
+	state.Players[1].Hand = []Card{{Rank: RankAce, Suit: 0}, {Rank: RankThree, Suit: 0}}
+
+	RevealHands(state, nil, nil)
+	if state.Players[0].Score != 2 || state.Players[1].Score != 4 {
+		t.Errorf("Expected scores 2 and 4, got %d and %d", state.Players[0].Score, state.Players[1].Score)
+	}
+	if known := state.KnownCards(0, 1); len(known) != 2 {
+		t.Errorf("Expected player 1's hand known after the reveal, got %v", known)
+	}
+
+	state.ResetHand()
+	if known := state.KnownCards(0, 1); len(known) != 0 {
+		t.Errorf("Expected the next hand hidden, got %v", known)
+	}
+}
@@ -8,12 +8,8 @@ func EvaluateContracts(state *GameState, scoring *ContractScoring) {
 	}
 
 	for teamIdx := 0; teamIdx < numTeams; teamIdx++ {
-		// Sum tricks won by team members
-		tricksWon := int32(0)
-		teamPlayers := getTeamPlayers(state, teamIdx)
-
 		// Score Nil bids first
-		for _, playerIdx := range teamPlayers {
+		for _, playerIdx := range getTeamPlayers(state, teamIdx) {
 			player := &state.Players[playerIdx]
 			if player.IsNilBid {
 				if player.TricksWon == 0 {
@@ -22,10 +18,10 @@ func EvaluateContracts(state *GameState, scoring *ContractScoring) {
 					state.TeamScores[teamIdx] -= int32(scoring.NilPenalty)
 				}
 			}
-			tricksWon += int32(player.TricksWon)
 		}
 
-		// Score team contract (non-Nil bids)
+		// Score team contract (non-Nil bids) against the partnership's pooled tricks
+		tricksWon := int32(TeamTricksWon(state, teamIdx))
 		contract := int32(state.TeamContracts[teamIdx])
 
 		if tricksWon >= contract {
@@ -47,6 +43,17 @@ func EvaluateContracts(state *GameState, scoring *ContractScoring) {
 	}
 }
 
+// TeamTricksWon returns the combined tricks won this hand by all members of
+// a team. Partners' tricks pool toward the team's combined contract, so a
+// player who falls short of their own bid can be covered by their partner.
+func TeamTricksWon(state *GameState, teamIdx int) int {
+	total := 0
+	for _, playerIdx := range getTeamPlayers(state, teamIdx) {
+		total += int(state.Players[playerIdx].TricksWon)
+	}
+	return total
+}
+
 // getTeamPlayers returns player indices for a team.
 func getTeamPlayers(state *GameState, teamIdx int) []int {
 	players := []int{}
@@ -67,9 +74,115 @@ func ResetHandState(state *GameState) {
 		state.Players[i].TricksWon = 0
 	}
 	state.BiddingComplete = false
+	state.TrumpNamed = false
 
 	// Reset team contracts but keep scores and bags
 	for i := range state.TeamContracts {
 		state.TeamContracts[i] = 0
 	}
 }
+
+// HandPenalty modes define who scores the cards left in hand at game end
+const (
+	HandPenaltyNone   uint8 = 0 // Cards left in hand are ignored
+	HandPenaltySelf   uint8 = 1 // Each player adds their own hand value
+	HandPenaltyWinner uint8 = 2 // The winner collects every opponent's hand value
+)
+
+// HandPenaltyValue returns the points a hand is worth when the game ends.
+// HAND_END card scoring rules are used if the genome has any, with the hand
+// arranged into its best melds, with the wild ranks in wild, for rules that
+// score only melded or only unmatched cards (see BestMelds); otherwise each
+// card counts its primary value from eval, or its points in values (see
+// RankPoints) if eval has none.
+func HandPenaltyValue(hand []Card, scoring []CardScoringRule, eval *HandEvaluation, values []int32, wild uint16) int32 {
+	hasHandEndRules := false
+	for _, rule := range scoring {
+		if rule.Trigger == TriggerHandEnd {
+			hasHandEndRules = true
+			break
+		}
+	}
+
+	total := int32(0)
+	if hasHandEndRules {
+		var melded []bool
+		for _, rule := range scoring {
+			if rule.Trigger == TriggerHandEnd && rule.Meld != MeldAny {
+				melded = BestMelds(hand, wild)
+				break
+			}
+		}
+		for i, card := range hand {
+			for _, rule := range scoring {
+				if rule.Trigger != TriggerHandEnd {
+					continue
+				}
+				if melded != nil && (rule.Meld == MeldMatched && !melded[i] || rule.Meld == MeldUnmatched && melded[i]) {
+					continue
+				}
+				suitMatch := rule.Suit == 255 || rule.Suit == card.Suit
+				rankMatch := rule.Rank == 255 || rule.Rank == card.Rank
+				if suitMatch && rankMatch {
+					total += int32(rule.Points)
+				}
+			}
+		}
+		return total
+	}
+
+	var points [13]int32
+	for rank := range points {
+		points[rank] = RankPoints(values, uint8(rank))
+	}
+	if eval != nil {
+		for _, cv := range eval.CardValues {
+			if int(cv.Rank) < len(points) {
+				points[cv.Rank] = int32(cv.Value)
+			}
+		}
+	}
+	for _, card := range hand {
+		if int(card.Rank) < len(points) {
+			total += points[card.Rank]
+		}
+	}
+	return total
+}
+
+// ApplyHandPenalties scores the cards left in every seated player's hand.
+// In HandPenaltySelf mode each player adds their own hand value, which suits
+// low-score games. In HandPenaltyWinner mode the winner adds the value of
+// every opponent's hand (teammates' hands are not counted); it does nothing
+// without a winner.
+func ApplyHandPenalties(state *GameState, mode uint8, winner int, scoring []CardScoringRule, eval *HandEvaluation) {
+	switch mode {
+	case HandPenaltySelf:
+		for i := 0; i < showPlayerCount(state); i++ {
+			value := HandPenaltyValue(state.Players[i].Hand, scoring, eval, state.RankValues, state.WildRanks)
+			state.Players[i].Score += value
+			UpdateTeamScore(state, i, value)
+		}
+
+	case HandPenaltyWinner:
+		if winner < 0 || winner >= showPlayerCount(state) {
+			return
+		}
+		for i := 0; i < showPlayerCount(state); i++ {
+			if i == winner || sameTeam(state, i, winner) {
+				continue
+			}
+			value := HandPenaltyValue(state.Players[i].Hand, scoring, eval, state.RankValues, state.WildRanks)
+			state.Players[winner].Score += value
+			UpdateTeamScore(state, winner, value)
+		}
+	}
+}
+
+// sameTeam reports whether players a and b are partners.
+func sameTeam(state *GameState, a, b int) bool {
+	if a >= len(state.PlayerToTeam) || b >= len(state.PlayerToTeam) {
+		return false
+	}
+	return state.PlayerToTeam[a] >= 0 && state.PlayerToTeam[a] == state.PlayerToTeam[b]
+}
@@ -242,3 +242,137 @@ func TestResetHandState(t *testing.T) {
 		t.Errorf("AccumulatedBags should persist")
 	}
 }
+
+func TestPartnershipTricksPoolTowardContract(t *testing.T) {
+	state := NewGameState(4)
+	defer PutState(state)
+	state.InitializeTeams([][]int{{0, 2}, {1, 3}})
+
+	// Team 0 bids 1+2=3, team 1 bids 1+1=2
+	ApplyBidMove(state, 0, BidMove{Value: 1})
+	ApplyBidMove(state, 1, BidMove{Value: 1})
+	ApplyBidMove(state, 2, BidMove{Value: 2})
+	ApplyBidMove(state, 3, BidMove{Value: 1})
+	if !state.BiddingComplete {
+		t.Fatal("Expected bidding to be complete")
+	}
+
+	// Play four tricks in spades: player 2 takes three, player 1 takes one.
+	// Player 0 takes none, short of their own bid of 1.
+	g := &Genome{}
+	phase := PhaseDescriptor{PhaseType: PhaseTypeTrick, Data: []byte{0, 255, 1, 255}}
+	trickWinners := []uint8{2, 2, 1, 2}
+	for _, winner := range trickWinners {
+		for p := uint8(0); p < 4; p++ {
+			rank := RankTwo + p
+			if p == winner {
+				rank = RankAce
+			}
+			state.CurrentTrick = append(state.CurrentTrick, TrickCard{PlayerID: p, Card: Card{Rank: rank, Suit: 3}})
+		}
+		resolveTrick(state, g, phase)
+	}
+
+	if got := TeamTricksWon(state, 0); got != 3 {
+		t.Errorf("Team 0 expected 3 pooled tricks, got %d", got)
+	}
+	if got := TeamTricksWon(state, 1); got != 1 {
+		t.Errorf("Team 1 expected 1 pooled trick, got %d", got)
+	}
+
+	scoring := ContractScoring{
+		PointsPerTrickBid:     10,
+		OvertrickPoints:       1,
+		FailedContractPenalty: 10,
+		BagLimit:              10,
+		BagPenalty:            100,
+	}
+	EvaluateContracts(state, &scoring)
+
+	// Team 0 made its combined contract of 3 despite player 0's shortfall
+	if state.TeamScores[0] != 30 {
+		t.Errorf("Team 0 expected 30 for making contract, got %d", state.TeamScores[0])
+	}
+	// Team 1 took 1 of 2
+	if state.TeamScores[1] != -20 {
+		t.Errorf("Team 1 expected -20 for failing contract, got %d", state.TeamScores[1])
+	}
+}
+
+func TestApplyHandPenaltiesSelfHighCards(t *testing.T) {
+	state := &GameState{
+		NumPlayers: 2,
+		Players: []PlayerState{
+			{Hand: []Card{{Rank: RankKing, Suit: 0}, {Rank: RankKing, Suit: 1}, {Rank: RankAce, Suit: 2}}},
+			{Hand: []Card{{Rank: RankTwo, Suit: 0}}},
+		},
+	}
+
+	// Default table: K=10 and A=1
+	ApplyHandPenalties(state, HandPenaltySelf, 1, nil, nil)
+	if state.Players[0].Score != 21 {
+		t.Errorf("Player stuck with K, K, A expected 21 penalty, got %d", state.Players[0].Score)
+	}
+	if state.Players[1].Score != 2 {
+		t.Errorf("Player holding a Two expected 2 penalty, got %d", state.Players[1].Score)
+	}
+}
+
+func TestApplyHandPenaltiesCardScoring(t *testing.T) {
+	state := &GameState{
+		NumPlayers: 2,
+		Players: []PlayerState{
+			{Hand: []Card{{Rank: RankKing, Suit: 0}, {Rank: RankKing, Suit: 1}, {Rank: RankAce, Suit: 2}}},
+			{Hand: []Card{{Rank: RankTwo, Suit: 0}}},
+		},
+	}
+	scoring := []CardScoringRule{
+		{Suit: 255, Rank: RankKing, Points: 25, Trigger: TriggerHandEnd},
+		{Suit: 255, Rank: RankAce, Points: 50, Trigger: TriggerHandEnd},
+		{Suit: 255, Rank: 255, Points: 1000, Trigger: TriggerTrickWin}, // Not a hand-end rule
+	}
+
+	ApplyHandPenalties(state, HandPenaltySelf, 1, scoring, nil)
+	if state.Players[0].Score != 100 {
+		t.Errorf("Expected 100 from HAND_END rules, got %d", state.Players[0].Score)
+	}
+	if state.Players[1].Score != 0 {
+		t.Errorf("Expected unscored Two to cost nothing, got %d", state.Players[1].Score)
+	}
+}
+
+func TestApplyHandPenaltiesWinnerCollects(t *testing.T) {
+	state := &GameState{
+		NumPlayers: 4,
+		Players: []PlayerState{
+			{},
+			{Hand: []Card{{Rank: RankKing, Suit: 0}}},
+			{Hand: []Card{{Rank: RankAce, Suit: 0}}},
+			{Hand: []Card{{Rank: RankFive, Suit: 0}}},
+		},
+		TeamScores:   []int32{0, 0},
+		PlayerToTeam: []int8{0, 1, 0, 1},
+	}
+	eval := &HandEvaluation{CardValues: []CardValue{{Rank: RankKing, Value: 10}, {Rank: RankAce, Value: 1}}}
+
+	ApplyHandPenalties(state, HandPenaltyWinner, 0, nil, eval)
+
+	// Opponents hold K (10) and 5 (5); the partner's Ace is not collected
+	if state.Players[0].Score != 15 {
+		t.Errorf("Winner expected 15, got %d", state.Players[0].Score)
+	}
+	if state.TeamScores[0] != 15 {
+		t.Errorf("Winning team expected 15, got %d", state.TeamScores[0])
+	}
+	for i := 1; i < 4; i++ {
+		if state.Players[i].Score != 0 {
+			t.Errorf("Player %d should not score, got %d", i, state.Players[i].Score)
+		}
+	}
+
+	// No winner, nothing collected
+	ApplyHandPenalties(state, HandPenaltyWinner, -1, nil, eval)
+	if state.Players[0].Score != 15 {
+		t.Errorf("Expected no change without a winner, got %d", state.Players[0].Score)
+	}
+}
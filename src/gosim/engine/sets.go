@@ -0,0 +1,80 @@
+package engine
+
+// Some games lay completed sets down as soon as a player holds them, as
+// Old Maid players discard their pairs and Go Fish players their books.
+// The cleanup takes them out of the hand without spending a move.
+
+// CleanupSets removes every complete set of size cards of one rank from
+// seat's hand, as many sets of a rank as the hand holds, and scores points
+// for each. Cards of the state's wild ranks then complete what sets they
+// can, the ranks nearest a set first; a set needs at least one natural
+// card. The sets go to seat's captured pile if captured is set, otherwise
+// to the discard pile. Returns the number of sets removed.
+func CleanupSets(state *GameState, seat int, size int, points int32, captured bool) int {
+	if size < 2 {
+		return 0
+	}
+	p := &state.Players[seat]
+	var counts [13]int
+	wilds := 0
+	for _, card := range p.Hand {
+		if IsWildRank(state.WildRanks, card.Rank) {
+			wilds++
+		} else if int(card.Rank) < len(counts) {
+			counts[card.Rank]++
+		}
+	}
+
+	sets := 0
+	var remove [13]int
+	for rank, n := range counts {
+		remove[rank] = n / size * size
+		sets += n / size
+	}
+	// Wilds complete the sets needing fewest of them
+	wildsUsed := 0
+	for need := 1; need < size && wilds-wildsUsed >= need; need++ {
+		for rank, n := range counts {
+			if n%size == size-need && wilds-wildsUsed >= need {
+				remove[rank] += n % size
+				wildsUsed += need
+				sets++
+			}
+		}
+	}
+	if sets == 0 {
+		return 0
+	}
+
+	kept := p.Hand[:0]
+	for _, card := range p.Hand {
+		if IsWildRank(state.WildRanks, card.Rank) {
+			if wildsUsed == 0 {
+				kept = append(kept, card)
+				continue
+			}
+			wildsUsed--
+		} else if int(card.Rank) >= len(remove) || remove[card.Rank] == 0 {
+			kept = append(kept, card)
+			continue
+		} else {
+			remove[card.Rank]--
+		}
+		if captured {
+			p.Captured = append(p.Captured, card)
+			state.noteCaptured(card)
+		} else {
+			state.Discard = append(state.Discard, card)
+		}
+		if points != 0 {
+			state.noteScored(card)
+		}
+	}
+	p.Hand = kept
+
+	if total := points * int32(sets); total != 0 {
+		p.Score += total
+		UpdateTeamScore(state, seat, total)
+	}
+	return sets
+}
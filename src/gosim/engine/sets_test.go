@@ -0,0 +1,56 @@
+package engine
+
+import "testing"
+
+// TestCleanupSets checks every complete set leaves the hand and scores,
+// while the odd cards of a rank stay.
+func TestCleanupSets(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.Players[0].Hand = []Card{
+		{Rank: RankFive, Suit: 0}, {Rank: RankKing, Suit: 0}, {Rank: RankFive, Suit: 1},
+		{Rank: RankFive, Suit: 2}, {Rank: RankTwo, Suit: 3}, {Rank: RankKing, Suit: 3},
+	}
+
+	// Pairs: the kings and two of the fives
+	if sets := CleanupSets(state, 0, 2, 1, false); sets != 2 {
+		t.Fatalf("Expected 2 pairs laid down, got %d", sets)
+	}
+	if hand := state.Players[0].Hand; len(hand) != 2 || hand[0].Rank != RankFive || hand[1].Rank != RankTwo {
+		t.Errorf("Expected a five and a two left in hand, got %v", hand)
+	}
+	if len(state.Discard) != 4 || state.Players[0].Score != 2 {
+		t.Errorf("Expected 4 cards discarded for 2 points, got %d cards and %d points", len(state.Discard), state.Players[0].Score)
+	}
+
+	// Nothing left to pair
+	if sets := CleanupSets(state, 0, 2, 1, false); sets != 0 {
+		t.Errorf("Expected no pairs left, got %d", sets)
+	}
+}
+
+// TestCleanupSetsWild checks a wild completes the set nearest complete and
+// a natural set is laid down without one.
+func TestCleanupSetsWild(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.WildRanks = 1 << RankTwo
+	state.Players[0].Hand = []Card{
+		{Rank: RankFive, Suit: 0}, {Rank: RankFive, Suit: 1}, {Rank: RankTwo, Suit: 3},
+		{Rank: RankNine, Suit: 0}, {Rank: RankKing, Suit: 0}, {Rank: RankKing, Suit: 1},
+		{Rank: RankKing, Suit: 2},
+	}
+
+	// Kings make a set; the wild completes the fives, not the lone nine
+	if sets := CleanupSets(state, 0, 3, 1, true); sets != 2 {
+		t.Fatalf("Expected 2 sets laid down, got %d", sets)
+	}
+	if hand := state.Players[0].Hand; len(hand) != 1 || hand[0].Rank != RankNine {
+		t.Errorf("Expected the nine left in hand, got %v", hand)
+	}
+	if len(state.Players[0].Captured) != 6 {
+		t.Errorf("Expected 6 cards captured, got %d", len(state.Players[0].Captured))
+	}
+}
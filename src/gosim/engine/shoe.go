@@ -0,0 +1,30 @@
+package engine
+
+// PlaceCutCard sets the reshuffle point so that the given penetration
+// (fraction of the current deck) is dealt before the shoe is finished.
+// A penetration of 0 or 1 deals the whole deck.
+func PlaceCutCard(state *GameState, penetration float64) {
+	if penetration <= 0 || penetration >= 1 {
+		state.CutCard = 0
+		return
+	}
+	state.CutCard = int(float64(len(state.Deck)) * (1 - penetration))
+}
+
+// ShoeFinished reports whether the deck has been dealt down to the cut card.
+// Hands in progress may still draw past it; the shoe is only checked
+// between hands, as at a real table.
+func ShoeFinished(state *GameState) bool {
+	return len(state.Deck) <= state.CutCard
+}
+
+// ReshuffleShoe gathers the discards back into the deck, shuffles, and
+// places a fresh cut card. Cards still held by players stay out of the
+// shoe, so it should be called between hands.
+func ReshuffleShoe(state *GameState, penetration float64, seed uint64) {
+	state.Deck = append(state.Deck, state.Discard...)
+	state.Discard = state.Discard[:0]
+	state.ConcealedDiscards = state.ConcealedDiscards[:0]
+	state.ShuffleDeck(seed)
+	PlaceCutCard(state, penetration)
+}
@@ -0,0 +1,57 @@
+package engine
+
+import "testing"
+
+func TestPlaceCutCard(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	for i := 0; i < 52; i++ {
+		state.Deck = append(state.Deck, Card{Rank: uint8(i % 13), Suit: uint8(i / 13)})
+	}
+
+	PlaceCutCard(state, 0.75)
+	if state.CutCard != 13 {
+		t.Errorf("Expected cut card 13 cards from the end, got %d", state.CutCard)
+	}
+	if ShoeFinished(state) {
+		t.Error("Fresh shoe should not be finished")
+	}
+
+	// Deal down to the cut card
+	state.Discard = append(state.Discard, state.Deck[13:]...)
+	state.Deck = state.Deck[:13]
+	if !ShoeFinished(state) {
+		t.Error("Shoe should be finished at the cut card")
+	}
+
+	// No penetration deals the whole deck
+	PlaceCutCard(state, 0)
+	if state.CutCard != 0 || ShoeFinished(state) {
+		t.Errorf("Expected no cut card, got %d", state.CutCard)
+	}
+}
+
+func TestReshuffleShoe(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	for i := 0; i < 40; i++ {
+		state.Discard = append(state.Discard, Card{Rank: uint8(i % 13), Suit: uint8(i % 4)})
+	}
+	for i := 0; i < 12; i++ {
+		state.Deck = append(state.Deck, Card{Rank: uint8(i % 13), Suit: 0})
+	}
+
+	ReshuffleShoe(state, 0.5, 7)
+	if len(state.Deck) != 52 || len(state.Discard) != 0 {
+		t.Errorf("Expected all 52 cards back in the shoe, got deck=%d discard=%d", len(state.Deck), len(state.Discard))
+	}
+	if state.CutCard != 26 {
+		t.Errorf("Expected cut card at 26, got %d", state.CutCard)
+	}
+
+	clone := state.Clone()
+	defer PutState(clone)
+	if clone.CutCard != state.CutCard {
+		t.Errorf("Clone lost cut card: got %d", clone.CutCard)
+	}
+}
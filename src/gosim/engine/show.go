@@ -0,0 +1,175 @@
+package engine
+
+import "sort"
+
+// ShowAward constants define what winners of a show phase receive
+const (
+	ShowAwardPot    uint8 = 0 // Winners split the pot
+	ShowAwardPoints uint8 = 1 // Winners gain points
+	ShowAwardBoth   uint8 = 2 // Winners split the pot and gain points
+)
+
+// showHighCards is how many cards break ties in high-card comparisons
+const showHighCards = 5
+
+// ShowDue reports whether the hands in play are ready to be revealed:
+// at least two players are still in the hand, each holding cards, and
+// no show has happened yet this hand.
+func ShowDue(state *GameState) bool {
+	if state.ShowComplete {
+		return false
+	}
+
+	contenders := 0
+	for i := 0; i < showPlayerCount(state); i++ {
+		p := &state.Players[i]
+		if p.HasFolded {
+			continue
+		}
+		if len(p.Hand) == 0 {
+			return false
+		}
+		contenders++
+	}
+	return contenders >= 2
+}
+
+// IsBust reports whether hand has reached eval's bust threshold in a
+// point-total game.
+func IsBust(hand []Card, eval *HandEvaluation) bool {
+	return eval != nil && eval.Method == EvalMethodPointTotal &&
+		eval.BustThreshold > 0 && CalculateHandValue(hand, eval) >= int(eval.BustThreshold)
+}
+
+// ScoreHand returns a comparable score for hand under eval; higher is better.
+// A negative score means the hand cannot win (e.g. busted in a point-total
+// game under BustLoses).
+// A nil eval or EvalMethodNone compares by high card.
+func ScoreHand(hand []Card, eval *HandEvaluation, mode AceMode) int {
+	if len(hand) == 0 {
+		return -1
+	}
+	if eval == nil {
+		return highCardScore(hand, mode)
+	}
+
+	switch eval.Method {
+	case EvalMethodPointTotal:
+		value := CalculateHandValue(hand, eval)
+		if IsBust(hand, eval) {
+			target := int(eval.TargetValue)
+			if target == 0 {
+				target = int(eval.BustThreshold) - 1
+			}
+			return BustScore(value, target, eval.Bust)
+		}
+		return value
+
+	case EvalMethodPatternMatch:
+		// Pattern priority dominates, high cards break ties
+		priority := int(EvaluateHandPattern(hand, eval))
+		return priority*highCardScoreRange() + highCardScore(hand, mode)
+
+	case EvalMethodCardCount:
+		return len(hand)
+
+	default:
+		return highCardScore(hand, mode)
+	}
+}
+
+// FindShowWinners compares the hands of all players still in the hand and
+// returns every player tied for the best score. Returns nil if no hand can win.
+func FindShowWinners(state *GameState, eval *HandEvaluation) []int {
+	var winners []int
+	best := -1
+
+	for i := 0; i < showPlayerCount(state); i++ {
+		p := &state.Players[i]
+		if p.HasFolded {
+			continue
+		}
+		score := ScoreHand(ShowdownCards(state, i), eval, state.AceMode)
+		if score < 0 {
+			continue
+		}
+		if score > best {
+			best = score
+			winners = append(winners[:0], i)
+		} else if score == best {
+			winners = append(winners, i)
+		}
+	}
+
+	return winners
+}
+
+// ResolveShow reveals and compares hands, then awards the pot and/or points
+// to the winners as configured. It is independent of any betting round, so
+// games without betting can still be decided by comparing hands.
+// Returns the winning player IDs.
+func ResolveShow(state *GameState, eval *HandEvaluation, show *ShowPhaseData) []int {
+	winners := FindShowWinners(state, eval)
+
+	if show.Award == ShowAwardPot || show.Award == ShowAwardBoth {
+		AwardPot(state, winners)
+	}
+	if (show.Award == ShowAwardPoints || show.Award == ShowAwardBoth) && show.Points != 0 {
+		for _, w := range winners {
+			state.Players[w].Score += int32(show.Points)
+			UpdateTeamScore(state, w, int32(show.Points))
+		}
+	}
+
+	if show.ClearHands {
+		// Revealed cards leave play; the next show waits for fresh hands
+		for i := 0; i < showPlayerCount(state); i++ {
+			state.Discard = append(state.Discard, state.Players[i].Hand...)
+			state.Players[i].Hand = state.Players[i].Hand[:0]
+		}
+	} else {
+		state.ShowComplete = true
+	}
+
+	return winners
+}
+
+// showPlayerCount returns the number of seated players, defaulting to 2.
+func showPlayerCount(state *GameState) int {
+	n := int(state.NumPlayers)
+	if n == 0 {
+		n = 2
+	}
+	if n > len(state.Players) {
+		n = len(state.Players)
+	}
+	return n
+}
+
+// highCardScore encodes the top cards of hand, highest first, as a single
+// base-14 number so hands compare card by card.
+func highCardScore(hand []Card, mode AceMode) int {
+	values := make([]int, len(hand))
+	for i, c := range hand {
+		values[i] = RankValue(c.Rank, mode) + 1 // 0 (low Ace) .. 13 (high Ace)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(values)))
+
+	score := 0
+	for i := 0; i < showHighCards; i++ {
+		score *= 14
+		if i < len(values) {
+			score += values[i]
+		}
+	}
+	return score
+}
+
+// highCardScoreRange is one more than the largest possible highCardScore.
+func highCardScoreRange() int {
+	r := 1
+	for i := 0; i < showHighCards; i++ {
+		r *= 14
+	}
+	return r
+}
@@ -0,0 +1,112 @@
+package engine
+
+import "testing"
+
+func TestScoreHandHighCard(t *testing.T) {
+	kingHigh := []Card{{Rank: RankKing, Suit: 0}, {Rank: RankTwo, Suit: 1}}
+	aceHigh := []Card{{Rank: RankAce, Suit: 0}, {Rank: RankTwo, Suit: 1}}
+	kingQueen := []Card{{Rank: RankKing, Suit: 2}, {Rank: RankQueen, Suit: 1}}
+
+	if ScoreHand(aceHigh, nil, AceHigh) <= ScoreHand(kingHigh, nil, AceHigh) {
+		t.Error("Ace-high should beat King-high with Ace high")
+	}
+	if ScoreHand(aceHigh, nil, AceLow) >= ScoreHand(kingHigh, nil, AceLow) {
+		t.Error("King-high should beat Ace-high with Ace low")
+	}
+	if ScoreHand(kingQueen, nil, AceHigh) <= ScoreHand(kingHigh, nil, AceHigh) {
+		t.Error("Second card should break ties between King-high hands")
+	}
+}
+
+func TestScoreHandPointTotalBust(t *testing.T) {
+	eval := &HandEvaluation{Method: EvalMethodPointTotal, TargetValue: 21, BustThreshold: 22}
+	fifteen := []Card{{Rank: RankNine, Suit: 0}, {Rank: RankSix, Suit: 1}}
+	thirty := []Card{{Rank: RankTen, Suit: 0}, {Rank: RankTen, Suit: 1}, {Rank: RankTen, Suit: 2}}
+
+	if got := ScoreHand(fifteen, eval, AceHigh); got != 15 {
+		t.Errorf("Expected point total 15, got %d", got)
+	}
+	if got := ScoreHand(thirty, eval, AceHigh); got >= 0 {
+		t.Errorf("Expected bust hand to score negative, got %d", got)
+	}
+}
+
+func TestFindShowWinnersSkipsFoldedAndSplitsTies(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.Players[0].Hand = append(state.Players[0].Hand, Card{Rank: RankQueen, Suit: 0})
+	state.Players[1].Hand = append(state.Players[1].Hand, Card{Rank: RankQueen, Suit: 1})
+	state.Players[2].Hand = append(state.Players[2].Hand, Card{Rank: RankAce, Suit: 2})
+	state.Players[2].HasFolded = true
+
+	winners := FindShowWinners(state, nil)
+	if len(winners) != 2 || winners[0] != 0 || winners[1] != 1 {
+		t.Errorf("Expected tied winners [0 1], got %v", winners)
+	}
+}
+
+func TestResolveShowAwardsPotAndPoints(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.Players[0].Hand = append(state.Players[0].Hand, Card{Rank: RankFive, Suit: 0})
+	state.Players[1].Hand = append(state.Players[1].Hand, Card{Rank: RankJack, Suit: 0})
+	state.Pot = 40
+
+	if !ShowDue(state) {
+		t.Fatal("Expected show to be due when both players hold cards")
+	}
+
+	winners := ResolveShow(state, nil, &ShowPhaseData{Award: ShowAwardBoth, Points: 3})
+	if len(winners) != 1 || winners[0] != 1 {
+		t.Fatalf("Expected player 1 to win, got %v", winners)
+	}
+	if state.Players[1].Chips != 40 || state.Pot != 0 {
+		t.Errorf("Expected pot awarded to player 1, chips=%d pot=%d", state.Players[1].Chips, state.Pot)
+	}
+	if state.Players[1].Score != 3 || state.Players[0].Score != 0 {
+		t.Errorf("Expected 3 points to player 1, scores=%d/%d", state.Players[0].Score, state.Players[1].Score)
+	}
+	if ShowDue(state) {
+		t.Error("Expected show not to repeat within the same hand")
+	}
+}
+
+func TestResolveShowClearHands(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.Players[0].Hand = append(state.Players[0].Hand, Card{Rank: RankFive, Suit: 0})
+	state.Players[1].Hand = append(state.Players[1].Hand, Card{Rank: RankJack, Suit: 0})
+
+	ResolveShow(state, nil, &ShowPhaseData{Award: ShowAwardPoints, Points: 1, ClearHands: true})
+
+	if len(state.Players[0].Hand) != 0 || len(state.Players[1].Hand) != 0 {
+		t.Error("Expected hands to be cleared after show")
+	}
+	if len(state.Discard) != 2 {
+		t.Errorf("Expected revealed cards in discard, got %d", len(state.Discard))
+	}
+
+	// Fresh hands make the show due again
+	state.Players[0].Hand = append(state.Players[0].Hand, Card{Rank: RankTwo, Suit: 0})
+	state.Players[1].Hand = append(state.Players[1].Hand, Card{Rank: RankThree, Suit: 0})
+	if !ShowDue(state) {
+		t.Error("Expected show to be due for the next hand")
+	}
+}
+
+func TestParseShowPhaseData(t *testing.T) {
+	// Format: award:1 + points:4 + clear_hands:1 = 6 bytes
+	data := []byte{ShowAwardPoints, 0, 0, 0, 5, 1}
+
+	show, err := ParseShowPhaseData(data)
+	if err != nil {
+		t.Fatalf("Failed to parse show phase data: %v", err)
+	}
+	if show.Award != ShowAwardPoints || show.Points != 5 || !show.ClearHands {
+		t.Errorf("Unexpected show phase data: %+v", show)
+	}
+
+	if _, err := ParseShowPhaseData(data[:3]); err == nil {
+		t.Error("Expected error for short data")
+	}
+}
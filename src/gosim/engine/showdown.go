@@ -0,0 +1,37 @@
+package engine
+
+// ShowdownReveal is a hand turned over at a showdown. Every player has seen
+// it, and may remember it in the hands that follow.
+type ShowdownReveal struct {
+	Hand   int    // HandsPlayed when it was shown
+	Player uint8
+	Cards  []Card // The hand as shown; never modified
+}
+
+// RevealShowdown turns over the hands at a showdown that winner won among
+// the players still in the hand, going round the table from the first to
+// bet this hand, and records each in state.ShowdownReveals. Every player
+// in shows unless muck is set, when the losers throw their hands in unseen
+// and only the winner shows. Returns the cards the players in held and how
+// many of them were shown.
+func RevealShowdown(state *GameState, winner int, muck bool) (held, shown int) {
+	numPlayers := int(state.NumPlayers)
+	for i := 0; i < numPlayers; i++ {
+		seat := (state.BettingStartPlayer + i) % numPlayers
+		p := &state.Players[seat]
+		if p.HasFolded {
+			continue
+		}
+		held += len(p.Hand)
+		if muck && seat != winner {
+			continue
+		}
+		shown += len(p.Hand)
+		state.ShowdownReveals = append(state.ShowdownReveals, ShowdownReveal{
+			Hand:   state.HandsPlayed,
+			Player: uint8(seat),
+			Cards:  append([]Card(nil), p.Hand...),
+		})
+	}
+	return held, shown
+}
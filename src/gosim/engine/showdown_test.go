@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRevealShowdownMuckedLosers(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.HandsPlayed = 2
+	state.BettingStartPlayer = 1
+	state.Players[0].Hand = []Card{{Rank: RankKing, Suit: 0}, {Rank: RankKing, Suit: 1}}
+	state.Players[1].Hand = []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankSeven, Suit: 2}}
+	state.Players[2].Hand = []Card{{Rank: RankAce, Suit: 3}, {Rank: RankFive, Suit: 1}}
+	state.Players[2].HasFolded = true
+
+	// Only the winner turns their hand over; the folded hand isn't held
+	held, shown := RevealShowdown(state, 0, true)
+	if held != 4 || shown != 2 {
+		t.Fatalf("Expected 2 of the 4 cards held to be shown, got %d of %d", shown, held)
+	}
+	want := []ShowdownReveal{{Hand: 2, Player: 0, Cards: state.Players[0].Hand}}
+	if !reflect.DeepEqual(state.ShowdownReveals, want) {
+		t.Errorf("Expected only the winner's hand shown, got %+v", state.ShowdownReveals)
+	}
+
+	// Without the muck every player in shows, from the first to bet
+	state.ShowdownReveals = state.ShowdownReveals[:0]
+	held, shown = RevealShowdown(state, 0, false)
+	if held != 4 || shown != 4 {
+		t.Fatalf("Expected all 4 cards held to be shown, got %d of %d", shown, held)
+	}
+	if len(state.ShowdownReveals) != 2 || state.ShowdownReveals[0].Player != 1 || state.ShowdownReveals[1].Player != 0 {
+		t.Errorf("Expected players 1 then 0 to show, got %+v", state.ShowdownReveals)
+	}
+}
@@ -1,5 +1,7 @@
 package engine
 
+import "math"
+
 // WinType constants for tension detection
 // These map to win condition types in bytecode
 const (
@@ -14,6 +16,8 @@ const (
 	WinTypeMostTricks   uint8 = 8 // Trick-collecting games (Spades)
 	WinTypeFewestTricks uint8 = 9 // Trick-avoidance games (Hearts)
 	WinTypeMostChips    uint8 = 10 // Poker cash games
+	WinTypeMatchWins    uint8 = 11 // Matches of independently scored hands
+	WinTypeLastChipStanding uint8 = 12 // Tournaments played until one player holds every chip
 )
 
 // TensionMetrics tracks tension curve data during simulation
@@ -25,11 +29,19 @@ type TensionMetrics struct {
 	WinnerWasTrailing bool   // True if winner was behind at midpoint (comeback win)
 
 	// Internal tracking (not serialized)
-	currentLeader int   // Player ID of current leader (-1 for tie)
-	leaderHistory []int // Leader at each turn (for permanent lead calculation)
+	currentLeader int     // Player ID of current leader (-1 for tie)
+	leaderHistory []int   // Leader at each turn (for permanent lead calculation)
+	marginSum     float64 // Sum of signed lead margins (for volatility)
+	marginSumSq   float64 // Sum of squared signed lead margins
+	marginFlipped bool    // Margins are negated: the lead has changed hands an odd number of times
 }
 
-// LeaderDetector interface for game-type-specific leader detection
+// LeaderDetector interface for game-type-specific leader detection.
+// Detectors rank every seat in play, however many there are: the leader is
+// the one seat strictly ahead of all others (-1 if the best is shared), and
+// the margin is the gap between the best and second-best seats, whatever
+// happens further back. Lead changes at 3-4 player tables are therefore
+// changes of who is first, not of any pairwise ordering.
 type LeaderDetector interface {
 	GetLeader(state *GameState) int     // Returns player ID or -1 for tie
 	GetMargin(state *GameState) float32 // Normalized gap (0-1), 0 = tied, 1 = max gap
@@ -44,23 +56,52 @@ func NewTensionMetrics(numPlayers int) *TensionMetrics {
 	}
 }
 
+// Pooled states always hold four players, so a detector ranks only the
+// seats in play: an empty seat has no cards and no score, and would
+// otherwise lead every shedding game with fewer than four players.
+
+// seatsInPlay returns the players taking part in the game.
+func seatsInPlay(state *GameState) []PlayerState {
+	n := int(state.NumPlayers)
+	if n == 0 || n > len(state.Players) {
+		n = len(state.Players)
+	}
+	return state.Players[:n]
+}
+
+// tricksBySeat returns the tricks won by each of the n seats in play.
+// TricksWon only grows as far as the highest seat to have won a trick, so
+// seats past its end have won none.
+func tricksBySeat(state *GameState) (tricks [4]uint8, n int) {
+	n = int(state.NumPlayers)
+	if n == 0 {
+		n = len(state.TricksWon)
+	}
+	if n > len(tricks) {
+		n = len(tricks)
+	}
+	copy(tricks[:n], state.TricksWon)
+	return tricks, n
+}
+
 // ScoreLeaderDetector - for score-based games (Gin Rummy, Scopa)
 // Higher score = winning
 type ScoreLeaderDetector struct{}
 
 func (d *ScoreLeaderDetector) GetLeader(state *GameState) int {
-	if len(state.Players) < 2 {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
 		return -1
 	}
-	maxScore := state.Players[0].Score
+	maxScore := players[0].Score
 	leader := 0
 	tied := false
-	for i := 1; i < len(state.Players); i++ {
-		if state.Players[i].Score > maxScore {
-			maxScore = state.Players[i].Score
+	for i := 1; i < len(players); i++ {
+		if players[i].Score > maxScore {
+			maxScore = players[i].Score
 			leader = i
 			tied = false
-		} else if state.Players[i].Score == maxScore {
+		} else if players[i].Score == maxScore {
 			tied = true
 		}
 	}
@@ -71,11 +112,12 @@ func (d *ScoreLeaderDetector) GetLeader(state *GameState) int {
 }
 
 func (d *ScoreLeaderDetector) GetMargin(state *GameState) float32 {
-	if len(state.Players) < 2 {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
 		return 0
 	}
 	var first, second int32 = 0, 0
-	for _, p := range state.Players {
+	for _, p := range players {
 		if p.Score > first {
 			second = first
 			first = p.Score
@@ -89,19 +131,74 @@ func (d *ScoreLeaderDetector) GetMargin(state *GameState) float32 {
 	return float32(first-second) / float32(first)
 }
 
+// LowScoreLeaderDetector - for avoidance games scored in points (Hearts)
+// Lower score = winning
+type LowScoreLeaderDetector struct{}
+
+func (d *LowScoreLeaderDetector) GetLeader(state *GameState) int {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
+		return -1
+	}
+	minScore := players[0].Score
+	leader := 0
+	tied := false
+	for i := 1; i < len(players); i++ {
+		if players[i].Score < minScore {
+			minScore = players[i].Score
+			leader = i
+			tied = false
+		} else if players[i].Score == minScore {
+			tied = true
+		}
+	}
+	if tied {
+		return -1
+	}
+	return leader
+}
+
+func (d *LowScoreLeaderDetector) GetMargin(state *GameState) float32 {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
+		return 0
+	}
+	first, second := players[0].Score, players[1].Score
+	if second < first {
+		first, second = second, first
+	}
+	maxScore := second
+	for _, p := range players[2:] {
+		if p.Score < first {
+			second = first
+			first = p.Score
+		} else if p.Score < second {
+			second = p.Score
+		}
+		if p.Score > maxScore {
+			maxScore = p.Score
+		}
+	}
+	if maxScore <= 0 {
+		return 0
+	}
+	return float32(second-first) / float32(maxScore)
+}
+
 // HandSizeLeaderDetector - for shedding games (Crazy 8s, President)
 // Fewer cards = winning
 type HandSizeLeaderDetector struct{}
 
 func (d *HandSizeLeaderDetector) GetLeader(state *GameState) int {
-	if len(state.Players) < 2 {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
 		return -1
 	}
-	minCards := len(state.Players[0].Hand)
+	minCards := len(players[0].Hand)
 	leader := 0
 	tied := false
-	for i := 1; i < len(state.Players); i++ {
-		cards := len(state.Players[i].Hand)
+	for i := 1; i < len(players); i++ {
+		cards := len(players[i].Hand)
 		if cards < minCards {
 			minCards = cards
 			leader = i
@@ -117,12 +214,13 @@ func (d *HandSizeLeaderDetector) GetLeader(state *GameState) int {
 }
 
 func (d *HandSizeLeaderDetector) GetMargin(state *GameState) float32 {
-	if len(state.Players) < 2 {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
 		return 0
 	}
 	first, second := 999, 999
 	maxCards := 0
-	for _, p := range state.Players {
+	for _, p := range players {
 		cards := len(p.Hand)
 		if cards > maxCards {
 			maxCards = cards
@@ -145,14 +243,15 @@ func (d *HandSizeLeaderDetector) GetMargin(state *GameState) float32 {
 type HandSizeMaxLeaderDetector struct{}
 
 func (d *HandSizeMaxLeaderDetector) GetLeader(state *GameState) int {
-	if len(state.Players) < 2 {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
 		return -1
 	}
-	maxCards := len(state.Players[0].Hand)
+	maxCards := len(players[0].Hand)
 	leader := 0
 	tied := false
-	for i := 1; i < len(state.Players); i++ {
-		cards := len(state.Players[i].Hand)
+	for i := 1; i < len(players); i++ {
+		cards := len(players[i].Hand)
 		if cards > maxCards {
 			maxCards = cards
 			leader = i
@@ -168,12 +267,13 @@ func (d *HandSizeMaxLeaderDetector) GetLeader(state *GameState) int {
 }
 
 func (d *HandSizeMaxLeaderDetector) GetMargin(state *GameState) float32 {
-	if len(state.Players) < 2 {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
 		return 0
 	}
 	var first, second int = 0, 0
 	var totalCards int = 0
-	for _, p := range state.Players {
+	for _, p := range players {
 		cards := len(p.Hand)
 		totalCards += cards
 		if cards > first {
@@ -194,18 +294,20 @@ func (d *HandSizeMaxLeaderDetector) GetMargin(state *GameState) float32 {
 type TrickLeaderDetector struct{}
 
 func (d *TrickLeaderDetector) GetLeader(state *GameState) int {
-	if len(state.TricksWon) < 2 {
+	seats, n := tricksBySeat(state)
+	tricks := seats[:n]
+	if n < 2 {
 		return -1
 	}
-	maxTricks := state.TricksWon[0]
+	maxTricks := tricks[0]
 	leader := 0
 	tied := false
-	for i := 1; i < len(state.TricksWon); i++ {
-		if state.TricksWon[i] > maxTricks {
-			maxTricks = state.TricksWon[i]
+	for i := 1; i < len(tricks); i++ {
+		if tricks[i] > maxTricks {
+			maxTricks = tricks[i]
 			leader = i
 			tied = false
-		} else if state.TricksWon[i] == maxTricks {
+		} else if tricks[i] == maxTricks {
 			tied = true
 		}
 	}
@@ -216,18 +318,20 @@ func (d *TrickLeaderDetector) GetLeader(state *GameState) int {
 }
 
 func (d *TrickLeaderDetector) GetMargin(state *GameState) float32 {
-	if len(state.TricksWon) < 2 {
+	seats, n := tricksBySeat(state)
+	tricks := seats[:n]
+	if n < 2 {
 		return 0
 	}
 	var first, second uint8 = 0, 0
 	var totalTricks uint8 = 0
-	for _, tricks := range state.TricksWon {
-		totalTricks += tricks
-		if tricks > first {
+	for _, won := range tricks {
+		totalTricks += won
+		if won > first {
 			second = first
-			first = tricks
-		} else if tricks > second {
-			second = tricks
+			first = won
+		} else if won > second {
+			second = won
 		}
 	}
 	if totalTricks == 0 {
@@ -241,18 +345,20 @@ func (d *TrickLeaderDetector) GetMargin(state *GameState) float32 {
 type TrickAvoidanceLeaderDetector struct{}
 
 func (d *TrickAvoidanceLeaderDetector) GetLeader(state *GameState) int {
-	if len(state.TricksWon) < 2 {
+	seats, n := tricksBySeat(state)
+	tricks := seats[:n]
+	if n < 2 {
 		return -1
 	}
-	minTricks := state.TricksWon[0]
+	minTricks := tricks[0]
 	leader := 0
 	tied := false
-	for i := 1; i < len(state.TricksWon); i++ {
-		if state.TricksWon[i] < minTricks {
-			minTricks = state.TricksWon[i]
+	for i := 1; i < len(tricks); i++ {
+		if tricks[i] < minTricks {
+			minTricks = tricks[i]
 			leader = i
 			tied = false
-		} else if state.TricksWon[i] == minTricks {
+		} else if tricks[i] == minTricks {
 			tied = true
 		}
 	}
@@ -263,18 +369,20 @@ func (d *TrickAvoidanceLeaderDetector) GetLeader(state *GameState) int {
 }
 
 func (d *TrickAvoidanceLeaderDetector) GetMargin(state *GameState) float32 {
-	if len(state.TricksWon) < 2 {
+	seats, n := tricksBySeat(state)
+	tricks := seats[:n]
+	if n < 2 {
 		return 0
 	}
 	var first, second uint8 = 255, 255
 	var totalTricks uint8 = 0
-	for _, tricks := range state.TricksWon {
-		totalTricks += tricks
-		if tricks < first {
+	for _, won := range tricks {
+		totalTricks += won
+		if won < first {
 			second = first
-			first = tricks
-		} else if tricks < second {
-			second = tricks
+			first = won
+		} else if won < second {
+			second = won
 		}
 	}
 	if totalTricks == 0 || second == 255 {
@@ -288,18 +396,19 @@ func (d *TrickAvoidanceLeaderDetector) GetMargin(state *GameState) float32 {
 type ChipLeaderDetector struct{}
 
 func (d *ChipLeaderDetector) GetLeader(state *GameState) int {
-	if len(state.Players) < 2 {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
 		return -1
 	}
-	maxChips := state.Players[0].Chips
+	maxChips := players[0].Chips
 	leader := 0
 	tied := false
-	for i := 1; i < len(state.Players); i++ {
-		if state.Players[i].Chips > maxChips {
-			maxChips = state.Players[i].Chips
+	for i := 1; i < len(players); i++ {
+		if players[i].Chips > maxChips {
+			maxChips = players[i].Chips
 			leader = i
 			tied = false
-		} else if state.Players[i].Chips == maxChips {
+		} else if players[i].Chips == maxChips {
 			tied = true
 		}
 	}
@@ -310,12 +419,13 @@ func (d *ChipLeaderDetector) GetLeader(state *GameState) int {
 }
 
 func (d *ChipLeaderDetector) GetMargin(state *GameState) float32 {
-	if len(state.Players) < 2 {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
 		return 0
 	}
 	var first, second int64 = 0, 0
 	var totalChips int64 = 0
-	for _, p := range state.Players {
+	for _, p := range players {
 		totalChips += p.Chips
 		if p.Chips > first {
 			second = first
@@ -330,6 +440,54 @@ func (d *ChipLeaderDetector) GetMargin(state *GameState) float32 {
 	return float32(first-second) / float32(totalChips)
 }
 
+// MatchLeaderDetector - for matches of independently scored hands
+// More hands won = winning
+type MatchLeaderDetector struct{}
+
+func (d *MatchLeaderDetector) GetLeader(state *GameState) int {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
+		return -1
+	}
+	maxWon := players[0].HandsWon
+	leader := 0
+	tied := false
+	for i := 1; i < len(players); i++ {
+		if players[i].HandsWon > maxWon {
+			maxWon = players[i].HandsWon
+			leader = i
+			tied = false
+		} else if players[i].HandsWon == maxWon {
+			tied = true
+		}
+	}
+	if tied {
+		return -1
+	}
+	return leader
+}
+
+func (d *MatchLeaderDetector) GetMargin(state *GameState) float32 {
+	players := seatsInPlay(state)
+	if len(players) < 2 {
+		return 0
+	}
+	var first, second, totalWon int32
+	for _, p := range players {
+		totalWon += p.HandsWon
+		if p.HandsWon > first {
+			second = first
+			first = p.HandsWon
+		} else if p.HandsWon > second {
+			second = p.HandsWon
+		}
+	}
+	if totalWon == 0 {
+		return 0
+	}
+	return float32(first-second) / float32(totalWon)
+}
+
 // Update called after each turn in the game loop
 func (tm *TensionMetrics) Update(state *GameState, detector LeaderDetector) {
 	newLeader := detector.GetLeader(state)
@@ -343,6 +501,7 @@ func (tm *TensionMetrics) Update(state *GameState, detector LeaderDetector) {
 	// Track lead changes (ignore ties)
 	if newLeader != -1 && tm.currentLeader != -1 && newLeader != tm.currentLeader {
 		tm.LeadChanges++
+		tm.marginFlipped = !tm.marginFlipped
 	}
 
 	// Update current leader
@@ -350,11 +509,43 @@ func (tm *TensionMetrics) Update(state *GameState, detector LeaderDetector) {
 		tm.currentLeader = newLeader
 	}
 
+	// Record the lead margin, changing its sign whenever the lead changes
+	// hands, so the margin swings across zero whichever seats trade the lead
+	signed := float64(margin)
+	if newLeader == -1 {
+		signed = 0
+	} else if tm.marginFlipped {
+		signed = -signed
+	}
+	tm.marginSum += signed
+	tm.marginSumSq += signed * signed
+
 	// Record leader for permanent lead calculation
 	tm.leaderHistory = append(tm.leaderHistory, tm.currentLeader)
 	tm.TotalTurns++
 }
 
+// MarginVolatility returns the standard deviation of the turn-by-turn lead
+// margin, its sign changing each time the lead changes hands (0 = the gap
+// never moved, 1 = maximal swings). Unlike LeadChanges it measures how far
+// momentum swings, not just how often: a lead that see-saws between large
+// margins scores higher than a small one that flips back and forth. In
+// two-player games the signed margin is the normalized score differential,
+// up to sign; at a bigger table a lead passing between any two seats swings
+// it the same way.
+func (tm *TensionMetrics) MarginVolatility() float32 {
+	if tm.TotalTurns == 0 {
+		return 0
+	}
+	n := float64(tm.TotalTurns)
+	mean := tm.marginSum / n
+	variance := tm.marginSumSq/n - mean*mean
+	if variance <= 0 {
+		return 0
+	}
+	return float32(math.Sqrt(variance))
+}
+
 // Finalize computes DecisiveTurn and WinnerWasTrailing based on winner
 // DecisiveTurn = first turn where winner took lead and NEVER lost it
 // WinnerWasTrailing = true if winner was behind at game midpoint
@@ -445,11 +636,14 @@ func SelectLeaderDetector(genome *Genome) LeaderDetector {
 			return &HandSizeLeaderDetector{}
 		case WinTypeHighScore, WinTypeFirstToScore:
 			return &ScoreLeaderDetector{}
-		case WinTypeLowScore, WinTypeFewestTricks:
+		case WinTypeLowScore:
+			// Hearts-style: penalty points tracked via Score
+			return &LowScoreLeaderDetector{}
+		case WinTypeFewestTricks:
 			return &TrickAvoidanceLeaderDetector{}
 		case WinTypeMostTricks:
 			return &TrickLeaderDetector{}
-		case WinTypeMostChips, WinTypeBestHand:
+		case WinTypeMostChips, WinTypeBestHand, WinTypeLastChipStanding:
 			return &ChipLeaderDetector{}
 		case WinTypeCaptureAll:
 			// War-style: captured cards go back to hand, more cards = winning
@@ -457,6 +651,8 @@ func SelectLeaderDetector(genome *Genome) LeaderDetector {
 		case WinTypeMostCaptured:
 			// Scopa-style: captured cards tracked via Score
 			return &ScoreLeaderDetector{}
+		case WinTypeMatchWins:
+			return &MatchLeaderDetector{}
 		}
 	}
 
@@ -314,9 +314,9 @@ func TestSelectLeaderDetector_LowScore(t *testing.T) {
 	}
 
 	detector := SelectLeaderDetector(genome)
-	_, ok := detector.(*TrickAvoidanceLeaderDetector)
+	_, ok := detector.(*LowScoreLeaderDetector)
 	if !ok {
-		t.Errorf("expected TrickAvoidanceLeaderDetector for WinTypeLowScore")
+		t.Errorf("expected LowScoreLeaderDetector for WinTypeLowScore")
 	}
 }
 
@@ -427,6 +427,59 @@ func TestTensionMetrics_Update_LeadChanges(t *testing.T) {
 	}
 }
 
+func TestTensionMetrics_MarginVolatility(t *testing.T) {
+	// Both games change leader on every turn; only the size of the lead differs
+	play := func(scores [][2]int32) *TensionMetrics {
+		tm := NewTensionMetrics(2)
+		detector := &ScoreLeaderDetector{}
+		for _, s := range scores {
+			tm.Update(&GameState{Players: []PlayerState{{Score: s[0]}, {Score: s[1]}}}, detector)
+		}
+		return tm
+	}
+	seeSaw := play([][2]int32{{100, 10}, {100, 400}, {900, 400}, {900, 2000}})
+	steady := play([][2]int32{{100, 95}, {100, 105}, {110, 105}, {110, 115}})
+
+	if seeSaw.LeadChanges != steady.LeadChanges {
+		t.Fatalf("expected equal lead changes, got %d and %d", seeSaw.LeadChanges, steady.LeadChanges)
+	}
+	if seeSaw.MarginVolatility() <= steady.MarginVolatility() {
+		t.Errorf("expected see-saw volatility %f > steady %f",
+			seeSaw.MarginVolatility(), steady.MarginVolatility())
+	}
+
+	// A gap that never moves has no volatility, whoever holds it
+	constant := play([][2]int32{{50, 100}, {50, 100}, {50, 100}})
+	if v := constant.MarginVolatility(); v > 1e-6 {
+		t.Errorf("expected zero volatility for a constant gap, got %f", v)
+	}
+	if v := NewTensionMetrics(2).MarginVolatility(); v != 0 {
+		t.Errorf("expected zero volatility with no turns, got %f", v)
+	}
+}
+
+func TestTensionMetrics_MarginVolatilityThreePlayers(t *testing.T) {
+	// Seats 1 and 2 trade a big lead while seat 0 trails throughout
+	play := func(scores [][3]int32) *TensionMetrics {
+		tm := NewTensionMetrics(3)
+		detector := &ScoreLeaderDetector{}
+		for _, s := range scores {
+			tm.Update(&GameState{Players: []PlayerState{{Score: s[0]}, {Score: s[1]}, {Score: s[2]}}}, detector)
+		}
+		return tm
+	}
+	seeSaw := play([][3]int32{{0, 100, 10}, {0, 100, 400}, {0, 900, 400}, {0, 900, 2000}})
+	steady := play([][3]int32{{0, 100, 10}, {0, 200, 110}, {0, 300, 210}, {0, 400, 310}})
+
+	if seeSaw.MarginVolatility() <= steady.MarginVolatility() {
+		t.Errorf("expected a lead traded between seats 1 and 2 to be more volatile than a steady one, got %f <= %f",
+			seeSaw.MarginVolatility(), steady.MarginVolatility())
+	}
+	if v := seeSaw.MarginVolatility(); v < 0.3 {
+		t.Errorf("expected big swings between seats 1 and 2 to read as volatile, got %f", v)
+	}
+}
+
 func TestTensionMetrics_Update_ClosestMargin(t *testing.T) {
 	tm := NewTensionMetrics(2)
 	detector := &ScoreLeaderDetector{}
@@ -499,3 +552,124 @@ func TestTensionMetrics_DecisiveTurnPct(t *testing.T) {
 		t.Errorf("expected DecisiveTurnPct=0.75, got %f", pct)
 	}
 }
+
+// TestLeaderDetectors_SeatCounts plays the same race at 2, 3 and 4 player
+// tables for each win condition: the last seat leads, then seat 0 takes
+// over and wins. Pooled states always hold four players, so the seats past
+// the table must not be ranked.
+func TestLeaderDetectors_SeatCounts(t *testing.T) {
+	// trimTricks drops the seats past the last trick winner, as TricksWon
+	// is only grown when a seat wins its first trick
+	trimTricks := func(tricks []uint8) []uint8 {
+		for len(tricks) > 0 && tricks[len(tricks)-1] == 0 {
+			tricks = tricks[:len(tricks)-1]
+		}
+		return tricks
+	}
+
+	cases := []struct {
+		name    string
+		winType uint8
+		lead    func(state *GameState, seat int) // Puts seat alone in the lead
+	}{
+		{"high score", WinTypeHighScore, func(state *GameState, seat int) {
+			for i := 0; i < int(state.NumPlayers); i++ {
+				state.Players[i].Score = 10
+			}
+			state.Players[seat].Score = 20
+		}},
+		{"low score", WinTypeLowScore, func(state *GameState, seat int) {
+			for i := 0; i < int(state.NumPlayers); i++ {
+				state.Players[i].Score = 20
+			}
+			state.Players[seat].Score = 5
+		}},
+		{"empty hand", WinTypeEmptyHand, func(state *GameState, seat int) {
+			for i := 0; i < int(state.NumPlayers); i++ {
+				state.Players[i].Hand = make([]Card, 5)
+			}
+			state.Players[seat].Hand = make([]Card, 2)
+		}},
+		{"capture", WinTypeCaptureAll, func(state *GameState, seat int) {
+			for i := 0; i < int(state.NumPlayers); i++ {
+				state.Players[i].Hand = make([]Card, 5)
+			}
+			state.Players[seat].Hand = make([]Card, 12)
+		}},
+		{"most tricks", WinTypeMostTricks, func(state *GameState, seat int) {
+			tricks := make([]uint8, state.NumPlayers)
+			tricks[seat] = 3
+			state.TricksWon = trimTricks(tricks)
+		}},
+		{"fewest tricks", WinTypeFewestTricks, func(state *GameState, seat int) {
+			tricks := make([]uint8, state.NumPlayers)
+			for i := range tricks {
+				tricks[i] = 2
+			}
+			tricks[seat] = 0
+			state.TricksWon = trimTricks(tricks)
+		}},
+		{"most chips", WinTypeMostChips, func(state *GameState, seat int) {
+			for i := 0; i < int(state.NumPlayers); i++ {
+				state.Players[i].Chips = 500
+			}
+			state.Players[seat].Chips = 900
+		}},
+		{"match wins", WinTypeMatchWins, func(state *GameState, seat int) {
+			for i := 0; i < int(state.NumPlayers); i++ {
+				state.Players[i].HandsWon = 1
+			}
+			state.Players[seat].HandsWon = 2
+		}},
+	}
+
+	for _, tc := range cases {
+		detector := SelectLeaderDetector(&Genome{WinConditions: []WinCondition{{WinType: tc.winType}}})
+		for n := 2; n <= 4; n++ {
+			state := NewGameState(n)
+			tm := NewTensionMetrics(n)
+			for _, seat := range []int{n - 1, n - 1, n - 1, 0, 0} {
+				tc.lead(state, seat)
+				if got := detector.GetLeader(state); got != seat {
+					t.Errorf("%s, %d players: expected seat %d to lead, got %d", tc.name, n, seat, got)
+				}
+				if margin := detector.GetMargin(state); margin <= 0 || margin > 1 {
+					t.Errorf("%s, %d players: expected a margin in (0, 1], got %f", tc.name, n, margin)
+				}
+				tm.Update(state, detector)
+			}
+			tm.Finalize(0)
+			if tm.LeadChanges != 1 || !tm.WinnerWasTrailing {
+				t.Errorf("%s, %d players: expected 1 lead change and a comeback, got %d changes, trailing=%v",
+					tc.name, n, tm.LeadChanges, tm.WinnerWasTrailing)
+			}
+			PutState(state)
+		}
+	}
+}
+
+// TestLeaderDetectors_SharedLead checks a lead shared by two of several
+// seats is a tie with no margin, whoever trails behind them.
+func TestLeaderDetectors_SharedLead(t *testing.T) {
+	state := NewGameState(4)
+	defer PutState(state)
+	for i, score := range []int32{15, 3, 15, 8} {
+		state.Players[i].Score = score
+	}
+	if leader := (&ScoreLeaderDetector{}).GetLeader(state); leader != -1 {
+		t.Errorf("Expected a tie for the highest score, got seat %d", leader)
+	}
+	if margin := (&ScoreLeaderDetector{}).GetMargin(state); margin != 0 {
+		t.Errorf("Expected no margin between tied leaders, got %f", margin)
+	}
+
+	for i, score := range []int32{3, 15, 8, 3} {
+		state.Players[i].Score = score
+	}
+	if leader := (&LowScoreLeaderDetector{}).GetLeader(state); leader != -1 {
+		t.Errorf("Expected a tie for the lowest score, got seat %d", leader)
+	}
+	if margin := (&LowScoreLeaderDetector{}).GetMargin(state); margin != 0 {
+		t.Errorf("Expected no margin between tied leaders, got %f", margin)
+	}
+}
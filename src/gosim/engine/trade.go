@@ -0,0 +1,98 @@
+package engine
+
+// AppendTradeMoves adds a TradePhase's moves: offering each card in the
+// current player's hand to each opponent the phase lets them trade with
+// who can pay for it (with a card, or with the phase's price in chips),
+// plus declining to trade.
+func AppendTradeMoves(moves []LegalMove, state *GameState, phaseIdx int, trade *TradePhaseData) []LegalMove {
+	hand := state.Players[state.CurrentPlayer].Hand
+	applyToTargets(state, trade.Target, nil, func(recipient int) {
+		if !canPayForTrade(state, recipient, trade.Price) {
+			return
+		}
+		for cardIdx := range hand {
+			moves = append(moves, LegalMove{
+				PhaseIndex: phaseIdx,
+				CardIndex:  cardIdx,
+				TargetLoc:  LocationTradeWith + Location(recipient),
+			})
+		}
+	})
+	return append(moves, LegalMove{
+		PhaseIndex: phaseIdx,
+		CardIndex:  MoveTradePass,
+		TargetLoc:  LocationHand,
+	})
+}
+
+// canPayForTrade reports whether recipient has what an offer asks of them:
+// a card to exchange, or price chips.
+func canPayForTrade(state *GameState, recipient int, price int32) bool {
+	if price > 0 {
+		return state.Players[recipient].Chips >= int64(price)
+	}
+	return len(state.Players[recipient].Hand) > 0
+}
+
+// TradeRecipient returns the player a trade move makes its offer to, or -1
+// for a move that offers nothing.
+func TradeRecipient(move *LegalMove) int {
+	if move.CardIndex < 0 || move.TargetLoc < LocationTradeWith {
+		return -1
+	}
+	return int(move.TargetLoc - LocationTradeWith)
+}
+
+// TradeReply is the greedy answer to an offer of card for price chips
+// (price 0 asks for a card in exchange): pay with the lowest-ranked card
+// in hand, and accept only a card that ranks above it, or pay chips only
+// up to the card's face value (2 for a two up to 14 for an ace). Returns
+// whether the offer is accepted and the index of the card given back.
+func TradeReply(state *GameState, recipient int, card Card, price int32) (accept bool, giveBack int) {
+	p := &state.Players[recipient]
+	if price > 0 {
+		return p.Chips >= int64(price) && price <= int32(card.Rank)+2, -1
+	}
+	if len(p.Hand) == 0 {
+		return false, -1
+	}
+	giveBack = 0
+	for i, c := range p.Hand {
+		if c.Rank < p.Hand[giveBack].Rank {
+			giveBack = i
+		}
+	}
+	return card.Rank > p.Hand[giveBack].Rank, giveBack
+}
+
+// ApplyTrade carries out an accepted offer: the offered card goes to the
+// recipient, who pays price chips or, for a card-for-card trade, hands
+// over the card at giveBack. Returns false, changing nothing, if the move
+// is not an offer the recipient can pay for.
+func ApplyTrade(state *GameState, move *LegalMove, price int32, giveBack int) bool {
+	proposer := int(state.CurrentPlayer)
+	recipient := TradeRecipient(move)
+	if recipient < 0 || recipient >= int(state.NumPlayers) || recipient == proposer {
+		return false
+	}
+	from, to := &state.Players[proposer], &state.Players[recipient]
+	if move.CardIndex >= len(from.Hand) || !canPayForTrade(state, recipient, price) {
+		return false
+	}
+	if price <= 0 && (giveBack < 0 || giveBack >= len(to.Hand)) {
+		return false
+	}
+
+	card := from.Hand[move.CardIndex]
+	from.Hand = append(from.Hand[:move.CardIndex], from.Hand[move.CardIndex+1:]...)
+	if price > 0 {
+		to.Chips -= int64(price)
+		from.Chips += int64(price)
+	} else {
+		back := to.Hand[giveBack]
+		to.Hand = append(to.Hand[:giveBack], to.Hand[giveBack+1:]...)
+		from.Hand = append(from.Hand, back)
+	}
+	to.Hand = append(to.Hand, card)
+	return true
+}
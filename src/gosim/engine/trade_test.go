@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// tradeGenome returns a genome whose only phase trades with target for
+// price chips (0 for a card).
+func tradeGenome(target uint8, price int32) *Genome {
+	data := make([]byte, 5)
+	data[0] = target
+	binary.BigEndian.PutUint32(data[1:], uint32(price))
+	return &Genome{TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypeTrade, Data: data}}}
+}
+
+// TestTradeCardForCard checks offers go to opponents with cards to pay,
+// and the greedy reply swaps its lowest card for a better one only.
+func TestTradeCardForCard(t *testing.T) {
+	state := NewGameState(3)
+	defer PutState(state)
+	state.NumPlayers = 3
+	state.Players[0].Hand = []Card{{Rank: RankKing, Suit: 0}, {Rank: RankTwo, Suit: 1}}
+	state.Players[1].Hand = []Card{{Rank: RankNine, Suit: 2}, {Rank: RankFive, Suit: 3}}
+	genome := tradeGenome(TARGET_ALL_OPPONENTS, 0)
+
+	moves := GenerateLegalMoves(state, genome)
+	if len(moves) != 3 || moves[2].CardIndex != MoveTradePass {
+		t.Fatalf("Expected offers of 2 cards to player 1 only and a pass, got %v", moves)
+	}
+	for _, m := range moves[:2] {
+		if TradeRecipient(&m) != 1 {
+			t.Errorf("Expected an offer to player 1, got %+v", m)
+		}
+	}
+
+	// The two is worse than anything player 1 holds
+	if accept, _ := TradeReply(state, 1, state.Players[0].Hand[1], 0); accept {
+		t.Error("Expected the two to be declined")
+	}
+
+	offer := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationTradeWith + 1}
+	ApplyMove(state, &offer, genome)
+	want0 := []Card{{Rank: RankTwo, Suit: 1}, {Rank: RankFive, Suit: 3}}
+	want1 := []Card{{Rank: RankNine, Suit: 2}, {Rank: RankKing, Suit: 0}}
+	for i := range want0 {
+		if state.Players[0].Hand[i] != want0[i] || state.Players[1].Hand[i] != want1[i] {
+			t.Fatalf("Expected the king swapped for the five, got hands %v and %v", state.Players[0].Hand, state.Players[1].Hand)
+		}
+	}
+	if state.CurrentPlayer != 1 {
+		t.Errorf("Expected the turn to pass to player 1, got player %d", state.CurrentPlayer)
+	}
+}
+
+// TestTradeCardForChips checks a chip price is paid only by an opponent
+// who can afford it and thinks the card is worth it.
+func TestTradeCardForChips(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.Players[0].Hand = []Card{{Rank: RankAce, Suit: 0}, {Rank: RankThree, Suit: 0}}
+	state.Players[1].Chips = 10
+	genome := tradeGenome(TARGET_NEXT_PLAYER, 8)
+
+	if accept, _ := TradeReply(state, 1, state.Players[0].Hand[1], 8); accept {
+		t.Error("Expected 8 chips for a three to be declined")
+	}
+	offer := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationTradeWith + 1}
+	ApplyMove(state, &offer, genome)
+	if state.Players[0].Chips != 8 || state.Players[1].Chips != 2 || len(state.Players[1].Hand) != 1 {
+		t.Errorf("Expected the ace sold for 8 chips, got chips %d/%d and hand %v",
+			state.Players[0].Chips, state.Players[1].Chips, state.Players[1].Hand)
+	}
+
+	// Player 1 can no longer afford the price, so player 0 can only pass
+	state.CurrentPlayer = 0
+	if moves := GenerateLegalMoves(state, genome); len(moves) != 1 || moves[0].CardIndex != MoveTradePass {
+		t.Errorf("Expected only a pass, got %v", moves)
+	}
+}
@@ -0,0 +1,195 @@
+package engine
+
+// Trick constraints restrict which cards a player may follow with, beyond
+// following suit. They are read from an optional sixth byte of a trick
+// phase, after the tie rule.
+const (
+	TrickMustBeat      uint8 = 1 << 0 // Must beat the winning card when able
+	TrickMustOvertrump uint8 = 1 << 1 // When void in the lead suit, must trump, over any trump already played
+
+	// Trump discipline: when a player may, or must, trump
+	TrickTrumpOnlyWhenVoid uint8 = 1 << 2 // May trump only when void in the lead suit
+	TrickMustTrump         uint8 = 1 << 3 // When void in the lead suit, must trump if able
+)
+
+// Next-leader rules decide who leads the trick after each one is taken. They
+// are read from an optional seventh byte of a trick phase, after the
+// constraints.
+const (
+	TrickLeadWinner   uint8 = 0 // The trick's winner leads
+	TrickLeadRotate   uint8 = 1 // The lead passes one seat on, whoever won
+	TrickLeadHighCard uint8 = 2 // Whoever played the highest-ranked card leads, whatever its suit
+)
+
+// TrickRules are the rules of a trick phase that decide who wins a trick
+// and what a player may follow with.
+type TrickRules struct {
+	LeadSuitRequired bool
+	TrumpSuit        uint8 // 255 = none
+	HighCardWins     bool
+	BreakingSuit     uint8 // 255 = none
+	TieRule          uint8
+	Constraints      uint8 // TrickMustBeat, TrickMustOvertrump and trump discipline flags
+	NextLeader       uint8 // TrickLeadWinner, TrickLeadRotate or TrickLeadHighCard
+}
+
+// ParseTrickRules reads a trick phase's data. A trump named in bidding
+// replaces the phase's own.
+func ParseTrickRules(state *GameState, data []byte) TrickRules {
+	rules := TrickRules{TrumpSuit: 255, HighCardWins: true, BreakingSuit: 255, TieRule: TrickTieFirstPlayed}
+	if len(data) >= 4 {
+		rules.LeadSuitRequired = data[0] == 1
+		rules.TrumpSuit = data[1]
+		rules.HighCardWins = data[2] == 1
+		rules.BreakingSuit = data[3]
+	}
+	if len(data) >= 5 {
+		rules.TieRule = data[4]
+	}
+	if len(data) >= 6 {
+		rules.Constraints = data[5]
+	}
+	if len(data) >= 7 {
+		rules.NextLeader = data[6]
+	}
+	if state.TrumpNamed {
+		rules.TrumpSuit = state.Trump
+	}
+	return rules
+}
+
+// beats reports whether card, played later, takes the trick from the
+// winning card.
+func (r TrickRules) beats(card, winning Card, leadSuit uint8, aceMode AceMode) bool {
+	cardRank := RankValue(card.Rank, aceMode)
+	winningRank := RankValue(winning.Rank, aceMode)
+
+	if r.TrumpSuit != 255 {
+		winnerIsTrump := winning.Suit == r.TrumpSuit
+		cardIsTrump := card.Suit == r.TrumpSuit
+
+		if cardIsTrump && !winnerIsTrump {
+			// Trump beats non-trump
+			return true
+		} else if cardIsTrump && winnerIsTrump {
+			// Both trump - compare ranks
+			return outranks(cardRank, winningRank, r.HighCardWins, r.TieRule)
+		} else if !cardIsTrump && !winnerIsTrump && card.Suit == leadSuit {
+			// Neither trump - must follow suit to win
+			if winning.Suit == leadSuit {
+				return outranks(cardRank, winningRank, r.HighCardWins, r.TieRule)
+			}
+			// Current winner didn't follow suit, this card does
+			return true
+		}
+		return false
+	}
+
+	// No trump - only lead suit counts
+	if card.Suit != leadSuit {
+		return false
+	}
+	if winning.Suit != leadSuit {
+		return true
+	}
+	return outranks(cardRank, winningRank, r.HighCardWins, r.TieRule)
+}
+
+// winner returns the index in trick of the card that takes it.
+func (r TrickRules) winner(trick []TrickCard, aceMode AceMode) int {
+	leadSuit := trick[0].Card.Suit
+	winnerIdx := 0
+	for i := 1; i < len(trick); i++ {
+		if r.beats(trick[i].Card, trick[winnerIdx].Card, leadSuit, aceMode) {
+			winnerIdx = i
+		}
+	}
+	return winnerIdx
+}
+
+// nextLeader returns the seat that leads after the current trick, which the
+// card at winnerIdx took. Ties for the highest card go to the earlier player.
+func (r TrickRules) nextLeader(state *GameState, winnerIdx int) uint8 {
+	trick := state.CurrentTrick
+	switch r.NextLeader {
+	case TrickLeadRotate:
+		return uint8(state.NextActiveSeat(int(trick[0].PlayerID)))
+	case TrickLeadHighCard:
+		high := 0
+		for i := 1; i < len(trick); i++ {
+			if RankValue(trick[i].Card.Rank, state.AceMode) > RankValue(trick[high].Card.Rank, state.AceMode) {
+				high = i
+			}
+		}
+		return trick[high].PlayerID
+	}
+	return trick[winnerIdx].PlayerID
+}
+
+// FirstTrick reports whether no trick has been taken yet this hand.
+func (gs *GameState) FirstTrick() bool {
+	for seat := 0; seat < showPlayerCount(gs); seat++ {
+		if gs.Players[seat].TricksWon > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Follows returns the indices of the cards in hand that may be played to a
+// trick already led. Following suit comes first; a player void in the lead
+// suit must then trump under TrickMustOvertrump or TrickMustTrump, and one
+// who isn't may not trump under TrickTrumpOnlyWhenVoid; and TrickMustBeat,
+// or trumping under TrickMustOvertrump, keeps only the cards that would
+// take the trick, when there are any.
+func (r TrickRules) Follows(hand []Card, trick []TrickCard, aceMode AceMode) []int {
+	leadSuit := trick[0].Card.Suit
+	winning := trick[r.winner(trick, aceMode)].Card
+
+	suitOnly := func(suit uint8) []int {
+		var idx []int
+		for i, card := range hand {
+			if card.Suit == suit {
+				idx = append(idx, i)
+			}
+		}
+		return idx
+	}
+
+	allowed := suitOnly(leadSuit)
+	void := len(allowed) == 0
+	mustBeat := r.Constraints&TrickMustBeat != 0
+	if void || !r.LeadSuitRequired {
+		trumps := []int(nil)
+		if void && r.Constraints&(TrickMustOvertrump|TrickMustTrump) != 0 && r.TrumpSuit != 255 {
+			trumps = suitOnly(r.TrumpSuit)
+		}
+		if len(trumps) > 0 {
+			allowed = trumps
+			mustBeat = mustBeat || r.Constraints&TrickMustOvertrump != 0
+		} else {
+			// Holding the lead suit, trumps stay in hand under
+			// TrickTrumpOnlyWhenVoid (unless trumps were led)
+			keepTrumps := void || r.Constraints&TrickTrumpOnlyWhenVoid == 0 || leadSuit == r.TrumpSuit
+			allowed = make([]int, 0, len(hand))
+			for i, card := range hand {
+				if keepTrumps || card.Suit != r.TrumpSuit {
+					allowed = append(allowed, i)
+				}
+			}
+		}
+	}
+
+	if mustBeat {
+		var beating []int
+		for _, i := range allowed {
+			if r.beats(hand[i], winning, leadSuit, aceMode) {
+				beating = append(beating, i)
+			}
+		}
+		if len(beating) > 0 {
+			allowed = beating
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTrickFollows checks the cards each combination of trick constraints
+// allows a follower, with spades trump.
+func TestTrickFollows(t *testing.T) {
+	nineOfHearts := Card{Rank: RankNine, Suit: 0}
+	tenOfSpades := Card{Rank: RankTen, Suit: 3}
+
+	tests := []struct {
+		name        string
+		follow      bool
+		constraints uint8
+		trick       []Card
+		hand        []Card
+		want        []int
+	}{
+		{"unconstrained", false, 0,
+			[]Card{nineOfHearts}, []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankAce, Suit: 1}}, []int{0, 1}},
+		{"follow suit", true, 0,
+			[]Card{nineOfHearts}, []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankKing, Suit: 0}, {Rank: RankAce, Suit: 1}}, []int{0, 1}},
+		{"follow suit, void", true, 0,
+			[]Card{nineOfHearts}, []Card{{Rank: RankTwo, Suit: 3}, {Rank: RankAce, Suit: 1}}, []int{0, 1}},
+		{"must beat", true, TrickMustBeat,
+			[]Card{nineOfHearts}, []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankKing, Suit: 0}, {Rank: RankAce, Suit: 1}}, []int{1}},
+		{"must beat, unable", true, TrickMustBeat,
+			[]Card{nineOfHearts}, []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankFive, Suit: 0}, {Rank: RankAce, Suit: 1}}, []int{0, 1}},
+		{"must beat the trump, not the lead", true, TrickMustBeat,
+			[]Card{nineOfHearts, tenOfSpades}, []Card{{Rank: RankKing, Suit: 0}, {Rank: RankTwo, Suit: 0}}, []int{0, 1}},
+		{"must beat, void, trumps", true, TrickMustBeat,
+			[]Card{nineOfHearts}, []Card{{Rank: RankTwo, Suit: 3}, {Rank: RankAce, Suit: 1}}, []int{0}},
+		{"must beat without following suit", false, TrickMustBeat,
+			[]Card{nineOfHearts}, []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankKing, Suit: 0}, {Rank: RankTwo, Suit: 3}}, []int{1, 2}},
+		{"must overtrump, following", true, TrickMustOvertrump,
+			[]Card{nineOfHearts}, []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankTwo, Suit: 3}}, []int{0}},
+		{"must overtrump, void, must trump", true, TrickMustOvertrump,
+			[]Card{nineOfHearts}, []Card{{Rank: RankTwo, Suit: 3}, {Rank: RankAce, Suit: 1}, {Rank: RankFive, Suit: 3}}, []int{0, 2}},
+		{"must overtrump, void, over a trump", true, TrickMustOvertrump,
+			[]Card{nineOfHearts, tenOfSpades}, []Card{{Rank: RankTwo, Suit: 3}, {Rank: RankAce, Suit: 1}, {Rank: RankQueen, Suit: 3}}, []int{2}},
+		{"must overtrump, void, unable to overtrump", true, TrickMustOvertrump,
+			[]Card{nineOfHearts, tenOfSpades}, []Card{{Rank: RankTwo, Suit: 3}, {Rank: RankAce, Suit: 1}}, []int{0}},
+		{"must overtrump, void, no trumps", true, TrickMustOvertrump,
+			[]Card{nineOfHearts}, []Card{{Rank: RankTwo, Suit: 2}, {Rank: RankAce, Suit: 1}}, []int{0, 1}},
+		{"both, following", true, TrickMustBeat | TrickMustOvertrump,
+			[]Card{nineOfHearts}, []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankKing, Suit: 0}, {Rank: RankAce, Suit: 3}}, []int{1}},
+		{"both, void", true, TrickMustBeat | TrickMustOvertrump,
+			[]Card{nineOfHearts, tenOfSpades}, []Card{{Rank: RankTwo, Suit: 3}, {Rank: RankKing, Suit: 3}, {Rank: RankAce, Suit: 1}}, []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := TrickRules{LeadSuitRequired: tt.follow, TrumpSuit: 3, HighCardWins: true, BreakingSuit: 255, Constraints: tt.constraints}
+			var trick []TrickCard
+			for i, c := range tt.trick {
+				trick = append(trick, TrickCard{PlayerID: uint8(i), Card: c})
+			}
+			if got := rules.Follows(tt.hand, trick, AceHigh); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected cards %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestTrickTrumpDiscipline checks each trump discipline against a follower
+// holding a trump and an off-suit discard, with spades trump and hearts led.
+func TestTrickTrumpDiscipline(t *testing.T) {
+	trick := []TrickCard{{PlayerID: 0, Card: Card{Rank: RankNine, Suit: 0}}}
+	void := []Card{{Rank: RankTwo, Suit: 3}, {Rank: RankAce, Suit: 1}}
+	holding := []Card{{Rank: RankTwo, Suit: 3}, {Rank: RankAce, Suit: 1}, {Rank: RankFive, Suit: 0}}
+
+	tests := []struct {
+		name        string
+		follow      bool
+		constraints uint8
+		hand        []Card
+		want        []int
+	}{
+		{"free, void", true, 0, void, []int{0, 1}},
+		{"free, holding the lead suit", false, 0, holding, []int{0, 1, 2}},
+		{"only when void, void", true, TrickTrumpOnlyWhenVoid, void, []int{0, 1}},
+		{"only when void, holding the lead suit", false, TrickTrumpOnlyWhenVoid, holding, []int{1, 2}},
+		{"must trump, void", true, TrickMustTrump, void, []int{0}},
+		{"must trump, holding the lead suit", false, TrickMustTrump, holding, []int{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := TrickRules{LeadSuitRequired: tt.follow, TrumpSuit: 3, HighCardWins: true, BreakingSuit: 255, Constraints: tt.constraints}
+			if got := rules.Follows(tt.hand, trick, AceHigh); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected cards %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	// Unlike TrickMustOvertrump, any trump will do
+	overTrumped := append(trick, TrickCard{PlayerID: 1, Card: Card{Rank: RankTen, Suit: 3}})
+	rules := TrickRules{LeadSuitRequired: true, TrumpSuit: 3, HighCardWins: true, BreakingSuit: 255, Constraints: TrickMustTrump}
+	if got := rules.Follows(void, overTrumped, AceHigh); !reflect.DeepEqual(got, []int{0}) {
+		t.Errorf("Expected an undertrump to be allowed, got %v", got)
+	}
+
+	// With trumps led, trumps are the lead suit
+	trumpLed := []TrickCard{{PlayerID: 0, Card: Card{Rank: RankNine, Suit: 3}}}
+	rules = TrickRules{TrumpSuit: 3, HighCardWins: true, BreakingSuit: 255, Constraints: TrickTrumpOnlyWhenVoid}
+	if got := rules.Follows(void, trumpLed, AceHigh); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Errorf("Expected trumps playable to a trump lead, got %v", got)
+	}
+}
+
+// TestGenerateLegalMovesMustBeat checks the constraints byte of a trick
+// phase reaches move generation.
+func TestGenerateLegalMovesMustBeat(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.NumPlayers = 2
+	state.CurrentPlayer = 1
+	state.CurrentTrick = []TrickCard{{PlayerID: 0, Card: Card{Rank: RankNine, Suit: 0}}}
+	state.Players[1].Hand = []Card{{Rank: RankTwo, Suit: 0}, {Rank: RankKing, Suit: 0}}
+
+	for _, tt := range []struct {
+		data []byte
+		want int
+	}{
+		{[]byte{1, 255, 1, 255}, 2},
+		{[]byte{1, 255, 1, 255, TrickTieFirstPlayed, TrickMustBeat}, 1},
+	} {
+		genome := &Genome{TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypeTrick, Data: tt.data}}}
+		moves := GenerateLegalMoves(state, genome)
+		if len(moves) != tt.want {
+			t.Errorf("Phase %v: expected %d moves, got %d", tt.data, tt.want, len(moves))
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package engine
+
+// FallbackMaxTurns is the turn limit of a game whose length doesn't follow
+// from its cards, and the most DefaultMaxTurns ever gives.
+const FallbackMaxTurns = 1000
+
+const (
+	minDefaultMaxTurns = 50 // Fewest turns DefaultMaxTurns gives
+	scoreRaceDeals     = 10 // Deals budgeted for a race to a score or a chip tournament
+)
+
+// ExpectedDeals is how many deals a game won by wins at a table of
+// numPlayers is budgeted: Threshold hands per player for a match of hands,
+// Threshold hands for a tournament capped at that many, scoreRaceDeals for
+// a race to a score or an uncapped tournament, and a single deal for
+// anything else. It is 0 for War-style capture games, whose captured cards
+// go back into play so that no number of deals can be judged.
+func ExpectedDeals(numPlayers int, wins []WinCondition) int {
+	deals := 1
+	for _, wc := range wins {
+		var n int
+		switch wc.WinType {
+		case WinTypeCaptureAll:
+			return 0
+		case WinTypeMatchWins:
+			n = int(wc.Threshold) * numPlayers
+		case WinTypeLastChipStanding:
+			n = scoreRaceDeals
+			if wc.Threshold > 0 {
+				n = int(wc.Threshold)
+			}
+		case WinTypeFirstToScore:
+			n = scoreRaceDeals
+		}
+		if n > deals {
+			deals = n
+		}
+	}
+	return deals
+}
+
+// DefaultMaxTurns is the turn limit for a game that sets none, sized to the
+// game rather than a flat FallbackMaxTurns. A deal takes about a turn for
+// every card in play, each being drawn or played once, so each deal is
+// budgeted twice cardsInPlay turns to leave room for passes, bets and bids,
+// and a game is budgeted ExpectedDeals deals. A 24-card trick game is then
+// cut off after 50 turns where a race to 500 over a full deck keeps the
+// full 1000. The limit is kept between minDefaultMaxTurns and
+// FallbackMaxTurns, the latter also standing for games whose deals can't
+// be counted.
+func DefaultMaxTurns(cardsInPlay, numPlayers int, wins []WinCondition) uint32 {
+	deals := ExpectedDeals(numPlayers, wins)
+	if deals == 0 || cardsInPlay <= 0 {
+		return FallbackMaxTurns
+	}
+	turns := 2 * cardsInPlay * deals
+	if turns < minDefaultMaxTurns {
+		return minDefaultMaxTurns
+	}
+	if turns > FallbackMaxTurns {
+		return FallbackMaxTurns
+	}
+	return uint32(turns)
+}
@@ -0,0 +1,25 @@
+package engine
+
+import "testing"
+
+func TestDefaultMaxTurns(t *testing.T) {
+	tests := []struct {
+		name        string
+		cardsInPlay int
+		numPlayers  int
+		wins        []WinCondition
+		want        uint32
+	}{
+		{"single deal of a full deck", 52, 4, []WinCondition{{WinType: WinTypeAllHandEmpty}}, 104},
+		{"short deck held at the floor", 20, 4, []WinCondition{{WinType: WinTypeEmptyHand}}, minDefaultMaxTurns},
+		{"match of hands", 20, 2, []WinCondition{{WinType: WinTypeMatchWins, Threshold: 3}}, 240},
+		{"capped tournament", 10, 2, []WinCondition{{WinType: WinTypeLastChipStanding, Threshold: 4}}, 80},
+		{"race to a score capped at the fallback", 52, 4, []WinCondition{{WinType: WinTypeFirstToScore, Threshold: 500}}, FallbackMaxTurns},
+		{"War keeps the fallback", 52, 2, []WinCondition{{WinType: WinTypeCaptureAll}}, FallbackMaxTurns},
+	}
+	for _, tt := range tests {
+		if got := DefaultMaxTurns(tt.cardsInPlay, tt.numPlayers, tt.wins); got != tt.want {
+			t.Errorf("%s: expected %d turns, got %d", tt.name, tt.want, got)
+		}
+	}
+}
@@ -6,7 +6,7 @@ import (
 
 // Card represents a playing card (1 byte)
 type Card struct {
-	Rank uint8 // 0-12 (A,2-10,J,Q,K)
+	Rank uint8 // 0-12 (2-10,J,Q,K,A); see RankAce
 	Suit uint8 // 0-3 (H,D,C,S)
 }
 
@@ -21,22 +21,53 @@ const (
 	// Optional extensions
 	LocationOpponentHand
 	LocationOpponentDiscard
+	LocationBurned  // Out of play for the rest of the game (see Burn)
+	LocationExposed // The player's own face-up area (see PlayerState.Exposed)
+	LocationPiles   // Whichever discard pile takes the card (see PileRule)
 )
 
 // PlayerState is mutable for performance
 type PlayerState struct {
-	Hand   []Card
+	Hand   []Card // Compact: every entry is a real card; playing one removes it
 	Score  int32
 	Active bool // Still in the game (not folded/eliminated)
 	// Optional extensions for betting games
-	Chips      int64 // Chip/token count for betting games (int64 for precision)
-	CurrentBet int64 // Current bet in this round (int64 for precision)
-	HasFolded  bool  // Folded this round
-	IsAllIn    bool  // Track all-in status (can't act but still in hand)
+	Chips       int64 // Chip/token count for betting games (int64 for precision)
+	CurrentBet  int64 // Current bet in this round (int64 for precision)
+	HasFolded   bool  // Folded this round
+	IsAllIn     bool  // Track all-in status (can't act but still in hand)
+	RaiseClosed bool  // Acted since the last full bet or raise, so may not raise again
+	// Betting history for the whole game, used for opponent modeling
+	BettingTurns uint32 // Betting decisions made
+	BetsMade     uint32 // Decisions that put in chips voluntarily (bet, raise, all-in)
 	// Bidding fields (reset each hand)
 	CurrentBid int8 // -1 = not bid, 0+ = bid amount
 	IsNilBid   bool // True if this is a Nil bid
 	TricksWon  int8 // Tricks won this hand
+
+	// Bonus points from captures that cleared the table, kept apart from
+	// the card points they are included in Score with
+	SweepPoints int32
+
+	// Hands won in a match where each hand is scored on its own
+	HandsWon int32
+
+	// Draws taken from draw phases this game, for the per-game draw limit
+	Draws int32
+
+	// Cards won off the table this game, from tricks and rank-match
+	// captures, kept face down for end-of-game category scoring
+	Captured []Card
+
+	// Cards the player has laid face up in front of them, such as melds
+	// laid down or penalty cards taken, seen by every player and kept
+	// until the end of the game for exposed card scoring
+	Exposed []Card
+
+	// The player's own draw and discard piles when GameState.PersonalDecks
+	// is set; the discards are shuffled back into Deck when it runs out
+	Deck    []Card
+	Discard []Card
 }
 
 // Claim represents a bluffing claim for games like I Doubt It, Cheat, BS
@@ -61,34 +92,75 @@ type GameState struct {
 	Deck          []Card
 	Discard       []Card
 	Tableau       [][]Card // For games like War, Gin Rummy
+	Community     []Card   // Face-up cards shared by every hand, as in Hold'em (see DealCommunity)
+	Burned        []Card   // Cards out of play for the rest of the game; no reshuffle returns them
+	Kitty         []Card   // Cards left from the deal, set aside face down for the winning bidder (see TakeKitty)
+	PersonalDecks bool     // Each player draws from and discards to their own piles (see SetupPersonalDecks)
 	CurrentPlayer uint8
 	TurnNumber    uint32
 	WinnerID      int8 // -1 = no winner yet, 0/1 = player ID
+	CutCard       int  // Cards left in Deck when the shoe is due for a reshuffle
 	// Optional extensions for betting games
 	Pot                int64 // Current pot size (int64 for precision)
 	CurrentBet         int64 // Highest bet in current round (int64 for precision)
 	RaiseCount         int   // Raises this round
+	LastRaise          int64 // Size of the last full bet or raise this round (see MinRaise)
 	BettingStartPlayer int   // Rotates each hand for position fairness
+	Dealer             int   // Seat dealing the current hand
+	HandsPlayed        int   // Hands finished so far (counted by ResetHand)
 	BettingComplete    bool  // True after betting round finishes (for blackjack: betting before draw)
+	ShowComplete       bool  // True after hands were revealed and compared this hand
+	ChipBridge         uint8 // How chips and points settle into each other (see AwardPot)
+	// Hidden information state
+	Peeks    []Peek // Opponent hands seen through a PeekPhase this hand
+	Revealed bool   // Every hand was shown for scoring at the end of the game (see RevealHands)
+	HandSizesHidden bool // Players cannot count the cards in opponents' hands (see KnownHandSize)
+	ConcealedDiscards []ConcealedDiscard // Face-down cards on the discard pile (see ConcealedFrom)
+	ShowdownReveals   []ShowdownReveal   // Hands shown at showdowns this game, in the order shown (see RevealShowdown)
+	// Shedding state
+	FinishOrder []uint8 // Seats in the order they went out this hand (see RecordFinishers)
+	// Statistics
+	Usage *CardUsage // Where to record card usage (nil = not tracked; clones never track)
 	// Optional extensions for bluffing games
-	CurrentClaim *Claim // nil if no active claim
+	CurrentClaim  *Claim // nil if no active claim
+	LastClaimRank int8   // Rank the last claim named, -1 once a challenge clears the pile (see AppendClaims)
 	// Trick-taking game state
 	CurrentTrick   []TrickCard // Cards played in current trick
 	TrickLeader    uint8       // Who leads the current trick
 	TricksWon      []uint8     // Count of tricks won by each player
+	PlayedTricks   [][]Card    // Cards of each trick completed this hand, oldest first (see ForgottenCards)
 	HeartsBroken   bool        // For Hearts: whether hearts have been played
 	NumPlayers     uint8       // Number of players (for trick completion check)
 	CardsPerPlayer int         // Cards dealt to each player (for hand size check)
 	// Tableau mode for card matching games
 	TableauMode       uint8 // 0=NONE, 1=WAR, 2=MATCH_RANK, 3=SEQUENCE
 	SequenceDirection uint8 // 0=ASC, 1=DESC, 2=BOTH
+	AceMode           AceMode // Ace high, low, or both for sequences and comparisons
+	RankValues        []int32 // Points per rank for hand values (nil = DefaultRankValues; never modified)
+	WildRanks         uint16  // Bit r set when rank r is wild in runs and sets (see IsWildRank)
+	WarTieRule        uint8   // How a War battle tied for the high card is settled (WarTie*)
 	// Special effects state
 	PlayDirection int8  // 1 = clockwise, -1 = counter-clockwise
 	SkipCount     uint8 // Number of players to skip (capped at NumPlayers-1)
+	// Effect chain state
+	EffectsPlayer   uint8 // Player whose turn EffectsThisTurn counts
+	EffectsThisTurn int   // Effects resolved so far this turn
+	EffectsCapped   int   // Effects ignored this game because a turn hit the cap
+	MovesCapped     int   // Decisions this game whose legal moves were sampled down to the cap (see CapMoves)
+	// Draw limit state
+	DrawsPlayer    uint8 // Player whose turn DrawsTurnCount counts
+	DrawsTurnCount int   // Draws taken from draw phases so far this turn
 	// Blackjack-specific state
 	HasStood []bool // Track which players have stood (for blackjack)
 	// President/climbing game state
 	ConsecutivePasses int // Track consecutive passes (for clearing tableau)
+	// Repeating phase state
+	RepeatPhase int // Phase the current player is repeating
+	PhaseRuns   int // Times RepeatPhase has run this turn (0 = not repeating)
+	// Draw-discard state: a DrawDiscard phase's draw keeps the turn until the
+	// player discards in that phase
+	DiscardPending bool
+	DiscardPhase   int // Phase the player must discard in, while DiscardPending
 	// Team play fields
 	TeamScores   []int32 // Score for each team (nil if no teams)
 	PlayerToTeam []int8  // Maps player index -> team index (-1 if no teams)
@@ -97,6 +169,14 @@ type GameState struct {
 	BiddingComplete bool   // True when all players have bid
 	TeamContracts   []int8 // Contract per team (sum of non-Nil bids)
 	AccumulatedBags []int8 // Bags per team, persists across hands
+	// Trump named by the highest bidder, which replaces the trick phase's
+	// trump for the rest of the hand
+	Trump      uint8
+	TrumpNamed bool
+	// Face-up discard piles besides Discard, such as patience foundations,
+	// and which cards each takes (see PileRule; the rules are never modified)
+	Piles     [][]Card
+	PileRules []PileRule
 }
 
 // StatePool manages GameState memory
@@ -144,41 +224,87 @@ func (s *GameState) Reset() {
 		s.Players[i].CurrentBet = 0
 		s.Players[i].HasFolded = false
 		s.Players[i].IsAllIn = false
+		s.Players[i].RaiseClosed = false
+		s.Players[i].BettingTurns = 0
+		s.Players[i].BetsMade = 0
 		// Bidding fields
 		s.Players[i].CurrentBid = -1
 		s.Players[i].IsNilBid = false
 		s.Players[i].TricksWon = 0
+		s.Players[i].SweepPoints = 0
+		s.Players[i].HandsWon = 0
+		s.Players[i].Draws = 0
+		s.Players[i].Captured = s.Players[i].Captured[:0]
+		s.Players[i].Exposed = s.Players[i].Exposed[:0]
+		s.Players[i].Deck = s.Players[i].Deck[:0]
+		s.Players[i].Discard = s.Players[i].Discard[:0]
 	}
 
 	s.Deck = s.Deck[:0]
 	s.Discard = s.Discard[:0]
 	s.Tableau = s.Tableau[:0]
+	s.Piles = s.Piles[:0]
+	s.PileRules = nil
+	s.Community = s.Community[:0]
+	s.Burned = s.Burned[:0]
+	s.Kitty = s.Kitty[:0]
+	s.PersonalDecks = false
 	s.CurrentPlayer = 0
 	s.TurnNumber = 0
 	s.WinnerID = -1
+	s.CutCard = 0
 	s.Pot = 0
 	s.CurrentBet = 0
 	s.RaiseCount = 0
+	s.LastRaise = 0
 	s.BettingComplete = false
+	s.ShowComplete = false
+	s.ChipBridge = ChipBridgeNone
+	s.Peeks = s.Peeks[:0]
+	s.Revealed = false
+	s.HandSizesHidden = false
+	s.ConcealedDiscards = s.ConcealedDiscards[:0]
+	s.ShowdownReveals = s.ShowdownReveals[:0]
+	s.FinishOrder = s.FinishOrder[:0]
+	s.Usage = nil
 	s.BettingStartPlayer = 0
+	s.Dealer = 0
+	s.HandsPlayed = 0
 	s.CurrentClaim = nil
+	s.LastClaimRank = -1
 	// Trick-taking state
 	s.CurrentTrick = s.CurrentTrick[:0]
 	s.TrickLeader = 0
 	s.TricksWon = s.TricksWon[:0]
+	s.PlayedTricks = s.PlayedTricks[:0]
 	s.HeartsBroken = false
 	s.NumPlayers = 2
 	s.CardsPerPlayer = 0
 	s.TableauMode = 0
 	s.SequenceDirection = 0
+	s.AceMode = AceHigh
+	s.RankValues = nil
+	s.WildRanks = 0
+	s.WarTieRule = WarTieAlternate
 	s.PlayDirection = 1
 	s.SkipCount = 0
+	s.EffectsPlayer = 0
+	s.EffectsThisTurn = 0
+	s.EffectsCapped = 0
+	s.MovesCapped = 0
+	s.DrawsPlayer = 0
+	s.DrawsTurnCount = 0
 	// Blackjack state
 	for i := 0; i < len(s.HasStood); i++ {
 		s.HasStood[i] = false
 	}
 	// President state
 	s.ConsecutivePasses = 0
+	// Repeating phase state
+	s.RepeatPhase = 0
+	s.PhaseRuns = 0
+	s.DiscardPending = false
+	s.DiscardPhase = 0
 	// Team state
 	s.TeamScores = nil
 	s.PlayerToTeam = nil
@@ -187,6 +313,8 @@ func (s *GameState) Reset() {
 	s.BiddingComplete = false
 	s.TeamContracts = nil
 	s.AccumulatedBags = nil
+	s.Trump = 0
+	s.TrumpNamed = false
 }
 
 // Clone creates a deep copy for MCTS tree search
@@ -206,30 +334,61 @@ func (s *GameState) Clone() *GameState {
 		clone.Players[i].CurrentBet = s.Players[i].CurrentBet
 		clone.Players[i].HasFolded = s.Players[i].HasFolded
 		clone.Players[i].IsAllIn = s.Players[i].IsAllIn
+		clone.Players[i].RaiseClosed = s.Players[i].RaiseClosed
+		clone.Players[i].BettingTurns = s.Players[i].BettingTurns
+		clone.Players[i].BetsMade = s.Players[i].BetsMade
 		// Bidding fields
 		clone.Players[i].CurrentBid = s.Players[i].CurrentBid
 		clone.Players[i].IsNilBid = s.Players[i].IsNilBid
 		clone.Players[i].TricksWon = s.Players[i].TricksWon
+		clone.Players[i].SweepPoints = s.Players[i].SweepPoints
+		clone.Players[i].HandsWon = s.Players[i].HandsWon
+		clone.Players[i].Draws = s.Players[i].Draws
+		clone.Players[i].Captured = append(clone.Players[i].Captured, s.Players[i].Captured...)
+		clone.Players[i].Exposed = append(clone.Players[i].Exposed, s.Players[i].Exposed...)
+		clone.Players[i].Deck = append(clone.Players[i].Deck, s.Players[i].Deck...)
+		clone.Players[i].Discard = append(clone.Players[i].Discard, s.Players[i].Discard...)
 	}
 
 	clone.Deck = append(clone.Deck, s.Deck...)
 	clone.Discard = append(clone.Discard, s.Discard...)
+	clone.Community = append(clone.Community, s.Community...)
+	clone.Burned = append(clone.Burned, s.Burned...)
+	clone.Kitty = append(clone.Kitty, s.Kitty...)
+	clone.PersonalDecks = s.PersonalDecks
 
 	for _, pile := range s.Tableau {
 		tableuClone := make([]Card, len(pile))
 		copy(tableuClone, pile)
 		clone.Tableau = append(clone.Tableau, tableuClone)
 	}
+	for _, pile := range s.Piles {
+		clone.Piles = append(clone.Piles, append([]Card(nil), pile...))
+	}
+	clone.PileRules = s.PileRules
 
 	clone.CurrentPlayer = s.CurrentPlayer
 	clone.TurnNumber = s.TurnNumber
 	clone.WinnerID = s.WinnerID
+	clone.CutCard = s.CutCard
 	clone.Pot = s.Pot
 	clone.CurrentBet = s.CurrentBet
 	clone.RaiseCount = s.RaiseCount
+	clone.LastRaise = s.LastRaise
 	clone.BettingStartPlayer = s.BettingStartPlayer
+	clone.Dealer = s.Dealer
+	clone.HandsPlayed = s.HandsPlayed
+	clone.ChipBridge = s.ChipBridge
+	clone.ShowComplete = s.ShowComplete
+	clone.Revealed = s.Revealed
+	clone.HandSizesHidden = s.HandSizesHidden
+	clone.ConcealedDiscards = append(clone.ConcealedDiscards, s.ConcealedDiscards...)
+	clone.Peeks = append(clone.Peeks, s.Peeks...) // Peeked cards are shared; they are never modified
+	clone.ShowdownReveals = append(clone.ShowdownReveals, s.ShowdownReveals...) // As are shown hands
+	clone.FinishOrder = append(clone.FinishOrder, s.FinishOrder...)
 
 	// Clone claim if present
+	clone.LastClaimRank = s.LastClaimRank
 	if s.CurrentClaim != nil {
 		clone.CurrentClaim = &Claim{
 			ClaimerID:    s.CurrentClaim.ClaimerID,
@@ -243,6 +402,7 @@ func (s *GameState) Clone() *GameState {
 
 	// Clone trick-taking state
 	clone.CurrentTrick = append(clone.CurrentTrick, s.CurrentTrick...)
+	clone.PlayedTricks = append(clone.PlayedTricks, s.PlayedTricks...) // Played tricks are shared; they are never modified
 	clone.TrickLeader = s.TrickLeader
 	clone.TricksWon = append(clone.TricksWon, s.TricksWon...)
 	clone.HeartsBroken = s.HeartsBroken
@@ -250,14 +410,29 @@ func (s *GameState) Clone() *GameState {
 	clone.CardsPerPlayer = s.CardsPerPlayer
 	clone.TableauMode = s.TableauMode
 	clone.SequenceDirection = s.SequenceDirection
+	clone.AceMode = s.AceMode
+	clone.RankValues = s.RankValues
+	clone.WildRanks = s.WildRanks
+	clone.WarTieRule = s.WarTieRule
 	clone.PlayDirection = s.PlayDirection
 	clone.SkipCount = s.SkipCount
+	clone.EffectsPlayer = s.EffectsPlayer
+	clone.EffectsThisTurn = s.EffectsThisTurn
+	clone.DrawsPlayer = s.DrawsPlayer
+	clone.DrawsTurnCount = s.DrawsTurnCount
+	clone.EffectsCapped = s.EffectsCapped
+	clone.MovesCapped = s.MovesCapped
 	// Clone blackjack state
 	for i := 0; i < len(s.HasStood) && i < len(clone.HasStood); i++ {
 		clone.HasStood[i] = s.HasStood[i]
 	}
 	// Clone President state
 	clone.ConsecutivePasses = s.ConsecutivePasses
+	// Clone repeating phase state
+	clone.RepeatPhase = s.RepeatPhase
+	clone.PhaseRuns = s.PhaseRuns
+	clone.DiscardPending = s.DiscardPending
+	clone.DiscardPhase = s.DiscardPhase
 
 	// Clone team fields
 	if s.TeamScores != nil {
@@ -272,6 +447,8 @@ func (s *GameState) Clone() *GameState {
 
 	// Clone bidding fields
 	clone.BiddingComplete = s.BiddingComplete
+	clone.Trump = s.Trump
+	clone.TrumpNamed = s.TrumpNamed
 	if s.TeamContracts != nil {
 		clone.TeamContracts = make([]int8, len(s.TeamContracts))
 		copy(clone.TeamContracts, s.TeamContracts)
@@ -291,10 +468,12 @@ func (gs *GameState) InitializeChips(startingChips int) {
 		gs.Players[i].CurrentBet = 0
 		gs.Players[i].HasFolded = false
 		gs.Players[i].IsAllIn = false
+		gs.Players[i].RaiseClosed = false
 	}
 	gs.Pot = 0
 	gs.CurrentBet = 0
 	gs.RaiseCount = 0
+	gs.LastRaise = 0
 	gs.BettingStartPlayer = 0
 }
 
@@ -304,12 +483,20 @@ func (gs *GameState) ResetHand() {
 		gs.Players[i].CurrentBet = 0
 		gs.Players[i].HasFolded = false
 		gs.Players[i].IsAllIn = false
+		gs.Players[i].RaiseClosed = false
 	}
 	gs.Pot = 0
 	gs.CurrentBet = 0
 	gs.RaiseCount = 0
+	gs.LastRaise = 0
 	gs.BettingComplete = false
+	gs.ShowComplete = false
+	gs.Peeks = gs.Peeks[:0]
+	gs.Revealed = false
+	gs.FinishOrder = gs.FinishOrder[:0]
+	gs.PlayedTricks = gs.PlayedTricks[:0]
 	gs.BettingStartPlayer = (gs.BettingStartPlayer + 1) % len(gs.Players)
+	gs.HandsPlayed++
 }
 
 // BuildPlayerToTeamLookup creates a lookup table from player index to team index.
@@ -341,4 +528,5 @@ func (s *GameState) InitializeTeams(teams [][]int) {
 	s.TeamScores = make([]int32, len(teams))
 	s.PlayerToTeam = BuildPlayerToTeamLookup(teams, int(s.NumPlayers))
 	s.WinningTeam = -1
+	s.AccumulatedBags = make([]int8, len(teams))
 }
@@ -457,3 +457,59 @@ func TestGameStateCloneWithNilBidding(t *testing.T) {
 		t.Errorf("Clone should have nil AccumulatedBags, got %v", clone.AccumulatedBags)
 	}
 }
+
+func TestDealHandsRoundRobin(t *testing.T) {
+	s := GetState()
+	defer PutState(s)
+	for r := uint8(0); r < 6; r++ {
+		s.Deck = append(s.Deck, Card{Rank: r, Suit: 0})
+	}
+
+	s.DealHands(2, 3, DealRoundRobin)
+
+	// Deck top is the last element: ranks 5,4,3,2,1,0 are drawn in that order
+	want := [][]uint8{{5, 3, 1}, {4, 2, 0}}
+	for p, ranks := range want {
+		for i, r := range ranks {
+			if s.Players[p].Hand[i].Rank != r {
+				t.Errorf("Player %d card %d: expected rank %d, got %d", p, i, r, s.Players[p].Hand[i].Rank)
+			}
+		}
+	}
+}
+
+func TestDealHandsSequential(t *testing.T) {
+	s := GetState()
+	defer PutState(s)
+	for r := uint8(0); r < 6; r++ {
+		s.Deck = append(s.Deck, Card{Rank: r, Suit: 0})
+	}
+
+	s.DealHands(2, 3, DealSequential)
+
+	want := [][]uint8{{5, 4, 3}, {2, 1, 0}}
+	for p, ranks := range want {
+		for i, r := range ranks {
+			if s.Players[p].Hand[i].Rank != r {
+				t.Errorf("Player %d card %d: expected rank %d, got %d", p, i, r, s.Players[p].Hand[i].Rank)
+			}
+		}
+	}
+	if len(s.Deck) != 0 {
+		t.Errorf("Expected empty deck, got %d cards", len(s.Deck))
+	}
+}
+
+func TestDrawHandStopsAtEmptyDeck(t *testing.T) {
+	s := GetState()
+	defer PutState(s)
+	for r := uint8(0); r < 2; r++ {
+		s.Deck = append(s.Deck, Card{Rank: r, Suit: 0})
+	}
+
+	s.DrawHand(1, 3)
+	hand := s.Players[1].Hand
+	if len(hand) != 2 || hand[0].Rank != 1 || hand[1].Rank != 0 {
+		t.Errorf("Expected both cards, top first, got %v", hand)
+	}
+}
@@ -0,0 +1,63 @@
+package engine
+
+// CardCounts is a histogram of cards by rank and by suit.
+type CardCounts struct {
+	ByRank [13]uint64
+	BySuit [4]uint64
+}
+
+// add counts one card.
+func (c *CardCounts) add(card Card) {
+	if int(card.Rank) < len(c.ByRank) {
+		c.ByRank[card.Rank]++
+	}
+	if int(card.Suit) < len(c.BySuit) {
+		c.BySuit[card.Suit]++
+	}
+}
+
+// Merge adds other's counts to c.
+func (c *CardCounts) Merge(other *CardCounts) {
+	for i, n := range other.ByRank {
+		c.ByRank[i] += n
+	}
+	for i, n := range other.BySuit {
+		c.BySuit[i] += n
+	}
+}
+
+// CardUsage records what happens to cards over a game, so cards that never
+// matter and cards that always win stand out.
+type CardUsage struct {
+	Played   CardCounts // Cards played from a hand
+	Captured CardCounts // Cards won off the table: tricks, War battles and matches
+	Scored   CardCounts // Cards that earned points for whoever took them
+}
+
+// Merge adds other's counts to u.
+func (u *CardUsage) Merge(other *CardUsage) {
+	u.Played.Merge(&other.Played)
+	u.Captured.Merge(&other.Captured)
+	u.Scored.Merge(&other.Scored)
+}
+
+// notePlayed records a card played from a hand, if usage is being tracked.
+func (s *GameState) notePlayed(card Card) {
+	if s.Usage != nil {
+		s.Usage.Played.add(card)
+	}
+}
+
+// noteCaptured records a card won off the table, if usage is being tracked.
+func (s *GameState) noteCaptured(card Card) {
+	if s.Usage != nil {
+		s.Usage.Captured.add(card)
+	}
+}
+
+// noteScored records a card that earned points, if usage is being tracked.
+func (s *GameState) noteScored(card Card) {
+	if s.Usage != nil {
+		s.Usage.Scored.add(card)
+	}
+}
@@ -0,0 +1,73 @@
+package engine
+
+import "testing"
+
+// TestCardUsageTrick plays a Hearts-style trick and checks every card is
+// counted as played and captured, and only the point cards as scored.
+func TestCardUsageTrick(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	var usage CardUsage
+	state.Usage = &usage
+	queenOfSpades := Card{Rank: RankQueen, Suit: 3}
+	state.Players[0].Hand = []Card{{Rank: RankKing, Suit: 3}}
+	state.Players[1].Hand = []Card{queenOfSpades}
+	genome := &Genome{
+		TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypeTrick, Data: []byte{1, 255, 1, 0}}},
+	}
+
+	move := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationTableau}
+	ApplyMove(state, &move, genome)
+	ApplyMove(state, &move, genome)
+
+	if usage.Played.BySuit[3] != 2 || usage.Played.ByRank[RankKing] != 1 || usage.Played.ByRank[RankQueen] != 1 {
+		t.Errorf("Expected both spades played, got %+v", usage.Played)
+	}
+	if usage.Captured.BySuit[3] != 2 {
+		t.Errorf("Expected both spades captured, got %+v", usage.Captured)
+	}
+	if usage.Scored.ByRank[RankQueen] != 1 || usage.Scored.ByRank[RankKing] != 0 {
+		t.Errorf("Expected only the queen of spades scored, got %+v", usage.Scored)
+	}
+}
+
+func TestCardUsageNotTracked(t *testing.T) {
+	state := NewGameState(2)
+	defer PutState(state)
+	state.Players[0].Hand = []Card{{Rank: RankTwo, Suit: 0}}
+	genome := &Genome{
+		TurnPhases: []PhaseDescriptor{{PhaseType: PhaseTypeTrick, Data: []byte{1, 255, 1, 255}}},
+	}
+
+	// Without a destination, play records nothing and does not panic
+	move := LegalMove{PhaseIndex: 0, CardIndex: 0, TargetLoc: LocationTableau}
+	ApplyMove(state, &move, genome)
+
+	// Clones, such as MCTS rollouts, never record into the game's histogram
+	var usage CardUsage
+	state.Usage = &usage
+	clone := state.Clone()
+	defer PutState(clone)
+	if clone.Usage != nil {
+		t.Error("Expected a clone not to track card usage")
+	}
+	state.Reset()
+	if state.Usage != nil {
+		t.Error("Expected Reset to stop tracking card usage")
+	}
+}
+
+func TestCardUsageMerge(t *testing.T) {
+	var a, b CardUsage
+	a.Played.add(Card{Rank: RankAce, Suit: 1})
+	b.Played.add(Card{Rank: RankAce, Suit: 2})
+	b.Scored.add(Card{Rank: RankTen, Suit: 2})
+
+	a.Merge(&b)
+	if a.Played.ByRank[RankAce] != 2 || a.Played.BySuit[1] != 1 || a.Played.BySuit[2] != 1 {
+		t.Errorf("Unexpected merged plays: %+v", a.Played)
+	}
+	if a.Scored.ByRank[RankTen] != 1 {
+		t.Errorf("Unexpected merged scores: %+v", a.Scored)
+	}
+}
@@ -0,0 +1,140 @@
+package evolution
+
+import (
+	"log"
+	"sort"
+
+	"github.com/signalnine/darwindeck/gosim/evolution/operators"
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+// CrossoverChange names the crossover step in an Attribution.
+const CrossoverChange = "Crossover"
+
+// lineage remembers how an offspring was made, so an improvement over its
+// parent can be credited to the changes behind it.
+type lineage struct {
+	parent    *Individual
+	crossed   *genome.GameGenome          // The offspring after crossover, before mutation
+	mutations []operators.AppliedMutation // Mutations applied after crossover, in order
+}
+
+// ChangeContribution is the fitness one change earns on its own.
+type ChangeContribution struct {
+	Change   string  // CrossoverChange or the mutation's name
+	Fitness  float64 // Fitness of the parent with only this change made
+	Marginal float64 // Fitness minus the parent's fitness
+}
+
+// Attribution credits an offspring's improvement over its parent to the
+// individual changes that produced it. The marginal contributions need not
+// add up to the improvement, since changes can help or hurt each other.
+type Attribution struct {
+	Generation       int
+	Offspring        string // Offspring genome name
+	ParentFitness    float64
+	OffspringFitness float64
+	Changes          []ChangeContribution
+}
+
+// attributeImprovements re-evaluates, for every offspring that beat its
+// parent, the parent with each change applied in isolation: the crossover,
+// then each mutation replayed with its original random choices. All the
+// variants of a generation are evaluated in one parallel batch.
+func (e *EvolutionEngine) attributeImprovements() []Attribution {
+	var improved []*Individual
+	var variants []*genome.GameGenome
+	for _, ind := range e.Population.Individuals {
+		lin := ind.lineage
+		if lin == nil {
+			continue
+		}
+		if !ind.Evaluated || ind.Fitness <= lin.parent.Fitness || ind.Screened || lin.parent.Screened {
+			ind.lineage = nil // Nothing to explain, or no full fitness to explain it with
+			continue
+		}
+		improved = append(improved, ind)
+		variants = append(variants, lin.crossed)
+		for _, m := range lin.mutations {
+			variants = append(variants, m.Replay(lin.parent.Genome))
+		}
+	}
+	if len(improved) == 0 {
+		return nil
+	}
+
+	metrics := e.Evaluator.EvaluatePopulation(variants, e.Config.GamesPerEval, e.Config.UseMCTS)
+
+	attributions := make([]Attribution, 0, len(improved))
+	next := 0
+	for _, ind := range improved {
+		lin := ind.lineage
+		ind.lineage = nil
+		a := Attribution{
+			Generation:       e.Population.Generation,
+			Offspring:        ind.Genome.Name,
+			ParentFitness:    lin.parent.Fitness,
+			OffspringFitness: ind.Fitness,
+		}
+		names := []string{CrossoverChange}
+		for _, m := range lin.mutations {
+			names = append(names, m.Operator.Name())
+		}
+		for _, name := range names {
+			fit := metrics[next].TotalFitness
+			next++
+			a.Changes = append(a.Changes, ChangeContribution{
+				Change:   name,
+				Fitness:  fit,
+				Marginal: fit - lin.parent.Fitness,
+			})
+		}
+		attributions = append(attributions, a)
+
+		if e.Config.Verbose {
+			log.Printf("Offspring %s improved %.4f -> %.4f:", a.Offspring, a.ParentFitness, a.OffspringFitness)
+			for _, c := range a.Changes {
+				log.Printf("  %-24s %+.4f", c.Change, c.Marginal)
+			}
+		}
+	}
+	return attributions
+}
+
+// ChangeSummary is a change's average effect over every attribution that
+// involved it.
+type ChangeSummary struct {
+	Change       string
+	Count        int     // Improved offspring the change helped make
+	MeanMarginal float64 // Average fitness the change earned on its own
+}
+
+// SummarizeAttributions averages each change's marginal contribution
+// across attributions, best first.
+func SummarizeAttributions(attributions []Attribution) []ChangeSummary {
+	byChange := make(map[string]*ChangeSummary)
+	for _, a := range attributions {
+		for _, c := range a.Changes {
+			s := byChange[c.Change]
+			if s == nil {
+				s = &ChangeSummary{Change: c.Change}
+				byChange[c.Change] = s
+			}
+			s.Count++
+			s.MeanMarginal += c.Marginal
+		}
+	}
+
+	summaries := make([]ChangeSummary, 0, len(byChange))
+	for _, s := range byChange {
+		s.MeanMarginal /= float64(s.Count)
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].MeanMarginal != summaries[j].MeanMarginal {
+			return summaries[i].MeanMarginal > summaries[j].MeanMarginal
+		}
+		return summaries[i].Change < summaries[j].Change
+	})
+	return summaries
+}
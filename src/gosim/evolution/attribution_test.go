@@ -0,0 +1,99 @@
+package evolution
+
+import (
+	"testing"
+
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+func TestAttributeImprovements(t *testing.T) {
+	config := &EvolutionConfig{
+		PopulationSize:     6,
+		CrossoverRate:      0.7,
+		TournamentSize:     2,
+		RandomSeed:         42,
+		FitnessStyle:       "balanced",
+		GamesPerEval:       5,
+		NumWorkers:         2,
+		AttributeMutations: true,
+	}
+	engine := NewEvolutionEngine(config)
+	defer engine.Close()
+
+	// Parents no offspring can fail to beat
+	individuals := make([]*Individual, config.PopulationSize)
+	for i := range individuals {
+		individuals[i] = &Individual{Genome: genome.CreateCrazyEightsGenome(), Fitness: -1, Evaluated: true}
+	}
+	engine.Population = NewPopulation(individuals)
+
+	offspring := engine.CreateOffspring()
+	engine.Population = NewPopulation(offspring)
+	engine.EvaluatePopulation()
+	attributions := engine.attributeImprovements()
+
+	if len(attributions) != len(offspring) {
+		t.Fatalf("Expected every offspring to be credited, got %d of %d", len(attributions), len(offspring))
+	}
+	for _, a := range attributions {
+		if a.ParentFitness != -1 || a.OffspringFitness <= a.ParentFitness {
+			t.Errorf("%s: expected an improvement over -1, got %f", a.Offspring, a.OffspringFitness)
+		}
+		if len(a.Changes) == 0 || a.Changes[0].Change != CrossoverChange {
+			t.Fatalf("%s: expected the crossover to be credited first, got %+v", a.Offspring, a.Changes)
+		}
+		for _, c := range a.Changes {
+			if c.Marginal != c.Fitness-a.ParentFitness {
+				t.Errorf("%s: %s marginal %f doesn't match fitness %f", a.Offspring, c.Change, c.Marginal, c.Fitness)
+			}
+		}
+	}
+	for _, ind := range offspring {
+		if ind.lineage != nil {
+			t.Error("Expected lineage to be released once credited")
+		}
+	}
+}
+
+func TestAttributeImprovementsSkipsWorseOffspring(t *testing.T) {
+	config := &EvolutionConfig{
+		PopulationSize:     4,
+		TournamentSize:     2,
+		RandomSeed:         7,
+		FitnessStyle:       "balanced",
+		GamesPerEval:       5,
+		AttributeMutations: true,
+	}
+	engine := NewEvolutionEngine(config)
+	defer engine.Close()
+
+	individuals := make([]*Individual, config.PopulationSize)
+	for i := range individuals {
+		individuals[i] = &Individual{Genome: genome.CreateWarGenome(), Fitness: 10, Evaluated: true}
+	}
+	engine.Population = NewPopulation(individuals)
+	engine.Population = NewPopulation(engine.CreateOffspring())
+	engine.EvaluatePopulation()
+
+	if attributions := engine.attributeImprovements(); len(attributions) != 0 {
+		t.Errorf("Expected no credit without an improvement, got %d attributions", len(attributions))
+	}
+}
+
+func TestSummarizeAttributions(t *testing.T) {
+	attributions := []Attribution{
+		{Changes: []ChangeContribution{{Change: CrossoverChange, Marginal: 0.1}, {Change: "AddDrawPhase", Marginal: 0.3}}},
+		{Changes: []ChangeContribution{{Change: CrossoverChange, Marginal: -0.1}}},
+	}
+
+	summaries := SummarizeAttributions(attributions)
+	if len(summaries) != 2 {
+		t.Fatalf("Expected 2 changes, got %+v", summaries)
+	}
+	if summaries[0].Change != "AddDrawPhase" || summaries[0].Count != 1 || summaries[0].MeanMarginal != 0.3 {
+		t.Errorf("Expected AddDrawPhase to rank first, got %+v", summaries[0])
+	}
+	if summaries[1].Change != CrossoverChange || summaries[1].Count != 2 || summaries[1].MeanMarginal != 0 {
+		t.Errorf("Expected crossover to average out, got %+v", summaries[1])
+	}
+}
@@ -3,11 +3,13 @@ package evolution
 import (
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/signalnine/darwindeck/gosim/evolution/fitness"
+	"github.com/signalnine/darwindeck/gosim/evolution/operators"
 	"github.com/signalnine/darwindeck/gosim/genome"
 )
 
@@ -17,14 +19,17 @@ type CheckpointData struct {
 	Config *EvolutionConfig `json:"config"`
 
 	// Current state
-	Generation   int               `json:"generation"`
-	Population   []IndividualData  `json:"population"`
-	BestEver     *IndividualData   `json:"best_ever,omitempty"`
-	StatsHistory []GenerationStats `json:"stats_history"`
+	Generation    int               `json:"generation"`
+	Population    []IndividualData  `json:"population"`
+	BestEver      *IndividualData   `json:"best_ever,omitempty"`
+	StatsHistory  []GenerationStats `json:"stats_history"`
+	UseAggressive bool              `json:"use_aggressive,omitempty"`
 
 	// Metadata
 	Timestamp   time.Time `json:"timestamp"`
 	RNGSeed     int64     `json:"rng_seed"`
+	RNG         *RNGState `json:"rng,omitempty"`       // Stream position for exact resume (1.1+)
+	EvalSeed    uint64    `json:"eval_seed,omitempty"` // Seed for fitness evaluation games (1.2+)
 	Version     string    `json:"version"`
 }
 
@@ -34,64 +39,105 @@ type IndividualData struct {
 	Fitness        float64                `json:"fitness"`
 	Evaluated      bool                   `json:"evaluated"`
 	FitnessMetrics *fitness.FitnessMetrics `json:"fitness_metrics,omitempty"`
+	Screened       bool                   `json:"screened,omitempty"`
 }
 
 // CheckpointVersion is the current checkpoint format version.
-const CheckpointVersion = "1.0"
+const CheckpointVersion = "1.2"
 
 // SaveCheckpoint saves the current evolution state to a file.
 func (e *EvolutionEngine) SaveCheckpoint(path string) error {
+	checkpoint, err := e.snapshot()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	return writeFileAtomic(path, data)
+}
+
+// snapshot captures the current evolution state in serializable form.
+func (e *EvolutionEngine) snapshot() (*CheckpointData, error) {
 	if e.Population == nil {
-		return fmt.Errorf("no population to save")
+		return nil, fmt.Errorf("no population to save")
 	}
 
 	// Convert population to serializable format
 	popData := make([]IndividualData, len(e.Population.Individuals))
 	for i, ind := range e.Population.Individuals {
-		popData[i] = IndividualData{
-			Genome:         ind.Genome,
-			Fitness:        ind.Fitness,
-			Evaluated:      ind.Evaluated,
-			FitnessMetrics: ind.FitnessMetrics,
-		}
+		popData[i] = newIndividualData(ind)
 	}
 
 	// Convert best ever
 	var bestData *IndividualData
 	if e.BestEver != nil {
-		bestData = &IndividualData{
-			Genome:         e.BestEver.Genome,
-			Fitness:        e.BestEver.Fitness,
-			Evaluated:      e.BestEver.Evaluated,
-			FitnessMetrics: e.BestEver.FitnessMetrics,
-		}
+		best := newIndividualData(e.BestEver)
+		bestData = &best
 	}
 
-	checkpoint := CheckpointData{
-		Config:       e.Config,
-		Generation:   e.Population.Generation,
-		Population:   popData,
-		BestEver:     bestData,
-		StatsHistory: e.StatsHistory,
-		Timestamp:    time.Now(),
-		RNGSeed:      e.Config.RandomSeed,
-		Version:      CheckpointVersion,
+	checkpoint := &CheckpointData{
+		Config:        e.Config,
+		Generation:    e.Population.Generation,
+		Population:    popData,
+		BestEver:      bestData,
+		StatsHistory:  e.StatsHistory,
+		UseAggressive: e.UseAggressive,
+		Timestamp:     time.Now(),
+		RNGSeed:       e.Config.RandomSeed,
+		EvalSeed:      e.Evaluator.Seed,
+		Version:       CheckpointVersion,
+	}
+	if e.rngSource != nil {
+		state := e.rngSource.State()
+		checkpoint.RNGSeed = state.Seed
+		checkpoint.RNG = &state
 	}
+	return checkpoint, nil
+}
+
+// newIndividualData converts an individual to its serializable form.
+func newIndividualData(ind *Individual) IndividualData {
+	return IndividualData{
+		Genome:         ind.Genome,
+		Fitness:        ind.Fitness,
+		Evaluated:      ind.Evaluated,
+		FitnessMetrics: ind.FitnessMetrics,
+		Screened:       ind.Screened,
+	}
+}
 
+// writeFileAtomic replaces path with data so that readers, and a crash at any
+// point, see either the old file or the complete new one. The data is written
+// to a temp file in the same directory, synced, and renamed over path.
+func writeFileAtomic(path string, data []byte) error {
 	// Ensure directory exists
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create checkpoint directory: %w", err)
 	}
 
-	// Write to temp file first, then rename (atomic)
-	tempPath := path + ".tmp"
-	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
 	if err != nil {
-		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+		return fmt.Errorf("failed to write checkpoint: %w", err)
 	}
+	tempPath := tmp.Name()
 
-	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tempPath, 0644)
+	}
+	if err != nil {
+		os.Remove(tempPath)
 		return fmt.Errorf("failed to write checkpoint: %w", err)
 	}
 
@@ -100,15 +146,24 @@ func (e *EvolutionEngine) SaveCheckpoint(path string) error {
 		return fmt.Errorf("failed to finalize checkpoint: %w", err)
 	}
 
+	// Persist the rename itself; not every platform can sync a directory
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
 	return nil
 }
 
-// LoadCheckpoint loads evolution state from a checkpoint file.
+// LoadCheckpoint loads evolution state from a checkpoint file, either a full
+// snapshot or an incremental checkpoint log.
 func LoadCheckpoint(path string) (*CheckpointData, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
 	}
+	if isCheckpointLog(data) {
+		return replayCheckpointLog(data)
+	}
 
 	var checkpoint CheckpointData
 	if err := json.Unmarshal(data, &checkpoint); err != nil {
@@ -137,6 +192,28 @@ func (e *EvolutionEngine) RestoreFromCheckpoint(checkpoint *CheckpointData) erro
 		e.Config.FitnessStyle = checkpoint.Config.FitnessStyle
 		e.Config.GamesPerEval = checkpoint.Config.GamesPerEval
 		e.Config.UseMCTS = checkpoint.Config.UseMCTS
+		e.Config.Determinizations = checkpoint.Config.Determinizations
+		e.Evaluator.Determinizations = e.Config.Determinizations
+		e.Config.MemoryWindow = checkpoint.Config.MemoryWindow
+		e.Evaluator.MemoryWindow = e.Config.MemoryWindow
+		e.Config.ReferenceAI = checkpoint.Config.ReferenceAI
+		e.Config.ReferenceGames = checkpoint.Config.ReferenceGames
+		e.Evaluator.Reference = e.Config.referenceOpponent()
+		e.Config.SkillGapTarget = checkpoint.Config.SkillGapTarget
+		e.Config.SkillGapStrongAI = checkpoint.Config.SkillGapStrongAI
+		e.Config.SkillGapWeakAI = checkpoint.Config.SkillGapWeakAI
+		e.Config.SkillGapGames = checkpoint.Config.SkillGapGames
+		e.Evaluator.SkillGap = e.Config.skillGapObjective()
+		e.Config.MaxPhases = checkpoint.Config.MaxPhases
+		e.Config.MaxRules = checkpoint.Config.MaxRules
+		e.Config.RealDeckOnly = checkpoint.Config.RealDeckOnly
+		e.Config.AttributeMutations = checkpoint.Config.AttributeMutations
+		e.Config.PlayerCounts = checkpoint.Config.PlayerCounts
+		e.Config.PlayerCountAggregate = checkpoint.Config.PlayerCountAggregate
+		e.Config.PlayerCountWeights = checkpoint.Config.PlayerCountWeights
+		e.Evaluator.PlayerCounts = e.Config.playerCountEvaluation()
+		e.Config.ScreenFraction = checkpoint.Config.ScreenFraction
+		e.Crossover.Budget = e.Config.complexityBudget()
 	}
 
 	// Restore population
@@ -147,6 +224,7 @@ func (e *EvolutionEngine) RestoreFromCheckpoint(checkpoint *CheckpointData) erro
 			Fitness:        data.Fitness,
 			Evaluated:      data.Evaluated,
 			FitnessMetrics: data.FitnessMetrics,
+			Screened:       data.Screened,
 		}
 	}
 	e.Population = NewPopulation(individuals)
@@ -162,8 +240,36 @@ func (e *EvolutionEngine) RestoreFromCheckpoint(checkpoint *CheckpointData) erro
 		}
 	}
 
-	// Restore stats history
+	// Restore stats history. Checkpoints are taken as a generation's stats
+	// are reported, and the evolution loop recomputes that generation's stats
+	// when it resumes, so drop them here rather than record them twice.
 	e.StatsHistory = checkpoint.StatsHistory
+	if n := len(e.StatsHistory); n > 0 && e.StatsHistory[n-1].Generation == checkpoint.Generation {
+		e.StatsHistory = e.StatsHistory[:n-1]
+	}
+
+	// Restore the random stream where it left off (older checkpoints only
+	// have the seed, so they restart the stream)
+	switch {
+	case checkpoint.RNG != nil:
+		e.rngSource = restoreTrackedSource(*checkpoint.RNG)
+	case checkpoint.RNGSeed != 0:
+		e.rngSource = newTrackedSource(checkpoint.RNGSeed)
+	}
+	if e.rngSource != nil {
+		e.Rng = rand.New(e.rngSource)
+	}
+	// Offspring must be evaluated on the same deals as before the interruption
+	// (checkpoints before 1.2 were evaluated with seed 0)
+	e.Evaluator.Seed = checkpoint.EvalSeed
+
+	// Restore mutation mode
+	e.UseAggressive = checkpoint.UseAggressive
+	if e.UseAggressive {
+		e.MutationPipeline = operators.NewAggressivePipeline(e.Rng).WithBudget(e.Config.complexityBudget())
+	} else {
+		e.MutationPipeline = operators.NewDefaultPipeline(e.Rng).WithBudget(e.Config.complexityBudget())
+	}
 
 	return nil
 }
@@ -193,6 +299,7 @@ type AutoCheckpointer struct {
 	Path       string
 	Interval   int  // Save every N generations
 	LastSaved  int  // Last generation saved
+	Log        *CheckpointLog // If set, checkpoints are appended to this log instead
 }
 
 // NewAutoCheckpointer creates an auto-checkpointer.
@@ -223,7 +330,7 @@ func (ac *AutoCheckpointer) Save(generation int) error {
 		return nil
 	}
 
-	if err := ac.Engine.SaveCheckpoint(ac.Path); err != nil {
+	if err := ac.save(); err != nil {
 		return err
 	}
 
@@ -233,5 +340,12 @@ func (ac *AutoCheckpointer) Save(generation int) error {
 
 // SaveFinal saves a final checkpoint regardless of interval.
 func (ac *AutoCheckpointer) SaveFinal() error {
+	return ac.save()
+}
+
+func (ac *AutoCheckpointer) save() error {
+	if ac.Log != nil {
+		return ac.Log.Save()
+	}
 	return ac.Engine.SaveCheckpoint(ac.Path)
 }
@@ -0,0 +1,288 @@
+package evolution
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// A checkpoint log is a JSON-lines file. The first record is a full snapshot;
+// each later record stores only what changed since the one before it: new
+// individuals, a new best, newly recorded stats, and the random stream
+// position. Individuals are referenced by a content hash, so elites and
+// unmutated offspring carried into the next generation are not stored again.
+//
+// Appends are synced as they are written. A crash mid-append leaves a final
+// line without its newline, which replay ignores, so the log always resumes
+// from the last complete record.
+
+// Checkpoint log record kinds
+const (
+	checkpointLogBase  = "base"
+	checkpointLogDelta = "delta"
+)
+
+// checkpointLogRecord is one line of a checkpoint log.
+type checkpointLogRecord struct {
+	Kind     string           `json:"kind"`
+	Snapshot *CheckpointData  `json:"snapshot,omitempty"` // Base records
+	Keys     []string         `json:"keys,omitempty"`     // Base records: key of each individual in Snapshot.Population
+	Delta    *checkpointDelta `json:"delta,omitempty"`    // Delta records
+}
+
+// checkpointDelta holds the changes since the previous record.
+type checkpointDelta struct {
+	Generation    int                       `json:"generation"`
+	Population    []string                  `json:"population"`          // Individual keys, in population order
+	Added         map[string]IndividualData `json:"added,omitempty"`     // Individuals not in the previous population
+	BestEver      *IndividualData           `json:"best_ever,omitempty"` // Only when it changed
+	Stats         []GenerationStats         `json:"stats,omitempty"`     // Stats recorded since the previous record
+	UseAggressive bool                      `json:"use_aggressive,omitempty"`
+	Timestamp     time.Time                 `json:"timestamp"`
+	RNG           *RNGState                 `json:"rng,omitempty"`
+}
+
+// CheckpointLog saves incremental checkpoints of an engine to a log file.
+// The first Save in a process starts a new log with a full snapshot.
+type CheckpointLog struct {
+	Engine       *EvolutionEngine
+	Path         string
+	CompactEvery int // Start a new log with a full snapshot after this many deltas (0 = never)
+
+	started    bool
+	keys       map[string]bool // Keys of the last saved population
+	bestKey    string
+	statsSaved int
+	deltas     int
+}
+
+// NewCheckpointLog creates a checkpoint log writer.
+func NewCheckpointLog(engine *EvolutionEngine, path string, compactEvery int) *CheckpointLog {
+	return &CheckpointLog{
+		Engine:       engine,
+		Path:         path,
+		CompactEvery: compactEvery,
+	}
+}
+
+// Save records the engine's current state, appending a delta when possible.
+func (l *CheckpointLog) Save() error {
+	snap, err := l.Engine.snapshot()
+	if err != nil {
+		return err
+	}
+
+	if !l.started || (l.CompactEvery > 0 && l.deltas >= l.CompactEvery) || len(snap.StatsHistory) < l.statsSaved {
+		return l.writeBase(snap)
+	}
+	return l.appendDelta(snap)
+}
+
+// writeBase replaces the log with a single full snapshot.
+func (l *CheckpointLog) writeBase(snap *CheckpointData) error {
+	keys, err := individualKeys(snap.Population)
+	if err != nil {
+		return err
+	}
+	bestKey, err := bestEverKey(snap.BestEver)
+	if err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(checkpointLogRecord{Kind: checkpointLogBase, Snapshot: snap, Keys: keys})
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+	if err := writeFileAtomic(l.Path, append(line, '\n')); err != nil {
+		return err
+	}
+
+	l.started = true
+	l.keys = keySet(keys)
+	l.bestKey = bestKey
+	l.statsSaved = len(snap.StatsHistory)
+	l.deltas = 0
+	return nil
+}
+
+// appendDelta appends the changes since the last record to the log.
+func (l *CheckpointLog) appendDelta(snap *CheckpointData) error {
+	keys, err := individualKeys(snap.Population)
+	if err != nil {
+		return err
+	}
+	bestKey, err := bestEverKey(snap.BestEver)
+	if err != nil {
+		return err
+	}
+
+	delta := &checkpointDelta{
+		Generation:    snap.Generation,
+		Population:    keys,
+		Stats:         snap.StatsHistory[l.statsSaved:],
+		UseAggressive: snap.UseAggressive,
+		Timestamp:     snap.Timestamp,
+		RNG:           snap.RNG,
+	}
+	for i, key := range keys {
+		if l.keys[key] {
+			continue
+		}
+		if delta.Added == nil {
+			delta.Added = make(map[string]IndividualData)
+		}
+		delta.Added[key] = snap.Population[i]
+	}
+	if bestKey != l.bestKey {
+		delta.BestEver = snap.BestEver
+	}
+
+	line, err := json.Marshal(checkpointLogRecord{Kind: checkpointLogDelta, Delta: delta})
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open checkpoint log: %w", err)
+	}
+	_, err = f.Write(append(line, '\n'))
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to append checkpoint: %w", err)
+	}
+
+	l.keys = keySet(keys)
+	l.bestKey = bestKey
+	l.statsSaved = len(snap.StatsHistory)
+	l.deltas++
+	return nil
+}
+
+// isCheckpointLog reports whether data is a checkpoint log rather than a
+// full checkpoint file.
+func isCheckpointLog(data []byte) bool {
+	line := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		line = data[:i]
+	}
+	var record struct {
+		Kind string `json:"kind"`
+	}
+	return json.Unmarshal(line, &record) == nil && record.Kind == checkpointLogBase
+}
+
+// replayCheckpointLog rebuilds the latest checkpoint from a log.
+func replayCheckpointLog(data []byte) (*CheckpointData, error) {
+	lines := bytes.Split(data, []byte{'\n'})
+	// The last piece is empty unless the final append was cut short
+	lines = lines[:len(lines)-1]
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("checkpoint log has no complete records")
+	}
+
+	var base checkpointLogRecord
+	if err := json.Unmarshal(lines[0], &base); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	}
+	if base.Kind != checkpointLogBase || base.Snapshot == nil || len(base.Keys) != len(base.Snapshot.Population) {
+		return nil, fmt.Errorf("checkpoint log does not start with a full snapshot")
+	}
+	checkpoint := base.Snapshot
+	pool := make(map[string]IndividualData, len(base.Keys))
+	for i, key := range base.Keys {
+		pool[key] = checkpoint.Population[i]
+	}
+
+	for n, line := range lines[1:] {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var record checkpointLogRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("checkpoint log record %d: %w", n+1, err)
+		}
+		if record.Kind != checkpointLogDelta || record.Delta == nil {
+			return nil, fmt.Errorf("checkpoint log record %d: unexpected %q record", n+1, record.Kind)
+		}
+		delta := record.Delta
+
+		population := make([]IndividualData, len(delta.Population))
+		next := make(map[string]IndividualData, len(delta.Population))
+		for i, key := range delta.Population {
+			ind, ok := delta.Added[key]
+			if !ok {
+				ind, ok = pool[key]
+			}
+			if !ok {
+				return nil, fmt.Errorf("checkpoint log record %d: unknown individual %s", n+1, key)
+			}
+			population[i] = ind
+			next[key] = ind
+		}
+		pool = next
+
+		checkpoint.Generation = delta.Generation
+		checkpoint.Population = population
+		if delta.BestEver != nil {
+			checkpoint.BestEver = delta.BestEver
+		}
+		checkpoint.StatsHistory = append(checkpoint.StatsHistory, delta.Stats...)
+		checkpoint.UseAggressive = delta.UseAggressive
+		checkpoint.Timestamp = delta.Timestamp
+		if delta.RNG != nil {
+			checkpoint.RNG = delta.RNG
+			checkpoint.RNGSeed = delta.RNG.Seed
+		}
+	}
+
+	return checkpoint, nil
+}
+
+// individualKey identifies an individual by a hash of its serialized form.
+func individualKey(ind IndividualData) (string, error) {
+	data, err := json.Marshal(ind)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal individual: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// individualKeys returns the key of each individual, in order.
+func individualKeys(population []IndividualData) ([]string, error) {
+	keys := make([]string, len(population))
+	for i, ind := range population {
+		key, err := individualKey(ind)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// bestEverKey returns the key of best, or "" if there is none.
+func bestEverKey(best *IndividualData) (string, error) {
+	if best == nil {
+		return "", nil
+	}
+	return individualKey(*best)
+}
+
+func keySet(keys []string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
@@ -21,6 +21,7 @@ type CrossoverOperator interface {
 // randomly selected from one of the two parents.
 type UniformCrossover struct {
 	probability float64
+	Budget      operators.ComplexityBudget // Offspring over budget are trimmed to fit
 }
 
 // NewUniformCrossover creates a new uniform crossover operator.
@@ -58,12 +59,19 @@ func (c *UniformCrossover) Crossover(parent1, parent2 *genome.GameGenome, rng *r
 
 	// Crossover turn structure parameters
 	if rng.Float64() < 0.5 {
+		// The turn limit travels with what it counts and how it is resolved
 		child1.TurnStructure.MaxTurns, child2.TurnStructure.MaxTurns =
 			child2.TurnStructure.MaxTurns, child1.TurnStructure.MaxTurns
+		child1.TurnStructure.TurnLimit, child2.TurnStructure.TurnLimit =
+			child2.TurnStructure.TurnLimit, child1.TurnStructure.TurnLimit
+		child1.TurnStructure.MaxTurnsRule, child2.TurnStructure.MaxTurnsRule =
+			child2.TurnStructure.MaxTurnsRule, child1.TurnStructure.MaxTurnsRule
 	}
 	if rng.Float64() < 0.5 {
 		child1.TurnStructure.TableauMode, child2.TurnStructure.TableauMode =
 			child2.TurnStructure.TableauMode, child1.TurnStructure.TableauMode
+		child1.TurnStructure.WarTieRule, child2.TurnStructure.WarTieRule =
+			child2.TurnStructure.WarTieRule, child1.TurnStructure.WarTieRule
 	}
 	if rng.Float64() < 0.5 {
 		child1.TurnStructure.SequenceDirection, child2.TurnStructure.SequenceDirection =
@@ -90,10 +98,12 @@ func (c *UniformCrossover) Crossover(parent1, parent2 *genome.GameGenome, rng *r
 			child2.Effects, child1.Effects
 	}
 
-	// Crossover card scoring - swap entire list
+	// Crossover card scoring - swap entire list, along with the hand penalty
 	if rng.Float64() < 0.5 {
 		child1.CardScoring, child2.CardScoring =
 			child2.CardScoring, child1.CardScoring
+		child1.HandPenalty, child2.HandPenalty =
+			child2.HandPenalty, child1.HandPenalty
 	}
 
 	// Crossover hand evaluation - swap entire struct
@@ -114,6 +124,9 @@ func (c *UniformCrossover) Crossover(parent1, parent2 *genome.GameGenome, rng *r
 	child1.Generation = max(parent1.Generation, parent2.Generation) + 1
 	child2.Generation = max(parent1.Generation, parent2.Generation) + 1
 
+	c.Budget.Repair(child1)
+	c.Budget.Repair(child2)
+
 	return child1, child2
 }
 
@@ -181,6 +194,7 @@ func cloneSinglePhase(p genome.Phase) genome.Phase {
 			condClone := *phase.Condition
 			clone.Condition = &condClone
 		}
+		clone.Repeat = phase.Repeat.Clone()
 		return &clone
 	case *genome.PlayPhase:
 		clone := *phase
@@ -188,6 +202,7 @@ func cloneSinglePhase(p genome.Phase) genome.Phase {
 			condClone := *phase.ValidPlayCondition
 			clone.ValidPlayCondition = &condClone
 		}
+		clone.Repeat = phase.Repeat.Clone()
 		return &clone
 	case *genome.DiscardPhase:
 		clone := *phase
@@ -197,6 +212,7 @@ func cloneSinglePhase(p genome.Phase) genome.Phase {
 		return &clone
 	case *genome.BettingPhase:
 		clone := *phase
+		clone.Community = append([]int(nil), phase.Community...)
 		return &clone
 	case *genome.BiddingPhase:
 		clone := *phase
@@ -204,6 +220,21 @@ func cloneSinglePhase(p genome.Phase) genome.Phase {
 	case *genome.ClaimPhase:
 		clone := *phase
 		return &clone
+	case *genome.ShowPhase:
+		clone := *phase
+		return &clone
+	case *genome.PeekPhase:
+		clone := *phase
+		return &clone
+	case *genome.DrawDiscardPhase:
+		clone := *phase
+		return &clone
+	case *genome.GivePhase:
+		clone := *phase
+		return &clone
+	case *genome.TradePhase:
+		clone := *phase
+		return &clone
 	default:
 		return p
 	}
@@ -243,6 +274,7 @@ func (c *SinglePointCrossover) Crossover(parent1, parent2 *genome.GameGenome, rn
 		// Swap win conditions and scoring
 		child1.WinConditions, child2.WinConditions = child2.WinConditions, child1.WinConditions
 		child1.CardScoring, child2.CardScoring = child2.CardScoring, child1.CardScoring
+		child1.HandPenalty, child2.HandPenalty = child2.HandPenalty, child1.HandPenalty
 	case 3:
 		// Swap effects and hand evaluation
 		child1.Effects, child2.Effects = child2.Effects, child1.Effects
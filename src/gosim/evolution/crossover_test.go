@@ -4,6 +4,7 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/signalnine/darwindeck/gosim/evolution/operators"
 	"github.com/signalnine/darwindeck/gosim/genome"
 )
 
@@ -36,6 +37,21 @@ func TestUniformCrossover(t *testing.T) {
 	}
 }
 
+func TestUniformCrossoverRepairsOverBudget(t *testing.T) {
+	crossover := &UniformCrossover{probability: 1.0, Budget: operators.ComplexityBudget{MaxPhases: 1, MaxRules: 1}}
+	rng := rand.New(rand.NewSource(12345))
+
+	for i := 0; i < 20; i++ {
+		child1, child2 := crossover.Crossover(genome.CreateCrazyEightsGenome(), genome.CreateHeartsGenome(), rng)
+		for _, child := range []*genome.GameGenome{child1, child2} {
+			if crossover.Budget.Exceeds(child) {
+				t.Fatalf("Expected offspring within budget, got %d phases and %d rules",
+					len(child.TurnStructure.Phases), operators.RuleCount(child))
+			}
+		}
+	}
+}
+
 func TestUniformCrossoverProducesVariation(t *testing.T) {
 	crossover := NewUniformCrossover(1.0)
 
@@ -3,6 +3,7 @@ package evolution
 import (
 	"fmt"
 	"log"
+	"math"
 	"math/rand"
 	"runtime"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/signalnine/darwindeck/gosim/evolution/fitness"
 	"github.com/signalnine/darwindeck/gosim/evolution/operators"
 	"github.com/signalnine/darwindeck/gosim/genome"
+	"github.com/signalnine/darwindeck/gosim/simulation"
 )
 
 // EvolutionConfig holds configuration for an evolutionary run.
@@ -24,11 +26,65 @@ type EvolutionConfig struct {
 	DiversityThreshold   float64 // Diversity below this triggers aggressive mutation
 	SeedRatio            float64 // Ratio of known games to mutants (0.7 = 70% known)
 	RandomSeed           int64   // Random seed (0 = use time)
-	FitnessStyle         string  // Fitness weight preset (balanced, bluffing, strategic, party, trick-taking)
+	FitnessStyle         string  // Fitness weight preset (balanced, bluffing, strategic, party, dramatic, trick-taking)
 	NumWorkers           int     // Number of parallel workers (0 = auto)
 	GamesPerEval         int     // Games per fitness evaluation
 	UseMCTS              bool    // Use MCTS for evaluation (slower but more accurate)
+	Determinizations     int     // Deals of the hidden cards each MCTS move searches (0 or 1 = the true deal)
+	MemoryWindow         int     // Tricks a forgetful MCTS remembers when measuring what memory is worth (0 = not measured)
 	Verbose              bool    // Enable verbose logging
+
+	// SeedGenomes are extra seeds, such as a curated library, that are all
+	// placed in the initial population. Checkpoints don't save them, since
+	// the population they seeded is saved instead.
+	SeedGenomes      []*genome.GameGenome `json:"-"`
+	SkipBuiltinSeeds bool                 // Seed only from SeedGenomes, not the built-in games
+
+	// ReferenceAI names an AI (see simulation.ParseAIPlayerType) that each
+	// genome is also played against, grounding skill in a fixed opponent.
+	ReferenceAI    string // "" = self-play only
+	ReferenceGames int    // Games against the reference AI per evaluation (0 = GamesPerEval)
+
+	// SkillGapTarget evolves toward a difficulty curve: each genome is also
+	// played between SkillGapStrongAI and SkillGapWeakAI, and its fitness is
+	// penalized the further the strong AI's win rate lands from the target
+	// (0.65 rewards skill without making the game a foregone conclusion).
+	SkillGapTarget   float64 // 0 = not measured
+	SkillGapStrongAI string  // "" = mcts500
+	SkillGapWeakAI   string  // "" = mcts100
+	SkillGapGames    int     // Games between them per evaluation (0 = GamesPerEval)
+
+	// Complexity budget: offspring with more phases or rules than this are
+	// rejected (mutations) or trimmed to fit (crossover). Rules are win
+	// conditions, special effects and card scoring rules. Seed genomes are
+	// kept as they are.
+	MaxPhases int // 0 = unlimited
+	MaxRules  int // 0 = unlimited
+
+	// RealDeckOnly keeps evolution to games playable with one standard
+	// 52-card deck and pen and paper: no chips, no duplicate cards. Seeds
+	// are repaired to fit, or left out if that breaks them; offspring are
+	// repaired (crossover) or rejected (mutations) like the budget above.
+	RealDeckOnly bool
+
+	// AttributeMutations re-evaluates every offspring that beats its parent
+	// with each of its changes made alone, to show which change helped.
+	// Costs an extra evaluation per change, so it is off by default.
+	AttributeMutations bool
+
+	// PlayerCounts evaluates every genome at each of these table sizes and
+	// combines the results with PlayerCountAggregate (PlayerCountMin,
+	// PlayerCountMean or PlayerCountWeighted, using PlayerCountWeights).
+	// Empty evaluates at genome.DefaultPlayerCount only.
+	PlayerCounts         []int
+	PlayerCountAggregate string
+	PlayerCountWeights   []float64
+
+	// ScreenFraction evaluates in two tiers: every new individual is first
+	// screened cheaply (see ParallelEvaluator.ScreenPopulation), and only
+	// the top ScreenFraction of the population gets the full evaluation.
+	// 0 (or 1) evaluates everything fully.
+	ScreenFraction float64
 }
 
 // DefaultConfig returns a default evolution configuration.
@@ -52,6 +108,66 @@ func DefaultConfig() *EvolutionConfig {
 	}
 }
 
+// referenceOpponent returns the configured reference opponent, or nil if
+// there is none or ReferenceAI isn't a known AI.
+func (c *EvolutionConfig) referenceOpponent() *ReferenceOpponent {
+	if c.ReferenceAI == "" {
+		return nil
+	}
+	ai, err := simulation.ParseAIPlayerType(c.ReferenceAI)
+	if err != nil {
+		return nil
+	}
+	return &ReferenceOpponent{AI: ai, Games: c.ReferenceGames}
+}
+
+// skillGapObjective returns the configured skill gap objective, or nil if
+// there is none or either AI isn't a known AI.
+func (c *EvolutionConfig) skillGapObjective() *SkillGapObjective {
+	if c.SkillGapTarget <= 0 {
+		return nil
+	}
+	strong, weak := c.SkillGapStrongAI, c.SkillGapWeakAI
+	if strong == "" {
+		strong = "mcts500"
+	}
+	if weak == "" {
+		weak = "mcts100"
+	}
+	strongAI, err := simulation.ParseAIPlayerType(strong)
+	if err != nil {
+		return nil
+	}
+	weakAI, err := simulation.ParseAIPlayerType(weak)
+	if err != nil {
+		return nil
+	}
+	return &SkillGapObjective{Strong: strongAI, Weak: weakAI, Target: c.SkillGapTarget, Games: c.SkillGapGames}
+}
+
+// playerCountEvaluation returns the configured player counts to evaluate
+// at, or nil to use the default count only.
+func (c *EvolutionConfig) playerCountEvaluation() *PlayerCountEvaluation {
+	if len(c.PlayerCounts) == 0 {
+		return nil
+	}
+	return &PlayerCountEvaluation{
+		Counts:      c.PlayerCounts,
+		Aggregation: c.PlayerCountAggregate,
+		Weights:     c.PlayerCountWeights,
+	}
+}
+
+// screening reports whether evaluation is two-tier.
+func (c *EvolutionConfig) screening() bool {
+	return c.ScreenFraction > 0 && c.ScreenFraction < 1
+}
+
+// complexityBudget returns the configured limits on genome size.
+func (c *EvolutionConfig) complexityBudget() operators.ComplexityBudget {
+	return operators.ComplexityBudget{MaxPhases: c.MaxPhases, MaxRules: c.MaxRules, RealDeck: c.RealDeckOnly}
+}
+
 // GenerationStats holds statistics for a single generation.
 type GenerationStats struct {
 	Generation  int
@@ -60,6 +176,10 @@ type GenerationStats struct {
 	Diversity   float64
 	Evaluations int
 	Timestamp   time.Time
+
+	// Throughput of the evaluation since the previous generation's stats
+	GamesSimulated int
+	GamesPerSec    float64
 }
 
 // EvolutionEngine runs the evolutionary algorithm.
@@ -69,13 +189,24 @@ type EvolutionEngine struct {
 	StatsHistory     []GenerationStats
 	BestEver         *Individual
 	Rng              *rand.Rand
+	rngSource        *trackedSource // Backs Rng; lets checkpoints record the stream position
 	Evaluator        *ParallelEvaluator
 	MutationPipeline *operators.MutationPipeline
 	Crossover        *UniformCrossover
 	UseAggressive    bool // Switch to aggressive mutation when diversity drops
 
+	// Credit for each offspring that beat its parent (AttributeMutations only)
+	Attributions []Attribution
+
 	// Callbacks for progress reporting
 	OnGenerationComplete func(stats GenerationStats)
+
+	// Optional live metrics, pushed after every generation's callback
+	Exporter MetricsExporter
+
+	// Games simulated since statsSince, for GenerationStats throughput
+	gamesSimulated int
+	statsSince     time.Time
 }
 
 // NewEvolutionEngine creates a new evolution engine.
@@ -89,7 +220,8 @@ func NewEvolutionEngine(config *EvolutionConfig) *EvolutionEngine {
 	if seed == 0 {
 		seed = time.Now().UnixNano()
 	}
-	rng := rand.New(rand.NewSource(seed))
+	source := newTrackedSource(seed)
+	rng := rand.New(source)
 
 	// Initialize workers
 	numWorkers := config.NumWorkers
@@ -98,14 +230,24 @@ func NewEvolutionEngine(config *EvolutionConfig) *EvolutionEngine {
 	}
 
 	// Create mutation pipeline
-	mutationPipeline := operators.NewDefaultPipeline(rng)
+	mutationPipeline := operators.NewDefaultPipeline(rng).WithBudget(config.complexityBudget())
+
+	evaluator := NewParallelEvaluator(config.FitnessStyle, numWorkers)
+	evaluator.Seed = uint64(seed)
+	evaluator.Reference = config.referenceOpponent()
+	evaluator.SkillGap = config.skillGapObjective()
+	evaluator.PlayerCounts = config.playerCountEvaluation()
+	evaluator.Determinizations = config.Determinizations
+	evaluator.MemoryWindow = config.MemoryWindow
+	evaluator.Verbose = config.Verbose
 
 	return &EvolutionEngine{
 		Config:           config,
 		Rng:              rng,
-		Evaluator:        NewParallelEvaluator(config.FitnessStyle, numWorkers),
+		rngSource:        source,
+		Evaluator:        evaluator,
 		MutationPipeline: mutationPipeline,
-		Crossover:        NewUniformCrossover(config.CrossoverRate),
+		Crossover:        &UniformCrossover{probability: config.CrossoverRate, Budget: config.complexityBudget()},
 		StatsHistory:     make([]GenerationStats, 0, config.MaxGenerations),
 	}
 }
@@ -123,17 +265,29 @@ func (e *EvolutionEngine) InitializePopulation() error {
 		log.Printf("Initializing population of size %d", e.Config.PopulationSize)
 	}
 
-	// Get seed genomes
-	seedGenomes := genome.GetSeedGenomes()
+	// Get seed genomes, extra seeds first so they are all kept
+	seedGenomes := append([]*genome.GameGenome(nil), e.Config.SeedGenomes...)
+	if !e.Config.SkipBuiltinSeeds {
+		seedGenomes = append(seedGenomes, genome.GetSeedGenomes()...)
+	}
+	if e.Config.RealDeckOnly {
+		seedGenomes = realDeckSeeds(seedGenomes, e.Config.Verbose)
+	}
 	if len(seedGenomes) == 0 {
 		return fmt.Errorf("no seed genomes available")
 	}
 
 	// Calculate how many should be seeds vs mutants
 	numSeeds := int(float64(e.Config.PopulationSize) * e.Config.SeedRatio)
+	if numSeeds < len(e.Config.SeedGenomes) {
+		numSeeds = len(e.Config.SeedGenomes)
+	}
 	if numSeeds > len(seedGenomes) {
 		numSeeds = len(seedGenomes)
 	}
+	if numSeeds > e.Config.PopulationSize {
+		numSeeds = e.Config.PopulationSize
+	}
 
 	individuals := make([]*Individual, 0, e.Config.PopulationSize)
 
@@ -174,6 +328,28 @@ func (e *EvolutionEngine) InitializePopulation() error {
 	return nil
 }
 
+// realDeckSeeds returns copies of seeds repaired to play with a standard
+// deck, leaving out any that the repair makes invalid.
+func realDeckSeeds(seeds []*genome.GameGenome, verbose bool) []*genome.GameGenome {
+	kept := make([]*genome.GameGenome, 0, len(seeds))
+	for _, seed := range seeds {
+		if operators.CheckRealDeck(seed) == nil {
+			kept = append(kept, seed)
+			continue
+		}
+		repaired := seed.Clone()
+		operators.RepairRealDeck(repaired)
+		if !genome.IsValid(repaired) {
+			if verbose {
+				log.Printf("Dropping seed %s: it can't be played with a standard deck", seed.Name)
+			}
+			continue
+		}
+		kept = append(kept, repaired)
+	}
+	return kept
+}
+
 // EvaluatePopulation evaluates fitness for all unevaluated individuals.
 func (e *EvolutionEngine) EvaluatePopulation() {
 	if e.Population == nil {
@@ -190,13 +366,67 @@ func (e *EvolutionEngine) EvaluatePopulation() {
 	}
 
 	// Evaluate in parallel
-	e.Evaluator.EvaluateIndividuals(unevaluated, e.Config.GamesPerEval, e.Config.UseMCTS)
+	if e.Config.screening() && e.Evaluator.ScreenIsCheaper(e.Config.UseMCTS) {
+		e.evaluateScreened(unevaluated)
+	} else {
+		e.Evaluator.EvaluateIndividuals(unevaluated, e.Config.GamesPerEval, e.Config.UseMCTS)
+		e.countGames(unevaluated)
+	}
 
 	if e.Config.Verbose {
 		log.Printf("Evaluation complete. Avg fitness: %.3f", e.Population.GetAverageFitness())
 	}
 }
 
+// evaluateScreened screens individuals cheaply, then fully evaluates those
+// that rank in the top ScreenFraction of the population. A promoted
+// individual can fall below one that was only screened, so promotion
+// repeats until the top of the population is all fully evaluated.
+func (e *EvolutionEngine) evaluateScreened(individuals []*Individual) {
+	genomes := make([]*genome.GameGenome, len(individuals))
+	for i, ind := range individuals {
+		genomes[i] = ind.Genome
+	}
+	for i, m := range e.Evaluator.ScreenPopulation(genomes, e.Config.GamesPerEval) {
+		individuals[i].Fitness = m.TotalFitness
+		individuals[i].FitnessMetrics = m
+		individuals[i].Evaluated = true
+		individuals[i].Screened = true
+	}
+	e.countGames(individuals)
+
+	top := int(math.Ceil(e.Config.ScreenFraction * float64(len(e.Population.Individuals))))
+	for {
+		var promoted []*Individual
+		for _, ind := range e.Population.SortByFitness()[:top] {
+			if ind.Screened {
+				promoted = append(promoted, ind)
+			}
+		}
+		if len(promoted) == 0 {
+			return
+		}
+		if e.Config.Verbose {
+			log.Printf("Fully evaluating %d screened individuals...", len(promoted))
+		}
+		e.Evaluator.EvaluateIndividuals(promoted, e.Config.GamesPerEval, e.Config.UseMCTS)
+		e.countGames(promoted)
+		for _, ind := range promoted {
+			ind.Screened = false
+		}
+	}
+}
+
+// countGames adds the games just played evaluating individuals to the
+// throughput reported in the next GenerationStats.
+func (e *EvolutionEngine) countGames(individuals []*Individual) {
+	for _, ind := range individuals {
+		if ind.FitnessMetrics != nil {
+			e.gamesSimulated += ind.FitnessMetrics.GamesSimulated
+		}
+	}
+}
+
 // CreateOffspring creates the next generation via selection, crossover, and mutation.
 func (e *EvolutionEngine) CreateOffspring() []*Individual {
 	offspring := make([]*Individual, 0, e.Config.PopulationSize)
@@ -217,15 +447,24 @@ func (e *EvolutionEngine) CreateOffspring() []*Individual {
 		// Crossover
 		child1, child2 := e.Crossover.Crossover(parent1.Genome, parent2.Genome, e.Rng)
 
-		// Mutation
-		e.MutationPipeline.Apply(child1, e.Rng)
-		e.MutationPipeline.Apply(child2, e.Rng)
+		// Mutation, remembering each change if it is to be credited later
+		var lineage1, lineage2 *lineage
+		if e.Config.AttributeMutations {
+			lineage1 = &lineage{parent: parent1, crossed: child1.Clone()}
+			lineage2 = &lineage{parent: parent2, crossed: child2.Clone()}
+			lineage1.mutations = e.MutationPipeline.ApplyTraced(child1, e.Rng)
+			lineage2.mutations = e.MutationPipeline.ApplyTraced(child2, e.Rng)
+		} else {
+			e.MutationPipeline.Apply(child1, e.Rng)
+			e.MutationPipeline.Apply(child2, e.Rng)
+		}
 
 		// Add to offspring (unevaluated)
 		offspring = append(offspring, &Individual{
 			Genome:    child1,
 			Fitness:   0.0,
 			Evaluated: false,
+			lineage:   lineage1,
 		})
 
 		if len(offspring) < e.Config.PopulationSize {
@@ -233,6 +472,7 @@ func (e *EvolutionEngine) CreateOffspring() []*Individual {
 				Genome:    child2,
 				Fitness:   0.0,
 				Evaluated: false,
+				lineage:   lineage2,
 			})
 		}
 	}
@@ -283,10 +523,12 @@ func (e *EvolutionEngine) Evolve() error {
 	}
 
 	// Evaluate initial population
+	e.statsSince = time.Now()
 	e.EvaluatePopulation()
 
-	// Evolution loop
-	for generation := 0; generation < e.Config.MaxGenerations; generation++ {
+	// Evolution loop (a population restored from a checkpoint picks up at its
+	// own generation)
+	for generation := e.Population.Generation; generation < e.Config.MaxGenerations; generation++ {
 		if e.Config.Verbose {
 			log.Printf("\n============================================================")
 			log.Printf("Generation %d/%d", generation+1, e.Config.MaxGenerations)
@@ -308,19 +550,30 @@ func (e *EvolutionEngine) Evolve() error {
 
 		// Store stats
 		stats := GenerationStats{
-			Generation:  generation,
-			BestFitness: best.Fitness,
-			AvgFitness:  avgFitness,
-			Diversity:   diversity,
-			Evaluations: len(e.Population.Individuals),
-			Timestamp:   time.Now(),
+			Generation:     generation,
+			BestFitness:    best.Fitness,
+			AvgFitness:     avgFitness,
+			Diversity:      diversity,
+			Evaluations:    len(e.Population.Individuals),
+			Timestamp:      time.Now(),
+			GamesSimulated: e.gamesSimulated,
 		}
+		if elapsed := stats.Timestamp.Sub(e.statsSince).Seconds(); elapsed > 0 {
+			stats.GamesPerSec = float64(e.gamesSimulated) / elapsed
+		}
+		e.gamesSimulated = 0
+		e.statsSince = stats.Timestamp
 		e.StatsHistory = append(e.StatsHistory, stats)
 
 		// Callback
 		if e.OnGenerationComplete != nil {
 			e.OnGenerationComplete(stats)
 		}
+		if e.Exporter != nil {
+			if err := e.Exporter.Export(stats); err != nil {
+				log.Printf("Warning: metrics export failed: %v", err)
+			}
+		}
 
 		if e.Config.Verbose {
 			modeIndicator := ""
@@ -339,7 +592,7 @@ func (e *EvolutionEngine) Evolve() error {
 					log.Printf("WARNING: Low diversity (%.4f) - switching to AGGRESSIVE mutation mode", diversity)
 				}
 				e.UseAggressive = true
-				e.MutationPipeline = operators.NewAggressivePipeline(e.Rng)
+				e.MutationPipeline = operators.NewAggressivePipeline(e.Rng).WithBudget(e.Config.complexityBudget())
 			}
 		} else if diversity > e.Config.DiversityThreshold*1.5 {
 			if e.UseAggressive {
@@ -347,7 +600,7 @@ func (e *EvolutionEngine) Evolve() error {
 					log.Printf("Diversity recovered (%.4f) - switching back to normal mutation mode", diversity)
 				}
 				e.UseAggressive = false
-				e.MutationPipeline = operators.NewDefaultPipeline(e.Rng)
+				e.MutationPipeline = operators.NewDefaultPipeline(e.Rng).WithBudget(e.Config.complexityBudget())
 			}
 		}
 
@@ -366,6 +619,9 @@ func (e *EvolutionEngine) Evolve() error {
 
 		// Evaluate new individuals
 		e.EvaluatePopulation()
+		if e.Config.AttributeMutations {
+			e.Attributions = append(e.Attributions, e.attributeImprovements()...)
+		}
 	}
 
 	if e.Config.Verbose {
@@ -1,10 +1,15 @@
 package evolution
 
 import (
+	"encoding/json"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/signalnine/darwindeck/gosim/evolution/fitness"
+	"github.com/signalnine/darwindeck/gosim/evolution/operators"
 	"github.com/signalnine/darwindeck/gosim/genome"
 )
 
@@ -98,6 +103,61 @@ func TestInitializePopulation(t *testing.T) {
 	}
 }
 
+func TestInitializePopulationWithSeedGenomes(t *testing.T) {
+	library := []*genome.GameGenome{genome.CreateWarGenome(), genome.CreateHeartsGenome(), genome.CreateCrazyEightsGenome()}
+	for i, g := range library {
+		g.Name = "Library" + string(rune('A'+i))
+	}
+	isLibrary := func(name string) bool { return strings.HasPrefix(name, "Library") }
+
+	config := &EvolutionConfig{
+		PopulationSize: 10,
+		SeedRatio:      0.1, // Fewer slots than library genomes
+		FitnessStyle:   "balanced",
+		RandomSeed:     42,
+		SeedGenomes:    library,
+	}
+	engine := NewEvolutionEngine(config)
+	defer engine.Close()
+	if err := engine.InitializePopulation(); err != nil {
+		t.Fatalf("InitializePopulation failed: %v", err)
+	}
+
+	// Every library genome is placed unmutated, ahead of the built-in seeds
+	for i, g := range library {
+		if got := engine.Population.Individuals[i].Genome; got.Name != g.Name || got == g {
+			t.Errorf("Individual %d: expected a copy of %s, got %s", i, g.Name, got.Name)
+		}
+	}
+	foundBuiltin := false
+	for _, ind := range engine.Population.Individuals {
+		foundBuiltin = foundBuiltin || !isLibrary(ind.Genome.Name)
+	}
+	if !foundBuiltin {
+		t.Error("Expected built-in seeds to fill out the population")
+	}
+
+	// Without the built-in seeds, everything descends from the library
+	config.SkipBuiltinSeeds = true
+	engine = NewEvolutionEngine(config)
+	defer engine.Close()
+	if err := engine.InitializePopulation(); err != nil {
+		t.Fatalf("InitializePopulation failed: %v", err)
+	}
+	for i, ind := range engine.Population.Individuals {
+		if !isLibrary(ind.Genome.Name) {
+			t.Errorf("Individual %d: expected a library descendant, got %s", i, ind.Genome.Name)
+		}
+	}
+
+	config.SeedGenomes = nil
+	engine = NewEvolutionEngine(config)
+	defer engine.Close()
+	if err := engine.InitializePopulation(); err == nil {
+		t.Error("Expected an error with no seeds to draw from")
+	}
+}
+
 func TestTournamentSelection(t *testing.T) {
 	// Create a simple population with known fitnesses
 	individuals := make([]*Individual, 10)
@@ -218,6 +278,114 @@ func TestCreateOffspring(t *testing.T) {
 	}
 }
 
+func TestCreateOffspringRespectsComplexityBudget(t *testing.T) {
+	config := &EvolutionConfig{
+		PopulationSize: 20,
+		ElitismRate:    0.1,
+		CrossoverRate:  0.7,
+		TournamentSize: 2,
+		RandomSeed:     42,
+		FitnessStyle:   "balanced",
+		GamesPerEval:   10,
+		MaxPhases:      3,
+		MaxRules:       3,
+	}
+
+	engine := NewEvolutionEngine(config)
+	defer engine.Close()
+
+	seeds := []*genome.GameGenome{genome.CreateWarGenome(), genome.CreateCrazyEightsGenome()}
+	individuals := make([]*Individual, config.PopulationSize)
+	for i := range individuals {
+		individuals[i] = &Individual{Genome: seeds[i%len(seeds)].Clone(), Fitness: 0.5, Evaluated: true}
+	}
+	engine.Population = NewPopulation(individuals)
+	budget := config.complexityBudget()
+
+	// Several generations of mutation without a budget would add phases
+	for gen := 0; gen < 5; gen++ {
+		offspring := engine.CreateOffspring()
+		for i, ind := range offspring {
+			if budget.Exceeds(ind.Genome) {
+				t.Fatalf("Generation %d offspring %d over budget: %d phases, %d rules",
+					gen, i, len(ind.Genome.TurnStructure.Phases), operators.RuleCount(ind.Genome))
+			}
+			ind.Fitness, ind.Evaluated = 0.5, true
+		}
+		engine.Population = NewPopulation(offspring)
+	}
+}
+
+func TestRealDeckOnlyEvolution(t *testing.T) {
+	config := &EvolutionConfig{
+		PopulationSize: 30,
+		ElitismRate:    0.1,
+		CrossoverRate:  0.7,
+		TournamentSize: 2,
+		SeedRatio:      0.5,
+		RandomSeed:     42,
+		FitnessStyle:   "balanced",
+		GamesPerEval:   10,
+		RealDeckOnly:   true,
+	}
+	engine := NewEvolutionEngine(config)
+	defer engine.Close()
+
+	if err := engine.InitializePopulation(); err != nil {
+		t.Fatalf("InitializePopulation failed: %v", err)
+	}
+	for gen := 0; gen < 3; gen++ {
+		for i, ind := range engine.Population.Individuals {
+			if err := operators.CheckRealDeck(ind.Genome); err != nil {
+				t.Fatalf("Generation %d individual %d (%s): %v", gen, i, ind.Genome.Name, err)
+			}
+			ind.Fitness, ind.Evaluated = 0.5, true
+		}
+		engine.Population = NewPopulation(engine.CreateOffspring())
+	}
+}
+
+// TestScreenedEvaluation checks two-tier evaluation leaves the top of the
+// population fully evaluated and the rest with their screening fitness.
+func TestScreenedEvaluation(t *testing.T) {
+	config := &EvolutionConfig{
+		PopulationSize: 12,
+		SeedRatio:      0.5,
+		RandomSeed:     42,
+		FitnessStyle:   "balanced",
+		GamesPerEval:   10,
+		ReferenceAI:    "random", // Only the full evaluation plays the reference AI
+		ScreenFraction: 0.25,
+	}
+	engine := NewEvolutionEngine(config)
+	defer engine.Close()
+
+	if err := engine.InitializePopulation(); err != nil {
+		t.Fatalf("InitializePopulation failed: %v", err)
+	}
+	engine.EvaluatePopulation()
+
+	sorted := engine.Population.SortByFitness()
+	screened := 0
+	for i, ind := range sorted {
+		if !ind.Evaluated {
+			t.Fatalf("Individual %d (%s) was not evaluated", i, ind.Genome.Name)
+		}
+		if i < 3 && ind.Screened {
+			t.Errorf("Individual ranked %d (%s) only screened", i, ind.Genome.Name)
+		}
+		if ind.Screened {
+			screened++
+		}
+	}
+	if screened == 0 {
+		t.Error("Expected some individuals to keep their screening fitness")
+	}
+	if engine.Population.GetBestIndividual().Screened {
+		t.Error("Expected the best individual to be fully evaluated")
+	}
+}
+
 func TestCheckPlateau(t *testing.T) {
 	config := &EvolutionConfig{
 		PlateauThreshold:     5,
@@ -470,3 +638,407 @@ func TestAutoCheckpointer(t *testing.T) {
 		t.Error("Should save at generation 10")
 	}
 }
+
+// checkpointTestEngine returns a small evaluated engine for checkpoint tests.
+func checkpointTestEngine(t *testing.T) *EvolutionEngine {
+	t.Helper()
+	engine := NewEvolutionEngine(&EvolutionConfig{
+		PopulationSize: 5,
+		MaxGenerations: 3,
+		SeedRatio:      1.0,
+		RandomSeed:     42,
+		FitnessStyle:   "balanced",
+		GamesPerEval:   5,
+		NumWorkers:     1,
+	})
+	if err := engine.InitializePopulation(); err != nil {
+		t.Fatalf("InitializePopulation failed: %v", err)
+	}
+	engine.EvaluatePopulation()
+	return engine
+}
+
+// advanceGeneration replaces the population with offspring, drawing from the
+// evolution random stream the way a generation of Evolve does.
+func advanceGeneration(engine *EvolutionEngine) {
+	engine.StatsHistory = append(engine.StatsHistory, GenerationStats{Generation: engine.Population.Generation})
+	generation := engine.Population.Generation + 1
+	engine.Population = NewPopulation(engine.CreateOffspring())
+	engine.Population.Generation = generation
+}
+
+func populationJSON(t *testing.T, engine *EvolutionEngine) string {
+	t.Helper()
+	data, err := json.Marshal(engine.Population.Individuals)
+	if err != nil {
+		t.Fatalf("Failed to marshal population: %v", err)
+	}
+	return string(data)
+}
+
+// assertSameContinuation checks that two engines hold the same population and
+// continue the same random stream.
+func assertSameContinuation(t *testing.T, want, got *EvolutionEngine) {
+	t.Helper()
+	if got.Population.Generation != want.Population.Generation {
+		t.Errorf("Generation mismatch: expected %d, got %d", want.Population.Generation, got.Population.Generation)
+	}
+	if populationJSON(t, got) != populationJSON(t, want) {
+		t.Fatal("Resumed population differs from the saved one")
+	}
+
+	wantNext, _ := json.Marshal(want.CreateOffspring())
+	gotNext, _ := json.Marshal(got.CreateOffspring())
+	if string(gotNext) != string(wantNext) {
+		t.Error("Resumed engine bred different offspring")
+	}
+	if got.Rng.Int63() != want.Rng.Int63() {
+		t.Error("Resumed engine is at a different position in the random stream")
+	}
+}
+
+func TestCheckpointResumeContinuesRandomStream(t *testing.T) {
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	engine := checkpointTestEngine(t)
+	defer engine.Close()
+	advanceGeneration(engine)
+
+	if err := engine.SaveCheckpoint(checkpointPath); err != nil {
+		t.Fatalf("SaveCheckpoint failed: %v", err)
+	}
+	// Overwriting an existing checkpoint must leave no temp files behind
+	if err := engine.SaveCheckpoint(checkpointPath); err != nil {
+		t.Fatalf("SaveCheckpoint failed: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Dir(checkpointPath))
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected only the checkpoint file, found %d entries", len(entries))
+	}
+
+	resumed, err := ResumeFromCheckpoint(checkpointPath)
+	if err != nil {
+		t.Fatalf("ResumeFromCheckpoint failed: %v", err)
+	}
+	defer resumed.Close()
+
+	assertSameContinuation(t, engine, resumed)
+}
+
+func TestCheckpointLog(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+
+	engine := checkpointTestEngine(t)
+	defer engine.Close()
+	log := NewCheckpointLog(engine, logPath, 0)
+
+	if err := log.Save(); err != nil {
+		t.Fatalf("Base save failed: %v", err)
+	}
+	base, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		advanceGeneration(engine)
+		if err := log.Save(); err != nil {
+			t.Fatalf("Delta save failed: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.HasPrefix(string(data), string(base)) {
+		t.Fatal("Delta saves should append to the log, not rewrite it")
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 3 {
+		t.Errorf("Expected 3 records, got %d", lines)
+	}
+
+	// A torn final append is ignored
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	f.WriteString(`{"kind":"delta","delta":{"generation":`)
+	f.Close()
+
+	checkpoint, err := LoadCheckpoint(logPath)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint failed: %v", err)
+	}
+	if checkpoint.Generation != 2 {
+		t.Errorf("Expected generation 2, got %d", checkpoint.Generation)
+	}
+	if len(checkpoint.StatsHistory) != 2 {
+		t.Errorf("Expected 2 stats entries, got %d", len(checkpoint.StatsHistory))
+	}
+
+	resumed, err := ResumeFromCheckpoint(logPath)
+	if err != nil {
+		t.Fatalf("ResumeFromCheckpoint failed: %v", err)
+	}
+	defer resumed.Close()
+
+	assertSameContinuation(t, engine, resumed)
+}
+
+func TestCheckpointLogCompaction(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+
+	engine := checkpointTestEngine(t)
+	defer engine.Close()
+	log := NewCheckpointLog(engine, logPath, 1)
+
+	// base, delta, then a fresh base
+	for i := 0; i < 3; i++ {
+		if err := log.Save(); err != nil {
+			t.Fatalf("Save %d failed: %v", i, err)
+		}
+		advanceGeneration(engine)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 1 {
+		t.Errorf("Expected compaction to leave 1 record, got %d", lines)
+	}
+	checkpoint, err := LoadCheckpoint(logPath)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint failed: %v", err)
+	}
+	if checkpoint.Generation != 2 {
+		t.Errorf("Expected generation 2, got %d", checkpoint.Generation)
+	}
+}
+
+func TestResumedRunMatchesUninterrupted(t *testing.T) {
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+	config := func() *EvolutionConfig {
+		return &EvolutionConfig{
+			PopulationSize:     6,
+			MaxGenerations:     10,
+			ElitismRate:        0.2,
+			CrossoverRate:      0.7,
+			TournamentSize:     2,
+			DiversityThreshold: 0.1,
+			SeedRatio:          0.5,
+			RandomSeed:         7,
+			FitnessStyle:       "balanced",
+			GamesPerEval:       5,
+			NumWorkers:         2,
+		}
+	}
+
+	// Uninterrupted run, checkpointing at generation 5 along the way
+	full := NewEvolutionEngine(config())
+	defer full.Close()
+	full.OnGenerationComplete = func(stats GenerationStats) {
+		if stats.Generation == 5 {
+			if err := full.SaveCheckpoint(checkpointPath); err != nil {
+				t.Fatalf("SaveCheckpoint failed: %v", err)
+			}
+		}
+	}
+	if err := full.Evolve(); err != nil {
+		t.Fatalf("Evolve failed: %v", err)
+	}
+
+	resumed, err := ResumeFromCheckpoint(checkpointPath)
+	if err != nil {
+		t.Fatalf("ResumeFromCheckpoint failed: %v", err)
+	}
+	defer resumed.Close()
+	if err := resumed.Evolve(); err != nil {
+		t.Fatalf("Resumed Evolve failed: %v", err)
+	}
+
+	if resumed.Population.Generation != full.Population.Generation {
+		t.Fatalf("Expected to finish at generation %d, got %d",
+			full.Population.Generation, resumed.Population.Generation)
+	}
+	wantBest, _ := json.Marshal(newIndividualData(full.BestEver))
+	gotBest, _ := json.Marshal(newIndividualData(resumed.BestEver))
+	if string(gotBest) != string(wantBest) {
+		t.Errorf("Resumed BestEver differs: fitness %.6f, want %.6f",
+			resumed.BestEver.Fitness, full.BestEver.Fitness)
+	}
+	if populationJSON(t, resumed) != populationJSON(t, full) {
+		t.Error("Resumed final population differs from the uninterrupted run")
+	}
+	if len(resumed.StatsHistory) != len(full.StatsHistory) {
+		t.Fatalf("Expected %d stats entries, got %d", len(full.StatsHistory), len(resumed.StatsHistory))
+	}
+	for i := range full.StatsHistory {
+		want, got := full.StatsHistory[i], resumed.StatsHistory[i]
+		if got.BestFitness != want.BestFitness || got.AvgFitness != want.AvgFitness || got.Diversity != want.Diversity {
+			t.Errorf("Generation %d stats differ: %+v vs %+v", i, got, want)
+		}
+	}
+}
+
+func TestReferenceOpponentEvaluation(t *testing.T) {
+	config := &EvolutionConfig{
+		PopulationSize: 4,
+		SeedRatio:      1.0,
+		RandomSeed:     42,
+		FitnessStyle:   "balanced",
+		GamesPerEval:   20,
+		NumWorkers:     1,
+		ReferenceAI:    "greedy",
+	}
+	engine := NewEvolutionEngine(config)
+	defer engine.Close()
+
+	if ref := engine.Evaluator.Reference; ref == nil || ref.AI != AITypeGreedy {
+		t.Fatalf("Expected a greedy reference opponent, got %+v", ref)
+	}
+
+	// Greedy play beats random play in Crazy Eights, so the rules reward skill
+	metrics := engine.Evaluator.evaluateGenome(genome.CreateCrazyEightsGenome(), 20, false)
+	if metrics.ReferenceEdge < 0.5 {
+		t.Errorf("Expected a clear reference edge, got %.2f", metrics.ReferenceEdge)
+	}
+
+	// The reference opponent survives a checkpoint
+	if err := engine.InitializePopulation(); err != nil {
+		t.Fatalf("InitializePopulation failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	if err := engine.SaveCheckpoint(path); err != nil {
+		t.Fatalf("SaveCheckpoint failed: %v", err)
+	}
+	resumed, err := ResumeFromCheckpoint(path)
+	if err != nil {
+		t.Fatalf("ResumeFromCheckpoint failed: %v", err)
+	}
+	defer resumed.Close()
+	if ref := resumed.Evaluator.Reference; ref == nil || ref.AI != AITypeGreedy {
+		t.Errorf("Expected the resumed engine to keep the reference opponent, got %+v", ref)
+	}
+
+	// Without a known reference AI, evaluation is self-play only
+	for _, name := range []string{"", "grandmaster"} {
+		if ref := (&EvolutionConfig{ReferenceAI: name}).referenceOpponent(); ref != nil {
+			t.Errorf("Expected no reference opponent for %q, got %+v", name, ref)
+		}
+	}
+}
+
+func TestSkillGapObjective(t *testing.T) {
+	config := &EvolutionConfig{
+		PopulationSize:   4,
+		SeedRatio:        1.0,
+		RandomSeed:       42,
+		FitnessStyle:     "balanced",
+		GamesPerEval:     20,
+		NumWorkers:       1,
+		SkillGapTarget:   0.6,
+		SkillGapStrongAI: "greedy",
+		SkillGapWeakAI:   "random",
+	}
+	engine := NewEvolutionEngine(config)
+	defer engine.Close()
+
+	gap := engine.Evaluator.SkillGap
+	if gap == nil || gap.Strong != AITypeGreedy || gap.Weak != AITypeRandom || gap.Target != 0.6 {
+		t.Fatalf("Expected a greedy over random objective at 0.6, got %+v", gap)
+	}
+	metrics := engine.Evaluator.evaluateGenome(genome.CreateCrazyEightsGenome(), 20, false)
+	if metrics.SkillGap <= 0.5 {
+		t.Errorf("Expected greedy play to beat random play, got a skill gap of %.2f", metrics.SkillGap)
+	}
+
+	// Unset AIs default to a 500- over a 100-iteration searcher
+	if gap := (&EvolutionConfig{SkillGapTarget: 0.7}).skillGapObjective(); gap == nil || gap.Strong != AITypeMCTS500 || gap.Weak != AITypeMCTS100 {
+		t.Errorf("Expected mcts500 over mcts100 by default, got %+v", gap)
+	}
+	// Without a target, or with an unknown AI, there is no objective
+	for _, c := range []EvolutionConfig{{}, {SkillGapTarget: 0.7, SkillGapWeakAI: "novice"}} {
+		if gap := c.skillGapObjective(); gap != nil {
+			t.Errorf("Expected no skill gap objective for %+v, got %+v", c, gap)
+		}
+	}
+}
+
+func TestPlayerCountEvaluation(t *testing.T) {
+	config := &EvolutionConfig{
+		PopulationSize:       4,
+		RandomSeed:           42,
+		FitnessStyle:         "balanced",
+		NumWorkers:           1,
+		PlayerCounts:         []int{2, 4},
+		PlayerCountAggregate: PlayerCountMin,
+	}
+	engine := NewEvolutionEngine(config)
+	defer engine.Close()
+
+	metrics := engine.Evaluator.evaluateGenome(genome.CreateCrazyEightsGenome(), 20, false)
+	if len(metrics.ByPlayerCount) != 2 || metrics.ByPlayerCount[0].Players != 2 || metrics.ByPlayerCount[1].Players != 4 {
+		t.Fatalf("Expected metrics at 2 and 4 players, got %+v", metrics.ByPlayerCount)
+	}
+	two, four := metrics.ByPlayerCount[0].Metrics, metrics.ByPlayerCount[1].Metrics
+	if !metrics.Valid || metrics.GamesSimulated != 40 {
+		t.Errorf("Expected a valid result over 40 games, got valid=%v games=%d", metrics.Valid, metrics.GamesSimulated)
+	}
+	if want := math.Min(two.TotalFitness, four.TotalFitness); metrics.TotalFitness != want {
+		t.Errorf("Expected min fitness %.4f, got %.4f", want, metrics.TotalFitness)
+	}
+	worst := two
+	if four.TotalFitness < two.TotalFitness {
+		worst = four
+	}
+	if metrics.DecisionDensity != worst.DecisionDensity || metrics.KingmakerRate != worst.KingmakerRate || metrics.ErrorRate != worst.ErrorRate {
+		t.Errorf("Expected the min to report the worst count's metrics, got %+v from %+v", metrics, worst)
+	}
+	if len(two.SeatWinRates) != 2 || len(four.SeatWinRates) != 4 || metrics.SeatWinRates != nil {
+		t.Errorf("Expected a win rate for each seat at each count and none combined, got %v, %v and %v",
+			two.SeatWinRates, four.SeatWinRates, metrics.SeatWinRates)
+	}
+
+	// Mean and weighted aggregation of the same per-count results
+	byCount := metrics.ByPlayerCount
+	mean := (&PlayerCountEvaluation{Aggregation: PlayerCountMean}).combine(byCount)
+	if want := (two.TotalFitness + four.TotalFitness) / 2; math.Abs(mean.TotalFitness-want) > 1e-9 {
+		t.Errorf("Expected mean fitness %.4f, got %.4f", want, mean.TotalFitness)
+	}
+	weighted := (&PlayerCountEvaluation{Aggregation: PlayerCountWeighted, Weights: []float64{1, 3}}).combine(byCount)
+	if want := (two.TotalFitness + 3*four.TotalFitness) / 4; math.Abs(weighted.TotalFitness-want) > 1e-9 {
+		t.Errorf("Expected weighted fitness %.4f, got %.4f", want, weighted.TotalFitness)
+	}
+
+	// A count where the game doesn't play scores zero
+	broken := []fitness.PlayerCountMetrics{byCount[0], {Players: 3, Metrics: &fitness.FitnessMetrics{Valid: false, TotalFitness: 0.9}}}
+	if got := (&PlayerCountEvaluation{Aggregation: PlayerCountMin}).combine(broken); got.TotalFitness != 0 || got.ErrorRate != 1 || !got.Valid {
+		t.Errorf("Expected an invalid count to pull the min to zero with every game an error, got %.4f and %.2f (valid=%v)",
+			got.TotalFitness, got.ErrorRate, got.Valid)
+	}
+	if got := (&PlayerCountEvaluation{Aggregation: PlayerCountMean}).combine(broken); got.ErrorRate != (byCount[0].Metrics.ErrorRate+1)/2 {
+		t.Errorf("Expected an invalid count to raise the mean error rate, got %.2f", got.ErrorRate)
+	}
+
+	// The player counts survive a checkpoint
+	if err := engine.InitializePopulation(); err != nil {
+		t.Fatalf("InitializePopulation failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	if err := engine.SaveCheckpoint(path); err != nil {
+		t.Fatalf("SaveCheckpoint failed: %v", err)
+	}
+	resumed, err := ResumeFromCheckpoint(path)
+	if err != nil {
+		t.Fatalf("ResumeFromCheckpoint failed: %v", err)
+	}
+	defer resumed.Close()
+	if pc := resumed.Evaluator.PlayerCounts; pc == nil || len(pc.Counts) != 2 || pc.Aggregation != PlayerCountMin {
+		t.Errorf("Expected the resumed engine to keep the player counts, got %+v", pc)
+	}
+}
@@ -0,0 +1,117 @@
+package evolution
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetricsExporter pushes each generation's statistics to a monitoring
+// system, so a long run can be watched live rather than from the terminal.
+// Set one on EvolutionEngine.Exporter.
+type MetricsExporter interface {
+	Export(stats GenerationStats) error
+}
+
+// gauge is one exported measurement.
+type gauge struct {
+	name  string
+	value float64
+}
+
+// generationGauges returns the measurements exported for a generation.
+func generationGauges(stats GenerationStats) []gauge {
+	return []gauge{
+		{"generation", float64(stats.Generation)},
+		{"best_fitness", stats.BestFitness},
+		{"avg_fitness", stats.AvgFitness},
+		{"diversity", stats.Diversity},
+		{"evaluations", float64(stats.Evaluations)},
+		{"games_simulated", float64(stats.GamesSimulated)},
+		{"games_per_second", stats.GamesPerSec},
+	}
+}
+
+// StatsdExporter sends each generation's statistics as statsd gauges over
+// UDP, named "<Prefix>.best_fitness" and so on.
+type StatsdExporter struct {
+	Prefix string
+	conn   net.Conn
+}
+
+// NewStatsdExporter returns an exporter sending to the statsd daemon at
+// addr ("host:port").
+func NewStatsdExporter(addr, prefix string) (*StatsdExporter, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("statsd %s: %w", addr, err)
+	}
+	return &StatsdExporter{Prefix: prefix, conn: conn}, nil
+}
+
+// Export sends the generation's gauges in a single packet.
+func (s *StatsdExporter) Export(stats GenerationStats) error {
+	var b strings.Builder
+	for _, g := range generationGauges(stats) {
+		if s.Prefix != "" {
+			b.WriteString(s.Prefix)
+			b.WriteByte('.')
+		}
+		fmt.Fprintf(&b, "%s:%s|g\n", g.name, strconv.FormatFloat(g.value, 'f', -1, 64))
+	}
+	_, err := s.conn.Write([]byte(b.String()))
+	return err
+}
+
+// Close closes the connection to the statsd daemon.
+func (s *StatsdExporter) Close() error {
+	return s.conn.Close()
+}
+
+// PushgatewayExporter pushes each generation's statistics to a Prometheus
+// Pushgateway, replacing the job's previous values. Metrics are named
+// "darwindeck_best_fitness" and so on.
+type PushgatewayExporter struct {
+	URL    string // Pushgateway base URL, e.g. http://localhost:9091
+	Job    string
+	Client *http.Client
+}
+
+// NewPushgatewayExporter returns an exporter pushing to the Pushgateway at
+// baseURL under job.
+func NewPushgatewayExporter(baseURL, job string) *PushgatewayExporter {
+	return &PushgatewayExporter{
+		URL:    strings.TrimRight(baseURL, "/"),
+		Job:    job,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Export pushes the generation's gauges in the Prometheus text format.
+func (p *PushgatewayExporter) Export(stats GenerationStats) error {
+	var body bytes.Buffer
+	for _, g := range generationGauges(stats) {
+		fmt.Fprintf(&body, "# TYPE darwindeck_%s gauge\ndarwindeck_%s %s\n",
+			g.name, g.name, strconv.FormatFloat(g.value, 'g', -1, 64))
+	}
+
+	req, err := http.NewRequest(http.MethodPut, p.URL+"/metrics/job/"+url.PathEscape(p.Job), &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("pushgateway %s: %s", p.URL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package evolution
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsdExporter(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("UDP unavailable: %v", err)
+	}
+	defer listener.Close()
+
+	exporter, err := NewStatsdExporter(listener.LocalAddr().String(), "dd")
+	if err != nil {
+		t.Fatalf("Failed to create exporter: %v", err)
+	}
+	defer exporter.Close()
+
+	if err := exporter.Export(GenerationStats{Generation: 3, BestFitness: 0.5, GamesPerSec: 1200}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	buf := make([]byte, 1024)
+	listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := listener.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("No packet received: %v", err)
+	}
+	packet := string(buf[:n])
+	for _, want := range []string{"dd.generation:3|g\n", "dd.best_fitness:0.5|g\n", "dd.games_per_second:1200|g\n"} {
+		if !strings.Contains(packet, want) {
+			t.Errorf("Expected %q in packet, got %q", want, packet)
+		}
+	}
+}
+
+func TestPushgatewayExporter(t *testing.T) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(data)
+	}))
+	defer server.Close()
+
+	exporter := NewPushgatewayExporter(server.URL+"/", "evolve")
+	if err := exporter.Export(GenerationStats{Generation: 7, Diversity: 0.25}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if method != http.MethodPut || path != "/metrics/job/evolve" {
+		t.Errorf("Expected PUT /metrics/job/evolve, got %s %s", method, path)
+	}
+	for _, want := range []string{"# TYPE darwindeck_generation gauge\ndarwindeck_generation 7\n", "darwindeck_diversity 0.25\n"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %q in body, got %q", want, body)
+		}
+	}
+
+	// A rejected push is reported
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad metrics", http.StatusBadRequest)
+	}))
+	defer failing.Close()
+	if err := NewPushgatewayExporter(failing.URL, "evolve").Export(GenerationStats{}); err == nil {
+		t.Error("Expected an error from a rejected push")
+	}
+}
+
+// recordingExporter keeps every exported generation.
+type recordingExporter struct {
+	stats []GenerationStats
+}
+
+func (r *recordingExporter) Export(stats GenerationStats) error {
+	r.stats = append(r.stats, stats)
+	return nil
+}
+
+func TestEngineExportsGenerations(t *testing.T) {
+	config := &EvolutionConfig{
+		PopulationSize: 5,
+		MaxGenerations: 2,
+		SeedRatio:      1.0,
+		RandomSeed:     42,
+		FitnessStyle:   "balanced",
+		GamesPerEval:   5,
+		NumWorkers:     1,
+	}
+	engine := NewEvolutionEngine(config)
+	defer engine.Close()
+	exporter := &recordingExporter{}
+	engine.Exporter = exporter
+
+	if err := engine.Evolve(); err != nil {
+		t.Fatalf("Evolve failed: %v", err)
+	}
+	if len(exporter.stats) != config.MaxGenerations {
+		t.Fatalf("Expected %d exports, got %d", config.MaxGenerations, len(exporter.stats))
+	}
+	first := exporter.stats[0]
+	if first.GamesSimulated == 0 || first.GamesPerSec <= 0 {
+		t.Errorf("Expected the initial evaluation's throughput, got %d games at %f/s", first.GamesSimulated, first.GamesPerSec)
+	}
+}
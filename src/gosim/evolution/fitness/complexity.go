@@ -106,6 +106,12 @@ func calculatePhaseCost(g *genome.GameGenome) float64 {
 		genome.PhaseTypeBetting: 0.50, // Check, bet, call, raise, fold, all-in, pot
 		genome.PhaseTypeClaim:   0.55, // Claim, lie option, challenge, truth check
 		genome.PhaseTypeBidding: 0.40, // Contract bidding
+		genome.PhaseTypeShow:    0.12, // Reveal hands, best hand wins
+		genome.PhaseTypePeek:    0.10, // Look at an opponent's hand
+
+		genome.PhaseTypeDrawDiscard: 0.15, // Draw, then discard to end the turn
+		genome.PhaseTypeGive:        0.08, // Hand a card to an opponent
+		genome.PhaseTypeTrade:       0.15, // Offer a card, opponent accepts or declines
 	}
 
 	cost := 0.0
@@ -266,11 +272,14 @@ func calculateStateTrackingCost(g *genome.GameGenome) float64 {
 	cost := 0.0
 
 	for _, p := range g.TurnStructure.Phases {
-		switch p.(type) {
+		switch phase := p.(type) {
 		case *genome.TrickPhase:
 			cost += 0.15 // Trump suit, lead suit
 		case *genome.BettingPhase:
 			cost += 0.20 // Pot, current bet, who's in
+			if len(phase.Community) > 0 {
+				cost += 0.10 // The board, and the best hand it makes
+			}
 		}
 	}
 
@@ -382,10 +391,26 @@ func estimateExplanationSentences(g *genome.GameGenome) int {
 			sentences += 5 // Lead, follow, trump, resolution, scoring
 		case *genome.BettingPhase:
 			sentences += 4 // Check, bet, raise, fold
+			if phase, ok := p.(*genome.BettingPhase); ok && len(phase.Community) > 0 {
+				sentences++ // Community cards between rounds
+			}
 		case *genome.ClaimPhase:
 			sentences += 3 // Claim, challenge, resolution
+			if phase, ok := p.(*genome.ClaimPhase); ok && phase.RankRule == genome.ClaimRankMatchOrHigher {
+				sentences++ // Claims can't go down until a challenge
+			}
 		case *genome.BiddingPhase:
 			sentences += 3 // Bidding rules
+		case *genome.ShowPhase:
+			sentences += 1 // Reveal and compare
+		case *genome.PeekPhase:
+			sentences += 1 // Whose hand and for how long
+		case *genome.DrawDiscardPhase:
+			sentences += 2 // Draw or take the discard, then discard
+		case *genome.GivePhase:
+			sentences += 1 // Which card to whom
+		case *genome.TradePhase:
+			sentences += 2 // The offer, then what accepting costs
 		default:
 			sentences += 1
 		}
@@ -11,6 +11,7 @@ import (
 type SimulationResults struct {
 	TotalGames  int
 	Wins        []int   // Wins per player (index = player ID)
+	TurnOrderWins []int // Wins per place in the turn order (nil = seat order, as when seat 0 always starts)
 	PlayerCount int     // Number of players (2-4)
 	Draws       int
 	AvgTurns    float64
@@ -39,10 +40,11 @@ type SimulationResults struct {
 	AllInCount   int
 
 	// Tension curve metrics
-	LeadChanges     int
-	DecisiveTurnPct float64
-	ClosestMargin   float64
-	TrailingWinners int // Games where winner was behind at midpoint
+	LeadChanges      int
+	DecisiveTurnPct  float64
+	ClosestMargin    float64
+	MarginVolatility float64 // Mean per-game standard deviation of the signed lead margin
+	TrailingWinners  int     // Games where winner was behind at midpoint
 
 	// Solitaire detection metrics
 	MoveDisruptionEvents int
@@ -52,6 +54,53 @@ type SimulationResults struct {
 
 	// Team play metrics
 	TeamWins []int // Win count per team (nil if not a team game)
+
+	// Kingmaker metrics (3+ player games)
+	KingmakerDecisions int // Losing players' final decisions replayed
+	KingmakerEvents    int // Decisions where the loser's choice picked the winner
+
+	// Economy metrics (games played with chips)
+	ChipGames       int     // Games that ended with chips in play
+	AvgChipVariance float64 // Mean variance of final chip counts
+	AvgChipSpread   float64 // Mean normalized spread of final chips (0-1)
+
+	// Shoe metrics (games dealt from a shoe with penetration)
+	ShoeHands    int     // Hands dealt between a counting and a random player
+	CountingEdge float64 // Counting player's hand win rate minus the random player's
+
+	// Tempo metrics
+	Tempo float64 // Mean cards moved per action
+
+	// Hidden information metrics
+	RevealedInfo     float64 // Share of opponents' cards the deciding player had peeked at
+	ShowdownExposure float64 // Share of the cards held at showdowns that were shown, not mucked
+
+	// Card usage metrics
+	CardRelevance float64 // How evenly play touched every rank and suit (0-1)
+
+	// Standoff metrics
+	StandoffGames int // Games where nearly every action was a pass or a check
+
+	// Multi-hand metrics (decided games of two or more hands)
+	MultiHandGames    int
+	HandLeadStability float64 // Mean share of hands after which the eventual winner led
+
+	// Reference metrics (games against a fixed reference AI)
+	ReferenceGames int     // Games between the reference AI and the evaluation AI
+	ReferenceEdge  float64 // Reference AI's win rate minus the evaluation AI's
+
+	// Skill gap metrics (games between a strong and a weak AI)
+	SkillGapGames  int     // Games either AI won
+	StrongWinRate  float64 // Strong AI's share of those games
+	SkillGapTarget float64 // Strong AI's ideal share (0 = no target)
+
+	// Memory metrics (games between MCTS players with perfect and limited recall)
+	MemoryGames int     // Games between them
+	MemoryEdge  float64 // Perfect recall's win rate minus limited recall's
+
+	// Seat metrics (see simulation.AggregatedStats.SeatWinRates)
+	SeatWinRates []float64 // Share of the decided games won from each seat
+	SeatEdge     float64   // Best seat's win rate above an even share
 }
 
 // Player0Wins returns wins for player 0 (backward compatibility).
@@ -75,15 +124,43 @@ type FitnessMetrics struct {
 	DecisionDensity      float64
 	ComebackPotential    float64
 	TensionCurve         float64
+	Swinginess           float64 // How far the lead swings back and forth within a game
+	Tempo                float64 // How many cards change hands per action
+	CardRelevance        float64 // How many ranks and suits matter in play
 	InteractionFrequency float64
 	RulesComplexity      float64
 	SessionLength        float64 // Tracked but not averaged (constraint only)
 	SkillVsLuck          float64
 	BluffingDepth        float64 // Quality of bluffing mechanics
 	BettingEngagement    float64 // Psychological appeal of betting
+	KingmakerRate        float64 // Fraction of losers' final decisions that picked the winner
+	EconomicVolatility   float64 // How far chips moved between players by game end
+	ReferenceEdge        float64 // Reference AI's edge over the evaluation AI (0 if not measured)
+	StandoffRate         float64 // Fraction of games that stalled in passes and checks
+	HandLeadStability    float64 // How early multi-hand games were settled (tracked, not scored)
+	SeatFairness         float64 // How little turn order decides who wins
+	SeatEdge             float64 // Best seat's win rate above an even share (tracked, not scored)
+	SkillGap             float64 // Strong AI's win rate over the weak AI (0 if not measured)
+	MemoryReward         float64 // Perfect recall's edge over limited recall (discounts SkillVsLuck)
+	ErrorRate            float64 // Fraction of games that ended in an error
 	TotalFitness         float64
 	GamesSimulated       int
 	Valid                bool
+
+	// ByPlayerCount holds the metrics at each table size when a genome was
+	// evaluated at several player counts; the fields above combine them.
+	ByPlayerCount []PlayerCountMetrics
+
+	// SeatWinRates is the share of decided games won from each seat, to
+	// spot positional imbalance such as a button edge (nil when combined
+	// across player counts).
+	SeatWinRates []float64
+}
+
+// PlayerCountMetrics is a genome's fitness at one table size.
+type PlayerCountMetrics struct {
+	Players int
+	Metrics *FitnessMetrics
 }
 
 // ComputeMetrics calculates fitness metrics from simulation results.
@@ -104,9 +181,12 @@ func ComputeMetrics(g *genome.GameGenome, results *SimulationResults, weights ma
 
 	// 3. Tension curve
 	tensionCurve := computeTensionCurve(results)
+	swinginess := computeSwinginess(results)
 
 	// 4. Interaction frequency
 	interactionFrequency := computeInteractionFrequency(g, results)
+	tempo := computeTempo(results)
+	cardRelevance := computeCardRelevance(results)
 
 	// 5. Rules complexity (inverted - simpler is better)
 	rulesComplexity := ComputeRulesComplexity(g)
@@ -123,11 +203,16 @@ func ComputeMetrics(g *genome.GameGenome, results *SimulationResults, weights ma
 	// 7. Skill vs luck
 	skillVsLuck := computeSkillVsLuck(g, results, comebackPotential, style)
 
-	// 8. Bluffing depth
-	bluffingDepth := computeBluffingDepth(results)
+	// 8. Bluffing depth, worth less the more of a bluffer's hand was peeked
+	// at, and the more hands were shown down for the table to remember
+	bluffingDepth := computeBluffingDepth(results) * (1.0 - results.RevealedInfo) * (1.0 - 0.5*results.ShowdownExposure)
 
 	// 9. Betting engagement
 	bettingEngagement := computeBettingEngagement(results)
+	economicVolatility := computeEconomicVolatility(results)
+
+	// 10. Seat fairness
+	seatFairness := computeSeatFairness(results)
 
 	// Check validity
 	validResult := results.Errors == 0 && results.TotalGames > 0
@@ -139,11 +224,15 @@ func ComputeMetrics(g *genome.GameGenome, results *SimulationResults, weights ma
 	totalFitness := weights["decision_density"]*decisionDensity +
 		weights["comeback_potential"]*comebackPotential +
 		weights["tension_curve"]*effectiveTension +
+		weights["swinginess"]*swinginess +
 		weights["interaction_frequency"]*interactionFrequency +
+		weights["tempo"]*tempo +
+		weights["card_relevance"]*cardRelevance +
 		weights["rules_complexity"]*rulesComplexity +
 		weights["skill_vs_luck"]*skillVsLuck +
 		weights["bluffing_depth"]*bluffingDepth +
-		weights["betting_engagement"]*bettingEngagement
+		weights["betting_engagement"]*bettingEngagement +
+		weights["seat_fairness"]*seatFairness
 
 	// Quality gates
 	qualityMultiplier := 1.0
@@ -158,6 +247,11 @@ func ComputeMetrics(g *genome.GameGenome, results *SimulationResults, weights ma
 		qualityMultiplier *= 0.7
 	}
 
+	// Meaningful interaction gate: social games still need choices
+	if gate, ok := InteractionGates[style]; ok && !gate.Passes(interactionFrequency, decisionDensity) {
+		qualityMultiplier *= gate.Multiplier
+	}
+
 	// One-sidedness check
 	if results.TotalGames > 0 && len(results.Wins) >= 2 {
 		maxWins := 0
@@ -176,21 +270,56 @@ func ComputeMetrics(g *genome.GameGenome, results *SimulationResults, weights ma
 	coherencePenalty := calculateCoherencePenalty(g)
 	qualityMultiplier *= (1.0 - coherencePenalty)
 
+	// Kingmaker penalty: strategic and balanced players resent losing to
+	// someone else's whim more than party players do
+	kingmakerRate := computeKingmakerRate(results)
+	if style == "strategic" || style == "balanced" {
+		qualityMultiplier *= 1.0 - kingmakerRate*0.5
+	}
+
+	// Standoff penalty: a draw where everyone passes until the turn limit
+	// isn't a tense game, however close the scores stay
+	standoffRate := computeStandoffRate(results)
+	qualityMultiplier *= 1.0 - standoffRate*0.5
+
+	// Error penalty: a genome whose games often break is culled however
+	// good the games that finished looked
+	errorRate := computeErrorRate(results)
+	qualityMultiplier *= math.Max(0, 1.0-errorRate*errorPenalty)
+
+	// Skill gap penalty: a game aimed at a difficulty curve should let the
+	// stronger player win about as often as the target, no more, no less
+	qualityMultiplier *= math.Max(0, 1.0-computeSkillGapMiss(results)*skillGapPenalty)
+
 	totalFitness *= qualityMultiplier
 
 	return &FitnessMetrics{
 		DecisionDensity:      decisionDensity,
 		ComebackPotential:    comebackPotential,
 		TensionCurve:         tensionCurve,
+		Swinginess:           swinginess,
 		InteractionFrequency: interactionFrequency,
+		Tempo:                tempo,
+		CardRelevance:        cardRelevance,
 		RulesComplexity:      rulesComplexity,
 		SessionLength:        sessionLength,
 		SkillVsLuck:          skillVsLuck,
 		BluffingDepth:        bluffingDepth,
 		BettingEngagement:    bettingEngagement,
+		KingmakerRate:        kingmakerRate,
+		EconomicVolatility:   economicVolatility,
+		ReferenceEdge:        results.ReferenceEdge,
+		StandoffRate:         standoffRate,
+		HandLeadStability:    results.HandLeadStability,
+		SeatFairness:         seatFairness,
+		SeatEdge:             results.SeatEdge,
+		SkillGap:             results.StrongWinRate,
+		MemoryReward:         results.MemoryEdge,
+		ErrorRate:            errorRate,
 		TotalFitness:         totalFitness,
 		GamesSimulated:       results.TotalGames,
 		Valid:                validResult,
+		SeatWinRates:         results.SeatWinRates,
 	}
 }
 
@@ -333,6 +462,12 @@ func computeTensionCurve(results *SimulationResults) float64 {
 	return math.Min(0.6, turnScore*0.6+lengthBonus*0.4)
 }
 
+// computeSwinginess scores the size of momentum swings: 0 for a steady gap,
+// 1 once the lead margin's standard deviation reaches half its range.
+func computeSwinginess(results *SimulationResults) float64 {
+	return math.Max(0.0, math.Min(1.0, results.MarginVolatility*2))
+}
+
 func computeInteractionFrequency(g *genome.GameGenome, results *SimulationResults) float64 {
 	if results.OpponentTurnCount > 0 {
 		moveDisruption := math.Min(1.0, float64(results.MoveDisruptionEvents)/float64(results.OpponentTurnCount))
@@ -362,6 +497,44 @@ func computeInteractionFrequency(g *genome.GameGenome, results *SimulationResult
 	return math.Min(1.0, specialEffectsScore*0.4+trickBasedScore+multiPhaseScore)
 }
 
+// computeSeatFairness scores how little turn order decides games, with
+// every seat played by the same AI: 1 when each place in the turn order wins
+// an even share of the decided games, 0 when one place wins them all.
+func computeSeatFairness(results *SimulationResults) float64 {
+	wins := results.TurnOrderWins
+	if wins == nil {
+		wins = results.Wins
+	}
+	n := results.PlayerCount
+	if n < 2 || n > len(wins) {
+		return 1.0
+	}
+	decided, best := 0, 0
+	for _, w := range wins[:n] {
+		decided += w
+		best = max(best, w)
+	}
+	if decided == 0 {
+		return 1.0
+	}
+	even := 1.0 / float64(n)
+	edge := float64(best)/float64(decided) - even
+	return math.Max(0.0, 1.0-edge/(1.0-even))
+}
+
+// computeTempo scores how snappy play is: 1 once actions average a card
+// each, lower the more turns pass or bet without touching the cards.
+func computeTempo(results *SimulationResults) float64 {
+	return math.Max(0.0, math.Min(1.0, results.Tempo))
+}
+
+// computeCardRelevance scores how much of the deck matters: 1 when plays,
+// captures and points spread evenly over every rank and suit, lower when
+// some cards are dead weight.
+func computeCardRelevance(results *SimulationResults) float64 {
+	return math.Max(0.0, math.Min(1.0, results.CardRelevance))
+}
+
 func computeSessionLength(results *SimulationResults) (float64, bool) {
 	estimatedDurationSec := results.AvgTurns * 2 // 2 sec per turn
 	targetMax := float64(60 * 60)                // 60 minutes
@@ -395,6 +568,24 @@ func computeSkillVsLuck(g *genome.GameGenome, results *SimulationResults, comeba
 			balanceFactor*0.3+
 			complexityFactor*0.3)
 
+	// Shoe games measure skill directly: does counting beat random play?
+	if results.ShoeHands > 0 {
+		skillVsLuck = skillVsLuck*0.7 + math.Max(0, results.CountingEdge)*0.3
+	}
+
+	// So do games against a reference AI: a strong, consistent player should
+	// beat a weaker one if the rules reward skill
+	if results.ReferenceGames > 0 {
+		skillVsLuck = skillVsLuck*0.5 + math.Max(0, results.ReferenceEdge)*0.5
+	}
+
+	// Skill that only perfect recall can reach is beyond most people, so
+	// the more a forgetful player loses to one who remembers every trick,
+	// the less the game is counted as skillful
+	if results.MemoryGames > 0 {
+		skillVsLuck *= 1.0 - 0.5*math.Max(0, results.MemoryEdge)
+	}
+
 	// For party style, invert skill metric
 	if style == "party" {
 		skillVsLuck = 1.0 - skillVsLuck
@@ -516,11 +707,70 @@ func computeBettingEngagement(results *SimulationResults) float64 {
 		showdownScore = math.Max(0.0, math.Min(1.0, showdownScore))
 	}
 
-	return resolutionScore*0.30 +
+	engagement := resolutionScore*0.30 +
 		dramaScore*0.20 +
 		activityScore*0.15 +
 		varianceScore*0.15 +
 		showdownScore*0.20
+
+	// Chip swings: betting where stacks never move is hollow
+	if results.ChipGames > 0 {
+		engagement = engagement*0.8 + computeEconomicVolatility(results)*0.2
+	}
+
+	return engagement
+}
+
+// computeEconomicVolatility scores how unevenly chips ended up distributed.
+// 0 means every game ended with even stacks (or no chips were used), 1 means
+// one player took every chip in every game.
+func computeEconomicVolatility(results *SimulationResults) float64 {
+	if results.ChipGames == 0 {
+		return 0.0
+	}
+	return math.Max(0.0, math.Min(1.0, results.AvgChipSpread))
+}
+
+// computeKingmakerRate returns the fraction of analyzed end-game decisions
+// where a losing player's choice decided which opponent won.
+func computeKingmakerRate(results *SimulationResults) float64 {
+	if results.KingmakerDecisions == 0 {
+		return 0.0
+	}
+	return math.Min(1.0, float64(results.KingmakerEvents)/float64(results.KingmakerDecisions))
+}
+
+// errorPenalty is the fitness multiplier lost per unit of error rate, so a
+// genome whose games fail half the time keeps no fitness at all.
+const errorPenalty = 2.0
+
+func computeErrorRate(results *SimulationResults) float64 {
+	if results.TotalGames == 0 {
+		return 0.0
+	}
+	return math.Min(1.0, float64(results.Errors)/float64(results.TotalGames))
+}
+
+// skillGapPenalty is the fitness multiplier lost per unit the strong AI's
+// win rate misses the target skill gap by, so missing by half keeps none.
+const skillGapPenalty = 2.0
+
+// computeSkillGapMiss returns how far the strong AI's win rate over the weak
+// AI landed from the target, or 0 if the gap wasn't measured.
+func computeSkillGapMiss(results *SimulationResults) float64 {
+	if results.SkillGapTarget <= 0 || results.SkillGapGames == 0 {
+		return 0.0
+	}
+	return math.Abs(results.StrongWinRate - results.SkillGapTarget)
+}
+
+// computeStandoffRate returns the fraction of games that were inert
+// standoffs, dominated by passes and checks.
+func computeStandoffRate(results *SimulationResults) float64 {
+	if results.TotalGames == 0 {
+		return 0.0
+	}
+	return math.Min(1.0, float64(results.StandoffGames)/float64(results.TotalGames))
 }
 
 func calculateCoherencePenalty(g *genome.GameGenome) float64 {
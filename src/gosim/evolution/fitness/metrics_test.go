@@ -1,6 +1,7 @@
 package fitness
 
 import (
+	"math"
 	"testing"
 
 	"github.com/signalnine/darwindeck/gosim/genome"
@@ -263,3 +264,441 @@ func TestSessionLengthTooLong(t *testing.T) {
 		t.Error("Expected invalid session length for >60 min game")
 	}
 }
+
+func TestKingmakerPenalty(t *testing.T) {
+	g := genome.CreateWarGenome()
+	base := SimulationResults{
+		TotalGames:  100,
+		Wins:        []int{34, 33, 33},
+		PlayerCount: 3,
+		AvgTurns:    52.0,
+	}
+	kingmade := base
+	kingmade.KingmakerDecisions = 200
+	kingmade.KingmakerEvents = 100
+
+	for _, style := range []string{"strategic", "balanced"} {
+		clean := ComputeMetrics(g, &base, StylePresets[style], style)
+		penalized := ComputeMetrics(g, &kingmade, StylePresets[style], style)
+
+		if penalized.KingmakerRate != 0.5 {
+			t.Errorf("%s: expected kingmaker rate 0.5, got %f", style, penalized.KingmakerRate)
+		}
+		if penalized.TotalFitness >= clean.TotalFitness {
+			t.Errorf("%s: expected kingmaker penalty, got %f >= %f", style, penalized.TotalFitness, clean.TotalFitness)
+		}
+	}
+
+	// Party games tolerate kingmaking
+	clean := ComputeMetrics(g, &base, StylePresets["party"], "party")
+	penalized := ComputeMetrics(g, &kingmade, StylePresets["party"], "party")
+	if penalized.TotalFitness != clean.TotalFitness {
+		t.Errorf("party: expected no kingmaker penalty, got %f vs %f", penalized.TotalFitness, clean.TotalFitness)
+	}
+}
+
+func TestStandoffPenalty(t *testing.T) {
+	g := genome.CreateWarGenome()
+	base := SimulationResults{
+		TotalGames:  100,
+		Wins:        []int{40, 40},
+		Draws:       20,
+		PlayerCount: 2,
+		AvgTurns:    52.0,
+	}
+	stalled := base
+	stalled.StandoffGames = 20
+
+	clean := ComputeMetrics(g, &base, StylePresets["balanced"], "balanced")
+	penalized := ComputeMetrics(g, &stalled, StylePresets["balanced"], "balanced")
+	if penalized.StandoffRate != 0.2 {
+		t.Errorf("Expected standoff rate 0.2, got %f", penalized.StandoffRate)
+	}
+	if penalized.TotalFitness >= clean.TotalFitness {
+		t.Errorf("Expected standoff penalty, got %f >= %f", penalized.TotalFitness, clean.TotalFitness)
+	}
+}
+
+func TestEconomicVolatility(t *testing.T) {
+	results := &SimulationResults{
+		TotalGames:   100,
+		Wins:         []int{50, 50},
+		TotalBets:    500,
+		AllInCount:   15,
+		ShowdownWins: 70,
+		FoldWins:     25,
+	}
+	if v := computeEconomicVolatility(results); v != 0 {
+		t.Errorf("Expected no volatility without chip data, got %f", v)
+	}
+	flat := computeBettingEngagement(results)
+
+	results.ChipGames = 100
+	results.AvgChipSpread = 0.8
+	if v := computeEconomicVolatility(results); v != 0.8 {
+		t.Errorf("Expected volatility 0.8, got %f", v)
+	}
+
+	// Stacks that never move make betting less engaging
+	results.AvgChipSpread = 0.0
+	if still := computeBettingEngagement(results); still >= flat {
+		t.Errorf("Expected static chips to reduce engagement, got %f >= %f", still, flat)
+	}
+}
+
+func TestSwinginess(t *testing.T) {
+	g := genome.CreateWarGenome()
+	steady := SimulationResults{
+		TotalGames:       100,
+		Wins:             []int{50, 50},
+		PlayerCount:      2,
+		AvgTurns:         52.0,
+		LeadChanges:      300,
+		DecisiveTurnPct:  0.8,
+		ClosestMargin:    0.1,
+		MarginVolatility: 0.05,
+	}
+	seeSaw := steady
+	seeSaw.MarginVolatility = 0.4
+
+	calm := ComputeMetrics(g, &steady, StylePresets["dramatic"], "dramatic")
+	wild := ComputeMetrics(g, &seeSaw, StylePresets["dramatic"], "dramatic")
+	if wild.Swinginess <= calm.Swinginess {
+		t.Errorf("Expected see-saw swinginess %f > steady %f", wild.Swinginess, calm.Swinginess)
+	}
+	if wild.TotalFitness <= calm.TotalFitness {
+		t.Errorf("Expected see-saw game to score higher at equal lead changes, got %f <= %f",
+			wild.TotalFitness, calm.TotalFitness)
+	}
+
+	// Styles that don't weight it are unaffected
+	if a, b := ComputeMetrics(g, &steady, StylePresets["balanced"], "balanced"),
+		ComputeMetrics(g, &seeSaw, StylePresets["balanced"], "balanced"); a.TotalFitness != b.TotalFitness {
+		t.Errorf("balanced: expected swinginess to be unweighted, got %f vs %f", a.TotalFitness, b.TotalFitness)
+	}
+
+	seeSaw.MarginVolatility = 0.9
+	if s := computeSwinginess(&seeSaw); s != 1.0 {
+		t.Errorf("Expected swinginess to cap at 1.0, got %f", s)
+	}
+}
+
+func TestSeatFairness(t *testing.T) {
+	g := genome.CreateWarGenome()
+	even := SimulationResults{
+		TotalGames:    100,
+		Wins:          []int{50, 50},
+		TurnOrderWins: []int{50, 50},
+		PlayerCount:   2,
+		AvgTurns:      52.0,
+	}
+	firstMover := even
+	firstMover.TurnOrderWins = []int{70, 30}
+
+	fair := ComputeMetrics(g, &even, StylePresets["balanced"], "balanced")
+	unfair := ComputeMetrics(g, &firstMover, StylePresets["balanced"], "balanced")
+	if fair.SeatFairness != 1.0 || math.Abs(unfair.SeatFairness-0.6) > 1e-9 {
+		t.Errorf("Expected seat fairness 1.0 and 0.6, got %f and %f", fair.SeatFairness, unfair.SeatFairness)
+	}
+	if unfair.TotalFitness >= fair.TotalFitness {
+		t.Errorf("Expected a first-mover edge to cost fitness, got %f >= %f", unfair.TotalFitness, fair.TotalFitness)
+	}
+
+	// Styles that don't weight it are unaffected
+	if a, b := ComputeMetrics(g, &even, StylePresets["party"], "party"),
+		ComputeMetrics(g, &firstMover, StylePresets["party"], "party"); a.TotalFitness != b.TotalFitness {
+		t.Errorf("party: expected seat fairness to be unweighted, got %f vs %f", a.TotalFitness, b.TotalFitness)
+	}
+
+	// Without turn order counts, seat order stands in for it
+	firstMover.TurnOrderWins = nil
+	firstMover.Wins = []int{100, 0}
+	if f := computeSeatFairness(&firstMover); f != 0.0 {
+		t.Errorf("Expected one seat winning every game to score 0, got %f", f)
+	}
+}
+
+func TestSkillVsLuckCountingEdge(t *testing.T) {
+	g := genome.CreateBlackjackGenome()
+	results := &SimulationResults{
+		TotalGames: 100,
+		Wins:       []int{50, 50},
+		AvgTurns:   10,
+	}
+	base := computeSkillVsLuck(g, results, 0.5, "balanced")
+
+	// Counting that clearly beats random play shows skill
+	results.ShoeHands = 150
+	results.CountingEdge = 0.9
+	if counted := computeSkillVsLuck(g, results, 0.5, "balanced"); counted <= base {
+		t.Errorf("Expected counting edge to raise skill vs luck, got %f <= %f", counted, base)
+	}
+
+	// No edge means the shoe is pure luck
+	results.CountingEdge = -0.1
+	if luck := computeSkillVsLuck(g, results, 0.5, "balanced"); luck >= base {
+		t.Errorf("Expected no counting edge to lower skill vs luck, got %f >= %f", luck, base)
+	}
+}
+
+func TestSkillVsLuckReferenceEdge(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+	results := &SimulationResults{
+		TotalGames: 100,
+		Wins:       []int{50, 50},
+		AvgTurns:   40,
+	}
+	base := computeSkillVsLuck(g, results, 0.5, "balanced")
+
+	// A reference AI that beats the evaluation AI shows the rules reward skill
+	results.ReferenceGames = 100
+	results.ReferenceEdge = 0.9
+	if skilled := computeSkillVsLuck(g, results, 0.5, "balanced"); skilled <= base {
+		t.Errorf("Expected reference edge to raise skill vs luck, got %f <= %f", skilled, base)
+	}
+
+	// A reference AI that can't pull ahead means the outcome is luck
+	results.ReferenceEdge = 0
+	if luck := computeSkillVsLuck(g, results, 0.5, "balanced"); luck >= base {
+		t.Errorf("Expected no reference edge to lower skill vs luck, got %f >= %f", luck, base)
+	}
+}
+
+func TestSkillVsLuckMemoryEdge(t *testing.T) {
+	g := genome.CreateHeartsGenome()
+	results := &SimulationResults{
+		TotalGames: 100,
+		Wins:       []int{50, 50},
+		AvgTurns:   40,
+	}
+	base := computeSkillVsLuck(g, results, 0.5, "balanced")
+
+	// A forgetful player who keeps up means memory isn't what wins
+	results.MemoryGames = 100
+	results.MemoryEdge = 0
+	if same := computeSkillVsLuck(g, results, 0.5, "balanced"); same != base {
+		t.Errorf("Expected no memory edge to leave skill vs luck at %f, got %f", base, same)
+	}
+
+	// Perfect recall winning every game halves the skill credited
+	results.MemoryEdge = 1
+	if recall := computeSkillVsLuck(g, results, 0.5, "balanced"); math.Abs(recall-base/2) > 1e-9 {
+		t.Errorf("Expected full memory edge to halve skill vs luck to %f, got %f", base/2, recall)
+	}
+}
+
+func TestCardRelevance(t *testing.T) {
+	g := genome.CreateWarGenome()
+	dead := SimulationResults{
+		TotalGames:    100,
+		Wins:          []int{50, 50},
+		PlayerCount:   2,
+		AvgTurns:      52.0,
+		CardRelevance: 0.2,
+	}
+	live := dead
+	live.CardRelevance = 0.95
+
+	narrow := ComputeMetrics(g, &dead, StylePresets["strategic"], "strategic")
+	wide := ComputeMetrics(g, &live, StylePresets["strategic"], "strategic")
+	if wide.CardRelevance != 0.95 || narrow.CardRelevance != 0.2 {
+		t.Errorf("Expected relevance to pass through, got %f and %f", wide.CardRelevance, narrow.CardRelevance)
+	}
+	if wide.TotalFitness <= narrow.TotalFitness {
+		t.Errorf("strategic: expected a deck without dead cards to score higher, got %f <= %f",
+			wide.TotalFitness, narrow.TotalFitness)
+	}
+}
+
+func TestTempo(t *testing.T) {
+	g := genome.CreateWarGenome()
+	slow := SimulationResults{
+		TotalGames:  100,
+		Wins:        []int{50, 50},
+		PlayerCount: 2,
+		AvgTurns:    52.0,
+		Tempo:       0.25,
+	}
+	fast := slow
+	fast.Tempo = 1.0
+
+	sluggish := ComputeMetrics(g, &slow, StylePresets["party"], "party")
+	snappy := ComputeMetrics(g, &fast, StylePresets["party"], "party")
+	if snappy.Tempo <= sluggish.Tempo {
+		t.Errorf("Expected snappy tempo %f > sluggish %f", snappy.Tempo, sluggish.Tempo)
+	}
+	if snappy.TotalFitness <= sluggish.TotalFitness {
+		t.Errorf("party: expected high tempo to score higher, got %f <= %f",
+			snappy.TotalFitness, sluggish.TotalFitness)
+	}
+
+	// Styles that don't weight it are unaffected
+	if a, b := ComputeMetrics(g, &slow, StylePresets["strategic"], "strategic"),
+		ComputeMetrics(g, &fast, StylePresets["strategic"], "strategic"); a.TotalFitness != b.TotalFitness {
+		t.Errorf("strategic: expected tempo to be unweighted, got %f vs %f", a.TotalFitness, b.TotalFitness)
+	}
+
+	fast.Tempo = 2.0
+	if s := computeTempo(&fast); s != 1.0 {
+		t.Errorf("Expected tempo to cap at 1.0, got %f", s)
+	}
+}
+
+func TestBluffingDepthRevealedInfo(t *testing.T) {
+	g := genome.CreateCheatGenome()
+	hidden := SimulationResults{
+		TotalGames:        100,
+		Wins:              []int{50, 50},
+		PlayerCount:       2,
+		AvgTurns:          60.0,
+		TotalClaims:       100,
+		TotalBluffs:       60,
+		TotalChallenges:   40,
+		SuccessfulBluffs:  20,
+		SuccessfulCatches: 20,
+	}
+	peeked := hidden
+	peeked.RevealedInfo = 0.5
+
+	a := ComputeMetrics(g, &hidden, StylePresets["bluffing"], "bluffing")
+	b := ComputeMetrics(g, &peeked, StylePresets["bluffing"], "bluffing")
+	if b.BluffingDepth != a.BluffingDepth*0.5 {
+		t.Errorf("Expected half the hidden cards to halve bluffing depth, got %f from %f", b.BluffingDepth, a.BluffingDepth)
+	}
+	if b.TotalFitness >= a.TotalFitness {
+		t.Errorf("Expected peeking to lower bluffing fitness, got %f >= %f", b.TotalFitness, a.TotalFitness)
+	}
+
+	// Hands shown down give bluffs away to the rest of the table
+	shown := hidden
+	shown.ShowdownExposure = 1
+	c := ComputeMetrics(g, &shown, StylePresets["bluffing"], "bluffing")
+	if c.BluffingDepth != a.BluffingDepth*0.5 {
+		t.Errorf("Expected every showdown hand shown to halve bluffing depth, got %f from %f", c.BluffingDepth, a.BluffingDepth)
+	}
+}
+
+func TestErrorPenalty(t *testing.T) {
+	g := genome.CreateWarGenome()
+	base := SimulationResults{
+		TotalGames:  100,
+		Wins:        []int{50, 50},
+		PlayerCount: 2,
+		AvgTurns:    52.0,
+	}
+	clean := ComputeMetrics(g, &base, StylePresets["balanced"], "balanced")
+
+	// A genome that breaks half the time keeps nothing
+	broken := base
+	broken.Wins = []int{25, 25}
+	broken.Errors = 50
+	crushed := ComputeMetrics(g, &broken, StylePresets["balanced"], "balanced")
+	if crushed.ErrorRate != 0.5 {
+		t.Errorf("Expected error rate 0.5, got %f", crushed.ErrorRate)
+	}
+	if clean.TotalFitness <= 0 || crushed.TotalFitness != 0 {
+		t.Errorf("Expected fitness crushed to 0 from %f, got %f", clean.TotalFitness, crushed.TotalFitness)
+	}
+
+	// The penalty grows with the error rate
+	flaky := base
+	flaky.Wins = []int{45, 45}
+	flaky.Errors = 10
+	penalized := ComputeMetrics(g, &flaky, StylePresets["balanced"], "balanced")
+	if penalized.TotalFitness <= crushed.TotalFitness || penalized.TotalFitness >= clean.TotalFitness {
+		t.Errorf("Expected fitness between %f and %f, got %f", crushed.TotalFitness, clean.TotalFitness, penalized.TotalFitness)
+	}
+}
+
+func TestSkillGapTarget(t *testing.T) {
+	g := genome.CreateWarGenome()
+	base := SimulationResults{
+		TotalGames:  100,
+		Wins:        []int{50, 50},
+		PlayerCount: 2,
+		AvgTurns:    52.0,
+	}
+	onTarget := base
+	onTarget.SkillGapGames, onTarget.StrongWinRate, onTarget.SkillGapTarget = 40, 0.65, 0.65
+	missed := onTarget
+	missed.StrongWinRate = 0.95
+
+	plain := ComputeMetrics(g, &base, StylePresets["balanced"], "balanced")
+	hit := ComputeMetrics(g, &onTarget, StylePresets["balanced"], "balanced")
+	miss := ComputeMetrics(g, &missed, StylePresets["balanced"], "balanced")
+	if hit.TotalFitness != plain.TotalFitness {
+		t.Errorf("Expected a gap on target to keep fitness %f, got %f", plain.TotalFitness, hit.TotalFitness)
+	}
+	if want := plain.TotalFitness * 0.4; math.Abs(miss.TotalFitness-want) > 1e-9 {
+		t.Errorf("Expected a gap 0.3 off target to scale fitness to %f, got %f", want, miss.TotalFitness)
+	}
+	if miss.SkillGap != 0.95 || plain.SkillGap != 0 {
+		t.Errorf("Expected skill gaps 0.95 and 0, got %f and %f", miss.SkillGap, plain.SkillGap)
+	}
+
+	// A target with no decided games is not measured
+	missed.SkillGapGames = 0
+	if f := ComputeMetrics(g, &missed, StylePresets["balanced"], "balanced"); f.TotalFitness != plain.TotalFitness {
+		t.Errorf("Expected an unmeasured gap to keep fitness %f, got %f", plain.TotalFitness, f.TotalFitness)
+	}
+}
+
+func TestPartyInteractionGate(t *testing.T) {
+	g := genome.CreateWarGenome()
+	// Both games interact on 60% of actions; the chaotic one never offers
+	// more than the one forced move
+	chaotic := SimulationResults{
+		TotalGames:        100,
+		Wins:              []int{50, 50},
+		PlayerCount:       2,
+		AvgTurns:          52.0,
+		TotalActions:      1000,
+		TotalInteractions: 600,
+		TotalDecisions:    1000,
+		TotalValidMoves:   1000,
+		ForcedDecisions:   1000,
+	}
+	choiceful := chaotic
+	choiceful.TotalValidMoves = 4000
+	choiceful.ForcedDecisions = 200
+	choiceful.TotalHandSize = 5000
+
+	party := StylePresets["party"]
+	gate := InteractionGates["party"]
+	ungate := func(results *SimulationResults) *FitnessMetrics {
+		defer func() { InteractionGates["party"] = gate }()
+		delete(InteractionGates, "party")
+		return ComputeMetrics(g, results, party, "party")
+	}
+	for _, tc := range []struct {
+		name    string
+		results SimulationResults
+		gated   bool
+	}{
+		{"chaotic", chaotic, true},
+		{"choiceful", choiceful, false},
+	} {
+		gated := ComputeMetrics(g, &tc.results, party, "party")
+		ungated := ungate(&tc.results)
+		if gated.InteractionFrequency != 0.6 {
+			t.Errorf("%s: expected interaction 0.6, got %f", tc.name, gated.InteractionFrequency)
+		}
+		want := ungated.TotalFitness
+		if tc.gated {
+			want *= gate.Multiplier
+		}
+		if math.Abs(gated.TotalFitness-want) > 1e-9 {
+			t.Errorf("%s: expected party fitness %f, got %f (decision density %f)",
+				tc.name, want, gated.TotalFitness, gated.DecisionDensity)
+		}
+	}
+
+	chaos := ComputeMetrics(g, &chaotic, party, "party")
+	choice := ComputeMetrics(g, &choiceful, party, "party")
+	if chaos.TotalFitness >= choice.TotalFitness {
+		t.Errorf("Expected the chaotic game to score below the choiceful one, got %f >= %f", chaos.TotalFitness, choice.TotalFitness)
+	}
+	if _, ok := InteractionGates["balanced"]; ok {
+		t.Error("Expected no interaction gate for balanced")
+	}
+}
@@ -0,0 +1,53 @@
+package fitness
+
+// ObjectiveNames are the metrics compared when ranking genomes by Pareto
+// dominance: the ones the style weights combine into TotalFitness, each
+// better when higher.
+var ObjectiveNames = []string{
+	"decision_density",
+	"comeback_potential",
+	"tension_curve",
+	"swinginess",
+	"interaction_frequency",
+	"tempo",
+	"card_relevance",
+	"rules_complexity",
+	"skill_vs_luck",
+	"bluffing_depth",
+	"betting_engagement",
+	"seat_fairness",
+}
+
+// Objectives returns the metrics' value for each of ObjectiveNames, in
+// order.
+func (m *FitnessMetrics) Objectives() []float64 {
+	return []float64{
+		m.DecisionDensity,
+		m.ComebackPotential,
+		m.TensionCurve,
+		m.Swinginess,
+		m.InteractionFrequency,
+		m.Tempo,
+		m.CardRelevance,
+		m.RulesComplexity,
+		m.SkillVsLuck,
+		m.BluffingDepth,
+		m.BettingEngagement,
+		m.SeatFairness,
+	}
+}
+
+// Dominates reports whether objectives a Pareto-dominate b: a is no worse
+// in any objective and better in at least one.
+func Dominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] < b[i] {
+			return false
+		}
+		if a[i] > b[i] {
+			better = true
+		}
+	}
+	return better
+}
@@ -1,6 +1,10 @@
 package fitness
 
-import "github.com/signalnine/darwindeck/gosim/genome"
+import (
+	"sort"
+
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
 
 // StylePresets defines weight configurations for different game styles.
 // IMPORTANT: Rules complexity is heavily weighted because complex games
@@ -9,13 +13,17 @@ var StylePresets = map[string]map[string]float64{
 	"balanced": {
 		// Balanced preset: games need meaningful decisions AND be learnable
 		"decision_density":      0.25, // PRIMARY - no decisions = not a game
-		"skill_vs_luck":         0.20, // Skill should matter
+		"skill_vs_luck":         0.17, // Skill should matter
 		"rules_complexity":      0.18, // Learnable but not dominant
-		"comeback_potential":    0.12, // Games should feel winnable
+		"comeback_potential":    0.10, // Games should feel winnable
 		"interaction_frequency": 0.10, // Social element
 		"tension_curve":         0.08, // Nice to have drama
+		"swinginess":            0.00,
+		"tempo":                 0.00,
+		"card_relevance":        0.00,
 		"bluffing_depth":        0.00,
 		"betting_engagement":    0.07,
+		"seat_fairness":         0.05, // Where you sit shouldn't decide the game
 	},
 	"bluffing": {
 		// Bluffing games can be slightly more complex, but still need to be learnable
@@ -23,32 +31,59 @@ var StylePresets = map[string]map[string]float64{
 		"decision_density":      0.05,
 		"comeback_potential":    0.05,
 		"tension_curve":         0.05,
+		"swinginess":            0.00,
+		"tempo":                 0.00,
+		"card_relevance":        0.00,
 		"interaction_frequency": 0.08,
 		"skill_vs_luck":         0.05,
 		"bluffing_depth":        0.18, // Quality bluffing mechanics
 		"betting_engagement":    0.19, // Betting psychology
+		"seat_fairness":         0.00,
 	},
 	"strategic": {
 		// Strategy gamers tolerate MORE complexity, but it still matters a lot
-		"rules_complexity":      0.30, // Lower than others, but still significant
+		"rules_complexity":      0.25, // Lower than others, but still significant
 		"decision_density":      0.20,
-		"comeback_potential":    0.08,
+		"comeback_potential":    0.06,
 		"tension_curve":         0.05,
-		"interaction_frequency": 0.10,
+		"swinginess":            0.00,
+		"tempo":                 0.00,
+		"card_relevance":        0.05, // Every card should be worth thinking about
+		"interaction_frequency": 0.07,
 		"skill_vs_luck":         0.27, // High skill emphasis
 		"bluffing_depth":        0.00,
 		"betting_engagement":    0.00,
+		"seat_fairness":         0.05, // Moving first shouldn't be a winning strategy
 	},
 	"party": {
 		// Party games MUST be dead simple - complexity is the killer
 		"rules_complexity":      0.50, // Half of fitness! Must explain in 1-2 minutes
 		"decision_density":      0.04,
-		"comeback_potential":    0.12, // Everyone can win
+		"comeback_potential":    0.10, // Everyone can win
 		"tension_curve":         0.06,
-		"interaction_frequency": 0.14, // High interaction
+		"swinginess":            0.00,
+		"tempo":                 0.08, // Snappy turns keep everyone engaged
+		"card_relevance":        0.00,
+		"interaction_frequency": 0.12, // High interaction
 		"skill_vs_luck":         0.04, // Luck-friendly
 		"bluffing_depth":        0.00,
-		"betting_engagement":    0.10,
+		"betting_engagement":    0.06,
+		"seat_fairness":         0.00,
+	},
+	"dramatic": {
+		// Dramatic games live on momentum: big leads that get wiped out
+		"rules_complexity":      0.30,
+		"decision_density":      0.12,
+		"comeback_potential":    0.14,
+		"tension_curve":         0.14,
+		"swinginess":            0.14, // Lead see-saws, not just flips
+		"tempo":                 0.00,
+		"card_relevance":        0.00,
+		"interaction_frequency": 0.10,
+		"skill_vs_luck":         0.06,
+		"bluffing_depth":        0.00,
+		"betting_engagement":    0.00,
+		"seat_fairness":         0.00,
 	},
 	"trick-taking": {
 		// Trick-taking is familiar, so complexity is less of a barrier
@@ -56,13 +91,41 @@ var StylePresets = map[string]map[string]float64{
 		"decision_density":      0.15,
 		"comeback_potential":    0.10,
 		"tension_curve":         0.12,
+		"swinginess":            0.00,
+		"tempo":                 0.00,
+		"card_relevance":        0.00,
 		"interaction_frequency": 0.18,
 		"skill_vs_luck":         0.15,
 		"bluffing_depth":        0.00,
 		"betting_engagement":    0.00,
+		"seat_fairness":         0.00,
 	},
 }
 
+// InteractionGate is a quality gate on how a game's interaction is earned:
+// a game must reach both MinInteraction interaction frequency and
+// MinDecisionDensity decision density, or its fitness is multiplied by
+// Multiplier. Interaction alone can be pure chaos, cards flung at
+// opponents with no choice in the matter.
+type InteractionGate struct {
+	MinInteraction     float64
+	MinDecisionDensity float64
+	Multiplier         float64
+}
+
+// Passes reports whether a game with the given interaction frequency and
+// decision density clears the gate.
+func (g InteractionGate) Passes(interaction, decisionDensity float64) bool {
+	return interaction >= g.MinInteraction && decisionDensity >= g.MinDecisionDensity
+}
+
+// InteractionGates holds the interaction gate for each style that has one.
+var InteractionGates = map[string]InteractionGate{
+	// Party games weight interaction high and decisions low, so without a
+	// floor on both random disruption scores as well as real play
+	"party": {MinInteraction: 0.15, MinDecisionDensity: 0.25, Multiplier: 0.6},
+}
+
 // Evaluator evaluates game fitness using configurable weights.
 type Evaluator struct {
 	weights map[string]float64
@@ -88,10 +151,16 @@ func NewEvaluator(style string, weights map[string]float64) *Evaluator {
 		finalStyle = "balanced"
 	}
 
-	// Normalize weights to sum to 1.0
+	// Normalize weights to sum to 1.0. Summing in key order keeps the total,
+	// and so every fitness score, the same from one evaluator to the next.
+	keys := make([]string, 0, len(finalWeights))
+	for k := range finalWeights {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
 	totalWeight := 0.0
-	for _, w := range finalWeights {
-		totalWeight += w
+	for _, k := range keys {
+		totalWeight += finalWeights[k]
 	}
 	for k := range finalWeights {
 		finalWeights[k] /= totalWeight
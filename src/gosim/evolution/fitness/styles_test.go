@@ -72,8 +72,23 @@ func TestNewEvaluatorInvalidStyle(t *testing.T) {
 	}
 }
 
+func TestNewEvaluatorWeightsAreStable(t *testing.T) {
+	// Normalization must not depend on map iteration order, or the same
+	// genome can score differently in a resumed run
+	for style := range StylePresets {
+		want := NewEvaluator(style, nil).Weights()
+		for i := 0; i < 20; i++ {
+			for k, w := range NewEvaluator(style, nil).Weights() {
+				if w != want[k] {
+					t.Fatalf("%s: weight %s is %v, previously %v", style, k, w, want[k])
+				}
+			}
+		}
+	}
+}
+
 func TestStylePresetsExist(t *testing.T) {
-	expectedStyles := []string{"balanced", "bluffing", "strategic", "party", "trick-taking"}
+	expectedStyles := []string{"balanced", "bluffing", "strategic", "party", "dramatic", "trick-taking"}
 
 	for _, style := range expectedStyles {
 		if _, ok := StylePresets[style]; !ok {
@@ -102,8 +117,12 @@ func TestStylePresetsHaveAllMetrics(t *testing.T) {
 		"comeback_potential",
 		"interaction_frequency",
 		"tension_curve",
+		"swinginess",
+		"tempo",
+		"card_relevance",
 		"bluffing_depth",
 		"betting_engagement",
+		"seat_fairness",
 	}
 
 	for style, weights := range StylePresets {
@@ -0,0 +1,103 @@
+package operators
+
+import (
+	"math/rand"
+
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+// ComplexityBudget caps how large a genome may grow, so evolution can't
+// bloat games with redundant phases and rules. A zero limit is unlimited.
+type ComplexityBudget struct {
+	MaxPhases int  // Most turn phases
+	MaxRules  int  // Most win conditions, special effects and card scoring rules combined
+	RealDeck  bool // Only games playable with one standard deck (see CheckRealDeck)
+}
+
+// Enabled reports whether the budget limits anything.
+func (b ComplexityBudget) Enabled() bool {
+	return b.MaxPhases > 0 || b.MaxRules > 0 || b.RealDeck
+}
+
+// RuleCount returns the number of rules g has towards MaxRules.
+func RuleCount(g *genome.GameGenome) int {
+	return len(g.WinConditions) + len(g.Effects) + len(g.CardScoring)
+}
+
+// Exceeds reports whether g is over the budget.
+func (b ComplexityBudget) Exceeds(g *genome.GameGenome) bool {
+	if b.MaxPhases > 0 && len(g.TurnStructure.Phases) > b.MaxPhases {
+		return true
+	}
+	if b.RealDeck && CheckRealDeck(g) != nil {
+		return true
+	}
+	return b.MaxRules > 0 && RuleCount(g) > b.MaxRules
+}
+
+// Repair trims g in place until it fits the budget. The latest phases go
+// first, then special effects, then card scoring rules, then win
+// conditions; the first win condition is always kept so the game can end.
+// With RealDeck, g is first made playable with a standard deck.
+func (b ComplexityBudget) Repair(g *genome.GameGenome) {
+	if b.RealDeck {
+		RepairRealDeck(g)
+	}
+	if b.MaxPhases > 0 && len(g.TurnStructure.Phases) > b.MaxPhases {
+		g.TurnStructure.Phases = g.TurnStructure.Phases[:b.MaxPhases]
+	}
+	if b.MaxRules <= 0 {
+		return
+	}
+
+	excess := RuleCount(g) - b.MaxRules
+	trim := func(n int) int {
+		if excess <= 0 {
+			return n
+		}
+		drop := excess
+		if drop > n {
+			drop = n
+		}
+		excess -= drop
+		return n - drop
+	}
+	g.Effects = g.Effects[:trim(len(g.Effects))]
+	g.CardScoring = g.CardScoring[:trim(len(g.CardScoring))]
+	if len(g.WinConditions) > 1 {
+		g.WinConditions = g.WinConditions[:1+trim(len(g.WinConditions)-1)]
+	}
+}
+
+// Budgeted wraps op so that a mutation which would push a genome over the
+// budget, or further over it, leaves the genome unchanged instead.
+func Budgeted(op MutationOperator, budget ComplexityBudget) MutationOperator {
+	return budgetedMutation{op, budget}
+}
+
+type budgetedMutation struct {
+	MutationOperator
+	budget ComplexityBudget
+}
+
+// Mutate applies the wrapped mutation, rejecting a result that grows a
+// genome past the budget.
+func (m budgetedMutation) Mutate(g *genome.GameGenome, rng *rand.Rand) *genome.GameGenome {
+	mutated := m.MutationOperator.Mutate(g, rng)
+	if m.budget.Exceeds(mutated) && m.grew(g, mutated) {
+		return CloneGenome(g)
+	}
+	return mutated
+}
+
+// grew reports whether mutated has more phases or rules than g in a
+// dimension the budget limits, or needs components g didn't.
+func (m budgetedMutation) grew(g, mutated *genome.GameGenome) bool {
+	if m.budget.MaxPhases > 0 && len(mutated.TurnStructure.Phases) > len(g.TurnStructure.Phases) {
+		return true
+	}
+	if m.budget.RealDeck && CheckRealDeck(mutated) != nil && CheckRealDeck(g) == nil {
+		return true
+	}
+	return m.budget.MaxRules > 0 && RuleCount(mutated) > RuleCount(g)
+}
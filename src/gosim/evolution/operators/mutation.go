@@ -45,19 +45,30 @@ func (m *BaseMutation) ShouldApply(rng *rand.Rand) bool {
 // This is necessary because Go genomes use slices which share underlying arrays.
 func CloneGenome(g *genome.GameGenome) *genome.GameGenome {
 	clone := &genome.GameGenome{
-		Name:       g.Name,
-		Generation: g.Generation,
-		Setup: genome.SetupRules{
-			CardsPerPlayer: g.Setup.CardsPerPlayer,
-			DealToTableau:  g.Setup.DealToTableau,
-			StartingChips:  g.Setup.StartingChips,
-			TableauSize:    g.Setup.TableauSize,
-		},
+		Name:            g.Name,
+		Generation:      g.Generation,
+		Setup:           g.Setup, // SetupRules is a value type
+		HandPenalty:     g.HandPenalty,
+		RevealScoring:   g.RevealScoring,
+		ScoreCarry:      g.ScoreCarry,
+		ChipBridge:      g.ChipBridge,
+		PlacementPoints: append([]int32(nil), g.PlacementPoints...),
+		RankValues:      append([]int32(nil), g.RankValues...),
+		WildRanks:       append([]int(nil), g.WildRanks...),
+		DiscardPiles:    append([]genome.DiscardPile(nil), g.DiscardPiles...),
 		TurnStructure: genome.TurnStructure{
 			MaxTurns:          g.TurnStructure.MaxTurns,
+			TurnLimit:         g.TurnStructure.TurnLimit,
 			TableauMode:       g.TurnStructure.TableauMode,
+			WarTieRule:        g.TurnStructure.WarTieRule,
 			SequenceDirection: g.TurnStructure.SequenceDirection,
+			AceMode:           g.TurnStructure.AceMode,
 			IsTrickBased:      g.TurnStructure.IsTrickBased,
+			JumpIn:            g.TurnStructure.JumpIn,
+			MaxTurnsRule:      g.TurnStructure.MaxTurnsRule,
+			StuckRule:         g.TurnStructure.StuckRule,
+			MaxEffectsPerTurn: g.TurnStructure.MaxEffectsPerTurn,
+			MaxLegalMoves:     g.TurnStructure.MaxLegalMoves,
 		},
 	}
 
@@ -101,12 +112,36 @@ func CloneGenome(g *genome.GameGenome) *genome.GameGenome {
 		}
 	}
 
+	if g.CatchUp != nil {
+		catchUp := *g.CatchUp
+		clone.CatchUp = &catchUp
+	}
+
+	if g.MoonShot != nil {
+		moonShot := *g.MoonShot
+		clone.MoonShot = &moonShot
+	}
+
+	if g.SetCleanup != nil {
+		cleanup := *g.SetCleanup
+		clone.SetCleanup = &cleanup
+	}
+
+	if g.CaptureScoring != nil {
+		clone.CaptureScoring = make([]genome.CaptureCategory, len(g.CaptureScoring))
+		for i, c := range g.CaptureScoring {
+			clone.CaptureScoring[i] = c
+			clone.CaptureScoring[i].PrimeValues = append([]int32(nil), c.PrimeValues...)
+		}
+	}
+
 	// Clone hand evaluation
 	if g.HandEval != nil {
 		clone.HandEval = &genome.HandEvaluation{
 			Method:        g.HandEval.Method,
 			TargetValue:   g.HandEval.TargetValue,
 			BustThreshold: g.HandEval.BustThreshold,
+			Bust:          g.HandEval.Bust,
 		}
 		if len(g.HandEval.CardValues) > 0 {
 			clone.HandEval.CardValues = make([]genome.CardValue, len(g.HandEval.CardValues))
@@ -130,6 +165,7 @@ func clonePhase(p genome.Phase) genome.Phase {
 			condClone := *phase.Condition
 			clone.Condition = &condClone
 		}
+		clone.Repeat = phase.Repeat.Clone()
 		return &clone
 	case *genome.PlayPhase:
 		clone := *phase
@@ -137,6 +173,7 @@ func clonePhase(p genome.Phase) genome.Phase {
 			condClone := *phase.ValidPlayCondition
 			clone.ValidPlayCondition = &condClone
 		}
+		clone.Repeat = phase.Repeat.Clone()
 		return &clone
 	case *genome.DiscardPhase:
 		clone := *phase
@@ -146,6 +183,7 @@ func clonePhase(p genome.Phase) genome.Phase {
 		return &clone
 	case *genome.BettingPhase:
 		clone := *phase
+		clone.Community = append([]int(nil), phase.Community...)
 		return &clone
 	case *genome.BiddingPhase:
 		clone := *phase
@@ -153,6 +191,21 @@ func clonePhase(p genome.Phase) genome.Phase {
 	case *genome.ClaimPhase:
 		clone := *phase
 		return &clone
+	case *genome.ShowPhase:
+		clone := *phase
+		return &clone
+	case *genome.PeekPhase:
+		clone := *phase
+		return &clone
+	case *genome.DrawDiscardPhase:
+		clone := *phase
+		return &clone
+	case *genome.GivePhase:
+		clone := *phase
+		return &clone
+	case *genome.TradePhase:
+		clone := *phase
+		return &clone
 	default:
 		return p
 	}
@@ -192,6 +245,35 @@ func (r *Registry) ApplyAll(g *genome.GameGenome, rng *rand.Rand) *genome.GameGe
 	return mutated
 }
 
+// AppliedMutation records one mutation a registry applied, with the seed of
+// the random source it ran on, so the same change can be replayed alone.
+type AppliedMutation struct {
+	Operator MutationOperator
+	Seed     int64
+}
+
+// Replay applies the mutation to g with the random choices it made when it
+// was first applied, returning the mutated genome.
+func (m AppliedMutation) Replay(g *genome.GameGenome) *genome.GameGenome {
+	return m.Operator.Mutate(g, rand.New(rand.NewSource(m.Seed)))
+}
+
+// ApplyAllTraced is ApplyAll that also returns the mutations it applied, in
+// order. Each mutation runs on its own random source seeded from rng, so
+// tracing draws different numbers from rng than ApplyAll does.
+func (r *Registry) ApplyAllTraced(g *genome.GameGenome, rng *rand.Rand) (*genome.GameGenome, []AppliedMutation) {
+	mutated := g
+	var applied []AppliedMutation
+	for _, op := range r.operators {
+		if rng.Float64() < op.Probability() {
+			m := AppliedMutation{Operator: op, Seed: rng.Int63()}
+			mutated = m.Replay(mutated)
+			applied = append(applied, m)
+		}
+	}
+	return mutated, applied
+}
+
 // MutationPipeline wraps a Registry and provides a convenient Apply interface.
 type MutationPipeline struct {
 	registry *Registry
@@ -209,18 +291,47 @@ func (p *MutationPipeline) Apply(g *genome.GameGenome, rng *rand.Rand) {
 	*g = *mutated
 }
 
-// NewDefaultPipeline creates a mutation pipeline with default probabilities.
-func NewDefaultPipeline(rng *rand.Rand) *MutationPipeline {
+// WithBudget returns a pipeline applying the same mutations, each one
+// rejected if it would grow a genome past budget. An unlimited budget
+// returns p unchanged.
+func (p *MutationPipeline) WithBudget(budget ComplexityBudget) *MutationPipeline {
+	if !budget.Enabled() {
+		return p
+	}
 	registry := NewRegistry()
+	for _, op := range p.registry.Operators() {
+		registry.Register(Budgeted(op, budget))
+	}
+	return NewMutationPipeline(registry)
+}
 
-	// Setup mutations
-	RegisterSetupMutations(registry)
+// ApplyTraced is Apply that also returns the mutations it applied.
+func (p *MutationPipeline) ApplyTraced(g *genome.GameGenome, rng *rand.Rand) []AppliedMutation {
+	mutated, applied := p.registry.ApplyAllTraced(g, rng)
+	*g = *mutated
+	return applied
+}
 
-	// Phase mutations
-	RegisterPhaseMutations(registry)
+// NewDefaultPipeline creates a mutation pipeline with default probabilities.
+// Semantic mutations stand in for the generic ones that tend to break
+// genomes, and the remaining generic mutations are guarded so that
+// offspring stay valid.
+func NewDefaultPipeline(rng *rand.Rand) *MutationPipeline {
+	registry := NewRegistry()
 
-	// Condition mutations
-	RegisterConditionMutations(registry)
+	// Semantic mutations
+	RegisterSemanticMutations(registry)
+
+	// Setup, phase and condition mutations
+	generic := NewRegistry()
+	RegisterSetupMutations(generic)
+	RegisterPhaseMutations(generic)
+	RegisterConditionMutations(generic)
+	for _, op := range generic.Operators() {
+		if !supersededMutations[op.Name()] {
+			registry.Register(Guarded(op))
+		}
+	}
 
 	return NewMutationPipeline(registry)
 }
@@ -71,6 +71,29 @@ func TestRegistryApplyAll(t *testing.T) {
 	}
 }
 
+func TestApplyAllTracedReplays(t *testing.T) {
+	registry := NewRegistry()
+	RegisterSetupMutations(registry)
+	RegisterPhaseMutations(registry)
+	original := genome.CreateCrazyEightsGenome()
+	rng := rand.New(rand.NewSource(12345))
+
+	for i := 0; i < 20; i++ {
+		mutated, applied := registry.ApplyAllTraced(original, rng)
+
+		// Replaying the same mutations in order rebuilds the offspring
+		replayed := original
+		for _, m := range applied {
+			replayed = m.Replay(replayed)
+		}
+		want, _ := genome.SaveGenomeToJSON(mutated)
+		got, _ := genome.SaveGenomeToJSON(replayed)
+		if string(got) != string(want) {
+			t.Fatalf("Replaying %d mutations gave a different genome", len(applied))
+		}
+	}
+}
+
 func TestCardsPerPlayerMutation(t *testing.T) {
 	mutation := NewCardsPerPlayerMutation(1.0) // 100% probability
 
@@ -134,6 +157,22 @@ func TestDealToTableauMutation(t *testing.T) {
 	}
 }
 
+func TestJumpInMutation(t *testing.T) {
+	mutation := NewJumpInMutation(1.0)
+
+	original := genome.CreateWarGenome()
+	rng := rand.New(rand.NewSource(12345))
+
+	mutated := mutation.Mutate(original, rng)
+
+	if mutated.TurnStructure.JumpIn == original.TurnStructure.JumpIn {
+		t.Error("Expected jump-in rule to change")
+	}
+	if clone := CloneGenome(mutated); clone.TurnStructure.JumpIn != mutated.TurnStructure.JumpIn {
+		t.Errorf("CloneGenome lost JumpIn: got %d", clone.TurnStructure.JumpIn)
+	}
+}
+
 func TestAddDrawPhaseMutation(t *testing.T) {
 	mutation := NewAddDrawPhaseMutation(1.0)
 
@@ -149,6 +188,104 @@ func TestAddDrawPhaseMutation(t *testing.T) {
 	}
 }
 
+func TestBudgetedMutationRejectsGrowth(t *testing.T) {
+	original := genome.CreateWarGenome()
+	phases := len(original.TurnStructure.Phases)
+	rng := rand.New(rand.NewSource(12345))
+
+	capped := Budgeted(NewAddDrawPhaseMutation(1.0), ComplexityBudget{MaxPhases: phases})
+	if mutated := capped.Mutate(original, rng); len(mutated.TurnStructure.Phases) != phases {
+		t.Errorf("Expected the phase over budget to be rejected, got %d phases", len(mutated.TurnStructure.Phases))
+	}
+
+	roomy := Budgeted(NewAddDrawPhaseMutation(1.0), ComplexityBudget{MaxPhases: phases + 1})
+	if mutated := roomy.Mutate(original, rng); len(mutated.TurnStructure.Phases) != phases+1 {
+		t.Errorf("Expected a phase within budget to be added, got %d phases", len(mutated.TurnStructure.Phases))
+	}
+}
+
+func TestComplexityBudgetRepair(t *testing.T) {
+	g := genome.CreateHeartsGenome()
+	g.TurnStructure.Phases = append(g.TurnStructure.Phases, &genome.DrawPhase{Count: 1}, &genome.DrawPhase{Count: 1})
+	g.WinConditions = append(g.WinConditions, genome.WinCondition{Type: genome.WinTypeEmptyHand})
+	g.Effects = []genome.SpecialEffect{{TriggerRank: 0}, {TriggerRank: 1}}
+	budget := ComplexityBudget{MaxPhases: 1, MaxRules: 2}
+	if !budget.Exceeds(g) {
+		t.Fatal("Expected the bloated genome to exceed the budget")
+	}
+	firstPhase := g.TurnStructure.Phases[0]
+	firstWin := g.WinConditions[0]
+
+	budget.Repair(g)
+	if budget.Exceeds(g) {
+		t.Fatalf("Expected the repaired genome to fit, got %d phases and %d rules", len(g.TurnStructure.Phases), RuleCount(g))
+	}
+	if g.TurnStructure.Phases[0] != firstPhase {
+		t.Error("Expected the earliest phase to be kept")
+	}
+	if len(g.Effects) != 0 || len(g.WinConditions) == 0 || g.WinConditions[0] != firstWin {
+		t.Errorf("Expected effects to go before win conditions, got %d effects and %v", len(g.Effects), g.WinConditions)
+	}
+
+	// A budget of one rule still keeps the game winnable
+	ComplexityBudget{MaxRules: 1}.Repair(g)
+	if len(g.WinConditions) != 1 || RuleCount(g) != 1 {
+		t.Errorf("Expected only the first win condition to remain, got %d rules", RuleCount(g))
+	}
+}
+
+func TestRealDeckRepair(t *testing.T) {
+	if err := CheckRealDeck(genome.CreateCrazyEightsGenome()); err != nil {
+		t.Errorf("Expected Crazy Eights to need only a standard deck, got %v", err)
+	}
+
+	poker := genome.CreateSimplePokerGenome()
+	if err := CheckRealDeck(poker); err != ErrNeedsChips {
+		t.Fatalf("Expected poker to need chips, got %v", err)
+	}
+	RepairRealDeck(poker)
+	if err := CheckRealDeck(poker); err != nil {
+		t.Errorf("Expected repaired poker to need only a standard deck, got %v", err)
+	}
+	for _, phase := range poker.TurnStructure.Phases {
+		if _, ok := phase.(*genome.BettingPhase); ok {
+			t.Error("Expected the betting rounds to be dropped")
+		}
+	}
+
+	g := genome.CreateCrazyEightsGenome()
+	g.TurnStructure.JumpIn = genome.JumpInIdentical
+	g.Setup.CardsPerPlayer = 26
+	g.Setup.DealToTableau = 4
+	if err := CheckRealDeck(g); err != ErrNeedsDuplicateCards {
+		t.Errorf("Expected identical jump-ins to need duplicate cards, got %v", err)
+	}
+	ComplexityBudget{RealDeck: true}.Repair(g)
+	if err := CheckRealDeck(g); err != nil {
+		t.Fatalf("Expected the repaired genome to need only a standard deck, got %v", err)
+	}
+	if g.TurnStructure.JumpIn != genome.JumpInRank || g.Setup.CardsPerPlayer != 24 {
+		t.Errorf("Expected rank jump-ins and 24-card hands, got %v and %d", g.TurnStructure.JumpIn, g.Setup.CardsPerPlayer)
+	}
+
+	// A mutation that would need chips is rejected
+	budgeted := Budgeted(chipMutation{}, ComplexityBudget{RealDeck: true})
+	if mutated := budgeted.Mutate(g, rand.New(rand.NewSource(1))); mutated.Setup.StartingChips != 0 {
+		t.Errorf("Expected a mutation adding chips to be rejected, got %d chips", mutated.Setup.StartingChips)
+	}
+}
+
+// chipMutation gives every player chips.
+type chipMutation struct{}
+
+func (chipMutation) Name() string         { return "AddChips" }
+func (chipMutation) Probability() float64 { return 1.0 }
+func (chipMutation) Mutate(g *genome.GameGenome, rng *rand.Rand) *genome.GameGenome {
+	mutated := CloneGenome(g)
+	mutated.Setup.StartingChips = 100
+	return mutated
+}
+
 func TestAddPlayPhaseMutation(t *testing.T) {
 	mutation := NewAddPlayPhaseMutation(1.0)
 
@@ -363,10 +500,10 @@ func TestMutationPreservesStructure(t *testing.T) {
 	genomes := genome.GetSeedGenomes()
 	rng := rand.New(rand.NewSource(12345))
 
-	// Note: Mutations can produce semantically incoherent genomes (e.g.,
-	// mutating tableau_mode on a capture-based game). That's expected -
-	// evolution relies on fitness evaluation to penalize invalid offspring.
-	// Here we just check that mutations don't corrupt basic structure.
+	// Note: Unguarded generic mutations can produce semantically incoherent
+	// genomes (e.g., mutating tableau_mode on a capture-based game); the
+	// default pipeline keeps offspring valid. Here we just check that
+	// mutations don't corrupt basic structure.
 	for _, g := range genomes {
 		t.Run(g.Name, func(t *testing.T) {
 			// Apply mutations multiple times
@@ -420,3 +557,138 @@ func TestAllMutationsRegistered(t *testing.T) {
 		t.Logf("  - %s (p=%.2f)", op.Name(), op.Probability())
 	}
 }
+
+func TestDefaultPipelineKeepsGenomesValid(t *testing.T) {
+	pipeline := NewDefaultPipeline(nil)
+	rng := rand.New(rand.NewSource(12345))
+
+	for _, seed := range genome.GetSeedGenomes() {
+		if !genome.IsValid(seed) {
+			continue
+		}
+		t.Run(seed.Name, func(t *testing.T) {
+			g := seed.Clone()
+			for i := 0; i < 50; i++ {
+				pipeline.Apply(g, rng)
+				if errs := genome.ValidateGenome(g); len(errs) > 0 {
+					t.Fatalf("Generation %d: mutation produced an invalid genome: %v", i, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestAddCompatiblePhaseMutation(t *testing.T) {
+	mutation := NewAddCompatiblePhaseMutation(1.0)
+	rng := rand.New(rand.NewSource(12345))
+
+	// Crazy Eights has no tricks and no chips
+	original := genome.CreateCrazyEightsGenome()
+	for i := 0; i < 100; i++ {
+		mutated := mutation.Mutate(original, rng)
+		if len(mutated.TurnStructure.Phases) != len(original.TurnStructure.Phases)+1 {
+			t.Fatalf("Expected one phase to be added, got %d", len(mutated.TurnStructure.Phases))
+		}
+		for _, phase := range mutated.TurnStructure.Phases {
+			switch phase.(type) {
+			case *genome.TrickPhase:
+				t.Fatal("Added a trick phase to a game that isn't trick-based")
+			case *genome.BettingPhase:
+				t.Fatal("Added a betting phase to a game without chips")
+			}
+		}
+	}
+}
+
+func TestRemoveCompatiblePhaseMutation(t *testing.T) {
+	mutation := NewRemoveCompatiblePhaseMutation(1.0)
+	rng := rand.New(rand.NewSource(12345))
+
+	// The play phase is the only card play, so only betting can go
+	g := genome.CreateWarGenome()
+	g.Setup.StartingChips = 500
+	g.TurnStructure.Phases = append([]genome.Phase{&genome.BettingPhase{MinBet: 10, MaxRaises: 2}},
+		g.TurnStructure.Phases...)
+	for i := 0; i < 20; i++ {
+		mutated := mutation.Mutate(g, rng)
+		if len(mutated.TurnStructure.Phases) != 1 {
+			t.Fatalf("Expected one phase left, got %d", len(mutated.TurnStructure.Phases))
+		}
+		if _, ok := mutated.TurnStructure.Phases[0].(*genome.PlayPhase); !ok {
+			t.Fatal("Removed the game's only card play phase")
+		}
+	}
+
+	// Removing the last trick phase makes the game no longer trick-based
+	g = genome.CreateHeartsGenome()
+	g.TurnStructure.Phases = append(g.TurnStructure.Phases, &genome.PlayPhase{Target: genome.LocationDiscard, MinCards: 1, MaxCards: 1})
+	for i := 0; i < 20; i++ {
+		mutated := mutation.Mutate(g, rng)
+		if !hasTrickPhase(mutated) && mutated.TurnStructure.IsTrickBased {
+			t.Fatal("Expected a game with no trick phases to stop being trick-based")
+		}
+	}
+}
+
+func TestTweakParameterMutationStaysInRange(t *testing.T) {
+	mutation := NewTweakParameterMutation(1.0)
+	rng := rand.New(rand.NewSource(12345))
+
+	g := genome.CreateBettingWarGenome()
+	for i := 0; i < 500; i++ {
+		g = mutation.Mutate(g, rng)
+		if errs := genome.ValidateGenome(g); len(errs) > 0 {
+			t.Fatalf("Tweak %d left the genome invalid: %v", i, errs)
+		}
+		if g.Setup.CardsPerPlayer < 1 || g.TurnStructure.MaxTurns < 10 {
+			t.Fatalf("Tweak %d went out of range: %d cards, %d turns", i, g.Setup.CardsPerPlayer, g.TurnStructure.MaxTurns)
+		}
+	}
+}
+
+func TestSwapWinConditionMutation(t *testing.T) {
+	mutation := NewSwapWinConditionMutation(1.0)
+	rng := rand.New(rand.NewSource(12345))
+
+	// War has no scoring, so score-based wins are never swapped in
+	original := genome.CreateWarGenome()
+	seen := make(map[genome.WinConditionType]bool)
+	for i := 0; i < 100; i++ {
+		mutated := mutation.Mutate(original, rng)
+		wt := mutated.WinConditions[0].Type
+		if wt == original.WinConditions[0].Type {
+			t.Fatal("Expected the win condition to change")
+		}
+		switch wt {
+		case genome.WinTypeHighScore, genome.WinTypeLowScore, genome.WinTypeFirstToScore, genome.WinTypeBestHand:
+			t.Fatalf("Swapped in incompatible win type %d", wt)
+		}
+		seen[wt] = true
+	}
+	if !seen[genome.WinTypeEmptyHand] {
+		t.Error("Expected empty-hand, which War supports, to be swapped in")
+	}
+	// War's captures go back into the hand, never to a captured pile
+	if seen[genome.WinTypeMostCaptured] {
+		t.Error("Swapped in most-captured, which War can never satisfy")
+	}
+}
+
+func TestAdjustScoringRuleMutation(t *testing.T) {
+	mutation := NewAdjustScoringRuleMutation(1.0)
+	rng := rand.New(rand.NewSource(12345))
+
+	// Crazy Eights has no tricks or captures, and its card values are penalties
+	g := genome.CreateCrazyEightsGenome()
+	g.CardScoring = []genome.CardScoringRule{{Suit: genome.SuitAny, Rank: genome.RankEight, Points: -50, Trigger: genome.TriggerHandEnd}}
+	for i := 0; i < 100; i++ {
+		g = mutation.Mutate(g, rng)
+		rule := g.CardScoring[0]
+		if rule.Points >= 0 {
+			t.Fatalf("Expected a penalty to stay negative, got %d", rule.Points)
+		}
+		if rule.Trigger == genome.TriggerTrickWin || rule.Trigger == genome.TriggerCapture {
+			t.Fatalf("Moved the rule to trigger %d, which can't fire in this game", rule.Trigger)
+		}
+	}
+}
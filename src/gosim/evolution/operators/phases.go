@@ -481,7 +481,7 @@ func (m *ModifyTrickPhaseMutation) Mutate(g *genome.GameGenome, rng *rand.Rand)
 		genome.SuitSpades,
 	}
 
-	switch rng.Intn(4) {
+	switch rng.Intn(8) {
 	case 0: // Toggle lead suit required
 		newPhase.LeadSuitRequired = !newPhase.LeadSuitRequired
 	case 1: // Change trump suit
@@ -498,6 +498,14 @@ func (m *ModifyTrickPhaseMutation) Mutate(g *genome.GameGenome, rng *rand.Rand)
 		} else {
 			newPhase.BreakingSuit = suits[rng.Intn(len(suits))]
 		}
+	case 4: // Toggle must beat when able
+		newPhase.MustBeat = !newPhase.MustBeat
+	case 5: // Toggle must overtrump when void
+		newPhase.MustOvertrump = !newPhase.MustOvertrump
+	case 6: // Change trump discipline
+		newPhase.TrumpDiscipline = genome.TrumpDiscipline(rng.Intn(3))
+	case 7: // Change who leads after each trick
+		newPhase.NextLeader = genome.TrickLead(rng.Intn(3))
 	}
 
 	clone.TurnStructure.Phases[idx] = &newPhase
@@ -540,7 +548,7 @@ func (m *ModifyBettingPhaseMutation) Mutate(g *genome.GameGenome, rng *rand.Rand
 
 	newPhase := *bettingPhase
 
-	switch rng.Intn(2) {
+	switch rng.Intn(4) {
 	case 0: // Modify min bet
 		minBets := []int{5, 10, 20, 25, 50, 100}
 		newPhase.MinBet = minBets[rng.Intn(len(minBets))]
@@ -552,6 +560,14 @@ func (m *ModifyBettingPhaseMutation) Mutate(g *genome.GameGenome, rng *rand.Rand
 		if newPhase.MaxRaises > 5 {
 			newPhase.MaxRaises = 5
 		}
+	case 2: // Toggle Hold'em-style community cards
+		if newPhase.Community == nil {
+			newPhase.Community = []int{3, 1, 1}
+		} else {
+			newPhase.Community = nil
+		}
+	case 3: // Toggle whether losers muck at the showdown
+		newPhase.MuckLosers = !newPhase.MuckLosers
 	}
 
 	clone.TurnStructure.Phases[idx] = &newPhase
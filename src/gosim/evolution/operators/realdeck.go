@@ -0,0 +1,81 @@
+package operators
+
+import (
+	"errors"
+
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+// Reasons a genome can't be played with one standard deck.
+var (
+	ErrNeedsChips          = errors.New("needs chips for betting")
+	ErrNeedsDuplicateCards = errors.New("needs duplicate cards for identical jump-ins")
+	ErrNeedsMoreCards      = errors.New("deals more cards than a standard deck holds")
+)
+
+// CheckRealDeck returns nil if g can be played at a table tonight with one
+// standard 52-card deck and pen and paper for the score, or the reason it
+// can't. Chips, duplicate cards and deals larger than the deck all rule a
+// game out.
+func CheckRealDeck(g *genome.GameGenome) error {
+	if g.Setup.StartingChips > 0 {
+		return ErrNeedsChips
+	}
+	for _, phase := range g.TurnStructure.Phases {
+		switch p := phase.(type) {
+		case *genome.BettingPhase:
+			return ErrNeedsChips
+		case *genome.ShowPhase:
+			if p.Award != genome.ShowAwardPoints {
+				return ErrNeedsChips
+			}
+		}
+	}
+	if g.TurnStructure.JumpIn == genome.JumpInIdentical {
+		return ErrNeedsDuplicateCards
+	}
+	if realDeckCardsNeeded(g) > genome.StandardDeckSize {
+		return ErrNeedsMoreCards
+	}
+	return nil
+}
+
+// RepairRealDeck changes g in place so that CheckRealDeck passes: chips
+// and betting rounds are dropped, shows award points instead of the pot,
+// identical jump-ins become same-rank jump-ins, and hands shrink until the
+// deal fits the deck. A repaired genome may no longer be valid, such as a
+// poker game whose only win was the pot.
+func RepairRealDeck(g *genome.GameGenome) {
+	g.Setup.StartingChips = 0
+
+	phases := g.TurnStructure.Phases[:0]
+	for _, phase := range g.TurnStructure.Phases {
+		switch p := phase.(type) {
+		case *genome.BettingPhase:
+			continue
+		case *genome.ShowPhase:
+			if p.Award != genome.ShowAwardPoints {
+				p.Award = genome.ShowAwardPoints
+				p.Points = max(p.Points, 1)
+			}
+		}
+		phases = append(phases, phase)
+	}
+	g.TurnStructure.Phases = phases
+
+	if g.TurnStructure.JumpIn == genome.JumpInIdentical {
+		g.TurnStructure.JumpIn = genome.JumpInRank
+	}
+
+	if g.Setup.DealToTableau > genome.StandardDeckSize {
+		g.Setup.DealToTableau = genome.StandardDeckSize
+	}
+	if realDeckCardsNeeded(g) > genome.StandardDeckSize {
+		g.Setup.CardsPerPlayer = (genome.StandardDeckSize - g.Setup.DealToTableau) / genome.DefaultPlayerCount
+	}
+}
+
+// realDeckCardsNeeded returns how many cards g deals at the start.
+func realDeckCardsNeeded(g *genome.GameGenome) int {
+	return g.Setup.CardsPerPlayer*genome.DefaultPlayerCount + g.Setup.DealToTableau
+}
@@ -0,0 +1,389 @@
+// Package operators provides genetic mutation operators for evolving card game genomes.
+package operators
+
+import (
+	"math/rand"
+
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+// The mutations in this file understand what the parts of a genome mean:
+// they only add phases the game can run, only remove phases it can do
+// without, keep numbers in their valid ranges, and only swap in win
+// conditions and scoring triggers the game can satisfy. The default
+// pipeline uses them so offspring stay valid and evaluations are not wasted
+// on dead genomes.
+
+// AddCompatiblePhaseMutation adds a phase that fits the game: trick phases
+// only in trick-based games and betting phases only when players have chips.
+type AddCompatiblePhaseMutation struct {
+	BaseMutation
+	adders []MutationOperator
+}
+
+// NewAddCompatiblePhaseMutation creates a new add compatible phase mutation.
+func NewAddCompatiblePhaseMutation(probability float64) *AddCompatiblePhaseMutation {
+	return &AddCompatiblePhaseMutation{
+		BaseMutation: BaseMutation{
+			probability: probability,
+			name:        "AddCompatiblePhase",
+		},
+		adders: []MutationOperator{
+			NewAddDrawPhaseMutation(1),
+			NewAddPlayPhaseMutation(1),
+			NewAddDiscardPhaseMutation(1),
+			NewAddTrickPhaseMutation(1),
+			NewAddBettingPhaseMutation(1),
+		},
+	}
+}
+
+// Mutate adds a random phase kind the game supports.
+func (m *AddCompatiblePhaseMutation) Mutate(g *genome.GameGenome, rng *rand.Rand) *genome.GameGenome {
+	// Draw, play and discard phases fit any game
+	candidates := append([]MutationOperator(nil), m.adders[:3]...)
+	if g.TurnStructure.IsTrickBased {
+		candidates = append(candidates, m.adders[3])
+	}
+	if g.Setup.StartingChips > 0 {
+		candidates = append(candidates, m.adders[4])
+	}
+
+	clone := candidates[rng.Intn(len(candidates))].Mutate(g, rng)
+	fitBetsToChips(clone)
+	return clone
+}
+
+// RemoveCompatiblePhaseMutation removes a phase the game can do without,
+// never its last card play phase or a phase another one depends on.
+type RemoveCompatiblePhaseMutation struct {
+	BaseMutation
+}
+
+// NewRemoveCompatiblePhaseMutation creates a new remove compatible phase mutation.
+func NewRemoveCompatiblePhaseMutation(probability float64) *RemoveCompatiblePhaseMutation {
+	return &RemoveCompatiblePhaseMutation{
+		BaseMutation: BaseMutation{
+			probability: probability,
+			name:        "RemoveCompatiblePhase",
+		},
+	}
+}
+
+// Mutate removes a random phase whose removal keeps the genome valid.
+func (m *RemoveCompatiblePhaseMutation) Mutate(g *genome.GameGenome, rng *rand.Rand) *genome.GameGenome {
+	clone := CloneGenome(g)
+
+	if len(clone.TurnStructure.Phases) <= 1 {
+		return clone
+	}
+
+	// Find phases that can go without breaking the game
+	baseline := len(genome.ValidateGenome(clone))
+	var removable []int
+	for i := range clone.TurnStructure.Phases {
+		trial := *clone
+		trial.TurnStructure.Phases = removePhase(clone.TurnStructure.Phases, i)
+		if len(genome.ValidateGenome(&trial)) <= baseline {
+			removable = append(removable, i)
+		}
+	}
+	if len(removable) == 0 {
+		return clone
+	}
+
+	pos := removable[rng.Intn(len(removable))]
+	clone.TurnStructure.Phases = removePhase(clone.TurnStructure.Phases, pos)
+
+	// A game with no tricks left is no longer trick-based
+	if !hasTrickPhase(clone) {
+		clone.TurnStructure.IsTrickBased = false
+	}
+
+	return clone
+}
+
+// TweakParameterMutation nudges one numeric parameter within its valid range.
+type TweakParameterMutation struct {
+	BaseMutation
+}
+
+// NewTweakParameterMutation creates a new tweak parameter mutation.
+func NewTweakParameterMutation(probability float64) *TweakParameterMutation {
+	return &TweakParameterMutation{
+		BaseMutation: BaseMutation{
+			probability: probability,
+			name:        "TweakParameter",
+		},
+	}
+}
+
+// Mutate picks one of the genome's numeric parameters and nudges it.
+func (m *TweakParameterMutation) Mutate(g *genome.GameGenome, rng *rand.Rand) *genome.GameGenome {
+	clone := CloneGenome(g)
+
+	maxCards := genome.StandardDeckSize / genome.DefaultPlayerCount
+	tweaks := []func(){
+		func() {
+			clone.Setup.CardsPerPlayer = nudge(clone.Setup.CardsPerPlayer, 2, 1, maxCards, rng)
+		},
+		func() {
+			clone.TurnStructure.MaxTurns = scale(clone.TurnStructure.MaxTurns, 0.2, 10, 2000, rng)
+		},
+	}
+	if clone.Setup.StartingChips > 0 {
+		tweaks = append(tweaks, func() {
+			clone.Setup.StartingChips = scale(clone.Setup.StartingChips, 0.3, 100, 5000, rng)
+		})
+	}
+	for _, phase := range clone.TurnStructure.Phases {
+		switch p := phase.(type) {
+		case *genome.DrawPhase:
+			tweaks = append(tweaks, func() { p.Count = nudge(p.Count, 1, 1, 5, rng) })
+		case *genome.PlayPhase:
+			tweaks = append(tweaks,
+				func() { p.MinCards = nudge(p.MinCards, 1, 0, p.MaxCards, rng) },
+				func() { p.MaxCards = nudge(p.MaxCards, 1, max(p.MinCards, 1), 5, rng) },
+			)
+		case *genome.DiscardPhase:
+			tweaks = append(tweaks, func() { p.Count = nudge(p.Count, 1, 1, 5, rng) })
+		case *genome.BettingPhase:
+			tweaks = append(tweaks,
+				func() { p.MinBet = scale(p.MinBet, 0.5, 1, max(clone.Setup.StartingChips/2, 1), rng) },
+				func() { p.MaxRaises = nudge(p.MaxRaises, 1, 1, 5, rng) },
+			)
+		}
+	}
+	for i := range clone.WinConditions {
+		if wc := &clone.WinConditions[i]; wc.Type == genome.WinTypeFirstToScore {
+			tweaks = append(tweaks, func() {
+				wc.Threshold = int32(nudge(int(wc.Threshold), 10, 10, 500, rng))
+			})
+		}
+	}
+
+	tweaks[rng.Intn(len(tweaks))]()
+	fitBetsToChips(clone)
+	return clone
+}
+
+// SwapWinConditionMutation replaces a win condition with a different type
+// the game can satisfy, such as a score-based win only when cards score.
+type SwapWinConditionMutation struct {
+	BaseMutation
+}
+
+// NewSwapWinConditionMutation creates a new swap win condition mutation.
+func NewSwapWinConditionMutation(probability float64) *SwapWinConditionMutation {
+	return &SwapWinConditionMutation{
+		BaseMutation: BaseMutation{
+			probability: probability,
+			name:        "SwapWinCondition",
+		},
+	}
+}
+
+// Mutate swaps a random win condition for a compatible one.
+func (m *SwapWinConditionMutation) Mutate(g *genome.GameGenome, rng *rand.Rand) *genome.GameGenome {
+	clone := CloneGenome(g)
+
+	idx := len(clone.WinConditions)
+	if idx == 0 {
+		clone.WinConditions = []genome.WinCondition{{}}
+	} else {
+		idx = rng.Intn(idx)
+	}
+	current := clone.WinConditions[idx]
+
+	winTypes := []genome.WinConditionType{
+		genome.WinTypeEmptyHand,
+		genome.WinTypeHighScore,
+		genome.WinTypeFirstToScore,
+		genome.WinTypeCaptureAll,
+		genome.WinTypeLowScore,
+		genome.WinTypeAllHandsEmpty,
+		genome.WinTypeBestHand,
+		genome.WinTypeMostCaptured,
+	}
+
+	// Keep the types that don't make the genome any less valid
+	baseline := len(genome.ValidateGenome(g))
+	var compatible []genome.WinCondition
+	for _, wt := range winTypes {
+		if len(g.WinConditions) > 0 && wt == current.Type {
+			continue
+		}
+		wc := genome.WinCondition{Type: wt, Threshold: current.Threshold}
+		if wt == genome.WinTypeFirstToScore && wc.Threshold <= 0 {
+			wc.Threshold = int32((rng.Intn(10) + 1) * 10) // 10-100
+		}
+		clone.WinConditions[idx] = wc
+		if len(genome.ValidateGenome(clone)) <= baseline {
+			compatible = append(compatible, wc)
+		}
+	}
+
+	if len(compatible) == 0 {
+		return CloneGenome(g)
+	}
+	clone.WinConditions[idx] = compatible[rng.Intn(len(compatible))]
+	return clone
+}
+
+// AdjustScoringRuleMutation adjusts a card scoring rule's points or moves
+// it to a trigger that can fire in this game.
+type AdjustScoringRuleMutation struct {
+	BaseMutation
+}
+
+// NewAdjustScoringRuleMutation creates a new adjust scoring rule mutation.
+func NewAdjustScoringRuleMutation(probability float64) *AdjustScoringRuleMutation {
+	return &AdjustScoringRuleMutation{
+		BaseMutation: BaseMutation{
+			probability: probability,
+			name:        "AdjustScoringRule",
+		},
+	}
+}
+
+// Mutate adjusts a random scoring rule. Points keep their sign, so a
+// penalty card stays a penalty.
+func (m *AdjustScoringRuleMutation) Mutate(g *genome.GameGenome, rng *rand.Rand) *genome.GameGenome {
+	clone := CloneGenome(g)
+
+	if len(clone.CardScoring) == 0 {
+		return clone
+	}
+
+	idx := rng.Intn(len(clone.CardScoring))
+	rule := &clone.CardScoring[idx]
+
+	if rng.Intn(2) == 0 {
+		sign := 1
+		if rule.Points < 0 {
+			sign = -1
+		}
+		magnitude := nudge(int(rule.Points)*sign, 3, 1, 50, rng)
+		rule.Points = int16(magnitude * sign)
+	} else {
+		triggers := firingTriggers(clone)
+		rule.Trigger = triggers[rng.Intn(len(triggers))]
+	}
+
+	return clone
+}
+
+// Helper functions
+
+// firingTriggers returns the scoring triggers that can fire in g.
+func firingTriggers(g *genome.GameGenome) []genome.ScoringTrigger {
+	triggers := []genome.ScoringTrigger{genome.TriggerPlay, genome.TriggerHandEnd}
+	if g.TurnStructure.IsTrickBased || hasTrickPhase(g) {
+		triggers = append(triggers, genome.TriggerTrickWin)
+	}
+	if g.TurnStructure.TableauMode == genome.TableauModeWar || g.TurnStructure.TableauMode == genome.TableauModeMatchRank {
+		triggers = append(triggers, genome.TriggerCapture)
+	}
+	if g.TurnStructure.TableauMode == genome.TableauModeMatchRank {
+		triggers = append(triggers, genome.TriggerSweep)
+	}
+	if playsToExposed(g) {
+		triggers = append(triggers, genome.TriggerExposed)
+	}
+	return triggers
+}
+
+// fitBetsToChips lowers minimum bets that would leave players only one bet.
+func fitBetsToChips(g *genome.GameGenome) {
+	limit := max(g.Setup.StartingChips/2, 1)
+	for _, phase := range g.TurnStructure.Phases {
+		if bp, ok := phase.(*genome.BettingPhase); ok && bp.MinBet > limit {
+			bp.MinBet = limit
+		}
+	}
+}
+
+// hasTrickPhase reports whether g has a trick phase.
+func hasTrickPhase(g *genome.GameGenome) bool {
+	for _, phase := range g.TurnStructure.Phases {
+		if _, ok := phase.(*genome.TrickPhase); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// playsToExposed reports whether any play phase lays cards face up in
+// front of the player.
+func playsToExposed(g *genome.GameGenome) bool {
+	for _, phase := range g.TurnStructure.Phases {
+		if p, ok := phase.(*genome.PlayPhase); ok && p.Target == genome.LocationExposed {
+			return true
+		}
+	}
+	return false
+}
+
+// nudge moves v by up to step in either direction, clamped to [lo, hi].
+func nudge(v, step, lo, hi int, rng *rand.Rand) int {
+	delta := rng.Intn(step) + 1
+	if rng.Intn(2) == 0 {
+		delta = -delta
+	}
+	return min(max(v+delta, lo), hi)
+}
+
+// scale multiplies v by a random factor within frac of 1, clamped to [lo, hi].
+func scale(v int, frac float64, lo, hi int, rng *rand.Rand) int {
+	factor := 1 - frac + rng.Float64()*2*frac
+	return min(max(int(float64(v)*factor), lo), hi)
+}
+
+// Guarded wraps op so that a mutation which would make a genome less valid
+// leaves it unchanged instead.
+func Guarded(op MutationOperator) MutationOperator {
+	return guardedMutation{op}
+}
+
+type guardedMutation struct {
+	MutationOperator
+}
+
+// Mutate applies the wrapped mutation, keeping the result only if it adds
+// no validation errors.
+func (m guardedMutation) Mutate(g *genome.GameGenome, rng *rand.Rand) *genome.GameGenome {
+	mutated := m.MutationOperator.Mutate(g, rng)
+	if len(genome.ValidateGenome(mutated)) > len(genome.ValidateGenome(g)) {
+		return CloneGenome(g)
+	}
+	return mutated
+}
+
+// supersededMutations names the generic mutations the semantic ones replace
+// in the default pipeline.
+var supersededMutations = map[string]bool{
+	"CardsPerPlayer":     true,
+	"MaxTurns":           true,
+	"StartingChips":      true,
+	"TrickBased":         true, // Left trick phases in non-trick games
+	"AddDrawPhase":       true,
+	"AddPlayPhase":       true,
+	"AddDiscardPhase":    true,
+	"AddTrickPhase":      true,
+	"AddBettingPhase":    true,
+	"RemovePhase":        true,
+	"ModifyBettingPhase": true,
+	"ModifyWinCondition": true,
+	"ModifyCardScoring":  true,
+}
+
+// RegisterSemanticMutations adds all semantic mutations to a registry.
+func RegisterSemanticMutations(r *Registry) {
+	r.Register(NewAddCompatiblePhaseMutation(0.2))
+	r.Register(NewRemoveCompatiblePhaseMutation(0.08))
+	// A nudged card count or a moved scoring trigger can leave a win
+	// condition out of reach, so those two are kept only if still valid
+	r.Register(Guarded(NewTweakParameterMutation(0.25)))
+	r.Register(NewSwapWinConditionMutation(0.1))
+	r.Register(Guarded(NewAdjustScoringRuleMutation(0.05)))
+}
@@ -307,6 +307,44 @@ func (m *TrickBasedMutation) Mutate(g *genome.GameGenome, rng *rand.Rand) *genom
 	return clone
 }
 
+// JumpInMutation changes which cards may be played out of turn.
+type JumpInMutation struct {
+	BaseMutation
+}
+
+// NewJumpInMutation creates a new jump-in mutation.
+func NewJumpInMutation(probability float64) *JumpInMutation {
+	return &JumpInMutation{
+		BaseMutation: BaseMutation{
+			probability: probability,
+			name:        "JumpIn",
+		},
+	}
+}
+
+// Mutate switches to a different jump-in rule.
+func (m *JumpInMutation) Mutate(g *genome.GameGenome, rng *rand.Rand) *genome.GameGenome {
+	clone := CloneGenome(g)
+
+	rules := []genome.JumpInRule{
+		genome.JumpInNone,
+		genome.JumpInRank,
+		genome.JumpInIdentical,
+	}
+
+	// Pick a different rule than current
+	current := clone.TurnStructure.JumpIn
+	for {
+		newRule := rules[rng.Intn(len(rules))]
+		if newRule != current {
+			clone.TurnStructure.JumpIn = newRule
+			break
+		}
+	}
+
+	return clone
+}
+
 // RegisterSetupMutations adds all setup-related mutations to a registry.
 func RegisterSetupMutations(r *Registry) {
 	r.Register(NewCardsPerPlayerMutation(0.1))
@@ -317,4 +355,5 @@ func RegisterSetupMutations(r *Registry) {
 	r.Register(NewTableauModeMutation(0.05))
 	r.Register(NewSequenceDirectionMutation(0.05))
 	r.Register(NewTrickBasedMutation(0.05))
+	r.Register(NewJumpInMutation(0.03))
 }
@@ -1,6 +1,7 @@
 package evolution
 
 import (
+	"log"
 	"runtime"
 	"sync"
 
@@ -19,6 +20,9 @@ const (
 	AITypeMCTS2000 = simulation.MCTS2000AI
 )
 
+// shoeEvalShoes is how many shoes are dealt when evaluating a shoe game.
+const shoeEvalShoes = 20
+
 // EvaluationTask represents a single genome evaluation task.
 type EvaluationTask struct {
 	Index          int
@@ -33,11 +37,65 @@ type EvaluationResult struct {
 	Metrics *fitness.FitnessMetrics
 }
 
+// ReferenceOpponent configures evaluation games against a fixed reference
+// AI, which stands in for a strong human player.
+type ReferenceOpponent struct {
+	AI    simulation.AIPlayerType
+	Games int // Games per evaluation (0 = as many as the self-play games)
+}
+
+// SkillGapObjective configures evaluation games between a strong and a weak
+// AI, scored against a target win rate for the strong one. The gap is only
+// a measure of skill between AIs that play from what their seat can know,
+// so MCTS players search deals of the hidden cards rather than the true one
+// (see simulation.RunReferenceMatch).
+type SkillGapObjective struct {
+	Strong, Weak simulation.AIPlayerType
+	Target       float64 // Strong AI's ideal share of the decided games
+	Games        int     // Games per evaluation (0 = as many as the self-play games)
+}
+
+// Ways of combining a genome's fitness across player counts.
+const (
+	PlayerCountMin      = "min"      // Worst table size, every metric taken from it: the game must work at every count
+	PlayerCountMean     = "mean"     // Plain average over the table sizes
+	PlayerCountWeighted = "weighted" // Average weighted by PlayerCountEvaluation.Weights
+)
+
+// PlayerCountEvaluation plays each genome at several table sizes and
+// combines the results into one fitness.
+type PlayerCountEvaluation struct {
+	Counts      []int     // Player counts to evaluate at, from 2 to simulation.MaxPlayers
+	Aggregation string    // PlayerCountMin, PlayerCountMean or PlayerCountWeighted ("" = mean)
+	Weights     []float64 // Weight of each of Counts for PlayerCountWeighted (missing = 1)
+}
+
 // ParallelEvaluator evaluates genomes in parallel using goroutines.
 type ParallelEvaluator struct {
 	NumWorkers int
 	Evaluator  *fitness.Evaluator
 	Style      string
+	Seed       uint64             // Seeds every genome's games, so results don't depend on which worker ran them
+	Reference  *ReferenceOpponent // Also play against a reference AI (nil = self-play only)
+	SkillGap   *SkillGapObjective // Also score a strong AI's edge over a weak one (nil = not measured)
+
+	// PlayerCounts evaluates each genome at several table sizes
+	// (nil = genome.DefaultPlayerCount only).
+	PlayerCounts *PlayerCountEvaluation
+
+	// Determinizations is how many deals of the hidden cards an MCTS
+	// evaluation searches per move, trading speed for not letting the
+	// searcher see its opponents' hands (0 or 1 = the true deal). Fully
+	// observable genomes always search the true deal.
+	Determinizations int
+	Verbose          bool // Log which genomes are determinized
+
+	// MemoryWindow is how many tricks of the hand a forgetful MCTS
+	// remembers in a match against one with perfect recall, measuring how
+	// much a hidden-information genome rewards memory (0 = not measured).
+	MemoryWindow int
+
+	screening bool // Skip shoe play (see ScreenPopulation)
 }
 
 // NewParallelEvaluator creates a new parallel evaluator.
@@ -139,14 +197,150 @@ func (pe *ParallelEvaluator) evaluateGenome(
 		aiType = simulation.MCTS100AI
 	}
 
-	// Run simulations using typed genome runner (direct AST interpretation)
-	simResults := simulation.RunBatchTyped(g, numSimulations, aiType, 0, 0)
+	// MCTS players search several deals of the cards they cannot see,
+	// unless the game hides nothing
+	determinizations := 1
+	if useMCTS && pe.Determinizations > 1 && simulation.HasHiddenInformation(g) {
+		determinizations = pe.Determinizations
+		if pe.Verbose {
+			log.Printf("Determinizing %s: %d deals per MCTS move", g.Name, determinizations)
+		}
+	}
+
+	// Shoe games also check whether counting cards pays off over a shoe
+	var shoe *simulation.ShoeStats
+	if simulation.IsShoeGame(g) && !pe.screening {
+		stats := simulation.RunShoes(g, shoeEvalShoes, pe.Seed)
+		shoe = &stats
+	}
+
+	// The evaluation AI also plays the reference AI, so skill is measured
+	// against a consistent opponent rather than only against itself
+	var ref *simulation.ReferenceStats
+	if pe.Reference != nil {
+		games := pe.Reference.Games
+		if games <= 0 {
+			games = numSimulations
+		}
+		stats := simulation.RunReferenceMatch(g, games, pe.Reference.AI, aiType, pe.Seed)
+		ref = &stats
+	}
+
+	// The strong AI plays the weak one, so fitness can aim at a difficulty
+	// curve rather than only at "more skill"
+	var gap *simulation.ReferenceStats
+	if pe.SkillGap != nil {
+		games := pe.SkillGap.Games
+		if games <= 0 {
+			games = numSimulations
+		}
+		stats := simulation.RunReferenceMatch(g, games, pe.SkillGap.Strong, pe.SkillGap.Weak, pe.Seed)
+		gap = &stats
+	}
+
+	// Perfect recall plays a searcher that forgets older tricks, so fitness
+	// can tell games of skill from games of memory
+	var memory *simulation.ReferenceStats
+	if pe.MemoryWindow > 0 && simulation.HasHiddenInformation(g) {
+		stats := simulation.RunMemoryMatch(g, numSimulations, simulation.MCTS100AI, pe.MemoryWindow, pe.Seed)
+		memory = &stats
+	}
+
+	evaluateAt := func(numPlayers int) *fitness.FitnessMetrics {
+		// Run simulations using typed genome runner (direct AST interpretation)
+		simResults := simulation.RunBatchTypedDeterminized(g, numPlayers, numSimulations, aiType, 0, determinizations, pe.Seed)
+
+		// Convert to fitness.SimulationResults
+		fitnessResults := convertAggregatedStats(&simResults, numPlayers)
+		if shoe != nil {
+			fitnessResults.ShoeHands = int(shoe.Hands)
+			fitnessResults.CountingEdge = shoe.CountingEdge()
+		}
+		if ref != nil {
+			fitnessResults.ReferenceGames = int(ref.Games)
+			fitnessResults.ReferenceEdge = ref.Edge()
+		}
+		if gap != nil {
+			fitnessResults.SkillGapGames = int(gap.ReferenceWins + gap.OpponentWins)
+			fitnessResults.StrongWinRate = gap.WinRate()
+			fitnessResults.SkillGapTarget = pe.SkillGap.Target
+		}
+		if memory != nil {
+			fitnessResults.MemoryGames = int(memory.Games)
+			fitnessResults.MemoryEdge = memory.Edge()
+		}
+
+		// Evaluate fitness
+		return pe.Evaluator.Evaluate(g, fitnessResults)
+	}
+
+	if pe.PlayerCounts == nil || len(pe.PlayerCounts.Counts) == 0 {
+		return evaluateAt(genome.DefaultPlayerCount)
+	}
+	byCount := make([]fitness.PlayerCountMetrics, len(pe.PlayerCounts.Counts))
+	for i, n := range pe.PlayerCounts.Counts {
+		byCount[i] = fitness.PlayerCountMetrics{Players: n, Metrics: evaluateAt(n)}
+	}
+	return pe.PlayerCounts.combine(byCount)
+}
+
+// combine merges a genome's metrics at each player count into one set. The
+// mean and weighted aggregations average each metric, fitness included, the
+// same way; the min aggregation reports every metric of the count with the
+// lowest fitness, so penalties such as the error rate aren't taken from a
+// better count. A count where the game didn't play validly scores zero, with
+// an error rate of one. The genome is valid if it played validly at any
+// count.
+func (pc *PlayerCountEvaluation) combine(byCount []fitness.PlayerCountMetrics) *fitness.FitnessMetrics {
+	combined := &fitness.FitnessMetrics{ByPlayerCount: byCount}
+	fields := func(m *fitness.FitnessMetrics) []*float64 {
+		return []*float64{
+			&m.DecisionDensity, &m.ComebackPotential, &m.TensionCurve, &m.Swinginess,
+			&m.Tempo, &m.CardRelevance, &m.InteractionFrequency, &m.RulesComplexity,
+			&m.SessionLength, &m.SkillVsLuck, &m.BluffingDepth, &m.BettingEngagement,
+			&m.KingmakerRate, &m.EconomicVolatility, &m.ReferenceEdge, &m.StandoffRate,
+			&m.HandLeadStability, &m.SeatFairness, &m.SeatEdge, &m.SkillGap, &m.MemoryReward, &m.ErrorRate, &m.TotalFitness,
+		}
+	}
+	out := fields(combined)
+
+	var totalWeight float64
+	var worst *fitness.FitnessMetrics
+	for i, c := range byCount {
+		m := c.Metrics
+		combined.GamesSimulated += m.GamesSimulated
+		if m.Valid {
+			combined.Valid = true
+		} else {
+			m = &fitness.FitnessMetrics{ErrorRate: 1}
+		}
+		if pc.Aggregation == PlayerCountMin {
+			if worst == nil || m.TotalFitness < worst.TotalFitness {
+				worst = m
+			}
+			continue
+		}
 
-	// Convert to fitness.SimulationResults
-	fitnessResults := convertAggregatedStats(&simResults, genome.DefaultPlayerCount)
+		weight := 1.0
+		if pc.Aggregation == PlayerCountWeighted && i < len(pc.Weights) {
+			weight = pc.Weights[i]
+		}
+		totalWeight += weight
+		for j, v := range fields(m) {
+			*out[j] += weight * *v
+		}
+	}
 
-	// Evaluate fitness
-	return pe.Evaluator.Evaluate(g, fitnessResults)
+	if worst != nil {
+		for j, v := range fields(worst) {
+			*out[j] = *v
+		}
+	} else if totalWeight > 0 {
+		for _, v := range out {
+			*v /= totalWeight
+		}
+	}
+	return combined
 }
 
 // convertAggregatedStats converts simulation.AggregatedStats to fitness.SimulationResults.
@@ -163,14 +357,21 @@ func convertAggregatedStats(stats *simulation.AggregatedStats, playerCount int)
 	for i, w := range stats.Wins {
 		wins[i] = int(w)
 	}
+	var turnOrderWins []int
+	for _, w := range stats.TurnOrderWins {
+		turnOrderWins = append(turnOrderWins, int(w))
+	}
 
 	return &fitness.SimulationResults{
-		TotalGames:  int(stats.TotalGames),
-		Wins:        wins,
-		PlayerCount: playerCount,
-		Draws:       int(stats.Draws),
-		AvgTurns:    float64(stats.AvgTurns),
-		Errors:      int(stats.Errors),
+		TotalGames:    int(stats.TotalGames),
+		Wins:          wins,
+		PlayerCount:   playerCount,
+		TurnOrderWins: turnOrderWins,
+		SeatWinRates:  stats.SeatWinRates(playerCount),
+		SeatEdge:      stats.SeatEdge(playerCount),
+		Draws:         int(stats.Draws),
+		AvgTurns:      float64(stats.AvgTurns),
+		Errors:        int(stats.Errors),
 		// Bluffing metrics
 		TotalClaims:       int(stats.TotalClaims),
 		TotalBluffs:       int(stats.TotalBluffs),
@@ -182,9 +383,52 @@ func convertAggregatedStats(stats *simulation.AggregatedStats, playerCount int)
 		AllInCount:   int(stats.AllInCount),
 		ShowdownWins: int(stats.ShowdownWins),
 		FoldWins:     int(stats.FoldWins),
+		// Tension metrics
+		MarginVolatility: float64(stats.MarginVolatility),
+		// Tempo metrics
+		Tempo: stats.Tempo(),
+		// Hidden information metrics
+		RevealedInfo:     stats.RevealedInfo(),
+		ShowdownExposure: stats.ShowdownExposure(),
+		// Card usage metrics
+		CardRelevance: stats.CardRelevance(),
+		// Standoff metrics
+		StandoffGames: int(stats.StandoffGames),
+		// Multi-hand metrics
+		MultiHandGames:    int(stats.MultiHandGames),
+		HandLeadStability: float64(stats.HandLeadStability),
+		// Kingmaker metrics
+		KingmakerDecisions: int(stats.KingmakerDecisions),
+		KingmakerEvents:    int(stats.KingmakerEvents),
+		// Economy metrics
+		ChipGames:       int(stats.ChipGames),
+		AvgChipVariance: stats.AvgChipVariance,
+		AvgChipSpread:   float64(stats.AvgChipSpread),
 	}
 }
 
+// ScreenPopulation evaluates genomes cheaply: RandomAI self-play at the
+// default player count, without the reference, skill gap and memory
+// matches or shoe play. The
+// structural metrics this measures (decision density, interaction,
+// tension) stand in for full fitness until a genome is worth more games.
+func (pe *ParallelEvaluator) ScreenPopulation(genomes []*genome.GameGenome, numSimulations int) []*fitness.FitnessMetrics {
+	screen := &ParallelEvaluator{
+		NumWorkers: pe.NumWorkers,
+		Evaluator:  pe.Evaluator,
+		Style:      pe.Style,
+		Seed:       pe.Seed,
+		screening:  true,
+	}
+	return screen.EvaluatePopulation(genomes, numSimulations, false)
+}
+
+// ScreenIsCheaper reports whether ScreenPopulation skips any of the work a
+// full evaluation does, ignoring shoe play, which only shoe games have.
+func (pe *ParallelEvaluator) ScreenIsCheaper(useMCTS bool) bool {
+	return useMCTS || pe.Reference != nil || pe.SkillGap != nil || pe.MemoryWindow > 0 || (pe.PlayerCounts != nil && len(pe.PlayerCounts.Counts) > 0)
+}
+
 // EvaluateIndividuals evaluates a slice of individuals in parallel.
 // Returns the same individuals with fitness scores updated.
 func (pe *ParallelEvaluator) EvaluateIndividuals(
@@ -0,0 +1,92 @@
+package evolution
+
+import "github.com/signalnine/darwindeck/gosim/evolution/fitness"
+
+// NonDominatedSort splits individuals into Pareto fronts over their
+// fitness objectives (see fitness.ObjectiveNames). The first front holds
+// the individuals no other dominates, the second those dominated only by
+// the first, and so on; each front keeps the order of individuals.
+// Individuals without full metrics - unevaluated or only screened - are
+// left out.
+func NonDominatedSort(individuals []*Individual) [][]*Individual {
+	var ranked []*Individual
+	var objectives [][]float64
+	for _, ind := range individuals {
+		if !ind.Evaluated || ind.Screened || ind.FitnessMetrics == nil {
+			continue
+		}
+		ranked = append(ranked, ind)
+		objectives = append(objectives, ind.FitnessMetrics.Objectives())
+	}
+
+	// dominates[i] lists who i dominates; dominatedBy[j] counts who
+	// dominates j
+	dominates := make([][]int, len(ranked))
+	dominatedBy := make([]int, len(ranked))
+	for i := range ranked {
+		for j := range ranked {
+			if i != j && fitness.Dominates(objectives[i], objectives[j]) {
+				dominates[i] = append(dominates[i], j)
+				dominatedBy[j]++
+			}
+		}
+	}
+
+	var fronts [][]*Individual
+	var current []int
+	for i := range ranked {
+		if dominatedBy[i] == 0 {
+			current = append(current, i)
+		}
+	}
+	for len(current) > 0 {
+		front := make([]*Individual, len(current))
+		var next []int
+		for k, i := range current {
+			front[k] = ranked[i]
+			for _, j := range dominates[i] {
+				dominatedBy[j]--
+				if dominatedBy[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		fronts = append(fronts, front)
+		current = sortedIndices(next)
+	}
+	return fronts
+}
+
+// sortedIndices sorts idx in place, so each front keeps population order.
+func sortedIndices(idx []int) []int {
+	for i := 1; i < len(idx); i++ {
+		for j := i; j > 0 && idx[j-1] > idx[j]; j-- {
+			idx[j-1], idx[j] = idx[j], idx[j-1]
+		}
+	}
+	return idx
+}
+
+// ParetoFront returns the final population's Pareto-optimal individuals,
+// the trade-offs between fitness metrics that no other individual beats
+// in every metric, one per genome name and best total fitness first.
+func (e *EvolutionEngine) ParetoFront() []*Individual {
+	if e.Population == nil {
+		return nil
+	}
+	fronts := NonDominatedSort(e.Population.SortByFitness())
+	if len(fronts) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var front []*Individual
+	for _, ind := range fronts[0] {
+		if seen[ind.Genome.Name] {
+			continue
+		}
+		seen[ind.Genome.Name] = true
+		front = append(front, ind)
+	}
+	return front
+}
@@ -0,0 +1,76 @@
+package evolution
+
+import (
+	"testing"
+
+	"github.com/signalnine/darwindeck/gosim/evolution/fitness"
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+// paretoIndividual returns an evaluated individual scoring skill and
+// simplicity, the two metrics traded off in these tests.
+func paretoIndividual(name string, skill, simplicity, total float64) *Individual {
+	g := genome.CreateWarGenome()
+	g.Name = name
+	return &Individual{
+		Genome:    g,
+		Fitness:   total,
+		Evaluated: true,
+		FitnessMetrics: &fitness.FitnessMetrics{
+			SkillVsLuck:     skill,
+			RulesComplexity: simplicity,
+			TotalFitness:    total,
+		},
+	}
+}
+
+func TestNonDominatedSort(t *testing.T) {
+	skilful := paretoIndividual("skilful", 0.9, 0.2, 0.5)
+	simple := paretoIndividual("simple", 0.2, 0.9, 0.6)
+	middling := paretoIndividual("middling", 0.5, 0.5, 0.4)
+	dominated := paretoIndividual("dominated", 0.4, 0.4, 0.3)
+	worst := paretoIndividual("worst", 0.1, 0.1, 0.1)
+	screened := paretoIndividual("screened", 1.0, 1.0, 0.9)
+	screened.Screened = true
+
+	fronts := NonDominatedSort([]*Individual{worst, skilful, dominated, simple, screened, middling})
+
+	want := [][]string{{"skilful", "simple", "middling"}, {"dominated"}, {"worst"}}
+	if len(fronts) != len(want) {
+		t.Fatalf("Expected %d fronts, got %d", len(want), len(fronts))
+	}
+	for i, front := range fronts {
+		var names []string
+		for _, ind := range front {
+			names = append(names, ind.Genome.Name)
+		}
+		if len(names) != len(want[i]) {
+			t.Errorf("Front %d: expected %v, got %v", i, want[i], names)
+			continue
+		}
+		for k := range names {
+			if names[k] != want[i][k] {
+				t.Errorf("Front %d: expected %v, got %v", i, want[i], names)
+				break
+			}
+		}
+	}
+}
+
+func TestParetoFront(t *testing.T) {
+	engine := &EvolutionEngine{Population: NewPopulation([]*Individual{
+		paretoIndividual("skilful", 0.9, 0.2, 0.5),
+		paretoIndividual("dominated", 0.4, 0.1, 0.3),
+		paretoIndividual("simple", 0.2, 0.9, 0.6),
+		paretoIndividual("simple", 0.2, 0.9, 0.6),
+		{Genome: genome.CreateWarGenome()},
+	})}
+
+	front := engine.ParetoFront()
+	if len(front) != 2 {
+		t.Fatalf("Expected 2 genomes on the front, got %d", len(front))
+	}
+	if front[0].Genome.Name != "simple" || front[1].Genome.Name != "skilful" {
+		t.Errorf("Expected the front best fitness first, got %s then %s", front[0].Genome.Name, front[1].Genome.Name)
+	}
+}
@@ -17,6 +17,9 @@ type Individual struct {
 	Fitness        float64
 	Evaluated      bool
 	FitnessMetrics *fitness.FitnessMetrics // Full metrics breakdown
+	Screened       bool                    // Fitness is from the cheap screen only (see EvolutionConfig.ScreenFraction)
+
+	lineage *lineage // How the individual was bred, kept for attribution until evaluated
 }
 
 // Clone creates a deep copy of the individual.
@@ -25,6 +28,7 @@ func (ind *Individual) Clone() *Individual {
 		Genome:    ind.Genome.Clone(),
 		Fitness:   ind.Fitness,
 		Evaluated: ind.Evaluated,
+		Screened:  ind.Screened,
 	}
 	if ind.FitnessMetrics != nil {
 		metricsCopy := *ind.FitnessMetrics
@@ -111,10 +115,12 @@ func (p *Population) ComputeDiversity() float64 {
 			}
 		}
 	} else {
-		// Large population: sample 100 random pairs
+		// Large population: sample 100 random pairs. The sample is seeded by
+		// generation so a resumed run measures the same diversity.
+		rng := rand.New(rand.NewSource(int64(p.Generation)))
 		for k := 0; k < 100; k++ {
-			i := rand.Intn(len(p.Individuals))
-			j := rand.Intn(len(p.Individuals))
+			i := rng.Intn(len(p.Individuals))
+			j := rng.Intn(len(p.Individuals))
 			if i == j {
 				j = (i + 1) % len(p.Individuals)
 			}
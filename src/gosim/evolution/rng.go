@@ -0,0 +1,55 @@
+package evolution
+
+import "math/rand"
+
+// RNGState records a position in the evolution random stream: the seed and
+// how many values have been drawn since seeding.
+type RNGState struct {
+	Seed  int64  `json:"seed"`
+	Draws uint64 `json:"draws"`
+}
+
+// trackedSource is the standard seeded source with a draw counter, so the
+// engine's position in the random stream can be checkpointed and restored
+// exactly. math/rand's own source state is not exported.
+type trackedSource struct {
+	src   rand.Source64
+	seed  int64
+	draws uint64
+}
+
+func newTrackedSource(seed int64) *trackedSource {
+	return &trackedSource{src: rand.NewSource(seed).(rand.Source64), seed: seed}
+}
+
+// restoreTrackedSource reseeds and replays state.Draws values, leaving the
+// source exactly where it was when state was taken.
+func restoreTrackedSource(state RNGState) *trackedSource {
+	s := newTrackedSource(state.Seed)
+	for i := uint64(0); i < state.Draws; i++ {
+		s.src.Uint64()
+	}
+	s.draws = state.Draws
+	return s
+}
+
+func (s *trackedSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *trackedSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *trackedSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed = seed
+	s.draws = 0
+}
+
+// State returns the current position in the stream.
+func (s *trackedSource) State() RNGState {
+	return RNGState{Seed: s.seed, Draws: s.draws}
+}
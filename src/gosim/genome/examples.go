@@ -1,6 +1,8 @@
 // Package genome provides seed genomes for testing and evolution.
 package genome
 
+import "github.com/signalnine/darwindeck/gosim/engine"
+
 // Suit constants matching Python's Suit enum.
 const (
 	SuitHearts   uint8 = 0
@@ -10,21 +12,21 @@ const (
 	SuitAny      uint8 = 255
 )
 
-// Rank constants matching Python's Rank enum.
+// Rank constants matching Python's Rank enum and the engine's card encoding.
 const (
-	RankTwo   uint8 = 0
-	RankThree uint8 = 1
-	RankFour  uint8 = 2
-	RankFive  uint8 = 3
-	RankSix   uint8 = 4
-	RankSeven uint8 = 5
-	RankEight uint8 = 6
-	RankNine  uint8 = 7
-	RankTen   uint8 = 8
-	RankJack  uint8 = 9
-	RankQueen uint8 = 10
-	RankKing  uint8 = 11
-	RankAce   uint8 = 12
+	RankTwo   uint8 = engine.RankTwo
+	RankThree uint8 = engine.RankThree
+	RankFour  uint8 = engine.RankFour
+	RankFive  uint8 = engine.RankFive
+	RankSix   uint8 = engine.RankSix
+	RankSeven uint8 = engine.RankSeven
+	RankEight uint8 = engine.RankEight
+	RankNine  uint8 = engine.RankNine
+	RankTen   uint8 = engine.RankTen
+	RankJack  uint8 = engine.RankJack
+	RankQueen uint8 = engine.RankQueen
+	RankKing  uint8 = engine.RankKing
+	RankAce   uint8 = engine.RankAce
 	RankAny   uint8 = 255
 )
 
@@ -86,7 +88,8 @@ func CreateBettingWarGenome() *GameGenome {
 }
 
 // CreateHeartsGenome creates classic 4-player Hearts.
-// Must follow suit, Hearts can't be led until broken, lowest score wins.
+// The 2 of clubs leads, must follow suit, Hearts can't be led until broken,
+// lowest score wins.
 func CreateHeartsGenome() *GameGenome {
 	return &GameGenome{
 		Name: "Hearts",
@@ -100,6 +103,10 @@ func CreateHeartsGenome() *GameGenome {
 					TrumpSuit:        255, // No trump
 					HighCardWins:     true,
 					BreakingSuit:     SuitHearts,
+					OpeningLead:      OpeningLeadCardHolder,
+					LeadCardRank:     RankTwo,
+					LeadCardSuit:     SuitClubs,
+					MustLeadCard:     true,
 				},
 			},
 			MaxTurns: 200,
@@ -141,12 +148,14 @@ func CreateScotchWhistGenome() *GameGenome {
 }
 
 // CreateKnockoutWhistGenome creates Knock-Out Whist.
-// Simple elimination trick-taking game.
+// Simple elimination trick-taking game: each round deals one card fewer and
+// knocks out whoever takes no trick.
 func CreateKnockoutWhistGenome() *GameGenome {
 	return &GameGenome{
 		Name: "Knock-Out Whist",
 		Setup: SetupRules{
 			CardsPerPlayer: 7, // 4 players x 7 = 28 cards
+			HandShrink:     1, // 7, 6, 5... cards
 		},
 		TurnStructure: TurnStructure{
 			Phases: []Phase{
@@ -156,10 +165,10 @@ func CreateKnockoutWhistGenome() *GameGenome {
 					HighCardWins:     true,
 				},
 			},
-			MaxTurns: 100,
+			MaxTurns: 200,
 		},
+		// The rounds decide the match once the last one is played out
 		WinConditions: []WinCondition{
-			{Type: WinTypeMostCaptured},
 			{Type: WinTypeAllHandsEmpty},
 		},
 	}
@@ -499,6 +508,7 @@ func CreateSimplePokerGenome() *GameGenome {
 				},
 			},
 			MaxTurns: 10,
+			AceMode:  AceBoth,
 		},
 		WinConditions: []WinCondition{
 			{Type: WinTypeBestHand},
@@ -574,6 +584,9 @@ func CreateScopaGenome() *GameGenome {
 		WinConditions: []WinCondition{
 			{Type: WinTypeMostCaptured},
 		},
+		CardScoring: []CardScoringRule{
+			{Suit: SuitAny, Rank: RankAny, Points: 1, Trigger: TriggerSweep}, // A scopa
+		},
 	}
 }
 
@@ -609,6 +622,7 @@ func CreateDrawPokerGenome() *GameGenome {
 				},
 			},
 			MaxTurns: 20,
+			AceMode:  AceBoth,
 		},
 		WinConditions: []WinCondition{
 			{Type: WinTypeBestHand},
@@ -639,6 +653,7 @@ func CreateBlackjackGenome() *GameGenome {
 		Setup: SetupRules{
 			CardsPerPlayer: 2,
 			StartingChips:  500,
+			Penetration:    0.75, // Deal three quarters of the shoe, then reshuffle
 		},
 		TurnStructure: TurnStructure{
 			Phases: []Phase{
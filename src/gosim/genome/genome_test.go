@@ -2,6 +2,10 @@ package genome
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/signalnine/darwindeck/gosim/engine"
@@ -253,6 +257,139 @@ func TestTrickPhaseMovegen(t *testing.T) {
 			t.Errorf("Expected hearts only, got suit %d", card.Suit)
 		}
 	}
+
+	// Must beat when able: neither heart beats the 7, but only one beats a 3
+	genome.TurnStructure.Phases[0].(*TrickPhase).MustBeat = true
+	if moves = GenerateLegalMovesTyped(state, genome); len(moves) != 2 {
+		t.Errorf("Must beat, unable: expected 2 moves, got %d", len(moves))
+	}
+	state.CurrentTrick[0].Card.Rank = 3
+	moves = GenerateLegalMovesTyped(state, genome)
+	if len(moves) != 1 || moves[0].CardIndex != 1 {
+		t.Errorf("Must beat: expected only the higher heart, got %v", moves)
+	}
+}
+
+// TestTrickPhaseMustLeadCard checks the holder of the 2 of clubs must lead
+// it to the first trick, and may lead anything afterwards.
+// TestDrawPhaseLimits checks the draw move disappears once the player has
+// drawn the phase's per-turn limit, or the game's, and survives JSON.
+func TestDrawPhaseLimits(t *testing.T) {
+	phase := &DrawPhase{Source: LocationDeck, Count: 1, MaxPerTurn: 2}
+	g := &GameGenome{
+		Name:          "LimitedDraw",
+		Setup:         SetupRules{CardsPerPlayer: 5, MaxDrawsPerGame: 4},
+		TurnStructure: TurnStructure{Phases: []Phase{phase}, MaxTurns: 100},
+		WinConditions: []WinCondition{{Type: WinTypeEmptyHand}},
+	}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.Deck = []engine.Card{{Rank: 4, Suit: 0}, {Rank: 5, Suit: 0}}
+	hasDraw := func() bool {
+		for _, m := range GenerateLegalMovesTyped(state, g) {
+			if m.CardIndex == engine.MoveDraw {
+				return true
+			}
+		}
+		return false
+	}
+
+	state.DrawsTurnCount, state.Players[0].Draws = 1, 1
+	if !hasDraw() {
+		t.Fatal("Expected a draw under the per-turn limit")
+	}
+	state.DrawsTurnCount, state.Players[0].Draws = 2, 2
+	if hasDraw() {
+		t.Error("Expected the draw withdrawn at the per-turn limit")
+	}
+	state.DrawsTurnCount, state.Players[0].Draws = 0, 4
+	if hasDraw() {
+		t.Error("Expected the draw withdrawn at the per-game limit")
+	}
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var restored GameGenome
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if restored.Setup.MaxDrawsPerGame != 4 || restored.TurnStructure.Phases[0].(*DrawPhase).MaxPerTurn != 2 {
+		t.Errorf("Expected the draw limits to round-trip, got %s", data)
+	}
+}
+
+func TestTrickPhaseMustLeadCard(t *testing.T) {
+	g := CreateHeartsGenome()
+	state := engine.NewGameState(4)
+	defer engine.PutState(state)
+	state.NumPlayers = 4
+	state.Players[2].Hand = []engine.Card{
+		{Rank: RankAce, Suit: SuitSpades},
+		{Rank: RankTwo, Suit: SuitClubs},
+		{Rank: RankNine, Suit: SuitDiamonds},
+	}
+	state.CurrentPlayer = uint8(engine.FindCardHolder(state, RankTwo, SuitClubs))
+	if state.CurrentPlayer != 2 {
+		t.Fatalf("Expected seat 2 to hold the 2 of clubs, got %d", state.CurrentPlayer)
+	}
+
+	moves := GenerateLegalMovesTyped(state, g)
+	if len(moves) != 1 || moves[0].CardIndex != 1 {
+		t.Errorf("Expected only the 2 of clubs on the first trick, got %v", moves)
+	}
+
+	// Once a trick has been taken the lead is free
+	state.Players[0].TricksWon = 1
+	if moves = GenerateLegalMovesTyped(state, g); len(moves) != 3 {
+		t.Errorf("Expected a free lead after the first trick, got %d moves", len(moves))
+	}
+
+	// Without the rule, the holder leads what they like
+	state.Players[0].TricksWon = 0
+	g.TurnStructure.Phases[0].(*TrickPhase).MustLeadCard = false
+	if moves = GenerateLegalMovesTyped(state, g); len(moves) != 3 {
+		t.Errorf("Expected a free opening lead without the rule, got %d moves", len(moves))
+	}
+}
+
+// TestTrickPhaseTrumpDiscipline checks each trump discipline reaches typed
+// move generation, for a follower holding a trump and an off-suit discard.
+func TestTrickPhaseTrumpDiscipline(t *testing.T) {
+	phase := &TrickPhase{TrumpSuit: SuitSpades, HighCardWins: true, BreakingSuit: 255}
+	g := &GameGenome{
+		Name:          "Trumps",
+		TurnStructure: TurnStructure{Phases: []Phase{phase}, MaxTurns: 52},
+		WinConditions: []WinCondition{{Type: WinTypeAllHandsEmpty}},
+	}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.NumPlayers = 2
+	state.CurrentTrick = []engine.TrickCard{{PlayerID: 1, Card: engine.Card{Rank: 7, Suit: SuitHearts}}}
+
+	tests := []struct {
+		discipline TrumpDiscipline
+		void       int // Moves when void in hearts
+		holding    int // Moves when also holding a heart
+	}{
+		{TrumpFree, 2, 3},
+		{TrumpOnlyWhenVoid, 2, 2},
+		{TrumpMustWhenVoid, 1, 3},
+	}
+	for _, tt := range tests {
+		phase.TrumpDiscipline = tt.discipline
+		state.Players[0].Hand = []engine.Card{{Rank: 2, Suit: SuitSpades}, {Rank: 12, Suit: SuitDiamonds}}
+		if moves := GenerateLegalMovesTyped(state, g); len(moves) != tt.void {
+			t.Errorf("Discipline %d, void: expected %d moves, got %d", tt.discipline, tt.void, len(moves))
+		}
+		state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: 4, Suit: SuitHearts})
+		if moves := GenerateLegalMovesTyped(state, g); len(moves) != tt.holding {
+			t.Errorf("Discipline %d, holding hearts: expected %d moves, got %d", tt.discipline, tt.holding, len(moves))
+		}
+	}
 }
 
 // TestBettingPhaseMovegen tests betting move generation.
@@ -562,7 +699,7 @@ func TestJSONRoundTrip(t *testing.T) {
 					PassIfUnable: true,
 					ValidPlayCondition: &Condition{
 						OpCode:   12, // check_card_matches_rank
-						Operator: 50, // eq
+						Operator: 0, // eq
 						Value:    0,
 						RefLoc:   2,  // discard
 					},
@@ -638,3 +775,1176 @@ func TestJSONRoundTrip(t *testing.T) {
 		t.Errorf("Condition OpCode mismatch: got %d, want 12", playPhase.ValidPlayCondition.OpCode)
 	}
 }
+
+func TestDealOrderJSON(t *testing.T) {
+	original := &GameGenome{
+		Name:  "SequentialDeal",
+		Setup: SetupRules{CardsPerPlayer: 5, DealOrder: DealSequential},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	if !strings.Contains(string(jsonBytes), `"deal_order": "sequential"`) {
+		t.Errorf("Expected deal_order in JSON, got %s", jsonBytes)
+	}
+
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.Setup.DealOrder != DealSequential {
+		t.Errorf("DealOrder mismatch: got %d, want %d", loaded.Setup.DealOrder, DealSequential)
+	}
+
+	// Genomes without the field default to round-robin
+	loaded, err = LoadGenomeFromJSON([]byte(`{"setup":{"cards_per_player":5},"turn_structure":{"phases":[]},"win_conditions":[]}`))
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.Setup.DealOrder != DealRoundRobin {
+		t.Errorf("Expected default DealRoundRobin, got %d", loaded.Setup.DealOrder)
+	}
+}
+
+func TestDealStrategyJSON(t *testing.T) {
+	original := &GameGenome{
+		Name:  "ShoeDraw",
+		Setup: SetupRules{CardsPerPlayer: 2, DealStrategy: DealShoeDraw},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	if !strings.Contains(string(jsonBytes), `"deal_strategy": "shoe_draw"`) {
+		t.Errorf("Expected deal_strategy in JSON, got %s", jsonBytes)
+	}
+
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.Setup.DealStrategy != DealShoeDraw {
+		t.Errorf("DealStrategy mismatch: got %d, want %d", loaded.Setup.DealStrategy, DealShoeDraw)
+	}
+
+	// Genomes without the field are pre-dealt
+	loaded, err = LoadGenomeFromJSON([]byte(`{"setup":{"cards_per_player":5},"turn_structure":{"phases":[]},"win_conditions":[]}`))
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.Setup.DealStrategy != DealPreDealt {
+		t.Errorf("Expected default DealPreDealt, got %d", loaded.Setup.DealStrategy)
+	}
+}
+
+func TestPenetrationJSON(t *testing.T) {
+	original := CreateBlackjackGenome()
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.Setup.Penetration != 0.75 {
+		t.Errorf("Penetration mismatch: got %f, want 0.75", loaded.Setup.Penetration)
+	}
+}
+
+func TestSequencePlayTypedAceModes(t *testing.T) {
+	queen := engine.Card{Rank: RankQueen, Suit: SuitSpades}
+	king := engine.Card{Rank: RankKing, Suit: SuitSpades}
+	ace := engine.Card{Rank: RankAce, Suit: SuitSpades}
+	two := engine.Card{Rank: RankTwo, Suit: SuitSpades}
+	three := engine.Card{Rank: RankThree, Suit: SuitSpades}
+
+	// Q-K-A ascending with Ace high
+	if !isValidSequencePlayTyped(king, queen, 0, engine.AceHigh) || !isValidSequencePlayTyped(ace, king, 0, engine.AceHigh) {
+		t.Error("Q-K-A should be valid with Ace high")
+	}
+	// A-2-3 ascending with Ace low
+	if !isValidSequencePlayTyped(two, ace, 0, engine.AceLow) || !isValidSequencePlayTyped(three, two, 0, engine.AceLow) {
+		t.Error("A-2-3 should be valid with Ace low")
+	}
+	if isValidSequencePlayTyped(two, ace, 0, engine.AceHigh) {
+		t.Error("A-2 should be invalid with Ace high")
+	}
+	if isValidSequencePlayTyped(ace, king, 0, engine.AceLow) {
+		t.Error("K-A should be invalid with Ace low")
+	}
+}
+
+func TestAceModeJSON(t *testing.T) {
+	original := &GameGenome{
+		Name:          "AceLowRun",
+		TurnStructure: TurnStructure{AceMode: AceLow},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.TurnStructure.AceMode != AceLow {
+		t.Errorf("AceMode mismatch: got %d, want %d", loaded.TurnStructure.AceMode, AceLow)
+	}
+	if clone := loaded.Clone(); clone.TurnStructure.AceMode != AceLow {
+		t.Errorf("Clone lost AceMode: got %d", clone.TurnStructure.AceMode)
+	}
+}
+
+func TestHandPenaltyJSON(t *testing.T) {
+	original := &GameGenome{
+		Name:        "HandPenalty",
+		HandPenalty: HandPenaltyWinner,
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.HandPenalty != HandPenaltyWinner {
+		t.Errorf("HandPenalty mismatch: got %d, want %d", loaded.HandPenalty, HandPenaltyWinner)
+	}
+	if clone := loaded.Clone(); clone.HandPenalty != HandPenaltyWinner {
+		t.Errorf("Clone lost HandPenalty: got %d", clone.HandPenalty)
+	}
+}
+
+func TestScoreCarryJSON(t *testing.T) {
+	original := &GameGenome{
+		Name:          "Match",
+		ScoreCarry:    ScoreCarryPerHand,
+		WinConditions: []WinCondition{{Type: WinTypeMatchWins, Threshold: 3}},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.ScoreCarry != ScoreCarryPerHand {
+		t.Errorf("ScoreCarry mismatch: got %d, want %d", loaded.ScoreCarry, ScoreCarryPerHand)
+	}
+	if wc := loaded.WinConditions[0]; wc.Type != WinTypeMatchWins || wc.Threshold != 3 {
+		t.Errorf("Expected first to win 3 hands, got %+v", wc)
+	}
+	if clone := loaded.Clone(); clone.ScoreCarry != ScoreCarryPerHand {
+		t.Errorf("Clone lost ScoreCarry: got %d", clone.ScoreCarry)
+	}
+}
+
+func TestCatchUpJSON(t *testing.T) {
+	original := CreateHeartsGenome()
+	original.CatchUp = &CatchUpRule{Draw: 1, Points: 2}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.CatchUp == nil || *loaded.CatchUp != *original.CatchUp {
+		t.Fatalf("CatchUp mismatch: got %+v", loaded.CatchUp)
+	}
+	clone := loaded.Clone()
+	clone.CatchUp.Points = 5
+	if loaded.CatchUp.Points != 2 {
+		t.Error("Clone shares CatchUp with the original")
+	}
+}
+
+func TestPersonalDecksJSON(t *testing.T) {
+	original := CreateCrazyEightsGenome()
+	original.Setup.PersonalDecks = true
+	original.Setup.PersonalDeckSize = 20
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.Setup != original.Setup {
+		t.Fatalf("Setup mismatch: got %+v", loaded.Setup)
+	}
+	if errs := ValidateGenome(loaded); len(errs) != 0 {
+		t.Errorf("Expected 10-card hands from 20-card decks to be valid, got %v", errs)
+	}
+
+	loaded.Setup.PersonalDeckSize = 8
+	if errs := ValidateGenome(loaded); len(errs) == 0 {
+		t.Error("Expected 10-card hands from 8-card decks to be rejected")
+	}
+}
+
+func TestMoonShotJSON(t *testing.T) {
+	original := CreateHeartsGenome()
+	original.MoonShot = &MoonShotRule{Points: 26}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.MoonShot == nil || *loaded.MoonShot != *original.MoonShot {
+		t.Fatalf("MoonShot mismatch: got %+v", loaded.MoonShot)
+	}
+	if errs := ValidateGenome(loaded); len(errs) != 0 {
+		t.Errorf("Expected Hearts with a moon shot to be valid, got %v", errs)
+	}
+
+	// Without tricks there is no moon to shoot
+	crazy := CreateCrazyEightsGenome()
+	crazy.MoonShot = &MoonShotRule{}
+	if errs := ValidateGenome(crazy); len(errs) == 0 {
+		t.Error("Expected a moon shot without a TrickPhase to be rejected")
+	}
+}
+
+func TestSetCleanupJSON(t *testing.T) {
+	original := CreateOldMaidGenome()
+	original.SetCleanup = &SetCleanupRule{Size: 2}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.SetCleanup == nil || *loaded.SetCleanup != *original.SetCleanup {
+		t.Fatalf("SetCleanup mismatch: got %+v", loaded.SetCleanup)
+	}
+	if clone := loaded.Clone(); clone.SetCleanup == loaded.SetCleanup {
+		t.Error("Clone should deep copy SetCleanup")
+	}
+
+	// A single card is no set
+	loaded.SetCleanup.Size = 1
+	if errs := ValidateGenome(loaded); len(errs) == 0 {
+		t.Error("Expected a set of one card to be rejected")
+	}
+}
+
+func TestCaptureScoringJSON(t *testing.T) {
+	original := CreateScopaGenome()
+	original.CaptureScoring = []CaptureCategory{
+		{Kind: CaptureMostSuit, Suit: 1, Points: 1},
+		{Kind: CaptureCard, Suit: 1, Rank: 5, Points: 1},
+		{Kind: CapturePrime, Points: 1, PrimeValues: []int32{12, 13, 14, 15, 18, 21, 10, 10, 10, 10, 10, 10, 16}},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.CaptureScoring, original.CaptureScoring) {
+		t.Fatalf("CaptureScoring mismatch: got %+v", loaded.CaptureScoring)
+	}
+	clone := loaded.Clone()
+	clone.CaptureScoring[2].PrimeValues[0] = 99
+	if loaded.CaptureScoring[2].PrimeValues[0] != 12 {
+		t.Error("Clone shares PrimeValues with the original")
+	}
+
+	original.CaptureScoring[2].PrimeValues = []int32{1, 2}
+	if errs := ValidateGenome(original); len(errs) == 0 {
+		t.Error("Expected short PrimeValues to be rejected")
+	}
+}
+
+func TestRevealScoringJSON(t *testing.T) {
+	original := CreateGinRummyGenome()
+	original.RevealScoring = true
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if !loaded.RevealScoring || !loaded.Clone().RevealScoring {
+		t.Error("RevealScoring lost in the round trip")
+	}
+}
+
+func TestBurnJSON(t *testing.T) {
+	original := &GameGenome{
+		Name:  "Burn",
+		Setup: SetupRules{CardsPerPlayer: 2, BurnCards: 1},
+		TurnStructure: TurnStructure{Phases: []Phase{
+			&BettingPhase{MinBet: 10, Community: []int{3, 1, 1}, Burn: 1},
+			&DiscardPhase{Target: LocationBurned, Count: 1},
+		}},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.Setup.BurnCards != 1 {
+		t.Errorf("BurnCards mismatch: got %d", loaded.Setup.BurnCards)
+	}
+	if bp := loaded.TurnStructure.Phases[0].(*BettingPhase); bp.Burn != 1 {
+		t.Errorf("BettingPhase Burn mismatch: got %d", bp.Burn)
+	}
+	if dp := loaded.TurnStructure.Phases[1].(*DiscardPhase); dp.Target != LocationBurned {
+		t.Errorf("DiscardPhase Target mismatch: got %d", dp.Target)
+	}
+}
+
+func TestPlacementPointsJSON(t *testing.T) {
+	original := CreateCrazyEightsGenome()
+	original.PlacementPoints = []int32{5, 2, 1}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.PlacementPoints, original.PlacementPoints) {
+		t.Errorf("PlacementPoints mismatch: got %v, want %v", loaded.PlacementPoints, original.PlacementPoints)
+	}
+	clone := loaded.Clone()
+	clone.PlacementPoints[0] = 9
+	if loaded.PlacementPoints[0] != 5 {
+		t.Error("Clone shares PlacementPoints with the original")
+	}
+
+	// Placings are only decided by going out
+	loaded.WinConditions = []WinCondition{{Type: WinTypeHighScore, Threshold: 10}}
+	if IsValid(loaded) {
+		t.Error("Expected placement points without empty_hand to be invalid")
+	}
+}
+
+func TestJumpInJSON(t *testing.T) {
+	original := &GameGenome{
+		Name:          "JumpIn",
+		TurnStructure: TurnStructure{JumpIn: JumpInIdentical},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.TurnStructure.JumpIn != JumpInIdentical {
+		t.Errorf("JumpIn mismatch: got %d, want %d", loaded.TurnStructure.JumpIn, JumpInIdentical)
+	}
+	if clone := loaded.Clone(); clone.TurnStructure.JumpIn != JumpInIdentical {
+		t.Errorf("Clone lost JumpIn: got %d", clone.TurnStructure.JumpIn)
+	}
+}
+
+func TestConditionOperatorJSONRoundTrip(t *testing.T) {
+	// Operators are stored the way the engine compares them, as offsets from
+	// OpEQ, so a loaded "hand size <op> 5" condition holds for a 3-card hand
+	// exactly when 3 <op> 5 does
+	state := engine.NewGameState(2)
+	state.Players[0].Hand = []engine.Card{{Rank: 0}, {Rank: 1}, {Rank: 2}}
+	holds := []bool{false, true, true, false, true, false} // eq, ne, lt, gt, le, ge
+	for op := uint8(0); op <= 5; op++ {
+		original := &GameGenome{
+			Name: "Condition",
+			TurnStructure: TurnStructure{Phases: []Phase{&DrawPhase{
+				Count:     1,
+				Condition: &Condition{OpCode: 0, Operator: op, Value: 5},
+			}}},
+		}
+		jsonBytes, err := SaveGenomeToJSON(original)
+		if err != nil {
+			t.Fatalf("Failed to serialize: %v", err)
+		}
+		loaded, err := LoadGenomeFromJSON(jsonBytes)
+		if err != nil {
+			t.Fatalf("Failed to deserialize: %v", err)
+		}
+		cond := loaded.TurnStructure.Phases[0].(*DrawPhase).Condition
+		if cond.Operator != op {
+			t.Errorf("Operator %d round-tripped as %d", op, cond.Operator)
+		}
+		if got := evaluateConditionTyped(state, 0, cond); got != holds[op] {
+			t.Errorf("Expected operator %s on a 3-card hand against 5 to be %v, got %v", operatorToString(op), holds[op], got)
+		}
+	}
+}
+
+func TestEffectTypeJSONRoundTrip(t *testing.T) {
+	for e := EffectSkipNext; e <= EffectDiscardPile; e++ {
+		original := &GameGenome{
+			Name:    "Effects",
+			Effects: []SpecialEffect{{TriggerRank: 12, Effect: e}},
+		}
+		jsonBytes, err := SaveGenomeToJSON(original)
+		if err != nil {
+			t.Fatalf("Failed to serialize: %v", err)
+		}
+		loaded, err := LoadGenomeFromJSON(jsonBytes)
+		if err != nil {
+			t.Fatalf("Failed to deserialize: %v", err)
+		}
+		if got := loaded.Effects[0].Effect; got != e {
+			t.Errorf("Effect %q round-tripped as %q", e, got)
+		}
+	}
+}
+
+func TestTrickTieRuleJSON(t *testing.T) {
+	original := CreateHeartsGenome()
+	original.TurnStructure.Phases[0].(*TrickPhase).TieRule = TrickTieLastPlayed
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	tp, ok := loaded.TurnStructure.Phases[0].(*TrickPhase)
+	if !ok {
+		t.Fatalf("Expected TrickPhase, got %T", loaded.TurnStructure.Phases[0])
+	}
+	if tp.TieRule != TrickTieLastPlayed {
+		t.Errorf("TieRule mismatch: got %d, want %d", tp.TieRule, TrickTieLastPlayed)
+	}
+}
+
+func TestDeclareTrumpJSON(t *testing.T) {
+	original := CreateSpadesGenome()
+	original.TurnStructure.Phases[0].(*BiddingPhase).DeclareTrump = true
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	bp, ok := loaded.TurnStructure.Phases[0].(*BiddingPhase)
+	if !ok {
+		t.Fatalf("Expected BiddingPhase, got %T", loaded.TurnStructure.Phases[0])
+	}
+	if !bp.DeclareTrump {
+		t.Error("Expected DeclareTrump to survive a round trip")
+	}
+}
+
+func TestTrickConstraintsJSON(t *testing.T) {
+	original := CreateHeartsGenome()
+	tp := original.TurnStructure.Phases[0].(*TrickPhase)
+	tp.MustBeat = true
+	tp.MustOvertrump = true
+	tp.TrumpDiscipline = TrumpOnlyWhenVoid
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	tp = loaded.TurnStructure.Phases[0].(*TrickPhase)
+	if !tp.MustBeat || !tp.MustOvertrump || tp.TrumpDiscipline != TrumpOnlyWhenVoid {
+		t.Errorf("Expected the trick constraints to survive a round trip, got %+v", tp)
+	}
+}
+
+func TestOpeningLeadJSON(t *testing.T) {
+	original := CreateHeartsGenome()
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	tp := loaded.TurnStructure.Phases[0].(*TrickPhase)
+	if tp.OpeningLead != OpeningLeadCardHolder || tp.LeadCardRank != RankTwo || tp.LeadCardSuit != SuitClubs {
+		t.Errorf("Expected the 2 of clubs to lead, got rule %d with rank %d suit %d", tp.OpeningLead, tp.LeadCardRank, tp.LeadCardSuit)
+	}
+	if !tp.MustLeadCard {
+		t.Error("Expected the 2 of clubs to still be the forced opening lead")
+	}
+
+	// The default dealer-left rule is omitted
+	jsonBytes, err = SaveGenomeToJSON(CreateSpadesGenome())
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	if strings.Contains(string(jsonBytes), "opening_lead") {
+		t.Error("Expected no opening_lead for the default rule")
+	}
+}
+
+func TestNextLeaderJSON(t *testing.T) {
+	original := CreateSpadesGenome()
+	original.TurnStructure.Phases[len(original.TurnStructure.Phases)-1].(*TrickPhase).NextLeader = TrickLeadRotate
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	if !strings.Contains(string(jsonBytes), `"next_leader": "rotate"`) {
+		t.Errorf("Expected next_leader rotate in %s", jsonBytes)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	phases := loaded.TurnStructure.Phases
+	if tp := phases[len(phases)-1].(*TrickPhase); tp.NextLeader != TrickLeadRotate {
+		t.Errorf("Expected the rotating lead to survive a round trip, got %d", tp.NextLeader)
+	}
+}
+
+func TestClaimRankRuleJSON(t *testing.T) {
+	original := CreateCheatGenome()
+	original.TurnStructure.Phases[0].(*ClaimPhase).RankRule = ClaimRankMatchOrHigher
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	if !strings.Contains(string(jsonBytes), `"rank_rule": "match_or_higher"`) {
+		t.Errorf("Expected rank_rule match_or_higher in %s", jsonBytes)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if cp := loaded.TurnStructure.Phases[0].(*ClaimPhase); cp.RankRule != ClaimRankMatchOrHigher {
+		t.Errorf("Expected the rank rule to survive a round trip, got %d", cp.RankRule)
+	}
+}
+
+func TestShowPhaseJSON(t *testing.T) {
+	original := &GameGenome{
+		Name: "HighCardDuel",
+		TurnStructure: TurnStructure{
+			Phases: []Phase{
+				&ShowPhase{Award: ShowAwardPoints, Points: 2, ClearHands: true},
+			},
+		},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+
+	sp, ok := loaded.TurnStructure.Phases[0].(*ShowPhase)
+	if !ok {
+		t.Fatalf("Expected *ShowPhase, got %T", loaded.TurnStructure.Phases[0])
+	}
+	if sp.Award != ShowAwardPoints || sp.Points != 2 || !sp.ClearHands {
+		t.Errorf("ShowPhase mismatch: %+v", sp)
+	}
+	if clone := loaded.Clone(); clone.TurnStructure.Phases[0] == loaded.TurnStructure.Phases[0] {
+		t.Error("Clone should deep copy ShowPhase")
+	}
+}
+
+func TestPeekPhaseJSON(t *testing.T) {
+	original := &GameGenome{
+		Name: "Scouting",
+		TurnStructure: TurnStructure{
+			Phases: []Phase{
+				&PeekPhase{Target: PeekTargetAll, Duration: 4},
+			},
+		},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+
+	pp, ok := loaded.TurnStructure.Phases[0].(*PeekPhase)
+	if !ok {
+		t.Fatalf("Expected *PeekPhase, got %T", loaded.TurnStructure.Phases[0])
+	}
+	if pp.Target != PeekTargetAll || pp.Duration != 4 {
+		t.Errorf("PeekPhase mismatch: %+v", pp)
+	}
+	if clone := loaded.Clone(); clone.TurnStructure.Phases[0] == loaded.TurnStructure.Phases[0] {
+		t.Error("Clone should deep copy PeekPhase")
+	}
+
+	// Python format keeps the fields on the phase itself
+	flat := `{"name": "Scouting", "setup": {"cards_per_player": 5}, "turn_structure": {"phases": [{"type": "peek", "target": "previous_player", "duration": 2}]}}`
+	loaded, err = LoadGenomeFromJSON([]byte(flat))
+	if err != nil {
+		t.Fatalf("Failed to load Python format: %v", err)
+	}
+	if pp := loaded.TurnStructure.Phases[0].(*PeekPhase); pp.Target != PeekTargetPrevious || pp.Duration != 2 {
+		t.Errorf("Python format PeekPhase mismatch: %+v", pp)
+	}
+}
+
+func TestDrawDiscardPhaseJSON(t *testing.T) {
+	original := &GameGenome{
+		Name: "Gin",
+		TurnStructure: TurnStructure{
+			Phases: []Phase{
+				&DrawDiscardPhase{Source: LocationDeck, Count: 1, TakeDiscard: true},
+			},
+		},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+
+	dp, ok := loaded.TurnStructure.Phases[0].(*DrawDiscardPhase)
+	if !ok {
+		t.Fatalf("Expected *DrawDiscardPhase, got %T", loaded.TurnStructure.Phases[0])
+	}
+	if dp.Source != LocationDeck || dp.Count != 1 || !dp.TakeDiscard {
+		t.Errorf("DrawDiscardPhase mismatch: %+v", dp)
+	}
+	if clone := loaded.Clone(); clone.TurnStructure.Phases[0] == loaded.TurnStructure.Phases[0] {
+		t.Error("Clone should deep copy DrawDiscardPhase")
+	}
+
+	// Python format keeps the fields on the phase itself
+	flat := `{"name": "Gin", "setup": {"cards_per_player": 10}, "turn_structure": {"phases": [{"type": "draw_discard", "source": "deck", "count": 2}]}}`
+	loaded, err = LoadGenomeFromJSON([]byte(flat))
+	if err != nil {
+		t.Fatalf("Failed to load Python format: %v", err)
+	}
+	if dp := loaded.TurnStructure.Phases[0].(*DrawDiscardPhase); dp.Source != LocationDeck || dp.Count != 2 || dp.TakeDiscard {
+		t.Errorf("Python format DrawDiscardPhase mismatch: %+v", dp)
+	}
+}
+
+// TestDrawDiscardPhaseMovegen checks the typed path offers only discards
+// once the player has drawn.
+func TestDrawDiscardPhaseMovegen(t *testing.T) {
+	g := &GameGenome{
+		TurnStructure: TurnStructure{
+			Phases: []Phase{
+				&DrawPhase{Source: LocationDeck, Count: 1, Mandatory: true},
+				&DrawDiscardPhase{Source: LocationDeck, Count: 1},
+			},
+		},
+	}
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.NumPlayers = 2
+	state.Deck = []engine.Card{{Rank: 0, Suit: 0}}
+	state.Players[0].Hand = []engine.Card{{Rank: 5, Suit: 1}, {Rank: 6, Suit: 2}}
+
+	if moves := GenerateLegalMovesTyped(state, g); len(moves) != 2 {
+		t.Fatalf("Expected a draw from each phase, got %v", moves)
+	}
+
+	state.DiscardPending = true
+	state.DiscardPhase = 1
+	moves := GenerateLegalMovesTyped(state, g)
+	if len(moves) != 2 {
+		t.Fatalf("Expected only the 2 discards, got %v", moves)
+	}
+	for _, m := range moves {
+		if m.PhaseIndex != 1 || m.TargetLoc != engine.LocationDiscard {
+			t.Errorf("Expected a discard in phase 1, got %+v", m)
+		}
+	}
+}
+
+func TestGivePhaseJSON(t *testing.T) {
+	original := &GameGenome{
+		Name: "Gifts",
+		TurnStructure: TurnStructure{
+			Phases: []Phase{
+				&GivePhase{Target: GiveTargetChosen, Mandatory: true},
+			},
+		},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+
+	gp, ok := loaded.TurnStructure.Phases[0].(*GivePhase)
+	if !ok {
+		t.Fatalf("Expected *GivePhase, got %T", loaded.TurnStructure.Phases[0])
+	}
+	if gp.Target != GiveTargetChosen || !gp.Mandatory {
+		t.Errorf("GivePhase mismatch: %+v", gp)
+	}
+	if clone := loaded.Clone(); clone.TurnStructure.Phases[0] == loaded.TurnStructure.Phases[0] {
+		t.Error("Clone should deep copy GivePhase")
+	}
+
+	// Python format keeps the fields on the phase itself
+	flat := `{"name": "Gifts", "setup": {"cards_per_player": 5}, "turn_structure": {"phases": [{"type": "give", "target": "previous_player"}]}}`
+	loaded, err = LoadGenomeFromJSON([]byte(flat))
+	if err != nil {
+		t.Fatalf("Failed to load Python format: %v", err)
+	}
+	if gp := loaded.TurnStructure.Phases[0].(*GivePhase); gp.Target != GiveTargetPrevious || gp.Mandatory {
+		t.Errorf("Python format GivePhase mismatch: %+v", gp)
+	}
+}
+
+func TestTradePhaseJSON(t *testing.T) {
+	original := &GameGenome{
+		Name: "Market",
+		TurnStructure: TurnStructure{
+			Phases: []Phase{
+				&TradePhase{Target: GiveTargetChosen, Price: 5},
+			},
+		},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+
+	tp, ok := loaded.TurnStructure.Phases[0].(*TradePhase)
+	if !ok {
+		t.Fatalf("Expected *TradePhase, got %T", loaded.TurnStructure.Phases[0])
+	}
+	if tp.Target != GiveTargetChosen || tp.Price != 5 {
+		t.Errorf("TradePhase mismatch: %+v", tp)
+	}
+	if clone := loaded.Clone(); clone.TurnStructure.Phases[0] == loaded.TurnStructure.Phases[0] {
+		t.Error("Clone should deep copy TradePhase")
+	}
+
+	// Python format keeps the fields on the phase itself
+	flat := `{"name": "Market", "setup": {"cards_per_player": 5}, "turn_structure": {"phases": [{"type": "trade", "target": "next_player"}]}}`
+	loaded, err = LoadGenomeFromJSON([]byte(flat))
+	if err != nil {
+		t.Fatalf("Failed to load Python format: %v", err)
+	}
+	if tp := loaded.TurnStructure.Phases[0].(*TradePhase); tp.Target != GiveTargetNext || tp.Price != 0 {
+		t.Errorf("Python format TradePhase mismatch: %+v", tp)
+	}
+}
+
+func TestMaxEffectsPerTurnJSON(t *testing.T) {
+	original := CreateCrazyEightsGenome()
+	original.TurnStructure.MaxEffectsPerTurn = 4
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.TurnStructure.MaxEffectsPerTurn != 4 {
+		t.Errorf("Expected max_effects_per_turn 4, got %d", loaded.TurnStructure.MaxEffectsPerTurn)
+	}
+}
+
+func TestPhaseRepeatJSONRoundTrip(t *testing.T) {
+	original := &GameGenome{
+		Name: "Repeats",
+		TurnStructure: TurnStructure{
+			Phases: []Phase{
+				&DrawPhase{Source: LocationDeck, Count: 1, Repeat: &PhaseRepeat{Count: 3}},
+				&PlayPhase{Target: LocationDiscard, MinCards: 1, MaxCards: 1, Mandatory: true,
+					Repeat: &PhaseRepeat{Until: &Condition{OpCode: 0, Operator: 0, Value: 0}}},
+				&PlayPhase{Target: LocationTableau, MinCards: 1, MaxCards: 1, RefillTo: 3},
+			},
+		},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+
+	draw := loaded.TurnStructure.Phases[0].(*DrawPhase)
+	if draw.Repeat == nil || draw.Repeat.Count != 3 || draw.Repeat.Until != nil {
+		t.Errorf("Draw repeat round-tripped as %+v", draw.Repeat)
+	}
+	play := loaded.TurnStructure.Phases[1].(*PlayPhase)
+	if play.Repeat == nil || play.Repeat.Until == nil || *play.Repeat.Until != *original.TurnStructure.Phases[1].(*PlayPhase).Repeat.Until {
+		t.Errorf("Play repeat round-tripped as %+v", play.Repeat)
+	}
+	if loaded.TurnStructure.Phases[2].(*PlayPhase).Repeat != nil {
+		t.Error("A phase without a repeat should load without one")
+	}
+	if refill := loaded.TurnStructure.Phases[2].(*PlayPhase).RefillTo; refill != 3 {
+		t.Errorf("Expected refill_to 3, got %d", refill)
+	}
+
+	// Clones don't share the repeat
+	clone := loaded.Clone()
+	clone.TurnStructure.Phases[1].(*PlayPhase).Repeat.Until.Value = 2
+	if play.Repeat.Until.Value != 0 {
+		t.Error("Clone shares the repeat condition with the original")
+	}
+}
+
+func TestLoadGenomeDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data []byte) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	war, err := SaveGenomeToJSON(CreateWarGenome())
+	if err != nil {
+		t.Fatal(err)
+	}
+	write("b_war.json", war)
+	// Genomes saved by an evolution run are wrapped with their fitness
+	hearts, err := SaveGenomeToJSON(CreateHeartsGenome())
+	if err != nil {
+		t.Fatal(err)
+	}
+	write("a_hearts.json", []byte(`{"genome": `+string(hearts)+`, "fitness": 0.5}`))
+	write("c_broken.json", []byte(`{"name": `))
+	write("d_empty.json", []byte(`{"name": "Empty"}`))
+	write("notes.txt", []byte("not a genome"))
+
+	genomes, skipped, err := LoadGenomeDir(dir)
+	if err != nil {
+		t.Fatalf("LoadGenomeDir failed: %v", err)
+	}
+	if len(genomes) != 2 || genomes[0].Name != "Hearts" || genomes[1].Name != "War" {
+		names := make([]string, len(genomes))
+		for i, g := range genomes {
+			names[i] = g.Name
+		}
+		t.Errorf("Expected Hearts and War in file order, got %v", names)
+	}
+	if len(skipped) != 2 || !strings.HasPrefix(skipped[0].Error(), "c_broken.json") || !strings.HasPrefix(skipped[1].Error(), "d_empty.json") {
+		t.Errorf("Expected the broken and invalid files to be skipped, got %v", skipped)
+	}
+
+	if _, _, err := LoadGenomeDir(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestDealerAndBlindsJSON(t *testing.T) {
+	original := CreateSimplePokerGenome()
+	original.Setup.RotateDealer = true
+	original.TurnStructure.Phases[0].(*BettingPhase).Blinds = 20
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if !loaded.Setup.RotateDealer {
+		t.Error("RotateDealer was lost")
+	}
+	if blinds := loaded.TurnStructure.Phases[0].(*BettingPhase).Blinds; blinds != 20 {
+		t.Errorf("Expected blinds of 20, got %d", blinds)
+	}
+
+	// Both are omitted when off
+	plain, err := SaveGenomeToJSON(CreateSimplePokerGenome())
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	if strings.Contains(string(plain), "rotate_dealer") || strings.Contains(string(plain), "blinds") {
+		t.Error("Expected dealer settings to be omitted by default")
+	}
+}
+
+func TestMaxTurnsRuleJSON(t *testing.T) {
+	for _, rule := range []MaxTurnsRule{MaxTurnsDraw, MaxTurnsHighScore, MaxTurnsMostCaptured} {
+		original := CreateWarGenome()
+		original.TurnStructure.MaxTurnsRule = rule
+
+		jsonBytes, err := SaveGenomeToJSON(original)
+		if err != nil {
+			t.Fatalf("Failed to serialize: %v", err)
+		}
+		loaded, err := LoadGenomeFromJSON(jsonBytes)
+		if err != nil {
+			t.Fatalf("Failed to deserialize: %v", err)
+		}
+		if loaded.TurnStructure.MaxTurnsRule != rule {
+			t.Errorf("Expected rule %d, got %d", rule, loaded.TurnStructure.MaxTurnsRule)
+		}
+		// The default draw is omitted
+		if rule == MaxTurnsDraw && strings.Contains(string(jsonBytes), "max_turns_rule") {
+			t.Error("Expected max_turns_rule to be omitted for a draw")
+		}
+	}
+}
+
+func TestWarTieRuleJSON(t *testing.T) {
+	for _, rule := range []WarTieRule{WarTieAlternate, WarTieFirstPlayed, WarTieCarryOver} {
+		original := CreateWarGenome()
+		original.TurnStructure.WarTieRule = rule
+
+		jsonBytes, err := SaveGenomeToJSON(original)
+		if err != nil {
+			t.Fatalf("Failed to serialize: %v", err)
+		}
+		loaded, err := LoadGenomeFromJSON(jsonBytes)
+		if err != nil {
+			t.Fatalf("Failed to deserialize: %v", err)
+		}
+		if loaded.TurnStructure.WarTieRule != rule {
+			t.Errorf("Expected rule %d, got %d", rule, loaded.TurnStructure.WarTieRule)
+		}
+	}
+}
+
+func TestTurnLimitJSON(t *testing.T) {
+	for _, limit := range []TurnLimit{TurnLimitTurns, TurnLimitActions, TurnLimitHands} {
+		original := CreateBettingWarGenome()
+		original.TurnStructure.TurnLimit = limit
+
+		jsonBytes, err := SaveGenomeToJSON(original)
+		if err != nil {
+			t.Fatalf("Failed to serialize: %v", err)
+		}
+		loaded, err := LoadGenomeFromJSON(jsonBytes)
+		if err != nil {
+			t.Fatalf("Failed to deserialize: %v", err)
+		}
+		if loaded.TurnStructure.TurnLimit != limit {
+			t.Errorf("Expected limit %d, got %d", limit, loaded.TurnStructure.TurnLimit)
+		}
+		// Counting turns is the default and is omitted
+		if limit == TurnLimitTurns && strings.Contains(string(jsonBytes), "turn_limit") {
+			t.Error("Expected turn_limit to be omitted when counting turns")
+		}
+	}
+}
+
+func TestRankValuesJSON(t *testing.T) {
+	original := CreateWarGenome()
+	if got := GetRankValue(original, engine.Card{Rank: engine.RankKing}); got != 10 {
+		t.Errorf("Expected a king to count 10 by default, got %d", got)
+	}
+	original.RankValues = []int32{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 15}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if got := GetRankValue(loaded, engine.Card{Rank: engine.RankAce}); got != 15 {
+		t.Errorf("Expected an ace to count 15, got %d", got)
+	}
+	clone := loaded.Clone()
+	clone.RankValues[0] = 99
+	if loaded.RankValues[0] != 2 {
+		t.Error("Clone should deep copy RankValues")
+	}
+
+	loaded.RankValues = loaded.RankValues[:5]
+	valid := true
+	for _, e := range ValidateGenome(loaded) {
+		if e.Field == "rank_values" {
+			valid = false
+		}
+	}
+	if valid {
+		t.Error("Expected a short rank value table to be rejected")
+	}
+}
+
+func TestWildRanksJSON(t *testing.T) {
+	original := CreateGinRummyGenome()
+	original.WildRanks = []int{0, 12}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if mask := WildRankMask(loaded); mask != 1|1<<12 {
+		t.Errorf("Expected twos and aces wild, got mask %b", mask)
+	}
+
+	loaded.WildRanks = append(loaded.WildRanks, 13)
+	valid := true
+	for _, e := range ValidateGenome(loaded) {
+		if e.Field == "wild_ranks" {
+			valid = false
+		}
+	}
+	if valid {
+		t.Error("Expected an out-of-range wild rank to be rejected")
+	}
+}
+
+func TestStuckRuleJSON(t *testing.T) {
+	original := CreateFanTanGenome()
+	original.TurnStructure.StuckRule = StuckPass
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	if !strings.Contains(string(jsonBytes), `"stuck_rule": "pass"`) {
+		t.Errorf("Expected stuck_rule in %s", jsonBytes)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.TurnStructure.StuckRule != StuckPass || loaded.Clone().TurnStructure.StuckRule != StuckPass {
+		t.Errorf("Expected the pass rule to survive, got %d", loaded.TurnStructure.StuckRule)
+	}
+}
+
+func TestBettingPhaseCommunityJSON(t *testing.T) {
+	original := &GameGenome{
+		Name: "Holdem",
+		TurnStructure: TurnStructure{
+			Phases: []Phase{
+				&BettingPhase{MinBet: 10, MaxRaises: 3, Community: []int{3, 1, 1}, MuckLosers: true},
+			},
+		},
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(original)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+
+	bp := loaded.TurnStructure.Phases[0].(*BettingPhase)
+	if !reflect.DeepEqual(bp.Community, []int{3, 1, 1}) {
+		t.Errorf("Expected streets [3 1 1], got %v", bp.Community)
+	}
+	if !bp.MuckLosers {
+		t.Error("Expected the losers' muck to survive the round trip")
+	}
+	clone := loaded.Clone().TurnStructure.Phases[0].(*BettingPhase)
+	clone.Community[0] = 4
+	if bp.Community[0] != 3 {
+		t.Error("Clone should deep copy the community streets")
+	}
+
+	// Python format keeps the fields on the phase itself
+	flat := `{"name": "Holdem", "setup": {"cards_per_player": 2}, "turn_structure": {"phases": [{"type": "betting", "min_bet": 10, "community": [3, 2]}]}}`
+	loaded, err = LoadGenomeFromJSON([]byte(flat))
+	if err != nil {
+		t.Fatalf("Failed to load Python format: %v", err)
+	}
+	if bp := loaded.TurnStructure.Phases[0].(*BettingPhase); !reflect.DeepEqual(bp.Community, []int{3, 2}) {
+		t.Errorf("Python format community mismatch: %v", bp.Community)
+	}
+}
+
+func TestTurnLimitFor(t *testing.T) {
+	// A set MaxTurns is kept whatever the game
+	g := CreateCrazyEightsGenome()
+	if got := TurnLimitFor(g, 2); got != 500 {
+		t.Errorf("Expected the genome's own limit of 500, got %d", got)
+	}
+
+	// Drawing brings the whole deck into play
+	g.TurnStructure.MaxTurns = 0
+	if got := TurnLimitFor(g, 2); got != 2*StandardDeckSize {
+		t.Errorf("Expected a deal of the whole deck to default to %d turns, got %d", 2*StandardDeckSize, got)
+	}
+
+	// Without draws only the dealt cards are played, over every round of
+	// shrinking hands: (7+6+...+1) cards to each of 4 players
+	g = CreateKnockoutWhistGenome()
+	g.TurnStructure.MaxTurns = 0
+	if got := TurnLimitFor(g, 4); got != 2*28*4 {
+		t.Errorf("Expected the knockout rounds to default to %d turns, got %d", 2*28*4, got)
+	}
+
+	// A hand limit defaults to twice the deals a match is budgeted
+	g.WinConditions = []WinCondition{{Type: WinTypeMatchWins, Threshold: 2}}
+	g.TurnStructure.TurnLimit = TurnLimitHands
+	if got := TurnLimitFor(g, 4); got != 16 {
+		t.Errorf("Expected a match to 2 wins at 4 players to default to 16 hands, got %d", got)
+	}
+}
@@ -13,7 +13,7 @@ func GenerateLegalMovesTyped(state *engine.GameState, genome *GameGenome) []engi
 	for phaseIdx, phase := range genome.TurnStructure.Phases {
 		switch p := phase.(type) {
 		case *DrawPhase:
-			moves = appendDrawMoves(moves, state, currentPlayer, phaseIdx, p)
+			moves = appendDrawMoves(moves, state, currentPlayer, phaseIdx, p, genome)
 
 		case *PlayPhase:
 			moves = appendPlayMoves(moves, state, currentPlayer, phaseIdx, p, genome)
@@ -28,19 +28,65 @@ func GenerateLegalMovesTyped(state *engine.GameState, genome *GameGenome) []engi
 			moves = appendBettingMoves(moves, state, currentPlayer, phaseIdx, p)
 
 		case *ClaimPhase:
-			moves = appendClaimMoves(moves, state, currentPlayer, phaseIdx)
+			moves = appendClaimMoves(moves, state, currentPlayer, phaseIdx, p)
 
 		case *BiddingPhase:
 			moves = appendBiddingMoves(moves, state, currentPlayer, phaseIdx, p)
+
+		case *ShowPhase:
+			moves = appendShowMoves(moves, state, phaseIdx)
+
+		case *PeekPhase:
+			moves = appendPeekMoves(moves, state, phaseIdx, p)
+
+		case *DrawDiscardPhase:
+			moves = engine.AppendDrawDiscardMoves(moves, state, phaseIdx, p.EngineData())
+
+		case *GivePhase:
+			moves = engine.AppendGiveMoves(moves, state, phaseIdx, p.EngineData())
+
+		case *TradePhase:
+			moves = engine.AppendTradeMoves(moves, state, phaseIdx, p.EngineData())
 		}
 	}
 
-	return moves
+	// Partway through a repeating phase, only that phase can continue
+	if state.PhaseRuns > 0 && state.RepeatPhase < len(genome.TurnStructure.Phases) {
+		if repeat := EngineRepeat(genome.TurnStructure.Phases[state.RepeatPhase]); repeat != nil {
+			moves = engine.RepeatMoves(moves, state.RepeatPhase, repeat)
+		}
+	}
+	// Between a draw-discard's draw and discard, only the discard is left
+	moves = engine.PendingDiscardMoves(state, moves)
+
+	return engine.CapMoves(state, moves, genome.TurnStructure.MaxLegalMoves)
+}
+
+// EngineRepeat returns the engine form of a phase's repeat, or nil if the
+// phase runs once per turn. Only a mandatory phase holds the player to it.
+func EngineRepeat(phase Phase) *engine.PhaseRepeat {
+	var repeat *PhaseRepeat
+	var mandatory bool
+	switch p := phase.(type) {
+	case *DrawPhase:
+		repeat, mandatory = p.Repeat, p.Mandatory
+	case *PlayPhase:
+		repeat, mandatory = p.Repeat, p.Mandatory
+	}
+	if repeat == nil {
+		return nil
+	}
+
+	result := &engine.PhaseRepeat{Count: repeat.Count, Optional: !mandatory}
+	if repeat.Until != nil {
+		result.Until = conditionBytes(repeat.Until)
+	}
+	return result
 }
 
 // appendDrawMoves adds legal draw moves for a DrawPhase.
 // Compare to movegen.go case 1 - this reads struct fields directly instead of phase.Data bytes.
-func appendDrawMoves(moves []engine.LegalMove, state *engine.GameState, currentPlayer uint8, phaseIdx int, p *DrawPhase) []engine.LegalMove {
+func appendDrawMoves(moves []engine.LegalMove, state *engine.GameState, currentPlayer uint8, phaseIdx int, p *DrawPhase, genome *GameGenome) []engine.LegalMove {
 	// Skip if player has already stood (blackjack)
 	if int(currentPlayer) < len(state.HasStood) && state.HasStood[currentPlayer] {
 		return moves
@@ -54,11 +100,20 @@ func appendDrawMoves(moves []engine.LegalMove, state *engine.GameState, currentP
 		}
 	}
 
+	// A draw past the turn or game limit is withdrawn
+	if !engine.DrawAllowed(state, p.MaxPerTurn, genome.Setup.MaxDrawsPerGame) {
+		return moves
+	}
+
 	// Check if can draw, with automatic deck reshuffling
 	canDraw := false
 	source := engine.Location(p.Source)
 	switch source {
 	case engine.LocationDeck:
+		if state.PersonalDecks {
+			canDraw = engine.CanDrawOwn(state, currentPlayer)
+			break
+		}
 		// If deck is empty but discard has cards, reshuffle would happen
 		if len(state.Deck) == 0 && len(state.Discard) > 1 {
 			// reshuffleDeck would be called - for now just check
@@ -66,6 +121,10 @@ func appendDrawMoves(moves []engine.LegalMove, state *engine.GameState, currentP
 		}
 		canDraw = canDraw || len(state.Deck) > 0
 	case engine.LocationDiscard:
+		if state.PersonalDecks {
+			canDraw = len(state.Players[currentPlayer].Discard) > 0
+			break
+		}
 		canDraw = len(state.Discard) > 0
 	case engine.LocationOpponentHand:
 		opponentID := (currentPlayer + 1) % state.NumPlayers
@@ -127,6 +186,12 @@ func appendPlayMoves(moves []engine.LegalMove, state *engine.GameState, currentP
 					continue
 				}
 			}
+			if target == engine.LocationPiles {
+				var added int
+				moves, added = engine.AppendPilePlays(moves, state, phaseIdx, cardIdx, card)
+				playMoveCount += added
+				continue
+			}
 			moves = append(moves, engine.LegalMove{
 				PhaseIndex: phaseIdx,
 				CardIndex:  cardIdx,
@@ -137,7 +202,7 @@ func appendPlayMoves(moves []engine.LegalMove, state *engine.GameState, currentP
 	}
 
 	// Multi-card plays (Go Fish sets)
-	if p.MinCards > 1 {
+	if p.MinCards > 1 && target != engine.LocationPiles {
 		rankCounts := make(map[uint8]int)
 		for _, card := range hand {
 			rankCounts[card.Rank]++
@@ -209,7 +274,7 @@ func appendSequenceMoves(moves []engine.LegalMove, state *engine.GameState, curr
 			for _, pile := range state.Tableau {
 				if len(pile) > 0 {
 					topCard := pile[len(pile)-1]
-					if isValidSequencePlayTyped(card, topCard, state.SequenceDirection) {
+					if isValidSequencePlayTyped(card, topCard, state.SequenceDirection, state.AceMode) {
 						canPlayOnExisting = true
 						break
 					}
@@ -240,12 +305,17 @@ func appendSequenceMoves(moves []engine.LegalMove, state *engine.GameState, curr
 }
 
 func appendDiscardMoves(moves []engine.LegalMove, state *engine.GameState, currentPlayer uint8, phaseIdx int, p *DiscardPhase) []engine.LegalMove {
+	// Cards go to the discard pile unless the phase burns them
+	target := engine.LocationDiscard
+	if p.Target == LocationBurned {
+		target = engine.LocationBurned
+	}
 	if len(state.Players[currentPlayer].Hand) > 0 {
 		for cardIdx := range state.Players[currentPlayer].Hand {
 			moves = append(moves, engine.LegalMove{
 				PhaseIndex: phaseIdx,
 				CardIndex:  cardIdx,
-				TargetLoc:  engine.LocationDiscard,
+				TargetLoc:  target,
 			})
 		}
 	}
@@ -260,6 +330,19 @@ func appendTrickMoves(moves []engine.LegalMove, state *engine.GameState, current
 
 	isLeading := len(state.CurrentTrick) == 0
 
+	if isLeading && p.MustLeadCard && p.OpeningLead == OpeningLeadCardHolder && state.FirstTrick() {
+		// The holder of the lead card opens the hand with it
+		for cardIdx, card := range hand {
+			if card.Rank == p.LeadCardRank && card.Suit == p.LeadCardSuit {
+				return append(moves, engine.LegalMove{
+					PhaseIndex: phaseIdx,
+					CardIndex:  cardIdx,
+					TargetLoc:  engine.LocationTableau,
+				})
+			}
+		}
+	}
+
 	if isLeading {
 		for cardIdx, card := range hand {
 			if p.BreakingSuit != 255 && card.Suit == p.BreakingSuit && !state.HeartsBroken {
@@ -281,50 +364,36 @@ func appendTrickMoves(moves []engine.LegalMove, state *engine.GameState, current
 			})
 		}
 	} else {
-		leadSuit := state.CurrentTrick[0].Card.Suit
-
-		if p.LeadSuitRequired {
-			hasLeadSuit := false
-			for _, card := range hand {
-				if card.Suit == leadSuit {
-					hasLeadSuit = true
-					break
-				}
-			}
-
-			if hasLeadSuit {
-				for cardIdx, card := range hand {
-					if card.Suit == leadSuit {
-						moves = append(moves, engine.LegalMove{
-							PhaseIndex: phaseIdx,
-							CardIndex:  cardIdx,
-							TargetLoc:  engine.LocationTableau,
-						})
-					}
-				}
-			} else {
-				for cardIdx := range hand {
-					moves = append(moves, engine.LegalMove{
-						PhaseIndex: phaseIdx,
-						CardIndex:  cardIdx,
-						TargetLoc:  engine.LocationTableau,
-					})
-				}
-			}
-		} else {
-			for cardIdx := range hand {
-				moves = append(moves, engine.LegalMove{
-					PhaseIndex: phaseIdx,
-					CardIndex:  cardIdx,
-					TargetLoc:  engine.LocationTableau,
-				})
-			}
+		for _, cardIdx := range trickRules(state, p).Follows(hand, state.CurrentTrick, state.AceMode) {
+			moves = append(moves, engine.LegalMove{
+				PhaseIndex: phaseIdx,
+				CardIndex:  cardIdx,
+				TargetLoc:  engine.LocationTableau,
+			})
 		}
 	}
 
 	return moves
 }
 
+// trickRules converts a typed TrickPhase to the engine's trick rules,
+// including any trump named in bidding.
+func trickRules(state *engine.GameState, p *TrickPhase) engine.TrickRules {
+	rules := engine.TrickRules{
+		LeadSuitRequired: p.LeadSuitRequired,
+		TrumpSuit:        p.TrumpSuit,
+		HighCardWins:     p.HighCardWins,
+		BreakingSuit:     p.BreakingSuit,
+		TieRule:          uint8(p.TieRule),
+		Constraints:      p.Constraints(),
+		NextLeader:       uint8(p.NextLeader),
+	}
+	if state.TrumpNamed {
+		rules.TrumpSuit = state.Trump
+	}
+	return rules
+}
+
 func appendBettingMoves(moves []engine.LegalMove, state *engine.GameState, currentPlayer uint8, phaseIdx int, p *BettingPhase) []engine.LegalMove {
 	if state.BettingComplete {
 		return moves
@@ -343,8 +412,9 @@ func appendBettingMoves(moves []engine.LegalMove, state *engine.GameState, curre
 
 	// Convert typed BettingPhase to engine.BettingPhaseData for compatibility
 	bettingData := &engine.BettingPhaseData{
-		MinBet:    p.MinBet,
-		MaxRaises: p.MaxRaises,
+		MinBet:     p.MinBet,
+		MaxRaises:  p.MaxRaises,
+		MinPlayers: p.MinPlayers,
 	}
 
 	bettingMoves := engine.GenerateBettingMoves(state, bettingData, int(currentPlayer))
@@ -360,18 +430,9 @@ func appendBettingMoves(moves []engine.LegalMove, state *engine.GameState, curre
 	return moves
 }
 
-func appendClaimMoves(moves []engine.LegalMove, state *engine.GameState, currentPlayer uint8, phaseIdx int) []engine.LegalMove {
+func appendClaimMoves(moves []engine.LegalMove, state *engine.GameState, currentPlayer uint8, phaseIdx int, p *ClaimPhase) []engine.LegalMove {
 	if state.CurrentClaim == nil {
-		hand := state.Players[currentPlayer].Hand
-		if len(hand) > 0 {
-			for cardIdx := range hand {
-				moves = append(moves, engine.LegalMove{
-					PhaseIndex: phaseIdx,
-					CardIndex:  cardIdx,
-					TargetLoc:  engine.LocationDiscard,
-				})
-			}
-		}
+		moves = engine.AppendClaims(moves, state, phaseIdx, uint8(p.RankRule))
 	} else {
 		if currentPlayer != state.CurrentClaim.ClaimerID {
 			moves = append(moves, engine.LegalMove{
@@ -400,9 +461,10 @@ func appendBiddingMoves(moves []engine.LegalMove, state *engine.GameState, curre
 
 	// Convert to engine.BiddingPhase for compatibility
 	enginePhase := engine.BiddingPhase{
-		MinBid:   p.MinBid,
-		MaxBid:   p.MaxBid,
-		AllowNil: p.AllowNil,
+		MinBid:       p.MinBid,
+		MaxBid:       p.MaxBid,
+		AllowNil:     p.AllowNil,
+		DeclareTrump: p.DeclareTrump,
 	}
 
 	handSize := len(state.Players[currentPlayer].Hand)
@@ -413,6 +475,8 @@ func appendBiddingMoves(moves []engine.LegalMove, state *engine.GameState, curre
 		targetLoc := engine.LocationDeck
 		if bid.IsNil {
 			targetLoc = engine.LocationDiscard
+		} else if bid.NamesTrump {
+			targetLoc = engine.LocationTrumpBid + engine.Location(bid.Trump)
 		}
 		moves = append(moves, engine.LegalMove{
 			PhaseIndex: phaseIdx,
@@ -424,6 +488,158 @@ func appendBiddingMoves(moves []engine.LegalMove, state *engine.GameState, curre
 	return moves
 }
 
+// appendShowMoves adds the reveal move once every player still in the hand holds cards.
+func appendShowMoves(moves []engine.LegalMove, state *engine.GameState, phaseIdx int) []engine.LegalMove {
+	if !engine.ShowDue(state) {
+		return moves
+	}
+	return append(moves, engine.LegalMove{
+		PhaseIndex: phaseIdx,
+		CardIndex:  engine.MoveShow,
+		TargetLoc:  engine.LocationDeck,
+	})
+}
+
+// Constraints returns the engine's trick constraint flags for the phase.
+func (p *TrickPhase) Constraints() uint8 {
+	var flags uint8
+	if p.MustBeat {
+		flags |= engine.TrickMustBeat
+	}
+	if p.MustOvertrump {
+		flags |= engine.TrickMustOvertrump
+	}
+	switch p.TrumpDiscipline {
+	case TrumpOnlyWhenVoid:
+		flags |= engine.TrickTrumpOnlyWhenVoid
+	case TrumpMustWhenVoid:
+		flags |= engine.TrickMustTrump
+	}
+	return flags
+}
+
+// EngineData returns the engine form of the phase.
+func (p *DrawDiscardPhase) EngineData() *engine.DrawDiscardPhaseData {
+	return &engine.DrawDiscardPhaseData{
+		Source:      engine.Location(p.Source),
+		Count:       p.Count,
+		TakeDiscard: p.TakeDiscard,
+	}
+}
+
+// EngineData returns the engine form of the phase.
+func (p *GivePhase) EngineData() *engine.GivePhaseData {
+	return &engine.GivePhaseData{Target: uint8(p.Target), Mandatory: p.Mandatory}
+}
+
+// EngineData returns the engine form of the phase.
+func (p *TradePhase) EngineData() *engine.TradePhaseData {
+	return &engine.TradePhaseData{Target: uint8(p.Target), Price: int32(p.Price)}
+}
+
+// WildRankMask returns g's wild ranks as the engine's mask, bit r set when
+// rank r is wild (see engine.IsWildRank).
+func WildRankMask(g *GameGenome) uint16 {
+	var mask uint16
+	for _, r := range g.WildRanks {
+		if r >= 0 && r < 13 {
+			mask |= 1 << r
+		}
+	}
+	return mask
+}
+
+// GetRankValue returns the points card counts for under g's rank values.
+func GetRankValue(g *GameGenome, card engine.Card) int32 {
+	return engine.RankPoints(g.RankValues, card.Rank)
+}
+
+// TurnLimitFor returns the limit g is played to at a table of numPlayers,
+// in the unit its TurnLimit counts: MaxTurns when set, otherwise a default
+// sized to the game. Turns and actions default to DefaultMaxTurns; hands
+// default to twice the deals engine.ExpectedDeals budgets, or
+// engine.FallbackMaxTurns when those can't be counted.
+func TurnLimitFor(g *GameGenome, numPlayers int) int {
+	if g.TurnStructure.MaxTurns > 0 {
+		return g.TurnStructure.MaxTurns
+	}
+	if g.TurnStructure.TurnLimit == TurnLimitHands {
+		deals := engine.ExpectedDeals(numPlayers, engineWinConditions(g))
+		if deals == 0 {
+			return engine.FallbackMaxTurns
+		}
+		return 2 * deals
+	}
+	return DefaultMaxTurns(g, numPlayers)
+}
+
+// DefaultMaxTurns returns the turns g is played to at a table of numPlayers
+// when it sets no MaxTurns: engine.DefaultMaxTurns for the cards g brings
+// into play and its win conditions.
+func DefaultMaxTurns(g *GameGenome, numPlayers int) int {
+	return int(engine.DefaultMaxTurns(cardsInPlay(g, numPlayers), numPlayers, engineWinConditions(g)))
+}
+
+// engineWinConditions converts g's win conditions to the engine's.
+func engineWinConditions(g *GameGenome) []engine.WinCondition {
+	wins := make([]engine.WinCondition, len(g.WinConditions))
+	for i, wc := range g.WinConditions {
+		wins[i] = engine.WinCondition{WinType: uint8(wc.Type), Threshold: wc.Threshold, Margin: wc.Margin}
+	}
+	return wins
+}
+
+// cardsInPlay estimates the cards g plays with at a table of numPlayers:
+// the whole deck, or every player's personal deck, when a phase draws,
+// otherwise only the cards dealt, summed over every round of a knockout
+// game whose hands shrink each round.
+func cardsInPlay(g *GameGenome, numPlayers int) int {
+	deck := StandardDeckSize
+	if g.Setup.PersonalDecks {
+		size := g.Setup.PersonalDeckSize
+		if size <= 0 {
+			size = StandardDeckSize
+		}
+		deck = numPlayers * size
+	}
+	for _, phase := range g.TurnStructure.Phases {
+		switch phase.(type) {
+		case *DrawPhase, *DrawDiscardPhase:
+			return deck
+		}
+	}
+
+	hand := g.Setup.CardsPerPlayer
+	if hand <= 0 {
+		hand = 26 // Default for War
+	}
+	total := 0
+	for hand > 0 {
+		dealt := hand*numPlayers + g.Setup.DealToTableau
+		if dealt > deck {
+			dealt = deck
+		}
+		total += dealt
+		if g.Setup.HandShrink <= 0 {
+			break
+		}
+		hand -= g.Setup.HandShrink
+	}
+	return total
+}
+
+// appendPeekMoves adds the peek move while the target has a hand the current player hasn't seen.
+func appendPeekMoves(moves []engine.LegalMove, state *engine.GameState, phaseIdx int, p *PeekPhase) []engine.LegalMove {
+	if !engine.PeekDue(state, uint8(p.Target)) {
+		return moves
+	}
+	return append(moves, engine.LegalMove{
+		PhaseIndex: phaseIdx,
+		CardIndex:  engine.MovePeek,
+		TargetLoc:  engine.LocationHand,
+	})
+}
+
 // evaluateConditionTyped evaluates a condition using typed struct instead of bytes.
 func evaluateConditionTyped(state *engine.GameState, playerID uint8, cond *Condition) bool {
 	if cond == nil {
@@ -432,17 +648,21 @@ func evaluateConditionTyped(state *engine.GameState, playerID uint8, cond *Condi
 
 	// Build condition bytes for existing EvaluateCondition function
 	// This is a temporary bridge during the transition
+	return engine.EvaluateCondition(state, playerID, conditionBytes(cond))
+}
+
+// conditionBytes packs a typed condition into the 7-byte layout read by
+// the engine: opcode:1, operator:1, value:4 (big-endian), ref:1.
+func conditionBytes(cond *Condition) []byte {
 	condBytes := make([]byte, 7)
 	condBytes[0] = cond.OpCode
 	condBytes[1] = cond.Operator
-	// Value as 4 bytes big-endian
 	condBytes[2] = byte(cond.Value >> 24)
 	condBytes[3] = byte(cond.Value >> 16)
 	condBytes[4] = byte(cond.Value >> 8)
 	condBytes[5] = byte(cond.Value)
 	condBytes[6] = cond.RefLoc
-
-	return engine.EvaluateCondition(state, playerID, condBytes)
+	return condBytes
 }
 
 // evaluateCardConditionTyped evaluates a card condition using typed struct.
@@ -451,39 +671,24 @@ func evaluateCardConditionTyped(state *engine.GameState, playerID uint8, card en
 		return true
 	}
 
-	condBytes := make([]byte, 7)
-	condBytes[0] = cond.OpCode
-	condBytes[1] = cond.Operator
-	condBytes[2] = byte(cond.Value >> 24)
-	condBytes[3] = byte(cond.Value >> 16)
-	condBytes[4] = byte(cond.Value >> 8)
-	condBytes[5] = byte(cond.Value)
-	condBytes[6] = cond.RefLoc
-
-	return engine.EvaluateCardCondition(state, playerID, card, condBytes)
+	return engine.EvaluateCardCondition(state, playerID, card, conditionBytes(cond))
 }
 
 // isValidSequencePlayTyped checks sequence validity using typed direction.
-func isValidSequencePlayTyped(card engine.Card, topCard engine.Card, direction uint8) bool {
+// Sequences never wrap; the ace mode decides whether A follows K or precedes 2.
+func isValidSequencePlayTyped(card engine.Card, topCard engine.Card, direction uint8, aceMode engine.AceMode) bool {
 	if card.Suit != topCard.Suit {
 		return false
 	}
 
 	switch direction {
 	case 0: // ASCENDING
-		if topCard.Rank == 13 {
-			return false
-		}
-		return card.Rank == topCard.Rank+1
+		return engine.RanksAdjacent(topCard.Rank, card.Rank, aceMode)
 	case 1: // DESCENDING
-		if topCard.Rank == 2 {
-			return false
-		}
-		return card.Rank == topCard.Rank-1
+		return engine.RanksAdjacent(card.Rank, topCard.Rank, aceMode)
 	case 2: // BOTH
-		canAscend := topCard.Rank != 13 && card.Rank == topCard.Rank+1
-		canDescend := topCard.Rank != 2 && card.Rank == topCard.Rank-1
-		return canAscend || canDescend
+		return engine.RanksAdjacent(topCard.Rank, card.Rank, aceMode) ||
+			engine.RanksAdjacent(card.Rank, topCard.Rank, aceMode)
 	}
 	return false
 }
@@ -0,0 +1,158 @@
+package genome
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// RuleOverlay is a set of house rules applied over a genome when it is
+// loaded, so a rule variant can be tested against its base game without
+// editing the genome. Fields left unset keep the genome's own rule. The
+// JSON names and values are the genome's own.
+type RuleOverlay struct {
+	Name string `json:"name,omitempty"` // Appended to the genome's name, e.g. "no jump-in"
+
+	// Reshuffle policy and dealing
+	Penetration  *float64 `json:"penetration,omitempty"`   // Fraction of a shoe dealt before reshuffling in the counting evaluation (0 = no shoe play)
+	DealStrategy *string  `json:"deal_strategy,omitempty"` // "pre_dealt" or "shoe_draw"
+	RotateDealer *bool    `json:"rotate_dealer,omitempty"`
+
+	// Starting discard
+	StartCardEffect *bool `json:"start_card_effect,omitempty"` // The card turned up to start the discard pile acts on the first player
+
+	// Tie-breaks
+	MaxTurnsRule *string `json:"max_turns_rule,omitempty"` // How a game that reaches the turn limit is decided
+	TrickTieRule *string `json:"tie_rule,omitempty"`       // Which of two identical cards takes a trick, in every trick phase
+	StuckRule    *string `json:"stuck_rule,omitempty"`     // "end" or "pass" when the player to move has no legal move
+
+	// Optional mechanics
+	JumpIn            *string `json:"jump_in,omitempty"`   // "none", "rank" or "identical"
+	RefillTo          *int    `json:"refill_to,omitempty"` // Hand size every play phase draws back up to (0 = no refill)
+	MaxTurns          *int    `json:"max_turns,omitempty"`
+	TurnLimit         *string `json:"turn_limit,omitempty"` // What max_turns counts: "turns", "actions" or "hands"
+	MaxEffectsPerTurn *int    `json:"max_effects_per_turn,omitempty"`
+}
+
+// ParseRuleOverlay parses an overlay from JSON. Unknown fields are an
+// error, so a misspelt or unsupported rule isn't silently ignored.
+func ParseRuleOverlay(data []byte) (*RuleOverlay, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var o RuleOverlay
+	if err := dec.Decode(&o); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// LoadRuleOverlayFile reads an overlay from a JSON file.
+func LoadRuleOverlayFile(path string) (*RuleOverlay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	o, err := ParseRuleOverlay(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return o, nil
+}
+
+// Apply returns a copy of g with the overlay's rules in place; g itself is
+// left unchanged. It fails on a value the genome format doesn't know.
+func (o *RuleOverlay) Apply(g *GameGenome) (*GameGenome, error) {
+	out := g.Clone()
+	if o.Name != "" {
+		out.Name = fmt.Sprintf("%s (%s)", g.Name, o.Name)
+	}
+
+	if o.Penetration != nil {
+		if *o.Penetration < 0 || *o.Penetration >= 1 {
+			return nil, fmt.Errorf("penetration %v is not in [0, 1)", *o.Penetration)
+		}
+		out.Setup.Penetration = *o.Penetration
+	}
+	if o.DealStrategy != nil {
+		strategy := parseDealStrategy(*o.DealStrategy)
+		if err := checkOverlayValue("deal_strategy", *o.DealStrategy, dealStrategyToString(strategy)); err != nil {
+			return nil, err
+		}
+		out.Setup.DealStrategy = strategy
+	}
+	if o.RotateDealer != nil {
+		out.Setup.RotateDealer = *o.RotateDealer
+	}
+	if o.StartCardEffect != nil {
+		out.Setup.StartCardEffect = *o.StartCardEffect
+	}
+
+	if o.MaxTurnsRule != nil {
+		rule := parseMaxTurnsRule(*o.MaxTurnsRule)
+		if err := checkOverlayValue("max_turns_rule", *o.MaxTurnsRule, maxTurnsRuleToString(rule)); err != nil {
+			return nil, err
+		}
+		out.TurnStructure.MaxTurnsRule = rule
+	}
+	if o.StuckRule != nil {
+		rule := parseStuckRule(*o.StuckRule)
+		if err := checkOverlayValue("stuck_rule", *o.StuckRule, stuckRuleToString(rule)); err != nil {
+			return nil, err
+		}
+		out.TurnStructure.StuckRule = rule
+	}
+	if o.TrickTieRule != nil {
+		rule := parseTrickTieRule(*o.TrickTieRule)
+		if err := checkOverlayValue("tie_rule", *o.TrickTieRule, trickTieRuleToString(rule)); err != nil {
+			return nil, err
+		}
+		for _, phase := range out.TurnStructure.Phases {
+			if trick, ok := phase.(*TrickPhase); ok {
+				trick.TieRule = rule
+			}
+		}
+	}
+
+	if o.JumpIn != nil {
+		rule := parseJumpInRule(*o.JumpIn)
+		if err := checkOverlayValue("jump_in", *o.JumpIn, jumpInRuleToString(rule)); err != nil {
+			return nil, err
+		}
+		out.TurnStructure.JumpIn = rule
+	}
+	if o.RefillTo != nil {
+		if *o.RefillTo < 0 {
+			return nil, fmt.Errorf("refill_to %d is negative", *o.RefillTo)
+		}
+		for _, phase := range out.TurnStructure.Phases {
+			if play, ok := phase.(*PlayPhase); ok {
+				play.RefillTo = *o.RefillTo
+			}
+		}
+	}
+	if o.MaxTurns != nil {
+		out.TurnStructure.MaxTurns = *o.MaxTurns
+	}
+	if o.TurnLimit != nil {
+		limit := parseTurnLimit(*o.TurnLimit)
+		if err := checkOverlayValue("turn_limit", *o.TurnLimit, turnLimitToString(limit)); err != nil {
+			return nil, err
+		}
+		out.TurnStructure.TurnLimit = limit
+	}
+	if o.MaxEffectsPerTurn != nil {
+		out.TurnStructure.MaxEffectsPerTurn = *o.MaxEffectsPerTurn
+	}
+	return out, nil
+}
+
+// checkOverlayValue reports an overlay value that parsed to a different
+// rule than it names, which is how the genome parsers treat unknown values.
+func checkOverlayValue(field, value, parsed string) error {
+	if strings.ToLower(value) != parsed {
+		return fmt.Errorf("%s: unknown value %q", field, value)
+	}
+	return nil
+}
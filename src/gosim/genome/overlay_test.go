@@ -0,0 +1,94 @@
+package genome
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRuleOverlayApply(t *testing.T) {
+	base := CreateHeartsGenome()
+	overlay, err := ParseRuleOverlay([]byte(`{
+		"name": "house rules",
+		"penetration": 0.5,
+		"tie_rule": "last",
+		"jump_in": "rank",
+		"max_turns_rule": "high_score",
+		"stuck_rule": "pass"
+	}`))
+	if err != nil {
+		t.Fatalf("ParseRuleOverlay failed: %v", err)
+	}
+
+	variant, err := overlay.Apply(base)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if variant.Name != base.Name+" (house rules)" {
+		t.Errorf("Unexpected variant name %q", variant.Name)
+	}
+	if variant.Setup.Penetration != 0.5 || variant.TurnStructure.JumpIn != JumpInRank || variant.TurnStructure.MaxTurnsRule != MaxTurnsHighScore || variant.TurnStructure.StuckRule != StuckPass {
+		t.Errorf("Overlay rules not applied: setup %+v, jump-in %v, max turns rule %v",
+			variant.Setup, variant.TurnStructure.JumpIn, variant.TurnStructure.MaxTurnsRule)
+	}
+	for _, phase := range variant.TurnStructure.Phases {
+		if trick, ok := phase.(*TrickPhase); ok && trick.TieRule != TrickTieLastPlayed {
+			t.Errorf("Expected every trick phase to use the overlay's tie rule, got %v", trick.TieRule)
+		}
+	}
+
+	// Unset fields keep the genome's rules, and the base is untouched
+	if variant.Setup.CardsPerPlayer != base.Setup.CardsPerPlayer || variant.TurnStructure.MaxTurns != base.TurnStructure.MaxTurns {
+		t.Error("Expected rules the overlay doesn't set to be kept")
+	}
+	if base.TurnStructure.JumpIn != JumpInNone || base.Setup.Penetration != 0 {
+		t.Error("Expected Apply to leave the base genome unchanged")
+	}
+	for _, phase := range base.TurnStructure.Phases {
+		if trick, ok := phase.(*TrickPhase); ok && trick.TieRule != TrickTieFirstPlayed {
+			t.Error("Expected Apply to leave the base genome's trick phases unchanged")
+		}
+	}
+}
+
+func TestRuleOverlayRejectsUnknownRules(t *testing.T) {
+	// A mechanic the genome format doesn't have is an error, not ignored
+	if _, err := ParseRuleOverlay([]byte(`{"stacking": true}`)); err == nil {
+		t.Error("Expected an unknown overlay field to be rejected")
+	}
+
+	for _, data := range []string{`{"jump_in": "sometimes"}`, `{"penetration": 1.5}`, `{"refill_to": -1}`, `{"turn_limit": "forever"}`} {
+		overlay, err := ParseRuleOverlay([]byte(data))
+		if err != nil {
+			t.Fatalf("ParseRuleOverlay(%s) failed: %v", data, err)
+		}
+		if _, err := overlay.Apply(CreateCrazyEightsGenome()); err == nil {
+			t.Errorf("Expected %s to be rejected", data)
+		} else if strings.Contains(data, "jump_in") && !strings.Contains(err.Error(), "jump_in") {
+			t.Errorf("Expected the error to name the field, got %v", err)
+		}
+	}
+}
+
+func TestRuleOverlayStartCardEffect(t *testing.T) {
+	overlay, err := ParseRuleOverlay([]byte(`{"name": "first card counts", "start_card_effect": true}`))
+	if err != nil {
+		t.Fatalf("ParseRuleOverlay failed: %v", err)
+	}
+	variant, err := overlay.Apply(CreateUnoStyleGenome())
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	// The rule survives a save and load
+	data, err := SaveGenomeToJSON(variant)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if !loaded.Setup.StartCardEffect {
+		t.Error("Expected the starting card to take effect")
+	}
+}
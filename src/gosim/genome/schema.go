@@ -19,6 +19,12 @@ const (
 	PhaseTypeBetting uint8 = 5
 	PhaseTypeClaim   uint8 = 6
 	PhaseTypeBidding uint8 = 7
+	PhaseTypeShow    uint8 = 8
+	PhaseTypePeek    uint8 = 9
+
+	PhaseTypeDrawDiscard uint8 = 10
+	PhaseTypeGive        uint8 = 11
+	PhaseTypeTrade       uint8 = 12
 )
 
 // Location constants for card sources/targets
@@ -31,23 +37,53 @@ const (
 	LocationTableau      Location = 3
 	LocationOpponentHand Location = 4
 	LocationCaptured     Location = 5
+	LocationBurned       Location = 6 // Out of play for the rest of the game; never reshuffled
+	LocationExposed      Location = 7 // The player's own face-up area, seen by all; scored by TriggerExposed
+	LocationPiles        Location = 8 // Whichever of the genome's DiscardPiles takes the card
 )
 
 // Condition represents a condition that must be met for a phase to execute.
 // nil Condition means the phase always executes.
 type Condition struct {
 	OpCode   uint8  // Condition type (OpCheckHandSize, etc.)
-	Operator uint8  // Comparison operator (OpEQ, OpLT, etc.)
+	Operator uint8  // Comparison operator, offset from OpEQ (0=eq, 1=ne, 2=lt, 3=gt, 4=le, 5=ge)
 	Value    int32  // Value to compare against
 	RefLoc   uint8  // Reference location for some conditions
 }
 
+// PhaseRepeat lets a draw or play phase run several times in one turn.
+// The phase repeats until it has run Count times, Until holds for the
+// player, the player can't go again, or the engine's per-turn cap is hit.
+// A phase that isn't mandatory also lets the player stop at any point.
+type PhaseRepeat struct {
+	Count int        // Most runs per turn (0 = no fixed count)
+	Until *Condition // Stop once this holds (nil = no condition)
+}
+
+// Clone returns a deep copy of r (nil stays nil).
+func (r *PhaseRepeat) Clone() *PhaseRepeat {
+	if r == nil {
+		return nil
+	}
+	cp := *r
+	if r.Until != nil {
+		until := *r.Until
+		cp.Until = &until
+	}
+	return &cp
+}
+
 // DrawPhase represents drawing cards from a source.
 type DrawPhase struct {
-	Source    Location   // Where to draw from (deck, discard, opponent hand)
-	Count     int        // Number of cards to draw
-	Mandatory bool       // If false, player can choose to pass
-	Condition *Condition // Optional condition for this phase
+	Source    Location     // Where to draw from (deck, discard, opponent hand)
+	Count     int          // Number of cards to draw
+	Mandatory bool         // If false, player can choose to pass
+	Condition *Condition   // Optional condition for this phase
+	Repeat    *PhaseRepeat // Run again within the turn (nil = once)
+
+	// Draws a player may take in a turn, counting every draw phase, before
+	// this phase stops offering one (0 = unlimited)
+	MaxPerTurn int
 }
 
 func (p *DrawPhase) PhaseType() uint8 { return PhaseTypeDraw }
@@ -61,6 +97,8 @@ type PlayPhase struct {
 	Mandatory         bool       // If true, must play if able
 	PassIfUnable      bool       // If true, can pass when no valid plays
 	ValidPlayCondition *Condition // Optional condition cards must satisfy
+	Repeat            *PhaseRepeat // Run again within the turn (nil = once)
+	RefillTo          int          // Draw back up to this hand size when the phase ends (0 = no refill)
 }
 
 func (p *PlayPhase) PhaseType() uint8 { return PhaseTypePlay }
@@ -71,35 +109,156 @@ type DiscardPhase struct {
 	Target    Location // Where to discard (usually LocationDiscard)
 	Count     int      // Number of cards to discard
 	Mandatory bool     // If true, must discard
+	FaceDown  bool     // Discards are hidden from the other players (see engine.ConcealedDiscard)
 }
 
 func (p *DiscardPhase) PhaseType() uint8 { return PhaseTypeDiscard }
 func (p *DiscardPhase) phaseMarker()     {}
 
+// DrawDiscardPhase is a Gin Rummy turn: the player draws, then discards
+// one card, and the turn passes only after the discard. Separate draw and
+// discard phases would pass the turn between the two.
+type DrawDiscardPhase struct {
+	Source      Location // Pile drawn from (usually LocationDeck)
+	Count       int      // Cards drawn from Source (0 = 1)
+	TakeDiscard bool     // May take the top discard instead of drawing from Source
+}
+
+func (p *DrawDiscardPhase) PhaseType() uint8 { return PhaseTypeDrawDiscard }
+func (p *DrawDiscardPhase) phaseMarker()     {}
+
+// GiveTarget selects who a GivePhase's card may go to (matching
+// engine.TARGET_* constants).
+type GiveTarget uint8
+
+const (
+	GiveTargetNext     GiveTarget = 0 // The next player
+	GiveTargetPrevious GiveTarget = 1 // The previous player
+	GiveTargetChosen   GiveTarget = 4 // Any opponent, chosen by the giver
+)
+
+// GivePhase has the current player hand one card from their hand to an
+// opponent, as in Old Maid passing or gift mechanics.
+type GivePhase struct {
+	Target    GiveTarget // Who may receive the card
+	Mandatory bool       // If false, the player may keep their cards
+}
+
+func (p *GivePhase) PhaseType() uint8 { return PhaseTypeGive }
+func (p *GivePhase) phaseMarker()     {}
+
+// TradePhase has the current player offer one card from their hand to an
+// opponent, who accepts or declines. An accepted offer is paid for with a
+// card of the opponent's choosing, or with Price chips if Price is set.
+type TradePhase struct {
+	Target GiveTarget // Who offers may be made to
+	Price  int        // Chips paid for the card; 0 trades card for card
+}
+
+func (p *TradePhase) PhaseType() uint8 { return PhaseTypeTrade }
+func (p *TradePhase) phaseMarker()     {}
+
 // TrickPhase represents trick-taking mechanics.
 type TrickPhase struct {
-	LeadSuitRequired bool  // If true, must follow suit if able
-	TrumpSuit        uint8 // Trump suit (255 = none)
-	HighCardWins     bool  // If true, highest card wins; if false, lowest wins
-	BreakingSuit     uint8 // Suit that must be "broken" before leading (255 = none)
+	LeadSuitRequired bool         // If true, must follow suit if able
+	TrumpSuit        uint8        // Trump suit (255 = none)
+	HighCardWins     bool         // If true, highest card wins; if false, lowest wins
+	BreakingSuit     uint8        // Suit that must be "broken" before leading (255 = none)
+	TieRule          TrickTieRule // Which of two identical winning cards takes the trick
+	OpeningLead      OpeningLead  // Who leads the first trick of each hand
+	LeadCardRank     uint8        // Card whose holder leads, with OpeningLeadCardHolder
+	LeadCardSuit     uint8
+	MustLeadCard     bool         // With OpeningLeadCardHolder, the first trick must be led with the lead card
+	MustBeat         bool         // Must beat the winning card when able
+	MustOvertrump    bool         // When void in the lead suit, must trump, over any trump already played
+	NextLeader       TrickLead    // Who leads each trick after the first
+
+	// When a player may, or must, trump a trick
+	TrumpDiscipline TrumpDiscipline
 }
 
 func (p *TrickPhase) PhaseType() uint8 { return PhaseTypeTrick }
 func (p *TrickPhase) phaseMarker()     {}
 
+// TrickTieRule decides a trick when two identical cards (from duplicate
+// decks or jokers) would both win it.
+type TrickTieRule uint8
+
+const (
+	TrickTieFirstPlayed TrickTieRule = 0 // The earlier card keeps the trick (Pinochle)
+	TrickTieLastPlayed  TrickTieRule = 1 // The later card takes the trick
+)
+
+// OpeningLead decides who leads the first trick of a hand. Later tricks are
+// led as the phase's NextLeader says.
+type OpeningLead uint8
+
+const (
+	OpeningLeadDealerLeft OpeningLead = 0 // The player after the dealer
+	OpeningLeadDealer     OpeningLead = 1 // The dealer
+	OpeningLeadCardHolder OpeningLead = 2 // Whoever holds the lead card (the 2 of clubs in Hearts)
+)
+
+// TrickLead decides who leads the next trick once one is taken.
+type TrickLead uint8
+
+const (
+	TrickLeadWinner   TrickLead = 0 // The trick's winner leads, as in most trick games
+	TrickLeadRotate   TrickLead = 1 // The lead passes one seat on each trick, whoever won
+	TrickLeadHighCard TrickLead = 2 // Whoever played the highest-ranked card leads, whatever its suit
+)
+
+// TrumpDiscipline decides when a player may trump a trick led in another
+// suit. It matters most without LeadSuitRequired, where a player holding the
+// lead suit could otherwise trump at will.
+type TrumpDiscipline uint8
+
+const (
+	TrumpFree         TrumpDiscipline = 0 // Trump whenever the follow rules allow
+	TrumpOnlyWhenVoid TrumpDiscipline = 1 // Trump only when void in the lead suit
+	TrumpMustWhenVoid TrumpDiscipline = 2 // When void in the lead suit, must trump if able
+)
+
 // BettingPhase represents poker-style betting rounds.
 type BettingPhase struct {
-	MinBet    int // Minimum bet/raise amount
-	MaxRaises int // Maximum raises per round (prevents infinite loops)
+	MinBet     int // Minimum bet/raise amount
+	MaxRaises  int // Maximum raises per round (prevents infinite loops)
+	MinPlayers int // Players who must remain in the hand for the round to continue (0 = 2)
+	Blinds     int // Big blind posted after the dealer each hand; the small blind is half (0 = none)
+
+	// Community cards dealt face up before each later betting round of a
+	// hand, e.g. [3, 1, 1] for Hold'em's flop, turn and river; nil is a
+	// single round on private hands. Showdowns score each hand with them.
+	Community []int
+	Burn      int // Cards burned from the deck before each community street
+
+	// At the showdown only the winner shows; the losers muck their hands
+	// unseen, so nobody learns what they held.
+	MuckLosers bool
 }
 
 func (p *BettingPhase) PhaseType() uint8 { return PhaseTypeBetting }
 func (p *BettingPhase) phaseMarker()     {}
 
+// ClaimRankRule defines which rank a claim names (matching the engine's
+// ClaimRank values).
+type ClaimRankRule uint8
+
+const (
+	// ClaimRankSequential names A, 2, 3, ..., K, A, ... in turn.
+	ClaimRankSequential ClaimRankRule = 0
+	// ClaimRankFree lets the claimer name any rank.
+	ClaimRankFree ClaimRankRule = 1
+	// ClaimRankMatchOrHigher lets the claimer name any rank at least the
+	// last claim's; a challenge clears the pile and the restriction.
+	ClaimRankMatchOrHigher ClaimRankRule = 2
+)
+
 // ClaimPhase represents bluffing/claiming mechanics (like Cheat/BS).
 type ClaimPhase struct {
-	// ClaimPhase has minimal configuration - the mechanics are handled
-	// by the interpreter based on game state (current claim, etc.)
+	// The rest of the mechanics are handled by the interpreter based on
+	// game state (current claim, etc.)
+	RankRule ClaimRankRule
 }
 
 func (p *ClaimPhase) PhaseType() uint8 { return PhaseTypeClaim }
@@ -119,11 +278,63 @@ type BiddingPhase struct {
 	NilPenalty            int // Penalty for failed Nil bid
 	BagLimit              int // Number of bags before penalty
 	BagPenalty            int // Penalty when bag limit reached
+
+	// DeclareTrump has every bid name a trump suit; the highest bidder's
+	// suit (the first to bid it, on a tie) is trump for the hand's tricks
+	// in place of the trick phase's own.
+	DeclareTrump bool
+
+	// Blind seals the bids: every player chooses a bid before any is
+	// revealed, so nobody can react to another's bid.
+	Blind bool
 }
 
 func (p *BiddingPhase) PhaseType() uint8 { return PhaseTypeBidding }
 func (p *BiddingPhase) phaseMarker()     {}
 
+// ShowAward defines what the winners of a show receive (matching engine.ShowAward* constants).
+type ShowAward uint8
+
+const (
+	ShowAwardPot    ShowAward = 0 // Winners split the pot
+	ShowAwardPoints ShowAward = 1 // Winners gain Points
+	ShowAwardBoth   ShowAward = 2 // Winners split the pot and gain Points
+)
+
+// ShowPhase reveals all hands still in play and compares them using the
+// genome's HandEval, independent of any betting round.
+type ShowPhase struct {
+	Award      ShowAward // What the winners receive
+	Points     int       // Points per winner (ShowAwardPoints/ShowAwardBoth)
+	ClearHands bool      // If true, revealed hands are discarded so a new hand can be drawn
+}
+
+func (p *ShowPhase) PhaseType() uint8 { return PhaseTypeShow }
+func (p *ShowPhase) phaseMarker()     {}
+
+// PeekTarget selects whose hand a PeekPhase reveals (matching engine.TARGET_* constants).
+type PeekTarget uint8
+
+const (
+	PeekTargetNext     PeekTarget = 0 // The next player
+	PeekTargetPrevious PeekTarget = 1 // The previous player
+	PeekTargetAll      PeekTarget = 4 // Every opponent
+)
+
+// MaxPeekDuration is the longest a peek can last, in turns.
+const MaxPeekDuration = 65535
+
+// PeekPhase lets the current player look at an opponent's hand without
+// taking their turn. The cards seen stay known to the peeker for Duration
+// turns, or until the hand ends.
+type PeekPhase struct {
+	Target   PeekTarget // Whose hand is revealed
+	Duration int        // Turns the peeked cards stay known (0 = rest of the hand)
+}
+
+func (p *PeekPhase) PhaseType() uint8 { return PhaseTypePeek }
+func (p *PeekPhase) phaseMarker()     {}
+
 // WinConditionType constants
 type WinConditionType uint8
 
@@ -136,12 +347,26 @@ const (
 	WinTypeAllHandsEmpty WinConditionType = 5
 	WinTypeBestHand     WinConditionType = 6
 	WinTypeMostCaptured WinConditionType = 7
+
+	// First to win Threshold hands takes the match (ScoreCarryPerHand).
+	// Numbered after the engine's own win types, which it matches.
+	WinTypeMatchWins WinConditionType = 11
+
+	// The last player holding chips wins a tournament of betting hands;
+	// players with no chips are knocked out. A positive Threshold caps the
+	// hands played, after which the chip leader wins.
+	WinTypeLastChipStanding WinConditionType = 12
 )
 
 // WinCondition defines how the game ends and who wins.
 type WinCondition struct {
 	Type      WinConditionType
 	Threshold int32 // Score threshold for score-based wins
+
+	// Lead over every other player needed, once Threshold is reached, to
+	// win a HighScore or FirstToScore game, as in games won by two; play
+	// goes on until someone has it (0 = none)
+	Margin int32
 }
 
 // TableauMode defines how the tableau is used.
@@ -154,6 +379,15 @@ const (
 	TableauModeSequence  TableauMode = 3
 )
 
+// WarTieRule settles a War battle tied for the high card.
+type WarTieRule uint8
+
+const (
+	WarTieAlternate   WarTieRule = 0 // The tied players take turns winning ties
+	WarTieFirstPlayed WarTieRule = 1 // The first tied card played wins
+	WarTieCarryOver   WarTieRule = 2 // The cards stay for the next battle's winner ("war")
+)
+
 // SequenceDirection for sequence-based tableau play.
 type SequenceDirection uint8
 
@@ -163,6 +397,55 @@ const (
 	SequenceBoth       SequenceDirection = 2
 )
 
+// AceMode defines where the Ace sits in rank order (matching engine.AceMode).
+// It applies to sequence plays, poker straights, and rank comparisons.
+type AceMode uint8
+
+const (
+	AceHigh AceMode = 0 // Ace above King: Q-K-A runs, A-K-Q-J-10 straights
+	AceLow  AceMode = 1 // Ace below Two: A-2-3 runs, A-2-3-4-5 straights
+	AceBoth AceMode = 2 // Either end of a run, never wrapping K-A-2; high in comparisons
+)
+
+// JumpInRule defines which cards may be played out of turn right after a
+// play to the discard pile (Snap, Uno's "jump-in" house rule).
+type JumpInRule uint8
+
+const (
+	JumpInNone      JumpInRule = 0 // Strict turn order
+	JumpInRank      JumpInRule = 1 // Any card of the same rank (Snap)
+	JumpInIdentical JumpInRule = 2 // Same rank and suit (Uno; needs duplicate cards)
+)
+
+// MaxTurnsRule decides the game when it reaches the turn limit.
+type MaxTurnsRule uint8
+
+const (
+	MaxTurnsDraw         MaxTurnsRule = 0 // The game is a draw
+	MaxTurnsHighScore    MaxTurnsRule = 1 // Highest score wins
+	MaxTurnsMostCaptured MaxTurnsRule = 2 // Most cards captured wins
+)
+
+// TurnLimit chooses what MaxTurns counts. Turns suit most games, but every
+// betting action advances the turn counter, so a betting game can run out
+// of turns a few hands in; counting card plays or hands keeps its limit
+// meaningful.
+type TurnLimit uint8
+
+const (
+	TurnLimitTurns   TurnLimit = 0 // Turn counter increments
+	TurnLimitActions TurnLimit = 1 // Moves taken in turn, leaving out betting and bidding
+	TurnLimitHands   TurnLimit = 2 // Hands played to the end
+)
+
+// StuckRule decides what happens when the player to move has no legal move.
+type StuckRule uint8
+
+const (
+	StuckEnd  StuckRule = 0 // The game ends without a winner
+	StuckPass StuckRule = 1 // The turn passes to the next player who can move
+)
+
 // EffectType constants for special card effects.
 type EffectType uint8
 
@@ -177,6 +460,10 @@ const (
 	EffectStealCard   EffectType = 7
 	EffectPeekHand    EffectType = 8
 	EffectDiscardPile EffectType = 9
+
+	// Effects forcing an opponent's hand open, aimed by Target
+	EffectForceDiscard EffectType = 10 // The target discards Value cards
+	EffectForceReveal  EffectType = 11 // Value cards (at least one) of the target's hand are shown to every player
 )
 
 // String returns the lowercase string representation of EffectType for JSON serialization.
@@ -202,6 +489,10 @@ func (e EffectType) String() string {
 		return "peek_hand"
 	case EffectDiscardPile:
 		return "discard_pile"
+	case EffectForceDiscard:
+		return "force_discard"
+	case EffectForceReveal:
+		return "force_reveal"
 	default:
 		return "skip_next"
 	}
@@ -224,6 +515,43 @@ const (
 	TriggerPlay        ScoringTrigger = 2
 	TriggerHandEnd     ScoringTrigger = 3
 	TriggerSetComplete ScoringTrigger = 4
+	TriggerSweep       ScoringTrigger = 5 // A rank-match capture clears the table; matched against the capturing card
+	TriggerExposed     ScoringTrigger = 6 // Game end; matched against each card in a player's exposed area
+)
+
+// HandPenalty defines who scores the cards left in players' hands when the
+// game ends (Uno, Phase 10, Rummy).
+type HandPenalty uint8
+
+const (
+	HandPenaltyNone   HandPenalty = 0 // Cards left in hand are ignored
+	HandPenaltySelf   HandPenalty = 1 // Each player adds their own hand value (low score wins)
+	HandPenaltyWinner HandPenalty = 2 // The winner collects every opponent's hand value
+)
+
+// ScoreCarry defines whether scores carry from one hand of a multi-hand game
+// to the next.
+type ScoreCarry uint8
+
+const (
+	ScoreCarryAccumulate ScoreCarry = 0 // Scores and chips carry over, building toward a target
+	ScoreCarryPerHand    ScoreCarry = 1 // Each hand is scored on its own and won outright (match play)
+)
+
+// ChipBridge links a betting game's chips to its points, so hybrid games
+// that bet on a hand and also score it (poker-rummy) can settle one in the
+// other. Without one, chips and points are kept apart.
+type ChipBridge uint8
+
+const (
+	ChipBridgeNone ChipBridge = 0 // Chips and points are kept apart
+
+	// A pot still contested after the betting is not decided by comparing
+	// hands: the hand is played out and its top scorer takes the pot.
+	ChipBridgePointsWinPot ChipBridge = 1
+
+	// Chips taken from a pot are also added to the winner's score.
+	ChipBridgePotToScore ChipBridge = 2
 )
 
 // CardScoringRule defines points for specific cards.
@@ -232,8 +560,21 @@ type CardScoringRule struct {
 	Rank    uint8          // 0-12 for ranks, 255 for "any"
 	Points  int16          // Points to award (can be negative)
 	Trigger ScoringTrigger // When this rule applies
+	Meld    MeldFilter     `json:",omitempty"` // Which cards left in hand a hand_end rule scores
 }
 
+// MeldFilter picks the cards a hand_end scoring rule scores once the hand
+// is arranged into runs and sets of three or more (matching engine.Meld*
+// constants), so melds can score at the end of the game without being
+// laid down during play.
+type MeldFilter uint8
+
+const (
+	MeldAny       MeldFilter = 0 // Every card
+	MeldMatched   MeldFilter = 1 // Cards in a run or set
+	MeldUnmatched MeldFilter = 2 // Cards in no run or set (deadwood)
+)
+
 // HandEvaluationMethod defines how hands are compared.
 type HandEvaluationMethod uint8
 
@@ -245,6 +586,18 @@ const (
 	EvalMethodCardCount    HandEvaluationMethod = 4
 )
 
+// BustRule defines what going over the target costs in a POINT_TOTAL game
+// (matching engine.BustRule).
+type BustRule uint8
+
+const (
+	// BustLoses puts a busted hand out of the hand: it cannot win.
+	BustLoses BustRule = 0
+	// BustPenalty scores a busted hand as the target less what it went
+	// over by: a losing total that still beats a hand that stood low.
+	BustPenalty BustRule = 1
+)
+
 // CardValue defines point values for card ranks (e.g., Blackjack scoring).
 type CardValue struct {
 	Rank     uint8 // 0-12 for 2-A
@@ -269,25 +622,143 @@ type HandEvaluation struct {
 	Method        HandEvaluationMethod
 	TargetValue   uint8       // For POINT_TOTAL (e.g., 21 for Blackjack)
 	BustThreshold uint8       // For POINT_TOTAL (e.g., 22 for Blackjack bust)
+	Bust          BustRule    // For POINT_TOTAL: what going over costs
 	CardValues    []CardValue // Card point values
 	Patterns      []HandPattern // Hand patterns for PATTERN_MATCH
 }
 
+// DealOrder defines how initial hands are dealt (matching engine.DealOrder).
+type DealOrder uint8
+
+const (
+	// DealRoundRobin deals one card to each player in turn until hands are full.
+	DealRoundRobin DealOrder = 0
+	// DealSequential deals each player's full hand before moving to the next.
+	DealSequential DealOrder = 1
+)
+
+// DealStrategy defines who takes the starting hands off the deck (matching
+// engine.DealStrategy).
+type DealStrategy uint8
+
+const (
+	// DealPreDealt deals every hand from the shuffled deck in DealOrder
+	// before anyone acts.
+	DealPreDealt DealStrategy = 0
+	// DealShoeDraw has each player draw their own hand off the top of a
+	// shared shoe in seat order. In shoe play a player draws just before
+	// acting, so the cards a later seat starts with depend on what earlier
+	// seats drew.
+	DealShoeDraw DealStrategy = 1
+)
+
+// Remainder defines where the cards left in the deck after the deal go
+// (matching the engine's Remainder values).
+type Remainder uint8
+
+const (
+	// RemainderDeck leaves them in the deck for draw phases.
+	RemainderDeck Remainder = 0
+	// RemainderKitty sets them aside face down; the highest bidder takes
+	// them into hand once bidding is over.
+	RemainderKitty Remainder = 1
+	// RemainderBurn takes them out of play.
+	RemainderBurn Remainder = 2
+	// RemainderDealer deals them to the dealer as extra cards.
+	RemainderDealer Remainder = 3
+)
+
 // SetupRules defines initial game setup.
 type SetupRules struct {
-	CardsPerPlayer int  // Cards dealt to each player
-	TableauSize    int  // Number of tableau piles (0 = none)
-	StartingChips  int  // Chips for betting games (0 = no betting)
-	DealToTableau  int  // Cards dealt to tableau at start
+	CardsPerPlayer int       // Cards dealt to each player
+	TableauSize    int       // Number of tableau piles (0 = none)
+	StartingChips  int       // Chips for betting games (0 = no betting)
+	DealToTableau  int       // Cards dealt to tableau at start
+	DealOrder      DealOrder // Round-robin (default) or sequential dealing
+	RotateDealer   bool      // Deal passes left each hand, and the player after the dealer leads
+
+	// Penetration is the fraction of a shoe dealt before it is reshuffled
+	// (0 = no shoe play). Only the card-counting evaluation (see
+	// simulation.RunShoes) deals from a shoe; simulated games reshuffle
+	// every card for each deal whatever the penetration.
+	Penetration float64
+
+	// Who takes the starting hands off the deck. With a given seed both
+	// strategies draw from the same deck order but hand out different cards.
+	DealStrategy DealStrategy
+
+	// Whether the card turned up to start the discard pile takes effect on
+	// the first player, as a starting Skip skips them in Uno.
+	StartCardEffect bool
+
+	// Draws each player may take from draw phases in a game; once they are
+	// used up, draw phases stop offering one (0 = unlimited).
+	MaxDrawsPerGame int
+
+	// Cards burned from the top of the shuffled deck before the deal.
+	BurnCards int
+
+	// Each player gets a shuffled deck of their own, cut to PersonalDeckSize
+	// cards (0 = a full 52), and draws from and discards to their own piles.
+	// Hands are dealt from the personal decks; the shared deck stays in play
+	// for the starting discard and any other common cards.
+	PersonalDecks    bool
+	PersonalDeckSize int
+
+	// Players cannot see how many cards their opponents hold, as in
+	// bluffing games where a hand's size gives away its strength.
+	HiddenHandSizes bool
+
+	// Cards fewer dealt each round of a knockout trick game, as in Knock-Out
+	// Whist (0 = a single deal). Once the hands are played out, players who
+	// took no trick are knocked out and the rest are dealt again, until one
+	// player is left or the hands shrink to nothing; the player left, or
+	// the one taking most tricks in the last round, wins.
+	HandShrink int
+
+	// Where the cards left after the hands and starting cards are dealt go.
+	Remainder Remainder
+
+	// Throws in a deal that leaves a player an unplayable hand.
+	Misdeal MisdealRule
+}
+
+// MisdealCondition is the kind of starting hand thrown in as a misdeal
+// (matching the engine's Misdeal values).
+type MisdealCondition uint8
+
+const (
+	MisdealNone        MisdealCondition = 0 // Every deal stands
+	MisdealNoFaceCards MisdealCondition = 1 // No jack, queen or king
+	MisdealNoSuit      MisdealCondition = 2 // No card of Suit, such as no trumps
+	MisdealAllBelow    MisdealCondition = 3 // Every card ranks below Rank
+)
+
+// MisdealRule redeals when any player's starting hand meets Condition: the
+// cards are gathered, reshuffled and dealt again, at most MaxRedeals times,
+// after which the deal stands whatever it holds.
+type MisdealRule struct {
+	Condition  MisdealCondition
+	Rank       uint8 // MisdealAllBelow's rank
+	Suit       uint8 // MisdealNoSuit's suit
+	MaxRedeals int
 }
 
 // TurnStructure defines the phases of each turn.
 type TurnStructure struct {
 	Phases            []Phase           // Ordered phases in a turn
 	MaxTurns          int               // Maximum turns before game ends
+	TurnLimit         TurnLimit         // What MaxTurns counts: turns, actions or hands
 	TableauMode       TableauMode       // How tableau is used
+	WarTieRule        WarTieRule        // How a War battle tied for the high card is settled
 	SequenceDirection SequenceDirection // For sequence-based play
+	AceMode           AceMode           // Ace high, low, or both
 	IsTrickBased      bool              // If true, game uses trick-taking mechanics
+	JumpIn            JumpInRule        // Out-of-turn plays allowed after each play
+	MaxTurnsRule      MaxTurnsRule      // How a game that reaches MaxTurns is decided
+	StuckRule         StuckRule         // What a player with no legal move does
+	MaxEffectsPerTurn int               // Card effects resolved per turn before the rest are ignored (0 = engine default)
+	MaxLegalMoves     int               // Legal moves offered per decision before they are sampled (0 = engine default)
 }
 
 // TeamConfig defines team play settings.
@@ -296,6 +767,54 @@ type TeamConfig struct {
 	Teams   [][]int // Player indices per team, e.g., [[0,2], [1,3]]
 }
 
+// CatchUpRule is a rubber band against snowballing: at the start of each
+// of their turns the player in last place, by the standings the leader
+// detector keeps, receives a bonus.
+type CatchUpRule struct {
+	Draw   int   // Extra cards drawn from the deck
+	Points int32 // Points towards the lead (taken off the score in low-score games)
+}
+
+// SetCleanupRule lays completed sets down automatically, as Old Maid
+// players discard their pairs and Go Fish players their books: after each
+// draw, every Size cards of one rank in the drawer's hand leave it, scoring
+// Points a set.
+type SetCleanupRule struct {
+	Size     int   // Cards of one rank that make a set (2 = pairs, 4 = books)
+	Points   int32 // Points for each set laid down
+	Captured bool  // Sets go to the player's captured pile instead of the discard
+}
+
+// MoonShotRule is Hearts' "shooting the moon": when the last trick of a
+// hand is won, a player (or team) who took every card the trick-win card
+// scoring values gives those points back, and every other player scores
+// Points instead.
+type MoonShotRule struct {
+	Points int32 // Added to each other player (0 = the points the shooter took)
+}
+
+// CaptureCategoryKind is what a capture category measures.
+type CaptureCategoryKind uint8
+
+const (
+	CaptureMostCards CaptureCategoryKind = 0 // Most cards captured
+	CaptureMostSuit  CaptureCategoryKind = 1 // Most cards of Suit captured (Scopa's coins)
+	CaptureCard      CaptureCategoryKind = 2 // Captured the card of Rank and Suit (Scopa's settebello)
+	CapturePrime     CaptureCategoryKind = 3 // Best prime: the best prime value captured in each suit, summed
+	CapturePoints    CaptureCategoryKind = 4 // Most points captured, each card counting its rank value
+)
+
+// CaptureCategory scores the captured piles at the end of the game: the
+// one player who does best in the category scores Points, and a tie scores
+// nothing.
+type CaptureCategory struct {
+	Kind        CaptureCategoryKind
+	Suit        uint8   // 0-3 (CaptureMostSuit, CaptureCard)
+	Rank        uint8   // 0-12 (CaptureCard)
+	Points      int32   // Points for winning the category
+	PrimeValues []int32 // Prime value per rank 0-12 (CapturePrime; empty = Scopa's)
+}
+
 // GameGenome is the complete game definition.
 // This is the top-level struct that fully describes an evolved card game.
 type GameGenome struct {
@@ -306,10 +825,66 @@ type GameGenome struct {
 	WinConditions []WinCondition  // How the game ends
 	Effects       []SpecialEffect // Special card effects
 	CardScoring   []CardScoringRule // Scoring rules
+	HandPenalty   HandPenalty       // Scoring for cards left in hand at game end
+	RevealScoring bool              // Hands stay hidden until the game ends, then each scores its own value
+	ScoreCarry    ScoreCarry        // Whether scores carry between hands
+	ChipBridge    ChipBridge        // How chips and points settle into each other
 	HandEval      *HandEvaluation // Hand evaluation (poker, blackjack)
 	Teams         *TeamConfig     // Optional team configuration
+	CatchUp       *CatchUpRule    // Bonus for the trailing player (nil = none)
+	MoonShot      *MoonShotRule   // Shooting the moon in trick games (nil = none)
+	SetCleanup    *SetCleanupRule // Completed sets laid down after each draw (nil = none)
+
+	// CaptureScoring scores the captured piles by category when the game
+	// ends, in place of a point per captured card.
+	CaptureScoring []CaptureCategory
+
+	// PlacementPoints scores a shedding game by finishing order: going out
+	// no longer ends the game, play goes on until one player holds cards,
+	// and the player placed i-th scores PlacementPoints[i]. With another win
+	// condition beside empty_hand the cards are then redealt for the next
+	// round; otherwise the top scorer wins. nil = first out wins.
+	PlacementPoints []int32
+
+	// RankValues are the points each rank 0-12 counts for in blackjack
+	// totals, hand penalties and point captures (see GetRankValue). Empty
+	// uses engine.DefaultRankValues: pips at face value, court cards 10
+	// and the Ace 1.
+	RankValues []int32
+
+	// WildRanks are ranks 0-12 whose cards stand for any card a run or set
+	// lacks, as Canasta's twos do, when detecting melds, completing sets
+	// and scoring melds at the end (see WildRankMask). Empty = no wilds.
+	WildRanks []int
+
+	// DiscardPiles are discard piles besides the main discard, such as the
+	// foundations of a patience game, each taking only certain cards. Play
+	// phases targeting LocationPiles play onto them. Empty = none.
+	DiscardPiles []DiscardPile
 }
 
+// DiscardPile is one of a game's extra discard piles and the cards it
+// takes (matching engine.PileRule).
+type DiscardPile struct {
+	Name      string    // For display, e.g. "hearts"
+	Suit      uint8     // Suit taken (255 = any)
+	StartRank uint8     // Rank of the card starting the pile (255 = any)
+	Build     PileBuild // Which cards follow once the pile is started
+}
+
+// PileBuild defines how a discard pile is built once started (matching
+// engine.PileBuild* constants).
+type PileBuild uint8
+
+const (
+	PileBuildAny  PileBuild = 0 // Any card of the pile's suit
+	PileBuildUp   PileBuild = 1 // One rank above the top card, the ace following the king
+	PileBuildDown PileBuild = 2 // One rank below the top card, the king following the ace
+)
+
+// MaxDiscardPiles is the most DiscardPiles a genome can have.
+const MaxDiscardPiles = 8
+
 // Clone creates a deep copy of the genome.
 func (g *GameGenome) Clone() *GameGenome {
 	if g == nil {
@@ -317,17 +892,29 @@ func (g *GameGenome) Clone() *GameGenome {
 	}
 
 	clone := &GameGenome{
-		Name:       g.Name,
-		Generation: g.Generation,
-		Setup:      g.Setup, // SetupRules is a value type
+		Name:          g.Name,
+		Generation:    g.Generation,
+		Setup:         g.Setup, // SetupRules is a value type
+		HandPenalty:   g.HandPenalty,
+		RevealScoring: g.RevealScoring,
+		ScoreCarry:    g.ScoreCarry,
+		ChipBridge:    g.ChipBridge,
 	}
 
 	// Clone TurnStructure
 	clone.TurnStructure = TurnStructure{
 		MaxTurns:          g.TurnStructure.MaxTurns,
+		TurnLimit:         g.TurnStructure.TurnLimit,
 		TableauMode:       g.TurnStructure.TableauMode,
+		WarTieRule:        g.TurnStructure.WarTieRule,
 		SequenceDirection: g.TurnStructure.SequenceDirection,
+		AceMode:           g.TurnStructure.AceMode,
 		IsTrickBased:      g.TurnStructure.IsTrickBased,
+		JumpIn:            g.TurnStructure.JumpIn,
+		MaxTurnsRule:      g.TurnStructure.MaxTurnsRule,
+		StuckRule:         g.TurnStructure.StuckRule,
+		MaxEffectsPerTurn: g.TurnStructure.MaxEffectsPerTurn,
+		MaxLegalMoves:     g.TurnStructure.MaxLegalMoves,
 	}
 
 	// Clone phases
@@ -366,6 +953,27 @@ func (g *GameGenome) Clone() *GameGenome {
 		clone.Teams = cloneTeamConfig(g.Teams)
 	}
 
+	clone.PlacementPoints = append([]int32(nil), g.PlacementPoints...)
+	clone.RankValues = append([]int32(nil), g.RankValues...)
+	clone.WildRanks = append([]int(nil), g.WildRanks...)
+	clone.CaptureScoring = cloneCaptureScoring(g.CaptureScoring)
+	clone.DiscardPiles = append([]DiscardPile(nil), g.DiscardPiles...)
+
+	if g.CatchUp != nil {
+		catchUp := *g.CatchUp
+		clone.CatchUp = &catchUp
+	}
+
+	if g.MoonShot != nil {
+		moonShot := *g.MoonShot
+		clone.MoonShot = &moonShot
+	}
+
+	if g.SetCleanup != nil {
+		cleanup := *g.SetCleanup
+		clone.SetCleanup = &cleanup
+	}
+
 	return clone
 }
 
@@ -378,6 +986,7 @@ func clonePhase(p Phase) Phase {
 			cond := *phase.Condition
 			cp.Condition = &cond
 		}
+		cp.Repeat = phase.Repeat.Clone()
 		return &cp
 	case *PlayPhase:
 		cp := *phase
@@ -385,6 +994,7 @@ func clonePhase(p Phase) Phase {
 			cond := *phase.ValidPlayCondition
 			cp.ValidPlayCondition = &cond
 		}
+		cp.Repeat = phase.Repeat.Clone()
 		return &cp
 	case *DiscardPhase:
 		cp := *phase
@@ -394,6 +1004,7 @@ func clonePhase(p Phase) Phase {
 		return &cp
 	case *BettingPhase:
 		cp := *phase
+		cp.Community = append([]int(nil), phase.Community...)
 		return &cp
 	case *ClaimPhase:
 		cp := *phase
@@ -401,6 +1012,21 @@ func clonePhase(p Phase) Phase {
 	case *BiddingPhase:
 		cp := *phase
 		return &cp
+	case *ShowPhase:
+		cp := *phase
+		return &cp
+	case *PeekPhase:
+		cp := *phase
+		return &cp
+	case *DrawDiscardPhase:
+		cp := *phase
+		return &cp
+	case *GivePhase:
+		cp := *phase
+		return &cp
+	case *TradePhase:
+		cp := *phase
+		return &cp
 	default:
 		return nil
 	}
@@ -416,6 +1042,7 @@ func cloneHandEvaluation(h *HandEvaluation) *HandEvaluation {
 		Method:        h.Method,
 		TargetValue:   h.TargetValue,
 		BustThreshold: h.BustThreshold,
+		Bust:          h.Bust,
 	}
 
 	if h.CardValues != nil {
@@ -477,3 +1104,17 @@ func cloneTeamConfig(t *TeamConfig) *TeamConfig {
 
 	return clone
 }
+
+// cloneCaptureScoring creates a deep copy of capture categories.
+func cloneCaptureScoring(categories []CaptureCategory) []CaptureCategory {
+	if categories == nil {
+		return nil
+	}
+
+	clone := make([]CaptureCategory, len(categories))
+	for i, c := range categories {
+		clone[i] = c
+		clone[i].PrimeValues = append([]int32(nil), c.PrimeValues...)
+	}
+	return clone
+}
@@ -3,6 +3,8 @@ package genome
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -21,27 +23,66 @@ type PhaseJSON struct {
 	MaxCards           int                `json:"max_cards,omitempty"`
 	ValidPlayCondition *ConditionJSON     `json:"valid_play_condition,omitempty"`
 	Condition          *ConditionJSON     `json:"condition,omitempty"`
+	Repeat             *PhaseRepeatJSON   `json:"repeat,omitempty"`
+	RefillTo           int                `json:"refill_to,omitempty"`
+	MaxPerTurn         int                `json:"max_per_turn,omitempty"`
 	LeadSuitRequired   bool               `json:"lead_suit_required,omitempty"`
 	TrumpSuit          *string            `json:"trump_suit,omitempty"`
 	HighCardWins       bool               `json:"high_card_wins,omitempty"`
 	BreakingSuit       *string            `json:"breaking_suit,omitempty"`
+	TieRule            string             `json:"tie_rule,omitempty"`
+	OpeningLead        string             `json:"opening_lead,omitempty"`
+	NextLeader         string             `json:"next_leader,omitempty"`
+	LeadCardRank       string             `json:"lead_card_rank,omitempty"`
+	LeadCardSuit       string             `json:"lead_card_suit,omitempty"`
+	MustLeadCard       bool               `json:"must_lead_card,omitempty"`
+	MustBeat           bool               `json:"must_beat,omitempty"`
+	MustOvertrump      bool               `json:"must_overtrump,omitempty"`
+	TrumpDiscipline    string             `json:"trump_discipline,omitempty"`
 	MinBet             int                `json:"min_bet,omitempty"`
 	MaxRaises          int                `json:"max_raises,omitempty"`
+	MinPlayers         int                `json:"min_players,omitempty"`
+	Blinds             int                `json:"blinds,omitempty"`
+	Community          []int              `json:"community,omitempty"`
+	Burn               int                `json:"burn,omitempty"`
+	MuckLosers         bool               `json:"muck_losers,omitempty"`
 	MinBid             int                `json:"min_bid,omitempty"`
 	MaxBid             int                `json:"max_bid,omitempty"`
 	AllowNil           bool               `json:"allow_nil,omitempty"`
+	DeclareTrump       bool               `json:"declare_trump,omitempty"`
+	Blind              bool               `json:"blind,omitempty"`
 	// ClaimPhase fields
 	SequentialRank     bool               `json:"sequential_rank,omitempty"`
 	AllowChallenge     bool               `json:"allow_challenge,omitempty"`
 	PilePenalty        bool               `json:"pile_penalty,omitempty"`
+	// ShowPhase fields
+	Award              string             `json:"award,omitempty"`
+	Points             int                `json:"points,omitempty"`
+	ClearHands         bool               `json:"clear_hands,omitempty"`
+	// PeekPhase fields
+	Duration           int                `json:"duration,omitempty"`
+	// DrawDiscardPhase fields
+	TakeDiscard        bool               `json:"take_discard,omitempty"`
+	// TradePhase fields
+	Price              int                `json:"price,omitempty"`
+	// DiscardPhase fields
+	FaceDown           bool               `json:"face_down,omitempty"`
 }
 
 // TurnStructureJSON is used for JSON serialization.
 type TurnStructureJSON struct {
 	Phases            []json.RawMessage `json:"phases"`
 	MaxTurns          int               `json:"max_turns,omitempty"`
+	TurnLimit         string            `json:"turn_limit,omitempty"`
 	TableauMode       string            `json:"tableau_mode,omitempty"`
+	WarTieRule        string            `json:"war_tie_rule,omitempty"`
 	SequenceDirection string            `json:"sequence_direction,omitempty"`
+	AceMode           string            `json:"ace_mode,omitempty"`
+	JumpIn            string            `json:"jump_in,omitempty"`
+	MaxTurnsRule      string            `json:"max_turns_rule,omitempty"`
+	StuckRule         string            `json:"stuck_rule,omitempty"`
+	MaxEffectsPerTurn int               `json:"max_effects_per_turn,omitempty"`
+	MaxLegalMoves     int               `json:"max_legal_moves,omitempty"`
 	// Python format fields
 	IsTrickBased      bool              `json:"is_trick_based,omitempty"`
 	TricksPerHand     *int              `json:"tricks_per_hand,omitempty"`
@@ -49,29 +90,56 @@ type TurnStructureJSON struct {
 
 // SetupRulesJSON for Python format compatibility.
 type SetupRulesJSON struct {
-	CardsPerPlayer      int    `json:"cards_per_player"`
-	TableauSize         int    `json:"tableau_size,omitempty"`
-	StartingChips       int    `json:"starting_chips,omitempty"`
-	DealToTableau       int    `json:"deal_to_tableau,omitempty"`
+	CardsPerPlayer      int     `json:"cards_per_player"`
+	TableauSize         int     `json:"tableau_size,omitempty"`
+	StartingChips       int     `json:"starting_chips,omitempty"`
+	DealToTableau       int     `json:"deal_to_tableau,omitempty"`
+	DealOrder           string  `json:"deal_order,omitempty"`
+	DealStrategy        string  `json:"deal_strategy,omitempty"`
+	Penetration         float64 `json:"penetration,omitempty"`
+	RotateDealer        bool    `json:"rotate_dealer,omitempty"`
+	StartCardEffect     bool    `json:"start_card_effect,omitempty"`
+	MaxDrawsPerGame     int     `json:"max_draws_per_game,omitempty"`
+	BurnCards           int     `json:"burn_cards,omitempty"`
+	PersonalDecks       bool    `json:"personal_decks,omitempty"`
+	PersonalDeckSize    int     `json:"personal_deck_size,omitempty"`
+	HiddenHandSizes     bool    `json:"hidden_hand_sizes,omitempty"`
+	HandShrink          int     `json:"hand_shrink,omitempty"`
+	Remainder           string  `json:"remainder,omitempty"`
+
+	Misdeal *MisdealRuleJSON `json:"misdeal,omitempty"`
+
 	// Python format fields
-	InitialDeck         string `json:"initial_deck,omitempty"`
-	InitialDiscardCount int    `json:"initial_discard_count,omitempty"`
-	TrumpSuit           string `json:"trump_suit,omitempty"`
-	TableauMode         string `json:"tableau_mode,omitempty"`
-	SequenceDirection   string `json:"sequence_direction,omitempty"`
+	InitialDeck         string  `json:"initial_deck,omitempty"`
+	InitialDiscardCount int     `json:"initial_discard_count,omitempty"`
+	TrumpSuit           string  `json:"trump_suit,omitempty"`
+	TableauMode         string  `json:"tableau_mode,omitempty"`
+	SequenceDirection   string  `json:"sequence_direction,omitempty"`
 }
 
 // GameGenomeJSON is used for JSON serialization.
 // Supports both Go format and Python format.
 type GameGenomeJSON struct {
-	Name          string              `json:"name,omitempty"`
-	Setup         json.RawMessage     `json:"setup"`
-	TurnStructure TurnStructureJSON   `json:"turn_structure"`
-	WinConditions []WinConditionJSON  `json:"win_conditions"`
-	Effects       []SpecialEffect     `json:"effects,omitempty"`
-	CardScoring   []CardScoringRule   `json:"card_scoring,omitempty"`
-	HandEval      *HandEvaluation     `json:"hand_evaluation,omitempty"`
-	Teams         *TeamConfig         `json:"teams,omitempty"`
+	Name            string             `json:"name,omitempty"`
+	Setup           json.RawMessage    `json:"setup"`
+	TurnStructure   TurnStructureJSON  `json:"turn_structure"`
+	WinConditions   []WinConditionJSON `json:"win_conditions"`
+	Effects         []SpecialEffect    `json:"effects,omitempty"`
+	CardScoring     []CardScoringRule  `json:"card_scoring,omitempty"`
+	HandPenalty     string             `json:"hand_penalty,omitempty"`
+	RevealScoring   bool               `json:"reveal_scoring,omitempty"`
+	ScoreCarry      string             `json:"score_carry,omitempty"`
+	ChipBridge      string             `json:"chip_bridge,omitempty"`
+	PlacementPoints []int32            `json:"placement_points,omitempty"`
+	RankValues      []int32            `json:"rank_values,omitempty"`
+	WildRanks       []int              `json:"wild_ranks,omitempty"`
+	HandEval        *HandEvaluation    `json:"hand_evaluation,omitempty"`
+	Teams           *TeamConfig        `json:"teams,omitempty"`
+	CatchUp         *CatchUpRule       `json:"catch_up,omitempty"`
+	MoonShot        *MoonShotRule      `json:"moon_shot,omitempty"`
+	SetCleanup      *SetCleanupRule    `json:"set_cleanup,omitempty"`
+	CaptureScoring  []CaptureCategory  `json:"capture_scoring,omitempty"`
+	DiscardPiles    []DiscardPile      `json:"discard_piles,omitempty"`
 	// Python format fields
 	SchemaVersion  string              `json:"schema_version,omitempty"`
 	GenomeID       string              `json:"genome_id,omitempty"`
@@ -95,24 +163,35 @@ type SpecialEffectJSON struct {
 type WinConditionJSON struct {
 	Type      string `json:"type"`
 	Threshold int32  `json:"threshold,omitempty"`
+	Margin    int32  `json:"margin,omitempty"`
 }
 
 // DrawPhaseJSON for JSON serialization.
 type DrawPhaseJSON struct {
-	Source    string         `json:"source"`
-	Count     int            `json:"count"`
-	Mandatory bool           `json:"mandatory"`
-	Condition *ConditionJSON `json:"condition,omitempty"`
+	Source     string           `json:"source"`
+	Count      int              `json:"count"`
+	Mandatory  bool             `json:"mandatory"`
+	Condition  *ConditionJSON   `json:"condition,omitempty"`
+	Repeat     *PhaseRepeatJSON `json:"repeat,omitempty"`
+	MaxPerTurn int              `json:"max_per_turn,omitempty"`
 }
 
 // PlayPhaseJSON for JSON serialization.
 type PlayPhaseJSON struct {
-	Target             string         `json:"target"`
-	MinCards           int            `json:"min_cards"`
-	MaxCards           int            `json:"max_cards"`
-	Mandatory          bool           `json:"mandatory"`
-	PassIfUnable       bool           `json:"pass_if_unable"`
-	ValidPlayCondition *ConditionJSON `json:"valid_play_condition,omitempty"`
+	Target             string           `json:"target"`
+	MinCards           int              `json:"min_cards"`
+	MaxCards           int              `json:"max_cards"`
+	Mandatory          bool             `json:"mandatory"`
+	PassIfUnable       bool             `json:"pass_if_unable"`
+	ValidPlayCondition *ConditionJSON   `json:"valid_play_condition,omitempty"`
+	Repeat             *PhaseRepeatJSON `json:"repeat,omitempty"`
+	RefillTo           int              `json:"refill_to,omitempty"`
+}
+
+// PhaseRepeatJSON for JSON serialization.
+type PhaseRepeatJSON struct {
+	Count int            `json:"count,omitempty"`
+	Until *ConditionJSON `json:"until,omitempty"`
 }
 
 // DiscardPhaseJSON for JSON serialization.
@@ -120,6 +199,7 @@ type DiscardPhaseJSON struct {
 	Target    string `json:"target"`
 	Count     int    `json:"count"`
 	Mandatory bool   `json:"mandatory"`
+	FaceDown  bool   `json:"face_down,omitempty"`
 }
 
 // TrickPhaseJSON for JSON serialization.
@@ -128,17 +208,31 @@ type TrickPhaseJSON struct {
 	TrumpSuit        string `json:"trump_suit,omitempty"`
 	HighCardWins     bool   `json:"high_card_wins"`
 	BreakingSuit     string `json:"breaking_suit,omitempty"`
+	TieRule          string `json:"tie_rule,omitempty"`
+	OpeningLead      string `json:"opening_lead,omitempty"`
+	NextLeader       string `json:"next_leader,omitempty"`
+	LeadCardRank     string `json:"lead_card_rank,omitempty"` // With opening_lead card_holder
+	LeadCardSuit     string `json:"lead_card_suit,omitempty"`
+	MustLeadCard     bool   `json:"must_lead_card,omitempty"`
+	MustBeat         bool   `json:"must_beat,omitempty"`
+	MustOvertrump    bool   `json:"must_overtrump,omitempty"`
+	TrumpDiscipline  string `json:"trump_discipline,omitempty"`
 }
 
 // BettingPhaseJSON for JSON serialization.
 type BettingPhaseJSON struct {
-	MinBet    int `json:"min_bet"`
-	MaxRaises int `json:"max_raises"`
+	MinBet     int   `json:"min_bet"`
+	MaxRaises  int   `json:"max_raises"`
+	MinPlayers int   `json:"min_players,omitempty"`
+	Blinds     int   `json:"blinds,omitempty"`
+	Community  []int `json:"community,omitempty"`
+	Burn       int   `json:"burn,omitempty"`
+	MuckLosers bool  `json:"muck_losers,omitempty"`
 }
 
 // ClaimPhaseJSON for JSON serialization.
 type ClaimPhaseJSON struct {
-	// Currently empty - claim mechanics are state-based
+	RankRule string `json:"rank_rule,omitempty"` // "sequential", "free" or "match_or_higher"
 }
 
 // BiddingPhaseJSON for JSON serialization.
@@ -153,6 +247,40 @@ type BiddingPhaseJSON struct {
 	NilPenalty            int  `json:"nil_penalty,omitempty"`
 	BagLimit              int  `json:"bag_limit,omitempty"`
 	BagPenalty            int  `json:"bag_penalty,omitempty"`
+	DeclareTrump          bool `json:"declare_trump,omitempty"`
+	Blind                 bool `json:"blind,omitempty"`
+}
+
+// ShowPhaseJSON for JSON serialization.
+type ShowPhaseJSON struct {
+	Award      string `json:"award"`
+	Points     int    `json:"points,omitempty"`
+	ClearHands bool   `json:"clear_hands,omitempty"`
+}
+
+// PeekPhaseJSON for JSON serialization.
+type PeekPhaseJSON struct {
+	Target   string `json:"target"`
+	Duration int    `json:"duration,omitempty"`
+}
+
+// GivePhaseJSON for JSON serialization.
+type GivePhaseJSON struct {
+	Target    string `json:"target"`
+	Mandatory bool   `json:"mandatory,omitempty"`
+}
+
+// TradePhaseJSON for JSON serialization.
+type TradePhaseJSON struct {
+	Target string `json:"target"`
+	Price  int    `json:"price,omitempty"`
+}
+
+// DrawDiscardPhaseJSON for JSON serialization.
+type DrawDiscardPhaseJSON struct {
+	Source      string `json:"source"`
+	Count       int    `json:"count,omitempty"`
+	TakeDiscard bool   `json:"take_discard,omitempty"`
 }
 
 // ConditionJSON for JSON serialization.
@@ -184,6 +312,7 @@ func (g *GameGenome) UnmarshalJSON(data []byte) error {
 	if g.Name == "" && jg.GenomeID != "" {
 		g.Name = jg.GenomeID
 	}
+	g.Generation = jg.Generation
 
 	// Parse setup from raw JSON to handle both formats
 	var setupJSON SetupRulesJSON
@@ -195,12 +324,48 @@ func (g *GameGenome) UnmarshalJSON(data []byte) error {
 		TableauSize:    setupJSON.TableauSize,
 		StartingChips:  setupJSON.StartingChips,
 		DealToTableau:  setupJSON.DealToTableau,
+		DealOrder:      parseDealOrder(setupJSON.DealOrder),
+		Penetration:    setupJSON.Penetration,
+		RotateDealer:   setupJSON.RotateDealer,
+		DealStrategy:   parseDealStrategy(setupJSON.DealStrategy),
+	}
+	g.Setup.StartCardEffect = setupJSON.StartCardEffect
+	g.Setup.MaxDrawsPerGame = setupJSON.MaxDrawsPerGame
+	g.Setup.BurnCards = setupJSON.BurnCards
+	g.Setup.PersonalDecks = setupJSON.PersonalDecks
+	g.Setup.PersonalDeckSize = setupJSON.PersonalDeckSize
+	g.Setup.HiddenHandSizes = setupJSON.HiddenHandSizes
+	g.Setup.HandShrink = setupJSON.HandShrink
+	g.Setup.Remainder = parseRemainder(setupJSON.Remainder)
+	if m := setupJSON.Misdeal; m != nil {
+		g.Setup.Misdeal = MisdealRule{
+			Condition:  parseMisdealCondition(m.Condition),
+			MaxRedeals: m.MaxRedeals,
+		}
+		if m.Rank != "" {
+			g.Setup.Misdeal.Rank = parseRank(m.Rank)
+		}
+		if m.Suit != "" {
+			g.Setup.Misdeal.Suit = parseSuit(m.Suit)
+		}
 	}
 
 	g.Effects = jg.Effects
 	g.CardScoring = jg.CardScoring
+	g.HandPenalty = parseHandPenalty(jg.HandPenalty)
+	g.RevealScoring = jg.RevealScoring
+	g.ScoreCarry = parseScoreCarry(jg.ScoreCarry)
+	g.ChipBridge = parseChipBridge(jg.ChipBridge)
+	g.PlacementPoints = jg.PlacementPoints
+	g.RankValues = jg.RankValues
+	g.WildRanks = jg.WildRanks
 	g.HandEval = jg.HandEval
 	g.Teams = jg.Teams
+	g.CatchUp = jg.CatchUp
+	g.MoonShot = jg.MoonShot
+	g.SetCleanup = jg.SetCleanup
+	g.CaptureScoring = jg.CaptureScoring
+	g.DiscardPiles = jg.DiscardPiles
 
 	// Convert Python SpecialEffects to Go Effects
 	if len(jg.SpecialEffects) > 0 {
@@ -227,6 +392,7 @@ func (g *GameGenome) UnmarshalJSON(data []byte) error {
 	} else {
 		g.TurnStructure.TableauMode = parseTableauMode(jg.TurnStructure.TableauMode)
 	}
+	g.TurnStructure.WarTieRule = parseWarTieRule(jg.TurnStructure.WarTieRule)
 
 	// Handle sequence direction from setup (Python format) or turn_structure (Go format)
 	if setupJSON.SequenceDirection != "" {
@@ -234,6 +400,14 @@ func (g *GameGenome) UnmarshalJSON(data []byte) error {
 	} else {
 		g.TurnStructure.SequenceDirection = parseSequenceDirection(jg.TurnStructure.SequenceDirection)
 	}
+	g.TurnStructure.AceMode = parseAceMode(jg.TurnStructure.AceMode)
+	g.TurnStructure.JumpIn = parseJumpInRule(jg.TurnStructure.JumpIn)
+	g.TurnStructure.MaxTurnsRule = parseMaxTurnsRule(jg.TurnStructure.MaxTurnsRule)
+	g.TurnStructure.TurnLimit = parseTurnLimit(jg.TurnStructure.TurnLimit)
+	g.TurnStructure.StuckRule = parseStuckRule(jg.TurnStructure.StuckRule)
+	g.TurnStructure.MaxEffectsPerTurn = jg.TurnStructure.MaxEffectsPerTurn
+	g.TurnStructure.MaxLegalMoves = jg.TurnStructure.MaxLegalMoves
+	g.TurnStructure.IsTrickBased = jg.TurnStructure.IsTrickBased
 
 	// Convert phases
 	phases := make([]Phase, 0, len(jg.TurnStructure.Phases))
@@ -256,6 +430,7 @@ func (g *GameGenome) UnmarshalJSON(data []byte) error {
 		g.WinConditions[i] = WinCondition{
 			Type:      parseWinConditionType(wc.Type),
 			Threshold: wc.Threshold,
+			Margin:    wc.Margin,
 		}
 	}
 
@@ -270,6 +445,36 @@ func (g *GameGenome) MarshalJSON() ([]byte, error) {
 		TableauSize:    g.Setup.TableauSize,
 		StartingChips:  g.Setup.StartingChips,
 		DealToTableau:  g.Setup.DealToTableau,
+		Penetration:    g.Setup.Penetration,
+		RotateDealer:   g.Setup.RotateDealer,
+	}
+	setupJSON.StartCardEffect = g.Setup.StartCardEffect
+	setupJSON.MaxDrawsPerGame = g.Setup.MaxDrawsPerGame
+	setupJSON.BurnCards = g.Setup.BurnCards
+	setupJSON.PersonalDecks = g.Setup.PersonalDecks
+	setupJSON.PersonalDeckSize = g.Setup.PersonalDeckSize
+	setupJSON.HiddenHandSizes = g.Setup.HiddenHandSizes
+	setupJSON.HandShrink = g.Setup.HandShrink
+	if g.Setup.DealOrder != DealRoundRobin {
+		setupJSON.DealOrder = dealOrderToString(g.Setup.DealOrder)
+	}
+	if g.Setup.DealStrategy != DealPreDealt {
+		setupJSON.DealStrategy = dealStrategyToString(g.Setup.DealStrategy)
+	}
+	if g.Setup.Remainder != RemainderDeck {
+		setupJSON.Remainder = remainderToString(g.Setup.Remainder)
+	}
+	if m := g.Setup.Misdeal; m.Condition != MisdealNone {
+		setupJSON.Misdeal = &MisdealRuleJSON{
+			Condition:  misdealConditionToString(m.Condition),
+			MaxRedeals: m.MaxRedeals,
+		}
+		switch m.Condition {
+		case MisdealNoSuit:
+			setupJSON.Misdeal.Suit = suitToString(m.Suit)
+		case MisdealAllBelow:
+			setupJSON.Misdeal.Rank = rankToString(m.Rank)
+		}
 	}
 	setupBytes, err := json.Marshal(setupJSON)
 	if err != nil {
@@ -283,12 +488,52 @@ func (g *GameGenome) MarshalJSON() ([]byte, error) {
 		CardScoring: g.CardScoring,
 		HandEval:    g.HandEval,
 		Teams:       g.Teams,
+		CatchUp:     g.CatchUp,
+		Generation:  g.Generation,
+	}
+	jg.CaptureScoring = g.CaptureScoring
+	jg.MoonShot = g.MoonShot
+	jg.SetCleanup = g.SetCleanup
+	jg.DiscardPiles = g.DiscardPiles
+	if g.HandPenalty != HandPenaltyNone {
+		jg.HandPenalty = handPenaltyToString(g.HandPenalty)
 	}
+	if g.ScoreCarry != ScoreCarryAccumulate {
+		jg.ScoreCarry = scoreCarryToString(g.ScoreCarry)
+	}
+	if g.ChipBridge != ChipBridgeNone {
+		jg.ChipBridge = chipBridgeToString(g.ChipBridge)
+	}
+	jg.RevealScoring = g.RevealScoring
+	jg.PlacementPoints = g.PlacementPoints
+	jg.RankValues = g.RankValues
+	jg.WildRanks = g.WildRanks
 
 	// Convert turn structure
 	jg.TurnStructure.MaxTurns = g.TurnStructure.MaxTurns
 	jg.TurnStructure.TableauMode = tableauModeToString(g.TurnStructure.TableauMode)
+	if g.TurnStructure.WarTieRule != WarTieAlternate {
+		jg.TurnStructure.WarTieRule = warTieRuleToString(g.TurnStructure.WarTieRule)
+	}
 	jg.TurnStructure.SequenceDirection = sequenceDirectionToString(g.TurnStructure.SequenceDirection)
+	if g.TurnStructure.AceMode != AceHigh {
+		jg.TurnStructure.AceMode = aceModeToString(g.TurnStructure.AceMode)
+	}
+	if g.TurnStructure.JumpIn != JumpInNone {
+		jg.TurnStructure.JumpIn = jumpInRuleToString(g.TurnStructure.JumpIn)
+	}
+	if g.TurnStructure.MaxTurnsRule != MaxTurnsDraw {
+		jg.TurnStructure.MaxTurnsRule = maxTurnsRuleToString(g.TurnStructure.MaxTurnsRule)
+	}
+	if g.TurnStructure.TurnLimit != TurnLimitTurns {
+		jg.TurnStructure.TurnLimit = turnLimitToString(g.TurnStructure.TurnLimit)
+	}
+	if g.TurnStructure.StuckRule != StuckEnd {
+		jg.TurnStructure.StuckRule = stuckRuleToString(g.TurnStructure.StuckRule)
+	}
+	jg.TurnStructure.MaxEffectsPerTurn = g.TurnStructure.MaxEffectsPerTurn
+	jg.TurnStructure.MaxLegalMoves = g.TurnStructure.MaxLegalMoves
+	jg.TurnStructure.IsTrickBased = g.TurnStructure.IsTrickBased
 
 	// Convert phases to raw JSON
 	jg.TurnStructure.Phases = make([]json.RawMessage, len(g.TurnStructure.Phases))
@@ -310,6 +555,7 @@ func (g *GameGenome) MarshalJSON() ([]byte, error) {
 		jg.WinConditions[i] = WinConditionJSON{
 			Type:      winConditionTypeToString(wc.Type),
 			Threshold: wc.Threshold,
+			Margin:    wc.Margin,
 		}
 	}
 
@@ -331,18 +577,22 @@ func parsePhase(pj PhaseJSON) (Phase, error) {
 				return nil, fmt.Errorf("invalid draw phase: %w", err)
 			}
 			return &DrawPhase{
-				Source:    parseLocation(dp.Source),
-				Count:     dp.Count,
-				Mandatory: dp.Mandatory,
-				Condition: parseCondition(dp.Condition),
+				Source:     parseLocation(dp.Source),
+				Count:      dp.Count,
+				Mandatory:  dp.Mandatory,
+				Condition:  parseCondition(dp.Condition),
+				Repeat:     parsePhaseRepeat(dp.Repeat),
+				MaxPerTurn: dp.MaxPerTurn,
 			}, nil
 		}
 		// Python format (flat structure)
 		return &DrawPhase{
-			Source:    parseLocation(pj.Source),
-			Count:     pj.Count,
-			Mandatory: pj.Mandatory,
-			Condition: parseCondition(pj.Condition),
+			Source:     parseLocation(pj.Source),
+			Count:      pj.Count,
+			Mandatory:  pj.Mandatory,
+			Condition:  parseCondition(pj.Condition),
+			Repeat:     parsePhaseRepeat(pj.Repeat),
+			MaxPerTurn: pj.MaxPerTurn,
 		}, nil
 
 	case "play":
@@ -359,6 +609,8 @@ func parsePhase(pj PhaseJSON) (Phase, error) {
 				Mandatory:          pp.Mandatory,
 				PassIfUnable:       pp.PassIfUnable,
 				ValidPlayCondition: parseCondition(pp.ValidPlayCondition),
+				Repeat:             parsePhaseRepeat(pp.Repeat),
+				RefillTo:           pp.RefillTo,
 			}, nil
 		}
 		// Python format (flat structure)
@@ -369,6 +621,8 @@ func parsePhase(pj PhaseJSON) (Phase, error) {
 			Mandatory:          pj.Mandatory,
 			PassIfUnable:       !pj.Mandatory, // Python uses mandatory=false, Go uses pass_if_unable=true
 			ValidPlayCondition: parseCondition(pj.ValidPlayCondition),
+			Repeat:             parsePhaseRepeat(pj.Repeat),
+			RefillTo:           pj.RefillTo,
 		}, nil
 
 	case "discard":
@@ -381,6 +635,7 @@ func parsePhase(pj PhaseJSON) (Phase, error) {
 				Target:    parseLocation(dp.Target),
 				Count:     dp.Count,
 				Mandatory: dp.Mandatory,
+				FaceDown:  dp.FaceDown,
 			}, nil
 		}
 		// Python format
@@ -388,6 +643,7 @@ func parsePhase(pj PhaseJSON) (Phase, error) {
 			Target:    parseLocation(pj.Target),
 			Count:     pj.Count,
 			Mandatory: pj.Mandatory,
+			FaceDown:  pj.FaceDown,
 		}, nil
 
 	case "trick":
@@ -401,6 +657,15 @@ func parsePhase(pj PhaseJSON) (Phase, error) {
 				TrumpSuit:        parseSuit(tp.TrumpSuit),
 				HighCardWins:     tp.HighCardWins,
 				BreakingSuit:     parseSuit(tp.BreakingSuit),
+				TieRule:          parseTrickTieRule(tp.TieRule),
+				OpeningLead:      parseOpeningLead(tp.OpeningLead),
+				NextLeader:       parseTrickLead(tp.NextLeader),
+				LeadCardRank:     parseRank(tp.LeadCardRank),
+				LeadCardSuit:     parseSuit(tp.LeadCardSuit),
+				MustLeadCard:     tp.MustLeadCard,
+				MustBeat:         tp.MustBeat,
+				MustOvertrump:    tp.MustOvertrump,
+				TrumpDiscipline:  parseTrumpDiscipline(tp.TrumpDiscipline),
 			}, nil
 		}
 		// Python format
@@ -417,6 +682,15 @@ func parsePhase(pj PhaseJSON) (Phase, error) {
 			TrumpSuit:        parseSuit(trumpSuit),
 			HighCardWins:     pj.HighCardWins,
 			BreakingSuit:     parseSuit(breakingSuit),
+			TieRule:          parseTrickTieRule(pj.TieRule),
+			OpeningLead:      parseOpeningLead(pj.OpeningLead),
+			NextLeader:       parseTrickLead(pj.NextLeader),
+			LeadCardRank:     parseRank(pj.LeadCardRank),
+			LeadCardSuit:     parseSuit(pj.LeadCardSuit),
+			MustLeadCard:     pj.MustLeadCard,
+			MustBeat:         pj.MustBeat,
+			MustOvertrump:    pj.MustOvertrump,
+			TrumpDiscipline:  parseTrumpDiscipline(pj.TrumpDiscipline),
 		}, nil
 
 	case "betting":
@@ -426,17 +700,35 @@ func parsePhase(pj PhaseJSON) (Phase, error) {
 				return nil, fmt.Errorf("invalid betting phase: %w", err)
 			}
 			return &BettingPhase{
-				MinBet:    bp.MinBet,
-				MaxRaises: bp.MaxRaises,
+				MinBet:     bp.MinBet,
+				MaxRaises:  bp.MaxRaises,
+				MinPlayers: bp.MinPlayers,
+				Blinds:     bp.Blinds,
+				Community:  bp.Community,
+				Burn:       bp.Burn,
+				MuckLosers: bp.MuckLosers,
 			}, nil
 		}
 		// Python format
 		return &BettingPhase{
-			MinBet:    pj.MinBet,
-			MaxRaises: pj.MaxRaises,
+			MinBet:     pj.MinBet,
+			MaxRaises:  pj.MaxRaises,
+			MinPlayers: pj.MinPlayers,
+			Blinds:     pj.Blinds,
+			Community:  pj.Community,
+			Burn:       pj.Burn,
+			MuckLosers: pj.MuckLosers,
 		}, nil
 
 	case "claim":
+		if pj.Data != nil && len(pj.Data) > 0 {
+			var cp ClaimPhaseJSON
+			if err := json.Unmarshal(pj.Data, &cp); err != nil {
+				return nil, fmt.Errorf("invalid claim phase: %w", err)
+			}
+			return &ClaimPhase{RankRule: parseClaimRankRule(cp.RankRule)}, nil
+		}
+		// Python format
 		return &ClaimPhase{}, nil
 
 	case "bidding":
@@ -456,13 +748,106 @@ func parsePhase(pj PhaseJSON) (Phase, error) {
 				NilPenalty:            bp.NilPenalty,
 				BagLimit:              bp.BagLimit,
 				BagPenalty:            bp.BagPenalty,
+				DeclareTrump:          bp.DeclareTrump,
+				Blind:                 bp.Blind,
 			}, nil
 		}
 		// Python format
 		return &BiddingPhase{
-			MinBid:   pj.MinBid,
-			MaxBid:   pj.MaxBid,
-			AllowNil: pj.AllowNil,
+			MinBid:       pj.MinBid,
+			MaxBid:       pj.MaxBid,
+			AllowNil:     pj.AllowNil,
+			DeclareTrump: pj.DeclareTrump,
+			Blind:        pj.Blind,
+		}, nil
+
+	case "show":
+		if pj.Data != nil && len(pj.Data) > 0 {
+			var sp ShowPhaseJSON
+			if err := json.Unmarshal(pj.Data, &sp); err != nil {
+				return nil, fmt.Errorf("invalid show phase: %w", err)
+			}
+			return &ShowPhase{
+				Award:      parseShowAward(sp.Award),
+				Points:     sp.Points,
+				ClearHands: sp.ClearHands,
+			}, nil
+		}
+		// Python format
+		return &ShowPhase{
+			Award:      parseShowAward(pj.Award),
+			Points:     pj.Points,
+			ClearHands: pj.ClearHands,
+		}, nil
+
+	case "peek":
+		if pj.Data != nil && len(pj.Data) > 0 {
+			var pp PeekPhaseJSON
+			if err := json.Unmarshal(pj.Data, &pp); err != nil {
+				return nil, fmt.Errorf("invalid peek phase: %w", err)
+			}
+			return &PeekPhase{
+				Target:   parsePeekTarget(pp.Target),
+				Duration: pp.Duration,
+			}, nil
+		}
+		// Python format
+		return &PeekPhase{
+			Target:   parsePeekTarget(pj.Target),
+			Duration: pj.Duration,
+		}, nil
+
+	case "draw_discard", "drawdiscard":
+		if pj.Data != nil && len(pj.Data) > 0 {
+			var dd DrawDiscardPhaseJSON
+			if err := json.Unmarshal(pj.Data, &dd); err != nil {
+				return nil, fmt.Errorf("invalid draw_discard phase: %w", err)
+			}
+			return &DrawDiscardPhase{
+				Source:      parseLocation(dd.Source),
+				Count:       dd.Count,
+				TakeDiscard: dd.TakeDiscard,
+			}, nil
+		}
+		// Python format
+		return &DrawDiscardPhase{
+			Source:      parseLocation(pj.Source),
+			Count:       pj.Count,
+			TakeDiscard: pj.TakeDiscard,
+		}, nil
+
+	case "give":
+		if pj.Data != nil && len(pj.Data) > 0 {
+			var gp GivePhaseJSON
+			if err := json.Unmarshal(pj.Data, &gp); err != nil {
+				return nil, fmt.Errorf("invalid give phase: %w", err)
+			}
+			return &GivePhase{
+				Target:    parseGiveTarget(gp.Target),
+				Mandatory: gp.Mandatory,
+			}, nil
+		}
+		// Python format
+		return &GivePhase{
+			Target:    parseGiveTarget(pj.Target),
+			Mandatory: pj.Mandatory,
+		}, nil
+
+	case "trade":
+		if pj.Data != nil && len(pj.Data) > 0 {
+			var tp TradePhaseJSON
+			if err := json.Unmarshal(pj.Data, &tp); err != nil {
+				return nil, fmt.Errorf("invalid trade phase: %w", err)
+			}
+			return &TradePhase{
+				Target: parseGiveTarget(tp.Target),
+				Price:  tp.Price,
+			}, nil
+		}
+		// Python format
+		return &TradePhase{
+			Target: parseGiveTarget(pj.Target),
+			Price:  pj.Price,
 		}, nil
 
 	default:
@@ -478,10 +863,12 @@ func marshalPhase(phase Phase) (PhaseJSON, error) {
 	case *DrawPhase:
 		pj.Type = "draw"
 		data = DrawPhaseJSON{
-			Source:    locationToString(p.Source),
-			Count:     p.Count,
-			Mandatory: p.Mandatory,
-			Condition: marshalCondition(p.Condition),
+			Source:     locationToString(p.Source),
+			Count:      p.Count,
+			Mandatory:  p.Mandatory,
+			Condition:  marshalCondition(p.Condition),
+			Repeat:     marshalPhaseRepeat(p.Repeat),
+			MaxPerTurn: p.MaxPerTurn,
 		}
 
 	case *PlayPhase:
@@ -493,6 +880,8 @@ func marshalPhase(phase Phase) (PhaseJSON, error) {
 			Mandatory:          p.Mandatory,
 			PassIfUnable:       p.PassIfUnable,
 			ValidPlayCondition: marshalCondition(p.ValidPlayCondition),
+			Repeat:             marshalPhaseRepeat(p.Repeat),
+			RefillTo:           p.RefillTo,
 		}
 
 	case *DiscardPhase:
@@ -501,27 +890,57 @@ func marshalPhase(phase Phase) (PhaseJSON, error) {
 			Target:    locationToString(p.Target),
 			Count:     p.Count,
 			Mandatory: p.Mandatory,
+			FaceDown:  p.FaceDown,
 		}
 
 	case *TrickPhase:
 		pj.Type = "trick"
-		data = TrickPhaseJSON{
+		tp := TrickPhaseJSON{
 			LeadSuitRequired: p.LeadSuitRequired,
 			TrumpSuit:        suitToString(p.TrumpSuit),
 			HighCardWins:     p.HighCardWins,
 			BreakingSuit:     suitToString(p.BreakingSuit),
+			MustBeat:         p.MustBeat,
+			MustOvertrump:    p.MustOvertrump,
+		}
+		if p.TieRule != TrickTieFirstPlayed {
+			tp.TieRule = trickTieRuleToString(p.TieRule)
 		}
+		if p.OpeningLead != OpeningLeadDealerLeft {
+			tp.OpeningLead = openingLeadToString(p.OpeningLead)
+		}
+		if p.NextLeader != TrickLeadWinner {
+			tp.NextLeader = trickLeadToString(p.NextLeader)
+		}
+		if p.TrumpDiscipline != TrumpFree {
+			tp.TrumpDiscipline = trumpDisciplineToString(p.TrumpDiscipline)
+		}
+		if p.OpeningLead == OpeningLeadCardHolder {
+			tp.LeadCardRank = rankToString(p.LeadCardRank)
+			tp.LeadCardSuit = suitToString(p.LeadCardSuit)
+			tp.MustLeadCard = p.MustLeadCard
+		}
+		data = tp
 
 	case *BettingPhase:
 		pj.Type = "betting"
 		data = BettingPhaseJSON{
-			MinBet:    p.MinBet,
-			MaxRaises: p.MaxRaises,
+			MinBet:     p.MinBet,
+			MaxRaises:  p.MaxRaises,
+			MinPlayers: p.MinPlayers,
+			Blinds:     p.Blinds,
+			Community:  p.Community,
+			Burn:       p.Burn,
+			MuckLosers: p.MuckLosers,
 		}
 
 	case *ClaimPhase:
 		pj.Type = "claim"
-		data = ClaimPhaseJSON{}
+		var cj ClaimPhaseJSON
+		if p.RankRule != ClaimRankSequential {
+			cj.RankRule = claimRankRuleToString(p.RankRule)
+		}
+		data = cj
 
 	case *BiddingPhase:
 		pj.Type = "bidding"
@@ -536,6 +955,45 @@ func marshalPhase(phase Phase) (PhaseJSON, error) {
 			NilPenalty:            p.NilPenalty,
 			BagLimit:              p.BagLimit,
 			BagPenalty:            p.BagPenalty,
+			DeclareTrump:          p.DeclareTrump,
+			Blind:                 p.Blind,
+		}
+
+	case *ShowPhase:
+		pj.Type = "show"
+		data = ShowPhaseJSON{
+			Award:      showAwardToString(p.Award),
+			Points:     p.Points,
+			ClearHands: p.ClearHands,
+		}
+
+	case *PeekPhase:
+		pj.Type = "peek"
+		data = PeekPhaseJSON{
+			Target:   peekTargetToString(p.Target),
+			Duration: p.Duration,
+		}
+
+	case *DrawDiscardPhase:
+		pj.Type = "draw_discard"
+		data = DrawDiscardPhaseJSON{
+			Source:      locationToString(p.Source),
+			Count:       p.Count,
+			TakeDiscard: p.TakeDiscard,
+		}
+
+	case *GivePhase:
+		pj.Type = "give"
+		data = GivePhaseJSON{
+			Target:    giveTargetToString(p.Target),
+			Mandatory: p.Mandatory,
+		}
+
+	case *TradePhase:
+		pj.Type = "trade"
+		data = TradePhaseJSON{
+			Target: giveTargetToString(p.Target),
+			Price:  p.Price,
 		}
 
 	default:
@@ -567,6 +1025,12 @@ func parseLocation(s string) Location {
 		return LocationOpponentHand
 	case "captured":
 		return LocationCaptured
+	case "burned":
+		return LocationBurned
+	case "exposed":
+		return LocationExposed
+	case "piles":
+		return LocationPiles
 	default:
 		return LocationDeck
 	}
@@ -586,6 +1050,12 @@ func locationToString(loc Location) string {
 		return "opponent_hand"
 	case LocationCaptured:
 		return "captured"
+	case LocationBurned:
+		return "burned"
+	case LocationExposed:
+		return "exposed"
+	case LocationPiles:
+		return "piles"
 	default:
 		return "deck"
 	}
@@ -653,6 +1123,30 @@ func tableauModeToString(mode TableauMode) string {
 	}
 }
 
+func parseWarTieRule(s string) WarTieRule {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "first_played":
+		return WarTieFirstPlayed
+	case "war", "carry_over":
+		return WarTieCarryOver
+	default:
+		return WarTieAlternate
+	}
+}
+
+func warTieRuleToString(rule WarTieRule) string {
+	switch rule {
+	case WarTieFirstPlayed:
+		return "first_played"
+	case WarTieCarryOver:
+		return "war"
+	default:
+		return "alternate"
+	}
+}
+
 func parseSequenceDirection(s string) SequenceDirection {
 	// Normalize to lowercase for matching
 	lower := strings.ToLower(s)
@@ -681,6 +1175,483 @@ func sequenceDirectionToString(dir SequenceDirection) string {
 	}
 }
 
+func parseAceMode(s string) AceMode {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "low":
+		return AceLow
+	case "both":
+		return AceBoth
+	default:
+		return AceHigh
+	}
+}
+
+func aceModeToString(mode AceMode) string {
+	switch mode {
+	case AceLow:
+		return "low"
+	case AceBoth:
+		return "both"
+	default:
+		return "high"
+	}
+}
+
+func parseTrickTieRule(s string) TrickTieRule {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "last":
+		return TrickTieLastPlayed
+	default:
+		return TrickTieFirstPlayed
+	}
+}
+
+func trickTieRuleToString(rule TrickTieRule) string {
+	switch rule {
+	case TrickTieLastPlayed:
+		return "last"
+	default:
+		return "first"
+	}
+}
+
+func parseTrickLead(s string) TrickLead {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "rotate":
+		return TrickLeadRotate
+	case "high_card":
+		return TrickLeadHighCard
+	default:
+		return TrickLeadWinner
+	}
+}
+
+func trickLeadToString(lead TrickLead) string {
+	switch lead {
+	case TrickLeadRotate:
+		return "rotate"
+	case TrickLeadHighCard:
+		return "high_card"
+	default:
+		return "winner"
+	}
+}
+
+func parseOpeningLead(s string) OpeningLead {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "dealer":
+		return OpeningLeadDealer
+	case "card_holder":
+		return OpeningLeadCardHolder
+	default:
+		return OpeningLeadDealerLeft
+	}
+}
+
+func openingLeadToString(lead OpeningLead) string {
+	switch lead {
+	case OpeningLeadDealer:
+		return "dealer"
+	case OpeningLeadCardHolder:
+		return "card_holder"
+	default:
+		return "dealer_left"
+	}
+}
+
+func parseTrumpDiscipline(s string) TrumpDiscipline {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "only_when_void":
+		return TrumpOnlyWhenVoid
+	case "must_trump_when_void":
+		return TrumpMustWhenVoid
+	default:
+		return TrumpFree
+	}
+}
+
+func trumpDisciplineToString(d TrumpDiscipline) string {
+	switch d {
+	case TrumpOnlyWhenVoid:
+		return "only_when_void"
+	case TrumpMustWhenVoid:
+		return "must_trump_when_void"
+	default:
+		return "free"
+	}
+}
+
+func parseJumpInRule(s string) JumpInRule {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "rank":
+		return JumpInRank
+	case "identical":
+		return JumpInIdentical
+	default:
+		return JumpInNone
+	}
+}
+
+func jumpInRuleToString(rule JumpInRule) string {
+	switch rule {
+	case JumpInRank:
+		return "rank"
+	case JumpInIdentical:
+		return "identical"
+	default:
+		return "none"
+	}
+}
+
+func parseMaxTurnsRule(s string) MaxTurnsRule {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "high_score":
+		return MaxTurnsHighScore
+	case "most_captured":
+		return MaxTurnsMostCaptured
+	default:
+		return MaxTurnsDraw
+	}
+}
+
+func maxTurnsRuleToString(rule MaxTurnsRule) string {
+	switch rule {
+	case MaxTurnsHighScore:
+		return "high_score"
+	case MaxTurnsMostCaptured:
+		return "most_captured"
+	default:
+		return "draw"
+	}
+}
+
+func parseTurnLimit(s string) TurnLimit {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "actions", "max_actions":
+		return TurnLimitActions
+	case "hands", "max_hands":
+		return TurnLimitHands
+	default:
+		return TurnLimitTurns
+	}
+}
+
+func turnLimitToString(limit TurnLimit) string {
+	switch limit {
+	case TurnLimitActions:
+		return "actions"
+	case TurnLimitHands:
+		return "hands"
+	default:
+		return "turns"
+	}
+}
+
+func parseStuckRule(s string) StuckRule {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "pass":
+		return StuckPass
+	default:
+		return StuckEnd
+	}
+}
+
+func stuckRuleToString(rule StuckRule) string {
+	switch rule {
+	case StuckPass:
+		return "pass"
+	default:
+		return "end"
+	}
+}
+
+func parseShowAward(s string) ShowAward {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "points":
+		return ShowAwardPoints
+	case "both":
+		return ShowAwardBoth
+	default:
+		return ShowAwardPot
+	}
+}
+
+func showAwardToString(award ShowAward) string {
+	switch award {
+	case ShowAwardPoints:
+		return "points"
+	case ShowAwardBoth:
+		return "both"
+	default:
+		return "pot"
+	}
+}
+
+func parseHandPenalty(s string) HandPenalty {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "self":
+		return HandPenaltySelf
+	case "winner":
+		return HandPenaltyWinner
+	default:
+		return HandPenaltyNone
+	}
+}
+
+func handPenaltyToString(p HandPenalty) string {
+	switch p {
+	case HandPenaltySelf:
+		return "self"
+	case HandPenaltyWinner:
+		return "winner"
+	default:
+		return "none"
+	}
+}
+
+func parseScoreCarry(s string) ScoreCarry {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "per_hand":
+		return ScoreCarryPerHand
+	default:
+		return ScoreCarryAccumulate
+	}
+}
+
+func scoreCarryToString(c ScoreCarry) string {
+	switch c {
+	case ScoreCarryPerHand:
+		return "per_hand"
+	default:
+		return "accumulate"
+	}
+}
+
+func parseChipBridge(s string) ChipBridge {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "points_win_pot":
+		return ChipBridgePointsWinPot
+	case "pot_to_score":
+		return ChipBridgePotToScore
+	default:
+		return ChipBridgeNone
+	}
+}
+
+func chipBridgeToString(b ChipBridge) string {
+	switch b {
+	case ChipBridgePointsWinPot:
+		return "points_win_pot"
+	case ChipBridgePotToScore:
+		return "pot_to_score"
+	default:
+		return "none"
+	}
+}
+
+func parsePeekTarget(s string) PeekTarget {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "previous", "previous_player":
+		return PeekTargetPrevious
+	case "all", "all_opponents":
+		return PeekTargetAll
+	default:
+		return PeekTargetNext
+	}
+}
+
+func peekTargetToString(target PeekTarget) string {
+	switch target {
+	case PeekTargetPrevious:
+		return "previous_player"
+	case PeekTargetAll:
+		return "all_opponents"
+	default:
+		return "next_player"
+	}
+}
+
+func parseGiveTarget(s string) GiveTarget {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "previous", "previous_player":
+		return GiveTargetPrevious
+	case "chosen", "chosen_opponent", "any_opponent":
+		return GiveTargetChosen
+	default:
+		return GiveTargetNext
+	}
+}
+
+func giveTargetToString(target GiveTarget) string {
+	switch target {
+	case GiveTargetPrevious:
+		return "previous_player"
+	case GiveTargetChosen:
+		return "chosen_opponent"
+	default:
+		return "next_player"
+	}
+}
+
+func parseDealOrder(s string) DealOrder {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "sequential":
+		return DealSequential
+	default:
+		return DealRoundRobin
+	}
+}
+
+func dealOrderToString(order DealOrder) string {
+	switch order {
+	case DealSequential:
+		return "sequential"
+	default:
+		return "round_robin"
+	}
+}
+
+func parseDealStrategy(s string) DealStrategy {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "shoe_draw":
+		return DealShoeDraw
+	default:
+		return DealPreDealt
+	}
+}
+
+func dealStrategyToString(strategy DealStrategy) string {
+	switch strategy {
+	case DealShoeDraw:
+		return "shoe_draw"
+	default:
+		return "pre_dealt"
+	}
+}
+
+func parseClaimRankRule(s string) ClaimRankRule {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "free", "player_choice":
+		return ClaimRankFree
+	case "match_or_higher":
+		return ClaimRankMatchOrHigher
+	default:
+		return ClaimRankSequential
+	}
+}
+
+func claimRankRuleToString(r ClaimRankRule) string {
+	switch r {
+	case ClaimRankFree:
+		return "free"
+	case ClaimRankMatchOrHigher:
+		return "match_or_higher"
+	default:
+		return "sequential"
+	}
+}
+
+func parseRemainder(s string) Remainder {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "kitty":
+		return RemainderKitty
+	case "burn", "burned":
+		return RemainderBurn
+	case "dealer":
+		return RemainderDealer
+	default:
+		return RemainderDeck
+	}
+}
+
+// MisdealRuleJSON is a setup's misdeal rule, its condition "no_face_cards",
+// "no_suit" (with suit) or "all_below" (with rank).
+type MisdealRuleJSON struct {
+	Condition  string `json:"condition"`
+	Rank       string `json:"rank,omitempty"`
+	Suit       string `json:"suit,omitempty"`
+	MaxRedeals int    `json:"max_redeals"`
+}
+
+func parseMisdealCondition(s string) MisdealCondition {
+	// Normalize to lowercase for matching
+	lower := strings.ToLower(s)
+	switch lower {
+	case "no_face_cards":
+		return MisdealNoFaceCards
+	case "no_suit", "no_trumps":
+		return MisdealNoSuit
+	case "all_below":
+		return MisdealAllBelow
+	default:
+		return MisdealNone
+	}
+}
+
+func misdealConditionToString(c MisdealCondition) string {
+	switch c {
+	case MisdealNoFaceCards:
+		return "no_face_cards"
+	case MisdealNoSuit:
+		return "no_suit"
+	case MisdealAllBelow:
+		return "all_below"
+	default:
+		return "none"
+	}
+}
+
+func remainderToString(r Remainder) string {
+	switch r {
+	case RemainderKitty:
+		return "kitty"
+	case RemainderBurn:
+		return "burn"
+	case RemainderDealer:
+		return "dealer"
+	default:
+		return "deck"
+	}
+}
+
 func parseWinConditionType(s string) WinConditionType {
 	// Normalize to lowercase for matching
 	lower := strings.ToLower(s)
@@ -701,6 +1672,10 @@ func parseWinConditionType(s string) WinConditionType {
 		return WinTypeBestHand
 	case "most_captured":
 		return WinTypeMostCaptured
+	case "match_wins":
+		return WinTypeMatchWins
+	case "last_chip_standing":
+		return WinTypeLastChipStanding
 	default:
 		return WinTypeEmptyHand
 	}
@@ -724,6 +1699,10 @@ func winConditionTypeToString(wct WinConditionType) string {
 		return "best_hand"
 	case WinTypeMostCaptured:
 		return "most_captured"
+	case WinTypeMatchWins:
+		return "match_wins"
+	case WinTypeLastChipStanding:
+		return "last_chip_standing"
 	default:
 		return "empty_hand"
 	}
@@ -819,19 +1798,19 @@ func parsePythonOperator(s string) uint8 {
 	upper := strings.ToUpper(s)
 	switch upper {
 	case "EQ", "EQUALS", "==":
-		return 50
+		return 0
 	case "NE", "NOT_EQUALS", "!=":
-		return 51
+		return 1
 	case "LT", "LESS_THAN", "<":
-		return 52
+		return 2
 	case "GT", "GREATER_THAN", ">":
-		return 53
+		return 3
 	case "LE", "LESS_EQUAL", "<=":
-		return 54
+		return 4
 	case "GE", "GREATER_EQUAL", ">=":
-		return 55
+		return 5
 	default:
-		return 50 // default to equality
+		return 0 // default to equality
 	}
 }
 
@@ -847,6 +1826,26 @@ func marshalCondition(c *Condition) *ConditionJSON {
 	}
 }
 
+func parsePhaseRepeat(rj *PhaseRepeatJSON) *PhaseRepeat {
+	if rj == nil {
+		return nil
+	}
+	return &PhaseRepeat{
+		Count: rj.Count,
+		Until: parseCondition(rj.Until),
+	}
+}
+
+func marshalPhaseRepeat(r *PhaseRepeat) *PhaseRepeatJSON {
+	if r == nil {
+		return nil
+	}
+	return &PhaseRepeatJSON{
+		Count: r.Count,
+		Until: marshalCondition(r.Until),
+	}
+}
+
 func parseOpCode(s string) uint8 {
 	switch s {
 	case "check_hand_size":
@@ -896,35 +1895,35 @@ func opCodeToString(op uint8) string {
 func parseOperator(s string) uint8 {
 	switch s {
 	case "eq":
-		return 50
+		return 0
 	case "ne":
-		return 51
+		return 1
 	case "lt":
-		return 52
+		return 2
 	case "gt":
-		return 53
+		return 3
 	case "le":
-		return 54
+		return 4
 	case "ge":
-		return 55
+		return 5
 	default:
-		return 50
+		return 0
 	}
 }
 
 func operatorToString(op uint8) string {
 	switch op {
-	case 50:
+	case 0:
 		return "eq"
-	case 51:
+	case 1:
 		return "ne"
-	case 52:
+	case 2:
 		return "lt"
-	case 53:
+	case 3:
 		return "gt"
-	case 54:
+	case 4:
 		return "le"
-	case 55:
+	case 5:
 		return "ge"
 	default:
 		return "eq"
@@ -978,7 +1977,7 @@ func parseEffectType(s string) EffectType {
 		return EffectDrawTwo
 	case "DRAW_FOUR":
 		return EffectDrawFour
-	case "WILD":
+	case "WILD_CARD", "WILD":
 		return EffectWild
 	case "SWAP_HANDS":
 		return EffectSwapHands
@@ -990,6 +1989,10 @@ func parseEffectType(s string) EffectType {
 		return EffectPeekHand
 	case "DISCARD_PILE":
 		return EffectDiscardPile
+	case "FORCE_DISCARD":
+		return EffectForceDiscard
+	case "FORCE_REVEAL", "REVEAL":
+		return EffectForceReveal
 	default:
 		return EffectSkipNext
 	}
@@ -1106,6 +2109,53 @@ func LoadGenomeFromJSON(data []byte) (*GameGenome, error) {
 	return &genome, nil
 }
 
+// LoadGenomeFile reads a genome from a JSON file. Besides bare genomes it
+// accepts the files saved by an evolution run, which wrap the genome with
+// its fitness under a "genome" key.
+func LoadGenomeFile(path string) (*GameGenome, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var wrapped struct {
+		Genome json.RawMessage `json:"genome"`
+	}
+	if json.Unmarshal(data, &wrapped) == nil && len(wrapped.Genome) > 0 {
+		data = wrapped.Genome
+	}
+	return LoadGenomeFromJSON(data)
+}
+
+// LoadGenomeDir loads and validates every .json genome in dir, in file name
+// order. A file that can't be read, parsed or validated is reported in
+// skipped, so one bad file doesn't stop the rest from loading; err is set
+// only when the directory itself can't be read.
+func LoadGenomeDir(dir string) (genomes []*GameGenome, skipped []error, err error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, path := range paths {
+		name := filepath.Base(path)
+		g, err := LoadGenomeFile(path)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		if invalid := ValidateGenome(g); len(invalid) > 0 {
+			skipped = append(skipped, fmt.Errorf("%s: invalid genome: %w", name, invalid[0]))
+			continue
+		}
+		genomes = append(genomes, g)
+	}
+	return genomes, skipped, nil
+}
+
 // SaveGenomeToJSON serializes a GameGenome to JSON bytes.
 func SaveGenomeToJSON(genome *GameGenome) ([]byte, error) {
 	return json.MarshalIndent(genome, "", "  ")
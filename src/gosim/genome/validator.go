@@ -35,7 +35,21 @@ func (v *GenomeValidator) Validate(genome *GameGenome) []ValidationError {
 	playerCount := DefaultPlayerCount
 
 	// Check 0: Setup requires valid number of cards
-	cardsNeeded := genome.Setup.CardsPerPlayer * playerCount
+	cardsNeeded := genome.Setup.CardsPerPlayer*playerCount + genome.Setup.BurnCards
+	if genome.Setup.PersonalDecks {
+		// Hands come from each player's own deck
+		cardsNeeded = genome.Setup.BurnCards
+		deckSize := genome.Setup.PersonalDeckSize
+		if deckSize == 0 {
+			deckSize = StandardDeckSize
+		}
+		if deckSize < 0 || deckSize > StandardDeckSize || genome.Setup.CardsPerPlayer > deckSize {
+			errors = append(errors, ValidationError{
+				Field:   "setup.personal_deck_size",
+				Message: fmt.Sprintf("Personal decks of %d cards cannot deal %d-card hands", genome.Setup.PersonalDeckSize, genome.Setup.CardsPerPlayer),
+			})
+		}
+	}
 	if cardsNeeded > StandardDeckSize {
 		errors = append(errors, ValidationError{
 			Field:   "setup.cards_per_player",
@@ -63,11 +77,11 @@ func (v *GenomeValidator) Validate(genome *GameGenome) []ValidationError {
 		}
 	}
 	if hasScoreWin {
-		hasScoring := len(genome.CardScoring) > 0
+		hasScoring := len(genome.CardScoring) > 0 || hasShowPoints(genome) || genome.HandPenalty != HandPenaltyNone || genome.RevealScoring || len(genome.PlacementPoints) > 0 || len(genome.CaptureScoring) > 0
 		if !hasScoring {
 			errors = append(errors, ValidationError{
 				Field:   "win_conditions",
-				Message: "Score-based win condition requires card_scoring",
+				Message: "Score-based win condition requires card_scoring, a hand_penalty, reveal_scoring, placement_points, capture_scoring, or a ShowPhase awarding points",
 			})
 		}
 	}
@@ -99,6 +113,17 @@ func (v *GenomeValidator) Validate(genome *GameGenome) []ValidationError {
 		})
 	}
 
+	// Check 4: A show that only awards the pot needs chips to win
+	for _, phase := range genome.TurnStructure.Phases {
+		if sp, ok := phase.(*ShowPhase); ok && sp.Award == ShowAwardPot && genome.Setup.StartingChips <= 0 {
+			errors = append(errors, ValidationError{
+				Field:   "turn_structure.phases",
+				Message: "ShowPhase awarding the pot requires setup.starting_chips > 0",
+			})
+			break
+		}
+	}
+
 	// Check 5: Capture wins require capture mechanic
 	captureWins := map[WinConditionType]bool{
 		WinTypeCaptureAll:   true,
@@ -145,7 +170,7 @@ func (v *GenomeValidator) Validate(genome *GameGenome) []ValidationError {
 	hasCardPlay := false
 	for _, phase := range genome.TurnStructure.Phases {
 		switch phase.(type) {
-		case *PlayPhase, *DrawPhase, *DiscardPhase, *TrickPhase:
+		case *PlayPhase, *DrawPhase, *DiscardPhase, *TrickPhase, *DrawDiscardPhase:
 			hasCardPlay = true
 			break
 		}
@@ -171,6 +196,12 @@ func (v *GenomeValidator) Validate(genome *GameGenome) []ValidationError {
 					})
 				}
 			}
+			if bp.MinPlayers < 0 {
+				errors = append(errors, ValidationError{
+					Field:   "betting_phase.min_players",
+					Message: fmt.Sprintf("BettingPhase min_players (%d) cannot be negative", bp.MinPlayers),
+				})
+			}
 		}
 	}
 
@@ -180,6 +211,367 @@ func (v *GenomeValidator) Validate(genome *GameGenome) []ValidationError {
 	// Check 10: Bidding configuration validation
 	errors = append(errors, v.validateBidding(genome)...)
 
+	// Check 11: Penetration is a fraction of the deck
+	if genome.Setup.Penetration < 0 || genome.Setup.Penetration > 1 {
+		errors = append(errors, ValidationError{
+			Field:   "setup.penetration",
+			Message: fmt.Sprintf("Penetration %.2f must be between 0 and 1", genome.Setup.Penetration),
+		})
+	}
+
+	// Check 12: Hands can only be refilled to a size every player can hold
+	for _, phase := range genome.TurnStructure.Phases {
+		if pp, ok := phase.(*PlayPhase); ok {
+			if pp.RefillTo < 0 || pp.RefillTo*playerCount > StandardDeckSize {
+				errors = append(errors, ValidationError{
+					Field:   "play_phase.refill_to",
+					Message: fmt.Sprintf("PlayPhase refill_to (%d) must be between 0 and %d", pp.RefillTo, StandardDeckSize/playerCount),
+				})
+			}
+		}
+	}
+
+	// Check 13: A peek lasts a whole number of turns the engine can count
+	for _, phase := range genome.TurnStructure.Phases {
+		if pp, ok := phase.(*PeekPhase); ok && (pp.Duration < 0 || pp.Duration > MaxPeekDuration) {
+			errors = append(errors, ValidationError{
+				Field:   "peek_phase.duration",
+				Message: fmt.Sprintf("PeekPhase duration (%d) must be between 0 and %d", pp.Duration, MaxPeekDuration),
+			})
+		}
+	}
+
+	// Check 14: The effect cap cannot be negative
+	if genome.TurnStructure.MaxEffectsPerTurn < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "turn_structure.max_effects_per_turn",
+			Message: fmt.Sprintf("max_effects_per_turn (%d) cannot be negative", genome.TurnStructure.MaxEffectsPerTurn),
+		})
+	}
+	if genome.TurnStructure.MaxLegalMoves < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "turn_structure.max_legal_moves",
+			Message: fmt.Sprintf("max_legal_moves (%d) cannot be negative", genome.TurnStructure.MaxLegalMoves),
+		})
+	}
+
+	// Check 15: Community cards are dealt in streets of at least one card
+	for _, phase := range genome.TurnStructure.Phases {
+		if bp, ok := phase.(*BettingPhase); ok {
+			for _, n := range bp.Community {
+				if n < 1 {
+					errors = append(errors, ValidationError{
+						Field:   "betting_phase.community",
+						Message: fmt.Sprintf("BettingPhase community streets must deal at least 1 card, got %d", n),
+					})
+					break
+				}
+			}
+		}
+	}
+
+	// Check 16: A match is won hand by hand, so its hands are scored alone
+	if winTypes[WinTypeMatchWins] && genome.ScoreCarry != ScoreCarryPerHand {
+		errors = append(errors, ValidationError{
+			Field:   "win_conditions",
+			Message: "match_wins win condition requires score_carry per_hand",
+		})
+	}
+
+	// Check 17: Draw limits cannot be negative
+	if genome.Setup.MaxDrawsPerGame < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "setup.max_draws_per_game",
+			Message: fmt.Sprintf("MaxDrawsPerGame cannot be negative, got %d", genome.Setup.MaxDrawsPerGame),
+		})
+	}
+	for _, phase := range genome.TurnStructure.Phases {
+		if dp, ok := phase.(*DrawPhase); ok && dp.MaxPerTurn < 0 {
+			errors = append(errors, ValidationError{
+				Field:   "draw_phase.max_per_turn",
+				Message: fmt.Sprintf("DrawPhase MaxPerTurn cannot be negative, got %d", dp.MaxPerTurn),
+			})
+			break
+		}
+	}
+
+	// Check 18: Placement scoring ranks players by going out
+	if len(genome.PlacementPoints) > 0 && !winTypes[WinTypeEmptyHand] {
+		errors = append(errors, ValidationError{
+			Field:   "placement_points",
+			Message: "Placement points require an empty_hand win condition",
+		})
+	}
+
+	// Check 19: Burn counts cannot be negative
+	if genome.Setup.BurnCards < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "setup.burn_cards",
+			Message: fmt.Sprintf("BurnCards cannot be negative, got %d", genome.Setup.BurnCards),
+		})
+	}
+	for _, phase := range genome.TurnStructure.Phases {
+		if bp, ok := phase.(*BettingPhase); ok && bp.Burn < 0 {
+			errors = append(errors, ValidationError{
+				Field:   "betting_phase.burn",
+				Message: fmt.Sprintf("BettingPhase Burn cannot be negative, got %d", bp.Burn),
+			})
+			break
+		}
+	}
+
+	// Check 20: A catch-up bonus cannot take cards away
+	if genome.CatchUp != nil && genome.CatchUp.Draw < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "catch_up.draw",
+			Message: fmt.Sprintf("CatchUp Draw cannot be negative, got %d", genome.CatchUp.Draw),
+		})
+	}
+
+	// Check 21: Capture categories name real cards
+	for _, c := range genome.CaptureScoring {
+		if c.Kind > CapturePoints || c.Suit > 3 || c.Rank > 12 {
+			errors = append(errors, ValidationError{
+				Field:   "capture_scoring",
+				Message: fmt.Sprintf("Invalid capture category %+v", c),
+			})
+		} else if len(c.PrimeValues) != 0 && len(c.PrimeValues) != 13 {
+			errors = append(errors, ValidationError{
+				Field:   "capture_scoring.prime_values",
+				Message: fmt.Sprintf("PrimeValues needs one value per rank, got %d", len(c.PrimeValues)),
+			})
+		}
+	}
+
+	// Check 22: Only a trick game can shoot the moon
+	if genome.MoonShot != nil && !hasTrickPhase(genome) {
+		errors = append(errors, ValidationError{
+			Field:   "moon_shot",
+			Message: "Shooting the moon requires a TrickPhase",
+		})
+	}
+
+	// Check 23: A chip tournament is played out over betting hands
+	if winTypes[WinTypeLastChipStanding] && !hasBetting {
+		errors = append(errors, ValidationError{
+			Field:   "win_conditions",
+			Message: "last_chip_standing win condition requires a BettingPhase",
+		})
+	}
+
+	// Check 24: Each win condition can be met by what the phases do. Hand
+	// penalties, reveals and capture categories only score once the game
+	// is over, too late to reach a score threshold, though they can still
+	// decide a game that another win condition ends.
+	scoresAtEnd := genome.HandPenalty != HandPenaltyNone || genome.RevealScoring || len(genome.CaptureScoring) > 0
+	for _, wc := range genome.WinConditions {
+		reason := ""
+		switch wc.Type {
+		case WinTypeHighScore, WinTypeFirstToScore, WinTypeLowScore:
+			endedElsewhere := scoresAtEnd && len(winTypes) > 1
+			if wc.Threshold > 0 && !scoresDuringPlay(genome) && !endedElsewhere {
+				reason = "no phase scores points during play"
+			}
+		case WinTypeEmptyHand, WinTypeAllHandsEmpty, WinTypeCaptureAll:
+			if !shedsCards(genome) {
+				reason = "no phase takes cards out of a hand"
+			}
+		case WinTypeMostCaptured:
+			setsCaptured := genome.SetCleanup != nil && genome.SetCleanup.Captured
+			if !hasTrickPhase(genome) && !capturesFromTableau(genome) && !setsCaptured {
+				reason = "no phase captures cards"
+			}
+		case WinTypeBestHand:
+			if !comparesHands(genome) {
+				reason = "no BettingPhase or ShowPhase compares hands"
+			}
+		}
+		if reason != "" {
+			errors = append(errors, ValidationError{
+				Field:   "win_conditions",
+				Message: fmt.Sprintf("%s win condition can never be met: %s", winConditionTypeToString(wc.Type), reason),
+			})
+		}
+	}
+
+	// Check 25: A trade for chips needs chips to pay with
+	for _, phase := range genome.TurnStructure.Phases {
+		if tp, ok := phase.(*TradePhase); ok {
+			if tp.Price < 0 {
+				errors = append(errors, ValidationError{
+					Field:   "trade_phase.price",
+					Message: fmt.Sprintf("TradePhase price must be non-negative, got %d", tp.Price),
+				})
+			} else if tp.Price > 0 && genome.Setup.StartingChips <= 0 {
+				errors = append(errors, ValidationError{
+					Field:   "trade_phase.price",
+					Message: "TradePhase with a chip price requires setup.starting_chips > 0",
+				})
+			}
+		}
+	}
+
+	// Check 26: A rank value table covers every rank
+	if len(genome.RankValues) != 0 && len(genome.RankValues) != 13 {
+		errors = append(errors, ValidationError{
+			Field:   "rank_values",
+			Message: fmt.Sprintf("RankValues needs one value per rank, got %d", len(genome.RankValues)),
+		})
+	}
+
+	// Check 27: A set takes at least two cards, and is completed by drawing
+	if genome.SetCleanup != nil {
+		if genome.SetCleanup.Size < 2 {
+			errors = append(errors, ValidationError{
+				Field:   "set_cleanup.size",
+				Message: fmt.Sprintf("SetCleanup Size must be at least 2, got %d", genome.SetCleanup.Size),
+			})
+		}
+		if !drawsCards(genome) {
+			errors = append(errors, ValidationError{
+				Field:   "set_cleanup",
+				Message: "SetCleanup requires a DrawPhase or DrawDiscardPhase",
+			})
+		}
+	}
+
+	// Check 28: Only the cards left in hand at the end are melded
+	for _, rule := range genome.CardScoring {
+		if rule.Meld > MeldUnmatched || (rule.Meld != MeldAny && rule.Trigger != TriggerHandEnd) {
+			errors = append(errors, ValidationError{
+				Field:   "card_scoring.meld",
+				Message: fmt.Sprintf("Meld filter %d needs a hand_end trigger", rule.Meld),
+			})
+		}
+	}
+
+	// Check 29: Wild ranks are real ranks
+	for _, r := range genome.WildRanks {
+		if r < 0 || r > 12 {
+			errors = append(errors, ValidationError{
+				Field:   "wild_ranks",
+				Message: fmt.Sprintf("Wild rank must be 0-12, got %d", r),
+			})
+		}
+	}
+
+	// Check 30: Shrinking hands knock out players who take no trick
+	if genome.Setup.HandShrink < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "setup.hand_shrink",
+			Message: fmt.Sprintf("Hand shrink must be non-negative, got %d", genome.Setup.HandShrink),
+		})
+	} else if genome.Setup.HandShrink > 0 && !hasTrickPhase(genome) {
+		errors = append(errors, ValidationError{
+			Field:   "setup.hand_shrink",
+			Message: "Shrinking hands require a trick phase",
+		})
+	}
+
+	// Check 31: Chips are only bridged to points in a betting game
+	if genome.ChipBridge > ChipBridgePotToScore {
+		errors = append(errors, ValidationError{
+			Field:   "chip_bridge",
+			Message: fmt.Sprintf("Unknown chip bridge %d", genome.ChipBridge),
+		})
+	} else if genome.ChipBridge != ChipBridgeNone && genome.Setup.StartingChips <= 0 {
+		errors = append(errors, ValidationError{
+			Field:   "chip_bridge",
+			Message: "A chip bridge requires starting_chips",
+		})
+	}
+
+	// Check 32: A kitty is only taken by a winning bidder
+	if genome.Setup.Remainder > RemainderDealer {
+		errors = append(errors, ValidationError{
+			Field:   "setup.remainder",
+			Message: fmt.Sprintf("Unknown remainder disposition %d", genome.Setup.Remainder),
+		})
+	} else if genome.Setup.Remainder == RemainderKitty && !hasBiddingPhase(genome) {
+		errors = append(errors, ValidationError{
+			Field:   "setup.remainder",
+			Message: "A kitty requires a bidding phase to award it",
+		})
+	}
+
+	// Check 33: Claims name their rank by a known rule
+	for _, phase := range genome.TurnStructure.Phases {
+		if cp, ok := phase.(*ClaimPhase); ok && cp.RankRule > ClaimRankMatchOrHigher {
+			errors = append(errors, ValidationError{
+				Field:   "claim_phase.rank_rule",
+				Message: fmt.Sprintf("Unknown claim rank rule %d", cp.RankRule),
+			})
+		}
+	}
+
+	// Check 34: Exposed cards are scored only if some play lays them out
+	if hasScoringTrigger(genome, TriggerExposed) && !playsTo(genome, LocationExposed) {
+		errors = append(errors, ValidationError{
+			Field:   "card_scoring.trigger",
+			Message: "Exposed card scoring requires a play phase targeting the exposed area",
+		})
+	}
+
+	// Check 35: A misdeal names a real hand and redeals a shared deck
+	if m := genome.Setup.Misdeal; m.Condition != MisdealNone {
+		switch {
+		case m.Condition > MisdealAllBelow:
+			errors = append(errors, ValidationError{
+				Field:   "setup.misdeal",
+				Message: fmt.Sprintf("Unknown misdeal condition %d", m.Condition),
+			})
+		case m.MaxRedeals < 0:
+			errors = append(errors, ValidationError{
+				Field:   "setup.misdeal",
+				Message: fmt.Sprintf("MaxRedeals must be non-negative, got %d", m.MaxRedeals),
+			})
+		case m.Condition == MisdealNoSuit && m.Suit > 3, m.Condition == MisdealAllBelow && m.Rank > 12:
+			errors = append(errors, ValidationError{
+				Field:   "setup.misdeal",
+				Message: "A misdeal's suit or rank is not a real one",
+			})
+		case genome.Setup.PersonalDecks:
+			errors = append(errors, ValidationError{
+				Field:   "setup.misdeal",
+				Message: "Misdeals cannot be redealt from personal decks",
+			})
+		}
+	}
+
+	// Check 36: Only games won on reaching a score are won by a margin
+	for _, wc := range genome.WinConditions {
+		if wc.Margin < 0 || (wc.Margin > 0 && wc.Type != WinTypeHighScore && wc.Type != WinTypeFirstToScore) {
+			errors = append(errors, ValidationError{
+				Field:   "win_conditions.margin",
+				Message: fmt.Sprintf("Margin %d needs a high_score or first_to_score win condition", wc.Margin),
+			})
+		}
+	}
+
+	// Check 37: Extra discard piles are real piles that something plays to
+	if len(genome.DiscardPiles) > MaxDiscardPiles {
+		errors = append(errors, ValidationError{
+			Field:   "discard_piles",
+			Message: fmt.Sprintf("At most %d discard piles, got %d", MaxDiscardPiles, len(genome.DiscardPiles)),
+		})
+	}
+	for _, p := range genome.DiscardPiles {
+		if (p.Suit > 3 && p.Suit != 255) || (p.StartRank > 12 && p.StartRank != 255) || p.Build > PileBuildDown {
+			errors = append(errors, ValidationError{
+				Field:   "discard_piles",
+				Message: fmt.Sprintf("Discard pile %q has an unknown suit, start rank or build", p.Name),
+			})
+		}
+	}
+	for _, phase := range genome.TurnStructure.Phases {
+		if p, ok := phase.(*PlayPhase); ok && p.Target == LocationPiles && (len(genome.DiscardPiles) == 0 || p.MinCards > 1) {
+			errors = append(errors, ValidationError{
+				Field:   "play_phase.target",
+				Message: "Plays to the discard piles need piles and play one card at a time",
+			})
+		}
+	}
+
 	return errors
 }
 
@@ -244,6 +636,144 @@ func (v *GenomeValidator) validateTeams(genome *GameGenome) []ValidationError {
 	return errors
 }
 
+// hasShowPoints reports whether any ShowPhase awards points to its winners.
+func hasShowPoints(genome *GameGenome) bool {
+	for _, phase := range genome.TurnStructure.Phases {
+		if sp, ok := phase.(*ShowPhase); ok && sp.Award != ShowAwardPot && sp.Points != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasBiddingPhase reports whether the genome bids for contracts.
+func hasBiddingPhase(genome *GameGenome) bool {
+	for _, phase := range genome.TurnStructure.Phases {
+		if _, ok := phase.(*BiddingPhase); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTrickPhase reports whether the genome plays tricks.
+func hasTrickPhase(genome *GameGenome) bool {
+	for _, phase := range genome.TurnStructure.Phases {
+		if _, ok := phase.(*TrickPhase); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// scoresDuringPlay reports whether any phase or rule adds to a player's
+// score while the game is still going: trick points (the implicit Hearts
+// points when there are no card scoring rules), rank-match captures and
+// sweeps, sets played to the discard, shows, placings, contracts or the
+// catch-up bonus.
+func scoresDuringPlay(genome *GameGenome) bool {
+	if len(genome.PlacementPoints) > 0 || hasShowPoints(genome) || (genome.CatchUp != nil && genome.CatchUp.Points != 0) || (genome.SetCleanup != nil && genome.SetCleanup.Points != 0) {
+		return true
+	}
+	if hasTrickPhase(genome) && (len(genome.CardScoring) == 0 || hasScoringTrigger(genome, TriggerTrickWin)) {
+		return true
+	}
+	if capturesFromTableau(genome) && (len(genome.CaptureScoring) == 0 || hasScoringTrigger(genome, TriggerSweep)) {
+		return true
+	}
+	for _, phase := range genome.TurnStructure.Phases {
+		switch p := phase.(type) {
+		case *BiddingPhase:
+			return true
+		case *PlayPhase:
+			if p.Target == LocationDiscard && p.MinCards > 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasScoringTrigger reports whether a card scoring rule with trigger is
+// worth any points.
+func hasScoringTrigger(genome *GameGenome, trigger ScoringTrigger) bool {
+	for _, rule := range genome.CardScoring {
+		if rule.Trigger == trigger && rule.Points != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// playsTo reports whether any play phase plays cards to target.
+func playsTo(genome *GameGenome, target Location) bool {
+	for _, phase := range genome.TurnStructure.Phases {
+		if p, ok := phase.(*PlayPhase); ok && p.Target == target {
+			return true
+		}
+	}
+	return false
+}
+
+// capturesFromTableau reports whether cards played to the tableau capture
+// the matching cards there.
+func capturesFromTableau(genome *GameGenome) bool {
+	if genome.TurnStructure.TableauMode != TableauModeMatchRank {
+		return false
+	}
+	for _, phase := range genome.TurnStructure.Phases {
+		if p, ok := phase.(*PlayPhase); ok && p.Target == LocationTableau {
+			return true
+		}
+	}
+	return false
+}
+
+// shedsCards reports whether any phase, or laying down sets, takes cards
+// out of a player's hand.
+func shedsCards(genome *GameGenome) bool {
+	if genome.SetCleanup != nil {
+		return true
+	}
+	for _, phase := range genome.TurnStructure.Phases {
+		switch p := phase.(type) {
+		case *PlayPhase:
+			if p.MaxCards > 0 {
+				return true
+			}
+		case *DiscardPhase, *DrawDiscardPhase, *TrickPhase, *ClaimPhase, *GivePhase:
+			return true
+		case *TradePhase:
+			if p.Price > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// drawsCards reports whether any phase draws cards into a hand.
+func drawsCards(genome *GameGenome) bool {
+	for _, phase := range genome.TurnStructure.Phases {
+		switch phase.(type) {
+		case *DrawPhase, *DrawDiscardPhase:
+			return true
+		}
+	}
+	return false
+}
+
+// comparesHands reports whether a phase compares hands to pick a winner.
+func comparesHands(genome *GameGenome) bool {
+	for _, phase := range genome.TurnStructure.Phases {
+		switch phase.(type) {
+		case *BettingPhase, *ShowPhase:
+			return true
+		}
+	}
+	return false
+}
+
 // validateBidding validates bidding phase configuration.
 func (v *GenomeValidator) validateBidding(genome *GameGenome) []ValidationError {
 	var errors []ValidationError
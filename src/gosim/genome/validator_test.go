@@ -1,6 +1,8 @@
 package genome
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -97,6 +99,52 @@ func TestValidateScoreWinWithoutScoring(t *testing.T) {
 	}
 }
 
+func TestValidateLowScoreWithHandPenalty(t *testing.T) {
+	genome := &GameGenome{
+		Name: "HandPenaltyScoreWin",
+		Setup: SetupRules{
+			CardsPerPlayer: 7,
+		},
+		TurnStructure: TurnStructure{
+			Phases: []Phase{
+				&PlayPhase{Target: LocationDiscard, MinCards: 1, MaxCards: 1},
+			},
+		},
+		WinConditions: []WinCondition{
+			{Type: WinTypeLowScore, Threshold: 100},
+			{Type: WinTypeEmptyHand}, // Going out ends the game and the hands are scored
+		},
+		HandPenalty: HandPenaltySelf, // Cards left in hand are the scoring
+	}
+
+	for _, e := range ValidateGenome(genome) {
+		if e.Field == "win_conditions" {
+			t.Errorf("Hand penalty should count as scoring, got: %v", e)
+		}
+	}
+}
+
+func TestValidateMatchWinsNeedsPerHandScoring(t *testing.T) {
+	genome := CreateSimplePokerGenome()
+	genome.WinConditions = []WinCondition{{Type: WinTypeMatchWins, Threshold: 2}}
+
+	hasError := func() bool {
+		for _, e := range ValidateGenome(genome) {
+			if e.Field == "win_conditions" {
+				return true
+			}
+		}
+		return false
+	}
+	if !hasError() {
+		t.Error("Expected a match win to need hands scored on their own")
+	}
+	genome.ScoreCarry = ScoreCarryPerHand
+	if hasError() {
+		t.Errorf("Expected a per-hand match to be valid, got %v", ValidateGenome(genome))
+	}
+}
+
 func TestValidateBettingWithoutChips(t *testing.T) {
 	genome := &GameGenome{
 		Name: "Poker",
@@ -131,6 +179,127 @@ func TestValidateBettingWithoutChips(t *testing.T) {
 	}
 }
 
+func TestValidateShowPhase(t *testing.T) {
+	genome := &GameGenome{
+		Name:  "HighCardDuel",
+		Setup: SetupRules{CardsPerPlayer: 1},
+		TurnStructure: TurnStructure{
+			Phases: []Phase{
+				&DrawPhase{Source: LocationDeck, Count: 1, Mandatory: true},
+				&ShowPhase{Award: ShowAwardPoints, Points: 1, ClearHands: true},
+			},
+		},
+		WinConditions: []WinCondition{
+			{Type: WinTypeFirstToScore, Threshold: 5},
+		},
+	}
+
+	// Show points count as scoring for score-based wins
+	if errors := ValidateGenome(genome); len(errors) != 0 {
+		t.Errorf("Expected points show to be valid, got: %v", errors)
+	}
+
+	// A pot-only show needs chips
+	genome.TurnStructure.Phases[1] = &ShowPhase{Award: ShowAwardPot}
+	errors := ValidateGenome(genome)
+	found := false
+	for _, e := range errors {
+		if e.Field == "turn_structure.phases" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("Expected pot show without chips error, got: %v", errors)
+	}
+}
+
+func TestValidatePenetration(t *testing.T) {
+	hasPenetrationError := func(genome *GameGenome) bool {
+		for _, e := range ValidateGenome(genome) {
+			if e.Field == "setup.penetration" {
+				return true
+			}
+		}
+		return false
+	}
+
+	genome := CreateBlackjackGenome()
+	if hasPenetrationError(genome) {
+		t.Errorf("Expected penetration %.2f to be valid", genome.Setup.Penetration)
+	}
+
+	genome.Setup.Penetration = 1.5
+	if !hasPenetrationError(genome) {
+		t.Error("Expected penetration error for more than the whole deck")
+	}
+}
+
+func TestValidateRefillTo(t *testing.T) {
+	genome := CreateCrazyEightsGenome()
+	play := genome.TurnStructure.Phases[1].(*PlayPhase)
+	for _, tt := range []struct {
+		refill  int
+		wantErr bool
+	}{
+		{0, false},
+		{7, false},
+		{-1, true},
+		{27, true}, // Two hands of 27 need more than one deck
+	} {
+		play.RefillTo = tt.refill
+		hasErr := false
+		for _, e := range ValidateGenome(genome) {
+			if e.Field == "play_phase.refill_to" {
+				hasErr = true
+			}
+		}
+		if hasErr != tt.wantErr {
+			t.Errorf("refill_to %d: expected error %v, got %v", tt.refill, tt.wantErr, hasErr)
+		}
+	}
+}
+
+func TestValidatePeekDuration(t *testing.T) {
+	genome := CreateCrazyEightsGenome()
+	peek := &PeekPhase{Target: PeekTargetNext}
+	genome.TurnStructure.Phases = append(genome.TurnStructure.Phases, peek)
+	for _, tt := range []struct {
+		duration int
+		wantErr  bool
+	}{
+		{0, false},
+		{10, false},
+		{-1, true},
+		{MaxPeekDuration + 1, true},
+	} {
+		peek.Duration = tt.duration
+		hasErr := false
+		for _, e := range ValidateGenome(genome) {
+			if e.Field == "peek_phase.duration" {
+				hasErr = true
+			}
+		}
+		if hasErr != tt.wantErr {
+			t.Errorf("duration %d: expected error %v, got %v", tt.duration, tt.wantErr, hasErr)
+		}
+	}
+}
+
+func TestValidateMaxEffectsPerTurn(t *testing.T) {
+	genome := CreateCrazyEightsGenome()
+	genome.TurnStructure.MaxEffectsPerTurn = -1
+	found := false
+	for _, e := range ValidateGenome(genome) {
+		if e.Field == "turn_structure.max_effects_per_turn" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected error for a negative effect cap")
+	}
+}
+
 func TestValidateCaptureWinWithoutTableauMode(t *testing.T) {
 	genome := &GameGenome{
 		Name: "CaptureGame",
@@ -286,6 +455,159 @@ func TestValidateValidTrickGame(t *testing.T) {
 	}
 }
 
+func TestValidateUnreachableWins(t *testing.T) {
+	unreachable := func(g *GameGenome) bool {
+		for _, e := range ValidateGenome(g) {
+			if strings.Contains(e.Message, "can never be met") {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Hearts scores its tricks, so the score threshold is reachable
+	hearts := CreateHeartsGenome()
+	if unreachable(hearts) {
+		t.Errorf("Expected Hearts' win conditions to be reachable, got %v", ValidateGenome(hearts))
+	}
+
+	// Trick points are only scored on trick wins: with the scoring moved to
+	// the end of the hand no phase scores during play
+	for i := range hearts.CardScoring {
+		hearts.CardScoring[i].Trigger = TriggerHandEnd
+	}
+	hearts.WinConditions = hearts.WinConditions[:1]
+	if !unreachable(hearts) {
+		t.Error("Expected low_score with only hand-end scoring to be unreachable")
+	}
+
+	// Drawing alone never empties a hand
+	drawOnly := &GameGenome{
+		Name:  "DrawOnly",
+		Setup: SetupRules{CardsPerPlayer: 5},
+		TurnStructure: TurnStructure{
+			Phases: []Phase{&DrawPhase{Source: LocationDeck, Count: 1, Mandatory: true}},
+		},
+		WinConditions: []WinCondition{{Type: WinTypeEmptyHand}},
+	}
+	if !unreachable(drawOnly) {
+		t.Error("Expected empty_hand to be unreachable without a phase that sheds cards")
+	}
+}
+
+func TestValidateTradePrice(t *testing.T) {
+	g := CreateCrazyEightsGenome()
+	g.TurnStructure.Phases = append(g.TurnStructure.Phases, &TradePhase{Target: GiveTargetNext})
+	if errs := ValidateGenome(g); len(errs) != 0 {
+		t.Errorf("Expected a card-for-card trade to be valid, got %v", errs)
+	}
+
+	hasPriceError := func(g *GameGenome) bool {
+		for _, e := range ValidateGenome(g) {
+			if e.Field == "trade_phase.price" {
+				return true
+			}
+		}
+		return false
+	}
+	trade := g.TurnStructure.Phases[len(g.TurnStructure.Phases)-1].(*TradePhase)
+	trade.Price = 3
+	if !hasPriceError(g) {
+		t.Error("Expected a chip price without starting chips to be rejected")
+	}
+	g.Setup.StartingChips = 100
+	if hasPriceError(g) {
+		t.Errorf("Expected a chip price with starting chips to be valid, got %v", ValidateGenome(g))
+	}
+	trade.Price = -1
+	if !hasPriceError(g) {
+		t.Error("Expected a negative price to be rejected")
+	}
+}
+
+func TestValidateMeldScoring(t *testing.T) {
+	g := CreateCrazyEightsGenome()
+	g.RevealScoring = true
+	g.CardScoring = []CardScoringRule{{Suit: 255, Rank: 255, Points: 5, Trigger: TriggerHandEnd, Meld: MeldMatched}}
+	if errs := ValidateGenome(g); len(errs) != 0 {
+		t.Errorf("Expected hand-end meld scoring to be valid, got %v", errs)
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(g)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.CardScoring[0] != g.CardScoring[0] {
+		t.Errorf("Expected the meld filter to round-trip, got %+v", loaded.CardScoring[0])
+	}
+
+	// Melds are only scored from the hand at the end
+	g.CardScoring[0].Trigger = TriggerPlay
+	if errs := ValidateGenome(g); len(errs) == 0 {
+		t.Error("Expected a meld filter on a play rule to be rejected")
+	}
+}
+
+func TestValidateHandShrink(t *testing.T) {
+	g := CreateKnockoutWhistGenome()
+	if errs := ValidateGenome(g); len(errs) != 0 {
+		t.Errorf("Expected Knock-Out Whist to be valid, got %v", errs)
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(g)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.Setup.HandShrink != 1 {
+		t.Errorf("Expected the hand shrink to round-trip, got %d", loaded.Setup.HandShrink)
+	}
+
+	// Knockouts are decided by tricks
+	g = CreateCrazyEightsGenome()
+	g.Setup.HandShrink = 1
+	if errs := ValidateGenome(g); len(errs) == 0 {
+		t.Error("Expected shrinking hands without a trick phase to be rejected")
+	}
+	g.Setup.HandShrink = -1
+	if errs := ValidateGenome(g); len(errs) == 0 {
+		t.Error("Expected a negative hand shrink to be rejected")
+	}
+}
+
+func TestValidateChipBridge(t *testing.T) {
+	g := CreateBettingWarGenome()
+	g.ChipBridge = ChipBridgePointsWinPot
+	if errs := ValidateGenome(g); len(errs) != 0 {
+		t.Errorf("Expected a bridged betting game to be valid, got %v", errs)
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(g)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.ChipBridge != ChipBridgePointsWinPot {
+		t.Errorf("Expected the chip bridge to round-trip, got %d", loaded.ChipBridge)
+	}
+
+	// There are no chips to bridge without a stake
+	g.Setup.StartingChips = 0
+	if errs := ValidateGenome(g); len(errs) == 0 {
+		t.Error("Expected a chip bridge without chips to be rejected")
+	}
+}
+
 func TestIsValid(t *testing.T) {
 	validGenome := &GameGenome{
 		Name: "SimpleGame",
@@ -323,3 +645,190 @@ func TestIsValid(t *testing.T) {
 		t.Error("Expected IsValid to return false for invalid genome")
 	}
 }
+
+func TestValidateRemainder(t *testing.T) {
+	g := CreateSpadesGenome()
+	g.Setup.CardsPerPlayer = 12
+	g.Setup.Remainder = RemainderKitty
+	for _, e := range ValidateGenome(g) {
+		if e.Field == "setup.remainder" {
+			t.Errorf("Expected a kitty in a bidding game to be valid, got %v", e)
+		}
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(g)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.Setup.Remainder != RemainderKitty {
+		t.Errorf("Expected the remainder to round-trip, got %d", loaded.Setup.Remainder)
+	}
+
+	// Nobody wins a kitty without bidding
+	crazy := CreateCrazyEightsGenome()
+	crazy.Setup.Remainder = RemainderKitty
+	if errs := ValidateGenome(crazy); len(errs) == 0 {
+		t.Error("Expected a kitty without a bidding phase to be rejected")
+	}
+	crazy.Setup.Remainder = RemainderDealer + 1
+	if errs := ValidateGenome(crazy); len(errs) == 0 {
+		t.Error("Expected an unknown remainder to be rejected")
+	}
+}
+
+func TestValidateExposedScoring(t *testing.T) {
+	g := CreateCrazyEightsGenome()
+	g.CardScoring = append(g.CardScoring, CardScoringRule{Suit: 255, Rank: RankKing, Points: 10, Trigger: TriggerExposed})
+	exposedErrors := func() int {
+		n := 0
+		for _, e := range ValidateGenome(g) {
+			if e.Field == "card_scoring.trigger" {
+				n++
+			}
+		}
+		return n
+	}
+	if exposedErrors() != 1 {
+		t.Error("Expected exposed scoring without exposed plays to be rejected")
+	}
+
+	for _, phase := range g.TurnStructure.Phases {
+		if p, ok := phase.(*PlayPhase); ok {
+			p.Target = LocationExposed
+		}
+	}
+	if n := exposedErrors(); n != 0 {
+		t.Errorf("Expected exposed scoring with exposed plays to be valid, got %d errors", n)
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(g)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if !playsTo(loaded, LocationExposed) {
+		t.Error("Expected the exposed target to round-trip")
+	}
+}
+
+func TestValidateMisdeal(t *testing.T) {
+	g := CreateCrazyEightsGenome()
+	g.Setup.Misdeal = MisdealRule{Condition: MisdealNoSuit, Suit: SuitSpades, MaxRedeals: 2}
+	misdealErrors := func() int {
+		n := 0
+		for _, e := range ValidateGenome(g) {
+			if e.Field == "setup.misdeal" {
+				n++
+			}
+		}
+		return n
+	}
+	if n := misdealErrors(); n != 0 {
+		t.Errorf("Expected a no-spades misdeal to be valid, got %d errors", n)
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(g)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.Setup.Misdeal != g.Setup.Misdeal {
+		t.Errorf("Expected the misdeal rule to round-trip, got %+v", loaded.Setup.Misdeal)
+	}
+
+	for _, bad := range []MisdealRule{
+		{Condition: MisdealAllBelow + 1, MaxRedeals: 1},
+		{Condition: MisdealNoFaceCards, MaxRedeals: -1},
+		{Condition: MisdealAllBelow, Rank: 13, MaxRedeals: 1},
+	} {
+		g.Setup.Misdeal = bad
+		if misdealErrors() != 1 {
+			t.Errorf("Expected %+v to be rejected", bad)
+		}
+	}
+	g.Setup.Misdeal = MisdealRule{Condition: MisdealNoFaceCards, MaxRedeals: 1}
+	g.Setup.PersonalDecks = true
+	if misdealErrors() != 1 {
+		t.Error("Expected a misdeal with personal decks to be rejected")
+	}
+}
+
+func TestValidateWinMargin(t *testing.T) {
+	g := CreateCrazyEightsGenome()
+	g.WinConditions = []WinCondition{{Type: WinTypeFirstToScore, Threshold: 100, Margin: 2}}
+	jsonBytes, err := SaveGenomeToJSON(g)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if loaded.WinConditions[0].Margin != 2 {
+		t.Errorf("Expected the margin to round-trip, got %d", loaded.WinConditions[0].Margin)
+	}
+
+	for _, wc := range []WinCondition{{Type: WinTypeFirstToScore, Threshold: 100, Margin: 2}, {Type: WinTypeEmptyHand, Margin: 2}, {Type: WinTypeHighScore, Margin: -1}} {
+		g.WinConditions = []WinCondition{wc}
+		rejected := false
+		for _, e := range ValidateGenome(g) {
+			rejected = rejected || e.Field == "win_conditions.margin"
+		}
+		if want := wc.Type == WinTypeEmptyHand || wc.Margin < 0; rejected != want {
+			t.Errorf("%+v: expected rejected=%v, got %v", wc, want, rejected)
+		}
+	}
+}
+
+func TestValidateDiscardPiles(t *testing.T) {
+	g := CreateCrazyEightsGenome()
+	g.DiscardPiles = []DiscardPile{{Name: "clubs", Suit: SuitClubs, StartRank: RankAce, Build: PileBuildUp}}
+	for _, phase := range g.TurnStructure.Phases {
+		if p, ok := phase.(*PlayPhase); ok {
+			p.Target = LocationPiles
+		}
+	}
+	pileErrors := func() int {
+		n := 0
+		for _, e := range ValidateGenome(g) {
+			if e.Field == "discard_piles" || e.Field == "play_phase.target" {
+				n++
+			}
+		}
+		return n
+	}
+	if n := pileErrors(); n != 0 {
+		t.Errorf("Expected plays to a clubs foundation to be valid, got %d errors", n)
+	}
+
+	jsonBytes, err := SaveGenomeToJSON(g)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.DiscardPiles, g.DiscardPiles) || !playsTo(loaded, LocationPiles) {
+		t.Errorf("Expected the discard piles to round-trip, got %+v", loaded.DiscardPiles)
+	}
+
+	g.DiscardPiles[0].Build = PileBuildDown + 1
+	if pileErrors() != 1 {
+		t.Error("Expected an unknown build to be rejected")
+	}
+	g.DiscardPiles = nil
+	if pileErrors() != 1 {
+		t.Error("Expected plays to the piles without any piles to be rejected")
+	}
+}
@@ -0,0 +1,57 @@
+package mcts
+
+import (
+	"context"
+
+	"github.com/signalnine/darwindeck/gosim/engine"
+)
+
+// SearchContext performs MCTS like Search but stops when ctx is done,
+// returning the most visited move found so far, so a caller with a hard
+// per-move deadline always gets a move in time. If ctx is already done on
+// entry, or the deadline leaves no time for a single iteration, it falls
+// back to FastMove.
+func SearchContext(ctx context.Context, state *engine.GameState, genome *engine.Genome, iterations int, explorationParam float64) *engine.LegalMove {
+	if ctx.Err() != nil {
+		return FastMove(state, genome)
+	}
+	root := buildTree(ctx, state, genome, iterations, explorationParam)
+	defer PutNode(root)
+
+	bestChild := root.MostVisitedChild()
+	if bestChild == nil || bestChild.Move == nil || bestChild.Visits == 0 {
+		return FastMove(state, genome)
+	}
+	moveCopy := *bestChild.Move
+	return &moveCopy
+}
+
+// FastMove picks a move by looking one move ahead, without search: a move
+// that wins at once, else the one raising the mover's score most, else the
+// one leaving the fewest cards in hand. Ties go to the earlier move. It
+// returns nil when there is no legal move.
+func FastMove(state *engine.GameState, genome *engine.Genome) *engine.LegalMove {
+	moves := engine.GenerateLegalMoves(state, genome)
+	if len(moves) == 0 {
+		return nil
+	}
+
+	mover := state.CurrentPlayer
+	best, bestScore, bestHand := 0, int32(0), 0
+	for i := range moves {
+		next := state.Clone()
+		engine.ApplyMove(next, &moves[i], genome)
+		winner := engine.CheckWinConditions(next, genome)
+		score, hand := next.Players[mover].Score, len(next.Players[mover].Hand)
+		engine.PutState(next)
+
+		if winner >= 0 && uint8(winner) == mover {
+			best = i
+			break
+		}
+		if i == 0 || score > bestScore || (score == bestScore && hand < bestHand) {
+			best, bestScore, bestHand = i, score, hand
+		}
+	}
+	return &moves[best]
+}
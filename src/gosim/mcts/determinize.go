@@ -0,0 +1,191 @@
+package mcts
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/signalnine/darwindeck/gosim/engine"
+)
+
+// hiddenSlot is the position of a card the searcher cannot see.
+type hiddenSlot struct {
+	seat  int // -1 for the deck, -2 for the kitty, -3 for the current claim
+	index int
+}
+
+// hiddenSlots lists the opponents' cards viewer has not peeked at, then the
+// deck and the kitty, which no one can see, and the cards laid face-down
+// under an opponent's claim.
+func hiddenSlots(state *engine.GameState, viewer int) []hiddenSlot {
+	var slots []hiddenSlot
+	for seat := 0; seat < int(state.NumPlayers); seat++ {
+		if seat == viewer {
+			continue
+		}
+		known := make(map[engine.Card]int)
+		for _, c := range state.KnownCards(viewer, seat) {
+			known[c]++
+		}
+		for i, c := range state.Players[seat].Hand {
+			if known[c] > 0 {
+				known[c]--
+				continue
+			}
+			slots = append(slots, hiddenSlot{seat: seat, index: i})
+		}
+	}
+	for i := range state.Deck {
+		slots = append(slots, hiddenSlot{seat: -1, index: i})
+	}
+	for i := range state.Kitty {
+		slots = append(slots, hiddenSlot{seat: -2, index: i})
+	}
+	if claimHidden(state, viewer) {
+		for i := range state.CurrentClaim.CardsPlayed {
+			slots = append(slots, hiddenSlot{seat: -3, index: i})
+		}
+	}
+	return slots
+}
+
+// claimHidden reports whether viewer faces a claim whose cards they did not
+// lay. They sit on top of the discard pile, where the claim put them.
+func claimHidden(state *engine.GameState, viewer int) bool {
+	claim := state.CurrentClaim
+	if claim == nil || int(claim.ClaimerID) == viewer {
+		return false
+	}
+	top := len(state.Discard) - len(claim.CardsPlayed)
+	if top < 0 {
+		return false
+	}
+	for i, c := range claim.CardsPlayed {
+		if state.Discard[top+i] != c {
+			return false
+		}
+	}
+	return true
+}
+
+// HasHiddenCards reports whether viewer faces hidden information in state:
+// at least two cards whose places it cannot tell apart. A searcher that can
+// see everything gains nothing from determinization.
+func HasHiddenCards(state *engine.GameState, viewer int) bool {
+	return len(hiddenSlots(state, viewer)) > 1
+}
+
+// Determinize redeals the cards viewer cannot see: the opponents' unpeeked
+// cards, the deck, the kitty and the cards under an opponent's claim are
+// pooled, shuffled and dealt back into the same places, so every hand keeps
+// its size and viewer's own hand, peeked cards and the face-up cards are
+// untouched. The result is one deal consistent with what viewer knows, in
+// which a bluff can't be told from a true claim.
+func Determinize(state *engine.GameState, viewer int, rng *rand.Rand) {
+	DeterminizeRecall(state, viewer, 0, rng)
+}
+
+// DeterminizeRecall is Determinize for a viewer who remembers only the last
+// memory tricks of the hand (0 = every trick). The cards of the tricks it
+// has forgotten join the pool, since for all it recalls they may still be
+// out, so the deal it imagines can hand an opponent a card already played.
+// Such a deal holds that card twice, as the viewer's beliefs do.
+func DeterminizeRecall(state *engine.GameState, viewer, memory int, rng *rand.Rand) {
+	slots := hiddenSlots(state, viewer)
+	pool := make([]engine.Card, len(slots))
+	for i, s := range slots {
+		pool[i] = slotCard(state, s)
+	}
+	pool = append(pool, state.ForgottenCards(memory)...)
+	rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	for i, s := range slots {
+		switch s.seat {
+		case -1:
+			state.Deck[s.index] = pool[i]
+		case -2:
+			state.Kitty[s.index] = pool[i]
+		case -3:
+			claim := state.CurrentClaim
+			claim.CardsPlayed[s.index] = pool[i]
+			state.Discard[len(state.Discard)-len(claim.CardsPlayed)+s.index] = pool[i]
+		default:
+			state.Players[s.seat].Hand[s.index] = pool[i]
+		}
+	}
+}
+
+func slotCard(state *engine.GameState, s hiddenSlot) engine.Card {
+	switch s.seat {
+	case -1:
+		return state.Deck[s.index]
+	case -2:
+		return state.Kitty[s.index]
+	case -3:
+		return state.CurrentClaim.CardsPlayed[s.index]
+	}
+	return state.Players[s.seat].Hand[s.index]
+}
+
+// SearchDeterminized performs MCTS over several determinizations of the
+// hidden cards rather than the true deal, so the searcher cannot exploit
+// cards it should not see. The iterations are split evenly across the
+// determinizations and the move with the most visits summed over all of
+// them is returned. With one determinization, or when the player to move
+// can see every card, it searches the true deal like Search.
+func SearchDeterminized(state *engine.GameState, genome *engine.Genome, iterations, determinizations int, explorationParam float64, rng *rand.Rand) *engine.LegalMove {
+	return searchDeterminized(context.Background(), state, genome, iterations, determinizations, 0, explorationParam, rng)
+}
+
+// searchDeterminized is SearchDeterminized stopping once ctx is done, as
+// SearchContext does, for a searcher remembering the last memory tricks
+// (see DeterminizeRecall).
+func searchDeterminized(ctx context.Context, state *engine.GameState, genome *engine.Genome, iterations, determinizations, memory int, explorationParam float64, rng *rand.Rand) *engine.LegalMove {
+	viewer := int(state.CurrentPlayer)
+	if determinizations > iterations {
+		determinizations = iterations
+	}
+	if determinizations <= 1 || !HasHiddenCards(state, viewer) {
+		return SearchContext(ctx, state, genome, iterations, explorationParam)
+	}
+	if ctx.Err() != nil {
+		return FastMove(state, genome)
+	}
+
+	moves := engine.GenerateLegalMoves(state, genome)
+	if len(moves) == 0 {
+		return nil
+	}
+	visits := make([]int, len(moves))
+	for d := 0; d < determinizations && ctx.Err() == nil; d++ {
+		n := iterations / determinizations
+		if d < iterations%determinizations {
+			n++
+		}
+		sample := state.Clone()
+		DeterminizeRecall(sample, viewer, memory, rng)
+		root := buildTree(ctx, sample, genome, n, explorationParam)
+		for _, child := range root.Children {
+			if child.Move == nil {
+				continue
+			}
+			for i, move := range moves {
+				if *child.Move == move {
+					visits[i] += child.Visits
+					break
+				}
+			}
+		}
+		PutNode(root)
+		engine.PutState(sample)
+	}
+
+	best := 0
+	for i, v := range visits {
+		if v > visits[best] {
+			best = i
+		}
+	}
+	if visits[best] == 0 {
+		return FastMove(state, genome)
+	}
+	return &moves[best]
+}
@@ -1,7 +1,10 @@
 package mcts
 
 import (
+	"context"
+	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/signalnine/darwindeck/gosim/engine"
 )
@@ -203,6 +206,350 @@ func TestMCTSSearch(t *testing.T) {
 	}
 }
 
+func TestSearchDistribution(t *testing.T) {
+	state := engine.GetState()
+	defer engine.PutState(state)
+	state.Players[0].Hand = append(state.Players[0].Hand,
+		engine.Card{Rank: 2, Suit: 0},
+		engine.Card{Rank: 7, Suit: 1},
+		engine.Card{Rank: 11, Suit: 2},
+	)
+	state.Players[1].Hand = append(state.Players[1].Hand,
+		engine.Card{Rank: 4, Suit: 0},
+		engine.Card{Rank: 9, Suit: 3},
+	)
+
+	// Shedding game: play one card to the discard, first to empty their hand wins
+	genome := &engine.Genome{
+		Header: &engine.BytecodeHeader{
+			PlayerCount: 2,
+			MaxTurns:    100,
+		},
+		TurnPhases: []engine.PhaseDescriptor{
+			{
+				PhaseType: 2, // PlayPhase
+				Data: []byte{
+					byte(engine.LocationDiscard), // target
+					1,                            // min_cards
+					1,                            // max_cards
+					1,                            // mandatory
+					0,                            // pass_if_unable
+					0, 0, 0, 0,                   // conditionLen
+				},
+			},
+		},
+		WinConditions: []engine.WinCondition{
+			{WinType: 0, Threshold: 0}, // empty_hand
+		},
+	}
+
+	const iterations = 200
+	stats := SearchDistribution(state, genome, iterations, DefaultExplorationParam)
+
+	moves := engine.GenerateLegalMoves(state, genome)
+	if len(stats) != len(moves) {
+		t.Fatalf("Expected stats for %d moves, got %d", len(moves), len(stats))
+	}
+
+	totalVisits := 0
+	for i, s := range stats {
+		if s.Move != moves[i] {
+			t.Errorf("Stats %d out of legal-move order: got %+v, want %+v", i, s.Move, moves[i])
+		}
+		if s.Visits == 0 {
+			t.Errorf("Expected move %d to be explored", i)
+		}
+		if s.MeanValue < 0 || s.MeanValue > 1 {
+			t.Errorf("Mean value out of range for move %d: %f", i, s.MeanValue)
+		}
+		totalVisits += s.Visits
+	}
+	if totalVisits > iterations {
+		t.Errorf("Expected at most %d visits across root moves, got %d", iterations, totalVisits)
+	}
+}
+
+func TestDeterminize(t *testing.T) {
+	state := engine.GetState()
+	defer engine.PutState(state)
+	state.NumPlayers = 3
+	for i := 0; i < 19; i++ {
+		card := engine.Card{Rank: uint8(i % 13), Suit: uint8(i / 13)}
+		switch {
+		case i < 3:
+			state.Players[0].Hand = append(state.Players[0].Hand, card)
+		case i < 6:
+			state.Players[1].Hand = append(state.Players[1].Hand, card)
+		case i < 9:
+			state.Players[2].Hand = append(state.Players[2].Hand, card)
+		default:
+			state.Deck = append(state.Deck, card)
+		}
+	}
+	// Player 0 has seen player 1's hand, but not the card drawn after
+	state.RecordPeek(0, 1, 0)
+	state.Players[1].Hand = append(state.Players[1].Hand, state.Deck[0])
+	state.Deck = state.Deck[1:]
+
+	counts := func(s *engine.GameState) map[engine.Card]int {
+		m := make(map[engine.Card]int)
+		for p := 0; p < 3; p++ {
+			for _, c := range s.Players[p].Hand {
+				m[c]++
+			}
+		}
+		for _, c := range s.Deck {
+			m[c]++
+		}
+		return m
+	}
+	want := counts(state)
+
+	rng := rand.New(rand.NewSource(1))
+	redealt := false
+	for i := 0; i < 10; i++ {
+		sample := state.Clone()
+		Determinize(sample, 0, rng)
+		for p := 0; p < 3; p++ {
+			if len(sample.Players[p].Hand) != len(state.Players[p].Hand) {
+				t.Fatalf("Player %d's hand changed size", p)
+			}
+		}
+		for j := 0; j < 3; j++ {
+			if sample.Players[0].Hand[j] != state.Players[0].Hand[j] || sample.Players[1].Hand[j] != state.Players[1].Hand[j] {
+				t.Fatalf("Expected the searcher's hand and the peeked cards kept, got %v and %v", sample.Players[0].Hand, sample.Players[1].Hand)
+			}
+		}
+		for c, n := range counts(sample) {
+			if want[c] != n {
+				t.Fatalf("Expected the same cards redealt, %v appears %d times instead of %d", c, n, want[c])
+			}
+		}
+		if sample.Players[2].Hand[0] != state.Players[2].Hand[0] {
+			redealt = true
+		}
+		engine.PutState(sample)
+	}
+	if !redealt {
+		t.Error("Expected the unseen hand to be redealt")
+	}
+
+	if !HasHiddenCards(state, 0) {
+		t.Error("Expected hidden cards with an unseen hand and a deck")
+	}
+	state.Deck = state.Deck[:0]
+	state.Players[2].Hand = state.Players[2].Hand[:0]
+	state.RecordPeek(0, 1, 0)
+	if HasHiddenCards(state, 0) {
+		t.Error("Expected no hidden cards once every opponent card is seen")
+	}
+}
+
+// TestDeterminizeHidesClaim checks the card under an opponent's claim is
+// redealt with the other hidden cards, on the claim and the pile alike,
+// while the claimer keeps it.
+func TestDeterminizeRecall(t *testing.T) {
+	state := engine.GetState()
+	defer engine.PutState(state)
+	state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: 2, Suit: 0})
+	state.Players[1].Hand = append(state.Players[1].Hand, engine.Card{Rank: 4, Suit: 0})
+	// Two tricks played; the first went by long enough ago to forget
+	old := engine.Card{Rank: 12, Suit: 3}
+	state.PlayedTricks = append(state.PlayedTricks,
+		[]engine.Card{old, {Rank: 12, Suit: 2}},
+		[]engine.Card{{Rank: 7, Suit: 1}, {Rank: 8, Suit: 1}})
+
+	rng := rand.New(rand.NewSource(1))
+	imagined := false
+	for i := 0; i < 20; i++ {
+		sample := state.Clone()
+		DeterminizeRecall(sample, 0, 1, rng)
+		switch c := sample.Players[1].Hand[0]; c {
+		case old, engine.Card{Rank: 12, Suit: 2}:
+			imagined = true
+		case state.Players[1].Hand[0]:
+		default:
+			t.Fatalf("Expected the opponent's card or a forgotten one, got %v", c)
+		}
+		engine.PutState(sample)
+	}
+	if !imagined {
+		t.Error("Expected a forgotten card imagined in the opponent's hand")
+	}
+
+	// With perfect recall the only card that can be there is the real one
+	for i := 0; i < 5; i++ {
+		sample := state.Clone()
+		DeterminizeRecall(sample, 0, 0, rng)
+		if sample.Players[1].Hand[0] != state.Players[1].Hand[0] {
+			t.Fatalf("Expected perfect recall to leave the lone hidden card, got %v", sample.Players[1].Hand[0])
+		}
+		engine.PutState(sample)
+	}
+}
+
+func TestDeterminizeHidesClaim(t *testing.T) {
+	state := engine.GetState()
+	defer engine.PutState(state)
+	state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: 2, Suit: 0})
+	state.Players[1].Hand = append(state.Players[1].Hand, engine.Card{Rank: 4, Suit: 0}, engine.Card{Rank: 9, Suit: 3})
+	// Player 1 claims a king with a five
+	bluff := engine.Card{Rank: 5, Suit: 2}
+	state.Discard = append(state.Discard, engine.Card{Rank: 11, Suit: 1}, bluff)
+	state.CurrentClaim = &engine.Claim{ClaimerID: 1, ClaimedRank: 12, ClaimedCount: 1, CardsPlayed: []engine.Card{bluff}}
+
+	rng := rand.New(rand.NewSource(1))
+	redealt := false
+	for i := 0; i < 10; i++ {
+		sample := state.Clone()
+		Determinize(sample, 0, rng)
+		played := sample.CurrentClaim.CardsPlayed[0]
+		if sample.Discard[1] != played || sample.Discard[0] != state.Discard[0] {
+			t.Fatalf("Expected the claimed card on top of the pile, got %v under claim %v", sample.Discard, played)
+		}
+		if played != bluff {
+			redealt = true
+		}
+		engine.PutState(sample)
+	}
+	if !redealt {
+		t.Error("Expected the claimed card redealt for the challenger")
+	}
+
+	sample := state.Clone()
+	defer engine.PutState(sample)
+	Determinize(sample, 1, rng)
+	if sample.CurrentClaim.CardsPlayed[0] != bluff {
+		t.Error("Expected the claimer to keep the card they laid")
+	}
+}
+
+func TestSearchDeterminized(t *testing.T) {
+	state := engine.GetState()
+	defer engine.PutState(state)
+	state.Players[0].Hand = append(state.Players[0].Hand,
+		engine.Card{Rank: 2, Suit: 0},
+		engine.Card{Rank: 7, Suit: 1},
+	)
+	state.Players[1].Hand = append(state.Players[1].Hand,
+		engine.Card{Rank: 4, Suit: 0},
+		engine.Card{Rank: 9, Suit: 3},
+	)
+	state.Deck = append(state.Deck, engine.Card{Rank: 12, Suit: 2})
+
+	genome := &engine.Genome{
+		Header: &engine.BytecodeHeader{PlayerCount: 2, MaxTurns: 100},
+		TurnPhases: []engine.PhaseDescriptor{
+			{
+				PhaseType: 2, // PlayPhase to the discard, one card
+				Data:      []byte{byte(engine.LocationDiscard), 1, 1, 1, 0, 0, 0, 0, 0},
+			},
+		},
+		WinConditions: []engine.WinCondition{{WinType: 0, Threshold: 0}},
+	}
+
+	before := append([]engine.Card(nil), state.Players[1].Hand...)
+	move := SearchDeterminized(state, genome, 100, 4, DefaultExplorationParam, rand.New(rand.NewSource(1)))
+	if move == nil {
+		t.Fatal("SearchDeterminized returned nil move")
+	}
+	legal := false
+	for _, m := range engine.GenerateLegalMoves(state, genome) {
+		if m == *move {
+			legal = true
+		}
+	}
+	if !legal {
+		t.Errorf("Expected a legal move in the true deal, got %+v", *move)
+	}
+	if state.Players[1].Hand[0] != before[0] || state.Players[1].Hand[1] != before[1] {
+		t.Error("Expected the searched state left untouched")
+	}
+}
+
+// TestSearchCapsMoves checks a genome offering thousands of moves has its
+// search tree expand no more than the move cap from the root.
+func TestSearchCapsMoves(t *testing.T) {
+	state := engine.GetState()
+	defer engine.PutState(state)
+	for r := uint8(0); r < 13; r++ {
+		state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: r, Suit: 0}, engine.Card{Rank: r, Suit: 1})
+		state.Players[1].Hand = append(state.Players[1].Hand, engine.Card{Rank: r, Suit: 2})
+	}
+
+	genome := &engine.Genome{
+		Header:        &engine.BytecodeHeader{PlayerCount: 2, MaxTurns: 100},
+		WinConditions: []engine.WinCondition{{WinType: 0, Threshold: 0}},
+		MaxLegalMoves: 30,
+	}
+	for i := 0; i < 200; i++ {
+		genome.TurnPhases = append(genome.TurnPhases, engine.PhaseDescriptor{
+			PhaseType: 2, // PlayPhase to the discard, one card
+			Data:      []byte{byte(engine.LocationDiscard), 1, 1, 1, 0, 0, 0, 0, 0},
+		})
+	}
+
+	root := buildTree(context.Background(), state, genome, 100, DefaultExplorationParam)
+	defer PutNode(root)
+	if expanded := len(root.Children) + len(root.UntriedMoves); expanded != 30 {
+		t.Errorf("Expected the root to offer the 30 capped moves of 5200, got %d", expanded)
+	}
+	if root.State.MovesCapped == 0 {
+		t.Error("Expected the capped decision to be counted")
+	}
+}
+
+// TestSearchContextDeadline checks a search far too long for its deadline
+// returns a legal move in time, and that a deadline already passed falls
+// back to the fast move.
+func TestSearchContextDeadline(t *testing.T) {
+	state := engine.GetState()
+	defer engine.PutState(state)
+	state.Players[0].Hand = append(state.Players[0].Hand,
+		engine.Card{Rank: 2, Suit: 0},
+		engine.Card{Rank: 7, Suit: 1},
+	)
+	state.Players[1].Hand = append(state.Players[1].Hand,
+		engine.Card{Rank: 4, Suit: 0},
+		engine.Card{Rank: 9, Suit: 3},
+	)
+
+	genome := &engine.Genome{
+		Header: &engine.BytecodeHeader{PlayerCount: 2, MaxTurns: 100},
+		TurnPhases: []engine.PhaseDescriptor{
+			{
+				PhaseType: 2, // PlayPhase to the discard, one card
+				Data:      []byte{byte(engine.LocationDiscard), 1, 1, 1, 0, 0, 0, 0, 0},
+			},
+		},
+		WinConditions: []engine.WinCondition{{WinType: 0, Threshold: 0}},
+	}
+	legal := engine.GenerateLegalMoves(state, genome)
+	isLegal := func(move *engine.LegalMove) bool {
+		for _, m := range legal {
+			if move != nil && m == *move {
+				return true
+			}
+		}
+		return false
+	}
+
+	start := time.Now()
+	move := SearchWithParams(state, genome, SearchParams{Iterations: 1 << 30, Timeout: 20 * time.Millisecond})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the search cut off at its deadline, took %v", elapsed)
+	}
+	if !isLegal(move) {
+		t.Errorf("Expected a legal move at the deadline, got %+v", move)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if move := SearchContext(ctx, state, genome, 100, DefaultExplorationParam); !isLegal(move) || *move != *FastMove(state, genome) {
+		t.Errorf("Expected the fast move once the deadline has passed, got %+v", move)
+	}
+}
+
 func BenchmarkMCTSSearch(b *testing.B) {
 	state := engine.GetState()
 	defer engine.PutState(state)
@@ -1,7 +1,9 @@
 package mcts
 
 import (
+	"context"
 	"math/rand"
+	"time"
 
 	"github.com/signalnine/darwindeck/gosim/engine"
 )
@@ -10,22 +12,79 @@ const (
 	DefaultExplorationParam = 1.414 // sqrt(2)
 )
 
+// MoveStats is the search result for one legal move at the root.
+type MoveStats struct {
+	Move      engine.LegalMove
+	Visits    int
+	MeanValue float64 // Average result for the player to move: win=1, draw=0.5, loss=0
+}
+
 // Search performs MCTS from the given state and returns the best move
 func Search(state *engine.GameState, genome *engine.Genome, iterations int, explorationParam float64) *engine.LegalMove {
+	root := buildTree(context.Background(), state, genome, iterations, explorationParam)
+	defer PutNode(root)
+
+	// Return most visited child's move
+	bestChild := root.MostVisitedChild()
+	if bestChild == nil || bestChild.Move == nil {
+		// Fallback to first legal move if MCTS fails
+		moves := engine.GenerateLegalMoves(state, genome)
+		if len(moves) > 0 {
+			return &moves[0]
+		}
+		return nil
+	}
+
+	// Create a copy of the move to return
+	moveCopy := *bestChild.Move
+	return &moveCopy
+}
+
+// SearchDistribution performs MCTS like Search but returns the statistics
+// of every legal move at the root, in GenerateLegalMoves order, so callers
+// can see why a move was chosen. Moves the search never expanded report zero
+// visits. The move Search would pick is the one with the most visits.
+func SearchDistribution(state *engine.GameState, genome *engine.Genome, iterations int, explorationParam float64) []MoveStats {
+	root := buildTree(context.Background(), state, genome, iterations, explorationParam)
+	defer PutNode(root)
+
+	moves := engine.GenerateLegalMoves(state, genome)
+	stats := make([]MoveStats, len(moves))
+	used := make([]bool, len(root.Children))
+	for i, move := range moves {
+		stats[i].Move = move
+		for j, child := range root.Children {
+			if used[j] || child.Move == nil || *child.Move != move {
+				continue
+			}
+			used[j] = true
+			stats[i].Visits = child.Visits
+			if child.Visits > 0 {
+				stats[i].MeanValue = child.Wins / float64(child.Visits)
+			}
+			break
+		}
+	}
+
+	return stats
+}
+
+// buildTree runs the MCTS iterations, stopping early once ctx is done, and
+// returns the root of the search tree. The caller must release it with
+// PutNode.
+func buildTree(ctx context.Context, state *engine.GameState, genome *engine.Genome, iterations int, explorationParam float64) *MCTSNode {
 	if explorationParam == 0 {
 		explorationParam = DefaultExplorationParam
 	}
 
 	// Create root node
 	root := GetNode()
-	defer PutNode(root)
-
 	root.State = state.Clone()
 	root.PlayerID = state.CurrentPlayer
 	root.UntriedMoves = engine.GenerateLegalMoves(root.State, genome)
 
 	// Run MCTS iterations
-	for i := 0; i < iterations; i++ {
+	for i := 0; i < iterations && ctx.Err() == nil; i++ {
 		node := root
 
 		// 1. Selection - traverse tree using UCB1
@@ -53,20 +112,7 @@ func Search(state *engine.GameState, genome *engine.Genome, iterations int, expl
 		backpropagate(node, winner)
 	}
 
-	// Return most visited child's move
-	bestChild := root.MostVisitedChild()
-	if bestChild == nil || bestChild.Move == nil {
-		// Fallback to first legal move if MCTS fails
-		moves := engine.GenerateLegalMoves(state, genome)
-		if len(moves) > 0 {
-			return &moves[0]
-		}
-		return nil
-	}
-
-	// Create a copy of the move to return
-	moveCopy := *bestChild.Move
-	return &moveCopy
+	return root
 }
 
 // expand adds a new child node for an untried move
@@ -162,6 +208,10 @@ func backpropagate(node *MCTSNode, winner int8) {
 type SearchParams struct {
 	Iterations       int
 	ExplorationParam float64
+	Determinizations int           // Deals of the hidden cards to search (0 or 1 = the true deal)
+	Rand             *rand.Rand    // Source for determinization; required when Determinizations > 1
+	Memory           int           // Tricks of the hand a determinizing searcher remembers (0 = all)
+	Timeout          time.Duration // Deadline for the move; the best move so far is returned (0 = none)
 	// Future extensions:
 	// UseRAVE         bool
 	// UseProgWiden    bool
@@ -170,5 +220,14 @@ type SearchParams struct {
 
 // SearchWithParams runs MCTS with custom parameters
 func SearchWithParams(state *engine.GameState, genome *engine.Genome, params SearchParams) *engine.LegalMove {
-	return Search(state, genome, params.Iterations, params.ExplorationParam)
+	ctx := context.Background()
+	if params.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, params.Timeout)
+		defer cancel()
+	}
+	if params.Determinizations > 1 && params.Rand != nil {
+		return searchDeterminized(ctx, state, genome, params.Iterations, params.Determinizations, params.Memory, params.ExplorationParam, params.Rand)
+	}
+	return SearchContext(ctx, state, genome, params.Iterations, params.ExplorationParam)
 }
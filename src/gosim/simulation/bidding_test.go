@@ -1,9 +1,11 @@
 package simulation
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/signalnine/darwindeck/gosim/engine"
+	"github.com/signalnine/darwindeck/gosim/genome"
 )
 
 func TestRunSingleGameWithBidding(t *testing.T) {
@@ -100,3 +102,80 @@ func TestRunSingleGameWithBidding(t *testing.T) {
 		state.Players[2].CurrentBid,
 		state.Players[3].CurrentBid)
 }
+
+func TestSelectGreedyBidDeclaresLongestSuit(t *testing.T) {
+	state := engine.GetState()
+	defer engine.PutState(state)
+	state.NumPlayers = 2
+	state.Players[0].Hand = []engine.Card{
+		{Rank: 0, Suit: 3}, {Rank: 12, Suit: 1}, {Rank: 2, Suit: 1},
+		{Rank: 5, Suit: 1}, {Rank: 7, Suit: 3}, {Rank: 1, Suit: 0},
+	}
+
+	phase := engine.BiddingPhase{MinBid: 1, MaxBid: 13, DeclareTrump: true}
+	bid := selectGreedyBid(state, phase, 0)
+	if !bid.NamesTrump || bid.Trump != 1 {
+		t.Errorf("Expected the longest suit, diamonds, as trump, got %d (named %v)", bid.Trump, bid.NamesTrump)
+	}
+
+	// Without trump selection the bid names nothing
+	phase.DeclareTrump = false
+	if bid := selectGreedyBid(state, phase, 0); bid.NamesTrump {
+		t.Error("Expected a plain bid not to name trump")
+	}
+}
+
+// TestBlindBiddingHidesEarlierBids checks a greedy bidder who would hold
+// back after a strong earlier bid bids its full estimate when the bids are
+// sealed.
+func TestBlindBiddingHidesEarlierBids(t *testing.T) {
+	phase := &genome.BiddingPhase{MinBid: 1, MaxBid: 6, Blind: true}
+	g := &genome.GameGenome{
+		Name:          "Sealed",
+		TurnStructure: genome.TurnStructure{Phases: []genome.Phase{phase}},
+	}
+	jsonBytes, err := genome.SaveGenomeToJSON(g)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := genome.LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if bp, ok := loaded.TurnStructure.Phases[0].(*genome.BiddingPhase); !ok || !bp.Blind {
+		t.Fatalf("Expected a blind bidding phase to round-trip, got %+v", loaded.TurnStructure.Phases[0])
+	}
+
+	state := engine.GetState()
+	defer engine.PutState(state)
+	state.NumPlayers = 2
+	// Seat 0 bids 4 of the 6 tricks; seat 1 would bid 3
+	state.Players[0].Hand = []engine.Card{
+		{Rank: engine.RankAce, Suit: 3}, {Rank: engine.RankKing, Suit: 3}, {Rank: engine.RankQueen, Suit: 3},
+		{Rank: engine.RankJack, Suit: 3}, {Rank: engine.RankTen, Suit: 3}, {Rank: engine.RankAce, Suit: 2},
+	}
+	state.Players[1].Hand = []engine.Card{
+		{Rank: engine.RankAce, Suit: 1}, {Rank: engine.RankKing, Suit: 1}, {Rank: engine.RankQueen, Suit: 1},
+		{Rank: engine.RankAce, Suit: 0}, {Rank: engine.RankKing, Suit: 0}, {Rank: engine.RankQueen, Suit: 0},
+	}
+	seats := []AIPlayerType{GreedyAI, GreedyAI}
+	rng := rand.New(rand.NewSource(1))
+
+	// In the open, seat 1 sees the 4 and claims only the 2 tricks left
+	phase.Blind = false
+	if sealed := runBiddingRoundTyped(state, g, seats, rng); sealed != 0 {
+		t.Errorf("Expected no sealed bids in an open round, got %d", sealed)
+	}
+	if state.Players[0].CurrentBid != 4 || state.Players[1].CurrentBid != 2 {
+		t.Fatalf("Expected open bids 4 and 2, got %d and %d", state.Players[0].CurrentBid, state.Players[1].CurrentBid)
+	}
+
+	// Sealed, seat 1 bids as if nobody had bid
+	phase.Blind = true
+	if sealed := runBiddingRoundTyped(state, g, seats, rng); sealed != 2 {
+		t.Errorf("Expected 2 sealed bids, got %d", sealed)
+	}
+	if state.Players[0].CurrentBid != 4 || state.Players[1].CurrentBid != 3 || !state.BiddingComplete {
+		t.Errorf("Expected sealed bids 4 and 3, got %d and %d", state.Players[0].CurrentBid, state.Players[1].CurrentBid)
+	}
+}
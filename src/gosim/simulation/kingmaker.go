@@ -0,0 +1,154 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/signalnine/darwindeck/gosim/engine"
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+// Kingmaker analysis parameters. Only the last few decisions of a game are
+// re-examined, and each alternative gets a handful of random rollouts, so the
+// cost stays bounded per game.
+const (
+	kingmakerWindow          = 6   // Final decisions kept for analysis
+	kingmakerMaxAlternatives = 4   // Alternative moves tried per decision
+	kingmakerRollouts        = 4   // Random rollouts per alternative
+	kingmakerRolloutSteps    = 200 // Move limit per rollout
+)
+
+// kingmakerSnapshot is a game state captured just before a decision.
+type kingmakerSnapshot struct {
+	state  *engine.GameState
+	player int
+}
+
+// kingmakerTracker keeps snapshots of the most recent decisions so that, once
+// the winner is known, a losing player's final choices can be replayed with
+// counterfactual moves. A nil tracker is a no-op (two-player games cannot
+// have a kingmaker).
+type kingmakerTracker struct {
+	snapshots []kingmakerSnapshot
+	next      int
+}
+
+// newKingmakerTracker returns a tracker for games with 3+ players, nil otherwise.
+func newKingmakerTracker(numPlayers int) *kingmakerTracker {
+	if numPlayers < 3 {
+		return nil
+	}
+	return &kingmakerTracker{snapshots: make([]kingmakerSnapshot, 0, kingmakerWindow)}
+}
+
+// record snapshots the state before the current player makes a decision.
+func (k *kingmakerTracker) record(state *engine.GameState) {
+	if k == nil {
+		return
+	}
+	snap := kingmakerSnapshot{state: state.Clone(), player: int(state.CurrentPlayer)}
+	if len(k.snapshots) < kingmakerWindow {
+		k.snapshots = append(k.snapshots, snap)
+		return
+	}
+	engine.PutState(k.snapshots[k.next].state)
+	k.snapshots[k.next] = snap
+	k.next = (k.next + 1) % kingmakerWindow
+}
+
+// release returns all snapshots to the state pool.
+func (k *kingmakerTracker) release() {
+	if k == nil {
+		return
+	}
+	for _, snap := range k.snapshots {
+		engine.PutState(snap.state)
+	}
+	k.snapshots = k.snapshots[:0]
+	k.next = 0
+}
+
+// analyzeTyped replays the recorded decisions of players other than winner.
+// A decision counts as kingmaking when the player could not win with any
+// alternative, yet different alternatives hand the game to different
+// opponents. Rollouts are seeded from seed so results are reproducible.
+// Returns the number of decisions examined and how many were kingmaking.
+func (k *kingmakerTracker) analyzeTyped(g *genome.GameGenome, winner int, seed uint64) (decisions, kingmaking uint64) {
+	if k == nil || winner < 0 {
+		return 0, 0
+	}
+	rng := rand.New(rand.NewSource(int64(seed)))
+
+	for _, snap := range k.snapshots {
+		if snap.player == winner {
+			continue
+		}
+
+		moves := genome.GenerateLegalMovesTyped(snap.state, g)
+		if len(moves) < 2 || hasBettingMoves(moves) || hasBiddingMoves(moves) {
+			continue
+		}
+		decisions++
+
+		favoured := -1
+		swing := false
+		canWin := false
+		numPlayers := int(snap.state.NumPlayers)
+
+		for i := 0; i < len(moves) && i < kingmakerMaxAlternatives; i++ {
+			counts := make([]int, numPlayers)
+			for r := 0; r < kingmakerRollouts; r++ {
+				sim := snap.state.Clone()
+				applyMoveTyped(sim, &moves[i], g)
+				if w := rolloutTyped(sim, g, rng); w >= 0 && int(w) < numPlayers {
+					counts[w]++
+				}
+				engine.PutState(sim)
+			}
+
+			likely := -1
+			for p, c := range counts {
+				if c > 0 && (likely < 0 || c > counts[likely]) {
+					likely = p
+				}
+			}
+			if likely < 0 {
+				continue
+			}
+			if likely == snap.player {
+				canWin = true
+				break
+			}
+			if favoured < 0 {
+				favoured = likely
+			} else if likely != favoured {
+				swing = true
+			}
+		}
+
+		if swing && !canWin {
+			kingmaking++
+		}
+	}
+
+	return decisions, kingmaking
+}
+
+// rolloutTyped plays random moves until someone wins or the rollout stalls.
+// Betting and bidding rounds end the rollout, since they are driven by their
+// own loops in the runner. Returns the winner or -1.
+func rolloutTyped(state *engine.GameState, g *genome.GameGenome, rng *rand.Rand) int8 {
+	// The step cap bounds the rollout's actions, so only turns and hands are
+	// checked against the game's limit
+	limit := genome.TurnLimitFor(g, int(state.NumPlayers))
+	for step := 0; step < kingmakerRolloutSteps && !turnLimitReached(state, g, limit, 0); step++ {
+		if winner := checkWinConditionsTyped(state, g); winner >= 0 {
+			return winner
+		}
+		moves := genome.GenerateLegalMovesTyped(state, g)
+		if len(moves) == 0 || hasBettingMoves(moves) || hasBiddingMoves(moves) {
+			return -1
+		}
+		applyMoveTyped(state, &moves[rng.Intn(len(moves))], g)
+	}
+	return checkWinConditionsTyped(state, g)
+}
@@ -0,0 +1,103 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/signalnine/darwindeck/gosim/engine"
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+// kingmakerGenome is a shedding game where a Seven makes the next player draw two.
+func kingmakerGenome() *genome.GameGenome {
+	return &genome.GameGenome{
+		Name: "KingmakerTest",
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{
+				&genome.PlayPhase{Target: genome.LocationDiscard, MinCards: 1, MaxCards: 1, Mandatory: true},
+			},
+			MaxTurns: 100,
+		},
+		Effects: []genome.SpecialEffect{
+			{TriggerRank: engine.RankSeven, Effect: genome.EffectDrawTwo, Target: engine.TARGET_NEXT_PLAYER, Value: 2},
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeEmptyHand}},
+	}
+}
+
+func TestNewKingmakerTrackerTwoPlayers(t *testing.T) {
+	if newKingmakerTracker(2) != nil {
+		t.Error("Expected nil tracker for 2-player games")
+	}
+
+	// Nil tracker is a no-op
+	var k *kingmakerTracker
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	k.record(state)
+	decisions, events := k.analyzeTyped(kingmakerGenome(), 0, 1)
+	if decisions != 0 || events != 0 {
+		t.Errorf("Expected no analysis from nil tracker, got %d/%d", decisions, events)
+	}
+	k.release()
+}
+
+func TestKingmakerTrackerDetectsKingmaking(t *testing.T) {
+	g := kingmakerGenome()
+
+	// Player 2 cannot win, but chooses between letting player 0 go out
+	// (plain card) and making player 0 draw so player 1 goes out (Seven).
+	state := engine.NewGameState(3)
+	defer engine.PutState(state)
+	state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: engine.RankTwo, Suit: 0})
+	state.Players[1].Hand = append(state.Players[1].Hand, engine.Card{Rank: engine.RankThree, Suit: 0})
+	state.Players[2].Hand = append(state.Players[2].Hand,
+		engine.Card{Rank: engine.RankSeven, Suit: 0},
+		engine.Card{Rank: engine.RankNine, Suit: 0},
+		engine.Card{Rank: engine.RankTen, Suit: 0},
+		engine.Card{Rank: engine.RankJack, Suit: 0},
+		engine.Card{Rank: engine.RankQueen, Suit: 0},
+	)
+	for i := 0; i < 10; i++ {
+		state.Deck = append(state.Deck, engine.Card{Rank: engine.RankKing, Suit: uint8(i % 4)})
+	}
+	state.CurrentPlayer = 2
+
+	k := newKingmakerTracker(3)
+	defer k.release()
+	k.record(state)
+
+	decisions, events := k.analyzeTyped(g, 1, 42)
+	if decisions != 1 {
+		t.Fatalf("Expected 1 decision analyzed, got %d", decisions)
+	}
+	if events != 1 {
+		t.Errorf("Expected kingmaking decision, got %d events", events)
+	}
+
+	// The winner's own decisions are never counted
+	decisions, _ = k.analyzeTyped(g, 2, 42)
+	if decisions != 0 {
+		t.Errorf("Expected winner's decisions to be skipped, got %d", decisions)
+	}
+}
+
+func TestKingmakerTrackerWindow(t *testing.T) {
+	state := engine.NewGameState(3)
+	defer engine.PutState(state)
+
+	k := newKingmakerTracker(3)
+	defer k.release()
+	for i := 0; i < kingmakerWindow*2; i++ {
+		state.TurnNumber = uint32(i)
+		k.record(state)
+	}
+
+	if len(k.snapshots) != kingmakerWindow {
+		t.Fatalf("Expected %d snapshots, got %d", kingmakerWindow, len(k.snapshots))
+	}
+	for _, snap := range k.snapshots {
+		if snap.state.TurnNumber < kingmakerWindow {
+			t.Errorf("Snapshot from turn %d should have been evicted", snap.state.TurnNumber)
+		}
+	}
+}
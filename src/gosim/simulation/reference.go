@@ -0,0 +1,83 @@
+package simulation
+
+import (
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+// ReferenceStats summarizes games between a fixed reference AI and the AI
+// under evaluation.
+type ReferenceStats struct {
+	Games         uint32
+	ReferenceWins uint32
+	OpponentWins  uint32
+	Draws         uint32
+	Errors        uint32
+}
+
+// Edge returns how much more often the reference AI won than its opponent,
+// from -1 to 1. Draws and errors count for neither.
+func (s ReferenceStats) Edge() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return (float64(s.ReferenceWins) - float64(s.OpponentWins)) / float64(s.Games)
+}
+
+// WinRate returns the reference AI's share of the games either player
+// won, or 0.5 when none was decided.
+func (s ReferenceStats) WinRate() float64 {
+	decided := s.ReferenceWins + s.OpponentWins
+	if decided == 0 {
+		return 0.5
+	}
+	return float64(s.ReferenceWins) / float64(decided)
+}
+
+// RunReferenceMatch plays numGames of g heads-up between reference and
+// opponent. Both halves of the match use the same deals, with the reference
+// in the first seat and then the second, so neither a seat advantage nor a
+// lucky run of cards shows up as skill. MCTS players search as often as
+// their type names, over AsymmetricDeterminizations deals of the cards they
+// cannot see, so neither AI's edge comes from seeing its opponent's hand.
+func RunReferenceMatch(g *genome.GameGenome, numGames int, reference, opponent AIPlayerType, seed uint64) ReferenceStats {
+	return RunReferenceMatchDeterminized(g, numGames, reference, opponent, asymmetricDeterminizations(g), seed)
+}
+
+// RunReferenceMatchDeterminized is RunReferenceMatch with MCTS players
+// searching determinizations deals of the hidden cards (1 = the true deal).
+func RunReferenceMatchDeterminized(g *genome.GameGenome, numGames int, reference, opponent AIPlayerType, determinizations int, seed uint64) ReferenceStats {
+	firstHalf := numGames / 2
+	asFirst := RunBatchTypedAsymmetricDeterminized(g, firstHalf, []AIPlayerType{reference, opponent}, determinizations, seed)
+	asSecond := RunBatchTypedAsymmetricDeterminized(g, numGames-firstHalf, []AIPlayerType{opponent, reference}, determinizations, seed)
+
+	return ReferenceStats{
+		Games:         asFirst.TotalGames + asSecond.TotalGames,
+		ReferenceWins: asFirst.Wins[0] + asSecond.Wins[1],
+		OpponentWins:  asFirst.Wins[1] + asSecond.Wins[0],
+		Draws:         asFirst.Draws + asSecond.Draws,
+		Errors:        asFirst.Errors + asSecond.Errors,
+	}
+}
+
+// RunMemoryMatch plays numGames of g heads-up between two aiType players,
+// the reference remembering every trick of the hand and its opponent only
+// the last memory, both searching AsymmetricDeterminizations deals of the
+// cards they cannot see (see mcts.DeterminizeRecall). The reference's edge
+// is how much the game rewards perfect memory: near 0 when a player who
+// forgets old tricks, as people do, plays as well. Seats and deals are
+// balanced as in RunReferenceMatch.
+func RunMemoryMatch(g *genome.GameGenome, numGames int, aiType AIPlayerType, memory int, seed uint64) ReferenceStats {
+	determinizations := asymmetricDeterminizations(g)
+	aiTypes := []AIPlayerType{aiType}
+	firstHalf := numGames / 2
+	asFirst := runBatchTypedAsymmetric(g, firstHalf, aiTypes, determinizations, []int{0, memory}, seed)
+	asSecond := runBatchTypedAsymmetric(g, numGames-firstHalf, aiTypes, determinizations, []int{memory, 0}, seed)
+
+	return ReferenceStats{
+		Games:         asFirst.TotalGames + asSecond.TotalGames,
+		ReferenceWins: asFirst.Wins[0] + asSecond.Wins[1],
+		OpponentWins:  asFirst.Wins[1] + asSecond.Wins[0],
+		Draws:         asFirst.Draws + asSecond.Draws,
+		Errors:        asFirst.Errors + asSecond.Errors,
+	}
+}
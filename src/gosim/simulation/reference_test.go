@@ -0,0 +1,92 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+func TestRunReferenceMatch(t *testing.T) {
+	// Greedy play should dominate random play in Crazy Eights
+	stats := RunReferenceMatch(genome.CreateCrazyEightsGenome(), 40, GreedyAI, RandomAI, 7)
+	if stats.Games != 40 {
+		t.Fatalf("Expected 40 games, got %d", stats.Games)
+	}
+	if stats.ReferenceWins+stats.OpponentWins+stats.Draws+stats.Errors != stats.Games {
+		t.Errorf("Outcomes don't add up: %+v", stats)
+	}
+	if stats.Edge() < 0.5 {
+		t.Errorf("Expected greedy to beat random in Crazy Eights, got edge %.2f (%+v)", stats.Edge(), stats)
+	}
+
+	// War has no decisions, so the reference can't pull ahead
+	stats = RunReferenceMatch(genome.CreateWarGenome(), 40, GreedyAI, RandomAI, 7)
+	if edge := stats.Edge(); edge > 0.3 || edge < -0.3 {
+		t.Errorf("Expected no real edge in War, got %.2f (%+v)", edge, stats)
+	}
+}
+
+// TestSkillGapDeterminized checks the skill gap in a bluffing game is
+// measured with MCTS searching deals of the hidden cards: searching the true
+// deal it sees through every bluff, inflating its edge.
+func TestSkillGapDeterminized(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping skill gap match in short mode")
+	}
+	g := genome.CreateCheatGenome()
+	g.Setup.CardsPerPlayer = 5
+
+	inflated := RunReferenceMatchDeterminized(g, 40, MCTS100AI, GreedyAI, 1, 11)
+	corrected := RunReferenceMatch(g, 40, MCTS100AI, GreedyAI, 11)
+	t.Logf("Strong win rate: %.2f seeing every card, %.2f determinized", inflated.WinRate(), corrected.WinRate())
+	if inflated.WinRate()-corrected.WinRate() < 0.1 {
+		t.Errorf("Expected determinizing to take away the omniscient edge, got %.2f and %.2f (%+v, %+v)",
+			inflated.WinRate(), corrected.WinRate(), inflated, corrected)
+	}
+}
+
+// TestRunMemoryMatch checks a perfect-recall MCTS against a forgetful one
+// plays every game, with seats swapped, and accounts for every outcome.
+func TestRunMemoryMatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping memory match in short mode")
+	}
+	g := genome.CreateKnockoutWhistGenome()
+
+	stats := RunMemoryMatch(g, 20, MCTS100AI, 1, 5)
+	t.Logf("Perfect recall edge over a one-trick memory: %+.2f (%+v)", stats.Edge(), stats)
+	if stats.Games != 20 || stats.ReferenceWins+stats.OpponentWins+stats.Draws+stats.Errors != stats.Games {
+		t.Fatalf("Expected 20 games with outcomes adding up, got %+v", stats)
+	}
+	if stats.Errors > 0 {
+		t.Errorf("Expected no errors, got %d", stats.Errors)
+	}
+}
+
+func TestReferenceStatsEdge(t *testing.T) {
+	if edge := (ReferenceStats{}).Edge(); edge != 0 {
+		t.Errorf("Expected no edge without games, got %f", edge)
+	}
+	stats := ReferenceStats{Games: 10, ReferenceWins: 6, OpponentWins: 2, Draws: 2}
+	if edge := stats.Edge(); edge != 0.4 {
+		t.Errorf("Expected edge 0.4, got %f", edge)
+	}
+}
+
+func TestRunSingleGameTypedAsymmetricSeats(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+
+	// One AI in every seat plays the same game as the symmetric runner
+	a := RunSingleGameTypedAsymmetric(g, []AIPlayerType{RandomAI}, 99)
+	b := RunSingleGameTyped(g, RandomAI, 0, 99)
+	if a.WinnerID != b.WinnerID || a.TurnCount != b.TurnCount {
+		t.Errorf("Expected matching games, got winner %d in %d turns vs %d in %d", a.WinnerID, a.TurnCount, b.WinnerID, b.TurnCount)
+	}
+
+	// Swapping seats moves the stronger player's wins with it
+	first := RunBatchTypedAsymmetric(g, 20, []AIPlayerType{GreedyAI, RandomAI}, 3)
+	second := RunBatchTypedAsymmetric(g, 20, []AIPlayerType{RandomAI, GreedyAI}, 3)
+	if first.Wins[0] <= first.Wins[1] || second.Wins[1] <= second.Wins[0] {
+		t.Errorf("Expected the greedy seat to win more, got %v and %v", first.Wins[:2], second.Wins[:2])
+	}
+}
@@ -0,0 +1,160 @@
+package simulation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+
+	"github.com/signalnine/darwindeck/gosim/engine"
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+// ReplayVersion is the replay file format written by RecordGameTyped.
+const ReplayVersion = 1
+
+// Replay is a portable record of one typed game: the genome, seed and
+// seats it was played with, and the index of the move chosen at each turn
+// decision with more than one legal move. Betting, bidding and jump-in
+// decisions aren't recorded; the seat AIs make them again from the seeded
+// game RNG, which replays them exactly. The recorded outcome lets a replay
+// check it reproduced the game.
+type Replay struct {
+	Version   int                `json:"version"`
+	Genome    *genome.GameGenome `json:"genome"`
+	Seed      uint64             `json:"seed"`
+	Players   int                `json:"players"`
+	AI        []string           `json:"ai"`    // Seat AI names (see ParseAIPlayerType)
+	Moves     []int              `json:"moves"` // Index into the legal moves of each recorded decision
+	WinnerID  int8               `json:"winner"`
+	TurnCount uint32             `json:"turns"`
+}
+
+// RecordGameTyped plays one game as RunSingleGameTypedAsymmetric does, at a
+// table of numPlayers, and returns its replay with the result.
+func RecordGameTyped(g *genome.GameGenome, numPlayers int, aiTypes []AIPlayerType, seed uint64) (*Replay, GameResult) {
+	log := &moveLog{}
+	result := runSingleGameTyped(g, numPlayers, aiTypes, typeIterations, 1, nil, seed, log)
+
+	replay := &Replay{
+		Version:   ReplayVersion,
+		Genome:    g.Clone(),
+		Seed:      seed,
+		Players:   numPlayers,
+		Moves:     log.moves,
+		WinnerID:  result.WinnerID,
+		TurnCount: result.TurnCount,
+	}
+	for _, aiType := range aiTypes {
+		replay.AI = append(replay.AI, aiType.String())
+	}
+	return replay, result
+}
+
+// RunFromReplay plays a recorded game again, move for move, without
+// running its AIs' searches. It fails if the moves no longer fit the game,
+// as after a rules change, or the game ends differently than recorded.
+func RunFromReplay(r *Replay) (GameResult, error) {
+	if r.Version != ReplayVersion {
+		return GameResult{}, fmt.Errorf("unsupported replay version %d", r.Version)
+	}
+	if r.Genome == nil || len(r.AI) == 0 {
+		return GameResult{}, fmt.Errorf("replay has no genome or seats")
+	}
+	aiTypes := make([]AIPlayerType, len(r.AI))
+	for i, name := range r.AI {
+		aiType, err := ParseAIPlayerType(name)
+		if err != nil {
+			return GameResult{}, err
+		}
+		aiTypes[i] = aiType
+	}
+
+	log := &moveLog{moves: r.Moves, replaying: true}
+	result := runSingleGameTyped(r.Genome, r.Players, aiTypes, typeIterations, 1, nil, r.Seed, log)
+	if log.err != "" {
+		return result, fmt.Errorf("replay diverged: %s", log.err)
+	}
+	if log.next != len(log.moves) {
+		return result, fmt.Errorf("replay diverged: game ended after %d of %d recorded moves", log.next, len(log.moves))
+	}
+	if result.WinnerID != r.WinnerID || result.TurnCount != r.TurnCount {
+		return result, fmt.Errorf("replay ended with winner %d after %d turns, recorded winner %d after %d turns",
+			result.WinnerID, result.TurnCount, r.WinnerID, r.TurnCount)
+	}
+	return result, nil
+}
+
+// SaveReplay writes a replay to a JSON file.
+func SaveReplay(r *Replay, path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadReplay reads a replay from a JSON file.
+func LoadReplay(path string) (*Replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Replay
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// moveLog records the turn decisions of a typed game, or feeds recorded
+// ones back in.
+type moveLog struct {
+	moves     []int
+	replaying bool
+	next      int    // Next recorded move to replay
+	err       string // Why the replay stopped, if it diverged
+}
+
+// record notes which of moves was chosen.
+func (l *moveLog) record(moves []engine.LegalMove, move *engine.LegalMove) {
+	idx := -1
+	if move != nil {
+		for i := range moves {
+			if moves[i] == *move {
+				idx = i
+				break
+			}
+		}
+	}
+	l.moves = append(l.moves, idx)
+}
+
+// replay returns the next recorded move, or nil once the recording runs
+// out or names a move that isn't legal. A random seat still draws its
+// choice from rng, so the decisions the game RNG makes later match the
+// recording.
+func (l *moveLog) replay(moves []engine.LegalMove, aiType AIPlayerType, rng *rand.Rand) *engine.LegalMove {
+	if aiType == RandomAI {
+		rng.Intn(len(moves))
+	}
+	if l.next >= len(l.moves) {
+		l.err = fmt.Sprintf("game continued past the %d recorded moves", len(l.moves))
+		return nil
+	}
+	idx := l.moves[l.next]
+	if idx < 0 || idx >= len(moves) {
+		l.err = fmt.Sprintf("move %d chose option %d of %d", l.next, idx, len(moves))
+		return nil
+	}
+	l.next++
+	return &moves[idx]
+}
+
+// nilMoveError describes a game stopped by a nil move.
+func (l *moveLog) nilMoveError() string {
+	if l != nil && l.err != "" {
+		return "replay diverged: " + l.err
+	}
+	return "AI returned nil move"
+}
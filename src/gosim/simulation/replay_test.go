@@ -0,0 +1,61 @@
+package simulation
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+// TestReplayWar records a War game, saves and loads the replay, and checks
+// playing it again ends identically.
+func TestReplayWar(t *testing.T) {
+	replay, result := RecordGameTyped(genome.CreateWarGenome(), 2, []AIPlayerType{RandomAI}, 12345)
+	if result.Error != "" {
+		t.Fatalf("War game returned error: %s", result.Error)
+	}
+
+	path := filepath.Join(t.TempDir(), "war.replay.json")
+	if err := SaveReplay(replay, path); err != nil {
+		t.Fatalf("Failed to save replay: %v", err)
+	}
+	loaded, err := LoadReplay(path)
+	if err != nil {
+		t.Fatalf("Failed to load replay: %v", err)
+	}
+
+	replayed, err := RunFromReplay(loaded)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replayed.WinnerID != result.WinnerID || replayed.TurnCount != result.TurnCount || replayed.Metrics.TotalDecisions != result.Metrics.TotalDecisions {
+		t.Errorf("Expected winner %d after %d turns, replay gave winner %d after %d turns",
+			result.WinnerID, result.TurnCount, replayed.WinnerID, replayed.TurnCount)
+	}
+}
+
+// TestReplayChoices checks a game with real decisions replays from its
+// recorded moves, and that a tampered replay is caught.
+func TestReplayChoices(t *testing.T) {
+	replay, result := RecordGameTyped(genome.CreateCrazyEightsGenome(), 2, []AIPlayerType{GreedyAI, RandomAI}, 7)
+	if result.Error != "" {
+		t.Fatalf("Crazy Eights game returned error: %s", result.Error)
+	}
+	if len(replay.Moves) == 0 {
+		t.Fatal("Expected recorded decisions")
+	}
+
+	replayed, err := RunFromReplay(replay)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replayed.WinnerID != result.WinnerID || replayed.TurnCount != result.TurnCount {
+		t.Errorf("Expected winner %d after %d turns, replay gave winner %d after %d turns",
+			result.WinnerID, result.TurnCount, replayed.WinnerID, replayed.TurnCount)
+	}
+
+	replay.Moves = replay.Moves[:len(replay.Moves)-1]
+	if _, err := RunFromReplay(replay); err == nil {
+		t.Error("Expected a truncated replay to fail")
+	}
+}
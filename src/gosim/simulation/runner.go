@@ -2,6 +2,8 @@ package simulation
 
 import (
 	"encoding/binary"
+	"fmt"
+	"math"
 	"math/rand"
 	"time"
 
@@ -21,6 +23,35 @@ const (
 	MCTS2000AI  AIPlayerType = 5
 )
 
+// aiPlayerNames maps command-line and config names to AI types.
+var aiPlayerNames = map[string]AIPlayerType{
+	"random":   RandomAI,
+	"greedy":   GreedyAI,
+	"mcts100":  MCTS100AI,
+	"mcts500":  MCTS500AI,
+	"mcts1000": MCTS1000AI,
+	"mcts2000": MCTS2000AI,
+}
+
+// ParseAIPlayerType returns the AI type with the given name, such as
+// "greedy" or "mcts500".
+func ParseAIPlayerType(name string) (AIPlayerType, error) {
+	if aiType, ok := aiPlayerNames[name]; ok {
+		return aiType, nil
+	}
+	return RandomAI, fmt.Errorf("unknown AI type %q", name)
+}
+
+// String returns the AI type's name, as ParseAIPlayerType reads it.
+func (t AIPlayerType) String() string {
+	for name, aiType := range aiPlayerNames {
+		if aiType == t {
+			return name
+		}
+	}
+	return fmt.Sprintf("AIPlayerType(%d)", uint8(t))
+}
+
 // GameMetrics holds Phase 1 instrumentation counters
 type GameMetrics struct {
 	TotalDecisions    uint64 // Decision points (when player chooses move)
@@ -54,23 +85,133 @@ type GameMetrics struct {
 	LeadChanges       uint32  // Number of times the lead changed hands
 	DecisiveTurnPct   float32 // Fraction of turns with margin >= 50% of max possible
 	ClosestMargin     float32 // Smallest margin observed (normalized 0-1)
+	MarginVolatility  float32 // Standard deviation of the signed lead margin (0-1)
 	WinnerWasTrailing bool    // True if winner was behind at midpoint (comeback win)
+
+	// Kingmaker metrics (3+ player games)
+	KingmakerDecisions uint64 // Losing players' final decisions replayed with alternatives
+	KingmakerEvents    uint64 // Of those, decisions where the loser's choice picked the winner
+
+	// Jump-in metrics (out-of-turn play)
+	JumpIns uint64 // Cards played out of turn
+
+	// Trade metrics
+	Trades uint64 // Trade offers accepted and carried out
+
+	// Tempo metrics
+	CardsMoved uint64 // Cards that entered or left the acting player's hand
+
+	// Hidden information metrics
+	OpponentCards  uint64 // Cards in opponents' hands at each decision
+	PeekedCards    uint64 // Of those, cards the deciding player had peeked at
+	HiddenSizes    uint64 // Decisions made without knowing the opponents' hand sizes
+	HiddenDiscards uint64 // Face-down discards by others on the pile at each decision
+	SealedBids     uint64 // Bids chosen without seeing the others' bids (blind bidding)
+	ShowdownCards  uint64 // Cards held by the players still in at decided showdowns
+	ShowdownShown  uint64 // Of those, cards turned over rather than mucked
+
+	// Effect chain metrics
+	EffectsCapped uint64 // Card effects ignored because a turn hit the effect cap
+	MovesCapped   uint64 // Decisions whose legal moves were sampled down to the move cap
+
+	// Standoff metrics
+	PassActions uint64 // Passes and checks: actions that left the game as it was
+
+	// Multi-hand metrics
+	HandLeadStability float32 // Share of hands after which the eventual winner led (see handLeadStability)
+
+	// Card usage histograms, by rank and suit
+	CardUsage engine.CardUsage
+}
+
+// StandoffPassShare is the share of a game's actions that must be passes or
+// checks for it to count as a standoff.
+const StandoffPassShare = 0.8
+
+// Standoff reports whether the game was an inert standoff: nearly every
+// action was a pass or a check, as when no one ever bets or everyone passes
+// until the turn limit. A tense game may pass often, but something still
+// happens between the passes.
+func (m *GameMetrics) Standoff() bool {
+	return m.TotalActions > 0 && float64(m.PassActions) >= StandoffPassShare*float64(m.TotalActions)
 }
 
 // GameResult holds the outcome of a single game
 type GameResult struct {
 	WinnerID       int8
 	WinningTeam    int8   // -1 = no teams or no winner, 0+ = winning team index
+	FirstPlayer    int8   // Seat that took the first turn
 	TurnCount      uint32
 	DurationNs     uint64
 	Error          string
 	Metrics        GameMetrics // Phase 1 instrumentation
+	FinalChips     []int64     // Chips per player at game end (nil if chips unused)
+	FinalScores    []int32     // Score per player at game end
+	Hands          []HandScore // Scoreboard after each finished hand (multi-hand games)
+}
+
+// HandScore is the scoreboard after one hand of a multi-hand game. Chips and
+// scores are running totals; HandDeltas gives what each hand changed.
+type HandScore struct {
+	Chips  []int64 // Chips per player (nil if chips unused)
+	Scores []int32 // Score per player
+	Leader int     // Player ahead by the game's leader detector, -1 if tied
+
+	// Set when each hand is scored on its own (genome.ScoreCarryPerHand):
+	// Chips and Scores are then the hand's own, and Winner took the hand
+	// (-1 if tied)
+	PerHand bool
+	Winner  int
+}
+
+// HandDeltas returns each player's change in standing over every hand: in
+// chips if the game is played with chips, otherwise in score. Players start
+// level, with no score and equal stacks.
+func (r *GameResult) HandDeltas() [][]int64 {
+	deltas := make([][]int64, len(r.Hands))
+	for h, hand := range r.Hands {
+		deltas[h] = handStanding(hand)
+		var prev []int64
+		if h > 0 && !hand.PerHand {
+			prev = handStanding(r.Hands[h-1])
+		} else if hand.Chips != nil {
+			// Chips are all in the stacks between hands
+			var total int64
+			for _, c := range hand.Chips {
+				total += c
+			}
+			prev = make([]int64, len(hand.Chips))
+			for i := range prev {
+				prev[i] = total / int64(len(prev))
+			}
+		}
+		for i := range deltas[h] {
+			if i < len(prev) {
+				deltas[h][i] -= prev[i]
+			}
+		}
+	}
+	return deltas
+}
+
+// handStanding returns each player's standing after a hand.
+func handStanding(hand HandScore) []int64 {
+	standing := make([]int64, len(hand.Scores))
+	for i := range standing {
+		if hand.Chips != nil {
+			standing[i] = hand.Chips[i]
+		} else {
+			standing[i] = int64(hand.Scores[i])
+		}
+	}
+	return standing
 }
 
 // AggregatedStats summarizes multiple game results
 type AggregatedStats struct {
 	TotalGames    uint32
 	Wins          []uint32 // Wins per player (index = player ID)
+	TurnOrderWins []uint32 // Wins per place in the turn order (0 = the first player to act)
 	Draws         uint32
 	AvgTurns      float32
 	MedianTurns   uint32
@@ -100,10 +241,11 @@ type AggregatedStats struct {
 	AllInCount    uint64
 
 	// Tension metrics: aggregated across all games
-	LeadChanges     uint32  // Sum of lead changes across all games
-	DecisiveTurnPct float32 // Average decisive turn percentage
-	ClosestMargin   float32 // Average closest margin
-	TrailingWinners uint32  // Games where winner was behind at midpoint
+	LeadChanges      uint32  // Sum of lead changes across all games
+	DecisiveTurnPct  float32 // Average decisive turn percentage
+	ClosestMargin    float32 // Average closest margin
+	MarginVolatility float32 // Average lead margin volatility
+	TrailingWinners  uint32  // Games where winner was behind at midpoint
 
 	// Solitaire detection metrics (interaction quality)
 	MoveDisruptionEvents uint64 // Opponent turns that changed waiting player's legal moves
@@ -113,6 +255,157 @@ type AggregatedStats struct {
 
 	// Team play metrics
 	TeamWins []uint32 // Win count per team (nil if no teams)
+
+	// Kingmaker metrics
+	KingmakerDecisions uint64
+	KingmakerEvents    uint64
+
+	// Jump-in metrics
+	JumpIns uint64
+
+	// Trade metrics
+	Trades uint64
+
+	// Tempo metrics
+	CardsMoved uint64 // Sum of cards moved by every action
+
+	// Hidden information metrics
+	OpponentCards  uint64
+	PeekedCards    uint64
+	HiddenSizes    uint64
+	HiddenDiscards uint64
+	SealedBids     uint64
+	ShowdownCards  uint64
+	ShowdownShown  uint64
+
+	// Effect chain metrics: effects ignored at the per-turn cap, a sign of a
+	// runaway genome
+	EffectsCapped uint64
+
+	// Decisions whose legal moves were sampled down to the move cap, a sign
+	// of a degenerate genome
+	MovesCapped uint64
+
+	// Card usage histograms summed over all games
+	CardUsage engine.CardUsage
+
+	// Standoff metrics
+	PassActions   uint64
+	StandoffGames uint32 // Games dominated by passes and checks (see GameMetrics.Standoff)
+
+	// Economy metrics: final chip distribution of games played with chips
+	ChipGames       uint32  // Games that ended with chips in play
+	AvgChipVariance float64 // Mean variance of final chip counts
+	AvgChipSpread   float32 // Mean normalized chip spread (0 = even stacks, 1 = one player holds all)
+
+	// Multi-hand metrics: decided games that ran two or more hands
+	MultiHandGames    uint32
+	HandLeadStability float32 // Mean share of hands after which the eventual winner led
+}
+
+// SeatWinRates returns the share of the decided games won from each of the
+// first numSeats seats. Every seat winning about equally often means acting
+// position carries no edge. Returns nil if no game was decided.
+func (s *AggregatedStats) SeatWinRates(numSeats int) []float64 {
+	if numSeats > len(s.Wins) {
+		numSeats = len(s.Wins)
+	}
+	var decided uint32
+	for _, w := range s.Wins[:numSeats] {
+		decided += w
+	}
+	if decided == 0 {
+		return nil
+	}
+
+	rates := make([]float64, numSeats)
+	for i, w := range s.Wins[:numSeats] {
+		rates[i] = float64(w) / float64(decided)
+	}
+	return rates
+}
+
+// SeatEdge returns how far the most successful seat's win rate is above an
+// even share of the decided games (0 = no positional imbalance).
+func (s *AggregatedStats) SeatEdge(numSeats int) float64 {
+	rates := s.SeatWinRates(numSeats)
+	if len(rates) == 0 {
+		return 0
+	}
+	best := 0.0
+	for _, r := range rates {
+		best = math.Max(best, r)
+	}
+	return best - 1/float64(len(rates))
+}
+
+// ErrorRate returns the fraction of games that ended in an error rather
+// than a result: a sign of a structurally broken genome.
+func (s *AggregatedStats) ErrorRate() float64 {
+	if s.TotalGames == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.TotalGames)
+}
+
+// RevealedInfo returns the share of opponents' cards the deciding player
+// had peeked at, averaged over decisions: 0 when every hand stays hidden.
+func (s *AggregatedStats) RevealedInfo() float64 {
+	if s.OpponentCards == 0 {
+		return 0
+	}
+	return float64(s.PeekedCards) / float64(s.OpponentCards)
+}
+
+// ShowdownExposure returns the share of the cards held at showdowns that
+// were shown rather than mucked: 0 when no hand went to a showdown.
+func (s *AggregatedStats) ShowdownExposure() float64 {
+	if s.ShowdownCards == 0 {
+		return 0
+	}
+	return float64(s.ShowdownShown) / float64(s.ShowdownCards)
+}
+
+// Tempo returns the average number of cards moved per action, so a game
+// where every turn plays or draws a single card has a tempo of 1.
+func (s *AggregatedStats) Tempo() float64 {
+	if s.TotalActions == 0 {
+		return 0
+	}
+	return float64(s.CardsMoved) / float64(s.TotalActions)
+}
+
+// CardRelevance returns how evenly play touches the deck: the normalized
+// entropy of every played, captured and scored card, averaged over the rank
+// and suit histograms. 1 means every rank and suit mattered about equally;
+// ranks or suits that never come up (dead cards) pull it towards 0.
+func (s *AggregatedStats) CardRelevance() float64 {
+	var total engine.CardCounts
+	total.Merge(&s.CardUsage.Played)
+	total.Merge(&s.CardUsage.Captured)
+	total.Merge(&s.CardUsage.Scored)
+	return (normalizedEntropy(total.ByRank[:]) + normalizedEntropy(total.BySuit[:])) / 2
+}
+
+// normalizedEntropy returns the Shannon entropy of counts divided by its
+// maximum, log(len(counts)): 1 for a flat histogram, 0 for an empty one or
+// one with a single bucket in use.
+func normalizedEntropy(counts []uint64) float64 {
+	var sum uint64
+	for _, n := range counts {
+		sum += n
+	}
+	if sum == 0 || len(counts) < 2 {
+		return 0
+	}
+	entropy := 0.0
+	for _, n := range counts {
+		if n > 0 {
+			p := float64(n) / float64(sum)
+			entropy -= p * math.Log(p)
+		}
+	}
+	return entropy / math.Log(float64(len(counts)))
 }
 
 // RunBatch simulates multiple games with the same genome and AI configuration
@@ -131,13 +424,16 @@ func RunBatch(genome *engine.Genome, numGames int, aiType AIPlayerType, mctsIter
 }
 
 // RunSingleGame plays one complete game to termination
-func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations int, seed uint64) GameResult {
+func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations int, seed uint64) (result GameResult) {
 	start := time.Now()
 	var metrics GameMetrics
 
 	// Initialize game state
 	state := engine.GetState()
 	defer engine.PutState(state)
+	state.Usage = &metrics.CardUsage
+	// Capture final chips/scores on every exit path, before the state is pooled
+	defer func() { result.FinalChips, result.FinalScores = finalPlayerState(state) }()
 
 	// Setup deck and deal cards
 	setupDeck(state, seed)
@@ -178,12 +474,8 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 		}
 	}
 
-	// Deal cards to each player
-	for i := 0; i < cardsPerPlayer; i++ {
-		for p := 0; p < numPlayers; p++ {
-			state.DrawCard(uint8(p), engine.LocationDeck)
-		}
-	}
+	// Deal cards to each player (bytecode genomes always deal round-robin)
+	state.DealHands(numPlayers, cardsPerPlayer, engine.DealRoundRobin)
 
 	// Deal initial cards to discard/tableau
 	// For TableauMode games (Scopa), cards go to Tableau[0]
@@ -218,8 +510,12 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 	detector := engine.SelectLeaderDetector(genome)
 	tensionMetrics := engine.NewTensionMetrics(int(state.NumPlayers))
 
-	// Game loop with turn limit protection
+	// Game loop with turn limit protection. A bytecode genome doesn't say
+	// which phases draw, so a default limit counts on the whole deck
 	maxTurns := genome.Header.MaxTurns
+	if maxTurns == 0 {
+		maxTurns = engine.DefaultMaxTurns(52, numPlayers, genome.WinConditions)
+	}
 	for state.TurnNumber < maxTurns {
 		// Check win conditions
 		winner := engine.CheckWinConditions(state, genome)
@@ -228,6 +524,7 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 			metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 			metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 			metrics.ClosestMargin = tensionMetrics.ClosestMargin
+			metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 			metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 			return GameResult{
 				WinnerID:    winner,
@@ -251,6 +548,7 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 					metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 					metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 					metrics.ClosestMargin = tensionMetrics.ClosestMargin
+					metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 					metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 					return GameResult{
 						WinnerID:    -1,
@@ -289,6 +587,10 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 					engine.AwardPot(state, winners)
 					metrics.FoldWins++ // Track fold win
 				} else if len(winners) > 1 {
+					if getShowPhaseData(genome) != nil {
+						// Hands are compared by the ShowPhase, which also awards the pot
+						continue
+					}
 					// Multiple players - use poker hand comparison
 					winner := engine.FindBestPokerWinner(state, int(state.NumPlayers))
 					if winner >= 0 {
@@ -310,15 +612,23 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 			for i := range aiTypes {
 				aiTypes[i] = aiType
 			}
-			runBiddingRound(state, genome, aiTypes)
+			metrics.SealedBids += uint64(runBiddingRound(state, genome, aiTypes))
 			continue // Skip normal move application, re-evaluate moves after bidding
 		}
 
+		// Reveal and compare hands once they are ready (not a player decision)
+		if showMove := findShowMove(moves); showMove != nil {
+			if resolveShowMove(state, genome, showMove) {
+				metrics.ShowdownWins++
+			}
+			continue
+		}
+
 		if len(moves) == 0 {
 			// No legal moves
 			// For blackjack, this means players can't draw anymore - determine winner
 			if engine.IsBlackjackGame(genome) {
-				winner := engine.FindBestBlackjackWinner(state, int(state.NumPlayers))
+				winner := engine.FindBestBlackjackWinner(state, int(state.NumPlayers), engine.BlackjackBustRule(genome))
 				if winner >= 0 {
 					metrics.ShowdownWins++
 				}
@@ -326,6 +636,7 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 				metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 				metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 				metrics.ClosestMargin = tensionMetrics.ClosestMargin
+				metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 				metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 				return GameResult{
 					WinnerID:    winner,
@@ -340,6 +651,7 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 			metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 			metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 			metrics.ClosestMargin = tensionMetrics.ClosestMargin
+			metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 			metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 			return GameResult{
 				WinnerID:    -1,
@@ -355,6 +667,7 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 		metrics.TotalDecisions++
 		metrics.TotalValidMoves += uint64(len(moves))
 		metrics.TotalHandSize += uint64(len(state.Players[state.CurrentPlayer].Hand))
+		trackHiddenInfo(state, &metrics)
 		if len(moves) == 1 {
 			metrics.ForcedDecisions++
 		}
@@ -384,8 +697,8 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 		if len(moves) == 1 {
 			move = &moves[0]
 		} else if hasBlackjackDrawMoves {
-			// Use basic blackjack strategy (hit <17, stand >=17)
-			idx := engine.SelectBlackjackMove(state, moves)
+			// Use basic blackjack strategy (hit below the stand value, stand on it)
+			idx := engine.SelectBlackjackMove(state, moves, engine.BlackjackBustRule(genome))
 			if idx >= 0 && idx < len(moves) {
 				move = &moves[idx]
 			} else {
@@ -415,6 +728,7 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 			metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 			metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 			metrics.ClosestMargin = tensionMetrics.ClosestMargin
+			metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 			metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 			return GameResult{
 				WinnerID:    -1,
@@ -431,6 +745,9 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 		if isInteraction(state, move, genome) {
 			metrics.TotalInteractions++
 		}
+		if isPassMove(move) {
+			metrics.PassActions++
+		}
 
 		// Track bluffing metrics before ApplyMove changes state
 		trackBluffingMetrics(state, move, genome, &metrics)
@@ -440,7 +757,11 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 			metrics.ContentionEvents++
 		}
 
+		handBefore := tallyHand(state.Players[actingPlayer].Hand)
 		engine.ApplyMove(state, move, genome)
+		metrics.CardsMoved += cardsMoved(handBefore, state.Players[actingPlayer].Hand)
+		metrics.EffectsCapped = uint64(state.EffectsCapped)
+		metrics.MovesCapped = uint64(state.MovesCapped)
 
 		// Track move disruption - did this turn change next player's options?
 		// Note: actingPlayer and nextPlayerIdx captured BEFORE ApplyMove
@@ -475,6 +796,7 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 	metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 	metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 	metrics.ClosestMargin = tensionMetrics.ClosestMargin
+	metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 	metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 	return GameResult{
 		WinnerID:    -1,
@@ -485,8 +807,17 @@ func RunSingleGame(genome *engine.Genome, aiType AIPlayerType, mctsIterations in
 	}
 }
 
+// AsymmetricDeterminizations is how many deals of the hidden cards an MCTS
+// player searches per move in asymmetric games, which measure one AI's edge
+// over another. Searching the true deal would let it play with its
+// opponents' cards face up, so in a hidden-information game its edge would
+// come from seeing what it shouldn't rather than from skill: a skill gap is
+// only meaningful between AIs that play from what their seat can know.
+const AsymmetricDeterminizations = 4
+
 // RunBatchAsymmetric simulates games with different AI types for each player.
-// Used for skill gap measurement (e.g., MCTS vs Random).
+// Used for skill gap measurement (e.g., MCTS vs Random). MCTS players search
+// AsymmetricDeterminizations deals of the cards they cannot see.
 func RunBatchAsymmetric(genome *engine.Genome, numGames int, p0AIType AIPlayerType, p1AIType AIPlayerType, mctsIterations int, seed uint64) AggregatedStats {
 	results := make([]GameResult, numGames)
 	rng := rand.New(rand.NewSource(int64(seed)))
@@ -500,12 +831,23 @@ func RunBatchAsymmetric(genome *engine.Genome, numGames int, p0AIType AIPlayerTy
 }
 
 // RunSingleGameAsymmetric plays one game with different AI for each player.
-func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIType AIPlayerType, mctsIterations int, seed uint64) GameResult {
+func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIType AIPlayerType, mctsIterations int, seed uint64) (result GameResult) {
+	return runSingleGameAsymmetric(genome, p0AIType, p1AIType, mctsIterations, AsymmetricDeterminizations, seed)
+}
+
+// runSingleGameAsymmetric is RunSingleGameAsymmetric with MCTS players
+// searching determinizations deals of the cards they cannot see (1 = the
+// true deal).
+func runSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIType AIPlayerType, mctsIterations int, determinizations int, seed uint64) (result GameResult) {
 	start := time.Now()
+	searchRng := rand.New(rand.NewSource(int64(seed)))
 	var metrics GameMetrics
 
 	state := engine.GetState()
 	defer engine.PutState(state)
+	state.Usage = &metrics.CardUsage
+	// Capture final chips/scores on every exit path, before the state is pooled
+	defer func() { result.FinalChips, result.FinalScores = finalPlayerState(state) }()
 
 	setupDeck(state, seed)
 
@@ -543,11 +885,7 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 		}
 	}
 
-	for i := 0; i < cardsPerPlayer; i++ {
-		for p := 0; p < numPlayers; p++ {
-			state.DrawCard(uint8(p), engine.LocationDeck)
-		}
-	}
+	state.DealHands(numPlayers, cardsPerPlayer, engine.DealRoundRobin)
 
 	// Deal initial cards to discard/tableau
 	// For TableauMode games (Scopa), cards go to Tableau[0]
@@ -583,6 +921,9 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 	tensionMetrics := engine.NewTensionMetrics(int(state.NumPlayers))
 
 	maxTurns := genome.Header.MaxTurns
+	if maxTurns == 0 {
+		maxTurns = engine.DefaultMaxTurns(52, numPlayers, genome.WinConditions)
+	}
 	for state.TurnNumber < maxTurns {
 		winner := engine.CheckWinConditions(state, genome)
 		if winner >= 0 {
@@ -590,6 +931,7 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 			metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 			metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 			metrics.ClosestMargin = tensionMetrics.ClosestMargin
+			metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 			metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 			return GameResult{
 				WinnerID:    winner,
@@ -612,6 +954,7 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 					metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 					metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 					metrics.ClosestMargin = tensionMetrics.ClosestMargin
+					metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 					metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 					return GameResult{
 						WinnerID:    -1,
@@ -650,6 +993,10 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 					engine.AwardPot(state, winners)
 					metrics.FoldWins++ // Track fold win
 				} else if len(winners) > 1 {
+					if getShowPhaseData(genome) != nil {
+						// Hands are compared by the ShowPhase, which also awards the pot
+						continue
+					}
 					// Multiple players - use poker hand comparison
 					winner := engine.FindBestPokerWinner(state, int(state.NumPlayers))
 					if winner >= 0 {
@@ -666,15 +1013,23 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 
 		// Check if this is a bidding phase
 		if hasBiddingMoves(moves) {
-			runBiddingRoundAsymmetric(state, genome, p0AIType, p1AIType)
+			metrics.SealedBids += uint64(runBiddingRoundAsymmetric(state, genome, p0AIType, p1AIType))
 			continue // Skip normal move application, re-evaluate moves after bidding
 		}
 
+		// Reveal and compare hands once they are ready (not a player decision)
+		if showMove := findShowMove(moves); showMove != nil {
+			if resolveShowMove(state, genome, showMove) {
+				metrics.ShowdownWins++
+			}
+			continue
+		}
+
 		if len(moves) == 0 {
 			// No legal moves
 			// For blackjack, this means players can't draw anymore - determine winner
 			if engine.IsBlackjackGame(genome) {
-				winner := engine.FindBestBlackjackWinner(state, int(state.NumPlayers))
+				winner := engine.FindBestBlackjackWinner(state, int(state.NumPlayers), engine.BlackjackBustRule(genome))
 				if winner >= 0 {
 					metrics.ShowdownWins++
 				}
@@ -682,6 +1037,7 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 				metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 				metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 				metrics.ClosestMargin = tensionMetrics.ClosestMargin
+				metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 				metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 				return GameResult{
 					WinnerID:    winner,
@@ -696,6 +1052,7 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 			metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 			metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 			metrics.ClosestMargin = tensionMetrics.ClosestMargin
+			metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 			metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 			return GameResult{
 				WinnerID:    -1,
@@ -710,6 +1067,7 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 		metrics.TotalDecisions++
 		metrics.TotalValidMoves += uint64(len(moves))
 		metrics.TotalHandSize += uint64(len(state.Players[state.CurrentPlayer].Hand))
+		trackHiddenInfo(state, &metrics)
 		if len(moves) == 1 {
 			metrics.ForcedDecisions++
 		}
@@ -745,13 +1103,13 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 			case GreedyAI:
 				move = selectGreedyMove(state, genome, moves)
 			case MCTS100AI:
-				move = mcts.Search(state, genome, 100, mcts.DefaultExplorationParam)
+				move = mcts.SearchDeterminized(state, genome, 100, determinizations, mcts.DefaultExplorationParam, searchRng)
 			case MCTS500AI:
-				move = mcts.Search(state, genome, 500, mcts.DefaultExplorationParam)
+				move = mcts.SearchDeterminized(state, genome, 500, determinizations, mcts.DefaultExplorationParam, searchRng)
 			case MCTS1000AI:
-				move = mcts.Search(state, genome, 1000, mcts.DefaultExplorationParam)
+				move = mcts.SearchDeterminized(state, genome, 1000, determinizations, mcts.DefaultExplorationParam, searchRng)
 			case MCTS2000AI:
-				move = mcts.Search(state, genome, 2000, mcts.DefaultExplorationParam)
+				move = mcts.SearchDeterminized(state, genome, 2000, determinizations, mcts.DefaultExplorationParam, searchRng)
 			default:
 				move = &moves[0]
 			}
@@ -762,6 +1120,7 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 			metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 			metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 			metrics.ClosestMargin = tensionMetrics.ClosestMargin
+			metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 			metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 			return GameResult{
 				WinnerID:    -1,
@@ -777,6 +1136,9 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 		if isInteraction(state, move, genome) {
 			metrics.TotalInteractions++
 		}
+		if isPassMove(move) {
+			metrics.PassActions++
+		}
 
 		// Track bluffing metrics before ApplyMove changes state
 		trackBluffingMetrics(state, move, genome, &metrics)
@@ -786,7 +1148,11 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 			metrics.ContentionEvents++
 		}
 
+		handBefore := tallyHand(state.Players[actingPlayer].Hand)
 		engine.ApplyMove(state, move, genome)
+		metrics.CardsMoved += cardsMoved(handBefore, state.Players[actingPlayer].Hand)
+		metrics.EffectsCapped = uint64(state.EffectsCapped)
+		metrics.MovesCapped = uint64(state.MovesCapped)
 
 		// Track move disruption - did this turn change next player's options?
 		// Note: actingPlayer and nextPlayerIdx captured BEFORE ApplyMove
@@ -821,6 +1187,7 @@ func RunSingleGameAsymmetric(genome *engine.Genome, p0AIType AIPlayerType, p1AIT
 	metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 	metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 	metrics.ClosestMargin = tensionMetrics.ClosestMargin
+	metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 	metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 	return GameResult{
 		WinnerID:    -1,
@@ -884,8 +1251,8 @@ func trackBluffingMetrics(state *engine.GameState, move *engine.LegalMove, genom
 		metrics.TotalClaims++
 
 		// Check if it's a bluff by looking at the cards being played
-		// The claimed rank will be based on turn number (sequential)
-		claimedRank := uint8(state.TurnNumber % 13)
+		// against the rank the move names (or the next in sequence)
+		claimedRank := engine.ClaimedRank(state, move)
 		hand := state.Players[state.CurrentPlayer].Hand
 
 		if move.CardIndex < len(hand) {
@@ -897,6 +1264,57 @@ func trackBluffingMetrics(state *engine.GameState, move *engine.LegalMove, genom
 	}
 }
 
+// trackHiddenInfo records how many of the opponents' cards the player about
+// to decide has seen, whether it can even count them, and how many discards
+// lie face-down out of its sight.
+func trackHiddenInfo(state *engine.GameState, metrics *GameMetrics) {
+	known, total := state.KnownOpponentCards(int(state.CurrentPlayer))
+	metrics.OpponentCards += uint64(total)
+	metrics.PeekedCards += uint64(known)
+	if state.HandSizesHidden && !state.Revealed {
+		metrics.HiddenSizes++
+	}
+	metrics.HiddenDiscards += uint64(len(state.ConcealedFrom(int(state.CurrentPlayer))))
+}
+
+// handTally counts the copies of each card in a hand, indexed by suit and
+// rank, so a hand can be compared before and after a move.
+type handTally [52]int16
+
+func tallyHand(hand []engine.Card) handTally {
+	var t handTally
+	for _, c := range hand {
+		t[int(c.Suit)*13+int(c.Rank)]++
+	}
+	return t
+}
+
+// cardsMoved returns how many cards entered or left a hand since before was
+// taken: one for a card played or drawn, two for a card swapped for another.
+func cardsMoved(before handTally, hand []engine.Card) uint64 {
+	after := tallyHand(hand)
+	moved := uint64(0)
+	for i := range before {
+		if d := after[i] - before[i]; d > 0 {
+			moved += uint64(d)
+		} else {
+			moved += uint64(-d)
+		}
+	}
+	return moved
+}
+
+// isPassMove reports whether a move declines to act: passing on a play,
+// draw, give or trade. Accepting a claim isn't a pass, since the claimed cards
+// still change hands.
+func isPassMove(move *engine.LegalMove) bool {
+	switch move.CardIndex {
+	case engine.MovePlayPass, engine.MoveDrawPass, engine.MoveGivePass, engine.MoveTradePass:
+		return true
+	}
+	return false
+}
+
 // isInteraction determines if a move affects the opponent's state
 func isInteraction(state *engine.GameState, move *engine.LegalMove, genome *engine.Genome) bool {
 	if move.PhaseIndex >= len(genome.TurnPhases) {
@@ -944,6 +1362,12 @@ func isInteraction(state *engine.GameState, move *engine.LegalMove, genome *engi
 		// Bluffing/Cheat is highly interactive - claims affect opponent decisions
 		// and challenges affect who picks up the pile
 		return true
+	case engine.PhaseTypeGive:
+		// Giving a card changes the recipient's hand
+		return engine.GiveRecipient(move) >= 0
+	case engine.PhaseTypeTrade:
+		// An offer asks the recipient to answer it
+		return engine.TradeRecipient(move) >= 0
 	}
 
 	return false
@@ -1029,7 +1453,9 @@ func getLegalMovesForPlayer(state *engine.GameState, genome *engine.Genome, play
 	return moves
 }
 
-// setupDeck creates and shuffles a standard 52-card deck
+// setupDeck creates and shuffles a standard 52-card deck. Before the
+// shuffle the deck runs suit by suit, ace to king; seed then fixes the order
+// through engine.ShuffleDeck, and cards are drawn from the end of the slice.
 func setupDeck(state *engine.GameState, seed uint64) {
 	// Create standard 52-card deck
 	for suit := uint8(0); suit < 4; suit++ {
@@ -1083,8 +1509,9 @@ func scoreMove(state *engine.GameState, move *engine.LegalMove) float64 {
 // aggregateResults computes summary statistics
 func aggregateResults(results []GameResult) AggregatedStats {
 	stats := AggregatedStats{
-		TotalGames: uint32(len(results)),
-		Wins:       make([]uint32, 4), // Support up to 4 players
+		TotalGames:    uint32(len(results)),
+		Wins:          make([]uint32, 4), // Support up to 4 players
+		TurnOrderWins: make([]uint32, 4),
 	}
 
 	turnCounts := make([]uint32, 0, len(results))
@@ -1124,6 +1551,9 @@ func aggregateResults(results []GameResult) AggregatedStats {
 		// Track wins by player ID (supports N players)
 		if result.WinnerID >= 0 && int(result.WinnerID) < len(stats.Wins) {
 			stats.Wins[result.WinnerID]++
+			if n := len(result.FinalScores); n > 0 {
+				stats.TurnOrderWins[(int(result.WinnerID)-int(result.FirstPlayer)+n)%n]++
+			}
 		} else {
 			stats.Draws++
 		}
@@ -1162,6 +1592,7 @@ func aggregateResults(results []GameResult) AggregatedStats {
 		stats.LeadChanges += result.Metrics.LeadChanges
 		stats.DecisiveTurnPct += result.Metrics.DecisiveTurnPct
 		stats.ClosestMargin += result.Metrics.ClosestMargin
+		stats.MarginVolatility += result.Metrics.MarginVolatility
 		if result.Metrics.WinnerWasTrailing {
 			stats.TrailingWinners++
 		}
@@ -1171,6 +1602,54 @@ func aggregateResults(results []GameResult) AggregatedStats {
 		stats.ContentionEvents += result.Metrics.ContentionEvents
 		stats.ForcedResponseEvents += result.Metrics.ForcedResponseEvents
 		stats.OpponentTurnCount += result.Metrics.OpponentTurnCount
+
+		// Kingmaker metrics
+		stats.KingmakerDecisions += result.Metrics.KingmakerDecisions
+		stats.KingmakerEvents += result.Metrics.KingmakerEvents
+
+		// Jump-in metrics
+		stats.JumpIns += result.Metrics.JumpIns
+
+		// Trade metrics
+		stats.Trades += result.Metrics.Trades
+
+		// Tempo metrics
+		stats.CardsMoved += result.Metrics.CardsMoved
+
+		// Hidden information metrics
+		stats.OpponentCards += result.Metrics.OpponentCards
+		stats.PeekedCards += result.Metrics.PeekedCards
+		stats.HiddenSizes += result.Metrics.HiddenSizes
+		stats.HiddenDiscards += result.Metrics.HiddenDiscards
+		stats.SealedBids += result.Metrics.SealedBids
+		stats.ShowdownCards += result.Metrics.ShowdownCards
+		stats.ShowdownShown += result.Metrics.ShowdownShown
+
+		// Effect chain metrics
+		stats.EffectsCapped += result.Metrics.EffectsCapped
+		stats.MovesCapped += result.Metrics.MovesCapped
+
+		// Card usage histograms
+		stats.CardUsage.Merge(&result.Metrics.CardUsage)
+
+		// Standoff metrics
+		stats.PassActions += result.Metrics.PassActions
+		if result.Metrics.Standoff() {
+			stats.StandoffGames++
+		}
+
+		// Economy metrics (averaged below)
+		if variance, spread, ok := chipSpread(result.FinalChips); ok {
+			stats.ChipGames++
+			stats.AvgChipVariance += variance
+			stats.AvgChipSpread += float32(spread)
+		}
+
+		// Multi-hand metrics (averaged below)
+		if len(result.Hands) >= 2 && result.WinnerID >= 0 {
+			stats.MultiHandGames++
+			stats.HandLeadStability += result.Metrics.HandLeadStability
+		}
 	}
 
 	// Calculate averages
@@ -1179,6 +1658,14 @@ func aggregateResults(results []GameResult) AggregatedStats {
 		// Tension metrics: compute averages
 		stats.DecisiveTurnPct = stats.DecisiveTurnPct / float32(validGames)
 		stats.ClosestMargin = stats.ClosestMargin / float32(validGames)
+		stats.MarginVolatility = stats.MarginVolatility / float32(validGames)
+	}
+	if stats.ChipGames > 0 {
+		stats.AvgChipVariance /= float64(stats.ChipGames)
+		stats.AvgChipSpread /= float32(stats.ChipGames)
+	}
+	if stats.MultiHandGames > 0 {
+		stats.HandLeadStability /= float32(stats.MultiHandGames)
 	}
 
 	if validGames > 0 {
@@ -1203,6 +1690,82 @@ func aggregateResults(results []GameResult) AggregatedStats {
 	return stats
 }
 
+// finalPlayerState copies each player's chips and score out of state.
+// Chips are nil when no chips are in play (non-betting games).
+func finalPlayerState(state *engine.GameState) ([]int64, []int32) {
+	numPlayers := int(state.NumPlayers)
+	if numPlayers > len(state.Players) {
+		numPlayers = len(state.Players)
+	}
+
+	scores := make([]int32, numPlayers)
+	var chips []int64
+	totalChips := state.Pot
+	for i := 0; i < numPlayers; i++ {
+		scores[i] = state.Players[i].Score
+		totalChips += state.Players[i].Chips
+	}
+	if totalChips > 0 {
+		chips = make([]int64, numPlayers)
+		for i := 0; i < numPlayers; i++ {
+			chips[i] = state.Players[i].Chips
+		}
+	}
+	return chips, scores
+}
+
+// recordHand returns the scoreboard at the end of a hand.
+func recordHand(state *engine.GameState, detector engine.LeaderDetector) HandScore {
+	chips, scores := finalPlayerState(state)
+	return HandScore{Chips: chips, Scores: scores, Leader: detector.GetLeader(state), Winner: -1}
+}
+
+// handLeadStability returns the share of hands after which the eventual
+// winner led. A game won in its first hand and coasted to the end scores
+// 1; one where the winner only took the lead in the final hand scores
+// 1/len(hands).
+func handLeadStability(hands []HandScore, winner int) float32 {
+	if len(hands) == 0 || winner < 0 {
+		return 0
+	}
+	led := 0
+	for _, hand := range hands {
+		if hand.Leader == winner {
+			led++
+		}
+	}
+	return float32(led) / float32(len(hands))
+}
+
+// chipSpread returns the variance of the final chip counts and their
+// coefficient of variation normalized to [0, 1], where 1 means a single
+// player holds every chip. ok is false when there are no chips to compare.
+func chipSpread(chips []int64) (variance, spread float64, ok bool) {
+	if len(chips) < 2 {
+		return 0, 0, false
+	}
+
+	total := int64(0)
+	for _, c := range chips {
+		total += c
+	}
+	if total <= 0 {
+		return 0, 0, false
+	}
+
+	n := float64(len(chips))
+	mean := float64(total) / n
+	for _, c := range chips {
+		d := float64(c) - mean
+		variance += d * d
+	}
+	variance /= n
+
+	// Coefficient of variation peaks at sqrt(n-1) when one player has everything
+	spread = math.Sqrt(variance) / mean / math.Sqrt(n-1)
+	return variance, math.Min(1.0, spread), true
+}
+
 // median calculates the median of a slice
 func median(values []uint32) uint32 {
 	if len(values) == 0 {
@@ -1249,6 +1812,44 @@ func hasBiddingMoves(moves []engine.LegalMove) bool {
 	return false
 }
 
+// findShowMove returns the show move if hands are due to be revealed, or nil.
+func findShowMove(moves []engine.LegalMove) *engine.LegalMove {
+	for i := range moves {
+		if moves[i].CardIndex == engine.MoveShow {
+			return &moves[i]
+		}
+	}
+	return nil
+}
+
+// getShowPhaseData finds and parses the first show phase from genome, or nil.
+func getShowPhaseData(genome *engine.Genome) *engine.ShowPhaseData {
+	for _, phase := range genome.TurnPhases {
+		if phase.PhaseType == engine.PhaseTypeShow {
+			data, _ := engine.ParseShowPhaseData(phase.Data)
+			return data
+		}
+	}
+	return nil
+}
+
+// resolveShowMove reveals and compares hands for a show move, awarding the
+// pot and/or points. If the show closes out a betting hand, the hand is reset
+// so the next betting round can start. Returns true if anyone won.
+func resolveShowMove(state *engine.GameState, genome *engine.Genome, move *engine.LegalMove) bool {
+	show, err := engine.ParseShowPhaseData(genome.TurnPhases[move.PhaseIndex].Data)
+	if err != nil {
+		return false
+	}
+
+	winners := engine.ResolveShow(state, genome.HandEval, show)
+	state.TurnNumber++
+	if state.BettingComplete {
+		state.ResetHand()
+	}
+	return len(winners) > 0
+}
+
 // getBettingPhaseData finds and parses the betting phase from genome
 func getBettingPhaseData(genome *engine.Genome) *engine.BettingPhaseData {
 	for _, phase := range genome.TurnPhases {
@@ -1270,6 +1871,20 @@ func anyNeedsToAct(needsToAct []bool) bool {
 	return false
 }
 
+// resetNeedsToAct puts every other player still able to bet to act again
+// once the current bet has gone up. After a full bet or raise they may raise
+// in turn; after an all-in short of the minimum raise, those who have acted
+// since the last full raise may only call or fold, as the engine tracks with
+// PlayerState.RaiseClosed.
+func resetNeedsToAct(state *engine.GameState, needsToAct []bool, actor int) {
+	for i := 0; i < int(state.NumPlayers); i++ {
+		p := &state.Players[i]
+		if !p.HasFolded && !p.IsAllIn && p.Chips > 0 && i != actor {
+			needsToAct[i] = true
+		}
+	}
+}
+
 // runBettingRound executes a complete betting round
 // Returns error string if round fails, empty string on success
 func runBettingRound(state *engine.GameState, genome *engine.Genome, bettingPhase *engine.BettingPhaseData, aiType AIPlayerType, metrics *GameMetrics, tensionMetrics *engine.TensionMetrics, detector engine.LeaderDetector) string {
@@ -1282,11 +1897,12 @@ func runBettingRound(state *engine.GameState, genome *engine.Genome, bettingPhas
 
 	// Ensure starting player is in bounds (BettingStartPlayer may exceed NumPlayers after rotation)
 	currentPlayer := state.BettingStartPlayer % int(state.NumPlayers)
-	maxActions := int(state.NumPlayers) * (bettingPhase.MaxRaises + 2) * 2 // Safety limit
+	maxActions := engine.MaxBettingActions(int(state.NumPlayers), bettingPhase)
+	minPlayers := bettingPhase.MinPlayersToContinue()
 
 	for actionCount := 0; actionCount < maxActions; actionCount++ {
-		// Check termination: only one player remains
-		if engine.CountActivePlayers(state) <= 1 {
+		// Check termination: too few players remain in the hand
+		if engine.CountPlayersInHand(state) < minPlayers {
 			break
 		}
 
@@ -1333,7 +1949,7 @@ func runBettingRound(state *engine.GameState, genome *engine.Genome, bettingPhas
 		switch aiType {
 		case GreedyAI:
 			handStrength := engine.EvaluateHandStrength(state.Players[currentPlayer].Hand)
-			action = engine.SelectGreedyBettingAction(state, moves, handStrength)
+			action = engine.SelectModeledBettingAction(state, currentPlayer, moves, handStrength)
 		default: // RandomAI and MCTS use random for betting
 			action = engine.SelectRandomBettingAction(moves, rand.Intn)
 		}
@@ -1357,6 +1973,9 @@ func runBettingRound(state *engine.GameState, genome *engine.Genome, bettingPhas
 		engine.ApplyBettingAction(state, bettingPhase, currentPlayer, action)
 		metrics.TotalActions++
 		metrics.TotalInteractions++ // Betting is always interactive
+		if action == engine.BettingCheck {
+			metrics.PassActions++
+		}
 
 		// Update tension tracking after each betting action
 		if tensionMetrics != nil && detector != nil {
@@ -1365,12 +1984,7 @@ func runBettingRound(state *engine.GameState, genome *engine.Genome, bettingPhas
 
 		// If bet increased, everyone else needs to act again
 		if state.CurrentBet > oldCurrentBet {
-			for i := 0; i < int(state.NumPlayers); i++ {
-				p := &state.Players[i]
-				if !p.HasFolded && !p.IsAllIn && p.Chips > 0 && i != currentPlayer {
-					needsToAct[i] = true
-				}
-			}
+			resetNeedsToAct(state, needsToAct, currentPlayer)
 		}
 
 		needsToAct[currentPlayer] = false
@@ -1393,11 +2007,12 @@ func runBettingRoundAsymmetric(state *engine.GameState, genome *engine.Genome, b
 
 	// Ensure starting player is in bounds (BettingStartPlayer may exceed NumPlayers after rotation)
 	currentPlayer := state.BettingStartPlayer % int(state.NumPlayers)
-	maxActions := int(state.NumPlayers) * (bettingPhase.MaxRaises + 2) * 2 // Safety limit
+	maxActions := engine.MaxBettingActions(int(state.NumPlayers), bettingPhase)
+	minPlayers := bettingPhase.MinPlayersToContinue()
 
 	for actionCount := 0; actionCount < maxActions; actionCount++ {
-		// Check termination: only one player remains
-		if engine.CountActivePlayers(state) <= 1 {
+		// Check termination: too few players remain in the hand
+		if engine.CountPlayersInHand(state) < minPlayers {
 			break
 		}
 
@@ -1452,7 +2067,7 @@ func runBettingRoundAsymmetric(state *engine.GameState, genome *engine.Genome, b
 		switch aiType {
 		case GreedyAI:
 			handStrength := engine.EvaluateHandStrength(state.Players[currentPlayer].Hand)
-			action = engine.SelectGreedyBettingAction(state, moves, handStrength)
+			action = engine.SelectModeledBettingAction(state, currentPlayer, moves, handStrength)
 		default: // RandomAI and MCTS use random for betting
 			action = engine.SelectRandomBettingAction(moves, rand.Intn)
 		}
@@ -1476,15 +2091,13 @@ func runBettingRoundAsymmetric(state *engine.GameState, genome *engine.Genome, b
 		engine.ApplyBettingAction(state, bettingPhase, currentPlayer, action)
 		metrics.TotalActions++
 		metrics.TotalInteractions++ // Betting is always interactive
+		if action == engine.BettingCheck {
+			metrics.PassActions++
+		}
 
 		// If bet increased, everyone else needs to act again
 		if state.CurrentBet > oldCurrentBet {
-			for i := 0; i < int(state.NumPlayers); i++ {
-				p := &state.Players[i]
-				if !p.HasFolded && !p.IsAllIn && p.Chips > 0 && i != currentPlayer {
-					needsToAct[i] = true
-				}
-			}
+			resetNeedsToAct(state, needsToAct, currentPlayer)
 		}
 
 		needsToAct[currentPlayer] = false
@@ -1515,32 +2128,44 @@ func getBiddingPhaseData(genome *engine.Genome) []byte {
 	return nil
 }
 
-// selectGreedyBid estimates tricks and returns a bid value for greedy AI
+// selectGreedyBid estimates tricks and returns a bid value for greedy AI,
+// never claiming more tricks than the bids already placed leave. When the
+// bid names trump, it names its longest suit.
 func selectGreedyBid(state *engine.GameState, biddingPhase engine.BiddingPhase, playerIdx int) engine.BidMove {
 	hand := state.Players[playerIdx].Hand
 	handSize := len(hand)
 
+	// Trump is spades (suit 3) unless the bidder gets to name it
+	trump := uint8(3)
+	if biddingPhase.DeclareTrump {
+		trump = longestSuit(hand)
+	}
+
+	// Tricks the bids already placed have not claimed
+	available := handSize
+	for i := 0; i < int(state.NumPlayers); i++ {
+		if p := &state.Players[i]; i != playerIdx && p.CurrentBid > 0 && !p.IsNilBid {
+			available -= int(p.CurrentBid)
+		}
+	}
+
 	// Estimate tricks based on high cards
 	estimate := 0
-	emptyCard := engine.Card{}
 	for _, card := range hand {
-		if card == emptyCard { // Empty slot
-			continue
-		}
 		// Count high cards (Q=10, K=11, A=12 in 0-indexed)
 		if card.Rank >= 10 { // Queen or higher
 			estimate++
 		}
-		// Bonus for spades (trump) - assuming spades is suit 3
-		if card.Suit == 3 { // Spades
+		// Bonus for trump
+		if card.Suit == trump {
 			estimate++
 		}
 	}
 
-	// Cap estimate at hand size and adjust to be conservative
+	// Cap estimate at the tricks left to claim and adjust to be conservative
 	estimate = estimate / 2 // Be conservative
-	if estimate > handSize {
-		estimate = handSize
+	if estimate > max(available, 0) {
+		estimate = max(available, 0)
 	}
 
 	// Ensure within valid bid range
@@ -1561,100 +2186,118 @@ func selectGreedyBid(state *engine.GameState, biddingPhase engine.BiddingPhase,
 		return engine.BidMove{Value: 0, IsNil: true}
 	}
 
+	if biddingPhase.DeclareTrump {
+		return engine.BidMove{Value: bid, Trump: trump, NamesTrump: true}
+	}
 	return engine.BidMove{Value: bid, IsNil: false}
 }
 
-// runBiddingRound executes a complete bidding round for all players
-func runBiddingRound(state *engine.GameState, genome *engine.Genome, aiTypes []AIPlayerType) {
+// longestSuit returns the suit hand holds most cards of, the lowest on a tie.
+func longestSuit(hand []engine.Card) uint8 {
+	var counts [4]int
+	for _, card := range hand {
+		if card.Suit < 4 {
+			counts[card.Suit]++
+		}
+	}
+	best := uint8(0)
+	for suit := uint8(1); suit < 4; suit++ {
+		if counts[suit] > counts[best] {
+			best = suit
+		}
+	}
+	return best
+}
+
+// runBiddingRound executes a complete bidding round for all players and
+// returns the number of sealed bids (see collectBids).
+func runBiddingRound(state *engine.GameState, genome *engine.Genome, aiTypes []AIPlayerType) int {
 	biddingData := getBiddingPhaseData(genome)
 	if biddingData == nil {
-		return
+		return 0
 	}
 	biddingPhase, _, bytesRead := engine.ParseBiddingPhase(biddingData)
 	if bytesRead == 0 {
-		return
-	}
-
-	// Reset bidding state
-	state.BiddingComplete = false
-	for i := 0; i < int(state.NumPlayers); i++ {
-		state.Players[i].CurrentBid = -1
-		state.Players[i].IsNilBid = false
-	}
-
-	// Each player bids in order starting from current player
-	startPlayer := int(state.CurrentPlayer)
-	for i := 0; i < int(state.NumPlayers); i++ {
-		playerIdx := (startPlayer + i) % int(state.NumPlayers)
-
-		// Get bid based on AI type
-		var bid engine.BidMove
-		aiType := aiTypes[playerIdx]
-		switch aiType {
-		case GreedyAI:
-			bid = selectGreedyBid(state, biddingPhase, playerIdx)
-		default: // RandomAI and MCTS use random for bidding
-			handSize := len(state.Players[playerIdx].Hand)
-			bidMoves := engine.GenerateBidMoves(biddingPhase, handSize)
-			if len(bidMoves) > 0 {
-				bid = bidMoves[rand.Intn(len(bidMoves))]
-			} else {
-				bid = engine.BidMove{Value: 1, IsNil: false}
-			}
-		}
-
-		engine.ApplyBidMove(state, playerIdx, bid)
-		state.TurnNumber++
+		return 0
 	}
+	return collectBids(state, biddingPhase.Blind, func(view *engine.GameState, playerIdx int) engine.BidMove {
+		return selectBid(view, biddingPhase, playerIdx, aiTypes[playerIdx], rand.Intn)
+	})
 }
 
 // runBiddingRoundAsymmetric executes a complete bidding round with different AI per player (for skill evaluation)
-func runBiddingRoundAsymmetric(state *engine.GameState, genome *engine.Genome, p0AIType AIPlayerType, p1AIType AIPlayerType) {
+func runBiddingRoundAsymmetric(state *engine.GameState, genome *engine.Genome, p0AIType AIPlayerType, p1AIType AIPlayerType) int {
 	biddingData := getBiddingPhaseData(genome)
 	if biddingData == nil {
-		return
+		return 0
 	}
 	biddingPhase, _, bytesRead := engine.ParseBiddingPhase(biddingData)
 	if bytesRead == 0 {
-		return
+		return 0
 	}
+	return collectBids(state, biddingPhase.Blind, func(view *engine.GameState, playerIdx int) engine.BidMove {
+		// Select AI based on current player
+		aiType := p1AIType
+		if playerIdx == 0 {
+			aiType = p0AIType
+		}
+		return selectBid(view, biddingPhase, playerIdx, aiType, rand.Intn)
+	})
+}
+
+// selectBid chooses playerIdx's bid as seen from view: greedy players bid
+// their estimate, everyone else a random legal bid drawn with intn.
+func selectBid(view *engine.GameState, biddingPhase engine.BiddingPhase, playerIdx int, aiType AIPlayerType, intn func(int) int) engine.BidMove {
+	if aiType == GreedyAI {
+		return selectGreedyBid(view, biddingPhase, playerIdx)
+	}
+	// RandomAI and MCTS use random for bidding
+	handSize := len(view.Players[playerIdx].Hand)
+	bidMoves := engine.GenerateBidMoves(biddingPhase, handSize)
+	if len(bidMoves) > 0 {
+		return bidMoves[intn(len(bidMoves))]
+	}
+	return engine.BidMove{Value: 1, IsNil: false}
+}
 
+// collectBids resets the bids and has every player bid once, in turn from
+// the current player, choosing each bid with choose. Normally each bid is
+// placed before the next player chooses, so later bidders see the earlier
+// bids. In a blind round every player chooses from the state as it stood
+// before anyone bid, and the sealed bids are then revealed together in
+// bidding order, the earlier bidder taking trump on a tie as usual.
+// Returns the number of sealed bids, 0 for an open round.
+func collectBids(state *engine.GameState, blind bool, choose func(view *engine.GameState, playerIdx int) engine.BidMove) int {
 	// Reset bidding state
 	state.BiddingComplete = false
+	state.TrumpNamed = false
 	for i := 0; i < int(state.NumPlayers); i++ {
 		state.Players[i].CurrentBid = -1
 		state.Players[i].IsNilBid = false
 	}
 
-	// Each player bids in order starting from current player
+	view := state
+	if blind {
+		view = state.Clone()
+		defer engine.PutState(view)
+	}
 	startPlayer := int(state.CurrentPlayer)
-	for i := 0; i < int(state.NumPlayers); i++ {
+	bids := make([]engine.BidMove, state.NumPlayers)
+	for i := range bids {
 		playerIdx := (startPlayer + i) % int(state.NumPlayers)
-
-		// Select AI based on current player
-		var aiType AIPlayerType
-		if playerIdx == 0 {
-			aiType = p0AIType
-		} else {
-			aiType = p1AIType
-		}
-
-		// Get bid based on AI type
-		var bid engine.BidMove
-		switch aiType {
-		case GreedyAI:
-			bid = selectGreedyBid(state, biddingPhase, playerIdx)
-		default: // RandomAI and MCTS use random for bidding
-			handSize := len(state.Players[playerIdx].Hand)
-			bidMoves := engine.GenerateBidMoves(biddingPhase, handSize)
-			if len(bidMoves) > 0 {
-				bid = bidMoves[rand.Intn(len(bidMoves))]
-			} else {
-				bid = engine.BidMove{Value: 1, IsNil: false}
-			}
+		bids[i] = choose(view, playerIdx)
+		if !blind {
+			engine.ApplyBidMove(state, playerIdx, bids[i])
+			state.TurnNumber++
 		}
+	}
+	if !blind {
+		return 0
+	}
 
-		engine.ApplyBidMove(state, playerIdx, bid)
+	for i, bid := range bids {
+		engine.ApplyBidMove(state, (startPlayer+i)%int(state.NumPlayers), bid)
 		state.TurnNumber++
 	}
+	return len(bids)
 }
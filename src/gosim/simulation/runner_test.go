@@ -1,8 +1,10 @@
 package simulation
 
 import (
+	"math"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/signalnine/darwindeck/gosim/engine"
@@ -519,3 +521,318 @@ func makeV2BytecodeWithTableauMode(tableauMode uint8, seqDir uint8) []byte {
 
 	return bytecode[:82]
 }
+
+func TestChipSpread(t *testing.T) {
+	if _, _, ok := chipSpread(nil); ok {
+		t.Error("Expected no spread without chips")
+	}
+
+	_, spread, ok := chipSpread([]int64{500, 500})
+	if !ok || spread != 0 {
+		t.Errorf("Expected zero spread for even stacks, got %f (ok=%v)", spread, ok)
+	}
+
+	variance, spread, _ := chipSpread([]int64{1000, 0, 0})
+	if spread < 0.999 {
+		t.Errorf("Expected full spread when one player holds all chips, got %f", spread)
+	}
+	if variance <= 0 {
+		t.Errorf("Expected positive variance, got %f", variance)
+	}
+}
+
+func TestAggregateResultsChipStats(t *testing.T) {
+	results := []GameResult{
+		{WinnerID: 0, FinalChips: []int64{1000, 0}},
+		{WinnerID: 1, FinalChips: []int64{500, 500}},
+		{WinnerID: 0}, // No chips in play
+	}
+
+	stats := aggregateResults(results)
+
+	if stats.ChipGames != 2 {
+		t.Errorf("Expected 2 chip games, got %d", stats.ChipGames)
+	}
+	if stats.AvgChipSpread < 0.49 || stats.AvgChipSpread > 0.51 {
+		t.Errorf("Expected average spread 0.5, got %f", stats.AvgChipSpread)
+	}
+	if stats.AvgChipVariance != 125000 {
+		t.Errorf("Expected average variance 125000, got %f", stats.AvgChipVariance)
+	}
+}
+
+func TestHandLeadStability(t *testing.T) {
+	// Won in the first hand and coasted, against contested to the last
+	coasted := []HandScore{{Leader: 1}, {Leader: 1}, {Leader: 1}, {Leader: 1}}
+	contested := []HandScore{{Leader: 0}, {Leader: -1}, {Leader: 0}, {Leader: 1}}
+	if got := handLeadStability(coasted, 1); got != 1 {
+		t.Errorf("Expected stability 1 for a coasted game, got %f", got)
+	}
+	if got := handLeadStability(contested, 1); got != 0.25 {
+		t.Errorf("Expected stability 0.25 for a contested game, got %f", got)
+	}
+	if got := handLeadStability(coasted, -1); got != 0 {
+		t.Errorf("Expected no stability for a draw, got %f", got)
+	}
+
+	results := []GameResult{
+		{WinnerID: 1, Hands: coasted, Metrics: GameMetrics{HandLeadStability: 1}},
+		{WinnerID: 1, Hands: contested, Metrics: GameMetrics{HandLeadStability: 0.25}},
+		{WinnerID: 0, Hands: coasted[:1], Metrics: GameMetrics{HandLeadStability: 0}}, // Single hand
+		{WinnerID: -1, Hands: contested},                                             // Draw
+	}
+	stats := aggregateResults(results)
+	if stats.MultiHandGames != 2 {
+		t.Errorf("Expected 2 multi-hand games, got %d", stats.MultiHandGames)
+	}
+	if stats.HandLeadStability != 0.625 {
+		t.Errorf("Expected mean stability 0.625, got %f", stats.HandLeadStability)
+	}
+}
+
+func TestHandDeltas(t *testing.T) {
+	scored := GameResult{Hands: []HandScore{
+		{Scores: []int32{10, 0}},
+		{Scores: []int32{10, 25}},
+	}}
+	want := [][]int64{{10, 0}, {0, 25}}
+	if got := scored.HandDeltas(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected score deltas %v, got %v", want, got)
+	}
+
+	// Chips take precedence over scores
+	chipped := GameResult{Hands: []HandScore{
+		{Chips: []int64{600, 400}, Scores: []int32{0, 0}},
+		{Chips: []int64{300, 700}, Scores: []int32{5, 0}},
+	}}
+	want = [][]int64{{100, -100}, {-300, 300}}
+	if got := chipped.HandDeltas(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected chip deltas %v, got %v", want, got)
+	}
+}
+
+// bettingTestState seats players with the given hands and plenty of chips.
+func bettingTestState(hands ...[]engine.Card) *engine.GameState {
+	state := engine.NewGameState(len(hands))
+	for i, hand := range hands {
+		state.Players[i].Hand = append(state.Players[i].Hand, hand...)
+		state.Players[i].Chips = 10000
+	}
+	return state
+}
+
+func TestRunBettingRoundManyRaisesHeadsUp(t *testing.T) {
+	aces := []engine.Card{{Rank: engine.RankAce, Suit: 0}, {Rank: engine.RankAce, Suit: 1}, {Rank: engine.RankAce, Suit: 2}}
+	kings := []engine.Card{{Rank: engine.RankKing, Suit: 0}, {Rank: engine.RankKing, Suit: 1}, {Rank: engine.RankKing, Suit: 2}}
+	state := bettingTestState(aces, kings)
+	defer engine.PutState(state)
+
+	// Two strong hands keep raising until the cap
+	phase := &engine.BettingPhaseData{MinBet: 10, MaxRaises: 40}
+	var metrics GameMetrics
+	if err := runBettingRound(state, &engine.Genome{}, phase, GreedyAI, &metrics, nil, nil); err != "" {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if state.RaiseCount != phase.MaxRaises {
+		t.Errorf("Expected all %d raises, got %d", phase.MaxRaises, state.RaiseCount)
+	}
+	if state.Players[0].CurrentBet != state.Players[1].CurrentBet {
+		t.Errorf("Round was cut short with unmatched bets: %d vs %d",
+			state.Players[0].CurrentBet, state.Players[1].CurrentBet)
+	}
+	// Opening bet, every raise, and the final call
+	if want := uint64(phase.MaxRaises + 2); metrics.TotalActions != want {
+		t.Errorf("Expected %d actions, got %d", want, metrics.TotalActions)
+	}
+}
+
+func TestRunBettingRoundMinPlayers(t *testing.T) {
+	aces := []engine.Card{{Rank: engine.RankAce, Suit: 0}, {Rank: engine.RankAce, Suit: 1}, {Rank: engine.RankAce, Suit: 2}}
+	weak := []engine.Card{{Rank: engine.RankTwo, Suit: 0}, {Rank: engine.RankSeven, Suit: 1}}
+	queens := []engine.Card{{Rank: engine.RankQueen, Suit: 0}, {Rank: engine.RankQueen, Suit: 1}}
+
+	// By default the round goes on with two players left
+	state := bettingTestState(aces, weak, queens)
+	var metrics GameMetrics
+	runBettingRound(state, &engine.Genome{}, &engine.BettingPhaseData{MinBet: 10, MaxRaises: 1}, GreedyAI, &metrics, nil, nil)
+	if state.Players[2].CurrentBet == 0 {
+		t.Error("Expected player 2 to act with two players left")
+	}
+	engine.PutState(state)
+
+	// Requiring three players ends the round at the first fold
+	state = bettingTestState(aces, weak, queens)
+	defer engine.PutState(state)
+	metrics = GameMetrics{}
+	runBettingRound(state, &engine.Genome{}, &engine.BettingPhaseData{MinBet: 10, MaxRaises: 1, MinPlayers: 3}, GreedyAI, &metrics, nil, nil)
+	if !state.Players[1].HasFolded {
+		t.Fatal("Expected the weak hand to fold")
+	}
+	if state.Players[2].CurrentBet != 0 || metrics.TotalActions != 2 {
+		t.Errorf("Expected the round to end after the fold, got %d actions", metrics.TotalActions)
+	}
+}
+
+// TestRunBettingRoundShortAllIn checks an all-in short of the minimum raise
+// makes the players who have acted call it, without reopening the raising.
+func TestRunBettingRoundShortAllIn(t *testing.T) {
+	aces := []engine.Card{{Rank: engine.RankAce, Suit: 0}, {Rank: engine.RankAce, Suit: 1}, {Rank: engine.RankAce, Suit: 2}}
+	kings := []engine.Card{{Rank: engine.RankKing, Suit: 0}, {Rank: engine.RankKing, Suit: 1}, {Rank: engine.RankKing, Suit: 2}}
+	queens := []engine.Card{{Rank: engine.RankQueen, Suit: 0}, {Rank: engine.RankQueen, Suit: 1}}
+	state := bettingTestState(aces, kings, queens)
+	defer engine.PutState(state)
+	state.Players[1].Chips = 15
+
+	// The aces bet 10 and the short-stacked kings raise all-in to 15
+	phase := &engine.BettingPhaseData{MinBet: 10, MaxRaises: 3}
+	var metrics GameMetrics
+	if err := runBettingRound(state, &engine.Genome{}, phase, GreedyAI, &metrics, nil, nil); err != "" {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !state.Players[1].IsAllIn {
+		t.Fatal("Expected the short stack to go all-in")
+	}
+	// The aces would raise again if the all-in had reopened the betting
+	if state.RaiseCount != 0 || state.CurrentBet != 15 {
+		t.Errorf("Expected no raise after the short all-in, got %d raises to %d", state.RaiseCount, state.CurrentBet)
+	}
+	for _, seat := range []int{0, 2} {
+		if state.Players[seat].CurrentBet != 15 {
+			t.Errorf("Expected player %d to call the all-in, got a bet of %d", seat, state.Players[seat].CurrentBet)
+		}
+	}
+	if metrics.TotalActions != 4 {
+		t.Errorf("Expected bet, all-in and two calls, got %d actions", metrics.TotalActions)
+	}
+}
+
+func TestSeatWinRates(t *testing.T) {
+	stats := AggregatedStats{TotalGames: 50, Wins: []uint32{30, 10, 0, 0}, Draws: 10}
+
+	rates := stats.SeatWinRates(2)
+	if len(rates) != 2 || rates[0] != 0.75 || rates[1] != 0.25 {
+		t.Errorf("Expected win rates [0.75 0.25] over decided games, got %v", rates)
+	}
+	if edge := stats.SeatEdge(2); edge != 0.25 {
+		t.Errorf("Expected seat 0 to be 0.25 above an even share, got %f", edge)
+	}
+
+	empty := AggregatedStats{Wins: []uint32{0, 0, 0, 0}}
+	if empty.SeatWinRates(2) != nil || empty.SeatEdge(2) != 0 {
+		t.Error("Expected no seat report without decided games")
+	}
+}
+
+// TestTurnOrderWins checks wins are also counted by the winner's place in
+// the turn order, whichever seat started.
+func TestTurnOrderWins(t *testing.T) {
+	scores := []int32{0, 0, 0}
+	stats := aggregateResults([]GameResult{
+		{WinnerID: 0, FirstPlayer: 0, FinalScores: scores},
+		{WinnerID: 2, FirstPlayer: 2, FinalScores: scores},
+		{WinnerID: 0, FirstPlayer: 1, FinalScores: scores},
+		{WinnerID: -1, FirstPlayer: 1, FinalScores: scores},
+	})
+	if want := []uint32{2, 0, 1, 0}; !reflect.DeepEqual(stats.TurnOrderWins, want) {
+		t.Errorf("Expected turn order wins %v, got %v", want, stats.TurnOrderWins)
+	}
+}
+
+func TestParseAIPlayerType(t *testing.T) {
+	for name, want := range map[string]AIPlayerType{"random": RandomAI, "greedy": GreedyAI, "mcts500": MCTS500AI} {
+		got, err := ParseAIPlayerType(name)
+		if err != nil || got != want {
+			t.Errorf("ParseAIPlayerType(%q) = %d, %v; want %d", name, got, err, want)
+		}
+	}
+	if _, err := ParseAIPlayerType("grandmaster"); err == nil {
+		t.Error("Expected an error for an unknown AI")
+	}
+}
+
+func TestCardsMoved(t *testing.T) {
+	ace := engine.Card{Rank: 12, Suit: 0}
+	two := engine.Card{Rank: 0, Suit: 0}
+	king := engine.Card{Rank: 11, Suit: 3}
+	hand := []engine.Card{ace, two, two}
+
+	cases := []struct {
+		name  string
+		after []engine.Card
+		want  uint64
+	}{
+		{"unchanged", []engine.Card{two, ace, two}, 0},
+		{"played one copy", []engine.Card{ace, two}, 1},
+		{"drew", []engine.Card{ace, two, two, king}, 1},
+		{"played and refilled", []engine.Card{king, two, two}, 2},
+		{"emptied", nil, 3},
+	}
+	for _, c := range cases {
+		if got := cardsMoved(tallyHand(hand), c.after); got != c.want {
+			t.Errorf("%s: expected %d cards moved, got %d", c.name, c.want, got)
+		}
+	}
+}
+
+func TestAggregateResultsTempo(t *testing.T) {
+	results := []GameResult{
+		{WinnerID: 0, Metrics: GameMetrics{TotalActions: 10, CardsMoved: 10}},
+		{WinnerID: 1, Metrics: GameMetrics{TotalActions: 10, CardsMoved: 20}},
+	}
+
+	stats := aggregateResults(results)
+	if stats.CardsMoved != 30 || stats.Tempo() != 1.5 {
+		t.Errorf("Expected 30 cards over 20 actions, got %d (tempo %f)", stats.CardsMoved, stats.Tempo())
+	}
+	if (&AggregatedStats{}).Tempo() != 0 {
+		t.Error("Expected zero tempo without actions")
+	}
+}
+
+func TestAggregateResultsErrorRate(t *testing.T) {
+	results := []GameResult{
+		{WinnerID: 0},
+		{WinnerID: -1, Error: "AI returned nil move"},
+		{WinnerID: 1},
+		{WinnerID: -1, Error: "no legal moves"},
+	}
+
+	stats := aggregateResults(results)
+	if stats.Errors != 2 || stats.ErrorRate() != 0.5 {
+		t.Errorf("Expected 2 errors in 4 games, got %d (rate %f)", stats.Errors, stats.ErrorRate())
+	}
+	if (&AggregatedStats{}).ErrorRate() != 0 {
+		t.Error("Expected zero error rate without games")
+	}
+}
+
+func TestAggregateResultsCardRelevance(t *testing.T) {
+	var even, narrow GameMetrics
+	for suit := uint8(0); suit < 4; suit++ {
+		for rank := uint8(0); rank < 13; rank++ {
+			even.CardUsage.Played.ByRank[rank]++
+			even.CardUsage.Played.BySuit[suit]++
+		}
+	}
+	// Only aces ever come up, all of them hearts
+	narrow.CardUsage.Captured.ByRank[engine.RankAce] = 10
+	narrow.CardUsage.Captured.BySuit[0] = 10
+
+	stats := aggregateResults([]GameResult{{WinnerID: 0, Metrics: even}})
+	if relevance := stats.CardRelevance(); math.Abs(relevance-1) > 1e-9 {
+		t.Errorf("Expected every card mattering to give relevance 1, got %f", relevance)
+	}
+	stats = aggregateResults([]GameResult{{WinnerID: 0, Metrics: narrow}})
+	if relevance := stats.CardRelevance(); relevance != 0 {
+		t.Errorf("Expected a single live card to give relevance 0, got %f", relevance)
+	}
+	stats = aggregateResults([]GameResult{{WinnerID: 0, Metrics: even}, {WinnerID: 1, Metrics: narrow}})
+	if stats.CardUsage.Played.ByRank[engine.RankAce] != 4 || stats.CardUsage.Captured.ByRank[engine.RankAce] != 10 {
+		t.Errorf("Expected histograms to be summed across games, got %+v", stats.CardUsage)
+	}
+	if (&AggregatedStats{}).CardRelevance() != 0 {
+		t.Error("Expected zero relevance without any card usage")
+	}
+}
@@ -0,0 +1,194 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/signalnine/darwindeck/gosim/engine"
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+// Shoe play parameters
+const (
+	shoeMaxHandSize   = 5  // Hit limit per hand (five-card charlie)
+	shoeStandMargin   = 4  // Base strategy stands this far below the target (17 of 21)
+	shoeCountMargin   = 2  // Cards this far from the average value are counted
+	shoeMaxCountShift = 2  // Most the count can move the stand threshold
+	shoeDeckSize      = 52 // Cards per deck, for converting the running count
+)
+
+// Seats in shoe play
+const (
+	shoeCounter = 0 // Card-counting player
+	shoeRandom  = 1 // Random player
+)
+
+// ShoeStats summarizes hands dealt from shoes between a card-counting player
+// and a random player facing the same cards.
+type ShoeStats struct {
+	Shoes       uint32
+	Hands       uint32
+	CounterWins uint32
+	RandomWins  uint32
+}
+
+// CountingEdge returns how much more often the counting player won a hand
+// than the random player, from -1 to 1. Pushes count for neither.
+func (s ShoeStats) CountingEdge() float64 {
+	if s.Hands == 0 {
+		return 0
+	}
+	return (float64(s.CounterWins) - float64(s.RandomWins)) / float64(s.Hands)
+}
+
+// IsShoeGame reports whether g deals many hands from one shoe: it sets a
+// penetration and compares point totals against a target.
+func IsShoeGame(g *genome.GameGenome) bool {
+	return g.Setup.Penetration > 0 &&
+		g.HandEval != nil &&
+		g.HandEval.Method == genome.EvalMethodPointTotal &&
+		g.HandEval.TargetValue > 0
+}
+
+// RunShoes plays numShoes shoes of g heads-up between a player keeping a
+// running count and a player choosing at random. Each shoe is dealt down to
+// the cut card set by the genome's penetration, one hand after another, so
+// the count carries over between hands as it would at a table. Shoe n is
+// shuffled with seed+n. Pre-dealt hands are all dealt before the first seat
+// acts; with shoe draws each seat draws its hand just before acting, after
+// the seats before it have hit.
+// Returns zero stats if g is not a shoe game.
+func RunShoes(g *genome.GameGenome, numShoes int, seed uint64) ShoeStats {
+	var stats ShoeStats
+	if !IsShoeGame(g) {
+		return stats
+	}
+
+	eval := convertHandEvaluation(g.HandEval)
+	tags := countTags(eval)
+	cardsPerPlayer := g.Setup.CardsPerPlayer
+	if cardsPerPlayer <= 0 {
+		cardsPerPlayer = 1
+	}
+	numPlayers := genome.DefaultPlayerCount
+
+	state := engine.GetState()
+	defer engine.PutState(state)
+	state.NumPlayers = uint8(numPlayers)
+	setupDeck(state, seed)
+	rng := rand.New(rand.NewSource(int64(seed)))
+
+	for shoe := 0; shoe < numShoes; shoe++ {
+		// Every card is back in the discards between shoes
+		engine.ReshuffleShoe(state, g.Setup.Penetration, seed+uint64(shoe))
+		stats.Shoes++
+
+		runningCount := 0
+		for hand := 0; !engine.ShoeFinished(state) && len(state.Deck) >= numPlayers*cardsPerPlayer; hand++ {
+			shoeDraw := g.Setup.DealStrategy == genome.DealShoeDraw
+			if !shoeDraw {
+				state.DealHands(numPlayers, cardsPerPlayer, engine.DealOrder(g.Setup.DealOrder))
+			}
+
+			// Seats take turns acting first
+			for i := 0; i < numPlayers; i++ {
+				seat := (hand + i) % numPlayers
+				if shoeDraw {
+					state.DrawHand(uint8(seat), cardsPerPlayer)
+				}
+				playShoeHand(state, seat, eval, tags, runningCount, rng)
+			}
+
+			winners := engine.FindShowWinners(state, eval)
+			stats.Hands++
+			if len(winners) == 1 {
+				switch winners[0] {
+				case shoeCounter:
+					stats.CounterWins++
+				case shoeRandom:
+					stats.RandomWins++
+				}
+			}
+
+			// Hands are revealed, counted, and discarded
+			for i := 0; i < numPlayers; i++ {
+				for _, c := range state.Players[i].Hand {
+					runningCount += tags[c.Rank]
+				}
+				state.Discard = append(state.Discard, state.Players[i].Hand...)
+				state.Players[i].Hand = state.Players[i].Hand[:0]
+			}
+		}
+	}
+
+	return stats
+}
+
+// playShoeHand lets seat hit until it stands, busts, or fills its hand.
+func playShoeHand(state *engine.GameState, seat int, eval *engine.HandEvaluation, tags [13]int, runningCount int, rng *rand.Rand) {
+	p := &state.Players[seat]
+	for len(p.Hand) < shoeMaxHandSize && len(state.Deck) > 0 {
+		if engine.IsBust(p.Hand, eval) {
+			return // Busted
+		}
+
+		var hit bool
+		if seat == shoeCounter {
+			value := engine.CalculateHandValue(p.Hand, eval)
+			hit = value < countingStandThreshold(eval, runningCount, len(state.Deck))
+		} else {
+			hit = rng.Intn(2) == 0
+		}
+		if !hit {
+			return
+		}
+
+		card := state.Deck[len(state.Deck)-1]
+		state.Deck = state.Deck[:len(state.Deck)-1]
+		p.Hand = append(p.Hand, card)
+	}
+}
+
+// countingStandThreshold returns the hand value the counting player stands
+// on. A positive count means the remaining cards run high, so the player
+// stands earlier; a negative count means it is safer to keep hitting.
+func countingStandThreshold(eval *engine.HandEvaluation, runningCount int, cardsLeft int) int {
+	threshold := int(eval.TargetValue) - shoeStandMargin
+
+	// True count: running count per deck remaining
+	decksLeft := float64(cardsLeft) / shoeDeckSize
+	if decksLeft < 0.25 {
+		decksLeft = 0.25
+	}
+	shift := int(float64(runningCount) / decksLeft / 2)
+	if shift > shoeMaxCountShift {
+		shift = shoeMaxCountShift
+	} else if shift < -shoeMaxCountShift {
+		shift = -shoeMaxCountShift
+	}
+
+	return threshold - shift
+}
+
+// countTags assigns each rank its running-count tag, in the spirit of Hi-Lo:
+// cards well below the average point value count +1 when seen (the rest of
+// the shoe gets richer in high cards), cards well above count -1.
+func countTags(eval *engine.HandEvaluation) [13]int {
+	var values [13]int
+	total := 0
+	for rank := 0; rank < 13; rank++ {
+		values[rank] = engine.CalculateHandValue([]engine.Card{{Rank: uint8(rank)}}, eval)
+		total += values[rank]
+	}
+	mean := float64(total) / 13
+
+	var tags [13]int
+	for rank, v := range values {
+		switch {
+		case float64(v) < mean-shoeCountMargin:
+			tags[rank] = 1
+		case float64(v) > mean+shoeCountMargin:
+			tags[rank] = -1
+		}
+	}
+	return tags
+}
@@ -0,0 +1,99 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/signalnine/darwindeck/gosim/engine"
+	"github.com/signalnine/darwindeck/gosim/genome"
+)
+
+func TestCountTagsBlackjack(t *testing.T) {
+	tags := countTags(convertHandEvaluation(genome.CreateBlackjackGenome().HandEval))
+
+	for _, rank := range []uint8{engine.RankTwo, engine.RankThree, engine.RankFour, engine.RankFive} {
+		if tags[rank] != 1 {
+			t.Errorf("Expected low card %d to count +1, got %d", rank, tags[rank])
+		}
+	}
+	for _, rank := range []uint8{engine.RankTen, engine.RankKing, engine.RankAce} {
+		if tags[rank] != -1 {
+			t.Errorf("Expected high card %d to count -1, got %d", rank, tags[rank])
+		}
+	}
+	if tags[engine.RankSeven] != 0 {
+		t.Errorf("Expected Seven to be neutral, got %d", tags[engine.RankSeven])
+	}
+}
+
+func TestCountingStandThreshold(t *testing.T) {
+	eval := convertHandEvaluation(genome.CreateBlackjackGenome().HandEval)
+
+	if got := countingStandThreshold(eval, 0, 52); got != 17 {
+		t.Errorf("Expected to stand on 17 at a neutral count, got %d", got)
+	}
+	if got := countingStandThreshold(eval, 10, 26); got >= 17 {
+		t.Errorf("Expected a high count to stand earlier, got %d", got)
+	}
+	if got := countingStandThreshold(eval, -10, 26); got <= 17 {
+		t.Errorf("Expected a low count to keep hitting, got %d", got)
+	}
+}
+
+func TestRunShoesCountingBeatsRandom(t *testing.T) {
+	g := genome.CreateBlackjackGenome()
+
+	stats := RunShoes(g, 50, 12345)
+	if stats.Shoes != 50 {
+		t.Fatalf("Expected 50 shoes, got %d", stats.Shoes)
+	}
+	// 75% penetration of 52 cards leaves room for several hands per shoe
+	if stats.Hands < stats.Shoes*5 {
+		t.Errorf("Expected several hands per shoe, got %d hands in %d shoes", stats.Hands, stats.Shoes)
+	}
+	if stats.CounterWins <= stats.RandomWins {
+		t.Errorf("Expected counting to beat random play, got %d vs %d", stats.CounterWins, stats.RandomWins)
+	}
+	if edge := stats.CountingEdge(); edge <= 0 || edge > 1 {
+		t.Errorf("Expected positive counting edge, got %f", edge)
+	}
+
+	// Deeper penetration deals more hands per shoe
+	g.Setup.Penetration = 0.5
+	shallow := RunShoes(g, 50, 12345)
+	if shallow.Hands >= stats.Hands {
+		t.Errorf("Expected fewer hands at 50%% penetration, got %d >= %d", shallow.Hands, stats.Hands)
+	}
+}
+
+func TestRunShoesShoeDraw(t *testing.T) {
+	g := genome.CreateBlackjackGenome()
+	preDealt := RunShoes(g, 20, 12345)
+
+	// Seat 1's starting cards now depend on how often seat 0 hit
+	g.Setup.DealStrategy = genome.DealShoeDraw
+	shoeDraw := RunShoes(g, 20, 12345)
+	if shoeDraw.Shoes != 20 || shoeDraw.Hands < shoeDraw.Shoes*5 {
+		t.Fatalf("Expected several hands per shoe, got %d hands in %d shoes", shoeDraw.Hands, shoeDraw.Shoes)
+	}
+	if shoeDraw == preDealt {
+		t.Errorf("Expected drawing from the shoe to deal different hands, got the same results %+v", shoeDraw)
+	}
+	if again := RunShoes(g, 20, 12345); again != shoeDraw {
+		t.Errorf("Expected the same seed to replay exactly, got %+v and %+v", shoeDraw, again)
+	}
+}
+
+func TestRunShoesRequiresShoeGame(t *testing.T) {
+	g := genome.CreateBlackjackGenome()
+	g.Setup.Penetration = 0
+	if IsShoeGame(g) {
+		t.Error("Expected no shoe play without penetration")
+	}
+	if stats := RunShoes(g, 10, 1); stats.Hands != 0 || stats.CountingEdge() != 0 {
+		t.Errorf("Expected empty stats, got %+v", stats)
+	}
+
+	if IsShoeGame(genome.CreateWarGenome()) {
+		t.Error("War is not a shoe game")
+	}
+}
@@ -0,0 +1,52 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/signalnine/darwindeck/gosim/engine"
+	"github.com/signalnine/darwindeck/gosim/genome"
+	"github.com/signalnine/darwindeck/gosim/solver"
+)
+
+// SolvedStats summarizes the exact values of a set of deals.
+type SolvedStats struct {
+	Deals  uint32
+	Wins   [2]uint32 // Deals each seat wins with best play
+	Draws  uint32    // Deals that are a draw with best play
+	States int       // Positions searched over all deals
+}
+
+// SolveTyped exhaustively solves the deal of g that seed gives, with all
+// hands known. It plays the game the MCTS players see, so runner-level steps
+// such as bidding rounds and the max-turns rule are not part of it; see
+// solver.Solve. Games with more than maxStates positions are refused with
+// solver.ErrTooLarge.
+func SolveTyped(g *genome.GameGenome, seed uint64, maxStates int) (solver.Result, error) {
+	state := engine.GetState()
+	defer engine.PutState(state)
+	dealGameTyped(state, g, genome.DefaultPlayerCount, seed)
+	return solver.Solve(state, createCompatGenome(g, genome.DefaultPlayerCount), maxStates)
+}
+
+// SolveDealsTyped solves numDeals deals of g, seeded as RunBatchTyped seeds
+// its games, so the values line up with simulated play of the same deals.
+// It stops at the first deal that cannot be solved.
+func SolveDealsTyped(g *genome.GameGenome, numDeals int, seed uint64, maxStates int) (SolvedStats, error) {
+	var stats SolvedStats
+	rng := rand.New(rand.NewSource(int64(seed)))
+
+	for i := 0; i < numDeals; i++ {
+		result, err := SolveTyped(g, rng.Uint64(), maxStates)
+		if err != nil {
+			return stats, err
+		}
+		stats.Deals++
+		stats.States += result.States
+		if result.Winner >= 0 {
+			stats.Wins[result.Winner]++
+		} else {
+			stats.Draws++
+		}
+	}
+	return stats, nil
+}
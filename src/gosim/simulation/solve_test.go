@@ -0,0 +1,57 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/signalnine/darwindeck/gosim/genome"
+	"github.com/signalnine/darwindeck/gosim/solver"
+)
+
+// tinyTrickGenome deals two cards each; the first trick with a heart in it
+// wins the game.
+func tinyTrickGenome() *genome.GameGenome {
+	return &genome.GameGenome{
+		Name:  "TinyTricks",
+		Setup: genome.SetupRules{CardsPerPlayer: 2},
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{
+				&genome.TrickPhase{LeadSuitRequired: true, TrumpSuit: 255, HighCardWins: true, BreakingSuit: genome.SuitHearts},
+			},
+			MaxTurns:     20,
+			IsTrickBased: true,
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeHighScore, Threshold: 1}},
+	}
+}
+
+func TestSolveDealsTyped(t *testing.T) {
+	g := tinyTrickGenome()
+
+	stats, err := SolveDealsTyped(g, 20, 42, 0)
+	if err != nil {
+		t.Fatalf("SolveDealsTyped failed: %v", err)
+	}
+	if stats.Deals != 20 || stats.Wins[0]+stats.Wins[1]+stats.Draws != 20 {
+		t.Fatalf("Expected a value for all 20 deals, got %+v", stats)
+	}
+	// Deals without a heart are drawn, but most have one
+	if stats.Wins[0]+stats.Wins[1] == 0 {
+		t.Errorf("Expected some deals to be won, got %+v", stats)
+	}
+
+	// The same seed is the same deal
+	again, err := SolveDealsTyped(g, 20, 42, 0)
+	if err != nil || again != stats {
+		t.Errorf("Expected the same values for the same deals, got %+v then %+v", stats, again)
+	}
+	t.Logf("Tiny trick game: seat 0 wins %d, seat 1 wins %d of %d deals (%d states)",
+		stats.Wins[0], stats.Wins[1], stats.Deals, stats.States)
+}
+
+func TestSolveTypedRefusesLargeGames(t *testing.T) {
+	_, err := SolveTyped(genome.CreateHeartsGenome(), 1, 1000)
+	if !errors.Is(err, solver.ErrTooLarge) {
+		t.Errorf("Expected Hearts to be too large to solve, got %v", err)
+	}
+}
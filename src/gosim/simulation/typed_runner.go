@@ -1,6 +1,8 @@
 package simulation
 
 import (
+	"encoding/binary"
+	"math"
 	"math/rand"
 	"runtime"
 	"sync"
@@ -21,12 +23,19 @@ type TypedGameJob struct {
 // This is the new entry point for the pure Go evolution system.
 // NOTE: This is the serial version. Use RunBatchTypedParallel for parallel execution.
 func RunBatchTyped(g *genome.GameGenome, numGames int, aiType AIPlayerType, mctsIterations int, seed uint64) AggregatedStats {
+	return RunBatchTypedPlayers(g, genome.DefaultPlayerCount, numGames, aiType, mctsIterations, seed)
+}
+
+// RunBatchTypedPlayers is RunBatchTyped at a table of numPlayers. Games are
+// seeded the same way at every table size, so each count sees the same
+// shuffles.
+func RunBatchTypedPlayers(g *genome.GameGenome, numPlayers int, numGames int, aiType AIPlayerType, mctsIterations int, seed uint64) AggregatedStats {
 	results := make([]GameResult, numGames)
 	rng := rand.New(rand.NewSource(int64(seed)))
 
 	for i := 0; i < numGames; i++ {
 		gameSeed := rng.Uint64()
-		results[i] = RunSingleGameTyped(g, aiType, mctsIterations, gameSeed)
+		results[i] = RunSingleGameTypedPlayers(g, numPlayers, aiType, mctsIterations, gameSeed)
 	}
 
 	return aggregateResults(results)
@@ -99,92 +108,132 @@ const GameTimeout = 100 * time.Millisecond
 
 // RunSingleGameTyped plays one complete game using a typed genome.
 func RunSingleGameTyped(g *genome.GameGenome, aiType AIPlayerType, mctsIterations int, seed uint64) GameResult {
-	start := time.Now()
-	var metrics GameMetrics
+	return runSingleGameTyped(g, genome.DefaultPlayerCount, []AIPlayerType{aiType}, mctsIterations, 1, nil, seed, nil)
+}
 
-	// Initialize game state
-	state := engine.GetState()
-	defer engine.PutState(state)
+// RunSingleGameTypedPlayers plays one game of g with numPlayers seated, from
+// 2 to MaxPlayers; counts outside that range are clamped.
+func RunSingleGameTypedPlayers(g *genome.GameGenome, numPlayers int, aiType AIPlayerType, mctsIterations int, seed uint64) GameResult {
+	return runSingleGameTyped(g, numPlayers, []AIPlayerType{aiType}, mctsIterations, 1, nil, seed, nil)
+}
 
-	// Setup deck and shuffle
-	setupDeck(state, seed)
+// RunBatchTypedDeterminized is RunBatchTypedPlayers with MCTS players that
+// search determinizations deals of the cards they cannot see rather than
+// the true deal (see mcts.SearchDeterminized). 0 or 1 searches the true
+// deal. Games are seeded as in RunBatchTypedPlayers.
+func RunBatchTypedDeterminized(g *genome.GameGenome, numPlayers int, numGames int, aiType AIPlayerType, mctsIterations int, determinizations int, seed uint64) AggregatedStats {
+	results := make([]GameResult, numGames)
+	rng := rand.New(rand.NewSource(int64(seed)))
 
-	// Read setup from typed genome
-	cardsPerPlayer := g.Setup.CardsPerPlayer
-	if cardsPerPlayer <= 0 {
-		cardsPerPlayer = 26 // Default for War
+	for i := 0; i < numGames; i++ {
+		gameSeed := rng.Uint64()
+		results[i] = runSingleGameTyped(g, numPlayers, []AIPlayerType{aiType}, mctsIterations, determinizations, nil, gameSeed, nil)
 	}
 
-	initialDiscardCount := g.Setup.DealToTableau
-	startingChips := g.Setup.StartingChips
+	return aggregateResults(results)
+}
 
-	// Determine number of players (default to 2)
-	numPlayers := 2 // TODO: Add PlayerCount to GameGenome if needed
+// RunBatchTypedAsymmetric simulates games with a different AI in each seat.
+// See RunSingleGameTypedAsymmetric for how aiTypes is read.
+func RunBatchTypedAsymmetric(g *genome.GameGenome, numGames int, aiTypes []AIPlayerType, seed uint64) AggregatedStats {
+	return RunBatchTypedAsymmetricDeterminized(g, numGames, aiTypes, asymmetricDeterminizations(g), seed)
+}
 
-	state.NumPlayers = uint8(numPlayers)
-	state.CardsPerPlayer = cardsPerPlayer
+// RunBatchTypedAsymmetricDeterminized is RunBatchTypedAsymmetric with MCTS
+// players searching determinizations deals of the cards they cannot see
+// (1 = the true deal, letting them play with their opponents' cards face up).
+func RunBatchTypedAsymmetricDeterminized(g *genome.GameGenome, numGames int, aiTypes []AIPlayerType, determinizations int, seed uint64) AggregatedStats {
+	return runBatchTypedAsymmetric(g, numGames, aiTypes, determinizations, nil, seed)
+}
 
-	// Set tableau mode from typed genome
-	state.TableauMode = uint8(g.TurnStructure.TableauMode)
-	state.SequenceDirection = uint8(g.TurnStructure.SequenceDirection)
+// runBatchTypedAsymmetric is RunBatchTypedAsymmetricDeterminized with each
+// seat's MCTS player remembering memory tricks of the hand (see
+// runSingleGameTyped).
+func runBatchTypedAsymmetric(g *genome.GameGenome, numGames int, aiTypes []AIPlayerType, determinizations int, memory []int, seed uint64) AggregatedStats {
+	results := make([]GameResult, numGames)
+	rng := rand.New(rand.NewSource(int64(seed)))
 
-	// Initialize teams if configured
-	if g.Teams != nil && g.Teams.Enabled && len(g.Teams.Teams) > 0 {
-		teams := make([][]int, len(g.Teams.Teams))
-		for i, team := range g.Teams.Teams {
-			teams[i] = make([]int, len(team))
-			copy(teams[i], team)
-		}
-		state.InitializeTeams(teams)
+	for i := 0; i < numGames; i++ {
+		gameSeed := rng.Uint64()
+		results[i] = runSingleGameTyped(g, genome.DefaultPlayerCount, aiTypes, typeIterations, determinizations, memory, gameSeed, nil)
 	}
 
-	// Deal cards to each player
-	for i := 0; i < cardsPerPlayer; i++ {
-		for p := 0; p < numPlayers; p++ {
-			state.DrawCard(uint8(p), engine.LocationDeck)
-		}
-	}
+	return aggregateResults(results)
+}
 
-	// Deal initial cards to discard/tableau
-	if initialDiscardCount > 0 && len(state.Deck) >= initialDiscardCount {
-		// Initialize tableau pile if needed for TableauMode games
-		if state.TableauMode != 0 && len(state.Tableau) == 0 {
-			state.Tableau = make([][]engine.Card, 1)
-			state.Tableau[0] = make([]engine.Card, 0, initialDiscardCount)
-		}
-		for i := 0; i < initialDiscardCount; i++ {
-			if len(state.Deck) > 0 {
-				card := state.Deck[len(state.Deck)-1]
-				state.Deck = state.Deck[:len(state.Deck)-1]
-				if state.TableauMode != 0 {
-					state.Tableau[0] = append(state.Tableau[0], card)
-				} else {
-					state.Discard = append(state.Discard, card)
-				}
-			}
-		}
-	}
+// RunSingleGameTypedAsymmetric plays one game with aiTypes[i] choosing for
+// seat i; a single entry plays every seat. As in RunSingleGameAsymmetric,
+// each MCTS player searches as often as its type names, so players of
+// different strengths can share a table, and searches
+// AsymmetricDeterminizations deals of the cards it cannot see.
+func RunSingleGameTypedAsymmetric(g *genome.GameGenome, aiTypes []AIPlayerType, seed uint64) GameResult {
+	return runSingleGameTyped(g, genome.DefaultPlayerCount, aiTypes, typeIterations, asymmetricDeterminizations(g), nil, seed, nil)
+}
 
-	// Initialize chips if this genome uses betting
-	if startingChips > 0 {
-		state.InitializeChips(startingChips)
+// asymmetricDeterminizations returns the deals MCTS players search in
+// asymmetric games of g: AsymmetricDeterminizations, or the true deal when
+// g hides nothing.
+func asymmetricDeterminizations(g *genome.GameGenome) int {
+	if HasHiddenInformation(g) {
+		return AsymmetricDeterminizations
 	}
+	return 1
+}
+
+// typeIterations has each MCTS player search as often as its type names.
+const typeIterations = -1
+
+// runSingleGameTyped plays one game at a table of numPlayers with the given
+// seat AIs, searching mctsIterations times per MCTS move (or typeIterations)
+// over determinizations deals of the hidden cards (1 = the true deal).
+// memory holds the tricks of the hand each seat's MCTS player remembers
+// when determinizing, read like aiTypes (nil = every trick; see
+// mcts.DeterminizeRecall). A non-nil log records the turn decisions, or
+// replays them (see Replay).
+func runSingleGameTyped(g *genome.GameGenome, numPlayers int, aiTypes []AIPlayerType, mctsIterations int, determinizations int, memory []int, seed uint64, log *moveLog) (result GameResult) {
+	start := time.Now()
+	var metrics GameMetrics
+
+	// Initialize game state
+	state := engine.GetState()
+	defer engine.PutState(state)
+	state.Usage = &metrics.CardUsage
+	// Runs before PutState, so the result sees the final chips and scores
+	defer func() { result.FinalChips, result.FinalScores = finalPlayerState(state) }()
+
+	dealGameTyped(state, g, numPlayers, seed)
+	first := int8(state.CurrentPlayer)
+	defer func() { result.FirstPlayer = first }()
+
+	// Random AI choices come from the game seed, so a game replays exactly
+	rng := rand.New(rand.NewSource(int64(seed)))
+
+	// Kingmaker analysis needs snapshots of the final decisions (3+ players only)
+	kingmaker := newKingmakerTracker(int(state.NumPlayers))
+	defer kingmaker.release()
+
+	handsStarted := state.HandsPlayed
+	turnPlayer := -1 // Player whose turn last started, for the catch-up rule
+
+	// The hand's betting rounds so far
+	var betting bettingLifecycle
 
 	// Create bytecode genome for compatibility with existing win condition checks
 	// TODO: Implement typed win condition checking
-	bytecodeGenome := createCompatGenome(g)
+	bytecodeGenome := createCompatGenome(g, numPlayers)
 
 	// Initialize tension tracking
 	detector := engine.SelectLeaderDetector(bytecodeGenome)
 	tensionMetrics := engine.NewTensionMetrics(int(state.NumPlayers))
 
-	// Game loop with turn limit protection
-	maxTurns := uint32(g.TurnStructure.MaxTurns)
-	if maxTurns == 0 {
-		maxTurns = 1000 // Default
-	}
+	// Scoreboard after each finished hand, for multi-hand analytics
+	var hands []HandScore
 
-	for state.TurnNumber < maxTurns {
+	// Game loop with turn limit protection, counting the moves taken in turn
+	// for a limit on actions
+	limit := genome.TurnLimitFor(g, numPlayers)
+	actions := uint64(0)
+	for !turnLimitReached(state, g, limit, actions) {
 		// Check timeout to prevent infinite loops from bad genomes
 		if time.Since(start) > GameTimeout {
 			tensionMetrics.Finalize(-1)
@@ -198,36 +247,83 @@ func RunSingleGameTyped(g *genome.GameGenome, aiType AIPlayerType, mctsIteration
 			}
 		}
 
+		// Each new hand may pass the deal and post blinds
+		if state.HandsPlayed != handsStarted {
+			handsStarted = state.HandsPlayed
+			betting = bettingLifecycle{}
+			hand := recordHand(state, detector)
+			if g.ScoreCarry == genome.ScoreCarryPerHand {
+				// The hand is won outright and the next one starts level
+				hand.PerHand = true
+				hand.Winner = engine.SettleMatchHand(state, int64(g.Setup.StartingChips))
+				hand.Leader = detector.GetLeader(state)
+			}
+			hands = append(hands, hand)
+			if g.Setup.RotateDealer {
+				state.RotateDealer()
+			}
+			if hasWinType(g, genome.WinTypeLastChipStanding) {
+				redealTyped(state, g, rng)
+			}
+			startHandTyped(state, g)
+		}
+
 		// Check win conditions
 		winner := checkWinConditionsTyped(state, g)
 		if winner >= 0 {
+			winner = settleHandsTyped(state, g, winner)
 			tensionMetrics.Finalize(int(winner))
 			metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 			metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 			metrics.ClosestMargin = tensionMetrics.ClosestMargin
+			metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 			metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
+			metrics.KingmakerDecisions, metrics.KingmakerEvents = kingmaker.analyzeTyped(g, int(winner), seed)
+			metrics.HandLeadStability = handLeadStability(hands, int(winner))
 			return GameResult{
 				WinnerID:    winner,
 				WinningTeam: state.WinningTeam,
 				TurnCount:   state.TurnNumber,
 				DurationNs:  uint64(time.Since(start).Nanoseconds()),
 				Metrics:     metrics,
+				Hands:       hands,
+			}
+		}
+
+		// A player in last place starting their turn gets the catch-up bonus
+		if g.CatchUp != nil && int(state.CurrentPlayer) != turnPlayer {
+			turnPlayer = int(state.CurrentPlayer)
+			if engine.TrailingPlayer(state, detector) == turnPlayer {
+				engine.ApplyCatchUp(state, detector, turnPlayer, g.CatchUp.Draw, g.CatchUp.Points)
 			}
 		}
 
 		// Generate legal moves using typed interpreter
 		moves := genome.GenerateLegalMovesTyped(state, g)
 
+		// Between betting rounds only the card phase being played is open;
+		// a player out of the hand, or with no part in it, passes
+		if betting.cardTurns > 0 {
+			moves = movesInPhase(moves, betting.cardPhase(int(state.NumPlayers)))
+			if len(moves) == 0 || state.Players[state.CurrentPlayer].HasFolded {
+				state.CurrentPlayer = (state.CurrentPlayer + 1) % state.NumPlayers
+				state.TurnNumber++
+				betting.endCardTurn(state)
+				continue
+			}
+		}
+
 		// Check if this is a betting phase
 		if hasBettingMoves(moves) {
-			bettingPhase := findBettingPhase(g)
+			bettingPhase, at := bettingRoundPhase(g, betting.round)
 			if bettingPhase != nil {
-				err := runBettingRoundTyped(state, g, bettingPhase, aiType, &metrics, tensionMetrics, detector)
+				err := runBettingHandTyped(state, g, bettingPhase, aiTypes, &metrics, tensionMetrics, detector, rng)
 				if err != "" {
 					tensionMetrics.Finalize(-1)
 					metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 					metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 					metrics.ClosestMargin = tensionMetrics.ClosestMargin
+					metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 					metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 					return GameResult{
 						WinnerID:    -1,
@@ -241,14 +337,33 @@ func RunSingleGameTyped(g *genome.GameGenome, aiType AIPlayerType, mctsIteration
 
 				state.BettingComplete = true
 
+				// A later betting phase waits for the card phases before it
+				if next, nextAt := bettingRoundPhase(g, betting.round+1); next != nil && engine.CountPlayersInHand(state) > 1 {
+					betting.startCardPhases(state, at, nextAt)
+					continue
+				}
+				betting = bettingLifecycle{}
+
 				// Resolve showdown after betting
 				winners := engine.ResolveShowdown(state)
 				if len(winners) == 1 {
 					engine.AwardPot(state, winners)
 					metrics.FoldWins++
 				} else if len(winners) > 1 {
+					if g.ChipBridge == genome.ChipBridgePointsWinPot {
+						// The hand is played out and its top scorer takes the
+						// pot (see settleHandsTyped)
+						continue
+					}
+					if findShowPhase(g) != nil {
+						// Hands are compared by the ShowPhase, which also awards the pot
+						continue
+					}
 					winner := engine.FindBestPokerWinner(state, int(state.NumPlayers))
 					if winner >= 0 {
+						held, shown := engine.RevealShowdown(state, int(winner), bettingPhase.MuckLosers)
+						metrics.ShowdownCards += uint64(held)
+						metrics.ShowdownShown += uint64(shown)
 						engine.AwardPot(state, []int{int(winner)})
 						metrics.ShowdownWins++
 					}
@@ -261,19 +376,31 @@ func RunSingleGameTyped(g *genome.GameGenome, aiType AIPlayerType, mctsIteration
 
 		// Check if this is a bidding phase
 		if hasBiddingMoves(moves) {
-			aiTypes := make([]AIPlayerType, state.NumPlayers)
-			for i := range aiTypes {
-				aiTypes[i] = aiType
+			seats := make([]AIPlayerType, state.NumPlayers)
+			for i := range seats {
+				seats[i] = seatAI(aiTypes, i)
 			}
-			runBiddingRoundTyped(state, g, aiTypes)
+			metrics.SealedBids += uint64(runBiddingRoundTyped(state, g, seats, rng))
 			continue
 		}
 
+		// Reveal and compare hands once they are ready
+		if showMove := findShowMove(moves); showMove != nil {
+			if resolveShowMove(state, bytecodeGenome, showMove) {
+				metrics.ShowdownWins++
+			}
+			continue
+		}
+
+		if len(moves) == 0 && g.TurnStructure.StuckRule == genome.StuckPass && passStuckTyped(state, g) {
+			continue
+		}
 		if len(moves) == 0 {
 			tensionMetrics.Finalize(-1)
 			metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 			metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 			metrics.ClosestMargin = tensionMetrics.ClosestMargin
+			metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 			metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 			return GameResult{
 				WinnerID:    -1,
@@ -289,6 +416,7 @@ func RunSingleGameTyped(g *genome.GameGenome, aiType AIPlayerType, mctsIteration
 		metrics.TotalDecisions++
 		metrics.TotalValidMoves += uint64(len(moves))
 		metrics.TotalHandSize += uint64(len(state.Players[state.CurrentPlayer].Hand))
+		trackHiddenInfo(state, &metrics)
 		if len(moves) == 1 {
 			metrics.ForcedDecisions++
 		}
@@ -298,18 +426,31 @@ func RunSingleGameTyped(g *genome.GameGenome, aiType AIPlayerType, mctsIteration
 
 		if len(moves) == 1 {
 			move = &moves[0]
+		} else if log != nil && log.replaying {
+			kingmaker.record(state)
+			move = log.replay(moves, seatAI(aiTypes, int(state.CurrentPlayer)), rng)
 		} else {
-			switch aiType {
+			kingmaker.record(state)
+			switch aiType := seatAI(aiTypes, int(state.CurrentPlayer)); aiType {
 			case RandomAI:
-				move = &moves[rand.Intn(len(moves))]
+				move = &moves[rng.Intn(len(moves))]
 			case GreedyAI:
 				move = selectGreedyMoveTyped(state, g, moves)
 			case MCTS100AI, MCTS500AI, MCTS1000AI, MCTS2000AI:
 				// Use bytecode genome for MCTS (requires existing infrastructure)
-				move = mcts.Search(state, bytecodeGenome, mctsIterations, mcts.DefaultExplorationParam)
+				move = mcts.SearchWithParams(state, bytecodeGenome, mcts.SearchParams{
+					Iterations:       searchIterations(aiType, mctsIterations),
+					ExplorationParam: mcts.DefaultExplorationParam,
+					Determinizations: determinizations,
+					Rand:             rng,
+					Memory:           seatMemory(memory, int(state.CurrentPlayer)),
+				})
 			default:
 				move = &moves[0]
 			}
+			if log != nil {
+				log.record(moves, move)
+			}
 		}
 
 		if move == nil {
@@ -317,49 +458,95 @@ func RunSingleGameTyped(g *genome.GameGenome, aiType AIPlayerType, mctsIteration
 			metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 			metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 			metrics.ClosestMargin = tensionMetrics.ClosestMargin
+			metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 			metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
 			return GameResult{
 				WinnerID:    -1,
 				WinningTeam: -1,
 				TurnCount:   state.TurnNumber,
 				DurationNs:  uint64(time.Since(start).Nanoseconds()),
-				Error:       "AI returned nil move",
+				Error:       log.nilMoveError(),
 				Metrics:     metrics,
 			}
 		}
 
 		// Instrumentation
+		actions++
 		metrics.TotalActions++
 		if isInteractionTyped(state, move, g) {
 			metrics.TotalInteractions++
 		}
+		if isPassMove(move) {
+			metrics.PassActions++
+		}
 
+		mover := int(state.CurrentPlayer)
+		handBefore := tallyHand(state.Players[mover].Hand)
+		if trade := tradePhaseOf(g, move); trade != nil && engine.TradeRecipient(move) >= 0 {
+			move = resolveTradeTyped(state, trade, move, aiTypes, &metrics, rng)
+		}
 		applyMoveTyped(state, move, g)
+		metrics.CardsMoved += cardsMoved(handBefore, state.Players[mover].Hand)
+		if betting.cardTurns > 0 {
+			betting.endCardTurn(state)
+		}
+		if opensJumpInWindow(state, g, move) {
+			runJumpInWindowTyped(state, g, move.PhaseIndex, mover, aiTypes, &metrics, rng)
+		}
+		if len(g.PlacementPoints) > 0 {
+			placeFinishersTyped(state, g, rng)
+		}
+		if g.Setup.HandShrink > 0 {
+			knockoutRoundTyped(state, g, rng)
+		}
+		metrics.EffectsCapped = uint64(state.EffectsCapped)
+		metrics.MovesCapped = uint64(state.MovesCapped)
 
 		// Update tension tracking
 		tensionMetrics.Update(state, detector)
 	}
 
-	// Max turns reached - draw
-	tensionMetrics.Finalize(-1)
+	// Max turns reached - resolved by the genome's rule (a draw by default)
+	settleHandsTyped(state, g, -1)
+	winner := resolveMaxTurnsTyped(state, g)
+	tensionMetrics.Finalize(int(winner))
 	metrics.LeadChanges = uint32(tensionMetrics.LeadChanges)
 	metrics.DecisiveTurnPct = tensionMetrics.DecisiveTurnPct()
 	metrics.ClosestMargin = tensionMetrics.ClosestMargin
+	metrics.MarginVolatility = tensionMetrics.MarginVolatility()
 	metrics.WinnerWasTrailing = tensionMetrics.WinnerWasTrailing
+	if winner >= 0 {
+		metrics.KingmakerDecisions, metrics.KingmakerEvents = kingmaker.analyzeTyped(g, int(winner), seed)
+	}
+	metrics.HandLeadStability = handLeadStability(hands, int(winner))
 	return GameResult{
-		WinnerID:    -1,
-		WinningTeam: -1,
+		WinnerID:    winner,
+		WinningTeam: state.WinningTeam,
 		TurnCount:   state.TurnNumber,
 		DurationNs:  uint64(time.Since(start).Nanoseconds()),
 		Metrics:     metrics,
+		Hands:       hands,
 	}
 }
 
 // checkWinConditionsTyped checks win conditions from typed genome.
 func checkWinConditionsTyped(state *engine.GameState, g *genome.GameGenome) int8 {
+	// Knockout rounds are dealt until the match is decided, so hands left
+	// played out mean the last round is over
+	if g.Setup.HandShrink > 0 && engine.RoundPlayedOut(state) {
+		return int8(engine.KnockoutLeader(state))
+	}
 	for _, wc := range g.WinConditions {
 		switch wc.Type {
 		case genome.WinTypeEmptyHand:
+			if len(g.PlacementPoints) > 0 {
+				// Scored by placing: once every player is placed, the top
+				// scorer wins, unless another condition decides the match
+				if len(state.FinishOrder) == int(state.NumPlayers) && !placementRedeals(g) {
+					return int8(engine.PlacementLeader(state))
+				}
+				continue
+			}
 			// First player to empty hand wins
 			for i := 0; i < int(state.NumPlayers); i++ {
 				if len(state.Players[i].Hand) == 0 {
@@ -407,7 +594,13 @@ func checkWinConditionsTyped(state *engine.GameState, g *genome.GameGenome) int8
 			}
 
 		case genome.WinTypeHighScore:
-			// First to reach threshold wins
+			// First to reach threshold wins, or the leader by the margin
+			if wc.Margin > 0 {
+				if leader := engine.MarginLeader(state, int(state.NumPlayers), wc.Threshold, wc.Margin); leader >= 0 {
+					return int8(leader)
+				}
+				continue
+			}
 			for i := 0; i < int(state.NumPlayers); i++ {
 				if state.Players[i].Score >= wc.Threshold {
 					return int8(i)
@@ -436,7 +629,7 @@ func checkWinConditionsTyped(state *engine.GameState, g *genome.GameGenome) int8
 			}
 
 		case genome.WinTypeMostCaptured:
-			// When all hands empty, most tricks won wins (used as captured proxy)
+			// When all hands and the deck are empty, the biggest captured pile wins
 			allEmpty := true
 			for i := 0; i < int(state.NumPlayers); i++ {
 				if len(state.Players[i].Hand) > 0 {
@@ -446,10 +639,10 @@ func checkWinConditionsTyped(state *engine.GameState, g *genome.GameGenome) int8
 			}
 			if allEmpty && len(state.Deck) == 0 {
 				bestPlayer := -1
-				mostTricks := int8(0)
+				mostCaptured := 0
 				for i := 0; i < int(state.NumPlayers); i++ {
-					if state.Players[i].TricksWon > mostTricks {
-						mostTricks = state.Players[i].TricksWon
+					if len(state.Players[i].Captured) > mostCaptured {
+						mostCaptured = len(state.Players[i].Captured)
 						bestPlayer = i
 					}
 				}
@@ -464,17 +657,492 @@ func checkWinConditionsTyped(state *engine.GameState, g *genome.GameGenome) int8
 
 		case genome.WinTypeFirstToScore:
 			// Same as high score
+			if wc.Margin > 0 {
+				if leader := engine.MarginLeader(state, int(state.NumPlayers), wc.Threshold, wc.Margin); leader >= 0 {
+					return int8(leader)
+				}
+				continue
+			}
 			for i := 0; i < int(state.NumPlayers); i++ {
 				if state.Players[i].Score >= wc.Threshold {
 					return int8(i)
 				}
 			}
+
+		case genome.WinTypeMatchWins:
+			// First to win Threshold hands takes the match
+			for i := 0; i < int(state.NumPlayers); i++ {
+				if wc.Threshold > 0 && state.Players[i].HandsWon >= wc.Threshold {
+					return int8(i)
+				}
+			}
+
+		case genome.WinTypeLastChipStanding:
+			// Decided between hands, once the pot is awarded: the last player
+			// holding chips wins, or the chip leader after Threshold hands
+			if state.Pot > 0 {
+				continue
+			}
+			if holder := engine.LastChipStanding(state); holder >= 0 {
+				return int8(holder)
+			}
+			if wc.Threshold > 0 && state.HandsPlayed >= int(wc.Threshold) {
+				if leader := (&engine.ChipLeaderDetector{}).GetLeader(state); leader >= 0 {
+					return int8(leader)
+				}
+			}
 		}
 	}
 
 	return -1 // No winner yet
 }
 
+// passStuckTyped passes the turn from a player with no legal move to the
+// next player, in seat order, who has one. It reports false, leaving the
+// turn where it was, when every player is stuck.
+func passStuckTyped(state *engine.GameState, g *genome.GameGenome) bool {
+	stuck := state.CurrentPlayer
+	n := int(state.NumPlayers)
+	for i := 1; i < n; i++ {
+		state.CurrentPlayer = uint8((int(stuck) + i) % n)
+		if len(genome.GenerateLegalMovesTyped(state, g)) > 0 {
+			return true
+		}
+	}
+	state.CurrentPlayer = stuck
+	return false
+}
+
+// settleHandsTyped scores the captured piles, the exposed cards and the
+// cards left in hand once the game has ended and returns the winner.
+// Capture categories, exposed cards and reveal scoring add points that, in
+// games won on score or captures, can change who leads. In low-score games
+// exposed penalty cards, and a penalty charging each player for their own
+// hand, can change who is lowest. Either way the
+// winner is re-decided afterwards. A draw (winner -1) stays a draw. A pot
+// left for the top scorer under ChipBridgePointsWinPot is awarded last, on
+// the final scores.
+func settleHandsTyped(state *engine.GameState, g *genome.GameGenome, winner int8) int8 {
+	defer engine.SettlePotOnPoints(state)
+	if len(g.CaptureScoring) > 0 {
+		engine.ScoreCaptureCategories(state, convertCaptureScoring(g.CaptureScoring))
+		if hasWinType(g, genome.WinTypeMostCaptured) {
+			winner = topScorerTyped(state, winner)
+		}
+	}
+	exposed := hasExposedScoringTyped(g)
+	if exposed {
+		engine.ScoreExposed(state, convertCardScoring(g.CardScoring))
+		if hasWinType(g, genome.WinTypeLowScore) {
+			winner = lowScorerTyped(state, winner)
+		}
+	}
+	if g.RevealScoring {
+		engine.RevealHands(state, convertCardScoring(g.CardScoring), convertHandEvaluation(g.HandEval))
+	}
+	if len(g.CaptureScoring) > 0 || g.RevealScoring || exposed {
+		if hasWinType(g, genome.WinTypeHighScore) || hasWinType(g, genome.WinTypeFirstToScore) {
+			winner = topScorerTyped(state, winner)
+		}
+	}
+	if g.HandPenalty == genome.HandPenaltyNone {
+		return winner
+	}
+	engine.ApplyHandPenalties(state, uint8(g.HandPenalty), int(winner),
+		convertCardScoring(g.CardScoring), convertHandEvaluation(g.HandEval))
+
+	if g.HandPenalty != genome.HandPenaltySelf || !hasWinType(g, genome.WinTypeLowScore) {
+		return winner
+	}
+	return lowScorerTyped(state, winner)
+}
+
+// hasExposedScoringTyped reports whether any card scoring rule scores the
+// cards players have exposed.
+func hasExposedScoringTyped(g *genome.GameGenome) bool {
+	for _, rule := range g.CardScoring {
+		if rule.Trigger == genome.TriggerExposed {
+			return true
+		}
+	}
+	return false
+}
+
+// lowScorerTyped returns the lowest scorer, ties going to winner, and sets
+// state.WinningTeam to match. A draw (winner -1) stays a draw.
+func lowScorerTyped(state *engine.GameState, winner int8) int8 {
+	if winner < 0 {
+		return winner
+	}
+	for i := 0; i < int(state.NumPlayers); i++ {
+		if state.Players[i].Score < state.Players[winner].Score {
+			winner = int8(i)
+		}
+	}
+	if int(winner) < len(state.PlayerToTeam) {
+		state.WinningTeam = state.PlayerToTeam[winner]
+	}
+	return winner
+}
+
+// topScorerTyped returns the highest scorer, ties going to winner, and sets
+// state.WinningTeam to match. A draw (winner -1) stays a draw.
+func topScorerTyped(state *engine.GameState, winner int8) int8 {
+	if winner < 0 {
+		return winner
+	}
+	for i := 0; i < int(state.NumPlayers); i++ {
+		if state.Players[i].Score > state.Players[winner].Score {
+			winner = int8(i)
+		}
+	}
+	if int(winner) < len(state.PlayerToTeam) {
+		state.WinningTeam = state.PlayerToTeam[winner]
+	}
+	return winner
+}
+
+// turnLimitReached reports whether the game has run to limit (see
+// genome.TurnLimitFor), counted in the unit the genome's TurnLimit names:
+// turns, the moves taken in turn (actions, leaving out betting and
+// bidding), or hands played.
+func turnLimitReached(state *engine.GameState, g *genome.GameGenome, limit int, actions uint64) bool {
+	switch g.TurnStructure.TurnLimit {
+	case genome.TurnLimitActions:
+		return actions >= uint64(limit)
+	case genome.TurnLimitHands:
+		return state.HandsPlayed >= limit
+	}
+	return state.TurnNumber >= uint32(limit)
+}
+
+// resolveMaxTurnsTyped picks the winner of a game that reached the turn
+// limit under the genome's MaxTurnsRule, setting state.WinningTeam to match.
+// Returns -1 for a draw, including when the leaders are tied.
+func resolveMaxTurnsTyped(state *engine.GameState, g *genome.GameGenome) int8 {
+	state.WinningTeam = -1
+
+	var tally func(p int) int64
+	switch g.TurnStructure.MaxTurnsRule {
+	case genome.MaxTurnsHighScore:
+		tally = func(p int) int64 { return int64(state.Players[p].Score) }
+	case genome.MaxTurnsMostCaptured:
+		if g.TurnStructure.TableauMode == genome.TableauModeWar {
+			// War captures go back into the winner's hand
+			tally = func(p int) int64 { return int64(len(state.Players[p].Hand)) }
+		} else {
+			tally = func(p int) int64 { return int64(len(state.Players[p].Captured)) }
+		}
+	default:
+		return -1
+	}
+
+	winner := int8(-1)
+	var best int64
+	tied := false
+	for i := 0; i < int(state.NumPlayers); i++ {
+		v := tally(i)
+		switch {
+		case winner < 0 || v > best:
+			winner, best, tied = int8(i), v, false
+		case v == best:
+			tied = true
+		}
+	}
+	if tied {
+		return -1
+	}
+	if int(winner) < len(state.PlayerToTeam) {
+		state.WinningTeam = state.PlayerToTeam[winner]
+	}
+	return winner
+}
+
+// hasWinType reports whether the genome has a win condition of type t.
+func hasWinType(g *genome.GameGenome, t genome.WinConditionType) bool {
+	for _, wc := range g.WinConditions {
+		if wc.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// redealTyped gathers every card, burned ones included, into a full deck,
+// shuffles it and deals the next hand of a chip tournament.
+func redealTyped(state *engine.GameState, g *genome.GameGenome, rng *rand.Rand) {
+	engine.CollectCards(state)
+	state.Deck = append(state.Deck, state.Burned...)
+	state.Burned = state.Burned[:0]
+	state.ShuffleDeck(rng.Uint64())
+	dealUntilPlayableTyped(state, g, state.CardsPerPlayer, rng.Uint64)
+}
+
+// placementRedeals reports whether a placement-scored genome plays several
+// rounds: it has a win condition besides empty_hand to end the match, so
+// each round's placings are scored and the cards dealt again.
+func placementRedeals(g *genome.GameGenome) bool {
+	for _, wc := range g.WinConditions {
+		if wc.Type != genome.WinTypeEmptyHand {
+			return true
+		}
+	}
+	return false
+}
+
+// placeFinishersTyped keeps a placement-scored round going after a move:
+// players who went out are placed and skipped, and once a single player
+// holds cards the placings are scored. If that leaves the match undecided,
+// the hand ends and the next round is dealt.
+func placeFinishersTyped(state *engine.GameState, g *genome.GameGenome, rng *rand.Rand) {
+	engine.RecordFinishers(state)
+	if !engine.PlacementRoundOver(state) {
+		engine.SkipFinished(state)
+		return
+	}
+	engine.AwardPlacements(state, g.PlacementPoints)
+	if placementRedeals(g) && checkWinConditionsTyped(state, g) < 0 {
+		engine.SettlePotOnPoints(state)
+		state.ResetHand()
+		engine.CollectCards(state)
+		state.ShuffleDeck(rng.Uint64())
+		dealUntilPlayableTyped(state, g, state.CardsPerPlayer, rng.Uint64)
+	}
+}
+
+// knockoutRoundTyped ends a round of a knockout trick game once the hands
+// are played out: players who took no trick are knocked out and, while more
+// than one is left and the shrinking deal still has cards to give, the
+// cards are collected and the next round dealt HandShrink cards smaller.
+// Otherwise the hands stay empty and the match is over.
+func knockoutRoundTyped(state *engine.GameState, g *genome.GameGenome, rng *rand.Rand) {
+	if !engine.RoundPlayedOut(state) {
+		return
+	}
+	if engine.KnockOutTrickless(state) <= 1 {
+		return
+	}
+	size := engine.ShrunkHandSize(state.CardsPerPlayer, g.Setup.HandShrink, state.HandsPlayed+1)
+	if size == 0 {
+		return
+	}
+	engine.SettlePotOnPoints(state)
+	state.ResetHand()
+	engine.ResetHandState(state)
+	engine.CollectCards(state)
+	state.ShuffleDeck(rng.Uint64())
+	dealUntilPlayableTyped(state, g, size, rng.Uint64)
+}
+
+// opensJumpInWindow reports whether move gives other players a chance to
+// jump in: the genome allows it, a single card was played to the discard,
+// and the player's turn is over rather than partway through a repeat.
+func opensJumpInWindow(state *engine.GameState, g *genome.GameGenome, move *engine.LegalMove) bool {
+	if g.TurnStructure.JumpIn == genome.JumpInNone || move.CardIndex < 0 || move.TargetLoc != engine.LocationDiscard || state.PhaseRuns > 0 {
+		return false
+	}
+	if move.PhaseIndex >= len(g.TurnStructure.Phases) {
+		return false
+	}
+	_, ok := g.TurnStructure.Phases[move.PhaseIndex].(*genome.PlayPhase)
+	return ok
+}
+
+// runJumpInWindowTyped polls the other players after lastPlayer plays to the
+// discard pile. The first player (in seat order) whose AI takes a matching
+// card plays it as though it were their turn, so play continues after them,
+// and the window reopens on the card they played. Every poll counts as a
+// decision (jump in or let it pass) and every jump-in as an interaction,
+// since it takes the turn away from whoever was due to play.
+func runJumpInWindowTyped(state *engine.GameState, g *genome.GameGenome, phaseIdx int, lastPlayer int, aiTypes []AIPlayerType, metrics *GameMetrics, rng *rand.Rand) {
+	rule := uint8(g.TurnStructure.JumpIn)
+	for {
+		jumped := false
+		for _, c := range engine.JumpInCandidates(state, lastPlayer, rule) {
+			metrics.TotalDecisions++
+			metrics.TotalValidMoves += 2
+			metrics.TotalHandSize += uint64(len(state.Players[c.PlayerID].Hand))
+			if !wantsJumpIn(seatAI(aiTypes, c.PlayerID), rng) {
+				continue
+			}
+
+			state.CurrentPlayer = uint8(c.PlayerID)
+			handBefore := tallyHand(state.Players[c.PlayerID].Hand)
+			applyMoveTyped(state, &engine.LegalMove{
+				PhaseIndex: phaseIdx,
+				CardIndex:  c.CardIndex,
+				TargetLoc:  engine.LocationDiscard,
+			}, g)
+			metrics.TotalActions++
+			metrics.TotalInteractions++
+			metrics.JumpIns++
+			metrics.CardsMoved += cardsMoved(handBefore, state.Players[c.PlayerID].Hand)
+
+			lastPlayer = c.PlayerID
+			jumped = true
+			break
+		}
+
+		// Each jump-in sheds a card, so the chain always ends. A jump-in
+		// into a repeating phase leaves the jumper to finish their turn.
+		if !jumped || len(state.Players[lastPlayer].Hand) == 0 || state.PhaseRuns > 0 {
+			return
+		}
+	}
+}
+
+// seatAI returns the AI playing seat p; a single entry plays every seat.
+func seatAI(aiTypes []AIPlayerType, p int) AIPlayerType {
+	return aiTypes[p%len(aiTypes)]
+}
+
+// seatMemory returns the tricks seat p's MCTS player remembers; a single
+// entry applies to every seat and nil to none (0 = every trick).
+func seatMemory(memory []int, p int) int {
+	if len(memory) == 0 {
+		return 0
+	}
+	return memory[p%len(memory)]
+}
+
+// searchIterations returns how many MCTS iterations aiType runs per move:
+// mctsIterations, unless it is typeIterations.
+func searchIterations(aiType AIPlayerType, mctsIterations int) int {
+	if mctsIterations != typeIterations {
+		return mctsIterations
+	}
+	switch aiType {
+	case MCTS500AI:
+		return 500
+	case MCTS1000AI:
+		return 1000
+	case MCTS2000AI:
+		return 2000
+	default:
+		return 100
+	}
+}
+
+// HasHiddenInformation reports whether players of g can face cards they
+// cannot see: opponents' hands, a deck that is drawn from, or face-down
+// discards. A game with
+// neither is fully observable, and determinizing its MCTS search only
+// repeats the same search.
+func HasHiddenInformation(g *genome.GameGenome) bool {
+	if g.Setup.CardsPerPlayer > 0 {
+		return true
+	}
+	for _, phase := range g.TurnStructure.Phases {
+		switch p := phase.(type) {
+		case *genome.DrawPhase:
+			if p.Source == genome.LocationDeck {
+				return true
+			}
+		case *genome.PlayPhase:
+			if p.RefillTo > 0 {
+				return true
+			}
+		case *genome.DiscardPhase:
+			if p.FaceDown {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wantsJumpIn decides whether a player offered a jump-in takes it. Shedding
+// a card without spending a turn is never worse in the games this rule
+// suits, so only the random player ever declines.
+func wantsJumpIn(aiType AIPlayerType, rng *rand.Rand) bool {
+	if aiType == RandomAI {
+		return rng.Intn(2) == 0
+	}
+	return true
+}
+
+// tradePhaseOf returns the TradePhase move belongs to, or nil.
+func tradePhaseOf(g *genome.GameGenome, move *engine.LegalMove) *genome.TradePhase {
+	if move.PhaseIndex >= len(g.TurnStructure.Phases) {
+		return nil
+	}
+	trade, _ := g.TurnStructure.Phases[move.PhaseIndex].(*genome.TradePhase)
+	return trade
+}
+
+// resolveTradeTyped polls the recipient of a trade offer for their answer,
+// carries the trade out if they accept, and returns the pass that ends the
+// proposer's turn in the phase. Random AIs accept on a coin flip and pay
+// with a random card; the others answer greedily (engine.TradeReply). The
+// answer counts as a decision, and every completed trade is tallied.
+func resolveTradeTyped(state *engine.GameState, trade *genome.TradePhase, move *engine.LegalMove, aiTypes []AIPlayerType, metrics *GameMetrics, rng *rand.Rand) *engine.LegalMove {
+	recipient := engine.TradeRecipient(move)
+	price := int32(trade.Price)
+	metrics.TotalDecisions++
+	metrics.TotalValidMoves += 2
+	metrics.TotalHandSize += uint64(len(state.Players[recipient].Hand))
+
+	var accept bool
+	giveBack := -1
+	if seatAI(aiTypes, recipient) == RandomAI {
+		accept = rng.Intn(2) == 0
+		if n := len(state.Players[recipient].Hand); price <= 0 && n > 0 {
+			giveBack = rng.Intn(n)
+		}
+	} else {
+		offered := state.Players[state.CurrentPlayer].Hand[move.CardIndex]
+		accept, giveBack = engine.TradeReply(state, recipient, offered, price)
+	}
+	if accept && engine.ApplyTrade(state, move, price, giveBack) {
+		metrics.Trades++
+	}
+
+	pass := *move
+	pass.CardIndex = engine.MoveTradePass
+	pass.TargetLoc = engine.LocationHand
+	return &pass
+}
+
+// startHandTyped opens a hand around the dealer. With a rotating dealer
+// the player after the dealer leads and opens the betting; blinds, when the
+// betting phase sets them, are posted and the betting opens after them. In
+// trick games the trick phase's opening lead rule picks the first leader.
+func startHandTyped(state *engine.GameState, g *genome.GameGenome) {
+	if g.Setup.RotateDealer {
+		lead := state.SeatAfterDealer(1)
+		state.CurrentPlayer = uint8(lead)
+		state.BettingStartPlayer = lead
+	}
+	if tp := findTrickPhase(g); tp != nil {
+		leadCard := engine.Card{Rank: tp.LeadCardRank, Suit: tp.LeadCardSuit}
+		lead := uint8(state.OpeningLeader(uint8(tp.OpeningLead), leadCard))
+		state.CurrentPlayer = lead
+		state.TrickLeader = lead
+	}
+	if bp := findBettingPhase(g); bp != nil && g.Setup.StartingChips > 0 {
+		// Players out of chips are out of the game and sit the hand out
+		engine.EliminateBankrupt(state)
+		if state.Players[state.CurrentPlayer].HasFolded {
+			state.CurrentPlayer = uint8(nextSolventSeat(state, int(state.CurrentPlayer)))
+		}
+		if bp.Blinds > 0 {
+			state.BettingStartPlayer = engine.PostBlinds(state, int64(bp.Blinds))
+		}
+	}
+}
+
+// nextSolventSeat returns the first seat after seat still holding chips,
+// or seat itself when no other does.
+func nextSolventSeat(state *engine.GameState, seat int) int {
+	n := int(state.NumPlayers)
+	for i := 1; i < n; i++ {
+		if next := (seat + i) % n; state.Players[next].Chips > 0 {
+			return next
+		}
+	}
+	return seat
+}
+
 // findBettingPhase returns the first BettingPhase in the genome, or nil.
 func findBettingPhase(g *genome.GameGenome) *genome.BettingPhase {
 	for _, phase := range g.TurnStructure.Phases {
@@ -485,6 +1153,249 @@ func findBettingPhase(g *genome.GameGenome) *genome.BettingPhase {
 	return nil
 }
 
+// bettingRoundPhase returns g's betting phase for a hand's round'th betting
+// round (0 = the first) in phase order, with its phase index, or nil when g
+// has no more.
+func bettingRoundPhase(g *genome.GameGenome, round int) (*genome.BettingPhase, int) {
+	for i, phase := range g.TurnStructure.Phases {
+		if bp, ok := phase.(*genome.BettingPhase); ok {
+			if round == 0 {
+				return bp, i
+			}
+			round--
+		}
+	}
+	return nil, -1
+}
+
+// bettingLifecycle follows a hand's betting rounds through the phase order.
+// Between two betting phases the card phases separating them are played in
+// order, every player taking a turn at each, before betting opens again, as
+// in draw poker: bet, discard, draw, bet.
+type bettingLifecycle struct {
+	round     int // Betting rounds finished this hand
+	nextAt    int // Phase index of the next betting phase
+	cardTurns int // Card phase turns left before it opens
+}
+
+// startCardPhases closes the betting round of the phase at index at and
+// starts the card phases up to the next betting phase, at index next, from
+// the player who opened the betting.
+func (b *bettingLifecycle) startCardPhases(state *engine.GameState, at, next int) {
+	b.round++
+	b.nextAt = next
+	b.cardTurns = (next - at - 1) * int(state.NumPlayers)
+	state.CurrentPlayer = uint8(state.BettingStartPlayer % int(state.NumPlayers))
+	if b.cardTurns == 0 {
+		b.reopen(state)
+	}
+}
+
+// cardPhase returns the index of the card phase being played among n
+// players.
+func (b *bettingLifecycle) cardPhase(n int) int {
+	return b.nextAt - (b.cardTurns+n-1)/n
+}
+
+// endCardTurn counts a card phase turn taken, opening the next betting
+// round after the last.
+func (b *bettingLifecycle) endCardTurn(state *engine.GameState) {
+	if b.cardTurns--; b.cardTurns == 0 {
+		b.reopen(state)
+	}
+}
+
+// reopen starts the next betting round: the bets of the last one are
+// squared away in the pot and betting opens again.
+func (b *bettingLifecycle) reopen(state *engine.GameState) {
+	engine.StartBettingStreet(state)
+	state.BettingComplete = false
+}
+
+// movesInPhase keeps the moves of the phase at index phaseIdx.
+func movesInPhase(moves []engine.LegalMove, phaseIdx int) []engine.LegalMove {
+	kept := moves[:0]
+	for _, m := range moves {
+		if m.PhaseIndex == phaseIdx {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// dealGameTyped sets up a new game of g for numPlayers on state: the
+// shuffled deck, the hands and any starting tableau and chips, and the first
+// hand's dealer and leader. The same seed always gives the same deal: the
+// deck order is the one setupDeck gives for seed, and g's DealStrategy and
+// DealOrder decide which of those cards each player gets.
+func dealGameTyped(state *engine.GameState, g *genome.GameGenome, numPlayers int, seed uint64) {
+	// Setup deck and shuffle
+	setupDeck(state, seed)
+
+	// Read setup from typed genome
+	cardsPerPlayer := g.Setup.CardsPerPlayer
+	if cardsPerPlayer <= 0 {
+		cardsPerPlayer = 26 // Default for War
+	}
+
+	initialDiscardCount := g.Setup.DealToTableau
+	startingChips := g.Setup.StartingChips
+
+	numPlayers = clampPlayers(numPlayers)
+	state.NumPlayers = uint8(numPlayers)
+	state.CardsPerPlayer = cardsPerPlayer
+	if g.Setup.PersonalDecks {
+		engine.SetupPersonalDecks(state, numPlayers, g.Setup.PersonalDeckSize, seed)
+	}
+	state.HandSizesHidden = g.Setup.HiddenHandSizes
+
+	// Set tableau mode from typed genome
+	state.TableauMode = uint8(g.TurnStructure.TableauMode)
+	state.WarTieRule = uint8(g.TurnStructure.WarTieRule)
+	state.SequenceDirection = uint8(g.TurnStructure.SequenceDirection)
+	state.AceMode = engine.AceMode(g.TurnStructure.AceMode)
+	state.RankValues = g.RankValues
+	state.WildRanks = genome.WildRankMask(g)
+	state.ChipBridge = uint8(g.ChipBridge)
+	engine.SetupPiles(state, convertDiscardPiles(g.DiscardPiles))
+
+	// Initialize teams if configured
+	if g.Teams != nil && g.Teams.Enabled && len(g.Teams.Teams) > 0 {
+		teams := make([][]int, len(g.Teams.Teams))
+		for i, team := range g.Teams.Teams {
+			teams[i] = make([]int, len(team))
+			copy(teams[i], team)
+		}
+		state.InitializeTeams(teams)
+	}
+
+	// The last seat deals the first hand, so seat 0 leads it
+	state.Dealer = numPlayers - 1
+	dealUntilPlayableTyped(state, g, cardsPerPlayer, misdealSeeds(seed))
+
+	// Initialize chips if this genome uses betting
+	if startingChips > 0 {
+		state.InitializeChips(startingChips)
+	}
+
+	startHandTyped(state, g)
+
+	// Uno's first-card rule: a starting Skip or Reverse changes who leads
+	if g.Setup.StartCardEffect && initialDiscardCount > 0 && state.TableauMode == 0 {
+		engine.ResolveStartingCard(state, convertEffects(g))
+	}
+}
+
+// dealHandsTyped burns g's setup burn cards, deals the hands from the top of
+// state's deck, then any starting cards to the discard or tableau, and
+// disposes of the cards left over as g's setup says.
+func dealHandsTyped(state *engine.GameState, g *genome.GameGenome, cardsPerPlayer int) {
+	numPlayers := int(state.NumPlayers)
+	initialDiscardCount := g.Setup.DealToTableau
+
+	// Burned cards leave play before anyone is dealt
+	engine.Burn(state, g.Setup.BurnCards)
+
+	// Deal cards to each player, or let each draw their own hand in seat order
+	if g.Setup.DealStrategy == genome.DealShoeDraw {
+		for p := 0; p < numPlayers; p++ {
+			if state.Players[p].Active {
+				state.DrawHand(uint8(p), cardsPerPlayer)
+			}
+		}
+	} else {
+		state.DealHands(numPlayers, cardsPerPlayer, engine.DealOrder(g.Setup.DealOrder))
+	}
+
+	// Deal initial cards to discard/tableau
+	if initialDiscardCount > 0 && len(state.Deck) >= initialDiscardCount {
+		// Initialize tableau pile if needed for TableauMode games
+		if state.TableauMode != 0 && len(state.Tableau) == 0 {
+			state.Tableau = make([][]engine.Card, 1)
+			state.Tableau[0] = make([]engine.Card, 0, initialDiscardCount)
+		}
+		for i := 0; i < initialDiscardCount; i++ {
+			if len(state.Deck) > 0 {
+				card := state.Deck[len(state.Deck)-1]
+				state.Deck = state.Deck[:len(state.Deck)-1]
+				if state.TableauMode != 0 {
+					state.Tableau[0] = append(state.Tableau[0], card)
+				} else {
+					state.Discard = append(state.Discard, card)
+				}
+			}
+		}
+	}
+
+	engine.DealRemainder(state, uint8(g.Setup.Remainder))
+}
+
+// dealUntilPlayableTyped deals the hands from state's shuffled deck with
+// dealHandsTyped, then throws the deal in while it leaves a player a hand
+// g's misdeal rule calls unplayable: the cards in play and those the deal
+// burned are gathered, reshuffled with the next of seeds and dealt again,
+// up to the rule's MaxRedeals times. It returns the number of redeals.
+func dealUntilPlayableTyped(state *engine.GameState, g *genome.GameGenome, cardsPerPlayer int, seeds func() uint64) int {
+	burned := len(state.Burned)
+	dealHandsTyped(state, g, cardsPerPlayer)
+
+	m := g.Setup.Misdeal
+	redeals := 0
+	for ; redeals < m.MaxRedeals && engine.HasMisdeal(state, uint8(m.Condition), m.Rank, m.Suit); redeals++ {
+		engine.CollectCards(state)
+		state.Deck = append(state.Deck, state.Burned[burned:]...)
+		state.Burned = state.Burned[:burned]
+		state.ShuffleDeck(seeds())
+		dealHandsTyped(state, g, cardsPerPlayer)
+	}
+	return redeals
+}
+
+// misdealSeeds returns the shuffle seeds for redealing a game's first deal,
+// shuffled with seed: each is seed mixed with the redeal's number, so a
+// game redeals the same way every time it is played.
+func misdealSeeds(seed uint64) func() uint64 {
+	n := uint64(0)
+	return func() uint64 {
+		n++
+		return seed + n*0x9E3779B97F4A7C15
+	}
+}
+
+// MaxPlayers is the largest table the engine can seat.
+const MaxPlayers = 4
+
+// clampPlayers keeps a requested table size between 2 and MaxPlayers.
+func clampPlayers(numPlayers int) int {
+	if numPlayers < 2 {
+		return 2
+	}
+	if numPlayers > MaxPlayers {
+		return MaxPlayers
+	}
+	return numPlayers
+}
+
+// findTrickPhase returns the first TrickPhase in the genome, or nil.
+func findTrickPhase(g *genome.GameGenome) *genome.TrickPhase {
+	for _, phase := range g.TurnStructure.Phases {
+		if tp, ok := phase.(*genome.TrickPhase); ok {
+			return tp
+		}
+	}
+	return nil
+}
+
+// findShowPhase returns the first ShowPhase in the genome, or nil.
+func findShowPhase(g *genome.GameGenome) *genome.ShowPhase {
+	for _, phase := range g.TurnStructure.Phases {
+		if sp, ok := phase.(*genome.ShowPhase); ok {
+			return sp
+		}
+	}
+	return nil
+}
+
 // findBiddingPhase returns the first BiddingPhase in the genome, or nil.
 func findBiddingPhase(g *genome.GameGenome) *genome.BiddingPhase {
 	for _, phase := range g.TurnStructure.Phases {
@@ -505,12 +1416,38 @@ func hasBettingMoves(moves []engine.LegalMove) bool {
 	return false
 }
 
+// runBettingHandTyped runs a hand's betting: a round on the private hands,
+// then for each community street the phase's burn cards burned, the street's
+// cards dealt to the board and another round, until no more than one player is left in the hand. The
+// previous hand's board is cleared first.
+func runBettingHandTyped(state *engine.GameState, g *genome.GameGenome, bettingPhase *genome.BettingPhase, aiTypes []AIPlayerType, metrics *GameMetrics, tensionMetrics *engine.TensionMetrics, detector engine.LeaderDetector, rng *rand.Rand) string {
+	if len(bettingPhase.Community) > 0 {
+		engine.ClearCommunity(state)
+	}
+	if err := runBettingRoundTyped(state, g, bettingPhase, aiTypes, metrics, tensionMetrics, detector, rng); err != "" {
+		return err
+	}
+	for _, count := range bettingPhase.Community {
+		if engine.CountPlayersInHand(state) <= 1 {
+			break
+		}
+		engine.Burn(state, bettingPhase.Burn)
+		engine.DealCommunity(state, count)
+		engine.StartBettingStreet(state)
+		if err := runBettingRoundTyped(state, g, bettingPhase, aiTypes, metrics, tensionMetrics, detector, rng); err != "" {
+			return err
+		}
+	}
+	return ""
+}
+
 // runBettingRoundTyped executes a betting round using typed genome.
-func runBettingRoundTyped(state *engine.GameState, g *genome.GameGenome, bettingPhase *genome.BettingPhase, aiType AIPlayerType, metrics *GameMetrics, tensionMetrics *engine.TensionMetrics, detector engine.LeaderDetector) string {
+func runBettingRoundTyped(state *engine.GameState, g *genome.GameGenome, bettingPhase *genome.BettingPhase, aiTypes []AIPlayerType, metrics *GameMetrics, tensionMetrics *engine.TensionMetrics, detector engine.LeaderDetector, rng *rand.Rand) string {
 	// Convert to engine type for compatibility
 	engineBettingPhase := &engine.BettingPhaseData{
-		MinBet:    bettingPhase.MinBet,
-		MaxRaises: bettingPhase.MaxRaises,
+		MinBet:     bettingPhase.MinBet,
+		MaxRaises:  bettingPhase.MaxRaises,
+		MinPlayers: bettingPhase.MinPlayers,
 	}
 
 	// Track who needs to act
@@ -521,10 +1458,11 @@ func runBettingRoundTyped(state *engine.GameState, g *genome.GameGenome, betting
 	}
 
 	currentPlayer := state.BettingStartPlayer % int(state.NumPlayers)
-	maxActions := int(state.NumPlayers) * (bettingPhase.MaxRaises + 2) * 2
+	maxActions := engine.MaxBettingActions(int(state.NumPlayers), engineBettingPhase)
+	minPlayers := engineBettingPhase.MinPlayersToContinue()
 
 	for actionCount := 0; actionCount < maxActions; actionCount++ {
-		if engine.CountActivePlayers(state) <= 1 {
+		if engine.CountPlayersInHand(state) < minPlayers {
 			break
 		}
 		if engine.CountActingPlayers(state) == 0 {
@@ -559,15 +1497,15 @@ func runBettingRoundTyped(state *engine.GameState, g *genome.GameGenome, betting
 		}
 
 		var action engine.BettingAction
-		switch aiType {
+		switch seatAI(aiTypes, currentPlayer) {
 		case GreedyAI:
-			handStrength := engine.EvaluateHandStrength(state.Players[currentPlayer].Hand)
-			action = engine.SelectGreedyBettingAction(state, moves, handStrength)
+			handStrength := engine.EvaluateHandStrength(engine.ShowdownCards(state, currentPlayer))
+			action = engine.SelectModeledBettingAction(state, currentPlayer, moves, handStrength)
 		default:
-			action = engine.SelectRandomBettingAction(moves, rand.Intn)
+			action = engine.SelectRandomBettingAction(moves, rng.Intn)
 		}
 
-		handStrength := engine.EvaluateHandStrength(state.Players[currentPlayer].Hand)
+		handStrength := engine.EvaluateHandStrength(engine.ShowdownCards(state, currentPlayer))
 		if action == engine.BettingBet || action == engine.BettingRaise || action == engine.BettingAllIn {
 			metrics.TotalBets++
 			if handStrength < 0.3 {
@@ -582,18 +1520,16 @@ func runBettingRoundTyped(state *engine.GameState, g *genome.GameGenome, betting
 		engine.ApplyBettingAction(state, engineBettingPhase, currentPlayer, action)
 		metrics.TotalActions++
 		metrics.TotalInteractions++
+		if action == engine.BettingCheck {
+			metrics.PassActions++
+		}
 
 		if tensionMetrics != nil && detector != nil {
 			tensionMetrics.Update(state, detector)
 		}
 
 		if state.CurrentBet > oldCurrentBet {
-			for i := 0; i < int(state.NumPlayers); i++ {
-				p := &state.Players[i]
-				if !p.HasFolded && !p.IsAllIn && p.Chips > 0 && i != currentPlayer {
-					needsToAct[i] = true
-				}
-			}
+			resetNeedsToAct(state, needsToAct, currentPlayer)
 		}
 
 		needsToAct[currentPlayer] = false
@@ -604,49 +1540,25 @@ func runBettingRoundTyped(state *engine.GameState, g *genome.GameGenome, betting
 	return ""
 }
 
-// runBiddingRoundTyped executes a bidding round using typed genome.
-func runBiddingRoundTyped(state *engine.GameState, g *genome.GameGenome, aiTypes []AIPlayerType) {
+// runBiddingRoundTyped executes a bidding round using typed genome and
+// returns the number of sealed bids (see collectBids).
+func runBiddingRoundTyped(state *engine.GameState, g *genome.GameGenome, aiTypes []AIPlayerType, rng *rand.Rand) int {
 	biddingPhase := findBiddingPhase(g)
 	if biddingPhase == nil {
-		return
+		return 0
 	}
 
 	// Convert to engine type
 	engineBiddingPhase := engine.BiddingPhase{
-		MinBid:   biddingPhase.MinBid,
-		MaxBid:   biddingPhase.MaxBid,
-		AllowNil: biddingPhase.AllowNil,
-	}
-
-	// Reset bidding state
-	state.BiddingComplete = false
-	for i := 0; i < int(state.NumPlayers); i++ {
-		state.Players[i].CurrentBid = -1
-		state.Players[i].IsNilBid = false
-	}
-
-	startPlayer := int(state.CurrentPlayer)
-	for i := 0; i < int(state.NumPlayers); i++ {
-		playerIdx := (startPlayer + i) % int(state.NumPlayers)
-
-		var bid engine.BidMove
-		aiType := aiTypes[playerIdx]
-		switch aiType {
-		case GreedyAI:
-			bid = selectGreedyBid(state, engineBiddingPhase, playerIdx)
-		default:
-			handSize := len(state.Players[playerIdx].Hand)
-			bidMoves := engine.GenerateBidMoves(engineBiddingPhase, handSize)
-			if len(bidMoves) > 0 {
-				bid = bidMoves[rand.Intn(len(bidMoves))]
-			} else {
-				bid = engine.BidMove{Value: 1, IsNil: false}
-			}
-		}
-
-		engine.ApplyBidMove(state, playerIdx, bid)
-		state.TurnNumber++
+		MinBid:       biddingPhase.MinBid,
+		MaxBid:       biddingPhase.MaxBid,
+		AllowNil:     biddingPhase.AllowNil,
+		DeclareTrump: biddingPhase.DeclareTrump,
+		Blind:        biddingPhase.Blind,
 	}
+	return collectBids(state, engineBiddingPhase.Blind, func(view *engine.GameState, playerIdx int) engine.BidMove {
+		return selectBid(view, engineBiddingPhase, playerIdx, aiTypes[playerIdx], rng.Intn)
+	})
 }
 
 // selectGreedyMoveTyped picks the move that maximizes immediate score.
@@ -688,32 +1600,71 @@ func isInteractionTyped(state *engine.GameState, move *engine.LegalMove, g *geno
 		return true
 	case *genome.BettingPhase:
 		return true
+	case *genome.GivePhase:
+		// Giving a card changes the recipient's hand
+		return engine.GiveRecipient(move) >= 0
+	case *genome.TradePhase:
+		// An offer asks the recipient to answer it
+		return engine.TradeRecipient(move) >= 0
 	}
 
 	return false
 }
 
-// applyMoveTyped applies a move using typed phase information.
+// applyMoveTyped applies a move using typed phase information. A draw
+// is followed by the genome's set cleanup, if it has one.
 func applyMoveTyped(state *engine.GameState, move *engine.LegalMove, g *genome.GameGenome) {
+	mover := int(state.CurrentPlayer)
 	// Use existing engine.ApplyMove with a compatibility wrapper
-	bytecodeGenome := createCompatGenome(g)
+	bytecodeGenome := createCompatGenome(g, int(state.NumPlayers))
 	engine.ApplyMove(state, move, bytecodeGenome)
+
+	if c := g.SetCleanup; c != nil && isDrawMoveTyped(g, move) {
+		engine.CleanupSets(state, mover, c.Size, c.Points, c.Captured)
+	}
 }
 
-// createCompatGenome creates a bytecode genome for compatibility with existing engine functions.
-// This is a temporary bridge during the transition to pure typed genomes.
-func createCompatGenome(g *genome.GameGenome) *engine.Genome {
+// isDrawMoveTyped reports whether move draws in a DrawPhase or the draw
+// half of a DrawDiscardPhase.
+func isDrawMoveTyped(g *genome.GameGenome, move *engine.LegalMove) bool {
+	if move.CardIndex != engine.MoveDraw || move.PhaseIndex >= len(g.TurnStructure.Phases) {
+		return false
+	}
+	switch g.TurnStructure.Phases[move.PhaseIndex].(type) {
+	case *genome.DrawPhase, *genome.DrawDiscardPhase:
+		return true
+	}
+	return false
+}
+
+// createCompatGenome creates a bytecode genome for compatibility with existing engine functions,
+// for a table of numPlayers. This is a temporary bridge during the transition to pure typed genomes.
+func createCompatGenome(g *genome.GameGenome, numPlayers int) *engine.Genome {
+	// Bytecode limits count turns, and MCTS rollouts and the solver stop
+	// at them, so a genome without a limit in turns gets its default one
+	maxTurns := 0
+	if g.TurnStructure.TurnLimit == genome.TurnLimitTurns {
+		maxTurns = g.TurnStructure.MaxTurns
+	}
+	if maxTurns == 0 {
+		maxTurns = genome.DefaultMaxTurns(g, numPlayers)
+	}
+
 	// Create minimal bytecode genome for compatibility
 	result := &engine.Genome{
 		Header: &engine.BytecodeHeader{
-			MaxTurns:          uint32(g.TurnStructure.MaxTurns),
+			MaxTurns:          uint32(maxTurns),
 			TableauMode:       uint8(g.TurnStructure.TableauMode),
 			SequenceDirection: uint8(g.TurnStructure.SequenceDirection),
 			PlayerCount:       2, // Default
 		},
 		TurnPhases:    make([]engine.PhaseDescriptor, len(g.TurnStructure.Phases)),
 		WinConditions: make([]engine.WinCondition, len(g.WinConditions)),
-		Effects:       make(map[uint8]engine.SpecialEffect),
+
+		MaxEffectsPerTurn: g.TurnStructure.MaxEffectsPerTurn,
+		MaxDrawsPerGame:   g.Setup.MaxDrawsPerGame,
+		MaxLegalMoves:     g.TurnStructure.MaxLegalMoves,
+		CaptureCategories: convertCaptureScoring(g.CaptureScoring),
 	}
 
 	// Convert phases to descriptors
@@ -721,26 +1672,276 @@ func createCompatGenome(g *genome.GameGenome) *engine.Genome {
 		result.TurnPhases[i] = engine.PhaseDescriptor{
 			PhaseType: phase.PhaseType(),
 			// Data is not needed for basic compatibility
+			Repeat: genome.EngineRepeat(phase),
+		}
+		// Draws, discards, show, peek, draw-discard, give, trade, claims and
+		// trick resolution read their settings from the phase data; play phases carry their
+		// hand refill and draw phases their turn limit
+		switch p := phase.(type) {
+		case *genome.ShowPhase:
+			result.TurnPhases[i].Data = encodeShowPhaseData(p)
+		case *genome.PeekPhase:
+			result.TurnPhases[i].Data = encodePeekPhaseData(p)
+		case *genome.DrawDiscardPhase:
+			result.TurnPhases[i].Data = encodeDrawDiscardPhaseData(p)
+		case *genome.DiscardPhase:
+			result.TurnPhases[i].Data = encodeDiscardPhaseData(p)
+		case *genome.GivePhase:
+			result.TurnPhases[i].Data = encodeGivePhaseData(p)
+		case *genome.TradePhase:
+			result.TurnPhases[i].Data = encodeTradePhaseData(p)
+		case *genome.ClaimPhase:
+			result.TurnPhases[i].Data = encodeClaimPhaseData(p)
+		case *genome.TrickPhase:
+			result.TurnPhases[i].Data = encodeTrickPhaseData(p)
+		case *genome.PlayPhase:
+			result.TurnPhases[i].RefillTo = p.RefillTo
+		case *genome.DrawPhase:
+			result.TurnPhases[i].DrawLimit = p.MaxPerTurn
+			result.TurnPhases[i].Data = encodeDrawPhaseData(p)
 		}
 	}
+	result.HandEval = convertHandEvaluation(g.HandEval)
+	result.CardScoring = convertCardScoring(g.CardScoring)
+	if g.MoonShot != nil {
+		result.MoonShot = &engine.MoonShotRule{Points: g.MoonShot.Points}
+	}
 
 	// Convert win conditions
 	for i, wc := range g.WinConditions {
 		result.WinConditions[i] = engine.WinCondition{
 			WinType:   uint8(wc.Type),
 			Threshold: wc.Threshold,
+			Margin:    wc.Margin,
 		}
 	}
 
-	// Convert effects
+	result.Effects = convertEffects(g)
+
+	return result
+}
+
+// convertEffects maps g's special effects by their trigger rank.
+func convertEffects(g *genome.GameGenome) map[uint8]engine.SpecialEffect {
+	effects := make(map[uint8]engine.SpecialEffect, len(g.Effects))
 	for _, effect := range g.Effects {
-		result.Effects[effect.TriggerRank] = engine.SpecialEffect{
+		effects[effect.TriggerRank] = engine.SpecialEffect{
 			TriggerRank: effect.TriggerRank,
-			EffectType:  uint8(effect.Effect),
+			EffectType:  engineEffectType(effect.Effect),
 			Target:      effect.Target,
 			Value:       effect.Value,
 		}
 	}
+	return effects
+}
+
+// engineEffectType returns the engine's number for a genome effect. The
+// forcing effects are translated; the effects the engine has no version of
+// are passed as an unknown type, which it ignores, so they cannot alias one
+// it does; the rest keep their genome number.
+func engineEffectType(effect genome.EffectType) uint8 {
+	switch effect {
+	case genome.EffectForceDiscard:
+		return engine.EFFECT_FORCE_DISCARD
+	case genome.EffectForceReveal:
+		return engine.EFFECT_FORCE_REVEAL
+	case genome.EffectSwapHands, genome.EffectBlockNext, genome.EffectStealCard,
+		genome.EffectPeekHand, genome.EffectDiscardPile:
+		return math.MaxUint8
+	}
+	return uint8(effect)
+}
+
+// encodeShowPhaseData packs a typed ShowPhase into the bytecode layout
+// read by engine.ParseShowPhaseData.
+func encodeShowPhaseData(sp *genome.ShowPhase) []byte {
+	data := make([]byte, 6)
+	data[0] = uint8(sp.Award)
+	binary.BigEndian.PutUint32(data[1:5], uint32(int32(sp.Points)))
+	if sp.ClearHands {
+		data[5] = 1
+	}
+	return data
+}
+
+// encodePeekPhaseData packs a typed PeekPhase into the bytecode layout
+// read by engine.ParsePeekPhaseData.
+func encodePeekPhaseData(pp *genome.PeekPhase) []byte {
+	data := make([]byte, 3)
+	data[0] = uint8(pp.Target)
+	binary.BigEndian.PutUint16(data[1:3], uint16(pp.Duration))
+	return data
+}
+
+// encodeDrawDiscardPhaseData packs a typed DrawDiscardPhase into the
+// bytecode layout read by engine.ParseDrawDiscardPhaseData.
+func encodeDrawDiscardPhaseData(dp *genome.DrawDiscardPhase) []byte {
+	data := make([]byte, 6)
+	data[0] = uint8(dp.Source)
+	binary.BigEndian.PutUint32(data[1:5], uint32(int32(dp.Count)))
+	if dp.TakeDiscard {
+		data[5] = 1
+	}
+	return data
+}
+
+// encodeDrawPhaseData packs a typed DrawPhase into the bytecode layout
+// ApplyMove draws from: source:1 + count:4 + mandatory:1 + has_condition:1.
+// The condition itself is checked by the typed move generator.
+func encodeDrawPhaseData(dp *genome.DrawPhase) []byte {
+	data := make([]byte, 7)
+	data[0] = uint8(dp.Source)
+	binary.BigEndian.PutUint32(data[1:5], uint32(int32(dp.Count)))
+	if dp.Mandatory {
+		data[5] = 1
+	}
+	return data
+}
+
+// encodeDiscardPhaseData packs a typed DiscardPhase into the bytecode
+// layout, target:1 + count:4 + mandatory:1, with face_down appended as a
+// seventh byte for ApplyMove.
+func encodeDiscardPhaseData(dp *genome.DiscardPhase) []byte {
+	data := make([]byte, 7)
+	data[0] = uint8(dp.Target)
+	binary.BigEndian.PutUint32(data[1:5], uint32(int32(dp.Count)))
+	if dp.Mandatory {
+		data[5] = 1
+	}
+	if dp.FaceDown {
+		data[6] = 1
+	}
+	return data
+}
+
+// encodeGivePhaseData packs a typed GivePhase into the bytecode layout
+// read by engine.ParseGivePhaseData.
+func encodeGivePhaseData(gp *genome.GivePhase) []byte {
+	data := []byte{uint8(gp.Target), 0}
+	if gp.Mandatory {
+		data[1] = 1
+	}
+	return data
+}
+
+// encodeClaimPhaseData packs a typed ClaimPhase into the 10-byte bytecode
+// layout: one card per claim, challenges allowed, the loser taking the
+// pile, and the rank rule in the sixth byte.
+func encodeClaimPhaseData(cp *genome.ClaimPhase) []byte {
+	data := make([]byte, 10)
+	data[0], data[1] = 1, 1
+	if cp.RankRule == genome.ClaimRankSequential {
+		data[2] = 1
+	}
+	data[3], data[4] = 1, 1
+	data[5] = uint8(cp.RankRule)
+	return data
+}
+
+// encodeTradePhaseData packs a typed TradePhase into the bytecode layout
+// read by engine.ParseTradePhaseData.
+func encodeTradePhaseData(tp *genome.TradePhase) []byte {
+	data := make([]byte, 5)
+	data[0] = uint8(tp.Target)
+	binary.BigEndian.PutUint32(data[1:5], uint32(tp.Price))
+	return data
+}
+
+// encodeTrickPhaseData packs a typed TrickPhase into the bytecode layout
+// read by trick resolution, with the tie rule appended as a fifth byte, the
+// trick constraints as a sixth and the next-leader rule as a seventh.
+func encodeTrickPhaseData(tp *genome.TrickPhase) []byte {
+	data := make([]byte, 7)
+	if tp.LeadSuitRequired {
+		data[0] = 1
+	}
+	data[1] = tp.TrumpSuit
+	if tp.HighCardWins {
+		data[2] = 1
+	}
+	data[3] = tp.BreakingSuit
+	data[4] = uint8(tp.TieRule)
+	data[5] = tp.Constraints()
+	data[6] = uint8(tp.NextLeader)
+	return data
+}
+
+// convertCardScoring converts typed card scoring rules to the engine form.
+// convertDiscardPiles converts the genome's extra discard piles to the
+// engine's pile rules.
+func convertDiscardPiles(piles []genome.DiscardPile) []engine.PileRule {
+	if len(piles) == 0 {
+		return nil
+	}
+	rules := make([]engine.PileRule, len(piles))
+	for i, p := range piles {
+		rules[i] = engine.PileRule{Suit: p.Suit, StartRank: p.StartRank, Build: uint8(p.Build)}
+	}
+	return rules
+}
+
+func convertCardScoring(rules []genome.CardScoringRule) []engine.CardScoringRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]engine.CardScoringRule, len(rules))
+	for i, r := range rules {
+		result[i] = engine.CardScoringRule{
+			Suit:    r.Suit,
+			Rank:    r.Rank,
+			Points:  r.Points,
+			Trigger: uint8(r.Trigger),
+			Meld:    uint8(r.Meld),
+		}
+	}
+	return result
+}
 
+// convertCaptureScoring converts typed capture categories to the engine form.
+func convertCaptureScoring(categories []genome.CaptureCategory) []engine.CaptureCategory {
+	if len(categories) == 0 {
+		return nil
+	}
+	result := make([]engine.CaptureCategory, len(categories))
+	for i, c := range categories {
+		result[i] = engine.CaptureCategory{
+			Kind:        uint8(c.Kind),
+			Suit:        c.Suit,
+			Rank:        c.Rank,
+			Points:      c.Points,
+			PrimeValues: c.PrimeValues,
+		}
+	}
+	return result
+}
+
+// convertHandEvaluation converts a typed hand evaluation to the engine form.
+func convertHandEvaluation(h *genome.HandEvaluation) *engine.HandEvaluation {
+	if h == nil {
+		return nil
+	}
+
+	result := &engine.HandEvaluation{
+		Method:        uint8(h.Method),
+		TargetValue:   h.TargetValue,
+		BustThreshold: h.BustThreshold,
+		Bust:          engine.BustRule(h.Bust),
+		CardValues:    make([]engine.CardValue, len(h.CardValues)),
+		Patterns:      make([]engine.HandPattern, len(h.Patterns)),
+	}
+	for i, cv := range h.CardValues {
+		result.CardValues[i] = engine.CardValue{Rank: cv.Rank, Value: cv.Value, AltValue: cv.AltValue}
+	}
+	for i, p := range h.Patterns {
+		result.Patterns[i] = engine.HandPattern{
+			RankPriority:   p.Priority,
+			RequiredCount:  p.RequiredCount,
+			SameSuitCount:  p.SameSuitCount,
+			SequenceLength: p.SequenceLength,
+			SequenceWrap:   p.SequenceWrap,
+			SameRankGroups: p.SameRankGroups,
+			RequiredRanks:  p.RequiredRanks,
+		}
+	}
 	return result
 }
@@ -1,10 +1,14 @@
 package simulation
 
 import (
+	"math/rand"
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/signalnine/darwindeck/gosim/engine"
 	"github.com/signalnine/darwindeck/gosim/genome"
+	"github.com/signalnine/darwindeck/gosim/mcts"
 )
 
 func TestRunSingleGameTypedWar(t *testing.T) {
@@ -71,6 +75,20 @@ func TestRunSingleGameTypedCrazyEights(t *testing.T) {
 		result.WinnerID, result.TurnCount, result.Error)
 }
 
+func TestRunSingleGameTypedRandomAIReplays(t *testing.T) {
+	// Random choices come from the game seed, including betting decisions
+	for _, g := range []*genome.GameGenome{genome.CreateCrazyEightsGenome(), genome.CreateSimplePokerGenome()} {
+		for seed := uint64(1); seed <= 20; seed++ {
+			a := RunSingleGameTyped(g, RandomAI, 0, seed)
+			b := RunSingleGameTyped(g, RandomAI, 0, seed)
+			if a.WinnerID != b.WinnerID || a.TurnCount != b.TurnCount || a.Metrics.TotalDecisions != b.Metrics.TotalDecisions {
+				t.Fatalf("%s seed %d: replay differs (winner %d/%d, turns %d/%d)",
+					g.Name, seed, a.WinnerID, b.WinnerID, a.TurnCount, b.TurnCount)
+			}
+		}
+	}
+}
+
 func TestRunSingleGameTypedSimplePoker(t *testing.T) {
 	g := genome.CreateSimplePokerGenome()
 
@@ -80,6 +98,235 @@ func TestRunSingleGameTypedSimplePoker(t *testing.T) {
 		result.WinnerID, result.TurnCount, result.Error)
 }
 
+// TestShowdownMuckTyped checks that every hand still in is shown at a
+// showdown unless the losers muck, when only the winner's is.
+func TestShowdownMuckTyped(t *testing.T) {
+	g := genome.CreateSimplePokerGenome()
+	g.TurnStructure.MaxTurns = 200
+	stats := RunBatchTypedPlayers(g, 3, 20, RandomAI, 0, 7)
+	if stats.ShowdownCards == 0 || stats.ShowdownExposure() != 1 {
+		t.Fatalf("Expected every showdown hand shown, got %d of %d cards", stats.ShowdownShown, stats.ShowdownCards)
+	}
+
+	g.TurnStructure.Phases[0].(*genome.BettingPhase).MuckLosers = true
+	mucked := RunBatchTypedPlayers(g, 3, 20, RandomAI, 0, 7)
+	if mucked.ShowdownShown == 0 || mucked.ShowdownExposure() >= 1 {
+		t.Errorf("Expected the losers to muck, got %d of %d cards shown", mucked.ShowdownShown, mucked.ShowdownCards)
+	}
+}
+
+// TestLastChipStandingTyped plays a three-player chip tournament: hands go
+// on after the first player busts out, until one player holds every chip.
+func TestLastChipStandingTyped(t *testing.T) {
+	g := genome.CreateSimplePokerGenome()
+	g.Setup.StartingChips = 40
+	g.TurnStructure.MaxTurns = 2000
+	g.WinConditions = []genome.WinCondition{{Type: genome.WinTypeLastChipStanding}}
+
+	result := RunSingleGameTypedPlayers(g, 3, RandomAI, 0, 3)
+	if result.Error != "" || result.WinnerID < 0 {
+		t.Fatalf("Expected a tournament winner, got %d (%s)", result.WinnerID, result.Error)
+	}
+	if got := result.FinalChips[result.WinnerID]; got != 120 {
+		t.Errorf("Expected the winner to hold all 120 chips, got %v", result.FinalChips)
+	}
+	bust := -1
+	for i, hand := range result.Hands {
+		for _, chips := range hand.Chips {
+			if chips == 0 && bust < 0 {
+				bust = i
+			}
+		}
+	}
+	if bust < 0 || bust == len(result.Hands)-1 {
+		t.Errorf("Expected play to go on after a bust-out, got first bust at hand %d of %d", bust, len(result.Hands))
+	}
+
+	// With a hand cap, the chip leader wins once it is reached (a tie for
+	// the lead plays on)
+	g.WinConditions[0].Threshold = 5
+	capped := RunSingleGameTypedPlayers(g, 3, GreedyAI, 0, 1)
+	if len(capped.Hands) < 5 || capped.WinnerID < 0 || capped.TurnCount >= 2000 {
+		t.Fatalf("Expected the chip leader to win after 5 hands, got %d after %d", capped.WinnerID, len(capped.Hands))
+	}
+	for p, chips := range capped.FinalChips {
+		if chips > capped.FinalChips[capped.WinnerID] {
+			t.Errorf("Player %d has more chips than the winner: %v", p, capped.FinalChips)
+		}
+	}
+}
+
+func TestHiddenHandSizesTyped(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+	if result := RunSingleGameTyped(g, RandomAI, 0, 5); result.Metrics.HiddenSizes != 0 {
+		t.Errorf("Expected public hand sizes by default, got %d hidden decisions", result.Metrics.HiddenSizes)
+	}
+
+	g.Setup.HiddenHandSizes = true
+	result := RunSingleGameTyped(g, RandomAI, 0, 5)
+	if result.Metrics.HiddenSizes == 0 || result.Metrics.HiddenSizes != result.Metrics.TotalDecisions {
+		t.Errorf("Expected every decision made blind to hand sizes, got %d of %d",
+			result.Metrics.HiddenSizes, result.Metrics.TotalDecisions)
+	}
+}
+
+func TestTradePhaseTyped(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+	g.TurnStructure.Phases = append([]genome.Phase{&genome.TradePhase{Target: genome.GiveTargetChosen}}, g.TurnStructure.Phases...)
+
+	for _, ai := range []AIPlayerType{RandomAI, GreedyAI} {
+		result := RunSingleGameTyped(g, ai, 0, 7)
+		if result.Error != "" {
+			t.Fatalf("AI %v: game failed: %s", ai, result.Error)
+		}
+		if result.Metrics.Trades == 0 {
+			t.Errorf("AI %v: expected some offers to be accepted", ai)
+		}
+		if result.Metrics.TotalInteractions < result.Metrics.Trades {
+			t.Errorf("AI %v: expected every trade to count as an interaction, got %d interactions for %d trades",
+				ai, result.Metrics.TotalInteractions, result.Metrics.Trades)
+		}
+	}
+}
+
+func TestFaceDownDiscardTyped(t *testing.T) {
+	g := genome.CreateGinRummyGenome()
+	faceDown := 0
+	for _, phase := range g.TurnStructure.Phases {
+		if dp, ok := phase.(*genome.DiscardPhase); ok {
+			dp.FaceDown = true
+			faceDown++
+		}
+	}
+	if faceDown == 0 {
+		t.Fatal("Expected Gin Rummy to have a discard phase")
+	}
+
+	jsonBytes, err := genome.SaveGenomeToJSON(g)
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	loaded, err := genome.LoadGenomeFromJSON(jsonBytes)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if !HasHiddenInformation(loaded) {
+		t.Error("Expected face-down discards to count as hidden information")
+	}
+
+	result := RunSingleGameTyped(loaded, RandomAI, 0, 3)
+	if result.Error != "" {
+		t.Fatalf("Game failed: %s", result.Error)
+	}
+	if result.Metrics.HiddenDiscards == 0 {
+		t.Error("Expected decisions made with face-down discards on the pile")
+	}
+
+	// Face-up discards hide nothing
+	if result := RunSingleGameTyped(genome.CreateGinRummyGenome(), RandomAI, 0, 3); result.Metrics.HiddenDiscards != 0 {
+		t.Errorf("Expected no hidden discards face-up, got %d", result.Metrics.HiddenDiscards)
+	}
+}
+
+func TestSetCleanupTyped(t *testing.T) {
+	g := &genome.GameGenome{
+		Name:  "Books",
+		Setup: genome.SetupRules{CardsPerPlayer: 3},
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{&genome.DrawPhase{Source: genome.LocationDeck, Count: 1, Mandatory: true}},
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeEmptyHand}},
+		SetCleanup:    &genome.SetCleanupRule{Size: 4, Points: 1, Captured: true},
+	}
+	if errs := genome.ValidateGenome(g); len(errs) != 0 {
+		t.Fatalf("Expected a valid genome, got %v", errs)
+	}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.NumPlayers = 2
+	for suit := uint8(0); suit < 3; suit++ {
+		state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: engine.RankSeven, Suit: suit})
+	}
+	state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: engine.RankQueen, Suit: 0})
+	state.Deck = append(state.Deck[:0], engine.Card{Rank: engine.RankSeven, Suit: 3})
+
+	// Drawing the fourth seven completes the book
+	moves := genome.GenerateLegalMovesTyped(state, g)
+	if len(moves) != 1 {
+		t.Fatalf("Expected a single draw, got %v", moves)
+	}
+	applyMoveTyped(state, &moves[0], g)
+
+	p := state.Players[0]
+	if len(p.Hand) != 1 || p.Hand[0].Rank != engine.RankQueen {
+		t.Errorf("Expected only the queen left in hand, got %v", p.Hand)
+	}
+	if len(p.Captured) != 4 || p.Score != 1 {
+		t.Errorf("Expected the book captured for a point, got %d cards and %d points", len(p.Captured), p.Score)
+	}
+}
+
+func TestFinalPlayerStateTyped(t *testing.T) {
+	poker := genome.CreateSimplePokerGenome()
+	result := RunSingleGameTyped(poker, RandomAI, 0, 11111)
+
+	if len(result.FinalChips) != 2 {
+		t.Fatalf("Expected final chips for 2 players, got %v", result.FinalChips)
+	}
+	if len(result.FinalScores) != 2 {
+		t.Errorf("Expected final scores for 2 players, got %v", result.FinalScores)
+	}
+	total := int64(0)
+	for _, c := range result.FinalChips {
+		total += c
+	}
+	if total > int64(2*poker.Setup.StartingChips) {
+		t.Errorf("Chips were created during play: %v", result.FinalChips)
+	}
+
+	// Games without chips report scores only
+	war := RunSingleGameTyped(genome.CreateWarGenome(), RandomAI, 0, 11111)
+	if war.FinalChips != nil {
+		t.Errorf("Expected nil chips for War, got %v", war.FinalChips)
+	}
+	if len(war.FinalScores) != 2 {
+		t.Errorf("Expected final scores for 2 players, got %v", war.FinalScores)
+	}
+}
+
+func TestRunSingleGameTypedShowPhase(t *testing.T) {
+	// High-card duel: reveal three cards each, best hand scores the point
+	g := &genome.GameGenome{
+		Name:  "HighCardDuel",
+		Setup: genome.SetupRules{CardsPerPlayer: 3},
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{
+				&genome.ShowPhase{Award: genome.ShowAwardPoints, Points: 1},
+			},
+			MaxTurns: 10,
+		},
+		WinConditions: []genome.WinCondition{
+			{Type: genome.WinTypeFirstToScore, Threshold: 1},
+		},
+	}
+
+	result := RunSingleGameTyped(g, RandomAI, 0, 4242)
+
+	if result.Error != "" {
+		t.Fatalf("High-card duel returned error: %s", result.Error)
+	}
+	if result.WinnerID < 0 {
+		t.Fatalf("Expected a winner, got draw after %d turns", result.TurnCount)
+	}
+	if result.FinalScores[result.WinnerID] != 1 {
+		t.Errorf("Winner should have scored the show point, got %v", result.FinalScores)
+	}
+	if result.Metrics.ShowdownWins != 1 {
+		t.Errorf("Expected 1 showdown win, got %d", result.Metrics.ShowdownWins)
+	}
+}
+
 func TestMetricsTrackedTyped(t *testing.T) {
 	g := genome.CreateWarGenome()
 
@@ -130,6 +377,316 @@ func TestCheckWinConditionsTypedEmptyHand(t *testing.T) {
 	t.Logf("EmptyHand Win: Winner=%d, Turns=%d", result.WinnerID, result.TurnCount)
 }
 
+func TestSettleHandsTypedLowScore(t *testing.T) {
+	g := &genome.GameGenome{
+		Name:          "HandPenaltyTest",
+		HandPenalty:   genome.HandPenaltySelf,
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeLowScore, Threshold: 50}},
+	}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.Players[0].Score = 29
+	state.Players[1].Score = 30
+	// Player 0 led on score but is stuck holding K, K, A
+	state.Players[0].Hand = append(state.Players[0].Hand,
+		engine.Card{Rank: engine.RankKing, Suit: 0},
+		engine.Card{Rank: engine.RankKing, Suit: 1},
+		engine.Card{Rank: engine.RankAce, Suit: 2},
+	)
+	state.Players[1].Hand = append(state.Players[1].Hand, engine.Card{Rank: engine.RankTwo, Suit: 0})
+
+	winner := settleHandsTyped(state, g, 0)
+	if state.Players[0].Score != 50 {
+		t.Errorf("Expected K, K, A to add a 21 point penalty, got score %d", state.Players[0].Score)
+	}
+	if state.Players[1].Score != 32 {
+		t.Errorf("Expected player 1 score 32, got %d", state.Players[1].Score)
+	}
+	if winner != 1 {
+		t.Errorf("Expected the penalty to hand the win to player 1, got %d", winner)
+	}
+
+	// Draws stay draws
+	if w := settleHandsTyped(state, g, -1); w != -1 {
+		t.Errorf("Expected draw to stay a draw, got %d", w)
+	}
+}
+
+func TestSettleHandsTypedWinnerCollects(t *testing.T) {
+	g := &genome.GameGenome{
+		Name:        "HandPenaltyTest",
+		HandPenalty: genome.HandPenaltyWinner,
+		CardScoring: []genome.CardScoringRule{
+			{Suit: 255, Rank: engine.RankKing, Points: 10, Trigger: genome.TriggerHandEnd},
+			{Suit: 255, Rank: engine.RankAce, Points: 20, Trigger: genome.TriggerHandEnd},
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeEmptyHand}},
+	}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.Players[1].Hand = append(state.Players[1].Hand,
+		engine.Card{Rank: engine.RankKing, Suit: 0},
+		engine.Card{Rank: engine.RankAce, Suit: 2},
+	)
+
+	if winner := settleHandsTyped(state, g, 0); winner != 0 {
+		t.Errorf("Expected winner to stay 0, got %d", winner)
+	}
+	if state.Players[0].Score != 30 {
+		t.Errorf("Expected winner to collect 30, got %d", state.Players[0].Score)
+	}
+	if state.Players[1].Score != 0 {
+		t.Errorf("Expected loser score unchanged, got %d", state.Players[1].Score)
+	}
+}
+
+func TestSettleHandsTypedExposed(t *testing.T) {
+	g := &genome.GameGenome{
+		Name: "ExposedPenaltyTest",
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{&genome.PlayPhase{Target: genome.LocationExposed, MinCards: 1, MaxCards: 1}},
+		},
+		CardScoring: []genome.CardScoringRule{
+			{Suit: genome.SuitHearts, Rank: 255, Points: 1, Trigger: genome.TriggerExposed},
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeLowScore, Threshold: 50}},
+	}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.Players[0].Score = 10
+	state.Players[1].Score = 11
+	// Player 0 led, but took two hearts face up
+	state.Players[0].Exposed = append(state.Players[0].Exposed,
+		engine.Card{Rank: engine.RankTwo, Suit: 0},
+		engine.Card{Rank: engine.RankAce, Suit: 0},
+		engine.Card{Rank: engine.RankAce, Suit: 3},
+	)
+
+	if winner := settleHandsTyped(state, g, 0); winner != 1 {
+		t.Errorf("Expected the exposed penalty to hand the win to player 1, got %d", winner)
+	}
+	if state.Players[0].Score != 12 || state.Players[1].Score != 11 {
+		t.Errorf("Expected scores 12 and 11, got %d and %d", state.Players[0].Score, state.Players[1].Score)
+	}
+}
+
+func TestSettleHandsTypedMelds(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+	g.RevealScoring = true
+	g.CardScoring = []genome.CardScoringRule{
+		{Suit: 255, Rank: 255, Points: 10, Trigger: genome.TriggerHandEnd, Meld: genome.MeldMatched},
+		{Suit: 255, Rank: 255, Points: -1, Trigger: genome.TriggerHandEnd, Meld: genome.MeldUnmatched},
+	}
+	g.WinConditions = []genome.WinCondition{{Type: genome.WinTypeHighScore, Threshold: 20}}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.Players[0].Score = 20
+	// Player 1 holds a run of three and an odd ace
+	for _, c := range []engine.Card{
+		{Rank: engine.RankFour, Suit: 1}, {Rank: engine.RankFive, Suit: 2},
+		{Rank: engine.RankSix, Suit: 1}, {Rank: engine.RankAce, Suit: 0},
+	} {
+		state.Players[1].Hand = append(state.Players[1].Hand, c)
+	}
+	state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: engine.RankTwo, Suit: 0})
+
+	if winner := settleHandsTyped(state, g, 0); winner != 1 {
+		t.Errorf("Expected the run to win for player 1, got %d", winner)
+	}
+	if state.Players[0].Score != 19 || state.Players[1].Score != 29 {
+		t.Errorf("Expected scores 19 and 29, got %d and %d", state.Players[0].Score, state.Players[1].Score)
+	}
+}
+
+func TestSettleHandsTypedReveal(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+	g.RevealScoring = true
+	g.WinConditions = []genome.WinCondition{{Type: genome.WinTypeHighScore, Threshold: 20}}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.Players[0].Score = 20
+	state.Players[1].Score = 15
+	// Player 1 trails in play but has kept a queen and a king hidden
+	state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: engine.RankTwo, Suit: 0})
+	state.Players[1].Hand = append(state.Players[1].Hand,
+		engine.Card{Rank: engine.RankQueen, Suit: 1},
+		engine.Card{Rank: engine.RankKing, Suit: 1},
+	)
+	if known, _ := state.KnownOpponentCards(0); known != 0 {
+		t.Fatalf("Expected the hands hidden before the reveal, %d cards known", known)
+	}
+
+	if winner := settleHandsTyped(state, g, 0); winner != 1 {
+		t.Errorf("Expected the revealed hand to win for player 1, got %d", winner)
+	}
+	if state.Players[0].Score != 22 || state.Players[1].Score != 35 {
+		t.Errorf("Expected scores 22 and 35 after the reveal, got %d and %d", state.Players[0].Score, state.Players[1].Score)
+	}
+	if known, total := state.KnownOpponentCards(0); known != total {
+		t.Errorf("Expected every hand known after the reveal, %d of %d", known, total)
+	}
+
+	// Play never sees a revealed hand, so none counts as known information
+	stats := RunBatchTyped(g, 20, RandomAI, 0, 1)
+	if stats.RevealedInfo() != 0 {
+		t.Errorf("Expected hands hidden throughout play, got revealed info %f", stats.RevealedInfo())
+	}
+}
+
+// TestSettleHandsTypedCaptureScoring checks a Scopa game ending with
+// the piles scored by category: the bigger pile ends the game, but the
+// coins and the settebello decide it.
+func TestSettleHandsTypedCaptureScoring(t *testing.T) {
+	g := genome.CreateScopaGenome()
+	g.CaptureScoring = []genome.CaptureCategory{
+		{Kind: genome.CaptureMostCards, Points: 1},
+		{Kind: genome.CaptureMostSuit, Suit: 1, Points: 1},
+		{Kind: genome.CaptureCard, Suit: 1, Rank: engine.RankSeven, Points: 1},
+	}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.NumPlayers = 2
+	state.Players[0].Captured = append(state.Players[0].Captured,
+		engine.Card{Rank: engine.RankTwo, Suit: 0},
+		engine.Card{Rank: engine.RankThree, Suit: 2},
+		engine.Card{Rank: engine.RankFour, Suit: 3},
+	)
+	state.Players[1].Captured = append(state.Players[1].Captured,
+		engine.Card{Rank: engine.RankSeven, Suit: 1},
+		engine.Card{Rank: engine.RankKing, Suit: 1},
+	)
+
+	winner := checkWinConditionsTyped(state, g)
+	if winner != 0 {
+		t.Fatalf("Expected the bigger pile to lead when the cards run out, got %d", winner)
+	}
+	if winner = settleHandsTyped(state, g, winner); winner != 1 {
+		t.Errorf("Expected the coins and settebello to win for player 1, got %d", winner)
+	}
+	if state.Players[0].Score != 1 || state.Players[1].Score != 2 {
+		t.Errorf("Expected scores 1 and 2, got %d and %d", state.Players[0].Score, state.Players[1].Score)
+	}
+}
+
+// TestCatchUpRaisesComebacks checks a points bonus for the player in last
+// place turns more games into comeback wins.
+func TestCatchUpRaisesComebacks(t *testing.T) {
+	g := genome.CreateHeartsGenome()
+	g.WinConditions = []genome.WinCondition{{Type: genome.WinTypeHighScore, Threshold: 100}, {Type: genome.WinTypeAllHandsEmpty}}
+	without := RunBatchTypedPlayers(g, 2, 200, RandomAI, 0, 1)
+
+	g.CatchUp = &genome.CatchUpRule{Points: 2}
+	with := RunBatchTypedPlayers(g, 2, 200, RandomAI, 0, 1)
+	if with.Errors != 0 {
+		t.Fatalf("Expected no errors, got %d", with.Errors)
+	}
+	if with.TrailingWinners <= without.TrailingWinners {
+		t.Errorf("Expected the catch-up rule to raise comeback wins, got %d with and %d without",
+			with.TrailingWinners, without.TrailingWinners)
+	}
+}
+
+func TestRunJumpInWindowTyped(t *testing.T) {
+	g := &genome.GameGenome{
+		Name: "JumpInTest",
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{
+				&genome.PlayPhase{Target: genome.LocationDiscard, MinCards: 1, MaxCards: 1, Mandatory: true},
+			},
+			JumpIn: genome.JumpInRank,
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeEmptyHand}},
+	}
+
+	// Player 0 has just played a Seven; player 1 is due next
+	state := engine.NewGameState(3)
+	defer engine.PutState(state)
+	state.Discard = append(state.Discard, engine.Card{Rank: engine.RankSeven, Suit: 0})
+	state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: engine.RankTwo, Suit: 0})
+	state.Players[1].Hand = append(state.Players[1].Hand, engine.Card{Rank: engine.RankThree, Suit: 0})
+	state.Players[2].Hand = append(state.Players[2].Hand,
+		engine.Card{Rank: engine.RankSeven, Suit: 1},
+		engine.Card{Rank: engine.RankNine, Suit: 0},
+	)
+	state.CurrentPlayer = 1
+
+	var metrics GameMetrics
+	runJumpInWindowTyped(state, g, 0, 0, []AIPlayerType{GreedyAI}, &metrics, nil)
+
+	if metrics.JumpIns != 1 {
+		t.Fatalf("Expected player 2 to jump in, got %d jump-ins", metrics.JumpIns)
+	}
+	if len(state.Players[2].Hand) != 1 {
+		t.Errorf("Expected player 2 to shed their Seven, hand is %v", state.Players[2].Hand)
+	}
+	if state.CurrentPlayer != 0 {
+		t.Errorf("Expected play to continue after the jumper (player 0), got %d", state.CurrentPlayer)
+	}
+	if metrics.TotalDecisions != 1 || metrics.TotalValidMoves != 2 {
+		t.Errorf("Expected one two-way decision, got %d decisions/%d moves", metrics.TotalDecisions, metrics.TotalValidMoves)
+	}
+	if metrics.TotalInteractions != 1 || metrics.TotalActions != 1 {
+		t.Errorf("Expected the jump-in to count as an interacting action, got %d/%d", metrics.TotalInteractions, metrics.TotalActions)
+	}
+}
+
+func TestOpensJumpInWindow(t *testing.T) {
+	g := &genome.GameGenome{
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{
+				&genome.DrawPhase{Source: genome.LocationDeck, Count: 1},
+				&genome.PlayPhase{Target: genome.LocationDiscard, MinCards: 1, MaxCards: 1},
+			},
+		},
+	}
+	play := &engine.LegalMove{PhaseIndex: 1, CardIndex: 0, TargetLoc: engine.LocationDiscard}
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+
+	if opensJumpInWindow(state, g, play) {
+		t.Error("Jump-in should be off by default")
+	}
+	g.TurnStructure.JumpIn = genome.JumpInIdentical
+	if !opensJumpInWindow(state, g, play) {
+		t.Error("Expected a play to the discard to open a jump-in window")
+	}
+	if opensJumpInWindow(state, g, &engine.LegalMove{PhaseIndex: 1, CardIndex: engine.MovePlayPass, TargetLoc: engine.LocationDiscard}) {
+		t.Error("Passing should not open a jump-in window")
+	}
+	if opensJumpInWindow(state, g, &engine.LegalMove{PhaseIndex: 0, CardIndex: engine.MoveDraw, TargetLoc: engine.LocationDeck}) {
+		t.Error("Drawing should not open a jump-in window")
+	}
+	state.RepeatPhase, state.PhaseRuns = 1, 1
+	if opensJumpInWindow(state, g, play) {
+		t.Error("A play partway through a repeat should not open a jump-in window")
+	}
+}
+
+func TestCompatGenomeTrickPhaseData(t *testing.T) {
+	g := genome.CreateHeartsGenome()
+	g.TurnStructure.Phases[0].(*genome.TrickPhase).TieRule = genome.TrickTieLastPlayed
+	g.TurnStructure.Phases[0].(*genome.TrickPhase).MustOvertrump = true
+	g.TurnStructure.Phases[0].(*genome.TrickPhase).TrumpDiscipline = genome.TrumpOnlyWhenVoid
+	g.TurnStructure.Phases[0].(*genome.TrickPhase).NextLeader = genome.TrickLeadHighCard
+
+	data := createCompatGenome(g, 2).TurnPhases[0].Data
+	want := []byte{1, 255, 1, genome.SuitHearts, engine.TrickTieLastPlayed, engine.TrickMustOvertrump | engine.TrickTrumpOnlyWhenVoid, engine.TrickLeadHighCard}
+	if len(data) != len(want) {
+		t.Fatalf("Expected %d bytes of trick data, got %v", len(want), data)
+	}
+	for i := range want {
+		if data[i] != want[i] {
+			t.Errorf("Byte %d: expected %d, got %d", i, want[i], data[i])
+		}
+	}
+}
+
 // TestEndToEndTypedPipeline validates the complete typed genome pipeline:
 // 1. Create genome from schema
 // 2. Validate with GenomeValidator
@@ -282,3 +839,1336 @@ func TestRunBatchTypedParallelSpeedup(t *testing.T) {
 		t.Logf("Warning: Parallel speedup is low (%.2fx), expected at least 1.5x on multi-core", speedup)
 	}
 }
+
+func TestRepeatPlayAsManyCardsAsYouCan(t *testing.T) {
+	handEmpty := &genome.Condition{OpCode: 0, Operator: 0, Value: 0} // check_hand_size eq 0
+	tests := []struct {
+		name      string
+		repeat    *genome.PhaseRepeat
+		wantPlays int
+	}{
+		{"until unable", &genome.PhaseRepeat{}, 3},
+		{"until hand empty", &genome.PhaseRepeat{Until: handEmpty}, 3},
+		{"fixed count", &genome.PhaseRepeat{Count: 2}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &genome.GameGenome{
+				TurnStructure: genome.TurnStructure{
+					Phases: []genome.Phase{
+						&genome.PlayPhase{Target: genome.LocationDiscard, MinCards: 1, MaxCards: 1, Mandatory: true, Repeat: tt.repeat},
+					},
+				},
+			}
+			state := engine.NewGameState(2)
+			defer engine.PutState(state)
+			state.Players[0].Hand = append(state.Players[0].Hand,
+				engine.Card{Rank: 2, Suit: 0}, engine.Card{Rank: 5, Suit: 1}, engine.Card{Rank: 9, Suit: 2})
+			state.Players[1].Hand = append(state.Players[1].Hand, engine.Card{Rank: 3, Suit: 0})
+
+			plays := 0
+			for state.CurrentPlayer == 0 {
+				moves := genome.GenerateLegalMovesTyped(state, g)
+				if len(moves) == 0 {
+					t.Fatal("Expected a move while the turn is in progress")
+				}
+				if moves[0].CardIndex >= 0 {
+					plays++
+				} else if moves[0].CardIndex != engine.MoveEndRepeat {
+					t.Fatalf("Unexpected move %+v partway through a repeat", moves[0])
+				}
+				applyMoveTyped(state, &moves[0], g)
+				if plays > 3 {
+					t.Fatal("Repeat did not end")
+				}
+			}
+
+			if plays != tt.wantPlays {
+				t.Errorf("Expected %d plays in one turn, got %d", tt.wantPlays, plays)
+			}
+			if len(state.Discard) != tt.wantPlays || len(state.Players[0].Hand) != 3-tt.wantPlays {
+				t.Errorf("Expected %d cards on the discard, got %d", tt.wantPlays, len(state.Discard))
+			}
+			if state.PhaseRuns != 0 {
+				t.Errorf("Expected the repeat to be cleared when the turn ends, got %d runs", state.PhaseRuns)
+			}
+		})
+	}
+}
+
+func TestRepeatOptionalPhaseCanStop(t *testing.T) {
+	g := &genome.GameGenome{
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{
+				&genome.DrawPhase{Source: genome.LocationDeck, Count: 1},
+				&genome.PlayPhase{Target: genome.LocationDiscard, MinCards: 1, MaxCards: 1, PassIfUnable: true, Repeat: &genome.PhaseRepeat{}},
+			},
+		},
+	}
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: 2, Suit: 0}, engine.Card{Rank: 5, Suit: 1})
+
+	applyMoveTyped(state, &engine.LegalMove{PhaseIndex: 1, CardIndex: 0, TargetLoc: engine.LocationDiscard}, g)
+	if state.CurrentPlayer != 0 || state.PhaseRuns != 1 {
+		t.Fatalf("Expected player 0 to keep the turn after one run, got player %d with %d runs", state.CurrentPlayer, state.PhaseRuns)
+	}
+
+	// Only the repeating phase continues, and the player may stop
+	moves := genome.GenerateLegalMovesTyped(state, g)
+	want := []engine.LegalMove{
+		{PhaseIndex: 1, CardIndex: 0, TargetLoc: engine.LocationDiscard},
+		{PhaseIndex: 1, CardIndex: engine.MoveEndRepeat, TargetLoc: engine.LocationDeck},
+	}
+	if len(moves) != len(want) || moves[0] != want[0] || moves[1] != want[1] {
+		t.Fatalf("Expected %v, got %v", want, moves)
+	}
+
+	applyMoveTyped(state, &moves[1], g)
+	if state.CurrentPlayer != 1 || state.PhaseRuns != 0 {
+		t.Errorf("Expected stopping to end the turn, got player %d with %d runs", state.CurrentPlayer, state.PhaseRuns)
+	}
+}
+
+func TestStartHandTypedRotatesDealerAndPostsBlinds(t *testing.T) {
+	g := genome.CreateSimplePokerGenome()
+	g.Setup.RotateDealer = true
+	g.TurnStructure.Phases[0].(*genome.BettingPhase).Blinds = 10
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.InitializeChips(100)
+	state.Dealer = 1
+
+	// Seat 0 leads; heads-up the dealer posts the small blind and opens the betting
+	startHandTyped(state, g)
+	if state.CurrentPlayer != 0 {
+		t.Errorf("Expected the seat after the dealer to lead, got %d", state.CurrentPlayer)
+	}
+	if state.BettingStartPlayer != 1 || state.Players[1].CurrentBet != 5 || state.Players[0].CurrentBet != 10 {
+		t.Errorf("Expected blinds 5 (dealer) and 10 with the dealer acting first, got %d/%d starting at %d",
+			state.Players[1].CurrentBet, state.Players[0].CurrentBet, state.BettingStartPlayer)
+	}
+
+	// Without a rotating dealer or blinds, a hand starts where play left off
+	g = genome.CreateSimplePokerGenome()
+	state.ResetHand()
+	state.CurrentPlayer = 1
+	startHandTyped(state, g)
+	if state.CurrentPlayer != 1 || state.Pot != 0 {
+		t.Error("Expected no change to the start player or pot without dealer settings")
+	}
+}
+
+func TestRunBatchTypedRotatingDealer(t *testing.T) {
+	g := genome.CreateBettingWarGenome()
+	g.Setup.RotateDealer = true
+	findBettingPhase(g).Blinds = 20
+
+	stats := RunBatchTyped(g, 50, RandomAI, 0, 7)
+	if stats.Errors != 0 {
+		t.Errorf("Expected no errors, got %d", stats.Errors)
+	}
+	rates := stats.SeatWinRates(genome.DefaultPlayerCount)
+	if len(rates) != 2 || rates[0]+rates[1] != 1 {
+		t.Fatalf("Expected win rates for both seats, got %v", rates)
+	}
+	t.Logf("Seat win rates with a rotating dealer: %v (edge %.2f)", rates, stats.SeatEdge(genome.DefaultPlayerCount))
+}
+
+// TestPassStuckTyped checks a player blocked out of a Fan Tan style
+// sequence passes, play goes on for the others, and the blocked player
+// plays again once the sequence reaches their card.
+func TestPassStuckTyped(t *testing.T) {
+	g := &genome.GameGenome{
+		Name: "StuckTest",
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{
+				&genome.PlayPhase{Target: genome.LocationTableau, MinCards: 1, MaxCards: 1, Mandatory: true},
+			},
+			TableauMode:       genome.TableauModeSequence,
+			SequenceDirection: genome.SequenceAscending,
+			StuckRule:         genome.StuckPass,
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeEmptyHand}},
+	}
+
+	state := engine.NewGameState(3)
+	defer engine.PutState(state)
+	state.NumPlayers = 3
+	state.TableauMode = 3 // SEQUENCE
+	state.SequenceDirection = 0
+	state.Tableau = [][]engine.Card{{{Rank: engine.RankFive, Suit: 3}}}
+	state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: engine.RankSeven, Suit: 3})
+	state.Players[1].Hand = append(state.Players[1].Hand, engine.Card{Rank: engine.RankKing, Suit: 0})
+	state.Players[2].Hand = append(state.Players[2].Hand, engine.Card{Rank: engine.RankSix, Suit: 3})
+
+	// Players 0 and 1 are blocked; the turn passes to player 2
+	if len(genome.GenerateLegalMovesTyped(state, g)) != 0 {
+		t.Fatal("Expected player 0 to be stuck")
+	}
+	if !passStuckTyped(state, g) || state.CurrentPlayer != 2 {
+		t.Fatalf("Expected the turn passed to player 2, got player %d", state.CurrentPlayer)
+	}
+	moves := genome.GenerateLegalMovesTyped(state, g)
+	applyMoveTyped(state, &moves[0], g)
+
+	// The six unblocks player 0
+	if state.CurrentPlayer != 0 {
+		t.Fatalf("Expected play to continue with player 0, got %d", state.CurrentPlayer)
+	}
+	if len(genome.GenerateLegalMovesTyped(state, g)) != 1 {
+		t.Error("Expected player 0 able to play the seven")
+	}
+
+	// With everyone blocked the turn stays put
+	state.Players[0].Hand[0] = engine.Card{Rank: engine.RankTwo, Suit: 1}
+	if passStuckTyped(state, g) || state.CurrentPlayer != 0 {
+		t.Errorf("Expected no pass when everyone is stuck, got player %d", state.CurrentPlayer)
+	}
+}
+
+func TestResolveMaxTurnsTyped(t *testing.T) {
+	g := genome.CreateWarGenome()
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.Players[0].Score = 12
+	state.Players[1].Score = 7
+	state.Players[0].Hand = append(state.Players[0].Hand, engine.Card{Rank: engine.RankTwo, Suit: 0})
+	state.Players[1].Hand = append(state.Players[1].Hand,
+		engine.Card{Rank: engine.RankThree, Suit: 0},
+		engine.Card{Rank: engine.RankFour, Suit: 0},
+	)
+
+	// The default is a flat draw
+	if w := resolveMaxTurnsTyped(state, g); w != -1 {
+		t.Errorf("Expected a draw by default, got %d", w)
+	}
+
+	g.TurnStructure.MaxTurnsRule = genome.MaxTurnsHighScore
+	if w := resolveMaxTurnsTyped(state, g); w != 0 {
+		t.Errorf("Expected the high scorer to win, got %d", w)
+	}
+
+	// War captures are counted in hand
+	g.TurnStructure.MaxTurnsRule = genome.MaxTurnsMostCaptured
+	if w := resolveMaxTurnsTyped(state, g); w != 1 {
+		t.Errorf("Expected the player holding more captured cards to win, got %d", w)
+	}
+
+	// Elsewhere the captured piles are counted
+	g.TurnStructure.TableauMode = genome.TableauModeNone
+	state.Players[0].Captured = append(state.Players[0].Captured, engine.Card{Rank: engine.RankTwo, Suit: 0}, engine.Card{Rank: engine.RankThree, Suit: 0})
+	if w := resolveMaxTurnsTyped(state, g); w != 0 {
+		t.Errorf("Expected the player with the bigger pile to win, got %d", w)
+	}
+
+	// A tie for the lead is a draw
+	state.Players[1].Captured = append(state.Players[1].Captured, engine.Card{Rank: engine.RankTwo, Suit: 1}, engine.Card{Rank: engine.RankThree, Suit: 1})
+	if w := resolveMaxTurnsTyped(state, g); w != -1 || state.WinningTeam != -1 {
+		t.Errorf("Expected a tie to be a draw, got winner %d team %d", w, state.WinningTeam)
+	}
+}
+
+func TestRunSingleGameTypedMaxTurnsRule(t *testing.T) {
+	g := genome.CreateWarGenome()
+	g.TurnStructure.MaxTurns = 20
+
+	if result := RunSingleGameTyped(g, RandomAI, 0, 11); result.WinnerID != -1 || result.TurnCount != 20 {
+		t.Fatalf("Expected a draw at the turn limit, got winner %d after %d turns", result.WinnerID, result.TurnCount)
+	}
+
+	// Uneven card counts after a few battles decide most games
+	g.TurnStructure.MaxTurnsRule = genome.MaxTurnsMostCaptured
+	decided := 0
+	for seed := uint64(1); seed <= 10; seed++ {
+		result := RunSingleGameTyped(g, RandomAI, 0, seed)
+		if result.TurnCount != 20 || result.Error != "" {
+			t.Fatalf("Expected the game to reach the turn limit, got %d turns (%s)", result.TurnCount, result.Error)
+		}
+		if result.WinnerID >= 0 {
+			decided++
+		}
+	}
+	if decided == 0 {
+		t.Error("Expected most-captured to decide some games at the turn limit")
+	}
+}
+
+func TestStartHandTypedOpeningLead(t *testing.T) {
+	g := genome.CreateHeartsGenome()
+	tp := findTrickPhase(g)
+
+	state := engine.NewGameState(4)
+	defer engine.PutState(state)
+	state.NumPlayers = 4
+	state.Dealer = 3
+	state.Players[2].Hand = []engine.Card{{Rank: genome.RankTwo, Suit: genome.SuitClubs}}
+
+	// Hearts: the 2 of clubs leads
+	startHandTyped(state, g)
+	if state.CurrentPlayer != 2 || state.TrickLeader != 2 {
+		t.Errorf("Expected the holder of the 2 of clubs to lead, got player %d and leader %d", state.CurrentPlayer, state.TrickLeader)
+	}
+
+	// The player after the dealer, following the deal round the table
+	tp.OpeningLead = genome.OpeningLeadDealerLeft
+	g.Setup.RotateDealer = true
+	for _, want := range []uint8{0, 1} {
+		startHandTyped(state, g)
+		if state.CurrentPlayer != want || state.TrickLeader != want {
+			t.Errorf("Expected seat %d after dealer %d to lead, got player %d and leader %d",
+				want, state.Dealer, state.CurrentPlayer, state.TrickLeader)
+		}
+		state.RotateDealer()
+	}
+
+	tp.OpeningLead = genome.OpeningLeadDealer
+	startHandTyped(state, g)
+	if state.CurrentPlayer != 1 || state.TrickLeader != 1 {
+		t.Errorf("Expected the dealer to lead, got player %d and leader %d", state.CurrentPlayer, state.TrickLeader)
+	}
+}
+
+func TestPlayPhaseRefillKeepsHandSize(t *testing.T) {
+	g := &genome.GameGenome{
+		Setup: genome.SetupRules{CardsPerPlayer: 3},
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{
+				&genome.PlayPhase{Target: genome.LocationDiscard, MinCards: 1, MaxCards: 1, Mandatory: true, RefillTo: 3},
+			},
+			MaxTurns: 200,
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeEmptyHand}},
+	}
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	dealGameTyped(state, g, 2, 42)
+
+	// Long enough to run through the deck and reshuffle the discards
+	for turn := 0; turn < 60; turn++ {
+		moves := genome.GenerateLegalMovesTyped(state, g)
+		if len(moves) == 0 {
+			t.Fatalf("Turn %d: expected a card to play", turn)
+		}
+		player := state.CurrentPlayer
+		applyMoveTyped(state, &moves[0], g)
+		if n := len(state.Players[player].Hand); n != 3 {
+			t.Fatalf("Turn %d: expected player %d to draw back to 3 cards, got %d", turn, player, n)
+		}
+	}
+	if len(state.Deck)+len(state.Discard)+6 != 52 {
+		t.Errorf("Expected every card to stay in play, got %d in the deck and %d discarded", len(state.Deck), len(state.Discard))
+	}
+}
+
+// TestPersonalDecksTyped checks hands are dealt from and refilled out of
+// each player's own deck, cycling their own discards, with the shared deck
+// untouched.
+func TestPersonalDecksTyped(t *testing.T) {
+	g := &genome.GameGenome{
+		Name:  "PersonalDeckTest",
+		Setup: genome.SetupRules{CardsPerPlayer: 3, PersonalDecks: true, PersonalDeckSize: 10},
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{
+				&genome.PlayPhase{Target: genome.LocationDiscard, MinCards: 1, MaxCards: 1, Mandatory: true, RefillTo: 3},
+			},
+			MaxTurns: 200,
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeEmptyHand}},
+	}
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	dealGameTyped(state, g, 2, 42)
+
+	// Long enough for each player to cycle their deck twice
+	for turn := 0; turn < 40; turn++ {
+		moves := genome.GenerateLegalMovesTyped(state, g)
+		if len(moves) == 0 {
+			t.Fatalf("Turn %d: expected a card to play", turn)
+		}
+		applyMoveTyped(state, &moves[0], g)
+	}
+	for p := 0; p < 2; p++ {
+		player := state.Players[p]
+		if len(player.Hand) != 3 || len(player.Deck)+len(player.Discard)+len(player.Hand) != 10 {
+			t.Errorf("Player %d: expected 10 cards of their own with 3 in hand, got deck %d, discard %d, hand %d",
+				p, len(player.Deck), len(player.Discard), len(player.Hand))
+		}
+	}
+	if len(state.Deck) != 52 || len(state.Discard) != 0 {
+		t.Errorf("Expected the shared piles untouched, got deck %d and discard %d", len(state.Deck), len(state.Discard))
+	}
+}
+
+// TestDealStrategyPinsHands pins the hands seed 42 deals under each
+// strategy, so a reference implementation can check its deck order and
+// dealing against ours.
+func TestDealStrategyPinsHands(t *testing.T) {
+	card := func(rank, suit uint8) engine.Card { return engine.Card{Rank: rank, Suit: suit} }
+	tests := []struct {
+		name     string
+		strategy genome.DealStrategy
+		order    genome.DealOrder
+		hands    [2][]engine.Card
+	}{
+		{"pre-dealt round-robin", genome.DealPreDealt, genome.DealRoundRobin, [2][]engine.Card{
+			{card(6, 3), card(12, 1), card(11, 3)},
+			{card(5, 0), card(11, 0), card(1, 1)},
+		}},
+		{"shoe draw", genome.DealShoeDraw, genome.DealRoundRobin, [2][]engine.Card{
+			{card(6, 3), card(5, 0), card(12, 1)},
+			{card(11, 0), card(11, 3), card(1, 1)},
+		}},
+		// Players drawing their own hands ignore the dealer's order
+		{"shoe draw ignores deal order", genome.DealShoeDraw, genome.DealSequential, [2][]engine.Card{
+			{card(6, 3), card(5, 0), card(12, 1)},
+			{card(11, 0), card(11, 3), card(1, 1)},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := genome.CreateWarGenome()
+			g.Setup.CardsPerPlayer = 3
+			g.Setup.DealStrategy = tt.strategy
+			g.Setup.DealOrder = tt.order
+			state := engine.GetState()
+			defer engine.PutState(state)
+
+			dealGameTyped(state, g, 2, 42)
+			for p, want := range tt.hands {
+				if got := state.Players[p].Hand; !reflect.DeepEqual(got, want) {
+					t.Errorf("Player %d: expected %v, got %v", p, want, got)
+				}
+			}
+			if len(state.Deck) != 46 {
+				t.Errorf("Expected 46 cards left in the deck, got %d", len(state.Deck))
+			}
+		})
+	}
+}
+
+func TestTempoTyped(t *testing.T) {
+	// Every War action flips a single card
+	war := RunBatchTyped(genome.CreateWarGenome(), 10, RandomAI, 0, 42)
+	if war.Tempo() != 1 {
+		t.Errorf("War: expected one card per action, got %f", war.Tempo())
+	}
+
+	// Fan Tan players often pass without a card to play
+	fanTan := RunBatchTyped(genome.CreateFanTanGenome(), 10, RandomAI, 0, 42)
+	if fanTan.Tempo() <= 0 || fanTan.Tempo() >= war.Tempo() {
+		t.Errorf("Fan Tan: expected a slower tempo than War, got %f", fanTan.Tempo())
+	}
+}
+
+func TestCardUsageTyped(t *testing.T) {
+	stats := RunBatchTyped(genome.CreateWarGenome(), 10, RandomAI, 0, 42)
+	for rank, n := range stats.CardUsage.Played.ByRank {
+		if n == 0 {
+			t.Errorf("Expected War to play every rank, rank %d never came up", rank)
+		}
+	}
+	var played, captured uint64
+	for suit := range stats.CardUsage.Played.BySuit {
+		played += stats.CardUsage.Played.BySuit[suit]
+		captured += stats.CardUsage.Captured.BySuit[suit]
+	}
+	if played == 0 || captured == 0 {
+		t.Errorf("Expected War cards to be played and captured, got %d and %d", played, captured)
+	}
+	if relevance := stats.CardRelevance(); relevance < 0.9 {
+		t.Errorf("Expected War to use the whole deck evenly, got relevance %f", relevance)
+	}
+}
+
+func TestPeekPhaseRevealsOpponentCards(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+	stats := RunBatchTyped(g, 10, RandomAI, 0, 42)
+	if stats.PeekedCards != 0 || stats.RevealedInfo() != 0 {
+		t.Fatalf("Expected hands to stay hidden without a PeekPhase, got %d peeked cards", stats.PeekedCards)
+	}
+
+	g.TurnStructure.Phases = append([]genome.Phase{&genome.PeekPhase{Target: genome.PeekTargetNext}}, g.TurnStructure.Phases...)
+	stats = RunBatchTyped(g, 10, RandomAI, 0, 42)
+	if stats.Errors != 0 {
+		t.Fatalf("Expected peeking games to finish, got %d errors", stats.Errors)
+	}
+	if revealed := stats.RevealedInfo(); revealed <= 0 || revealed > 1 {
+		t.Errorf("Expected peeks to reveal part of the opponents' cards, got %f", revealed)
+	}
+}
+
+func TestEffectCapReportsRunawayGenome(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+	play := g.TurnStructure.Phases[1].(*genome.PlayPhase)
+	play.Repeat = &genome.PhaseRepeat{} // Keep playing while anything matches
+	g.Effects = nil
+	for rank := uint8(0); rank < 13; rank++ {
+		g.Effects = append(g.Effects, genome.SpecialEffect{TriggerRank: rank, Effect: genome.EffectDrawTwo, Value: 2})
+	}
+	g.TurnStructure.MaxEffectsPerTurn = 1
+
+	stats := RunBatchTyped(g, 10, RandomAI, 0, 42)
+	if stats.Errors != 0 {
+		t.Fatalf("Expected capped games to finish, got %d errors", stats.Errors)
+	}
+	if stats.EffectsCapped == 0 {
+		t.Error("Expected chained plays to hit the effect cap")
+	}
+
+	// One card a turn never chains
+	if stats := RunBatchTyped(genome.CreateCrazyEightsGenome(), 10, RandomAI, 0, 42); stats.EffectsCapped != 0 {
+		t.Errorf("Expected no capped effects in plain Crazy Eights, got %d", stats.EffectsCapped)
+	}
+}
+
+func TestForcingEffectsTyped(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+	g.Effects = []genome.SpecialEffect{
+		{TriggerRank: genome.RankFive, Effect: genome.EffectForceDiscard, Target: 1, Value: 1}, // Previous player
+		{TriggerRank: genome.RankSix, Effect: genome.EffectForceReveal, Target: 0, Value: 2},   // Next player
+	}
+
+	state := engine.GetState()
+	defer engine.PutState(state)
+	dealGameTyped(state, g, 3, 42)
+	state.CurrentPlayer = 0
+	state.PlayDirection = 1
+	state.Players[0].Hand = []engine.Card{{Rank: genome.RankFive, Suit: genome.SuitHearts}, {Rank: genome.RankSix, Suit: genome.SuitHearts}}
+	play := engine.LegalMove{PhaseIndex: 1, CardIndex: 0, TargetLoc: engine.LocationDiscard}
+
+	// The five makes player 2 discard
+	held, discards := len(state.Players[2].Hand), len(state.Discard)
+	applyMoveTyped(state, &play, g)
+	if len(state.Players[2].Hand) != held-1 || len(state.Discard) != discards+2 {
+		t.Errorf("Expected player 2 to discard a card, got %d cards held and %d discards", len(state.Players[2].Hand), len(state.Discard)-discards)
+	}
+
+	// The six shows two of player 1's cards to everyone
+	state.CurrentPlayer = 0
+	applyMoveTyped(state, &play, g)
+	for _, viewer := range []int{0, 2} {
+		if known := state.KnownCards(viewer, 1); len(known) != 2 {
+			t.Errorf("Expected player %d to know 2 of player 1's cards, got %v", viewer, known)
+		}
+	}
+	var metrics GameMetrics
+	state.CurrentPlayer = 2
+	trackHiddenInfo(state, &metrics)
+	if metrics.PeekedCards != 2 {
+		t.Errorf("Expected the revealed cards to count as known, got %d", metrics.PeekedCards)
+	}
+}
+
+func TestRunBatchTypedPlayers(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+
+	state := engine.GetState()
+	defer engine.PutState(state)
+	dealGameTyped(state, g, 4, 42)
+	if state.NumPlayers != 4 {
+		t.Fatalf("Expected 4 players seated, got %d", state.NumPlayers)
+	}
+	for p := 0; p < 4; p++ {
+		if len(state.Players[p].Hand) != g.Setup.CardsPerPlayer {
+			t.Errorf("Player %d: expected %d cards, got %d", p, g.Setup.CardsPerPlayer, len(state.Players[p].Hand))
+		}
+	}
+
+	stats := RunBatchTypedPlayers(g, 4, 40, RandomAI, 0, 42)
+	if stats.Errors > 0 {
+		t.Fatalf("Unexpected errors at 4 players: %d", stats.Errors)
+	}
+	if stats.Wins[2]+stats.Wins[3] == 0 {
+		t.Errorf("Expected the third and fourth seats to win some games, got %v", stats.Wins)
+	}
+
+	// Out-of-range counts are clamped to a table the engine can seat
+	dealGameTyped(state, g, 9, 42)
+	if state.NumPlayers != MaxPlayers {
+		t.Errorf("Expected %d players for an oversized table, got %d", MaxPlayers, state.NumPlayers)
+	}
+}
+
+// holdemGenome is Simple Poker played Hold'em style: two private cards and
+// a flop, turn and river dealt between betting rounds.
+func holdemGenome() *genome.GameGenome {
+	g := genome.CreateSimplePokerGenome()
+	g.Name = "Holdem"
+	g.Setup.CardsPerPlayer = 2
+	g.TurnStructure.Phases = []genome.Phase{
+		&genome.BettingPhase{MinBet: 10, MaxRaises: 3, Community: []int{3, 1, 1}},
+	}
+	return g
+}
+
+func TestRunBettingHandTypedCommunity(t *testing.T) {
+	g := holdemGenome()
+	phase := g.TurnStructure.Phases[0].(*genome.BettingPhase)
+	rng := rand.New(rand.NewSource(1))
+
+	// With both players all in, the whole board is run out
+	state := bettingTestState(
+		[]engine.Card{{Rank: engine.RankAce, Suit: 0}, {Rank: engine.RankAce, Suit: 1}},
+		[]engine.Card{{Rank: engine.RankSeven, Suit: 2}, {Rank: engine.RankEight, Suit: 2}},
+	)
+	defer engine.PutState(state)
+	for i := range state.Players {
+		state.Players[i].Chips = 0
+		state.Players[i].IsAllIn = true
+	}
+	for r := uint8(0); r < 13; r++ {
+		state.Deck = append(state.Deck, engine.Card{Rank: r, Suit: 3})
+	}
+	state.Community = append(state.Community, engine.Card{Rank: engine.RankKing, Suit: 0})
+
+	var metrics GameMetrics
+	if err := runBettingHandTyped(state, g, phase, []AIPlayerType{GreedyAI}, &metrics, nil, nil, rng); err != "" {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(state.Community) != 5 {
+		t.Errorf("Expected a flop, turn and river on a fresh board, got %v", state.Community)
+	}
+
+	// Once everyone else has folded no more streets are dealt
+	engine.ClearCommunity(state)
+	state.Players[1].HasFolded = true
+	if err := runBettingHandTyped(state, g, phase, []AIPlayerType{GreedyAI}, &metrics, nil, nil, rng); err != "" {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(state.Community) != 0 {
+		t.Errorf("Expected no board after a fold, got %v", state.Community)
+	}
+}
+
+// TestRunBettingHandTypedBurn checks a card is burned before each street
+// and the setup burn comes off the deck before the deal.
+func TestRunBettingHandTypedBurn(t *testing.T) {
+	g := holdemGenome()
+	phase := g.TurnStructure.Phases[0].(*genome.BettingPhase)
+	phase.Burn = 1
+	g.Setup.BurnCards = 2
+
+	state := engine.GetState()
+	defer engine.PutState(state)
+	dealGameTyped(state, g, 2, 42)
+	if len(state.Burned) != 2 || len(state.Deck) != 52-2-4 {
+		t.Fatalf("Expected 2 burned before the deal, got %d burned and %d in the deck", len(state.Burned), len(state.Deck))
+	}
+
+	for i := range state.Players {
+		state.Players[i].Chips = 0
+		state.Players[i].IsAllIn = true
+	}
+	var metrics GameMetrics
+	rng := rand.New(rand.NewSource(1))
+	if err := runBettingHandTyped(state, g, phase, []AIPlayerType{GreedyAI}, &metrics, nil, nil, rng); err != "" {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(state.Community) != 5 || len(state.Burned) != 5 {
+		t.Errorf("Expected a 5-card board and 3 more burned, got %d and %d", len(state.Community), len(state.Burned))
+	}
+}
+
+func TestRunSingleGameTypedHoldem(t *testing.T) {
+	g := holdemGenome()
+	for seed := uint64(1); seed <= 20; seed++ {
+		result := RunSingleGameTyped(g, GreedyAI, 0, seed)
+		if result.Error != "" {
+			t.Fatalf("Seed %d: Hold'em game returned error: %s", seed, result.Error)
+		}
+		total := int64(0)
+		for _, c := range result.FinalChips {
+			total += c
+		}
+		if total > int64(2*g.Setup.StartingChips) {
+			t.Errorf("Seed %d: chips were created during play: %v", seed, result.FinalChips)
+		}
+	}
+}
+
+// TestRunSingleGameTypedHandScores checks a multi-hand game records the
+// scoreboard after every hand and scores how steadily the winner led.
+func TestRunSingleGameTypedHandScores(t *testing.T) {
+	g := genome.CreateBettingWarGenome()
+	decided := 0
+	for seed := uint64(1); seed <= 10; seed++ {
+		result := RunSingleGameTyped(g, RandomAI, 0, seed)
+		if result.WinnerID < 0 {
+			continue
+		}
+		decided++
+		if len(result.Hands) < 2 {
+			t.Fatalf("Seed %d: expected several hands, got %d", seed, len(result.Hands))
+		}
+
+		for h, hand := range result.Hands {
+			if len(hand.Scores) != 2 || hand.Leader < -1 || hand.Leader > 1 {
+				t.Errorf("Seed %d hand %d: unexpected scoreboard %+v", seed, h, hand)
+			}
+		}
+		stability := result.Metrics.HandLeadStability
+		if want := handLeadStability(result.Hands, int(result.WinnerID)); stability != want {
+			t.Errorf("Seed %d: expected stability %f, got %f", seed, want, stability)
+		}
+		if stability < 0 || stability > 1 {
+			t.Errorf("Seed %d: stability %f out of range", seed, stability)
+		}
+	}
+	if decided == 0 {
+		t.Fatal("Expected some Betting War games to be decided")
+	}
+}
+
+// TestRunSingleGameTypedMatch plays best-of-three poker matches: each hand
+// is won on its own from level stacks, and the first to win two hands takes
+// the match.
+func TestRunSingleGameTypedMatch(t *testing.T) {
+	g := genome.CreateSimplePokerGenome()
+	g.TurnStructure.MaxTurns = 500
+	g.ScoreCarry = genome.ScoreCarryPerHand
+	g.WinConditions = []genome.WinCondition{{Type: genome.WinTypeMatchWins, Threshold: 2}}
+	starting := int64(g.Setup.StartingChips)
+
+	decided := 0
+	for seed := uint64(1); seed <= 20; seed++ {
+		result := RunSingleGameTyped(g, RandomAI, 0, seed)
+		if result.Error != "" {
+			t.Fatalf("Seed %d: match returned error: %s", seed, result.Error)
+		}
+		if result.WinnerID < 0 {
+			continue
+		}
+		decided++
+
+		won := make([]int, 2)
+		for h, hand := range result.Hands {
+			if !hand.PerHand {
+				t.Fatalf("Seed %d hand %d: expected a hand scored on its own", seed, h)
+			}
+			if hand.Winner >= 0 {
+				if hand.Chips[hand.Winner] <= starting {
+					t.Errorf("Seed %d hand %d: winner %d finished on %d chips", seed, h, hand.Winner, hand.Chips[hand.Winner])
+				}
+				won[hand.Winner]++
+			}
+			if h < len(result.Hands)-1 && (won[0] >= 2 || won[1] >= 2) {
+				t.Errorf("Seed %d: match went on after hand %d with %v hands won", seed, h, won)
+			}
+		}
+		if won[result.WinnerID] != 2 || won[1-result.WinnerID] > 1 {
+			t.Errorf("Seed %d: expected the winner to take 2 hands, got %v for winner %d", seed, won, result.WinnerID)
+		}
+	}
+	if decided == 0 {
+		t.Fatal("Expected some matches to be decided")
+	}
+}
+
+func TestStandoffDetection(t *testing.T) {
+	// No one can ever play five of a kind, so every turn is a pass until
+	// the turn limit
+	stalled := &genome.GameGenome{
+		Name:  "Stalemate",
+		Setup: genome.SetupRules{CardsPerPlayer: 5},
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{
+				&genome.PlayPhase{Target: genome.LocationDiscard, MinCards: 5, MaxCards: 5, PassIfUnable: true},
+			},
+			MaxTurns: 40,
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeEmptyHand}},
+	}
+	stats := RunBatchTyped(stalled, 10, RandomAI, 0, 42)
+	if stats.Errors != 0 {
+		t.Fatalf("Stalemate games returned %d errors", stats.Errors)
+	}
+	if stats.StandoffGames != 10 || stats.PassActions != stats.TotalActions {
+		t.Errorf("Expected every game to be a standoff, got %d standoffs and %d passes in %d actions",
+			stats.StandoffGames, stats.PassActions, stats.TotalActions)
+	}
+
+	// Crazy Eights passes when stuck, but cards are played in between
+	stats = RunBatchTyped(genome.CreateCrazyEightsGenome(), 10, RandomAI, 0, 42)
+	if stats.StandoffGames != 0 {
+		t.Errorf("Expected no Crazy Eights standoffs, got %d", stats.StandoffGames)
+	}
+}
+
+// TestStartCardEffectSkip deals Uno until a Jack (a Skip) starts the
+// discard pile, and checks it skips the first player only when the
+// starting card takes effect.
+func TestStartCardEffectSkip(t *testing.T) {
+	g := genome.CreateUnoStyleGenome()
+	state := engine.NewGameState(3)
+	defer engine.PutState(state)
+
+	seed := uint64(0)
+	for s := uint64(1); s < 500 && seed == 0; s++ {
+		state.Reset()
+		dealGameTyped(state, g, 3, s)
+		if state.Discard[len(state.Discard)-1].Rank == engine.RankJack {
+			seed = s
+		}
+	}
+	if seed == 0 {
+		t.Fatal("No seed starts the discard pile with a Jack")
+	}
+	if state.CurrentPlayer != 0 {
+		t.Errorf("Expected player 0 to lead without the first-card rule, got %d", state.CurrentPlayer)
+	}
+
+	g.Setup.StartCardEffect = true
+	state.Reset()
+	dealGameTyped(state, g, 3, seed)
+	if state.CurrentPlayer != 1 {
+		t.Errorf("Expected the starting Skip to pass the lead to player 1, got %d", state.CurrentPlayer)
+	}
+	if result := RunSingleGameTypedPlayers(g, 3, RandomAI, 0, seed); result.Error != "" {
+		t.Errorf("Game with a starting Skip returned error: %s", result.Error)
+	}
+}
+
+func TestHasHiddenInformation(t *testing.T) {
+	if !HasHiddenInformation(genome.CreateWarGenome()) {
+		t.Error("Expected dealt hands to be hidden information")
+	}
+
+	g := genome.CreateWarGenome()
+	g.Setup.CardsPerPlayer = 0
+	g.TurnStructure.Phases = []genome.Phase{&genome.PlayPhase{Target: genome.LocationTableau, MinCards: 1, MaxCards: 1}}
+	if HasHiddenInformation(g) {
+		t.Error("Expected a game with no hands and no draws to be fully observable")
+	}
+	g.TurnStructure.Phases = append(g.TurnStructure.Phases, &genome.DrawPhase{Source: genome.LocationDeck, Count: 1})
+	if !HasHiddenInformation(g) {
+		t.Error("Expected drawing from the deck to be hidden information")
+	}
+}
+
+func TestRunBatchTypedDeterminized(t *testing.T) {
+	stats := RunBatchTypedDeterminized(genome.CreateGinRummyGenome(), 2, 5, MCTS100AI, 20, 4, 42)
+	if stats.Errors > 0 {
+		t.Errorf("Expected determinized MCTS games to finish cleanly, got %d errors", stats.Errors)
+	}
+	if stats.TotalGames != 5 {
+		t.Errorf("Expected 5 games, got %d", stats.TotalGames)
+	}
+}
+
+func TestRunSingleGameTypedPlacement(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+	g.PlacementPoints = []int32{3, 1}
+
+	// One round: play goes on past the first out and the top scorer wins
+	decided := 0
+	for seed := uint64(1); seed <= 10; seed++ {
+		result := RunSingleGameTypedPlayers(g, 3, RandomAI, 0, seed)
+		if result.Error != "" || result.WinnerID < 0 {
+			continue
+		}
+		decided++
+		total := int32(0)
+		for _, score := range result.FinalScores {
+			total += score
+		}
+		if total != 4 || result.FinalScores[result.WinnerID] != 3 {
+			t.Errorf("Seed %d: expected 3+1 points with the winner on 3, got %v", seed, result.FinalScores)
+		}
+	}
+	if decided == 0 {
+		t.Fatal("Expected some placement games to be decided")
+	}
+
+	// Rounds are redealt until someone reaches the target
+	g.WinConditions = append(g.WinConditions, genome.WinCondition{Type: genome.WinTypeFirstToScore, Threshold: 7})
+	g.TurnStructure.MaxTurns = 2000
+	decided = 0
+	for seed := uint64(1); seed <= 10; seed++ {
+		result := RunSingleGameTypedPlayers(g, 3, RandomAI, 0, seed)
+		if result.Error != "" || result.WinnerID < 0 {
+			continue
+		}
+		decided++
+		if len(result.Hands) < 2 {
+			t.Errorf("Seed %d: expected at least 3 rounds, got %d finished", seed, len(result.Hands))
+		}
+		if result.FinalScores[result.WinnerID] < 7 {
+			t.Errorf("Seed %d: winner finished on %d points", seed, result.FinalScores[result.WinnerID])
+		}
+	}
+	if decided == 0 {
+		t.Fatal("Expected some placement matches to be decided")
+	}
+}
+
+// TestKnockoutRoundsShrink plays out three rounds of a knockout trick game
+// by hand: each deal is a card smaller than the last, and the player taking
+// the only trick of the one-card round is left in and wins.
+func TestKnockoutRoundsShrink(t *testing.T) {
+	g := genome.CreateKnockoutWhistGenome()
+	g.Setup.CardsPerPlayer = 3
+	rng := rand.New(rand.NewSource(1))
+	state := engine.NewGameState(3)
+	defer engine.PutState(state)
+	dealGameTyped(state, g, 3, 42)
+
+	for round, size := range []int{3, 2, 1} {
+		for seat := 0; seat < 3; seat++ {
+			if got := len(state.Players[seat].Hand); got != size {
+				t.Fatalf("Round %d: expected seat %d dealt %d cards, got %d", round+1, seat, size, got)
+			}
+			// Everyone takes a trick until the last round, which seat 0 takes
+			state.Discard = append(state.Discard, state.Players[seat].Hand...)
+			state.Players[seat].Hand = state.Players[seat].Hand[:0]
+			state.Players[seat].TricksWon = 0
+			if size > 1 || seat == 0 {
+				state.Players[seat].TricksWon = 1
+			}
+		}
+		knockoutRoundTyped(state, g, rng)
+		if winner := checkWinConditionsTyped(state, g); size > 1 && winner >= 0 {
+			t.Fatalf("Round %d: expected the match to go on, got winner %d", round+1, winner)
+		}
+	}
+
+	if state.HandsPlayed != 2 {
+		t.Errorf("Expected 3 rounds dealt, got %d", state.HandsPlayed+1)
+	}
+	if state.Players[1].Active || state.Players[2].Active {
+		t.Error("Expected seats 1 and 2 knocked out of the last round")
+	}
+	if winner := checkWinConditionsTyped(state, g); winner != 0 {
+		t.Errorf("Expected seat 0 to win the match, got %d", winner)
+	}
+}
+
+func TestRunSingleGameTypedKnockoutWhist(t *testing.T) {
+	g := genome.CreateKnockoutWhistGenome()
+	multiRound := 0
+	for seed := uint64(1); seed <= 10; seed++ {
+		result := RunSingleGameTypedPlayers(g, 4, RandomAI, 0, seed)
+		if result.Error != "" || result.WinnerID < 0 {
+			t.Fatalf("Seed %d: expected a decided game, got winner %d (%s)", seed, result.WinnerID, result.Error)
+		}
+		if len(result.Hands) > 0 {
+			multiRound++
+		}
+	}
+	if multiRound == 0 {
+		t.Error("Expected some games to be dealt more than one round")
+	}
+}
+
+// TestChipBridgePointsWinPot plays a hybrid game that bets on a hand of
+// tricks: once the betting is done, the pot waits for the tricks to be
+// played and goes to whoever scores most from them.
+func TestChipBridgePointsWinPot(t *testing.T) {
+	g := &genome.GameGenome{
+		Name:  "PointsPot",
+		Setup: genome.SetupRules{CardsPerPlayer: 5, StartingChips: 100},
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{
+				&genome.BettingPhase{MinBet: 10, MaxRaises: 1},
+				&genome.TrickPhase{LeadSuitRequired: true, TrumpSuit: 255, HighCardWins: true, BreakingSuit: 255},
+			},
+			MaxTurns: 200,
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeAllHandsEmpty}},
+		CardScoring:   []genome.CardScoringRule{{Suit: 255, Rank: 255, Points: 1, Trigger: genome.TriggerTrickWin}},
+		ChipBridge:    genome.ChipBridgePointsWinPot,
+	}
+
+	contested := 0
+	for seed := uint64(1); seed <= 20; seed++ {
+		result := RunSingleGameTypedPlayers(g, 2, RandomAI, 0, seed)
+		if result.Error != "" || result.WinnerID < 0 {
+			t.Fatalf("Seed %d: expected a decided game, got winner %d (%s)", seed, result.WinnerID, result.Error)
+		}
+		if result.FinalChips[0]+result.FinalChips[1] != 200 {
+			t.Errorf("Seed %d: expected every chip back with a player, got %v", seed, result.FinalChips)
+		}
+		// Skip folds, and hands checked through with nothing in the pot
+		if result.Metrics.FoldWins > 0 || result.FinalChips[0] == result.FinalChips[1] {
+			continue
+		}
+		contested++
+		winner, loser := result.WinnerID, 1-result.WinnerID
+		if result.FinalChips[winner] <= result.FinalChips[loser] {
+			t.Errorf("Seed %d: expected the points winner to take the pot, got chips %v and scores %v", seed, result.FinalChips, result.FinalScores)
+		}
+	}
+	if contested == 0 {
+		t.Fatal("Expected some pots to be contested to the end of the hand")
+	}
+}
+
+func TestMoveCapReportsDegenerateGenome(t *testing.T) {
+	// A hundred copies of an open play phase offer thousands of moves
+	g := &genome.GameGenome{
+		Name:  "ManyPhases",
+		Setup: genome.SetupRules{CardsPerPlayer: 26},
+		TurnStructure: genome.TurnStructure{
+			MaxTurns: 100,
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeEmptyHand}},
+	}
+	for i := 0; i < 100; i++ {
+		g.TurnStructure.Phases = append(g.TurnStructure.Phases, &genome.PlayPhase{Target: genome.LocationDiscard, MinCards: 1, MaxCards: 1})
+	}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	dealGameTyped(state, g, 2, 42)
+	if moves := genome.GenerateLegalMovesTyped(state, g); len(moves) != engine.DefaultMaxLegalMoves {
+		t.Errorf("Expected the moves sampled down to %d, got %d", engine.DefaultMaxLegalMoves, len(moves))
+	}
+	g.TurnStructure.MaxLegalMoves = 40
+	moves := genome.GenerateLegalMovesTyped(state, g)
+	if len(moves) != 40 || state.MovesCapped != 2 {
+		t.Fatalf("Expected 40 moves and 2 capped decisions, got %d and %d", len(moves), state.MovesCapped)
+	}
+	if moves[0].PhaseIndex != 0 || moves[len(moves)-1].PhaseIndex < 90 {
+		t.Errorf("Expected the sample spread across the phases, got phases %d to %d", moves[0].PhaseIndex, moves[len(moves)-1].PhaseIndex)
+	}
+
+	stats := RunBatchTyped(g, 2, RandomAI, 0, 42)
+	if stats.Errors != 0 {
+		t.Fatalf("Expected capped games to finish, got %d errors", stats.Errors)
+	}
+	if stats.MovesCapped == 0 {
+		t.Error("Expected the move cap to be reported")
+	}
+
+	if stats := RunBatchTyped(genome.CreateCrazyEightsGenome(), 10, RandomAI, 0, 42); stats.MovesCapped != 0 {
+		t.Errorf("Expected no capped moves in plain Crazy Eights, got %d", stats.MovesCapped)
+	}
+}
+
+// TestDealRemainderTyped checks the cards left over after the deal end up
+// where each remainder disposition puts them.
+func TestDealRemainderTyped(t *testing.T) {
+	tests := []struct {
+		remainder              genome.Remainder
+		deck, kitty, burned    int
+		dealerHand, otherHands int
+	}{
+		{genome.RemainderDeck, 41, 0, 0, 5, 5},
+		{genome.RemainderKitty, 0, 41, 0, 5, 5},
+		{genome.RemainderBurn, 0, 0, 41, 5, 5},
+		{genome.RemainderDealer, 0, 0, 0, 46, 5},
+	}
+	for _, tt := range tests {
+		g := &genome.GameGenome{
+			Setup: genome.SetupRules{CardsPerPlayer: 5, DealToTableau: 1, Remainder: tt.remainder},
+			TurnStructure: genome.TurnStructure{
+				Phases:   []genome.Phase{&genome.PlayPhase{Target: genome.LocationDiscard, MinCards: 1, MaxCards: 1, Mandatory: true}},
+				MaxTurns: 100,
+			},
+			WinConditions: []genome.WinCondition{{Type: genome.WinTypeEmptyHand}},
+		}
+		state := engine.NewGameState(2)
+		dealGameTyped(state, g, 2, 42)
+
+		dealer := state.Dealer
+		other := 1 - dealer
+		if len(state.Deck) != tt.deck || len(state.Kitty) != tt.kitty || len(state.Burned) != tt.burned {
+			t.Errorf("Remainder %d: expected deck %d, kitty %d, burned %d, got %d, %d, %d",
+				tt.remainder, tt.deck, tt.kitty, tt.burned, len(state.Deck), len(state.Kitty), len(state.Burned))
+		}
+		if len(state.Players[dealer].Hand) != tt.dealerHand || len(state.Players[other].Hand) != tt.otherHands {
+			t.Errorf("Remainder %d: expected the dealer to hold %d and the other player %d, got %d and %d",
+				tt.remainder, tt.dealerHand, tt.otherHands, len(state.Players[dealer].Hand), len(state.Players[other].Hand))
+		}
+		if len(state.Discard) != 1 {
+			t.Errorf("Remainder %d: expected the starting discard dealt before the remainder, got %d", tt.remainder, len(state.Discard))
+		}
+		engine.PutState(state)
+	}
+}
+
+// TestFreeChoiceCheatTyped checks Cheat with free-choice claims offers every
+// rank for each card and plays out through the typed runner.
+func TestFreeChoiceCheatTyped(t *testing.T) {
+	g := genome.CreateCheatGenome()
+	g.TurnStructure.Phases[0].(*genome.ClaimPhase).RankRule = genome.ClaimRankFree
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	dealGameTyped(state, g, 2, 42)
+	moves := genome.GenerateLegalMovesTyped(state, g)
+	if want := 13 * len(state.Players[state.CurrentPlayer].Hand); len(moves) != want {
+		t.Fatalf("Expected %d free-choice claims, got %d", want, len(moves))
+	}
+	if bytecode := engine.GenerateLegalMoves(state, createCompatGenome(g, 2)); len(bytecode) != len(moves) {
+		t.Errorf("Expected the bytecode moves to match, got %d and %d", len(bytecode), len(moves))
+	}
+
+	stats := RunBatchTyped(g, 20, GreedyAI, 0, 42)
+	if stats.Errors != 0 {
+		t.Errorf("Expected free-choice Cheat to play without errors, got %d", stats.Errors)
+	}
+}
+
+// TestMisdealRedealTyped checks a deal leaving a player without a face card
+// is thrown in and dealt again, once here, and that no card is lost.
+func TestMisdealRedealTyped(t *testing.T) {
+	g := genome.CreateCrazyEightsGenome()
+	g.Setup.CardsPerPlayer = 5
+	deal := func(maxRedeals int) (*engine.GameState, int) {
+		g.Setup.Misdeal = genome.MisdealRule{Condition: genome.MisdealNoFaceCards, MaxRedeals: maxRedeals}
+		state := engine.NewGameState(2)
+		state.NumPlayers = 2
+		setupDeck(state, 1)
+		return state, dealUntilPlayableTyped(state, g, 5, misdealSeeds(1))
+	}
+
+	// Seed 1 deals a hand with no jack, queen or king
+	state, redeals := deal(0)
+	if redeals != 0 || !engine.HasMisdeal(state, engine.MisdealNoFaceCards, 0, 0) {
+		t.Fatalf("Expected seed 1 to deal a misdeal that stands without redeals, got %d redeals", redeals)
+	}
+	engine.PutState(state)
+
+	state, redeals = deal(3)
+	defer engine.PutState(state)
+	if redeals != 1 {
+		t.Errorf("Expected exactly one redeal, got %d", redeals)
+	}
+	if engine.HasMisdeal(state, engine.MisdealNoFaceCards, 0, 0) {
+		t.Errorf("Expected the redeal to give every player a face card, got %v and %v", state.Players[0].Hand, state.Players[1].Hand)
+	}
+	if total := len(state.Deck) + len(state.Discard) + len(state.Players[0].Hand) + len(state.Players[1].Hand); total != 52 {
+		t.Errorf("Expected all 52 cards after the redeal, got %d", total)
+	}
+}
+
+// TestWinByMarginTyped checks a game to 10 won by two goes on when both
+// players cross 10 in the same hand, until one leads by two.
+func TestWinByMarginTyped(t *testing.T) {
+	g := &genome.GameGenome{
+		Name:          "WinByTwoTest",
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeFirstToScore, Threshold: 10, Margin: 2}},
+	}
+	compat := createCompatGenome(g, 2)
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.NumPlayers = 2
+
+	hands := []struct {
+		scores [2]int32
+		winner int8
+	}{
+		{[2]int32{8, 9}, -1},   // Nobody has 10
+		{[2]int32{11, 11}, -1}, // Both crossed together
+		{[2]int32{12, 11}, -1}, // Ahead by one
+		{[2]int32{13, 14}, -1},
+		{[2]int32{14, 16}, 1}, // Ahead by two
+	}
+	for _, hand := range hands {
+		state.Players[0].Score, state.Players[1].Score = hand.scores[0], hand.scores[1]
+		if w := checkWinConditionsTyped(state, g); w != hand.winner {
+			t.Errorf("Scores %v: expected winner %d, got %d", hand.scores, hand.winner, w)
+		}
+		if w := engine.CheckWinConditions(state, compat); w != hand.winner {
+			t.Errorf("Scores %v: expected the engine's winner %d, got %d", hand.scores, hand.winner, w)
+		}
+	}
+}
+
+// TestFoundationPilesTyped builds a hearts and a spades foundation up from
+// the ace: each card is offered only to the pile of its suit once the card
+// below it is there.
+func TestFoundationPilesTyped(t *testing.T) {
+	g := &genome.GameGenome{
+		Name: "FoundationTest",
+		TurnStructure: genome.TurnStructure{
+			Phases: []genome.Phase{&genome.PlayPhase{Target: genome.LocationPiles, MinCards: 1, MaxCards: 1, PassIfUnable: true}},
+		},
+		WinConditions: []genome.WinCondition{{Type: genome.WinTypeEmptyHand}},
+		DiscardPiles: []genome.DiscardPile{
+			{Name: "hearts", Suit: genome.SuitHearts, StartRank: engine.RankAce, Build: genome.PileBuildUp},
+			{Name: "spades", Suit: genome.SuitSpades, StartRank: engine.RankAce, Build: genome.PileBuildUp},
+		},
+	}
+	if errs := genome.ValidateGenome(g); len(errs) != 0 {
+		t.Fatalf("Expected a valid genome, got %v", errs)
+	}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.NumPlayers = 2
+	engine.SetupPiles(state, convertDiscardPiles(g.DiscardPiles))
+	state.Players[0].Hand = append(state.Players[0].Hand,
+		engine.Card{Rank: engine.RankTwo, Suit: 3},
+		engine.Card{Rank: engine.RankAce, Suit: 0},
+		engine.Card{Rank: engine.RankThree, Suit: 0},
+		engine.Card{Rank: engine.RankAce, Suit: 3},
+		engine.Card{Rank: engine.RankTwo, Suit: 0},
+		engine.Card{Rank: engine.RankFive, Suit: 2},
+	)
+
+	// Play every card a pile takes until none fits
+	for {
+		state.CurrentPlayer = 0
+		moves := genome.GenerateLegalMovesTyped(state, g)
+		var play *engine.LegalMove
+		for i := range moves {
+			if moves[i].CardIndex < 0 {
+				continue
+			}
+			card := state.Players[0].Hand[moves[i].CardIndex]
+			want := 0
+			if card.Suit == genome.SuitSpades {
+				want = 1
+			}
+			if pile := state.PileIndex(moves[i].TargetLoc); pile != want {
+				t.Fatalf("Expected %v offered only to its suit's pile, got pile %d", card, pile)
+			}
+			if play == nil {
+				play = &moves[i]
+			}
+		}
+		if play == nil {
+			break
+		}
+		applyMoveTyped(state, play, g)
+	}
+
+	wantHearts := []engine.Card{{Rank: engine.RankAce, Suit: 0}, {Rank: engine.RankTwo, Suit: 0}, {Rank: engine.RankThree, Suit: 0}}
+	wantSpades := []engine.Card{{Rank: engine.RankAce, Suit: 3}, {Rank: engine.RankTwo, Suit: 3}}
+	if !reflect.DeepEqual(state.Piles[0], wantHearts) || !reflect.DeepEqual(state.Piles[1], wantSpades) {
+		t.Errorf("Expected foundations %v and %v, got %v and %v", wantHearts, wantSpades, state.Piles[0], state.Piles[1])
+	}
+	if len(state.Players[0].Hand) != 1 || len(state.Discard) != 0 {
+		t.Errorf("Expected only the club left in hand and nothing discarded, got %v and %v", state.Players[0].Hand, state.Discard)
+	}
+}
+
+func TestTurnLimitTyped(t *testing.T) {
+	// Spades' bids advance the turn counter but are not moves in turn
+	g := genome.CreateSpadesGenome()
+	g.TurnStructure.MaxTurns = 20
+	result := RunSingleGameTyped(g, RandomAI, 0, 1)
+	if result.Error != "" || result.TurnCount != 20 {
+		t.Fatalf("Expected the turn limit to stop the game at turn 20, got %d (%s)", result.TurnCount, result.Error)
+	}
+
+	g.TurnStructure.TurnLimit = genome.TurnLimitActions
+	result = RunSingleGameTyped(g, RandomAI, 0, 1)
+	if result.Error != "" || result.Metrics.TotalActions != 20 || result.TurnCount <= 20 {
+		t.Errorf("Expected the actions limit to stop the game after 20 plays, got %d plays in %d turns (%s)",
+			result.Metrics.TotalActions, result.TurnCount, result.Error)
+	}
+
+	// Every betting action is a turn, so a turn limit cuts a betting game
+	// short a hand or two in; a hand limit lets it play its hands out (the
+	// hand that reaches the limit ends the game before it is recorded)
+	g = genome.CreateBettingWarGenome()
+	g.TurnStructure.MaxTurns = 6
+	result = RunSingleGameTyped(g, RandomAI, 0, 1)
+	if result.TurnCount != 6 || len(result.Hands) >= 5 {
+		t.Fatalf("Expected the turn limit to stop the game at turn 6, got %d turns and %d hands", result.TurnCount, len(result.Hands))
+	}
+	g.TurnStructure.TurnLimit = genome.TurnLimitHands
+	result = RunSingleGameTyped(g, RandomAI, 0, 1)
+	if result.Error != "" || result.TurnCount <= 6 || len(result.Hands) != 5 {
+		t.Errorf("Expected the hand limit to stop the game after 6 hands, got %d turns and %d hands recorded (%s)",
+			result.TurnCount, len(result.Hands), result.Error)
+	}
+
+	state := engine.NewGameState(2)
+	defer engine.PutState(state)
+	state.HandsPlayed = 5
+	limit := genome.TurnLimitFor(g, 2)
+	if turnLimitReached(state, g, limit, 0) {
+		t.Error("Expected 5 hands to be inside a 6 hand limit")
+	}
+	state.HandsPlayed = 6
+	if !turnLimitReached(state, g, limit, 0) {
+		t.Error("Expected 6 hands to reach a 6 hand limit")
+	}
+}
+
+// TestHandLimitMCTSRollouts checks a limit in hands isn't read as a turn
+// count by MCTS, whose rollouts stop at twice the header's MaxTurns: five
+// hands would leave ten turns, too few to play out a hand, and score every
+// rollout a draw.
+func TestHandLimitMCTSRollouts(t *testing.T) {
+	g := genome.CreateKnockoutWhistGenome()
+	g.TurnStructure.TurnLimit = genome.TurnLimitHands
+	g.TurnStructure.MaxTurns = 5
+
+	compat := createCompatGenome(g, genome.DefaultPlayerCount)
+	if want := genome.DefaultMaxTurns(g, genome.DefaultPlayerCount); int(compat.Header.MaxTurns) != want {
+		t.Fatalf("Expected the default turn limit of %d for a hand limit, got %d", want, compat.Header.MaxTurns)
+	}
+
+	state := engine.GetState()
+	defer engine.PutState(state)
+	dealGameTyped(state, g, genome.DefaultPlayerCount, 1)
+	decided := false
+	for _, stats := range mcts.SearchDistribution(state, compat, 200, 0) {
+		if stats.Visits > 0 && stats.MeanValue != 0.5 {
+			decided = true
+		}
+	}
+	if !decided {
+		t.Error("Expected some rollouts to play to a winner, got nothing but draws")
+	}
+}
+
+func TestDrawPokerBettingRoundsTyped(t *testing.T) {
+	// Bet, discard, draw, bet again: one card is exchanged between the rounds
+	g := genome.CreateDrawPokerGenome()
+	g.TurnStructure.Phases[2].(*genome.DrawPhase).Count = 1
+	g.TurnStructure.Phases = append(g.TurnStructure.Phases, &genome.BettingPhase{MinBet: 20, MaxRaises: 3})
+
+	state := engine.GetState()
+	defer engine.PutState(state)
+	state.NumPlayers = 2
+	state.BettingStartPlayer = 1
+	state.BettingComplete = true
+	var betting bettingLifecycle
+	betting.startCardPhases(state, 0, 3)
+	if state.CurrentPlayer != 1 || betting.round != 1 {
+		t.Fatalf("Expected the card phases to start from the betting leader, got player %d", state.CurrentPlayer)
+	}
+	for _, want := range []int{1, 1, 2, 2} {
+		if got := betting.cardPhase(2); got != want {
+			t.Errorf("Expected phase %d to be played, got %d", want, got)
+		}
+		if !state.BettingComplete {
+			t.Fatal("Expected betting to stay closed during the card phases")
+		}
+		betting.endCardTurn(state)
+	}
+	if state.BettingComplete {
+		t.Error("Expected the second betting round to open after the draw")
+	}
+
+	played := uint64(0)
+	for seed := uint64(1); seed <= 5; seed++ {
+		result := RunSingleGameTyped(g, RandomAI, 0, seed)
+		if result.Error != "" {
+			t.Fatalf("Seed %d: %s", seed, result.Error)
+		}
+		if result.Metrics.CardsMoved == 0 || result.Metrics.ShowdownWins+result.Metrics.FoldWins == 0 {
+			t.Errorf("Seed %d: expected cards drawn between rounds and hands settled, got %d cards moved and %d showdowns",
+				seed, result.Metrics.CardsMoved, result.Metrics.ShowdownWins)
+		}
+		played += result.Metrics.ShowdownWins
+	}
+	if played == 0 {
+		t.Error("Expected hands redrawn to five cards to reach a showdown")
+	}
+
+	// With a single betting round the hand is shown down straight away
+	result := RunSingleGameTyped(genome.CreateDrawPokerGenome(), RandomAI, 0, 1)
+	if result.Metrics.CardsMoved != 0 {
+		t.Errorf("Expected no draw with one betting round, got %d cards moved", result.Metrics.CardsMoved)
+	}
+}
@@ -0,0 +1,292 @@
+// Package solver exhaustively searches tiny games to find their exact
+// game-theoretic value, a ground truth for the sampled skill measures.
+package solver
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/signalnine/darwindeck/gosim/engine"
+)
+
+// DefaultMaxStates bounds a search when the caller passes no limit.
+const DefaultMaxStates = 1_000_000
+
+var (
+	// ErrTooLarge is returned when a game has more reachable states than the
+	// search was allowed to visit.
+	ErrTooLarge = errors.New("game has too many states to solve exhaustively")
+	// ErrNotTwoPlayer is returned for games that are not heads-up; with more
+	// players a game is no longer zero-sum and has no single value.
+	ErrNotTwoPlayer = errors.New("only two-player games can be solved")
+)
+
+// Result is the value of a position with best play from both sides.
+type Result struct {
+	Winner int8 // Seat that wins with best play, or -1 for a draw
+	States int  // Distinct positions searched
+}
+
+// Solve searches every line of play from state and returns its value. The
+// game is the engine's: moves come from GenerateLegalMoves, play stops at the
+// first winner from CheckWinConditions, and a position with no legal moves,
+// a repeated position, or the genome's turn limit is a draw. Cards are dealt
+// from the deck in its current order, so this solves one deal with all hands
+// known. Moves are tried in engine.OrderMoves order, so a winning move is
+// usually found, and its siblings skipped, early. The search gives up with
+// ErrTooLarge once it has seen more than maxStates positions (0 =
+// DefaultMaxStates).
+func Solve(state *engine.GameState, genome *engine.Genome, maxStates int) (Result, error) {
+	if state.NumPlayers != 2 {
+		return Result{}, ErrNotTwoPlayer
+	}
+	s := newSearch(genome, maxStates)
+
+	root := state.Clone()
+	defer engine.PutState(root)
+	winner, err := s.solve(root)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Winner: winner, States: len(s.values)}, nil
+}
+
+// CountStates returns how many distinct positions can be reached from
+// state, following every legal move to the end of the game. It returns
+// ErrTooLarge once more than maxStates positions are found (0 =
+// DefaultMaxStates), so it doubles as a check that a game is small enough
+// for Solve.
+func CountStates(state *engine.GameState, genome *engine.Genome, maxStates int) (int, error) {
+	s := newSearch(genome, maxStates)
+
+	root := state.Clone()
+	defer engine.PutState(root)
+	if err := s.visit(root); err != nil {
+		return 0, err
+	}
+	return len(s.values), nil
+}
+
+// search holds the memo shared by one Solve or CountStates call.
+type search struct {
+	genome    *engine.Genome
+	maxStates int
+	maxTurns  uint32
+	values    map[string]int8 // Solved value of each position seen
+	onPath    map[string]bool // Positions on the line being searched
+	unordered bool            // Try moves in generated order (for comparison)
+}
+
+func newSearch(genome *engine.Genome, maxStates int) *search {
+	if maxStates <= 0 {
+		maxStates = DefaultMaxStates
+	}
+	// A genome without a turn limit gets the bytecode runner's default,
+	// which counts on the whole deck
+	var maxTurns uint32
+	if genome.Header != nil {
+		maxTurns = genome.Header.MaxTurns
+	}
+	if maxTurns == 0 {
+		maxTurns = engine.DefaultMaxTurns(52, 2, genome.WinConditions)
+	}
+	return &search{
+		genome:    genome,
+		maxStates: maxStates,
+		maxTurns:  maxTurns,
+		values:    make(map[string]int8),
+		onPath:    make(map[string]bool),
+	}
+}
+
+// solve returns the winner of state with best play. The player to move
+// prefers a win to a draw and a draw to a loss, and stops looking once a
+// move wins, which is the only cutoff a win/draw/loss game allows without
+// storing bounds in place of exact values.
+func (s *search) solve(state *engine.GameState) (int8, error) {
+	key := stateKey(state)
+	if winner, ok := s.values[key]; ok {
+		return winner, nil
+	}
+	if s.onPath[key] {
+		return -1, nil // Play has gone round in a circle
+	}
+	if len(s.values) >= s.maxStates {
+		return 0, ErrTooLarge
+	}
+
+	winner, moves := s.terminal(state)
+	if moves != nil {
+		if !s.unordered {
+			engine.OrderMoves(state, moves)
+		}
+		s.onPath[key] = true
+		mover := int8(state.CurrentPlayer)
+		winner = 1 - mover // Until a move does better
+		for i := range moves {
+			child := state.Clone()
+			engine.ApplyMove(child, &moves[i], s.genome)
+			result, err := s.solve(child)
+			engine.PutState(child)
+			if err != nil {
+				return 0, err
+			}
+			if result == mover {
+				winner = mover
+				break
+			}
+			if result == -1 {
+				winner = -1
+			}
+		}
+		delete(s.onPath, key)
+	}
+
+	s.values[key] = winner
+	return winner, nil
+}
+
+// visit records every position reachable from state.
+func (s *search) visit(state *engine.GameState) error {
+	key := stateKey(state)
+	if _, ok := s.values[key]; ok {
+		return nil
+	}
+	if len(s.values) >= s.maxStates {
+		return ErrTooLarge
+	}
+	s.values[key] = 0
+
+	_, moves := s.terminal(state)
+	for i := range moves {
+		child := state.Clone()
+		engine.ApplyMove(child, &moves[i], s.genome)
+		err := s.visit(child)
+		engine.PutState(child)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// terminal returns the winner of a finished game (-1 for a draw) with nil
+// moves, or the legal moves of a game still in progress.
+func (s *search) terminal(state *engine.GameState) (int8, []engine.LegalMove) {
+	if state.TurnNumber >= s.maxTurns {
+		return -1, nil
+	}
+	// CheckWinConditions records the winner, so check a copy
+	check := state.Clone()
+	winner := engine.CheckWinConditions(check, s.genome)
+	engine.PutState(check)
+	if winner >= 0 {
+		return winner, nil
+	}
+	moves := engine.GenerateLegalMoves(state, s.genome)
+	if len(moves) == 0 {
+		return -1, nil
+	}
+	return -1, moves
+}
+
+// stateKey encodes everything about a position that can change what
+// happens next. Statistics the engine only records, such as betting counts,
+// are left out so equal positions share a key.
+func stateKey(state *engine.GameState) string {
+	b := make([]byte, 0, 128)
+	b = append(b, state.NumPlayers, state.CurrentPlayer, state.TrickLeader, byte(state.PlayDirection), state.SkipCount)
+	b = binary.AppendUvarint(b, uint64(state.TurnNumber))
+	b = appendFlags(b, state.HeartsBroken, state.BettingComplete, state.ShowComplete, state.BiddingComplete)
+	b = binary.AppendVarint(b, state.Pot)
+	b = binary.AppendVarint(b, state.CurrentBet)
+	b = binary.AppendVarint(b, int64(state.RaiseCount))
+	b = binary.AppendVarint(b, state.LastRaise)
+	b = binary.AppendVarint(b, int64(state.BettingStartPlayer))
+	b = binary.AppendVarint(b, int64(state.ConsecutivePasses))
+	b = binary.AppendVarint(b, int64(state.RepeatPhase))
+	b = binary.AppendVarint(b, int64(state.PhaseRuns))
+	b = appendFlags(b, state.DiscardPending)
+	b = binary.AppendVarint(b, int64(state.DiscardPhase))
+	b = append(b, state.EffectsPlayer)
+	b = binary.AppendVarint(b, int64(state.EffectsThisTurn))
+	b = append(b, state.DrawsPlayer)
+	b = binary.AppendVarint(b, int64(state.DrawsTurnCount))
+
+	for i := 0; i < int(state.NumPlayers) && i < len(state.Players); i++ {
+		p := &state.Players[i]
+		b = appendCards(b, p.Hand)
+		b = appendCards(b, p.Captured)
+		b = appendCards(b, p.Deck)
+		b = appendCards(b, p.Discard)
+		b = appendCards(b, p.Exposed)
+		b = binary.AppendVarint(b, int64(p.Score))
+		b = binary.AppendVarint(b, int64(p.Draws))
+		b = binary.AppendVarint(b, p.Chips)
+		b = binary.AppendVarint(b, p.CurrentBet)
+		b = append(b, byte(p.CurrentBid), byte(p.TricksWon))
+		b = appendFlags(b, p.Active, p.HasFolded, p.IsAllIn, p.IsNilBid, p.RaiseClosed)
+	}
+	b = appendFlags(b, state.PersonalDecks)
+	b = appendCards(b, state.Deck)
+	b = appendCards(b, state.Discard)
+	b = binary.AppendUvarint(b, uint64(len(state.Tableau)))
+	for _, pile := range state.Tableau {
+		b = appendCards(b, pile)
+	}
+	b = binary.AppendUvarint(b, uint64(len(state.Piles)))
+	for _, pile := range state.Piles {
+		b = appendCards(b, pile)
+	}
+	b = appendCards(b, state.Community)
+
+	b = binary.AppendUvarint(b, uint64(len(state.CurrentTrick)))
+	for _, tc := range state.CurrentTrick {
+		b = append(b, tc.PlayerID, tc.Card.Rank, tc.Card.Suit)
+	}
+	b = append(b, byte(len(state.TricksWon)))
+	b = append(b, state.TricksWon...)
+	b = append(b, byte(len(state.FinishOrder)))
+	b = append(b, state.FinishOrder...)
+	if c := state.CurrentClaim; c != nil {
+		b = append(b, 1, c.ClaimerID, c.ClaimedRank, c.ClaimedCount, c.ChallengerID)
+		b = appendFlags(b, c.Challenged)
+		b = appendCards(b, c.CardsPlayed)
+	} else {
+		b = append(b, 0)
+	}
+	b = append(b, byte(state.LastClaimRank))
+	b = appendFlags(b, state.HasStood...)
+	for _, score := range state.TeamScores {
+		b = binary.AppendVarint(b, int64(score))
+	}
+	for _, contract := range state.TeamContracts {
+		b = append(b, byte(contract))
+	}
+	for _, bags := range state.AccumulatedBags {
+		b = append(b, byte(bags))
+	}
+	b = append(b, state.Trump)
+	b = appendFlags(b, state.TrumpNamed)
+	return string(b)
+}
+
+func appendCards(b []byte, cards []engine.Card) []byte {
+	b = binary.AppendUvarint(b, uint64(len(cards)))
+	for _, c := range cards {
+		b = append(b, c.Rank, c.Suit)
+	}
+	return b
+}
+
+func appendFlags(b []byte, flags ...bool) []byte {
+	b = append(b, byte(len(flags)))
+	for _, f := range flags {
+		if f {
+			b = append(b, 1)
+		} else {
+			b = append(b, 0)
+		}
+	}
+	return b
+}
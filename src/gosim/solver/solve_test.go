@@ -0,0 +1,193 @@
+package solver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/signalnine/darwindeck/gosim/engine"
+)
+
+// firstTrickGame is a two-trick game won by whoever takes the first trick.
+// Seat 0 leads and wins only by leading the ace of spades.
+func firstTrickGame() (*engine.GameState, *engine.Genome) {
+	state := engine.NewGameState(2)
+	state.Players[0].Hand = []engine.Card{{Rank: engine.RankTwo, Suit: 0}, {Rank: engine.RankAce, Suit: 3}}
+	state.Players[1].Hand = []engine.Card{{Rank: engine.RankThree, Suit: 0}, {Rank: engine.RankKing, Suit: 3}}
+
+	genome := &engine.Genome{
+		Header: &engine.BytecodeHeader{MaxTurns: 100},
+		TurnPhases: []engine.PhaseDescriptor{
+			{PhaseType: engine.PhaseTypeTrick, Data: []byte{1, 255, 1, 255}}, // Follow suit, no trump, high wins
+		},
+		WinConditions: []engine.WinCondition{{WinType: 1, Threshold: 2}}, // First to 2 points
+		CardScoring: []engine.CardScoringRule{
+			{Suit: 255, Rank: 255, Points: 1, Trigger: engine.TriggerTrickWin},
+		},
+	}
+	return state, genome
+}
+
+func TestSolveFindsWinningLine(t *testing.T) {
+	state, genome := firstTrickGame()
+	defer engine.PutState(state)
+
+	result, err := Solve(state, genome, 0)
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if result.Winner != 0 {
+		t.Errorf("Expected seat 0 to win by leading the ace, got %d", result.Winner)
+	}
+	if len(state.Players[0].Hand) != 2 || state.TurnNumber != 0 {
+		t.Error("Solve changed the position it was given")
+	}
+
+	// With the king promoted to an ace, seat 1 takes the first trick either way
+	state.Players[1].Hand[1].Rank = engine.RankAce
+	genome.TurnPhases[0].Data = append(genome.TurnPhases[0].Data, engine.TrickTieLastPlayed)
+	result, err = Solve(state, genome, 0)
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if result.Winner != 1 {
+		t.Errorf("Expected seat 1 to win, got %d", result.Winner)
+	}
+}
+
+func TestSolveDrawWithoutWinner(t *testing.T) {
+	state, genome := firstTrickGame()
+	defer engine.PutState(state)
+	genome.WinConditions[0].Threshold = 10 // Out of reach
+
+	result, err := Solve(state, genome, 0)
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if result.Winner != -1 {
+		t.Errorf("Expected a draw when nobody can win, got %d", result.Winner)
+	}
+}
+
+func TestCountStates(t *testing.T) {
+	state, genome := firstTrickGame()
+	defer engine.PutState(state)
+
+	// The start, and a lead and a finished trick for each of seat 0's cards
+	n, err := CountStates(state, genome, 0)
+	if err != nil {
+		t.Fatalf("CountStates failed: %v", err)
+	}
+	if n != 5 {
+		t.Errorf("Expected 5 reachable states, got %d", n)
+	}
+}
+
+func TestSolveRefusesLargeGames(t *testing.T) {
+	state, genome := firstTrickGame()
+	defer engine.PutState(state)
+
+	if _, err := CountStates(state, genome, 2); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge from CountStates, got %v", err)
+	}
+	// Leading the ace wins at once, so Solve needs a game seat 0 loses
+	// to search past two positions
+	state.Players[1].Hand[1].Rank = engine.RankAce
+	genome.TurnPhases[0].Data = append(genome.TurnPhases[0].Data, engine.TrickTieLastPlayed)
+	if _, err := Solve(state, genome, 2); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge from Solve, got %v", err)
+	}
+
+	state.NumPlayers = 3
+	if _, err := Solve(state, genome, 0); !errors.Is(err, ErrNotTwoPlayer) {
+		t.Errorf("Expected ErrNotTwoPlayer, got %v", err)
+	}
+}
+
+// TestSolveOrderingPrunes checks trying the high cards first finds the
+// winning ace lead before the losing two, searching fewer positions for the
+// same value.
+func TestSolveOrderingPrunes(t *testing.T) {
+	state, genome := firstTrickGame()
+	defer engine.PutState(state)
+
+	ordered := newSearch(genome, 0)
+	unordered := newSearch(genome, 0)
+	unordered.unordered = true
+	for _, s := range []*search{ordered, unordered} {
+		root := state.Clone()
+		winner, err := s.solve(root)
+		engine.PutState(root)
+		if err != nil || winner != 0 {
+			t.Fatalf("Expected seat 0 to win, got %d (%v)", winner, err)
+		}
+	}
+	if len(ordered.values) >= len(unordered.values) {
+		t.Errorf("Expected ordering to search fewer positions, got %d against %d", len(ordered.values), len(unordered.values))
+	}
+}
+
+// TestStateKeyDistinguishesPositions checks state that can change the
+// legal moves or the winner is part of a position's key.
+func TestStateKeyDistinguishesPositions(t *testing.T) {
+	changes := []struct {
+		name   string
+		change func(*engine.GameState)
+	}{
+		{"finish order", func(s *engine.GameState) { s.FinishOrder = append(s.FinishOrder, 1) }},
+		{"captured cards", func(s *engine.GameState) { s.Players[0].Captured = append(s.Players[0].Captured, engine.Card{Rank: 5}) }},
+		{"personal deck", func(s *engine.GameState) { s.Players[0].Deck = append(s.Players[0].Deck, engine.Card{Rank: 5}) }},
+		{"personal discard", func(s *engine.GameState) { s.Players[1].Discard = append(s.Players[1].Discard, engine.Card{Rank: 5}) }},
+		{"personal decks setting", func(s *engine.GameState) { s.PersonalDecks = true }},
+		{"last claimed rank", func(s *engine.GameState) { s.LastClaimRank = 7 }},
+		{"exposed cards", func(s *engine.GameState) { s.Players[1].Exposed = append(s.Players[1].Exposed, engine.Card{Rank: 5}) }},
+		{"discard piles", func(s *engine.GameState) { s.Piles = append(s.Piles, []engine.Card{{Rank: 5}}) }},
+		{"last raise", func(s *engine.GameState) { s.LastRaise = 20 }},
+		{"closed raising", func(s *engine.GameState) { s.Players[0].RaiseClosed = true }},
+	}
+	for _, c := range changes {
+		state := engine.NewGameState(2)
+		before := stateKey(state)
+		c.change(state)
+		if stateKey(state) == before {
+			t.Errorf("Expected the %s to change the position's key", c.name)
+		}
+		engine.PutState(state)
+	}
+}
+
+// BenchmarkSolveOrdering compares the positions searched, and the time
+// taken, solving a five-card trick game with and without move ordering.
+func BenchmarkSolveOrdering(b *testing.B) {
+	state, genome := firstTrickGame()
+	defer engine.PutState(state)
+	genome.WinConditions[0].Threshold = 3
+	state.Players[0].Hand = []engine.Card{
+		{Rank: engine.RankTwo, Suit: 0}, {Rank: engine.RankSix, Suit: 1}, {Rank: engine.RankNine, Suit: 2},
+		{Rank: engine.RankJack, Suit: 0}, {Rank: engine.RankAce, Suit: 1},
+	}
+	state.Players[1].Hand = []engine.Card{
+		{Rank: engine.RankThree, Suit: 1}, {Rank: engine.RankFive, Suit: 0}, {Rank: engine.RankEight, Suit: 2},
+		{Rank: engine.RankQueen, Suit: 1}, {Rank: engine.RankKing, Suit: 0},
+	}
+
+	for _, unordered := range []bool{false, true} {
+		name := "ordered"
+		if unordered {
+			name = "unordered"
+		}
+		b.Run(name, func(b *testing.B) {
+			states := 0
+			for i := 0; i < b.N; i++ {
+				s := newSearch(genome, 0)
+				s.unordered = unordered
+				root := state.Clone()
+				if _, err := s.solve(root); err != nil {
+					b.Fatalf("Solve failed: %v", err)
+				}
+				engine.PutState(root)
+				states = len(s.values)
+			}
+			b.ReportMetric(float64(states), "states/op")
+		})
+	}
+}